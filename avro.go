@@ -0,0 +1,100 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/duh-rpc/openapi-schema.go/internal/avro"
+	"github.com/duh-rpc/openapi-schema.go/internal/parser"
+)
+
+// AvroOptions configures ConvertToAvro.
+type AvroOptions struct {
+	// Namespace is the Avro namespace of the generated types, e.g.
+	// "com.example.events". Empty → no namespace.
+	Namespace string
+	// SchemaNames limits output to the named component schemas and the schemas
+	// they reference, directly or transitively. Empty → every schema. Naming a
+	// schema that does not exist is an error.
+	SchemaNames []string
+}
+
+// AvroResult holds the output of ConvertToAvro.
+type AvroResult struct {
+	// Schemas maps component schema names to their Avro schema (.avsc)
+	Schemas map[string][]byte
+	// Names lists the keys of Schemas in document order.
+	Names []string
+}
+
+// ConvertToAvro generates an Avro schema (.avsc) for each component schema that
+// is an object with properties, a string enum or a oneOf or anyOf union, for
+// Kafka consumers of the same contracts. Objects become records, enums Avro
+// enums and unions Avro unions, whose branches are told apart by type, so any
+// discriminator is dropped. Each .avsc is self-contained: the records and enums
+// it uses are defined where first used.
+//
+// Properties missing from required, and nullable ones, are unions with null
+// defaulting to null. Inline objects and enums are named after the enclosing
+// record and the property, as Go names their structs. Integers are int, or
+// long for int64; numbers double, or float for float; byte and binary strings
+// bytes; and date-time, date and uuid strings use the timestamp-millis, date
+// and uuid logical types. Arrays and maps (additionalProperties) become Avro
+// arrays and maps. allOf, free-form objects and schemas of several types have
+// no Avro equivalent and are rejected.
+func ConvertToAvro(openapi []byte, opts AvroOptions) (*AvroResult, error) {
+	return ConvertToAvroContext(context.Background(), openapi, opts)
+}
+
+// ConvertToAvroContext is like ConvertToAvro but stops early with an error
+// wrapping ctx.Err() when ctx is cancelled or its deadline expires.
+// Cancellation is checked before parsing and between schemas.
+func ConvertToAvroContext(ctx context.Context, openapi []byte, opts AvroOptions) (_ *AvroResult, err error) {
+	defer internal.Recover("ConvertToAvro", &err)
+
+	if len(openapi) == 0 {
+		return nil, fmt.Errorf("openapi input cannot be empty")
+	}
+	if opts.Namespace != "" && !avro.IsNamespace(opts.Namespace) {
+		return nil, fmt.Errorf("invalid Namespace %q: must be dot-separated names of letters, digits and underscores", opts.Namespace)
+	}
+
+	if err := internal.Cancelled(ctx); err != nil {
+		return nil, err
+	}
+
+	doc, err := parser.ParseDocument(openapi)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas, err := doc.Schemas()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(opts.SchemaNames) > 0 {
+		schemas, err = parser.SelectSchemas(schemas, opts.SchemaNames)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	avroCtx := avro.NewContext()
+	avroCtx.Ctx = ctx
+	avroCtx.Namespace = opts.Namespace
+	if err := avro.BuildFiles(schemas, avroCtx); err != nil {
+		return nil, err
+	}
+
+	result := &AvroResult{
+		Schemas: make(map[string][]byte, len(avroCtx.Files)),
+		Names:   make([]string, 0, len(avroCtx.Files)),
+	}
+	for _, file := range avroCtx.Files {
+		result.Schemas[file.Name] = file.JSON
+		result.Names = append(result.Names, file.Name)
+	}
+	return result, nil
+}
@@ -0,0 +1,100 @@
+package schema_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/require"
+)
+
+// benchCorpus lists the specs in testdata/bench. Regenerate the generated ones
+// with `go run gen.go` from that directory.
+var benchCorpus = []string{"small", "medium", "huge", "deep", "wide"}
+
+func loadBenchSpec(tb testing.TB, name string) []byte {
+	tb.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "bench", name+".yaml"))
+	require.NoError(tb, err)
+	return data
+}
+
+// TestBenchCorpus keeps the benchmark corpus convertible so a broken spec fails
+// CI instead of silently benchmarking an early error return.
+func TestBenchCorpus(t *testing.T) {
+	for _, name := range benchCorpus {
+		t.Run(name, func(t *testing.T) {
+			spec := loadBenchSpec(t, name)
+
+			result, err := schema.Convert(spec, schema.ConvertOptions{
+				PackageName: "bench",
+				PackagePath: "github.com/example/bench/v1",
+			})
+			require.NoError(t, err)
+			require.NotEmpty(t, result.Protobuf)
+
+			_, err = schema.ConvertToStruct(spec, schema.ConvertOptions{
+				GoPackagePath: "github.com/example/bench",
+			})
+			require.NoError(t, err)
+
+			examples, err := schema.ConvertToExamples(spec, schema.ExampleOptions{IncludeAll: true, Seed: 1})
+			require.NoError(t, err)
+			require.NotEmpty(t, examples.Examples)
+		})
+	}
+}
+
+func BenchmarkConvert(b *testing.B) {
+	for _, name := range benchCorpus {
+		spec := loadBenchSpec(b, name)
+		b.Run(name, func(b *testing.B) {
+			b.SetBytes(int64(len(spec)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, err := schema.Convert(spec, schema.ConvertOptions{
+					PackageName: "bench",
+					PackagePath: "github.com/example/bench/v1",
+				})
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkConvertToStruct(b *testing.B) {
+	for _, name := range benchCorpus {
+		spec := loadBenchSpec(b, name)
+		b.Run(name, func(b *testing.B) {
+			b.SetBytes(int64(len(spec)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, err := schema.ConvertToStruct(spec, schema.ConvertOptions{
+					GoPackagePath: "github.com/example/bench",
+				})
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkConvertToExamples(b *testing.B) {
+	for _, name := range benchCorpus {
+		spec := loadBenchSpec(b, name)
+		b.Run(name, func(b *testing.B) {
+			b.SetBytes(int64(len(spec)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, err := schema.ConvertToExamples(spec, schema.ExampleOptions{IncludeAll: true, Seed: 1})
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,368 @@
+package schema
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"go.yaml.in/yaml/v4"
+)
+
+// ConvertChanges compares a ConvertResult with ConvertOptions.Previous.
+type ConvertChanges struct {
+	// Reused is true when every schema hash matched Previous, so its outputs
+	// were returned without converting. Otherwise every schema was converted
+	// again, whichever changed.
+	Reused bool
+	// Schemas lists the schemas whose hash differs from Previous: those edited,
+	// added, or referencing an edited schema directly or transitively, in
+	// document order, followed by those removed, sorted. It reports what
+	// changed, not what was converted. A change to the options or to the spec
+	// outside components/schemas changes every hash.
+	Schemas []string
+	// Outputs lists the outputs that differ from Previous, of "protobuf",
+	// "golang" and "handlers" in that order, so unchanged files need not be
+	// rewritten.
+	Outputs []string
+}
+
+// manifestSkippedOptions do not affect the output, so changing them keeps the
+// hashes of a manifest
+var manifestSkippedOptions = map[string]bool{
+	"Previous":          true,
+	"BuildManifest":     true,
+	"Logger":            true,
+	"Concurrency":       true,
+	"VerifyDeterminism": true,
+}
+
+// maxFingerprintDepth bounds how deeply option values are followed
+const maxFingerprintDepth = 32
+
+// convertManifest returns the content hash of each component schema of
+// openapi, with the schema names in document order, and whether the hashes
+// capture every option: options holding functions cannot be fingerprinted, so
+// their results must not be reused. The hash of a schema covers its own
+// definition, those of the schemas it reaches through $ref, the options, and
+// the spec outside components/schemas. Nil when openapi is not YAML or JSON.
+func convertManifest(openapi []byte, opts ConvertOptions) (map[string]string, []string, bool) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(openapi, &root); err != nil {
+		return nil, nil, false
+	}
+
+	common := sha256.New()
+	cacheable := fingerprintOptions(common, opts)
+	schemaNodes := hashSpec(common, &root)
+	prefix := common.Sum(nil)
+
+	names := make([]string, 0, len(schemaNodes))
+	own := make(map[string][]byte, len(schemaNodes))
+	refs := make(map[string][]string, len(schemaNodes))
+	for i := 0; i+1 < len(schemaNodes); i += 2 {
+		name := schemaNodes[i].Value
+		h := sha256.New()
+		hashNode(h, schemaNodes[i+1], map[*yaml.Node]bool{})
+		names = append(names, name)
+		own[name] = h.Sum(nil)
+		refs[name] = schemaRefs(schemaNodes[i+1], nil, map[*yaml.Node]bool{})
+	}
+
+	manifest := make(map[string]string, len(names))
+	for _, name := range names {
+		reached := reachable(name, refs)
+		h := sha256.New()
+		h.Write(prefix)
+		for _, r := range reached {
+			if sum, ok := own[r]; ok {
+				fmt.Fprintf(h, "%s=%x;", r, sum)
+			}
+		}
+		manifest[name] = hex.EncodeToString(h.Sum(nil))
+	}
+	return manifest, names, cacheable
+}
+
+// hashSpec hashes the spec outside components/schemas into h, returning the
+// name and schema nodes of components/schemas in pairs
+func hashSpec(h hash.Hash, root *yaml.Node) []*yaml.Node {
+	doc := root
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		doc = doc.Content[0]
+	}
+	if doc.Kind != yaml.MappingNode {
+		hashNode(h, root, map[*yaml.Node]bool{})
+		return nil
+	}
+
+	var schemas []*yaml.Node
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		key, value := doc.Content[i], doc.Content[i+1]
+		hashNode(h, key, map[*yaml.Node]bool{})
+		if key.Value != "components" || value.Kind != yaml.MappingNode {
+			hashNode(h, value, map[*yaml.Node]bool{})
+			continue
+		}
+		for j := 0; j+1 < len(value.Content); j += 2 {
+			if value.Content[j].Value == "schemas" && value.Content[j+1].Kind == yaml.MappingNode {
+				schemas = value.Content[j+1].Content
+				continue
+			}
+			hashNode(h, value.Content[j], map[*yaml.Node]bool{})
+			hashNode(h, value.Content[j+1], map[*yaml.Node]bool{})
+		}
+	}
+	return schemas
+}
+
+// hashNode writes node and its children into h. Aliases are followed, except
+// back into a node being hashed.
+func hashNode(h hash.Hash, node *yaml.Node, stack map[*yaml.Node]bool) {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		if stack[node.Alias] {
+			fmt.Fprintf(h, "*%q;", node.Value)
+			return
+		}
+		node = node.Alias
+	}
+	stack[node] = true
+	defer delete(stack, node)
+
+	fmt.Fprintf(h, "%d%q%q%d[", node.Kind, node.Tag, node.Value, len(node.Content))
+	for _, child := range node.Content {
+		hashNode(h, child, stack)
+	}
+	h.Write([]byte("]"))
+}
+
+// schemaRefs appends the component schemas node references to refs
+func schemaRefs(node *yaml.Node, refs []string, stack map[*yaml.Node]bool) []string {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	if stack[node] {
+		return refs
+	}
+	stack[node] = true
+	defer delete(stack, node)
+
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value != "$ref" {
+				continue
+			}
+			ref := node.Content[i+1].Value
+			if !strings.HasPrefix(ref, "#/components/schemas/") {
+				continue
+			}
+			if name, err := internal.ExtractReferenceName(ref); err == nil {
+				refs = append(refs, name)
+			}
+		}
+	}
+	for _, child := range node.Content {
+		refs = schemaRefs(child, refs, stack)
+	}
+	return refs
+}
+
+// reachable returns name and the schemas it references, directly or
+// transitively, sorted
+func reachable(name string, refs map[string][]string) []string {
+	seen := map[string]bool{name: true}
+	queue := []string{name}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		for _, ref := range refs[next] {
+			if !seen[ref] {
+				seen[ref] = true
+				queue = append(queue, ref)
+			}
+		}
+	}
+	result := make([]string, 0, len(seen))
+	for r := range seen {
+		result = append(result, r)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// fingerprintOptions writes the options affecting output into h, reporting
+// false when one holds a function, whose behavior cannot be fingerprinted
+func fingerprintOptions(h hash.Hash, opts ConvertOptions) bool {
+	v := reflect.ValueOf(opts)
+	ok := true
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if manifestSkippedOptions[field.Name] {
+			continue
+		}
+		fmt.Fprintf(h, "%s:", field.Name)
+		ok = fingerprint(h, v.Field(i), 0) && ok
+	}
+	return ok
+}
+
+// fingerprint writes v into h, with map entries in key order, reporting false
+// for non-nil functions and channels and values nested too deeply
+func fingerprint(h hash.Hash, v reflect.Value, depth int) bool {
+	if depth > maxFingerprintDepth {
+		return false
+	}
+	switch v.Kind() {
+	case reflect.Invalid:
+		h.Write([]byte("nil;"))
+	case reflect.Bool:
+		fmt.Fprintf(h, "%t;", v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		fmt.Fprintf(h, "%d;", v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		fmt.Fprintf(h, "%d;", v.Uint())
+	case reflect.Float32, reflect.Float64:
+		fmt.Fprintf(h, "%g;", v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		fmt.Fprintf(h, "%g;", v.Complex())
+	case reflect.String:
+		fmt.Fprintf(h, "%q;", v.String())
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			h.Write([]byte("nil;"))
+			return true
+		}
+		fmt.Fprintf(h, "%s(", v.Elem().Type())
+		ok := fingerprint(h, v.Elem(), depth+1)
+		h.Write([]byte(")"))
+		return ok
+	case reflect.Slice, reflect.Array:
+		fmt.Fprintf(h, "%d[", v.Len())
+		ok := true
+		for i := 0; i < v.Len(); i++ {
+			ok = fingerprint(h, v.Index(i), depth+1) && ok
+		}
+		h.Write([]byte("]"))
+		return ok
+	case reflect.Map:
+		type entry struct {
+			key   []byte
+			value reflect.Value
+		}
+		entries := make([]entry, 0, v.Len())
+		ok := true
+		for iter := v.MapRange(); iter.Next(); {
+			key := sha256.New()
+			ok = fingerprint(key, iter.Key(), depth+1) && ok
+			entries = append(entries, entry{key: key.Sum(nil), value: iter.Value()})
+		}
+		sort.Slice(entries, func(i, j int) bool { return bytes.Compare(entries[i].key, entries[j].key) < 0 })
+		fmt.Fprintf(h, "%d{", len(entries))
+		for _, e := range entries {
+			h.Write(e.key)
+			ok = fingerprint(h, e.value, depth+1) && ok
+		}
+		h.Write([]byte("}"))
+		return ok
+	case reflect.Struct:
+		fmt.Fprintf(h, "%s{", v.Type())
+		ok := true
+		for i := 0; i < v.NumField(); i++ {
+			fmt.Fprintf(h, "%s:", v.Type().Field(i).Name)
+			ok = fingerprint(h, v.Field(i), depth+1) && ok
+		}
+		h.Write([]byte("}"))
+		return ok
+	default:
+		// Functions, channels and unsafe pointers
+		if v.IsNil() {
+			h.Write([]byte("nil;"))
+			return true
+		}
+		return false
+	}
+	return true
+}
+
+// cachedResult returns a copy of previous for a conversion whose manifest is
+// unchanged; nil when it cannot be reused. The cache holds whole results: one
+// changed hash converts every schema again, as names, field numbers and the
+// split between proto and Go depend on the schemas together. The copy shares
+// nothing mutable with previous, so either can be changed by the caller.
+func cachedResult(previous *ConvertResult, manifest map[string]string, cacheable bool) *ConvertResult {
+	if previous == nil || !cacheable || len(previous.Manifest) == 0 || !maps.Equal(previous.Manifest, manifest) {
+		return nil
+	}
+	return &ConvertResult{
+		Protobuf:     bytes.Clone(previous.Protobuf),
+		Golang:       bytes.Clone(previous.Golang),
+		TypeMap:      cloneTypeMap(previous.TypeMap),
+		Deprecations: slices.Clone(previous.Deprecations),
+		Warnings:     slices.Clone(previous.Warnings),
+		Descriptor:   previous.Descriptor,
+		Handlers:     bytes.Clone(previous.Handlers),
+		Renames:      slices.Clone(previous.Renames),
+		Manifest:     manifest,
+		Changes:      &ConvertChanges{Reused: true, Schemas: []string{}, Outputs: []string{}},
+	}
+}
+
+// cloneTypeMap returns a deep copy of typeMap
+func cloneTypeMap(typeMap map[string]*TypeInfo) map[string]*TypeInfo {
+	if typeMap == nil {
+		return nil
+	}
+	clone := make(map[string]*TypeInfo, len(typeMap))
+	for name, info := range typeMap {
+		if info == nil {
+			clone[name] = nil
+			continue
+		}
+		cp := *info
+		cp.Dependencies = slices.Clone(info.Dependencies)
+		cp.Notes = slices.Clone(info.Notes)
+		cp.ReadOnly = slices.Clone(info.ReadOnly)
+		cp.WriteOnly = slices.Clone(info.WriteOnly)
+		clone[name] = &cp
+	}
+	return clone
+}
+
+// compareResults lists what changed in result since previous
+func compareResults(previous, result *ConvertResult, names []string) *ConvertChanges {
+	changes := &ConvertChanges{Schemas: []string{}, Outputs: []string{}}
+	for _, name := range names {
+		if previous.Manifest[name] != result.Manifest[name] {
+			changes.Schemas = append(changes.Schemas, name)
+		}
+	}
+	var removed []string
+	for name := range previous.Manifest {
+		if _, ok := result.Manifest[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(removed)
+	changes.Schemas = append(changes.Schemas, removed...)
+
+	for _, output := range []struct {
+		name     string
+		old, new []byte
+	}{
+		{"protobuf", previous.Protobuf, result.Protobuf},
+		{"golang", previous.Golang, result.Golang},
+		{"handlers", previous.Handlers, result.Handlers},
+	} {
+		if !bytes.Equal(output.old, output.new) {
+			changes.Outputs = append(changes.Outputs, output.name)
+		}
+	}
+	return changes
+}
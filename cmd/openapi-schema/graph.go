@@ -0,0 +1,41 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+)
+
+// runGraph prints the schema dependency graph of a spec as Graphviz DOT, or as
+// JSON with -json
+func runGraph(args []string) error {
+	flags := flag.NewFlagSet("graph", flag.ContinueOnError)
+	asJSON := flags.Bool("json", false, "print the graph as JSON instead of DOT")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("graph expects exactly one spec file")
+	}
+
+	openapi, err := os.ReadFile(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	analysis, err := schema.AnalyzeDependencies(openapi)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(analysis)
+	}
+	fmt.Print(analysis.DOT())
+	return nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+)
+
+// runLint checks a spec for proto compatibility, printing each issue and failing
+// when Convert would fail (or, with -strict, on any warning)
+func runLint(args []string) error {
+	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	asJSON := flags.Bool("json", false, "print the report as JSON")
+	strict := flags.Bool("strict", false, "fail on warnings as well as errors")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("lint expects exactly one spec file")
+	}
+
+	f, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	report, err := schema.LintReader(f, schema.LintOptions{})
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		for _, issue := range report.Issues {
+			fmt.Printf("%s: %s: %s\n", issue.Severity, issue.Pointer, issue.Message)
+		}
+	}
+
+	if report.HasErrors() || (*strict && len(report.Issues) > 0) {
+		return fmt.Errorf("%s: %d issue(s) found", flags.Arg(0), len(report.Issues))
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+// Command openapi-schema is a command line front end for the openapi-schema library.
+//
+// Usage:
+//
+//	openapi-schema playground [-addr localhost:8080]
+//	openapi-schema lint [-json] [-strict] spec.yaml
+//	openapi-schema graph [-json] spec.yaml
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+const usage = `Usage: openapi-schema <command> [flags]
+
+Commands:
+  playground   Serve a local web UI for converting specs interactively
+  lint         Check a spec for proto compatibility without generating output
+  graph        Print the schema dependency graph as Graphviz DOT
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "playground":
+		err = runPlayground(os.Args[2:])
+	case "lint":
+		err = runLint(os.Args[2:])
+	case "graph":
+		err = runGraph(os.Args[2:])
+	case "-h", "-help", "--help", "help":
+		fmt.Fprint(os.Stdout, usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "openapi-schema: %v\n", err)
+		os.Exit(1)
+	}
+}
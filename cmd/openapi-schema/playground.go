@@ -0,0 +1,110 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"time"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+)
+
+//go:embed assets
+var assets embed.FS
+
+// maxSpecSize bounds the request body accepted by the playground API
+const maxSpecSize = 10 << 20
+
+// playgroundRequest is the body accepted by POST /api/convert
+type playgroundRequest struct {
+	Spec        string `json:"spec"`
+	PackageName string `json:"packageName"`
+	PackagePath string `json:"packagePath"`
+	Seed        int64  `json:"seed"`
+}
+
+// playgroundResponse carries every output for a spec; each section reports its own
+// error so a failure in one does not hide the others
+type playgroundResponse struct {
+	Protobuf      string                      `json:"protobuf"`
+	Golang        string                      `json:"golang"`
+	TypeMap       map[string]*schema.TypeInfo `json:"typeMap"`
+	Warnings      []schema.Warning            `json:"warnings"`
+	Examples      map[string]json.RawMessage  `json:"examples"`
+	ConvertError  string                      `json:"convertError,omitempty"`
+	ExamplesError string                      `json:"examplesError,omitempty"`
+}
+
+func runPlayground(args []string) error {
+	flags := flag.NewFlagSet("playground", flag.ContinueOnError)
+	addr := flags.String("addr", "localhost:8080", "address to listen on")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	static, err := fs.Sub(assets, "assets")
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /", http.FileServerFS(static))
+	mux.HandleFunc("POST /api/convert", handleConvert)
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	log.Printf("playground listening on http://%s", *addr)
+	return server.ListenAndServe()
+}
+
+func handleConvert(w http.ResponseWriter, r *http.Request) {
+	var req playgroundRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSpecSize)).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	if req.PackageName == "" {
+		req.PackageName = "api"
+	}
+	if req.PackagePath == "" {
+		req.PackagePath = "github.com/example/api/v1"
+	}
+
+	var resp playgroundResponse
+	result, err := schema.ConvertContext(r.Context(), []byte(req.Spec), schema.ConvertOptions{
+		PackageName: req.PackageName,
+		PackagePath: req.PackagePath,
+	})
+	if err != nil {
+		resp.ConvertError = err.Error()
+	} else {
+		resp.Protobuf = string(result.Protobuf)
+		resp.Golang = string(result.Golang)
+		resp.TypeMap = result.TypeMap
+		resp.Warnings = result.Warnings
+	}
+
+	examples, err := schema.ConvertToExamplesContext(r.Context(), []byte(req.Spec), schema.ExampleOptions{
+		IncludeAll: true,
+		Seed:       req.Seed,
+	})
+	if err != nil {
+		resp.ExamplesError = err.Error()
+	} else {
+		resp.Examples = examples.Examples
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("failed to write response: %v", err)
+	}
+}
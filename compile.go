@@ -0,0 +1,321 @@
+package schema
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"go.yaml.in/yaml/v4"
+)
+
+// defaultProtoFileName names the proto output where a file name is needed
+const defaultProtoFileName = "schema.proto"
+
+// CompileTool selects the compiler CompileProto runs.
+type CompileTool string
+
+const (
+	// CompileToolProtoc runs protoc with one --<plugin>_out flag per plugin (the default).
+	CompileToolProtoc CompileTool = "protoc"
+	// CompileToolBuf runs buf generate with a buf.gen.yaml listing the plugins.
+	CompileToolBuf CompileTool = "buf"
+)
+
+func (t CompileTool) validate() error {
+	switch t {
+	case "", CompileToolProtoc, CompileToolBuf:
+		return nil
+	}
+	return fmt.Errorf("unknown CompileTool %q: must be protoc or buf", string(t))
+}
+
+// CompilePlugin is a protoc plugin run by CompileProto.
+type CompilePlugin struct {
+	// Name is the plugin name without its protoc-gen- prefix: "go" runs
+	// protoc-gen-go, which must be on PATH.
+	Name string
+	// Options are passed to the plugin, e.g. "paths=source_relative".
+	Options []string
+}
+
+// CompileOptions configures CompileProto.
+type CompileOptions struct {
+	// Tool is the compiler to run. Empty → CompileToolProtoc.
+	Tool CompileTool
+	// ToolPath is the compiler executable. Empty → Tool looked up on PATH.
+	ToolPath string
+	// FileName names the proto file written to the workspace, and so the file
+	// diagnostics refer to. Empty → "schema.proto".
+	FileName string
+	// Plugins are run in order; at least one is required.
+	Plugins []CompilePlugin
+	// IncludePaths are extra protoc import directories, for imports such as
+	// google/type/decimal.proto. Buf resolves imports from its own module
+	// configuration, so they are rejected with CompileToolBuf.
+	IncludePaths []string
+}
+
+// CompileResult holds the files generated by CompileProto.
+type CompileResult struct {
+	// Files maps each generated file's slash-separated path, relative to the
+	// output directory, to its content.
+	Files map[string][]byte
+}
+
+// CompileDiagnostic is a single problem the compiler reported against a file.
+type CompileDiagnostic struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Message string `json:"message"`
+}
+
+// CompileError is returned by CompileProto when the compiler exits with an
+// error. Diagnostics holds the file:line:column messages it reported; Output is
+// everything it wrote to stderr, including lines that are not diagnostics.
+type CompileError struct {
+	Tool        CompileTool
+	Diagnostics []CompileDiagnostic
+	Output      string
+	Err         error
+}
+
+func (e *CompileError) Error() string {
+	if len(e.Diagnostics) == 0 {
+		if output := strings.TrimSpace(e.Output); output != "" {
+			return fmt.Sprintf("%s failed: %v: %s", e.Tool, e.Err, output)
+		}
+		return fmt.Sprintf("%s failed: %v", e.Tool, e.Err)
+	}
+	lines := make([]string, len(e.Diagnostics))
+	for i, d := range e.Diagnostics {
+		lines[i] = fmt.Sprintf("%s:%d:%d: %s", d.File, d.Line, d.Column, d.Message)
+	}
+	return fmt.Sprintf("%s failed:\n%s", e.Tool, strings.Join(lines, "\n"))
+}
+
+func (e *CompileError) Unwrap() error { return e.Err }
+
+// CompileProto writes result's proto output to a temporary workspace, runs
+// protoc or buf generate with the configured plugins, and returns the files
+// they generate. The workspace is removed before returning.
+//
+// Returns a *CompileError when the compiler fails, and a plain error for
+// invalid options, a result without proto output, or a compiler that cannot
+// be found or started.
+func CompileProto(result *ConvertResult, opts CompileOptions) (*CompileResult, error) {
+	return CompileProtoContext(context.Background(), result, opts)
+}
+
+// CompileProtoContext is like CompileProto but kills the compiler and returns
+// ctx.Err() when ctx is cancelled or its deadline expires.
+func CompileProtoContext(ctx context.Context, result *ConvertResult, opts CompileOptions) (_ *CompileResult, err error) {
+	defer internal.Recover("CompileProto", &err)
+
+	if result == nil || len(result.Protobuf) == 0 {
+		return nil, fmt.Errorf("result has no proto output to compile")
+	}
+
+	if err := opts.Tool.validate(); err != nil {
+		return nil, err
+	}
+	if opts.Tool == "" {
+		opts.Tool = CompileToolProtoc
+	}
+
+	if opts.FileName == "" {
+		opts.FileName = defaultProtoFileName
+	}
+	if filepath.Base(opts.FileName) != opts.FileName || !strings.HasSuffix(opts.FileName, ".proto") {
+		return nil, fmt.Errorf("file name '%s' must be a base name ending in .proto", opts.FileName)
+	}
+
+	if len(opts.Plugins) == 0 {
+		return nil, fmt.Errorf("at least one plugin is required")
+	}
+	for _, plugin := range opts.Plugins {
+		if !pluginName.MatchString(plugin.Name) || strings.HasPrefix(plugin.Name, "protoc-gen-") {
+			return nil, fmt.Errorf("invalid plugin name '%s': use the name without its protoc-gen- prefix, e.g. go", plugin.Name)
+		}
+	}
+
+	if opts.Tool == CompileToolBuf && len(opts.IncludePaths) > 0 {
+		return nil, fmt.Errorf("IncludePaths is not supported with buf; declare dependencies in buf.yaml instead")
+	}
+
+	// The compiler runs in the workspace, so relative paths must be resolved here
+	includes := make([]string, len(opts.IncludePaths))
+	for i, include := range opts.IncludePaths {
+		abs, err := filepath.Abs(include)
+		if err != nil {
+			return nil, fmt.Errorf("include path '%s': %w", include, err)
+		}
+		includes[i] = abs
+	}
+	opts.IncludePaths = includes
+
+	toolPath := opts.ToolPath
+	if toolPath == "" {
+		var err error
+		if toolPath, err = exec.LookPath(string(opts.Tool)); err != nil {
+			return nil, fmt.Errorf("%s not found: %w", opts.Tool, err)
+		}
+	}
+
+	workspace, err := os.MkdirTemp("", "openapi-schema-compile-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(workspace)
+
+	srcDir := filepath.Join(workspace, "proto")
+	outDir := filepath.Join(workspace, "out")
+	for _, dir := range []string{srcDir, outDir} {
+		if err := os.Mkdir(dir, 0o755); err != nil {
+			return nil, err
+		}
+	}
+	if err := os.WriteFile(filepath.Join(srcDir, opts.FileName), result.Protobuf, 0o644); err != nil {
+		return nil, err
+	}
+
+	var args []string
+	switch opts.Tool {
+	case CompileToolProtoc:
+		args = protocArgs(opts, outDir)
+	case CompileToolBuf:
+		if args, err = writeBufConfig(srcDir, outDir, opts.Plugins); err != nil {
+			return nil, err
+		}
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, toolPath, args...)
+	cmd.Dir = srcDir
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("failed to run %s: %w", opts.Tool, err)
+		}
+		return nil, &CompileError{
+			Tool:        opts.Tool,
+			Diagnostics: parseDiagnostics(stderr.String()),
+			Output:      stderr.String(),
+			Err:         err,
+		}
+	}
+
+	files := make(map[string][]byte)
+	err = filepath.WalkDir(outDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		rel, err := filepath.Rel(outDir, path)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = content
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read generated files: %w", err)
+	}
+
+	return &CompileResult{Files: files}, nil
+}
+
+// pluginName matches plugin names as protoc accepts them in --<name>_out
+var pluginName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// protocArgs returns the protoc arguments compiling opts.FileName, run from the
+// directory holding it, into outDir
+func protocArgs(opts CompileOptions, outDir string) []string {
+	args := []string{"-I", "."}
+	for _, include := range opts.IncludePaths {
+		args = append(args, "-I", include)
+	}
+	for _, plugin := range opts.Plugins {
+		args = append(args, fmt.Sprintf("--%s_out=%s", plugin.Name, outDir))
+		if len(plugin.Options) > 0 {
+			args = append(args, fmt.Sprintf("--%s_opt=%s", plugin.Name, strings.Join(plugin.Options, ",")))
+		}
+	}
+	return append(args, opts.FileName)
+}
+
+// bufGenConfig is the buf.gen.yaml (version v2) written for buf generate
+type bufGenConfig struct {
+	Version string         `yaml:"version"`
+	Plugins []bufGenPlugin `yaml:"plugins"`
+}
+
+type bufGenPlugin struct {
+	Local string   `yaml:"local"`
+	Out   string   `yaml:"out"`
+	Opt   []string `yaml:"opt,omitempty"`
+}
+
+// writeBufConfig writes the buf.yaml and buf.gen.yaml generating plugins into
+// outDir, and returns the buf arguments that use them
+func writeBufConfig(srcDir, outDir string, plugins []CompilePlugin) ([]string, error) {
+	config := bufGenConfig{Version: "v2"}
+	for _, plugin := range plugins {
+		config.Plugins = append(config.Plugins, bufGenPlugin{
+			Local: "protoc-gen-" + plugin.Name,
+			Out:   outDir,
+			Opt:   plugin.Options,
+		})
+	}
+	gen, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.WriteFile(filepath.Join(srcDir, "buf.yaml"), []byte("version: v2\n"), 0o644); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(srcDir, "buf.gen.yaml"), gen, 0o644); err != nil {
+		return nil, err
+	}
+	return []string{"generate", "--template", "buf.gen.yaml"}, nil
+}
+
+// diagnosticLine matches the file:line:column: message lines protoc and buf print
+var diagnosticLine = regexp.MustCompile(`^(.+?\.proto):(\d+):(\d+):\s*(.*)$`)
+
+// parseDiagnostics extracts the file:line:column diagnostics from compiler output
+func parseDiagnostics(output string) []CompileDiagnostic {
+	var diagnostics []CompileDiagnostic
+	for _, line := range strings.Split(output, "\n") {
+		m := diagnosticLine.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		lineNum, _ := strconv.Atoi(m[2])
+		column, _ := strconv.Atoi(m[3])
+		diagnostics = append(diagnostics, CompileDiagnostic{
+			File:    m[1],
+			Line:    lineNum,
+			Column:  column,
+			Message: m[4],
+		})
+	}
+	return diagnostics
+}
@@ -0,0 +1,167 @@
+package schema_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCompiler writes a shell script standing in for protoc or buf
+func fakeCompiler(t *testing.T, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake compiler is a shell script")
+	}
+	path := filepath.Join(t.TempDir(), "compiler")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o755))
+	return path
+}
+
+func compileInput(t *testing.T) *schema.ConvertResult {
+	t.Helper()
+	result, err := schema.Convert([]byte(`openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        name:
+          type: string
+`), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+	})
+	require.NoError(t, err)
+	return result
+}
+
+func TestCompileProtoProtoc(t *testing.T) {
+	// Records its arguments and the proto it was given into the --go_out directory
+	protoc := fakeCompiler(t, `for arg in "$@"; do
+  case "$arg" in --go_out=*) out="${arg#--go_out=}" ;; esac
+done
+mkdir -p "$out/v1"
+echo "$@" > "$out/args.txt"
+cp api.proto "$out/v1/api.pb.go"
+`)
+
+	input := compileInput(t)
+	result, err := schema.CompileProto(input, schema.CompileOptions{
+		ToolPath:     protoc,
+		FileName:     "api.proto",
+		IncludePaths: []string{"/usr/include"},
+		Plugins: []schema.CompilePlugin{
+			{Name: "go", Options: []string{"paths=source_relative", "M=x"}},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, input.Protobuf, result.Files["v1/api.pb.go"])
+	args := string(result.Files["args.txt"])
+	assert.Contains(t, args, "-I . -I /usr/include --go_out=")
+	assert.Contains(t, args, "--go_opt=paths=source_relative,M=x api.proto")
+}
+
+func TestCompileProtoBuf(t *testing.T) {
+	buf := fakeCompiler(t, `echo "$@" > args.txt
+out=$(sed -n 's/^ *out: *//p' buf.gen.yaml)
+cp args.txt buf.gen.yaml "$out/"
+`)
+
+	result, err := schema.CompileProto(compileInput(t), schema.CompileOptions{
+		Tool:     schema.CompileToolBuf,
+		ToolPath: buf,
+		Plugins:  []schema.CompilePlugin{{Name: "go", Options: []string{"paths=source_relative"}}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "generate --template buf.gen.yaml\n", string(result.Files["args.txt"]))
+	gen := string(result.Files["buf.gen.yaml"])
+	assert.Contains(t, gen, "version: v2")
+	assert.Contains(t, gen, "local: protoc-gen-go")
+	assert.Contains(t, gen, "- paths=source_relative")
+}
+
+func TestCompileProtoDiagnostics(t *testing.T) {
+	protoc := fakeCompiler(t, `echo 'schema.proto:3:9: "Foo" is not defined.' >&2
+echo 'schema.proto:7:1: Expected ";".' >&2
+echo 'protoc-gen-go: program not found or is not executable' >&2
+exit 1
+`)
+
+	_, err := schema.CompileProto(compileInput(t), schema.CompileOptions{
+		ToolPath: protoc,
+		Plugins:  []schema.CompilePlugin{{Name: "go"}},
+	})
+	var compileErr *schema.CompileError
+	require.True(t, errors.As(err, &compileErr))
+	assert.Equal(t, schema.CompileToolProtoc, compileErr.Tool)
+	assert.Equal(t, []schema.CompileDiagnostic{
+		{File: "schema.proto", Line: 3, Column: 9, Message: `"Foo" is not defined.`},
+		{File: "schema.proto", Line: 7, Column: 1, Message: `Expected ";".`},
+	}, compileErr.Diagnostics)
+	assert.Contains(t, compileErr.Output, "protoc-gen-go: program not found")
+	assert.Contains(t, err.Error(), `schema.proto:3:9: "Foo" is not defined.`)
+}
+
+func TestCompileProtoInvalidOptions(t *testing.T) {
+	input := compileInput(t)
+	for _, test := range []struct {
+		name    string
+		result  *schema.ConvertResult
+		opts    schema.CompileOptions
+		wantErr string
+	}{
+		{
+			name:    "no proto output",
+			result:  &schema.ConvertResult{},
+			opts:    schema.CompileOptions{Plugins: []schema.CompilePlugin{{Name: "go"}}},
+			wantErr: "result has no proto output to compile",
+		},
+		{
+			name:    "unknown tool",
+			result:  input,
+			opts:    schema.CompileOptions{Tool: "bazel", Plugins: []schema.CompilePlugin{{Name: "go"}}},
+			wantErr: `unknown CompileTool "bazel"`,
+		},
+		{
+			name:    "no plugins",
+			result:  input,
+			opts:    schema.CompileOptions{},
+			wantErr: "at least one plugin is required",
+		},
+		{
+			name:    "prefixed plugin name",
+			result:  input,
+			opts:    schema.CompileOptions{Plugins: []schema.CompilePlugin{{Name: "protoc-gen-go"}}},
+			wantErr: "invalid plugin name 'protoc-gen-go'",
+		},
+		{
+			name:    "file name with directory",
+			result:  input,
+			opts:    schema.CompileOptions{FileName: "api/v1/api.proto", Plugins: []schema.CompilePlugin{{Name: "go"}}},
+			wantErr: "file name 'api/v1/api.proto' must be a base name ending in .proto",
+		},
+		{
+			name:   "include paths with buf",
+			result: input,
+			opts: schema.CompileOptions{Tool: schema.CompileToolBuf, IncludePaths: []string{"/usr/include"},
+				Plugins: []schema.CompilePlugin{{Name: "go"}}},
+			wantErr: "IncludePaths is not supported with buf",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := schema.CompileProto(test.result, test.opts)
+			require.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
@@ -1,15 +1,24 @@
 package schema
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/duh-rpc/openapi-schema.go/internal"
 	"github.com/duh-rpc/openapi-schema.go/internal/example"
 	"github.com/duh-rpc/openapi-schema.go/internal/golang"
 	"github.com/duh-rpc/openapi-schema.go/internal/parser"
 	"github.com/duh-rpc/openapi-schema.go/internal/proto"
 	"github.com/duh-rpc/openapi-schema.go/internal/validate"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 // ConvertResult contains the outputs from converting OpenAPI to proto3 and Go code.
@@ -27,6 +36,34 @@ type ConvertResult struct {
 	Protobuf []byte
 	Golang   []byte
 	TypeMap  map[string]*TypeInfo
+	// Deprecations lists deprecated schemas and properties with their x-sunset
+	// removal dates, in document order.
+	Deprecations []Deprecation
+	// Warnings reports information lost converting the schemas in Protobuf, in
+	// schema order. Warnings never cause Convert to fail.
+	Warnings []Warning
+	// Descriptor is Protobuf compiled into a file descriptor named
+	// "schema.proto", set when ConvertOptions.BuildDescriptor is true and proto
+	// output was generated.
+	Descriptor protoreflect.FileDescriptor
+	// Handlers is the duh-rpc handler scaffolding generated when
+	// ConvertOptions.Handlers is set: route constants, a service interface and
+	// an http.Handler wiring each POST operation's request and response.
+	Handlers []byte
+	// Renames lists the proto fields whose property names were sanitized
+	// (first-name → first_name), and the messages, enums and fields renamed to
+	// avoid a name already in use, in the order they were made, so API owners
+	// can audit the proto names against the JSON ones. See
+	// ConvertOptions.OnNameCollision.
+	Renames []Rename
+	// Manifest maps each component schema name to a content hash of its
+	// definition, the schemas it references, the options and the rest of the
+	// spec. Pass the result as ConvertOptions.Previous to skip converting an
+	// unchanged spec. Set only when ConvertOptions.BuildManifest or Previous is.
+	Manifest map[string]string
+	// Changes compares the result with ConvertOptions.Previous; nil when
+	// Previous is not set.
+	Changes *ConvertChanges
 }
 
 // StructResult contains the output from converting OpenAPI to Go structs only.
@@ -42,32 +79,95 @@ type ConvertResult struct {
 type StructResult struct {
 	Golang  []byte
 	TypeMap map[string]*TypeInfo
+	// Deprecations lists deprecated schemas and properties; see ConvertResult.
+	Deprecations []Deprecation
 }
 
 // ExampleResult contains generated JSON examples for schemas
 type ExampleResult struct {
 	Examples map[string]json.RawMessage // schema name → JSON example
+	// Metadata describes how examples were generated, for the schemas whose
+	// example has anything to report: schema name → metadata.
+	Metadata map[string]*ExampleMetadata
 }
 
-// ValidationResult contains the validation status for all examples in an OpenAPI spec
+// ExampleMetadata describes how one example was generated
+type ExampleMetadata struct {
+	// Truncations lists where a circular reference or MaxDepth cut the example
+	// short, in generation order, so readers know why values are missing.
+	Truncations []ExampleTruncation `json:"truncations"`
+}
+
+// ExampleTruncation records one place an example was cut short: the dotted
+// property path, as in FieldOverrides, the component schema left out, when
+// there is one, and the reason, TruncatedCircular or TruncatedMaxDepth.
+type ExampleTruncation = example.Truncation
+
+// Truncation reasons of ExampleTruncation
+const (
+	TruncatedCircular = example.TruncatedCircular // a schema references itself, directly or not
+	TruncatedMaxDepth = example.TruncatedMaxDepth // nesting reached MaxDepth
+)
+
+// ToYAML renders the examples as a YAML document keyed by schema name, with
+// schema names in sorted order.
+func (r *ExampleResult) ToYAML() ([]byte, error) {
+	return example.MarshalYAML(r.Examples)
+}
+
+// WriteBackToSpec injects each generated example into the `example` field of the
+// matching schema under components/schemas and returns the updated document.
+// Existing `example` values are replaced; schemas without a generated example are
+// left untouched. Comments and key order in the original YAML are preserved, so
+// documentation tooling can pick up the examples without further edits.
+//
+// JSON input is accepted, but the returned document is always YAML.
+//
+// Returns an error if:
+//   - openapi is empty
+//   - openapi is not valid YAML or JSON
+//   - the document has no components/schemas section
+func (r *ExampleResult) WriteBackToSpec(openapi []byte) (_ []byte, err error) {
+	defer internal.Recover("WriteBackToSpec", &err)
+
+	if len(openapi) == 0 {
+		return nil, fmt.Errorf("openapi input cannot be empty")
+	}
+	return example.InjectExamples(openapi, r.Examples)
+}
+
+// ValidationResult contains the validation status for all examples in an OpenAPI spec.
+// ToJSON, ToSARIF and ToJUnitXML render it for CI systems.
 type ValidationResult struct {
-	Schemas map[string]*SchemaValidationResult
+	Schemas map[string]*SchemaValidationResult `json:"schemas"`
+	// Examples holds the results for the examples of request body, response and
+	// parameter media types and of parameters, keyed by the JSON pointer of the
+	// object holding them (e.g. /paths/~1pets/post/requestBody/content/application~1json).
+	// Filled only when IncludeAll is set.
+	Examples map[string]*SchemaValidationResult `json:"examples,omitempty"`
 }
 
 // SchemaValidationResult contains validation details for a single schema
 type SchemaValidationResult struct {
-	SchemaPath  string
-	HasExamples bool
-	Valid       bool
-	Issues      []ValidationIssue
+	SchemaPath  string            `json:"schemaPath"`
+	HasExamples bool              `json:"hasExamples"`
+	Valid       bool              `json:"valid"`
+	Issues      []ValidationIssue `json:"issues"`
 }
 
 // ValidationIssue represents a single validation error or warning
 type ValidationIssue struct {
-	Severity     IssueSeverity
-	ExampleField string
-	Message      string
-	Line         int
+	Severity     IssueSeverity `json:"severity"`
+	ExampleField string        `json:"exampleField,omitempty"`
+	// Path is the JSON pointer to the example value, e.g.
+	// /components/schemas/User/example. Issues in a components/examples entry
+	// point at the entry, whichever media type or parameter referenced it.
+	Path string `json:"path,omitempty"`
+	// Class is the kind of issue: one of IssueClasses, such as "type-mismatch",
+	// "required", "additionalProperty" or the JSON Schema keyword that failed
+	Class   string `json:"class,omitempty"`
+	Message string `json:"message"`
+	Line    int    `json:"line,omitempty"`
 }
 
 // IssueSeverity indicates whether an issue is an error or warning
@@ -78,10 +178,77 @@ const (
 	IssueSeverityWarning IssueSeverity = "warning"
 )
 
+// validate reports an error for severities other than the declared constants
+func (s IssueSeverity) validate() error {
+	switch s {
+	case "", IssueSeverityError, IssueSeverityWarning:
+		return nil
+	}
+	return fmt.Errorf("unknown Threshold %q: must be error or warning", string(s))
+}
+
+// IssueClasses returns every ValidationIssue class ValidateOptions.TreatAsWarning
+// and TreatAsError accept
+func IssueClasses() []string {
+	return append([]string(nil), validate.Classes...)
+}
+
 // ValidateOptions configures example validation
 type ValidateOptions struct {
 	SchemaNames []string // Specific schemas to validate (ignored if IncludeAll is true)
 	IncludeAll  bool     // If true, validate all schemas (takes precedence over SchemaNames)
+	// TreatAsWarning and TreatAsError set the severity of issues of the listed
+	// classes (see IssueClasses), e.g. "additionalProperty" as a warning while a
+	// legacy spec is cleaned up. A class may not be in both.
+	TreatAsWarning []string
+	TreatAsError   []string
+	// Threshold is the severity at which an issue makes its result invalid:
+	// IssueSeverityError (the default) or IssueSeverityWarning.
+	Threshold IssueSeverity
+	// Formats checks example strings of its formats with their Validate
+	// functions. Any Validate turns on assertion of built-in formats too.
+	Formats *FormatRegistry
+}
+
+// validate reports unknown classes, classes given two severities and unknown
+// thresholds
+func (o ValidateOptions) validate() error {
+	for _, list := range []struct {
+		name    string
+		classes []string
+	}{{"TreatAsWarning", o.TreatAsWarning}, {"TreatAsError", o.TreatAsError}} {
+		for _, class := range list.classes {
+			if !slices.Contains(validate.Classes, class) {
+				return fmt.Errorf("unknown issue class %q in %s: see IssueClasses", class, list.name)
+			}
+		}
+	}
+	for _, class := range o.TreatAsWarning {
+		if slices.Contains(o.TreatAsError, class) {
+			return fmt.Errorf("issue class %q is in both TreatAsWarning and TreatAsError", class)
+		}
+	}
+	return o.Threshold.validate()
+}
+
+// severity returns the severity of an issue of class, reported at severity
+func (o ValidateOptions) severity(class string, severity IssueSeverity) IssueSeverity {
+	switch {
+	case slices.Contains(o.TreatAsWarning, class):
+		return IssueSeverityWarning
+	case slices.Contains(o.TreatAsError, class):
+		return IssueSeverityError
+	}
+	return severity
+}
+
+// fails reports whether an issue of class and severity makes its result
+// invalid. The OpenAPI 3.0 notice is informational, and never fails as a warning.
+func (o ValidateOptions) fails(class string, severity IssueSeverity) bool {
+	if severity == IssueSeverityError {
+		return true
+	}
+	return o.Threshold == IssueSeverityWarning && class != validate.ClassOpenAPIVersion
 }
 
 // ExampleOptions configures JSON example generation
@@ -91,17 +258,286 @@ type ExampleOptions struct {
 	IncludeAll  bool     // If true, generate examples for all schemas (takes precedence over SchemaNames)
 	Seed        int64    // Random seed for deterministic generation (0 = use time-based seed)
 	// FieldOverrides allows overriding generated values for specific field names (e.g., {"code": 400, "status": "error"}).
-	// - Applies to any field with matching name (case-sensitive) across all schemas
+	// - A plain key applies to any field with matching name (case-sensitive) across all schemas
+	// - A path key applies only under the schema it names: "ErrorResponse.code", "User.address.city"
+	// - Segments may hold *, ? and [...] wildcards: "*Error.code", "*Id"
+	// - Paths win over plain names, which win over name patterns
 	// - Takes precedence over heuristics and generated values
-	// - Does NOT override schema.Example or schema.Default (those have higher precedence)
+	// - Does NOT override schema.Example or schema.Default (those have higher precedence),
+	//   unless OverridePrecedence is OverridePrecedenceOverride
 	// - Type must match schema type or error is returned
 	FieldOverrides map[string]interface{}
+	// OverridePrecedence decides whether FieldOverrides beat the spec's example
+	// and default values. Empty → OverridePrecedenceSchema.
+	OverridePrecedence OverridePrecedence
+	// ValueFunc computes the values of scalar fields programmatically, such as
+	// sequential IDs or values from fixtures, for the top-level schema schemaName
+	// and the dotted property path fieldPath within it ("address.city"; "" for a
+	// scalar schema itself). Returning false leaves the value to generation. It is
+	// consulted after FieldOverrides, with the same precedence over example and
+	// default values, and its values must suit the field's type. Called
+	// concurrently when Concurrency > 1.
+	ValueFunc func(schemaName, fieldPath string, info ExampleFieldInfo) (interface{}, bool)
+	// Mode selects typical values, values at the edges of each constrained
+	// field's range, or values just outside it for negative-path tests. Empty →
+	// ExampleModeTypical.
+	Mode ExampleMode
+	// Concurrency is the number of schemas generated in parallel. Values <= 1 generate
+	// sequentially. In parallel mode each schema uses its own generator seeded from
+	// Seed and the schema name, so output is deterministic for a given Seed but not
+	// identical to sequential output.
+	Concurrency int
+	// Logger receives debug-level events for schemas skipped because generation
+	// failed and for field-name heuristics applied. Nil disables logging.
+	Logger *slog.Logger
+	// JSONTagCase rewrites generated property keys (and discriminator keys) to
+	// match the casing used by ConvertOptions.JSONTagCase. Explicit example and
+	// default values from the spec are emitted unchanged. Empty → preserve.
+	JSONTagCase JSONTagCase
+	// Providers generate realistic string values for the field names and formats
+	// they serve, such as FakeDataProviders. They are consulted in order, before
+	// the built-in cursor, error and message heuristics; example, default and
+	// FieldOverrides values still take precedence.
+	Providers []ExampleProvider
+	// Locale makes the fake data follow a locale, consulting its names, street
+	// addresses, postal codes, phone numbers, country and currency codes and
+	// display dates after Providers. "" adds none; to fake en-US data, set
+	// LocaleEnUS or pass FakeDataProviders.
+	Locale Locale
+	// SelfValidate runs each generated example back through the schema validator
+	// ValidateExamples uses, returning an error naming the schema and each
+	// violation when generation produced an invalid document. It cannot be
+	// combined with ExampleModeInvalid, or with a JSONTagCase that renames
+	// properties.
+	SelfValidate bool
+	// Formats generates example strings for its formats, after Providers, and
+	// checks them with their Validate functions when SelfValidate is set.
+	Formats *FormatRegistry
+	// BinaryDataURLs writes `format: binary` examples as base64 data URLs
+	// (data:application/octet-stream;base64,...) rather than bare base64, as
+	// `format: byte` examples are.
+	BinaryDataURLs bool
+	// CircularStrategy selects what examples hold where a circular reference or
+	// MaxDepth cuts generation short. Empty → CircularStrategyOmit.
+	CircularStrategy CircularStrategy
+	// RequiredDepth selects what becomes of required properties MaxDepth would
+	// cut short, so examples stay valid. Empty → RequiredDepthDrop.
+	RequiredDepth RequiredDepth
+	// ArrayItems selects how many items arrays get within minItems and
+	// maxItems. Empty → ArrayItemsRandom.
+	ArrayItems ArrayItems
+	// ArrayItemCount is the item count under ArrayItemsFixed, clamped into
+	// minItems and maxItems.
+	ArrayItemCount int
+}
+
+// ExampleMode selects how ConvertToExamples treats the range constraints of
+// each field: minimum and maximum (exclusive ones included), minLength and
+// maxLength, and minItems and maxItems. Where a field has both ends, one is
+// picked at random, so the Seed decides which.
+type ExampleMode string
+
+const (
+	// ExampleModeTypical generates values comfortably inside every range (the
+	// default).
+	ExampleModeTypical ExampleMode = example.ModeTypical
+	// ExampleModeBoundary generates each constrained field at its minimum or
+	// maximum: the smallest or largest number allowed, strings of exactly
+	// minLength or maxLength characters, arrays of exactly minItems or maxItems.
+	ExampleModeBoundary ExampleMode = example.ModeBoundary
+	// ExampleModeInvalid generates each constrained field just outside its
+	// range: one below minimum or above maximum (the bound itself when
+	// exclusive), one character or item fewer than minLength or minItems, or
+	// more than maxLength or maxItems.
+	ExampleModeInvalid ExampleMode = example.ModeInvalid
+)
+
+// validate reports an error for modes other than the declared constants.
+func (m ExampleMode) validate() error {
+	switch m {
+	case "", ExampleModeTypical, ExampleModeBoundary, ExampleModeInvalid:
+		return nil
+	}
+	return fmt.Errorf("unknown Mode %q: must be typical, boundary or invalid", string(m))
+}
+
+// CircularStrategy selects what an example holds where a circular reference or
+// MaxDepth cuts generation short. Each such place is listed in
+// ExampleResult.Metadata whatever the strategy.
+type CircularStrategy string
+
+const (
+	// CircularStrategyOmit leaves the property out, and the item out of arrays
+	// (the default).
+	CircularStrategyOmit CircularStrategy = example.CircularOmit
+	// CircularStrategyNull sets the property, or item, to null.
+	CircularStrategyNull CircularStrategy = example.CircularNull
+	// CircularStrategyTruncatedObject sets the property, or item, to an empty
+	// object.
+	CircularStrategyTruncatedObject CircularStrategy = example.CircularTruncatedObject
+)
+
+// validate reports an error for strategies other than the declared constants.
+func (c CircularStrategy) validate() error {
+	switch c {
+	case "", CircularStrategyOmit, CircularStrategyNull, CircularStrategyTruncatedObject:
+		return nil
+	}
+	return fmt.Errorf("unknown CircularStrategy %q: must be omit, null or truncatedObject", string(c))
+}
+
+// RequiredDepth selects what becomes of required properties that MaxDepth
+// would cut short, which otherwise leaves the example invalid against its
+// schema.
+type RequiredDepth string
+
+const (
+	// RequiredDepthDrop cuts required properties short like any other, as
+	// CircularStrategy says (the default).
+	RequiredDepthDrop RequiredDepth = example.RequiredDepthDrop
+	// RequiredDepthExtend generates required properties past MaxDepth, one
+	// level at a time, while optional properties below them are still cut. A
+	// cycle of required references is still cut at its first repeat.
+	RequiredDepthExtend RequiredDepth = example.RequiredDepthExtend
+	// RequiredDepthStub replaces required properties past MaxDepth with the
+	// smallest value their schema allows: objects of their required properties,
+	// arrays of minItems items, minLength strings and numbers nearest zero.
+	RequiredDepthStub RequiredDepth = example.RequiredDepthStub
+)
+
+// validate reports an error for values other than the declared constants.
+func (r RequiredDepth) validate() error {
+	switch r {
+	case "", RequiredDepthDrop, RequiredDepthExtend, RequiredDepthStub:
+		return nil
+	}
+	return fmt.Errorf("unknown RequiredDepth %q: must be drop, extend or stub", string(r))
+}
+
+// ArrayItems selects how many items example arrays get. Arrays without
+// minItems count as minItems 1, and those without maxItems as maxItems equal
+// to minItems, so only ArrayItemsFixed goes past minItems for them.
+type ArrayItems string
+
+const (
+	// ArrayItemsRandom picks a random count within minItems and maxItems (the
+	// default).
+	ArrayItemsRandom ArrayItems = example.ArrayItemsRandom
+	// ArrayItemsMin gives arrays minItems items, or one without minItems.
+	ArrayItemsMin ArrayItems = example.ArrayItemsMin
+	// ArrayItemsMax gives arrays maxItems items.
+	ArrayItemsMax ArrayItems = example.ArrayItemsMax
+	// ArrayItemsFixed gives arrays ArrayItemCount items, raised to minItems or
+	// lowered to maxItems where the schema requires.
+	ArrayItemsFixed ArrayItems = example.ArrayItemsFixed
+)
+
+// validate reports an error for strategies other than the declared constants,
+// or an ArrayItemCount they do not use.
+func (a ArrayItems) validate(count int) error {
+	switch a {
+	case "", ArrayItemsRandom, ArrayItemsMin, ArrayItemsMax:
+		if count != 0 {
+			return fmt.Errorf("ArrayItemCount requires ArrayItems fixed")
+		}
+		return nil
+	case ArrayItemsFixed:
+		if count < 0 {
+			return fmt.Errorf("ArrayItemCount must not be negative, got %d", count)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown ArrayItems %q: must be random, min, max or fixed", string(a))
+}
+
+// ExampleFieldInfo describes the scalar field ExampleOptions.ValueFunc is asked
+// for: its property path and name, the component schema declaring it, and its
+// type, format and description.
+type ExampleFieldInfo = example.FieldInfo
+
+// OverridePrecedence selects whether FieldOverrides or the spec's own example
+// and default values win where both give a field's value.
+type OverridePrecedence string
+
+const (
+	// OverridePrecedenceSchema keeps example and default values, overriding only
+	// generated ones (the default).
+	OverridePrecedenceSchema OverridePrecedence = example.OverridePrecedenceSchema
+	// OverridePrecedenceOverride forces FieldOverrides over example and default
+	// values, including fields within an object or array example, which are
+	// replaced as given.
+	OverridePrecedenceOverride OverridePrecedence = example.OverridePrecedenceOverride
+)
+
+// validate reports an error for precedences other than the declared constants.
+func (p OverridePrecedence) validate() error {
+	switch p {
+	case "", OverridePrecedenceSchema, OverridePrecedenceOverride:
+		return nil
+	}
+	return fmt.Errorf("unknown OverridePrecedence %q: must be schema or override", string(p))
+}
+
+// Locale selects the fake data ExampleOptions.Locale generates.
+type Locale string
+
+const (
+	// LocaleEnUS is American English: Ada Lovelace, 12 Main Street, 01/15/2024.
+	LocaleEnUS Locale = example.LocaleEnUS
+	// LocaleDeDE is German: Anna Müller, Hauptstraße 12, 15.01.2024.
+	LocaleDeDE Locale = example.LocaleDeDE
+	// LocaleJaJP is Japanese, family name first: 佐藤 太郎, 丸の内1-2-3, 2024/01/15.
+	LocaleJaJP Locale = example.LocaleJaJP
+)
+
+// validate reports an error for locales other than the declared constants.
+func (l Locale) validate() error {
+	switch l {
+	case "", LocaleEnUS, LocaleDeDE, LocaleJaJP:
+		return nil
+	}
+	return fmt.Errorf("unknown Locale %q: must be en-US, de-DE or ja-JP", string(l))
+}
+
+// ExampleProvider generates example strings for the fields it serves, by field
+// name (compared ignoring case, underscores and hyphens) or by string format.
+type ExampleProvider = example.Provider
+
+// FakeDataProviders returns ExampleProviders of realistic en-US fake data:
+// person names, street addresses, cities, postal codes, phone numbers, ISO 3166
+// country codes, ISO 4217 currency codes, display dates and lorem ipsum text.
+// Append providers of your own to extend it; ExampleOptions.Locale fakes the
+// same data for other locales.
+func FakeDataProviders() []ExampleProvider {
+	return example.FakeDataProviders()
 }
 
 // TypeInfo contains metadata about where a type is generated and why
 type TypeInfo struct {
 	Location TypeLocation
 	Reason   string
+	// GeneratedName is the generated proto message or enum, or Go struct, name.
+	// It differs from the TypeMap key when x-proto-name or x-go-name renames the
+	// type, or when the schema name is not PascalCase. Empty when nothing is
+	// generated for the schema.
+	GeneratedName string
+	// FieldCount is the number of fields of the generated message or struct (the
+	// variants of a union), or the number of values of a generated enum.
+	FieldCount int
+	// Dependencies lists the schemas this one references directly, through its
+	// properties, array items or oneOf variants, in first-reference order.
+	Dependencies []string
+	// Notes describes non-obvious mapping decisions made for the type, such as an
+	// array of arrays wrapped in a generated message.
+	Notes []string
+	// OriginalName is the schema's name in its spec when a NamedSpec.TypePrefix
+	// renamed it for ConvertMany; empty otherwise.
+	OriginalName string
+	// ReadOnly and WriteOnly list the properties marked `readOnly: true` and
+	// `writeOnly: true`, in declaration order, so servers can drop read-only
+	// fields from requests and write-only fields from responses.
+	ReadOnly  []string
+	WriteOnly []string
 }
 
 // TypeLocation indicates whether a type is generated as proto or golang
@@ -112,6 +548,355 @@ const (
 	TypeLocationGolang TypeLocation = "golang"
 )
 
+// JSONTagCase selects the casing of JSON keys in generated Go struct tags and examples.
+type JSONTagCase string
+
+const (
+	// JSONTagCasePreserve keeps OpenAPI property names as written (the default).
+	JSONTagCasePreserve JSONTagCase = internal.JSONCasePreserve
+	// JSONTagCaseCamel converts property names to camelCase (created_at → createdAt).
+	JSONTagCaseCamel JSONTagCase = internal.JSONCaseCamel
+	// JSONTagCaseSnake converts property names to snake_case (HTTPStatus → http_status).
+	JSONTagCaseSnake JSONTagCase = internal.JSONCaseSnake
+)
+
+// validate reports an error for casing values other than the declared constants.
+func (c JSONTagCase) validate() error {
+	switch c {
+	case "", JSONTagCasePreserve, JSONTagCaseCamel, JSONTagCaseSnake:
+		return nil
+	}
+	return fmt.Errorf("unknown JSONTagCase %q: must be preserve, camel or snake", string(c))
+}
+
+// OmitEmpty selects the generated Go struct fields whose json tag has omitempty.
+type OmitEmpty string
+
+const (
+	// OmitEmptyNever leaves omitempty off every field (the default).
+	OmitEmptyNever OmitEmpty = golang.OmitEmptyNever
+	// OmitEmptyOptional adds omitempty to fields whose property is not listed in
+	// the schema's required.
+	OmitEmptyOptional OmitEmpty = golang.OmitEmptyOptional
+	// OmitEmptyAlways adds omitempty to every field.
+	OmitEmptyAlways OmitEmpty = golang.OmitEmptyAlways
+)
+
+// validate reports an error for values other than the declared constants.
+func (o OmitEmpty) validate() error {
+	switch o {
+	case "", OmitEmptyNever, OmitEmptyOptional, OmitEmptyAlways:
+		return nil
+	}
+	return fmt.Errorf("unknown OmitEmpty %q: must be never, optional or always", string(o))
+}
+
+// UnionStyle selects how oneOf unions are represented in generated Go code.
+type UnionStyle string
+
+const (
+	// UnionStyleStruct generates a struct with a pointer field per variant and
+	// custom JSON marshaling (the default).
+	UnionStyleStruct UnionStyle = golang.UnionStyleStruct
+	// UnionStyleInterface generates a sealed interface the variants implement,
+	// for exhaustive type switches, and a Decode function reading the variant
+	// from JSON.
+	UnionStyleInterface UnionStyle = golang.UnionStyleInterface
+)
+
+// validate reports an error for values other than the declared constants.
+func (u UnionStyle) validate() error {
+	switch u {
+	case "", UnionStyleStruct, UnionStyleInterface:
+		return nil
+	}
+	return fmt.Errorf("unknown UnionStyle %q: must be struct or interface", string(u))
+}
+
+// GoFormat selects how generated Go source is formatted.
+type GoFormat string
+
+const (
+	// GoFormatNone returns Go source as generated.
+	GoFormatNone GoFormat = golang.FormatNone
+	// GoFormatGofmt formats Go source with go/format, as gofmt does (the
+	// default).
+	GoFormatGofmt GoFormat = golang.FormatGofmt
+	// GoFormatGofumpt formats Go source with go/format and then applies the
+	// subset of the gofumpt rules generated code can break, such as no empty
+	// lines at the start or end of a block. It does not run gofumpt itself.
+	GoFormatGofumpt GoFormat = golang.FormatGofumpt
+)
+
+// validate reports an error for values other than the declared constants.
+func (f GoFormat) validate() error {
+	switch f {
+	case "", GoFormatNone, GoFormatGofmt, GoFormatGofumpt:
+		return nil
+	}
+	return fmt.Errorf("unknown GoFormat %q: must be none, gofmt or gofumpt", string(f))
+}
+
+// formatGo formats generated Go source as opts.GoFormat selects. Source that
+// fails to format is returned as generated, unless opts.StrictGoFormat is set.
+func formatGo(src []byte, opts ConvertOptions) ([]byte, error) {
+	if len(src) == 0 {
+		return src, nil
+	}
+	formatted, err := golang.FormatSource(src, string(opts.GoFormat))
+	if err != nil {
+		if opts.StrictGoFormat {
+			return nil, err
+		}
+		return src, nil
+	}
+	return formatted, nil
+}
+
+// ProtoStyle sets the layout of the generated proto output, to match an existing
+// .proto style guide. The zero value gives the default layout.
+type ProtoStyle struct {
+	// Indent is the number of spaces per nesting level: 2 or 4. 0 → 2.
+	Indent int
+	// OmitDefaultJSONName leaves out json_name annotations equal to the JSON name
+	// protoc derives from the field name by dropping underscores and upper-casing
+	// the letter after each (status_code → statusCode), so only names that
+	// differ are annotated.
+	OmitDefaultJSONName bool
+	// BlankLines sets where blank lines separate fields. Empty →
+	// ProtoBlankLinesDefinitions.
+	BlankLines ProtoBlankLines
+}
+
+// ProtoBlankLines selects where blank lines appear in proto output.
+type ProtoBlankLines string
+
+const (
+	// ProtoBlankLinesDefinitions separates top-level definitions and follows
+	// nested messages, with fields on consecutive lines (the default).
+	ProtoBlankLinesDefinitions ProtoBlankLines = proto.BlankLinesDefinitions
+	// ProtoBlankLinesCommented also puts a blank line before each field with a
+	// comment.
+	ProtoBlankLinesCommented ProtoBlankLines = proto.BlankLinesCommented
+	// ProtoBlankLinesFields also puts a blank line between every field.
+	ProtoBlankLinesFields ProtoBlankLines = proto.BlankLinesFields
+)
+
+// validate reports an error for an unsupported indent or blank-line policy.
+func (s ProtoStyle) validate() error {
+	switch s.Indent {
+	case 0, 2, 4:
+	default:
+		return fmt.Errorf("unknown ProtoStyle.Indent %d: must be 2 or 4", s.Indent)
+	}
+	switch s.BlankLines {
+	case "", ProtoBlankLinesDefinitions, ProtoBlankLinesCommented, ProtoBlankLinesFields:
+		return nil
+	}
+	return fmt.Errorf("unknown ProtoStyle.BlankLines %q: must be definitions, commented or fields", string(s.BlankLines))
+}
+
+// ProtoSyntax selects the syntax of the generated .proto file.
+type ProtoSyntax string
+
+const (
+	// ProtoSyntaxProto3 emits `syntax = "proto3";` (the default).
+	ProtoSyntaxProto3 ProtoSyntax = "proto3"
+	// ProtoSyntaxEditions emits `edition = "2023";` with
+	// `option features.field_presence = IMPLICIT;`, so fields keep their proto3
+	// behavior. UseProto3Optional fields get
+	// `[features.field_presence = EXPLICIT]` in place of the optional keyword.
+	ProtoSyntaxEditions ProtoSyntax = "editions"
+)
+
+// validate reports an error for values other than the declared constants.
+func (s ProtoSyntax) validate() error {
+	switch s {
+	case "", ProtoSyntaxProto3, ProtoSyntaxEditions:
+		return nil
+	}
+	return fmt.Errorf("unknown ProtoSyntax %q: must be proto3 or editions", string(s))
+}
+
+// ProtoIdentifier is a FileOptions value written unquoted, for enum-valued
+// options such as optimize_for = SPEED.
+type ProtoIdentifier string
+
+// fileOptions returns FileOptions as proto option statements, sorted by name:
+// strings quoted, ProtoIdentifier, numbers and booleans as they are.
+func fileOptions(options map[string]any) ([]proto.Option, error) {
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]proto.Option, 0, len(names))
+	for _, name := range names {
+		if name == "go_package" {
+			return nil, fmt.Errorf("FileOptions cannot set go_package: it is derived from PackagePath")
+		}
+		if !proto.ValidOptionName(name) {
+			return nil, fmt.Errorf("invalid FileOptions name %q: must be an option name such as java_package or (my.option)", name)
+		}
+
+		var value string
+		switch v := options[name].(type) {
+		case string:
+			value = strconv.Quote(v)
+		case ProtoIdentifier:
+			value = string(v)
+		case bool, int, int32, int64, uint, uint32, uint64, float32, float64:
+			value = fmt.Sprint(v)
+		default:
+			return nil, fmt.Errorf("FileOptions %q: unsupported value type %T", name, v)
+		}
+		result = append(result, proto.Option{Name: name, Value: value})
+	}
+	return result, nil
+}
+
+// validateExtraTags reports invalid or repeated ExtraTags keys
+func validateExtraTags(keys []string) error {
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if err := golang.ValidateTagKey(key); err != nil {
+			return fmt.Errorf("ExtraTags: %w", err)
+		}
+		if seen[key] {
+			return fmt.Errorf("ExtraTags: tag '%s' is listed twice", key)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// ProtoFieldNaming selects how proto field names are derived from property names.
+type ProtoFieldNaming string
+
+const (
+	// ProtoFieldNamingPreserve keeps property names as written, replacing only
+	// characters proto3 does not allow (the default).
+	ProtoFieldNamingPreserve ProtoFieldNaming = proto.FieldNamingPreserve
+	// ProtoFieldNamingSnakeCase converts property names to lower_snake_case
+	// (userId → user_id, HTTPStatus → http_status), as buf lint requires.
+	ProtoFieldNamingSnakeCase ProtoFieldNaming = proto.FieldNamingSnakeCase
+)
+
+// validate reports an error for naming values other than the declared constants.
+func (n ProtoFieldNaming) validate() error {
+	switch n {
+	case "", ProtoFieldNamingPreserve, ProtoFieldNamingSnakeCase:
+		return nil
+	}
+	return fmt.Errorf("unknown ProtoFieldNaming %q: must be preserve or snake_case", string(n))
+}
+
+// MessageOrder selects the order of top-level messages and enums in proto output.
+type MessageOrder string
+
+const (
+	// MessageOrderInsertion keeps the order schemas appear in the document (the default).
+	MessageOrderInsertion MessageOrder = proto.OrderInsertion
+	// MessageOrderAlphabetical sorts by generated name, so reordering schemas
+	// leaves the output unchanged.
+	MessageOrderAlphabetical MessageOrder = proto.OrderAlphabetical
+	// MessageOrderTopological places every type after the types it references,
+	// otherwise keeping document order. Types in reference cycles, and those that
+	// reference them, come last; each cycle is broken at its first type.
+	MessageOrderTopological MessageOrder = proto.OrderTopological
+)
+
+// validate reports an error for order values other than the declared constants.
+func (o MessageOrder) validate() error {
+	switch o {
+	case "", MessageOrderInsertion, MessageOrderAlphabetical, MessageOrderTopological:
+		return nil
+	}
+	return fmt.Errorf("unknown SortMessages %q: must be insertion, alphabetical or topological", string(o))
+}
+
+// NameCollision selects how a generated proto type name already in use, such
+// as the message for schema "user" when schema "User" exists, is resolved.
+type NameCollision string
+
+const (
+	// NameCollisionSuffix appends a numeric suffix: User_2, User_3 (the default).
+	NameCollisionSuffix NameCollision = proto.NameCollisionSuffix
+	// NameCollisionError fails the schema, naming the schema or property that
+	// already uses the name. Colliding field names fail too.
+	NameCollisionError NameCollision = proto.NameCollisionError
+	// NameCollisionPrefixWithSchema prefixes a colliding inline type with the
+	// name of the schema declaring it (Order.address → OrderAddress), falling
+	// back to a suffix when that is taken too or the type is a schema's own.
+	NameCollisionPrefixWithSchema NameCollision = proto.NameCollisionPrefixWithSchema
+)
+
+// validate reports an error for strategies other than the declared constants.
+func (c NameCollision) validate() error {
+	switch c {
+	case "", NameCollisionSuffix, NameCollisionError, NameCollisionPrefixWithSchema:
+		return nil
+	}
+	return fmt.Errorf("unknown OnNameCollision %q: must be suffix, error or prefixWithSchema", string(c))
+}
+
+// NonASCIINames selects how non-ASCII characters in property names, such as
+// "prénom" or "名前", are made into proto field and Go identifier characters.
+// json_name and the json tag always keep the property name as written.
+type NonASCIINames string
+
+const (
+	// NonASCIITransliterate drops the accents of Latin letters (prénom → prenom,
+	// straße → strasse) and escapes other characters as NonASCIIEscape does
+	// (the default).
+	NonASCIITransliterate NonASCIINames = internal.NonASCIITransliterate
+	// NonASCIIEscape escapes every non-ASCII character as "u" and its hex code
+	// point, set apart by underscores: 名前 → u540d_u524d, prénom → pr_u00e9_nom.
+	NonASCIIEscape NonASCIINames = internal.NonASCIIEscape
+	// NonASCIIError fails any property whose name holds a non-ASCII character,
+	// naming the character.
+	NonASCIIError NonASCIINames = internal.NonASCIIError
+)
+
+// validate reports an error for modes other than the declared constants.
+func (n NonASCIINames) validate() error {
+	switch n {
+	case "", NonASCIITransliterate, NonASCIIEscape, NonASCIIError:
+		return nil
+	}
+	return fmt.Errorf("unknown NonASCIINames %q: must be transliterate, escape or error", string(n))
+}
+
+// Rename records a generated proto name that differs from the name it came
+// from. Kind is "type" for a message or enum and "field" for a message field;
+// Property is empty for a schema's own message or enum. Reason is "sanitized"
+// when characters proto does not allow in a field name were replaced with
+// underscores, and "collision" when the name was already in use.
+type Rename = internal.Rename
+
+// FreeFormGoType selects the Go type generated for free-form objects.
+type FreeFormGoType string
+
+const (
+	// FreeFormMap decodes free-form objects into map[string]any (the default).
+	FreeFormMap FreeFormGoType = "map"
+	// FreeFormRawMessage keeps free-form objects as undecoded json.RawMessage.
+	FreeFormRawMessage FreeFormGoType = "raw-message"
+)
+
+// goType returns the Go type for t, or an error for values other than the
+// declared constants.
+func (t FreeFormGoType) goType() (string, error) {
+	switch t {
+	case "", FreeFormMap:
+		return "map[string]any", nil
+	case FreeFormRawMessage:
+		return "json.RawMessage", nil
+	}
+	return "", fmt.Errorf("unknown FreeFormGoType %q: must be map or raw-message", string(t))
+}
+
 // FieldNumbers is an optional, name-keyed proto field-number assignment. When
 // non-nil on ConvertOptions it overrides positional numbering for any message or
 // enum it has an entry for and drives `reserved` rendering; when nil the library
@@ -139,6 +924,258 @@ type ConvertOptions struct {
 	GoPackagePath string
 	// FieldNumbers optionally overrides positional field numbering; nil → positional.
 	FieldNumbers *FieldNumbers
+	// ArrayWrapperSuffix names the message generated for each inner array of an
+	// array-of-arrays property (property name + suffix, e.g. data → DataRow).
+	// Defaults to "Row".
+	ArrayWrapperSuffix string
+	// Concurrency is the number of workers used to resolve schemas and build Go
+	// structs. Values <= 1 process schemas sequentially. Output is identical
+	// regardless of the setting.
+	Concurrency int
+	// Logger receives debug-level events describing conversion decisions: schemas
+	// skipped, names renamed to avoid conflicts, and imports added. Nil disables
+	// logging.
+	Logger *slog.Logger
+	// CollectErrors keeps converting past invalid schemas and properties and
+	// returns every error found, combined with errors.Join, in schema order.
+	// When false, conversion stops at the first error.
+	CollectErrors bool
+	// JSONTagCase sets the casing of json struct tags in generated Go code,
+	// including the discriminator key read by union UnmarshalJSON. Proto
+	// json_name annotations are unaffected. Empty → JSONTagCasePreserve.
+	JSONTagCase JSONTagCase
+	// OmitEmpty adds omitempty to the json tags of generated Go struct fields,
+	// for optional properties or all of them. Union variant fields and
+	// discriminators are unaffected. Empty → OmitEmptyNever.
+	OmitEmpty OmitEmpty
+	// ExtraTags are struct tag keys, such as yaml, bson or db, added to every
+	// generated Go struct field after json with the json tag's value:
+	// `json:"name,omitempty" yaml:"name,omitempty"`. A property's
+	// x-oapi-codegen-extra-tags extension adds tags of its own, replacing the
+	// value of any key also listed here.
+	ExtraTags []string
+	// UnionStyle selects how oneOf unions are represented in generated Go code.
+	// UnionStyleInterface makes each union a sealed interface (type Pet
+	// interface{ isPet() }) implemented by pointers to its variants, with a
+	// DecodePet function; structs holding a union decode it in UnmarshalJSON.
+	// Empty → UnionStyleStruct.
+	UnionStyle UnionStyle
+	// ProtoShims bridges the Go and proto halves of Convert's output. For every
+	// Go-located struct whose fields all have a proto equivalent (no union, enum or
+	// inline object fields), the proto output gains a message with the same fields
+	// and numbers, named with a "Proto" suffix (Dog → DogProto), and the Go output
+	// gains Dog.ToProto() and DogFromProto() converting to and from the
+	// protoc-generated type. Ignored by ConvertToStruct.
+	ProtoShims bool
+	// AlsoGenerateGoStructs emits a Go struct for every schema, including those
+	// located in proto, so code without protoc output can use native structs for
+	// everything. Json tags match the proto json_name annotations, and Go structs
+	// reference each other rather than protoc-generated types. GoPackagePath must
+	// then differ from PackagePath, where protoc-gen-go places the same names.
+	// Cannot be combined with ProtoShims.
+	AlsoGenerateGoStructs bool
+	// BuildDescriptor compiles the proto output in-process into
+	// ConvertResult.Descriptor, for inspecting the generated types through
+	// protoreflect and confirming the output is valid without protoc. Imports
+	// other than the google/protobuf well-known types cannot be resolved, so it
+	// fails with TypeMappings that import other files.
+	BuildDescriptor bool
+	// Handlers generates duh-rpc server scaffolding into ConvertResult.Handlers,
+	// serving each POST operation whose request body and 200 response reference
+	// component schemas generated as proto messages. The handler imports those
+	// types from PackagePath, where protoc-gen-go places them. Nil → none.
+	Handlers *HandlerOptions
+	// TypeMappings override the built-in scalar mapping for specific OpenAPI
+	// type/format pairs, e.g. string/decimal → google.type.Decimal in proto and
+	// string/uuid → uuid.UUID in Go. The required imports are added to each output.
+	TypeMappings []TypeMapping
+	// Formats maps its formats with a Go or proto type as TypeMappings do, unless
+	// TypeMappings already map the format.
+	Formats *FormatRegistry
+	// WellKnownTypes maps OpenAPI constructs without a proto3 equivalent onto
+	// google.protobuf well-known types, adding the imports they need. Go output
+	// uses FreeFormGoType for Struct and json.RawMessage for Any.
+	WellKnownTypes WellKnownTypes
+	// Decimals maps decimal values onto exact types; see DecimalOptions. Nil →
+	// the built-in double and float64 (and an error for Go number/decimal).
+	Decimals *DecimalOptions
+	// FreeFormGoType is the Go type of free-form objects when WellKnownTypes.Struct
+	// is set. Empty → FreeFormMap.
+	FreeFormGoType FreeFormGoType
+	// NestedNameFunc names the nested messages and enums generated for inline
+	// objects, inline array items and inline integer enums. It receives the name of
+	// the enclosing message and the JSON property name, and returns a proto type
+	// name such as "LineItem", or "" for the default: the PascalCase property name,
+	// rejected when the property name is plural. Go structs for inline objects are
+	// named with the enclosing struct's name as a prefix (Order + LineItem). Called
+	// concurrently when Concurrency > 1.
+	NestedNameFunc func(parent, property string) string
+	// ProtoFieldNaming sets how proto field names are derived from property names.
+	// Every field keeps a json_name annotation with the original property name, so
+	// the JSON form is unchanged. A property's x-proto-field-name overrides it.
+	// Empty → ProtoFieldNamingPreserve.
+	ProtoFieldNaming ProtoFieldNaming
+	// UseProto3Optional emits scalar and enum fields whose property is not listed
+	// in `required` with the proto3 optional keyword (optional string nickname = 3;),
+	// so absent and zero values can be told apart. Go structs for these messages
+	// get no ToProto/FromProto shims, since protoc-gen-go makes the fields pointers.
+	UseProto3Optional bool
+	// SchemaNames limits conversion to the named component schemas and the
+	// schemas they reference, directly or transitively. Empty → every schema.
+	// Naming a schema that does not exist is an error.
+	SchemaNames []string
+	// ExcludeSchemas names component schemas to leave out of the output, as does
+	// x-proto-skip: true on the schema itself. Other schemas may still reference
+	// them: such fields become bytes in proto and json.RawMessage in Go, holding
+	// the schema's JSON. Union variants cannot be excluded. Naming a schema
+	// that does not exist is an error.
+	ExcludeSchemas []string
+	// SortMessages sets the order of top-level messages and enums in the proto
+	// output. Empty → MessageOrderInsertion.
+	SortMessages MessageOrder
+	// GoNameFunc names the Go struct field generated for a JSON property, and the
+	// property part of inline struct names. Return "" to keep the default, which
+	// PascalCases the property and upper-cases common initialisms (userId → UserID,
+	// imageUrls → ImageURLs). A property's x-go-field-name overrides it. Called
+	// concurrently when Concurrency > 1.
+	GoNameFunc func(property string) string
+	// OnNameCollision selects how a proto message or enum name already in use
+	// is resolved; "" → NameCollisionSuffix. Every rename is listed in
+	// ConvertResult.Renames.
+	OnNameCollision NameCollision
+	// NonASCIINames selects how non-ASCII characters in property names become
+	// proto field, nested type and Go identifier characters; "" →
+	// NonASCIITransliterate. Changed field names are listed in
+	// ConvertResult.Renames.
+	NonASCIINames NonASCIINames
+	// TypePrefix is prepended to every generated top-level message, enum and
+	// struct name (Money → AcmeMoney), to keep types converted from different
+	// specs apart in a shared package. x-proto-name and x-go-name are prefixed
+	// too. TypeMap keys stay the original schema names; GeneratedName holds the
+	// prefixed name.
+	TypePrefix string
+	// CommentWidth is the column proto and Go comments generated from
+	// descriptions wrap at, breaking lines at spaces; indented (code) lines are
+	// never wrapped. Zero wraps Go doc comments at 80 and leaves proto comments
+	// unwrapped; a negative width wraps neither.
+	CommentWidth int
+	// StripMarkdown removes markdown markup from descriptions before they become
+	// proto and Go comments: heading markers, emphasis and code markers and
+	// code fences go, and links become "text (url)".
+	StripMarkdown bool
+	// HeaderComment is written as a comment at the top of the generated proto and
+	// Go files, after the generated header when GeneratedHeader is set, e.g. a
+	// license notice.
+	HeaderComment string
+	// GeneratedHeader starts the generated proto and Go files with the standard
+	// "// Code generated by openapi-schema. DO NOT EDIT." line, followed by the
+	// SHA-256 of the input spec and the version of this module, so tooling can
+	// recognise generated files and detect when they drift from the spec.
+	GeneratedHeader bool
+	// GoFormat formats the generated Go source, and the duh-rpc handlers, so they
+	// never need formatting by hand. Empty → GoFormatGofmt.
+	GoFormat GoFormat
+	// StrictGoFormat makes Go source that GoFormat fails to format an error;
+	// otherwise it is returned as generated.
+	StrictGoFormat bool
+	// ProtoStyle sets the indentation, json_name annotations and blank lines of
+	// the proto output. Ignored by ConvertToStruct.
+	ProtoStyle ProtoStyle
+	// ProtoSyntax selects proto3 or edition 2023 output. Empty →
+	// ProtoSyntaxProto3. Ignored by ConvertToStruct.
+	ProtoSyntax ProtoSyntax
+	// FileOptions are written as file-level options after go_package, such as
+	// java_package, csharp_namespace or objc_class_prefix, sorted by name.
+	// Strings are quoted; ProtoIdentifier values (optimize_for: SPEED), numbers
+	// and booleans are written as they are. go_package cannot be set. Messages
+	// and enums take options from a schema's x-proto-options extension.
+	// Ignored by ConvertToStruct.
+	FileOptions map[string]any
+	// ImportMappings maps component schema names onto types generated from
+	// another spec, e.g. Money → acme.common.v1.Money from
+	// acme/common/v1/money.proto. No message or struct is generated for a
+	// mapped schema; references use the mapped type and add its import. A Go
+	// struct referencing a mapping without a Go type is an error. Naming a schema
+	// that does not exist is an error.
+	ImportMappings map[string]ImportMapping
+	// RecognizeKnownSchemas imports component schemas matching the signature of
+	// a KnownSchemas entry, such as an object of currencyCode, units and nanos
+	// becoming google.type.Money, instead of generating a copy. Schemas setting
+	// x-proto-import are imported whether or not it is set.
+	RecognizeKnownSchemas bool
+	// KnownSchemas is the registry of shared types recognised by signature and
+	// by x-proto-import; nil uses DefaultKnownSchemas
+	KnownSchemas []KnownSchema
+	// Previous is the result of an earlier Convert of the same spec, used as a
+	// cache of the whole result: when the hash of every schema in its Manifest
+	// is unchanged, Convert returns its outputs without converting, and
+	// otherwise converts every schema again. Either way ConvertResult.Changes
+	// reports what changed since. Options holding functions, such as
+	// NestedNameFunc, cannot be hashed, so Previous is then only compared
+	// against. Previous needs a Manifest, so the first result of a chain must be
+	// converted with BuildManifest.
+	Previous *ConvertResult
+	// BuildManifest sets ConvertResult.Manifest, so the result can be passed as
+	// Previous. Hashing the spec adds to the time of the conversion, so it is
+	// only done when BuildManifest or Previous is set.
+	BuildManifest bool
+	// VerifyDeterminism converts twice and fails when the outputs differ in any
+	// byte, for reproducible builds that must prove it. A result reused from
+	// Previous is checked against a fresh conversion. Doubles the conversion
+	// time.
+	VerifyDeterminism bool
+}
+
+// commentStyle returns how descriptions are written as comments, given the
+// width the output wraps at by default
+func (o ConvertOptions) commentStyle(defaultWidth int) internal.CommentStyle {
+	width := o.CommentWidth
+	switch {
+	case width == 0:
+		width = defaultWidth
+	case width < 0:
+		width = 0
+	}
+	return internal.CommentStyle{Width: width, StripMarkdown: o.StripMarkdown}
+}
+
+// WellKnownTypes selects optional mappings onto google.protobuf well-known types:
+// Duration for `format: duration` strings, Struct for objects without properties
+// (and with unconstrained additionalProperties), including references to such
+// component schemas, and Any for properties using
+// anyOf without a discriminator. Note Any's JSON form carries an "@type" key.
+type WellKnownTypes = internal.WellKnownTypes
+
+// DecimalOptions maps decimal values, numbers and strings with `format: decimal`
+// or `x-money: true`, onto exact types instead of double and float64, so
+// financial amounts are not rounded. Proto fields become string, or
+// google.type.Money with Money set; Go fields become GoType, or else
+// json.Number for numbers and string for strings. A TypeMapping for the same
+// type and format wins.
+type DecimalOptions = internal.DecimalOptions
+
+// TypeMapping overrides how one OpenAPI type and format is generated. Set Proto
+// (with ProtoImport when the type lives in another .proto file), Go (with
+// GoImport when the type lives in another package), or both; an empty target
+// keeps the built-in mapping for that output. Format "" matches schemas with no
+// format. A format with a Proto mapping is not reported as unknown in Warnings.
+type TypeMapping = internal.TypeMapping
+
+// ImportMapping points a component schema at a type generated from another
+// spec, such as a shared common.proto. Set Proto, the fully qualified proto type,
+// and ProtoImport, the file declaring it; Go and GoImport give the Go type for
+// Go structs that reference the schema.
+type ImportMapping = internal.ImportMapping
+
+// KnownSchema is an entry in the registry of shared types: component schemas
+// whose properties match its signature are imported as its type. See
+// ConvertOptions.RecognizeKnownSchemas.
+type KnownSchema = internal.KnownSchema
+
+// DefaultKnownSchemas returns the registry used when ConvertOptions.KnownSchemas
+// is nil: google.type.Money, Date, TimeOfDay, LatLng and Fraction.
+func DefaultKnownSchemas() []KnownSchema {
+	return internal.DefaultKnownSchemas()
 }
 
 // Convert converts OpenAPI 3.x schemas (3.0, 3.1, 3.2) to Protocol Buffer 3 format.
@@ -164,10 +1201,25 @@ type ConvertOptions struct {
 //   - the OpenAPI document is invalid or not version 3.x
 //   - any schema contains unsupported features
 func Convert(openapi []byte, opts ConvertOptions) (*ConvertResult, error) {
+	return ConvertContext(context.Background(), openapi, opts)
+}
+
+// ConvertContext is like Convert but stops early with an error wrapping
+// ctx.Err() when ctx is cancelled or its deadline expires. Cancellation is
+// checked before parsing and between schemas, so a long conversion of a very
+// large spec can be time-bounded. errors.Is(err, context.Canceled), or
+// context.DeadlineExceeded, tells a cancelled conversion from a failed one.
+func ConvertContext(ctx context.Context, openapi []byte, opts ConvertOptions) (_ *ConvertResult, err error) {
+	defer internal.Recover("Convert", &err)
+
 	if len(openapi) == 0 {
 		return nil, fmt.Errorf("openapi input cannot be empty")
 	}
 
+	if opts.VerifyDeterminism {
+		return convertVerified(ctx, openapi, opts)
+	}
+
 	if opts.PackageName == "" {
 		return nil, fmt.Errorf("package name cannot be empty")
 	}
@@ -176,11 +1228,122 @@ func Convert(openapi []byte, opts ConvertOptions) (*ConvertResult, error) {
 		return nil, fmt.Errorf("package path cannot be empty")
 	}
 
+	if err := opts.JSONTagCase.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := opts.OmitEmpty.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := opts.UnionStyle.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := opts.GoFormat.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := opts.ProtoStyle.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := opts.ProtoSyntax.validate(); err != nil {
+		return nil, err
+	}
+
+	protoFileOptions, err := fileOptions(opts.FileOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateExtraTags(opts.ExtraTags); err != nil {
+		return nil, err
+	}
+
+	if err := opts.ProtoFieldNaming.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := opts.SortMessages.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := opts.OnNameCollision.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := opts.NonASCIINames.validate(); err != nil {
+		return nil, err
+	}
+
+	if opts.AlsoGenerateGoStructs {
+		if opts.ProtoShims {
+			return nil, fmt.Errorf("AlsoGenerateGoStructs cannot be combined with ProtoShims")
+		}
+		if opts.GoPackagePath == "" || opts.GoPackagePath == opts.PackagePath {
+			return nil, fmt.Errorf("AlsoGenerateGoStructs requires a GoPackagePath different from PackagePath")
+		}
+	}
+
+	freeFormType, err := opts.FreeFormGoType.goType()
+	if err != nil {
+		return nil, err
+	}
+
+	opts.TypeMappings = opts.Formats.typeMappings(opts.TypeMappings)
+	if err := internal.ValidateTypeMappings(opts.TypeMappings); err != nil {
+		return nil, err
+	}
+
+	if err := internal.ValidateDecimalOptions(opts.Decimals); err != nil {
+		return nil, err
+	}
+
+	if err := internal.ValidateImportMappings(opts.ImportMappings, opts.ExcludeSchemas); err != nil {
+		return nil, err
+	}
+
+	if err := internal.ValidateKnownSchemas(opts.KnownSchemas); err != nil {
+		return nil, err
+	}
+
+	if opts.TypePrefix != "" && !internal.IsTypeIdentifier(opts.TypePrefix) {
+		return nil, fmt.Errorf("invalid TypePrefix %q: must be letters, digits and underscores, starting with a letter", opts.TypePrefix)
+	}
+
 	// Default GoPackagePath to PackagePath if not provided
 	if opts.GoPackagePath == "" {
 		opts.GoPackagePath = opts.PackagePath
 	}
 
+	if err := internal.Cancelled(ctx); err != nil {
+		return nil, err
+	}
+
+	var manifest map[string]string
+	var schemaNames []string
+	if opts.BuildManifest || opts.Previous != nil {
+		var cacheable bool
+		manifest, schemaNames, cacheable = convertManifest(openapi, opts)
+		if result := cachedResult(opts.Previous, manifest, cacheable); result != nil {
+			return result, nil
+		}
+	}
+
+	header := fileHeader(openapi, opts)
+
+	// allOf inheritance and inline oneOf properties become named unions
+	openapi, err = parser.RewriteUnions(openapi)
+	if err != nil {
+		return nil, err
+	}
+
+	openapi, err = parser.PrefixSchemas(openapi, opts.TypePrefix)
+	if err != nil {
+		return nil, err
+	}
+
 	doc, err := parser.ParseDocument(openapi)
 	if err != nil {
 		return nil, err
@@ -191,9 +1354,41 @@ func Convert(openapi []byte, opts ConvertOptions) (*ConvertResult, error) {
 		return nil, err
 	}
 
-	ctx := proto.NewContext()
-	ctx.FieldNumbers = opts.FieldNumbers
-	graph, err := proto.BuildMessages(schemas, ctx)
+	schemas, opaque, err := parser.ExcludeSchemas(schemas, opts.ExcludeSchemas)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas, imported, err := importSchemas(schemas, opaque, opts, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(opts.SchemaNames) > 0 {
+		schemas, err = parser.SelectSchemas(schemas, opts.SchemaNames)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	protoCtx := proto.NewContext()
+	protoCtx.Ctx = ctx
+	protoCtx.FieldNumbers = opts.FieldNumbers
+	protoCtx.ArrayWrapperSuffix = opts.ArrayWrapperSuffix
+	protoCtx.Concurrency = opts.Concurrency
+	protoCtx.Logger = internal.LoggerOrDiscard(opts.Logger)
+	protoCtx.CollectErrors = opts.CollectErrors
+	protoCtx.TypeMappings = opts.TypeMappings
+	protoCtx.WellKnownTypes = opts.WellKnownTypes
+	protoCtx.Decimals = opts.Decimals
+	protoCtx.NestedNameFunc = opts.NestedNameFunc
+	protoCtx.FieldNaming = string(opts.ProtoFieldNaming)
+	protoCtx.UseProto3Optional = opts.UseProto3Optional
+	protoCtx.Opaque = opaque
+	protoCtx.Imported = imported
+	protoCtx.NameCollision = string(opts.OnNameCollision)
+	protoCtx.NonASCIINames = string(opts.NonASCIINames)
+	graph, err := proto.BuildMessages(schemas, protoCtx)
 	if err != nil {
 		return nil, err
 	}
@@ -201,22 +1396,129 @@ func Convert(openapi []byte, opts ConvertOptions) (*ConvertResult, error) {
 	// Compute transitive closure to classify types
 	goTypes, protoTypes, reasons := graph.ComputeTransitiveClosure()
 
+	// Schemas already reported invalid would only repeat their errors from the Go side
+	for name := range protoCtx.FailedSchemas {
+		delete(goTypes, name)
+	}
+
+	for _, msg := range protoCtx.Messages {
+		if goTypes[msg.OriginalSchema] {
+			protoCtx.Logger.Debug(internal.LogSchemaSkipped, "schema", msg.OriginalSchema,
+				"reason", "generated as Go: "+reasons[msg.OriginalSchema])
+		}
+	}
+
 	// Build TypeMap using classification results
-	typeMap := buildTypeMap(goTypes, protoTypes, reasons)
+	typeMap := buildTypeMap(goTypes, protoTypes, reasons, protoCtx.Notes)
+	noteAccessModes(typeMap, schemas)
+	noteProtoTypes(typeMap, protoCtx.Messages, protoCtx.Enums)
+	noteDependencies(typeMap, graph)
+
+	// Go structs are built for Go-only types, or for every valid schema when
+	// AlsoGenerateGoStructs is set
+	structTypes := goTypes
+	if opts.AlsoGenerateGoStructs {
+		structTypes = make(map[string]bool, len(schemas))
+		for _, entry := range schemas {
+			if !protoCtx.FailedSchemas[entry.Name] {
+				structTypes[entry.Name] = true
+			}
+		}
+	}
+
+	// Build Go structs, and the proto messages shimmed to them
+	var goCtx *golang.GoContext
+	var shimMessages []*proto.ProtoMessage
+	if len(structTypes) > 0 {
+		goCtx = golang.NewGoContext(golang.ExtractPackageName(opts.GoPackagePath))
+		goCtx.Ctx = ctx
+		goCtx.Concurrency = opts.Concurrency
+		goCtx.Logger = protoCtx.Logger
+		goCtx.CollectErrors = opts.CollectErrors
+		goCtx.JSONTagCase = string(opts.JSONTagCase)
+		goCtx.OmitEmpty = string(opts.OmitEmpty)
+		goCtx.UnionStyle = string(opts.UnionStyle)
+		goCtx.Comments = opts.commentStyle(golang.DefaultCommentWidth)
+		goCtx.ExtraTags = opts.ExtraTags
+		goCtx.TypeMappings = opts.TypeMappings
+		goCtx.WellKnownTypes = opts.WellKnownTypes
+		goCtx.Decimals = opts.Decimals
+		goCtx.FreeFormType = freeFormType
+		goCtx.NestedNameFunc = opts.NestedNameFunc
+		goCtx.GoNameFunc = opts.GoNameFunc
+		goCtx.NonASCIINames = string(opts.NonASCIINames)
+		goCtx.Opaque = opaque
+		goCtx.Imported = imported
+		err := golang.BuildGoStructs(schemas, structTypes, graph, goCtx)
+		if err != nil {
+			return nil, err
+		}
+		noteExternalTypes(typeMap, goCtx.External)
+		noteGoTypes(typeMap, goCtx.Structs)
+
+		if opts.ProtoShims {
+			shimMessages = golang.BuildShims(goCtx, protoCtx.Messages, protoCtx.Enums, protoCtx.Tracker)
+			for _, msg := range shimMessages {
+				info := typeMap[msg.OriginalSchema]
+				info.Notes = append(info.Notes, fmt.Sprintf("converts to and from proto message %s", msg.Name))
+			}
+		}
+	}
 
 	// Generate proto for proto-only types
 	// Skip proto generation only if there are Go types but no proto types
 	var protoBytes []byte
-	if len(protoTypes) > 0 || len(goTypes) == 0 {
-		protoMessages := filterProtoMessages(ctx.Messages, protoTypes)
+	if len(protoTypes) > 0 || len(goTypes) == 0 || len(shimMessages) > 0 {
+		protoMessages := filterProtoMessages(protoCtx.Messages, protoTypes)
 		// Create new context with filtered messages
-		protoCtx := proto.NewContext()
-		protoCtx.Messages = protoMessages
-		protoCtx.Enums = ctx.Enums
-		protoCtx.Definitions = filterProtoDefinitions(ctx.Definitions, protoTypes)
-		protoCtx.UsesTimestamp = ctx.UsesTimestamp
+		filteredCtx := proto.NewContext()
+		filteredCtx.Messages = append(protoMessages, shimMessages...)
+		filteredCtx.Enums = protoCtx.Enums
+		filteredCtx.Definitions = filterProtoDefinitions(protoCtx.Definitions, protoTypes)
+		for _, msg := range shimMessages {
+			filteredCtx.Definitions = append(filteredCtx.Definitions, msg)
+		}
+		filteredCtx.Definitions = proto.SortDefinitions(filteredCtx.Definitions, string(opts.SortMessages))
+		filteredCtx.UsesTimestamp = protoCtx.UsesTimestamp
+		filteredCtx.Imports = protoCtx.Imports
+		filteredCtx.Logger = protoCtx.Logger
+		filteredCtx.Style = proto.Style{
+			Comments:            opts.commentStyle(0),
+			Indent:              opts.ProtoStyle.Indent,
+			OmitDefaultJSONName: opts.ProtoStyle.OmitDefaultJSONName,
+			BlankLines:          string(opts.ProtoStyle.BlankLines),
+			Editions:            opts.ProtoSyntax == ProtoSyntaxEditions,
+		}
+		filteredCtx.FileOptions = protoFileOptions
+
+		if services := doc.Extension("x-services"); services != nil {
+			if err := proto.BuildServices(services, filteredCtx, goTypes); err != nil {
+				return nil, err
+			}
+		}
+
+		protoBytes, err = proto.Generate(opts.PackageName, opts.PackagePath, filteredCtx)
+		if err != nil {
+			return nil, err
+		}
+		protoBytes = withHeader(header, protoBytes)
+	}
+
+	var descriptor protoreflect.FileDescriptor
+	if opts.BuildDescriptor && len(protoBytes) > 0 {
+		descriptor, err = proto.BuildDescriptor(ctx, defaultProtoFileName, protoBytes)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-		protoBytes, err = proto.Generate(opts.PackageName, opts.PackagePath, protoCtx)
+	var handlerBytes []byte
+	if opts.Handlers != nil {
+		handlerBytes, err = generateHandlers(doc, *opts.Handlers, opts.PackagePath, typeMap, protoCtx.Logger)
+		if err != nil {
+			return nil, err
+		}
+		handlerBytes, err = formatGo(handlerBytes, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -224,23 +1526,53 @@ func Convert(openapi []byte, opts ConvertOptions) (*ConvertResult, error) {
 
 	// Generate Go for Go-only types
 	var goBytes []byte
-	if len(goTypes) > 0 {
-		goCtx := golang.NewGoContext(golang.ExtractPackageName(opts.GoPackagePath))
-		err := golang.BuildGoStructs(schemas, goTypes, graph, goCtx)
+	var goErrs []error
+	if goCtx != nil {
+		goErrs = goCtx.Errors
+		goBytes, err = golang.GenerateGo(goCtx)
 		if err != nil {
 			return nil, err
 		}
-		goBytes, err = golang.GenerateGo(goCtx)
+		goBytes = withHeader(header, goBytes)
+		goBytes, err = formatGo(goBytes, opts)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	return &ConvertResult{
-		Protobuf: protoBytes,
-		Golang:   goBytes,
-		TypeMap:  typeMap,
-	}, nil
+	errs := append(protoCtx.Errors, goErrs...)
+	deprecations, err := collectDeprecations(schemas, typeMap)
+	if err != nil {
+		if !opts.CollectErrors {
+			return nil, err
+		}
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	shimmed := make(map[string]bool, len(shimMessages))
+	for _, msg := range shimMessages {
+		shimmed[msg.OriginalSchema] = true
+	}
+
+	result := &ConvertResult{
+		Protobuf:     protoBytes,
+		Golang:       goBytes,
+		TypeMap:      typeMap,
+		Deprecations: deprecations,
+		Warnings:     protoWarnings(protoCtx.Warnings, goTypes, shimmed),
+		Renames:      protoRenames(protoCtx.Renames, goTypes, shimmed),
+		Descriptor:   descriptor,
+		Handlers:     handlerBytes,
+		Manifest:     manifest,
+	}
+	if opts.Previous != nil {
+		result.Changes = compareResults(opts.Previous, result, schemaNames)
+	}
+	return result, nil
 }
 
 // ConvertToStruct converts all OpenAPI schemas to Go structs only, without
@@ -269,19 +1601,98 @@ func Convert(openapi []byte, opts ConvertOptions) (*ConvertResult, error) {
 //   - the OpenAPI document is invalid or not version 3.x
 //   - any schema contains unsupported features
 func ConvertToStruct(openapi []byte, opts ConvertOptions) (*StructResult, error) {
+	return ConvertToStructContext(context.Background(), openapi, opts)
+}
+
+// ConvertToStructContext is like ConvertToStruct but stops early with an error
+// wrapping ctx.Err() when ctx is cancelled or its deadline expires.
+func ConvertToStructContext(ctx context.Context, openapi []byte, opts ConvertOptions) (_ *StructResult, err error) {
+	defer internal.Recover("ConvertToStruct", &err)
+
 	if len(openapi) == 0 {
 		return nil, fmt.Errorf("openapi input cannot be empty")
 	}
 
+	if opts.VerifyDeterminism {
+		return convertToStructVerified(ctx, openapi, opts)
+	}
+
 	if opts.GoPackagePath == "" {
 		return nil, fmt.Errorf("GoPackagePath cannot be empty")
 	}
 
+	if err := opts.JSONTagCase.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := opts.OmitEmpty.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := opts.UnionStyle.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := opts.GoFormat.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := opts.NonASCIINames.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := validateExtraTags(opts.ExtraTags); err != nil {
+		return nil, err
+	}
+
+	freeFormType, err := opts.FreeFormGoType.goType()
+	if err != nil {
+		return nil, err
+	}
+
+	opts.TypeMappings = opts.Formats.typeMappings(opts.TypeMappings)
+	if err := internal.ValidateTypeMappings(opts.TypeMappings); err != nil {
+		return nil, err
+	}
+
+	if err := internal.ValidateDecimalOptions(opts.Decimals); err != nil {
+		return nil, err
+	}
+
+	if err := internal.ValidateImportMappings(opts.ImportMappings, opts.ExcludeSchemas); err != nil {
+		return nil, err
+	}
+
+	if err := internal.ValidateKnownSchemas(opts.KnownSchemas); err != nil {
+		return nil, err
+	}
+
+	if opts.TypePrefix != "" && !internal.IsTypeIdentifier(opts.TypePrefix) {
+		return nil, fmt.Errorf("invalid TypePrefix %q: must be letters, digits and underscores, starting with a letter", opts.TypePrefix)
+	}
+
 	// Default PackageName to "main" if empty (needed by BuildMessages)
 	if opts.PackageName == "" {
 		opts.PackageName = "main"
 	}
 
+	if err := internal.Cancelled(ctx); err != nil {
+		return nil, err
+	}
+
+	header := fileHeader(openapi, opts)
+
+	// allOf inheritance and inline oneOf properties become named unions
+	openapi, err = parser.RewriteUnions(openapi)
+	if err != nil {
+		return nil, err
+	}
+
+	openapi, err = parser.PrefixSchemas(openapi, opts.TypePrefix)
+	if err != nil {
+		return nil, err
+	}
+
 	doc, err := parser.ParseDocument(openapi)
 	if err != nil {
 		return nil, err
@@ -292,9 +1703,35 @@ func ConvertToStruct(openapi []byte, opts ConvertOptions) (*StructResult, error)
 		return nil, err
 	}
 
+	schemas, opaque, err := parser.ExcludeSchemas(schemas, opts.ExcludeSchemas)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas, imported, err := importSchemas(schemas, opaque, opts, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(opts.SchemaNames) > 0 {
+		schemas, err = parser.SelectSchemas(schemas, opts.SchemaNames)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Build dependency graph for schema validation and discriminator support
-	ctx := proto.NewContext()
-	graph, err := proto.BuildMessages(schemas, ctx)
+	protoCtx := proto.NewContext()
+	protoCtx.Ctx = ctx
+	protoCtx.Concurrency = opts.Concurrency
+	protoCtx.CollectErrors = opts.CollectErrors
+	protoCtx.TypeMappings = opts.TypeMappings
+	protoCtx.WellKnownTypes = opts.WellKnownTypes
+	protoCtx.Decimals = opts.Decimals
+	protoCtx.NestedNameFunc = opts.NestedNameFunc
+	protoCtx.Opaque = opaque
+	protoCtx.Imported = imported
+	graph, err := proto.BuildMessages(schemas, protoCtx)
 	if err != nil {
 		return nil, err
 	}
@@ -302,35 +1739,125 @@ func ConvertToStruct(openapi []byte, opts ConvertOptions) (*StructResult, error)
 	// Compute transitive closure to get reasons map for TypeMap
 	_, _, reasons := graph.ComputeTransitiveClosure()
 
-	// Mark ALL schemas for Go generation (not filtered by transitive closure)
+	// Mark ALL schemas for Go generation (not filtered by transitive closure),
+	// except those already reported invalid
 	goTypes := make(map[string]bool)
 	for _, schema := range schemas {
-		goTypes[schema.Name] = true
+		goTypes[schema.Name] = !protoCtx.FailedSchemas[schema.Name]
 	}
 
 	// Generate Go structs for all schemas
 	goCtx := golang.NewGoContext(golang.ExtractPackageName(opts.GoPackagePath))
+	goCtx.Ctx = ctx
+	goCtx.Concurrency = opts.Concurrency
+	goCtx.Logger = internal.LoggerOrDiscard(opts.Logger)
+	goCtx.CollectErrors = opts.CollectErrors
+	goCtx.JSONTagCase = string(opts.JSONTagCase)
+	goCtx.OmitEmpty = string(opts.OmitEmpty)
+	goCtx.UnionStyle = string(opts.UnionStyle)
+	goCtx.Comments = opts.commentStyle(golang.DefaultCommentWidth)
+	goCtx.ExtraTags = opts.ExtraTags
+	goCtx.TypeMappings = opts.TypeMappings
+	goCtx.WellKnownTypes = opts.WellKnownTypes
+	goCtx.Decimals = opts.Decimals
+	goCtx.FreeFormType = freeFormType
+	goCtx.NestedNameFunc = opts.NestedNameFunc
+	goCtx.GoNameFunc = opts.GoNameFunc
+	goCtx.NonASCIINames = string(opts.NonASCIINames)
+	goCtx.Opaque = opaque
+	goCtx.Imported = imported
 	err = golang.BuildGoStructs(schemas, goTypes, graph, goCtx)
 	if err != nil {
 		return nil, err
 	}
 
+	// Build TypeMap marking all schemas as Golang location
+	typeMap := buildStructTypeMap(schemas, reasons)
+	noteExternalTypes(typeMap, goCtx.External)
+	noteAccessModes(typeMap, schemas)
+	noteGoTypes(typeMap, goCtx.Structs)
+	noteDependencies(typeMap, graph)
+
+	errs := append(protoCtx.Errors, goCtx.Errors...)
+	deprecations, err := collectDeprecations(schemas, typeMap)
+	if err != nil {
+		if !opts.CollectErrors {
+			return nil, err
+		}
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
 	goBytes, err := golang.GenerateGo(goCtx)
 	if err != nil {
 		return nil, err
 	}
-
-	// Build TypeMap marking all schemas as Golang location
-	typeMap := buildStructTypeMap(schemas, reasons)
+	goBytes = withHeader(header, goBytes)
+	goBytes, err = formatGo(goBytes, opts)
+	if err != nil {
+		return nil, err
+	}
 
 	return &StructResult{
-		Golang:  goBytes,
-		TypeMap: typeMap,
+		Golang:       goBytes,
+		TypeMap:      typeMap,
+		Deprecations: deprecations,
 	}, nil
 }
 
+// importSchemas removes the schemas whose types are generated from another
+// spec, adding them to opaque so no schema depends on them, and returns the
+// mappings references to them use: opts.ImportMappings, then the schemas
+// setting x-proto-import or, with opts.RecognizeKnownSchemas, matching an entry
+// of opts.KnownSchemas. goOnly keeps recognised schemas without a Go type,
+// which are generated as structs as before.
+func importSchemas(schemas []*parser.SchemaEntry, opaque map[string]bool, opts ConvertOptions, goOnly bool) ([]*parser.SchemaEntry, map[string]ImportMapping, error) {
+	known := opts.KnownSchemas
+	if known == nil {
+		known = DefaultKnownSchemas()
+	}
+
+	mappings := make(map[string]ImportMapping, len(opts.ImportMappings))
+	for name, mapping := range opts.ImportMappings {
+		mappings[name] = mapping
+	}
+	for _, entry := range schemas {
+		if _, ok := mappings[entry.Name]; ok {
+			continue
+		}
+		mapping, found, err := internal.KnownImport(entry.Proxy.Schema(), known, opts.RecognizeKnownSchemas)
+		if err != nil {
+			return nil, nil, internal.SchemaError(entry.Name, err.Error())
+		}
+		if found && (!goOnly || mapping.Go != "") {
+			mappings[entry.Name] = mapping
+		}
+	}
+	if len(mappings) == 0 {
+		return schemas, nil, nil
+	}
+
+	names := make([]string, 0, len(mappings))
+	for name := range mappings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	schemas, err := parser.ImportSchemas(schemas, names)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, name := range names {
+		opaque[name] = true
+	}
+	return schemas, mappings, nil
+}
+
 // buildTypeMap creates a TypeMap from dependency graph classification results
-func buildTypeMap(goTypes, protoTypes map[string]bool, reasons map[string]string) map[string]*TypeInfo {
+func buildTypeMap(goTypes, protoTypes map[string]bool, reasons map[string]string, notes map[string][]string) map[string]*TypeInfo {
 	typeMap := make(map[string]*TypeInfo)
 
 	// Add Go types
@@ -346,6 +1873,7 @@ func buildTypeMap(goTypes, protoTypes map[string]bool, reasons map[string]string
 		typeMap[name] = &TypeInfo{
 			Location: TypeLocationProto,
 			Reason:   "",
+			Notes:    notes[name],
 		}
 	}
 
@@ -370,6 +1898,77 @@ func buildStructTypeMap(schemas []*parser.SchemaEntry, reasons map[string]string
 	return typeMap
 }
 
+// noteExternalTypes notes the Go types that replace schemas carrying x-go-type
+func noteExternalTypes(typeMap map[string]*TypeInfo, external map[string]string) {
+	for name, goType := range external {
+		if info := typeMap[name]; info != nil {
+			info.Notes = append(info.Notes, fmt.Sprintf("uses existing Go type %s (x-go-type); no struct is generated", goType))
+		}
+	}
+}
+
+// noteProtoTypes records the message or enum generated for each proto-located schema
+func noteProtoTypes(typeMap map[string]*TypeInfo, messages []*proto.ProtoMessage, enums []*proto.ProtoEnum) {
+	for _, msg := range messages {
+		if info := typeMap[msg.OriginalSchema]; info != nil && info.Location == TypeLocationProto {
+			info.GeneratedName = msg.Name
+			info.FieldCount = len(msg.Fields)
+		}
+	}
+	for _, enum := range enums {
+		if info := typeMap[enum.OriginalSchema]; info != nil && info.Location == TypeLocationProto {
+			info.GeneratedName = enum.Name
+			info.FieldCount = len(enum.Values)
+		}
+	}
+}
+
+// noteGoTypes records the struct or enum generated for each Go-located schema, and notes
+// the structs AlsoGenerateGoStructs adds for proto-located ones
+func noteGoTypes(typeMap map[string]*TypeInfo, structs []*golang.GoStruct) {
+	for _, s := range structs {
+		info := typeMap[s.OriginalSchema]
+		switch {
+		case info == nil:
+		case info.Location == TypeLocationGolang:
+			info.GeneratedName = s.Name
+			info.FieldCount = len(s.Fields)
+			if s.EnumType != "" {
+				info.FieldCount = len(s.EnumValues)
+			}
+		default:
+			info.Notes = append(info.Notes, fmt.Sprintf("also generated as Go struct %s", s.Name))
+		}
+	}
+}
+
+// noteDependencies records the schemas each schema references directly
+func noteDependencies(typeMap map[string]*TypeInfo, graph *internal.DependencyGraph) {
+	for name, info := range typeMap {
+		info.Dependencies = graph.Dependencies(name)
+	}
+}
+
+// noteAccessModes records the readOnly and writeOnly properties of each schema
+func noteAccessModes(typeMap map[string]*TypeInfo, schemas []*parser.SchemaEntry) {
+	for _, entry := range schemas {
+		info := typeMap[entry.Name]
+		s := entry.Proxy.Schema()
+		if info == nil || s == nil || s.Properties == nil {
+			continue
+		}
+		for name, proxy := range s.Properties.FromOldest() {
+			prop := proxy.Schema()
+			if internal.IsReadOnly(prop) {
+				info.ReadOnly = append(info.ReadOnly, name)
+			}
+			if internal.IsWriteOnly(prop) {
+				info.WriteOnly = append(info.WriteOnly, name)
+			}
+		}
+	}
+}
+
 // filterProtoMessages removes messages marked as Go-only from proto output
 func filterProtoMessages(messages []*proto.ProtoMessage, protoTypes map[string]bool) []*proto.ProtoMessage {
 	filtered := make([]*proto.ProtoMessage, 0, len(protoTypes))
@@ -405,6 +2004,15 @@ func filterProtoDefinitions(definitions []interface{}, protoTypes map[string]boo
 
 // ConvertToExamples generates JSON examples from OpenAPI schemas
 func ConvertToExamples(openapi []byte, opts ExampleOptions) (*ExampleResult, error) {
+	return ConvertToExamplesContext(context.Background(), openapi, opts)
+}
+
+// ConvertToExamplesContext is like ConvertToExamples but stops early with an
+// error wrapping ctx.Err() when ctx is cancelled or its deadline expires.
+// Cancellation is checked before parsing and between schemas.
+func ConvertToExamplesContext(ctx context.Context, openapi []byte, opts ExampleOptions) (_ *ExampleResult, err error) {
+	defer internal.Recover("ConvertToExamples", &err)
+
 	if len(openapi) == 0 {
 		return nil, fmt.Errorf("openapi input cannot be empty")
 	}
@@ -417,10 +2025,59 @@ func ConvertToExamples(openapi []byte, opts ExampleOptions) (*ExampleResult, err
 		return nil, fmt.Errorf("must specify SchemaNames or set IncludeAll")
 	}
 
+	if err := opts.JSONTagCase.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := example.ValidateOverrides(opts.FieldOverrides); err != nil {
+		return nil, err
+	}
+
+	if err := opts.OverridePrecedence.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := opts.Mode.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := example.ValidateProviders(opts.Providers); err != nil {
+		return nil, err
+	}
+	opts.Providers = opts.Formats.providers(opts.Providers)
+
+	if err := opts.Locale.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := opts.CircularStrategy.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := opts.RequiredDepth.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := opts.ArrayItems.validate(opts.ArrayItemCount); err != nil {
+		return nil, err
+	}
+
+	if opts.SelfValidate && opts.Mode == ExampleModeInvalid {
+		return nil, fmt.Errorf("SelfValidate cannot be combined with Mode invalid, whose examples are invalid by design")
+	}
+
+	if opts.SelfValidate && opts.JSONTagCase != "" && opts.JSONTagCase != JSONTagCasePreserve {
+		return nil, fmt.Errorf("SelfValidate cannot be combined with JSONTagCase %s, which renames schema properties", opts.JSONTagCase)
+	}
+
 	if opts.Seed == 0 {
 		opts.Seed = time.Now().UnixNano()
 	}
 
+	if err := internal.Cancelled(ctx); err != nil {
+		return nil, err
+	}
+
 	doc, err := parser.ParseDocument(openapi)
 	if err != nil {
 		return nil, err
@@ -436,34 +2093,118 @@ func ConvertToExamples(openapi []byte, opts ExampleOptions) (*ExampleResult, err
 		schemaNames = nil
 	}
 
-	examples, err := example.GenerateExamples(schemas, schemaNames, opts.MaxDepth, opts.Seed, opts.FieldOverrides)
+	generated, err := example.Generate(schemas, schemaNames, example.Options{
+		FieldOverrides:     opts.FieldOverrides,
+		OverridePrecedence: string(opts.OverridePrecedence),
+		ValueFunc:          opts.ValueFunc,
+		Mode:               string(opts.Mode),
+		MaxDepth:           opts.MaxDepth,
+		Concurrency:        opts.Concurrency,
+		Seed:               opts.Seed,
+		Ctx:                ctx,
+		Logger:             opts.Logger,
+		JSONCase:           string(opts.JSONTagCase),
+		Providers:          opts.Providers,
+		Locale:             string(opts.Locale),
+		BinaryDataURLs:     opts.BinaryDataURLs,
+		CircularStrategy:   string(opts.CircularStrategy),
+		RequiredDepth:      string(opts.RequiredDepth),
+		ArrayItems:         string(opts.ArrayItems),
+		ArrayItemCount:     opts.ArrayItemCount,
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	if opts.SelfValidate {
+		if err := validateGenerated(doc, schemas, generated.Examples, opts.Formats.validators()); err != nil {
+			return nil, err
+		}
+	}
+
+	metadata := make(map[string]*ExampleMetadata, len(generated.Truncations))
+	for name, truncations := range generated.Truncations {
+		metadata[name] = &ExampleMetadata{Truncations: truncations}
+	}
+
 	return &ExampleResult{
-		Examples: examples,
+		Examples: generated.Examples,
+		Metadata: metadata,
 	}, nil
 }
 
+// validateGenerated validates each generated example against its schema,
+// reporting the first schema, in document order, whose example is invalid,
+// with its violations sorted by field and message
+func validateGenerated(doc *parser.Document, schemas []*parser.SchemaEntry, examples map[string]json.RawMessage, formats map[string]func(string) error) error {
+	validator := validate.NewSchemaValidator(formats)
+	isOpenAPI30 := strings.HasPrefix(doc.Version(), "3.0")
+
+	for _, entry := range schemas {
+		example, ok := examples[entry.Name]
+		if !ok {
+			continue
+		}
+		schema := entry.Proxy.Schema()
+		if schema == nil {
+			continue
+		}
+
+		violations := validate.ValidateJSON(validator, schema, example, isOpenAPI30)
+		if len(violations) == 0 {
+			continue
+		}
+		// The validator reports violations in no stable order
+		sort.Slice(violations, func(i, j int) bool {
+			if violations[i].Field != violations[j].Field {
+				return violations[i].Field < violations[j].Field
+			}
+			return violations[i].Message < violations[j].Message
+		})
+		problems := make([]string, len(violations))
+		for i, violation := range violations {
+			problems[i] = violation.Message
+			if violation.Field != "" {
+				problems[i] = violation.Field + ": " + violation.Message
+			}
+		}
+		return fmt.Errorf("generated example for '%s' is invalid: %s", entry.Name, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
 // ValidateExamples validates examples in OpenAPI spec against schemas.
 // It validates the 'example' and 'examples' fields in Schema Objects under components/schemas.
 //
 // For schemas with the 'examples' map, all entries are validated.
 // If both 'example' and 'examples' exist on the same schema, both are validated.
 //
+// With IncludeAll, the 'example' and 'examples' fields of parameters and of
+// request body, response and parameter media types under paths are validated
+// too, reported in ValidationResult.Examples. Entries of components/examples
+// are validated against the schema of each place that references them.
+//
 // Parameters:
 //   - openapi: OpenAPI specification bytes (YAML or JSON)
 //   - opts: Validation options (SchemaNames to filter specific schemas, or IncludeAll to validate all)
 //
 // Returns:
-//   - ValidationResult containing per-schema validation results with errors and warnings
+//   - ValidationResult containing per-schema and per-media-type validation results with
+//     errors and warnings, each pointing at its example with a JSON pointer
 //
 // Returns an error if:
 //   - openapi is empty
 //   - opts.IncludeAll is false and opts.SchemaNames is empty
 //   - the OpenAPI document is invalid or not version 3.x
 func ValidateExamples(openapi []byte, opts ValidateOptions) (*ValidationResult, error) {
+	return ValidateExamplesContext(context.Background(), openapi, opts)
+}
+
+// ValidateExamplesContext is like ValidateExamples but stops early with an
+// error wrapping ctx.Err() when ctx is cancelled or its deadline expires.
+func ValidateExamplesContext(ctx context.Context, openapi []byte, opts ValidateOptions) (_ *ValidationResult, err error) {
+	defer internal.Recover("ValidateExamples", &err)
+
 	if len(openapi) == 0 {
 		return nil, fmt.Errorf("openapi input cannot be empty")
 	}
@@ -472,39 +2213,53 @@ func ValidateExamples(openapi []byte, opts ValidateOptions) (*ValidationResult,
 		return nil, fmt.Errorf("must specify SchemaNames or set IncludeAll")
 	}
 
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
 	schemaNames := opts.SchemaNames
 	if opts.IncludeAll {
 		schemaNames = nil
 	}
 
-	internalResult, err := validate.ValidateExamples(openapi, schemaNames)
+	internalResult, err := validate.ValidateExamples(ctx, openapi, schemaNames, opts.Formats.validators())
 	if err != nil {
 		return nil, err
 	}
 
-	// Convert internal result to public API types
-	result := &ValidationResult{
-		Schemas: make(map[string]*SchemaValidationResult),
-	}
+	return &ValidationResult{
+		Schemas:  validationResults(internalResult.Schemas, opts),
+		Examples: validationResults(internalResult.Examples, opts),
+	}, nil
+}
 
-	for schemaName, schemaValidation := range internalResult.Schemas {
+// validationResults converts internal validation results to public API types,
+// applying the severities and threshold of opts
+func validationResults(internalResults map[string]*validate.SchemaValidation, opts ValidateOptions) map[string]*SchemaValidationResult {
+	results := make(map[string]*SchemaValidationResult, len(internalResults))
+	for key, schemaValidation := range internalResults {
+		valid := true
 		issues := make([]ValidationIssue, len(schemaValidation.Issues))
 		for i, issue := range schemaValidation.Issues {
 			issues[i] = ValidationIssue{
-				Severity:     IssueSeverity(issue.Severity),
+				Severity:     opts.severity(issue.Class, IssueSeverity(issue.Severity)),
 				ExampleField: issue.ExampleField,
+				Path:         issue.Path,
+				Class:        issue.Class,
 				Message:      issue.Message,
 				Line:         issue.Line,
 			}
+			if opts.fails(issue.Class, issues[i].Severity) {
+				valid = false
+			}
 		}
 
-		result.Schemas[schemaName] = &SchemaValidationResult{
+		results[key] = &SchemaValidationResult{
 			SchemaPath:  schemaValidation.SchemaPath,
 			HasExamples: schemaValidation.HasExamples,
-			Valid:       schemaValidation.Valid,
+			Valid:       valid,
 			Issues:      issues,
 		}
 	}
-
-	return result, nil
+	return results
 }
@@ -0,0 +1,174 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertAllOfInheritance(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		mapping  string
+		expected []string
+	}{
+		{
+			name: "discriminator mapping",
+			mapping: `        mapping:
+          canine: '#/components/schemas/Dog'
+          feline: '#/components/schemas/Cat'
+`,
+			expected: []string{
+				"// A pet.\ntype Pet struct {\n\tDog *Dog `json:\"-\"`\n\tCat *Cat `json:\"-\"`\n}\n",
+				"\tcase \"canine\":\n\t\tu.Dog = &Dog{}\n",
+			},
+		},
+		{
+			name: "subclasses in document order",
+			expected: []string{
+				"// A pet.\ntype Pet struct {\n\tDog *Dog `json:\"-\"`\n\tCat *Cat `json:\"-\"`\n}\n",
+				"\tcase \"dog\":\n\t\tu.Dog = &Dog{}\n",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			given := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      description: A pet.
+      type: object
+      required: [petType]
+      properties:
+        petType:
+          type: string
+        name:
+          type: string
+      discriminator:
+        propertyName: petType
+` + test.mapping + `    Dog:
+      description: A dog.
+      allOf:
+        - $ref: '#/components/schemas/Pet'
+        - type: object
+          properties:
+            bark:
+              type: string
+    Cat:
+      allOf:
+        - $ref: '#/components/schemas/Pet'
+        - type: object
+          properties:
+            name:
+              type: string
+              description: Cats name themselves.
+            meow:
+              type: string
+    Owner:
+      type: object
+      properties:
+        pet:
+          $ref: '#/components/schemas/Pet'
+    Address:
+      type: object
+      properties:
+        street:
+          type: string
+`
+			result, err := schema.Convert([]byte(given), schema.ConvertOptions{
+				GoFormat:      schema.GoFormatNone,
+				PackageName:   "testpkg",
+				PackagePath:   "github.com/example/proto",
+				GoPackagePath: "github.com/example/types",
+			})
+			require.NoError(t, err)
+
+			golang := string(result.Golang)
+			for _, expected := range test.expected {
+				assert.Contains(t, golang, expected)
+			}
+			assert.Contains(t, golang, "// A dog.\ntype Dog struct {\n\tPetType string `json:\"petType\"`\n\tName string `json:\"name\"`\n\tBark string `json:\"bark\"`\n}\n")
+			assert.Contains(t, golang, "type Cat struct {\n\tPetType string `json:\"petType\"`\n\t// Cats name themselves.\n\tName string `json:\"name\"`\n\tMeow string `json:\"meow\"`\n}\n")
+			assert.Contains(t, golang, "\tPet *Pet `json:\"pet\"`\n")
+			assert.Contains(t, string(result.Protobuf), "message Address {")
+
+			assert.Equal(t, "contains oneOf", result.TypeMap["Pet"].Reason)
+			assert.Equal(t, "variant of union type Pet", result.TypeMap["Dog"].Reason)
+			assert.Equal(t, []string{"Dog", "Cat"}, result.TypeMap["Pet"].Dependencies)
+		})
+	}
+}
+
+func TestConvertToStructAllOfInheritance(t *testing.T) {
+	given := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Shape:
+      type: object
+      properties:
+        kind:
+          type: string
+      discriminator:
+        propertyName: kind
+    Circle:
+      allOf:
+        - $ref: '#/components/schemas/Shape'
+        - type: object
+          required: [radius]
+          properties:
+            radius:
+              type: number
+    Square:
+      allOf:
+        - $ref: '#/components/schemas/Shape'
+        - type: object
+          properties:
+            side:
+              type: number
+`
+	result, err := schema.ConvertToStruct([]byte(given), schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
+		GoPackagePath: "github.com/example/types",
+		OmitEmpty:     schema.OmitEmptyOptional,
+	})
+	require.NoError(t, err)
+
+	golang := string(result.Golang)
+	assert.Contains(t, golang, "type Shape struct {\n\tCircle *Circle `json:\"-\"`\n\tSquare *Square `json:\"-\"`\n}\n")
+	assert.Contains(t, golang, "type Circle struct {\n\tKind string `json:\"kind,omitempty\"`\n\tRadius float64 `json:\"radius\"`\n}\n")
+}
+
+func TestConvertAllOfWithoutDiscriminator(t *testing.T) {
+	given := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Base:
+      type: object
+      properties:
+        id:
+          type: string
+    Derived:
+      allOf:
+        - $ref: '#/components/schemas/Base'
+        - type: object
+          properties:
+            name:
+              type: string
+`
+	_, err := schema.Convert([]byte(given), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto",
+	})
+	require.ErrorContains(t, err, "schema 'Derived': uses 'allOf' which is not supported")
+}
@@ -0,0 +1,117 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const alsoGoStructsSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Owner:
+      type: object
+      properties:
+        pet:
+          $ref: '#/components/schemas/Pet'
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+      discriminator:
+        propertyName: petType
+    Dog:
+      type: object
+      properties:
+        petType:
+          type: string
+    Cat:
+      type: object
+      properties:
+        petType:
+          type: string
+    Order:
+      type: object
+      x-proto-name: PurchaseOrder
+      properties:
+        order_id:
+          type: string
+        address:
+          $ref: '#/components/schemas/Address'
+    Address:
+      type: object
+      properties:
+        street:
+          type: string
+`
+
+func TestConvertAlsoGenerateGoStructs(t *testing.T) {
+	result, err := schema.Convert([]byte(alsoGoStructsSpec), schema.ConvertOptions{
+		GoFormat:              schema.GoFormatNone,
+		PackageName:           "testpkg",
+		PackagePath:           "github.com/example/proto/v1",
+		GoPackagePath:         "github.com/example/types/v1",
+		AlsoGenerateGoStructs: true,
+	})
+	require.NoError(t, err)
+
+	// Proto output is unchanged
+	proto := string(result.Protobuf)
+	assert.Contains(t, proto, "message PurchaseOrder {")
+	assert.Contains(t, proto, "message Address {")
+	assert.Contains(t, proto, `string order_id = 1 [json_name = "order_id"];`)
+
+	// Go output gains structs for the proto-located types, referenced natively
+	golang := string(result.Golang)
+	assert.Contains(t, golang, "type Owner struct {")
+	assert.Contains(t, golang, "type Pet struct {")
+	assert.Contains(t, golang, "type Order struct {")
+	assert.Contains(t, golang, "OrderID string `json:\"order_id\"`")
+	assert.Contains(t, golang, "Address *Address `json:\"address\"`")
+	assert.Contains(t, golang, "type Address struct {")
+
+	order := result.TypeMap["Order"]
+	assert.Equal(t, schema.TypeLocationProto, order.Location)
+	assert.Equal(t, "PurchaseOrder", order.GeneratedName)
+	assert.Contains(t, order.Notes, "also generated as Go struct Order")
+	assert.Equal(t, schema.TypeLocationGolang, result.TypeMap["Owner"].Location)
+}
+
+func TestConvertAlsoGenerateGoStructsInvalid(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		opts    schema.ConvertOptions
+		wantErr string
+	}{
+		{
+			name: "same package",
+			opts: schema.ConvertOptions{
+				PackageName:           "testpkg",
+				PackagePath:           "github.com/example/proto/v1",
+				AlsoGenerateGoStructs: true,
+			},
+			wantErr: "AlsoGenerateGoStructs requires a GoPackagePath different from PackagePath",
+		},
+		{
+			name: "with shims",
+			opts: schema.ConvertOptions{
+				PackageName:           "testpkg",
+				PackagePath:           "github.com/example/proto/v1",
+				GoPackagePath:         "github.com/example/types/v1",
+				AlsoGenerateGoStructs: true,
+				ProtoShims:            true,
+			},
+			wantErr: "AlsoGenerateGoStructs cannot be combined with ProtoShims",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := schema.Convert([]byte(alsoGoStructsSpec), test.opts)
+			require.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const arrayItemsSpec = `openapi: 3.1.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Cart:
+      type: object
+      properties:
+        ranged:
+          type: array
+          minItems: 2
+          maxItems: 5
+          items:
+            type: integer
+        open:
+          type: array
+          items:
+            type: integer
+        capped:
+          type: array
+          maxItems: 2
+          items:
+            type: integer
+`
+
+func TestConvertToExamplesArrayItems(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		items schema.ArrayItems
+		count int
+		want  map[string]int
+	}{
+		{
+			name:  "min",
+			items: schema.ArrayItemsMin,
+			want:  map[string]int{"ranged": 2, "open": 1, "capped": 1},
+		},
+		{
+			name:  "max",
+			items: schema.ArrayItemsMax,
+			want:  map[string]int{"ranged": 5, "open": 1, "capped": 2},
+		},
+		{
+			name:  "fixed",
+			items: schema.ArrayItemsFixed,
+			count: 3,
+			want:  map[string]int{"ranged": 3, "open": 3, "capped": 2},
+		},
+		{
+			name:  "fixed below minItems",
+			items: schema.ArrayItemsFixed,
+			want:  map[string]int{"ranged": 2, "open": 0, "capped": 0},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := schema.ConvertToExamples([]byte(arrayItemsSpec), schema.ExampleOptions{
+				SchemaNames:    []string{"Cart"},
+				Seed:           1,
+				SelfValidate:   true,
+				ArrayItems:     test.items,
+				ArrayItemCount: test.count,
+			})
+			require.NoError(t, err)
+
+			var cart map[string][]int
+			require.NoError(t, json.Unmarshal(result.Examples["Cart"], &cart))
+			got := make(map[string]int, len(cart))
+			for field, items := range cart {
+				got[field] = len(items)
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestConvertToExamplesArrayItemsRandom(t *testing.T) {
+	counts := make(map[int]bool)
+	for seed := int64(1); seed <= 20; seed++ {
+		result, err := schema.ConvertToExamples([]byte(arrayItemsSpec), schema.ExampleOptions{
+			SchemaNames: []string{"Cart"},
+			Seed:        seed,
+			ArrayItems:  schema.ArrayItemsRandom,
+		})
+		require.NoError(t, err)
+
+		var cart map[string][]int
+		require.NoError(t, json.Unmarshal(result.Examples["Cart"], &cart))
+		require.GreaterOrEqual(t, len(cart["ranged"]), 2)
+		require.LessOrEqual(t, len(cart["ranged"]), 5)
+		counts[len(cart["ranged"])] = true
+	}
+	assert.Greater(t, len(counts), 1)
+}
+
+func TestConvertToExamplesArrayItemsInvalid(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		items   schema.ArrayItems
+		count   int
+		wantErr string
+	}{
+		{
+			name:    "unknown",
+			items:   "most",
+			wantErr: `unknown ArrayItems "most": must be random, min, max or fixed`,
+		},
+		{
+			name:    "count without fixed",
+			items:   schema.ArrayItemsMax,
+			count:   3,
+			wantErr: "ArrayItemCount requires ArrayItems fixed",
+		},
+		{
+			name:    "negative count",
+			items:   schema.ArrayItemsFixed,
+			count:   -1,
+			wantErr: "ArrayItemCount must not be negative, got -1",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := schema.ConvertToExamples([]byte(arrayItemsSpec), schema.ExampleOptions{
+				IncludeAll:     true,
+				ArrayItems:     test.items,
+				ArrayItemCount: test.count,
+			})
+			require.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
@@ -0,0 +1,358 @@
+package schema_test
+
+import (
+	"context"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const avroSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Order:
+      type: object
+      description: A placed order
+      required: [id, status, createdAt, items]
+      properties:
+        id:
+          type: string
+          format: uuid
+        status:
+          $ref: '#/components/schemas/Status'
+        createdAt:
+          type: string
+          format: date-time
+        quantity:
+          type: integer
+        total:
+          type: integer
+          format: int64
+        weight:
+          type: number
+          format: float
+        price:
+          type: number
+          description: Unit price
+        gift:
+          type: boolean
+        note:
+          type: string
+          nullable: true
+        items:
+          type: array
+          items:
+            $ref: '#/components/schemas/Item'
+        labels:
+          type: object
+          additionalProperties:
+            type: string
+        shipping:
+          type: object
+          properties:
+            status:
+              $ref: '#/components/schemas/Status'
+        payment:
+          $ref: '#/components/schemas/Payment'
+    Item:
+      type: object
+      required: [sku]
+      properties:
+        sku:
+          type: string
+        parent:
+          $ref: '#/components/schemas/Item'
+    Status:
+      type: string
+      enum: [pending, shipped]
+    Payment:
+      oneOf:
+        - $ref: '#/components/schemas/Card'
+        - type: string
+      discriminator:
+        propertyName: kind
+    Card:
+      type: object
+      properties:
+        number:
+          type: string
+          format: byte
+    Amount:
+      type: integer
+`
+
+func TestConvertToAvro(t *testing.T) {
+	result, err := schema.ConvertToAvro([]byte(avroSpec), schema.AvroOptions{Namespace: "com.example.events"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Order", "Item", "Status", "Payment", "Card"}, result.Names)
+	assert.Len(t, result.Schemas, 5)
+	assert.Equal(t, `{
+  "type": "record",
+  "name": "Order",
+  "namespace": "com.example.events",
+  "doc": "A placed order",
+  "fields": [
+    {
+      "name": "id",
+      "type": {
+        "type": "string",
+        "logicalType": "uuid"
+      }
+    },
+    {
+      "name": "status",
+      "type": {
+        "type": "enum",
+        "name": "Status",
+        "symbols": [
+          "pending",
+          "shipped"
+        ]
+      }
+    },
+    {
+      "name": "createdAt",
+      "type": {
+        "type": "long",
+        "logicalType": "timestamp-millis"
+      }
+    },
+    {
+      "name": "quantity",
+      "type": [
+        "null",
+        "int"
+      ],
+      "default": null
+    },
+    {
+      "name": "total",
+      "type": [
+        "null",
+        "long"
+      ],
+      "default": null
+    },
+    {
+      "name": "weight",
+      "type": [
+        "null",
+        "float"
+      ],
+      "default": null
+    },
+    {
+      "name": "price",
+      "type": [
+        "null",
+        "double"
+      ],
+      "default": null,
+      "doc": "Unit price"
+    },
+    {
+      "name": "gift",
+      "type": [
+        "null",
+        "boolean"
+      ],
+      "default": null
+    },
+    {
+      "name": "note",
+      "type": [
+        "null",
+        "string"
+      ],
+      "default": null
+    },
+    {
+      "name": "items",
+      "type": {
+        "type": "array",
+        "items": {
+          "type": "record",
+          "name": "Item",
+          "fields": [
+            {
+              "name": "sku",
+              "type": "string"
+            },
+            {
+              "name": "parent",
+              "type": [
+                "null",
+                "Item"
+              ],
+              "default": null
+            }
+          ]
+        }
+      }
+    },
+    {
+      "name": "labels",
+      "type": [
+        "null",
+        {
+          "type": "map",
+          "values": "string"
+        }
+      ],
+      "default": null
+    },
+    {
+      "name": "shipping",
+      "type": [
+        "null",
+        {
+          "type": "record",
+          "name": "OrderShipping",
+          "fields": [
+            {
+              "name": "status",
+              "type": [
+                "null",
+                "Status"
+              ],
+              "default": null
+            }
+          ]
+        }
+      ],
+      "default": null
+    },
+    {
+      "name": "payment",
+      "type": [
+        "null",
+        {
+          "type": "record",
+          "name": "Card",
+          "fields": [
+            {
+              "name": "number",
+              "type": [
+                "null",
+                "bytes"
+              ],
+              "default": null
+            }
+          ]
+        },
+        "string"
+      ],
+      "default": null
+    }
+  ]
+}
+`, string(result.Schemas["Order"]))
+
+	assert.Equal(t, `[
+  {
+    "type": "record",
+    "name": "Card",
+    "namespace": "com.example.events",
+    "fields": [
+      {
+        "name": "number",
+        "type": [
+          "null",
+          "bytes"
+        ],
+        "default": null
+      }
+    ]
+  },
+  "string"
+]
+`, string(result.Schemas["Payment"]))
+}
+
+func TestConvertToAvroSchemaNames(t *testing.T) {
+	result, err := schema.ConvertToAvro([]byte(avroSpec), schema.AvroOptions{SchemaNames: []string{"Status"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Status"}, result.Names)
+	assert.Equal(t, "{\n  \"type\": \"enum\",\n  \"name\": \"Status\",\n  \"symbols\": [\n    \"pending\",\n    \"shipped\"\n  ]\n}\n", string(result.Schemas["Status"]))
+}
+
+func TestConvertToAvroErrors(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		schemas string
+		opts    schema.AvroOptions
+		wantErr string
+	}{
+		{
+			name:    "invalid namespace",
+			schemas: "    A:\n      type: string\n",
+			opts:    schema.AvroOptions{Namespace: "com..example"},
+			wantErr: `invalid Namespace "com..example": must be dot-separated names of letters, digits and underscores`,
+		},
+		{
+			name:    "invalid field name",
+			schemas: "    A:\n      type: object\n      properties:\n        content-type:\n          type: string\n",
+			wantErr: "schema 'A': property 'content-type' is not a valid Avro field name",
+		},
+		{
+			name:    "invalid symbol",
+			schemas: "    A:\n      type: string\n      enum: [in-progress]\n",
+			wantErr: "schema 'A': enum value 'in-progress' is not a valid Avro symbol",
+		},
+		{
+			name:    "allOf",
+			schemas: "    A:\n      type: object\n      properties:\n        b:\n          allOf:\n            - type: string\n",
+			wantErr: "schema 'A': property 'b': allOf has no Avro equivalent",
+		},
+		{
+			name:    "free-form object",
+			schemas: "    A:\n      type: object\n      properties:\n        b:\n          type: object\n",
+			wantErr: "schema 'A': property 'b': objects without properties or an additionalProperties schema have no Avro equivalent",
+		},
+		{
+			name:    "several types",
+			schemas: "    A:\n      type: object\n      properties:\n        b:\n          type: [string, integer]\n",
+			wantErr: "schema 'A': property 'b': schemas of several types have no Avro equivalent; use oneOf",
+		},
+		{
+			name:    "duplicate union branch",
+			schemas: "    A:\n      oneOf:\n        - type: string\n        - type: string\n          format: email\n",
+			wantErr: "schema 'A': variant 1: union already has a 'string' branch",
+		},
+		{
+			name:    "inline union record",
+			schemas: "    A:\n      oneOf:\n        - type: object\n          properties:\n            b:\n              type: string\n",
+			wantErr: "schema 'A': variant 0: inline objects and enums cannot be union branches; move them to components/schemas",
+		},
+		{
+			name:    "circular array",
+			schemas: "    A:\n      type: object\n      properties:\n        tree:\n          $ref: '#/components/schemas/Tree'\n    Tree:\n      type: array\n      items:\n        $ref: '#/components/schemas/Tree'\n",
+			wantErr: "schema 'A': property 'tree': circular reference to 'Tree': only records can refer to themselves in Avro",
+		},
+		{
+			name:    "inline name collision",
+			schemas: "    A:\n      type: object\n      properties:\n        b:\n          type: object\n          properties:\n            c:\n              type: string\n    AB:\n      type: object\n      properties:\n        c:\n          type: string\n",
+			wantErr: "schema 'A': property 'b': inline type 'AB' has the name of a component schema",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			spec := "openapi: 3.1.0\ninfo:\n  title: Test API\n  version: 1.0.0\ncomponents:\n  schemas:\n" + test.schemas
+			_, err := schema.ConvertToAvro([]byte(spec), test.opts)
+			require.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
+
+func TestConvertToAvroContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := schema.ConvertToAvroContext(ctx, []byte(avroSpec), schema.AvroOptions{})
+	require.ErrorIs(t, err, context.Canceled)
+}
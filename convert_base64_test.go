@@ -0,0 +1,89 @@
+package schema_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const base64Spec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Upload:
+      type: object
+      properties:
+        checksum:
+          type: string
+          format: byte
+        thumbnail:
+          type: string
+          format: byte
+          minLength: 6
+          maxLength: 10
+        content:
+          type: string
+          format: binary
+`
+
+func TestConvertToExamplesBase64(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		dataURLs   bool
+		wantPrefix string
+	}{
+		{name: "base64", wantPrefix: ""},
+		{name: "data URLs", dataURLs: true, wantPrefix: "data:application/octet-stream;base64,"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := schema.ConvertToExamples([]byte(base64Spec), schema.ExampleOptions{
+				SchemaNames:    []string{"Upload"},
+				Seed:           42,
+				SelfValidate:   true,
+				BinaryDataURLs: test.dataURLs,
+			})
+			require.NoError(t, err)
+
+			var upload map[string]string
+			require.NoError(t, json.Unmarshal(result.Examples["Upload"], &upload))
+
+			checksum, err := base64.StdEncoding.DecodeString(upload["checksum"])
+			require.NoError(t, err)
+			assert.Len(t, checksum, 12)
+
+			// Only whole base64 groups fit, so 6 to 10 characters holds 8
+			assert.Len(t, upload["thumbnail"], 8)
+			_, err = base64.StdEncoding.DecodeString(upload["thumbnail"])
+			require.NoError(t, err)
+
+			require.True(t, strings.HasPrefix(upload["content"], test.wantPrefix))
+			_, err = base64.StdEncoding.DecodeString(strings.TrimPrefix(upload["content"], test.wantPrefix))
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateExamplesBase64(t *testing.T) {
+	spec := strings.Replace(base64Spec, "3.0.0", "3.1.0", 1) + `      example:
+        checksum: not base64!
+        thumbnail: aGVsbG8=
+`
+	result, err := schema.ValidateExamples([]byte(spec), schema.ValidateOptions{
+		SchemaNames: []string{"Upload"},
+	})
+	require.NoError(t, err)
+
+	upload := result.Schemas["Upload"]
+	assert.False(t, upload.Valid)
+	require.Len(t, upload.Issues, 1)
+	assert.Equal(t, "/components/schemas/Upload/example/checksum", upload.Issues[0].Path)
+	assert.Equal(t, "format", upload.Issues[0].Class)
+	assert.Equal(t, "'not base64!' is not valid 'byte': not base64", upload.Issues[0].Message)
+}
@@ -0,0 +1,265 @@
+package schema_test
+
+import (
+	"strings"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cacheSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        id:
+          type: string
+        customer:
+          $ref: '#/components/schemas/Customer'
+    Customer:
+      type: object
+      properties:
+        name:
+          type: string
+    Product:
+      type: object
+      properties:
+        sku:
+          type: string
+`
+
+func cacheOptions() schema.ConvertOptions {
+	return schema.ConvertOptions{
+		PackageName:   "testpkg",
+		PackagePath:   "github.com/example/proto/v1",
+		BuildManifest: true,
+	}
+}
+
+func TestConvertCache(t *testing.T) {
+	first, err := schema.Convert([]byte(cacheSpec), cacheOptions())
+	require.NoError(t, err)
+	require.Len(t, first.Manifest, 3)
+	assert.Nil(t, first.Changes)
+
+	for _, test := range []struct {
+		name    string
+		spec    string
+		opts    func(*schema.ConvertOptions)
+		reused  bool
+		schemas []string
+		outputs []string
+	}{
+		{
+			name:    "unchanged",
+			spec:    cacheSpec,
+			reused:  true,
+			schemas: []string{},
+			outputs: []string{},
+		},
+		{
+			name:    "unchanged apart from skipped options",
+			spec:    cacheSpec,
+			opts:    func(opts *schema.ConvertOptions) { opts.Concurrency = 4 },
+			reused:  true,
+			schemas: []string{},
+			outputs: []string{},
+		},
+		{
+			name:    "referenced schema changed",
+			spec:    strings.Replace(cacheSpec, "        name:\n", "        email:\n          type: string\n        name:\n", 1),
+			schemas: []string{"Order", "Customer"},
+			outputs: []string{"protobuf"},
+		},
+		{
+			name:    "schema changed",
+			spec:    strings.Replace(cacheSpec, "        sku:\n", "        title:\n          type: string\n        sku:\n", 1),
+			schemas: []string{"Product"},
+			outputs: []string{"protobuf"},
+		},
+		{
+			name: "schema added",
+			spec: cacheSpec + `    Invoice:
+      type: object
+      properties:
+        total:
+          type: number
+`,
+			schemas: []string{"Invoice"},
+			outputs: []string{"protobuf"},
+		},
+		{
+			name:    "schema removed",
+			spec:    cacheSpec[:strings.Index(cacheSpec, "    Product:")],
+			schemas: []string{"Product"},
+			outputs: []string{"protobuf"},
+		},
+		{
+			name:    "options changed",
+			spec:    cacheSpec,
+			opts:    func(opts *schema.ConvertOptions) { opts.PackageName = "otherpkg" },
+			schemas: []string{"Order", "Customer", "Product"},
+			outputs: []string{"protobuf"},
+		},
+		{
+			name:    "spec outside schemas changed",
+			spec:    strings.Replace(cacheSpec, "version: 1.0.0", "version: 1.1.0", 1),
+			schemas: []string{"Order", "Customer", "Product"},
+			outputs: []string{},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			opts := cacheOptions()
+			if test.opts != nil {
+				test.opts(&opts)
+			}
+			opts.Previous = first
+
+			result, err := schema.Convert([]byte(test.spec), opts)
+			require.NoError(t, err)
+			require.NotNil(t, result.Changes)
+			assert.Equal(t, test.reused, result.Changes.Reused)
+			assert.Equal(t, test.schemas, result.Changes.Schemas)
+			assert.Equal(t, test.outputs, result.Changes.Outputs)
+
+			fresh := cacheOptions()
+			if test.opts != nil {
+				test.opts(&fresh)
+			}
+			expected, err := schema.Convert([]byte(test.spec), fresh)
+			require.NoError(t, err)
+			assert.Equal(t, expected.Manifest, result.Manifest)
+			assert.Equal(t, string(expected.Protobuf), string(result.Protobuf))
+			assert.Equal(t, string(expected.Golang), string(result.Golang))
+		})
+	}
+}
+
+func TestConvertCacheManifestOptIn(t *testing.T) {
+	opts := cacheOptions()
+	opts.BuildManifest = false
+
+	result, err := schema.Convert([]byte(cacheSpec), opts)
+	require.NoError(t, err)
+	assert.Nil(t, result.Manifest)
+	assert.Nil(t, result.Changes)
+
+	first, err := schema.Convert([]byte(cacheSpec), cacheOptions())
+	require.NoError(t, err)
+
+	// Previous builds the manifest it is compared with
+	opts.Previous = first
+	result, err = schema.Convert([]byte(cacheSpec), opts)
+	require.NoError(t, err)
+	assert.True(t, result.Changes.Reused)
+	assert.Equal(t, first.Manifest, result.Manifest)
+}
+
+func TestConvertCacheReusedResultIsCopy(t *testing.T) {
+	first, err := schema.Convert([]byte(cacheSpec), cacheOptions())
+	require.NoError(t, err)
+	require.Contains(t, first.TypeMap, "Order")
+
+	opts := cacheOptions()
+	opts.Previous = first
+	result, err := schema.Convert([]byte(cacheSpec), opts)
+	require.NoError(t, err)
+	require.True(t, result.Changes.Reused)
+
+	result.TypeMap["Order"].Notes = append(result.TypeMap["Order"].Notes, "edited")
+	result.TypeMap["Order"].GeneratedName = "Edited"
+	delete(result.TypeMap, "Customer")
+	result.Protobuf[0] = '#'
+
+	assert.NotContains(t, first.TypeMap["Order"].Notes, "edited")
+	assert.Equal(t, "Order", first.TypeMap["Order"].GeneratedName)
+	assert.Contains(t, first.TypeMap, "Customer")
+	assert.NotEqual(t, byte('#'), first.Protobuf[0])
+}
+
+func TestConvertCacheFuncOption(t *testing.T) {
+	opts := cacheOptions()
+	opts.NestedNameFunc = func(parent, property string) string { return parent + property }
+
+	first, err := schema.Convert([]byte(cacheSpec), opts)
+	require.NoError(t, err)
+
+	opts.Previous = first
+	result, err := schema.Convert([]byte(cacheSpec), opts)
+	require.NoError(t, err)
+	assert.False(t, result.Changes.Reused)
+	assert.Equal(t, []string{}, result.Changes.Schemas)
+	assert.Equal(t, []string{}, result.Changes.Outputs)
+}
+
+func TestConvertDirRejectsPrevious(t *testing.T) {
+	opts := cacheOptions()
+	opts.Previous = &schema.ConvertResult{}
+
+	_, err := schema.ConvertDir(nil, "*.yaml", opts)
+	require.ErrorContains(t, err, "Previous is not supported by ConvertDir")
+}
+
+func TestConvertCacheConvertsWholeResult(t *testing.T) {
+	spec := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        order-id:
+          type: string
+    Customer:
+      type: object
+      properties:
+        full-name:
+          type: string
+    Product:
+      type: object
+      properties:
+        sku-code:
+          type: string
+`
+	// Each schema converted logs the rename of its property
+	logger, records := captureLogger(t)
+	converted := func() []string {
+		schemas := []string{}
+		for _, rec := range records() {
+			if rec["msg"] == "name renamed" {
+				schemas = append(schemas, rec["schema"].(string))
+			}
+		}
+		return schemas
+	}
+
+	opts := cacheOptions()
+	opts.Logger = logger
+	first, err := schema.Convert([]byte(spec), opts)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Order", "Customer", "Product"}, converted())
+
+	opts.Previous = first
+	result, err := schema.Convert([]byte(spec), opts)
+	require.NoError(t, err)
+	assert.True(t, result.Changes.Reused)
+	assert.Equal(t, []string{}, converted())
+
+	// One changed schema converts every schema again
+	edited := strings.Replace(spec, "        sku-code:\n", "        unit-price:\n          type: number\n        sku-code:\n", 1)
+	result, err = schema.Convert([]byte(edited), opts)
+	require.NoError(t, err)
+	assert.False(t, result.Changes.Reused)
+	assert.Equal(t, []string{"Product"}, result.Changes.Schemas)
+	assert.Equal(t, []string{"Order", "Customer", "Product", "Product"}, converted())
+}
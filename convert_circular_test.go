@@ -0,0 +1,109 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const circularSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Node:
+      type: object
+      properties:
+        name:
+          type: string
+          example: root
+        parent:
+          $ref: '#/components/schemas/Node'
+        children:
+          type: array
+          items:
+            $ref: '#/components/schemas/Node'
+`
+
+func TestConvertToExamplesCircularStrategy(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		strategy schema.CircularStrategy
+		want     string
+	}{
+		{name: "default omits", want: `{"name":"root","children":[]}`},
+		{name: "omit", strategy: schema.CircularStrategyOmit, want: `{"name":"root","children":[]}`},
+		{name: "null", strategy: schema.CircularStrategyNull, want: `{"name":"root","parent":null,"children":[null]}`},
+		{name: "truncated object", strategy: schema.CircularStrategyTruncatedObject, want: `{"name":"root","parent":{},"children":[{}]}`},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := schema.ConvertToExamples([]byte(circularSpec), schema.ExampleOptions{
+				SchemaNames:      []string{"Node"},
+				Seed:             1,
+				CircularStrategy: test.strategy,
+			})
+			require.NoError(t, err)
+			assert.JSONEq(t, test.want, string(result.Examples["Node"]))
+
+			require.Contains(t, result.Metadata, "Node")
+			assert.Equal(t, []schema.ExampleTruncation{
+				{Path: "parent", Schema: "Node", Reason: schema.TruncatedCircular},
+				{Path: "children", Schema: "Node", Reason: schema.TruncatedCircular},
+			}, result.Metadata["Node"].Truncations)
+		})
+	}
+}
+
+func TestConvertToExamplesMaxDepthTruncation(t *testing.T) {
+	spec := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        id:
+          type: string
+          example: o-1
+        customer:
+          $ref: '#/components/schemas/Customer'
+    Customer:
+      type: object
+      properties:
+        address:
+          type: object
+          properties:
+            city:
+              type: string
+              example: Paris
+`
+	result, err := schema.ConvertToExamples([]byte(spec), schema.ExampleOptions{
+		IncludeAll:       true,
+		MaxDepth:         2,
+		Seed:             1,
+		CircularStrategy: schema.CircularStrategyNull,
+	})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"id":"o-1","customer":{"address":null}}`, string(result.Examples["Order"]))
+	assert.Equal(t, []schema.ExampleTruncation{
+		{Path: "customer.address", Reason: schema.TruncatedMaxDepth},
+	}, result.Metadata["Order"].Truncations)
+
+	// Customer fits within MaxDepth, so it has nothing to report
+	assert.JSONEq(t, `{"address":{"city":"Paris"}}`, string(result.Examples["Customer"]))
+	assert.NotContains(t, result.Metadata, "Customer")
+}
+
+func TestConvertToExamplesCircularStrategyInvalid(t *testing.T) {
+	_, err := schema.ConvertToExamples([]byte(circularSpec), schema.ExampleOptions{
+		IncludeAll:       true,
+		CircularStrategy: "cut",
+	})
+	require.ErrorContains(t, err, `unknown CircularStrategy "cut": must be omit, null or truncatedObject`)
+}
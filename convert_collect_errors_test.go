@@ -0,0 +1,113 @@
+package schema_test
+
+import (
+	"errors"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const collectErrorsSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Composite:
+      allOf:
+        - $ref: '#/components/schemas/Valid'
+    Valid:
+      type: object
+      properties:
+        name:
+          type: string
+    Order:
+      type: object
+      properties:
+        note:
+          anyOf:
+            - type: string
+        id:
+          type: string
+        addresses:
+          type: object
+          properties:
+            street:
+              type: string
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+      discriminator:
+        propertyName: petType
+    Dog:
+      type: object
+      properties:
+        petType:
+          type: string
+    Cat:
+      type: object
+      properties:
+        name:
+          type: string
+`
+
+func TestConvertCollectErrors(t *testing.T) {
+	opts := schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+	}
+
+	_, err := schema.Convert([]byte(collectErrorsSpec), opts)
+	require.EqualError(t, err, "schema 'Composite': uses 'allOf' which is not supported")
+
+	opts.CollectErrors = true
+	result, err := schema.Convert([]byte(collectErrorsSpec), opts)
+	require.Error(t, err)
+	assert.Nil(t, result)
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	require.True(t, ok)
+
+	var messages []string
+	for _, e := range joined.Unwrap() {
+		messages = append(messages, e.Error())
+	}
+	assert.Equal(t, []string{
+		"schema 'Composite': uses 'allOf' which is not supported",
+		"schema 'Order': property 'note' uses 'anyOf' which is not supported",
+		"schema 'Order': cannot derive message name from property 'addresses'; use singular form or $ref",
+		"discriminator property 'petType' missing in variant 'Cat'",
+	}, messages)
+
+	// Fix suggestions remain reachable through the joined error
+	fix, ok := schema.SuggestedFix(err)
+	require.True(t, ok)
+	assert.Contains(t, fix.Description, "addresses")
+
+	var fixable *schema.FixableError
+	assert.True(t, errors.As(err, &fixable))
+}
+
+func TestConvertToStructCollectErrors(t *testing.T) {
+	_, err := schema.ConvertToStruct([]byte(collectErrorsSpec), schema.ConvertOptions{
+		GoPackagePath: "github.com/example/types",
+		CollectErrors: true,
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "schema 'Composite': uses 'allOf' which is not supported")
+	assert.ErrorContains(t, err, "schema 'Order': property 'note' uses 'anyOf' which is not supported")
+	assert.ErrorContains(t, err, "discriminator property 'petType' missing in variant 'Cat'")
+}
+
+func TestConvertCollectErrorsValidSpec(t *testing.T) {
+	result, err := schema.Convert([]byte(contextSpec), schema.ConvertOptions{
+		PackageName:   "testpkg",
+		PackagePath:   "github.com/example/proto/v1",
+		CollectErrors: true,
+	})
+	require.NoError(t, err)
+	assert.Contains(t, string(result.Protobuf), "message User {")
+}
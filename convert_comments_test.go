@@ -0,0 +1,162 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const commentsSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Order:
+      description: |
+        ## Order
+
+        An **order** placed by a customer, see [orders](https://example.com/orders).
+      type: object
+      properties:
+        id:
+          type: string
+          description: "Unique id.\r\nAssigned by the server when the order is created and never changes afterwards."
+    Owner:
+      type: object
+      properties:
+        pet:
+          $ref: '#/components/schemas/Pet'
+    Pet:
+      description: A pet, as one of the kinds of animal the shop sells to its customers.
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+      discriminator:
+        propertyName: petType
+    Dog:
+      type: object
+      properties:
+        petType:
+          type: string
+    Cat:
+      type: object
+      properties:
+        petType:
+          type: string
+`
+
+func TestConvertComments(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		opts   schema.ConvertOptions
+		proto  []string
+		golang []string
+	}{
+		{
+			name: "defaults",
+			proto: []string{
+				"// ## Order\n//\n// An **order** placed by a customer, see [orders](https://example.com/orders).\nmessage Order {\n",
+				"  // Unique id.\n  // Assigned by the server when the order is created and never changes afterwards.\n",
+			},
+			golang: []string{
+				"// A pet, as one of the kinds of animal the shop sells to its customers.\ntype Pet struct {\n",
+			},
+		},
+		{
+			name: "width",
+			opts: schema.ConvertOptions{CommentWidth: 40},
+			proto: []string{
+				"  // Unique id.\n  // Assigned by the server when the\n  // order is created and never changes\n  // afterwards.\n",
+			},
+			golang: []string{
+				"// A pet, as one of the kinds of animal\n// the shop sells to its customers.\ntype Pet struct {\n",
+			},
+		},
+		{
+			name: "no wrapping",
+			opts: schema.ConvertOptions{CommentWidth: -1},
+			golang: []string{
+				"// A pet, as one of the kinds of animal the shop sells to its customers.\ntype Pet struct {\n",
+			},
+		},
+		{
+			name: "strip markdown",
+			opts: schema.ConvertOptions{StripMarkdown: true},
+			proto: []string{
+				"// Order\n//\n// An order placed by a customer, see orders (https://example.com/orders).\nmessage Order {\n",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.opts.PackageName = "testpkg"
+			test.opts.PackagePath = "github.com/example/proto"
+			test.opts.GoPackagePath = "github.com/example/types"
+			result, err := schema.Convert([]byte(commentsSpec), test.opts)
+			require.NoError(t, err)
+
+			for _, expected := range test.proto {
+				assert.Contains(t, string(result.Protobuf), expected)
+			}
+			for _, expected := range test.golang {
+				assert.Contains(t, string(result.Golang), expected)
+			}
+		})
+	}
+}
+
+func TestConvertToStructCommentWidth(t *testing.T) {
+	result, err := schema.ConvertToStruct([]byte(commentsSpec), schema.ConvertOptions{
+		GoPackagePath: "github.com/example/types",
+		CommentWidth:  40,
+		StripMarkdown: true,
+	})
+	require.NoError(t, err)
+
+	golang := string(result.Golang)
+	assert.Contains(t, golang, "// Order\n//\n// An order placed by a customer, see\n// orders (https://example.com/orders).\ntype Order struct {\n")
+	assert.Contains(t, golang, "\t// Unique id.\n\t// Assigned by the server when the\n\t// order is created and never\n\t// changes afterwards.\n\tID string `json:\"id\"`\n")
+}
+
+func TestConvertTitleAndExternalDocs(t *testing.T) {
+	given := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Invoice:
+      title: Invoice
+      description: A bill sent to a customer.
+      externalDocs:
+        url: https://example.com/docs/invoices
+      type: object
+      properties:
+        total:
+          type: number
+          title: Total amount
+          externalDocs:
+            url: https://example.com/docs/totals
+        status:
+          type: string
+          title: Status
+          description: Status
+`
+	result, err := schema.Convert([]byte(given), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, string(result.Protobuf), "// Invoice\n//\n// A bill sent to a customer.\n//\n// See: https://example.com/docs/invoices\nmessage Invoice {\n"+
+		"  // Total amount\n  //\n  // See: https://example.com/docs/totals\n  double total = 1 [json_name = \"total\"];\n"+
+		"  // Status\n  string status = 2 [json_name = \"status\"];\n")
+
+	structs, err := schema.ConvertToStruct([]byte(given), schema.ConvertOptions{
+		GoPackagePath: "github.com/example/types",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, string(structs.Golang), "// Invoice\n//\n// A bill sent to a customer.\n//\n// See: https://example.com/docs/invoices\ntype Invoice struct {\n"+
+		"\t// Total amount\n\t//\n\t// See: https://example.com/docs/totals\n\tTotal float64 `json:\"total\"`\n")
+}
@@ -0,0 +1,145 @@
+package schema_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// wideSpec builds a spec with count object schemas, each referencing the previous
+// one and carrying a date-time field, plus a discriminated union over the first two.
+func wideSpec(count int) []byte {
+	var b strings.Builder
+	b.WriteString(`openapi: 3.0.0
+info:
+  title: Wide
+  version: 1.0.0
+components:
+  schemas:
+    Shape:
+      oneOf:
+        - $ref: '#/components/schemas/Model0'
+        - $ref: '#/components/schemas/Model1'
+      discriminator:
+        propertyName: kind
+`)
+	for i := 0; i < count; i++ {
+		fmt.Fprintf(&b, `    Model%d:
+      type: object
+      description: Model number %d
+      properties:
+        kind:
+          type: string
+        id:
+          type: integer
+          format: int64
+        createdAt:
+          type: string
+          format: date-time
+        tags:
+          type: array
+          items:
+            type: string
+`, i, i)
+		if i > 0 {
+			fmt.Fprintf(&b, `        previous:
+          $ref: '#/components/schemas/Model%d'
+`, i-1)
+		}
+	}
+	return []byte(b.String())
+}
+
+func TestConvertConcurrencyMatchesSequential(t *testing.T) {
+	spec := wideSpec(200)
+
+	sequential, err := schema.Convert(spec, schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+	})
+	require.NoError(t, err)
+
+	parallel, err := schema.Convert(spec, schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+		Concurrency: 8,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, string(sequential.Protobuf), string(parallel.Protobuf))
+	assert.Equal(t, string(sequential.Golang), string(parallel.Golang))
+	assert.Equal(t, sequential.TypeMap, parallel.TypeMap)
+
+	structs1, err := schema.ConvertToStruct(spec, schema.ConvertOptions{
+		GoPackagePath: "github.com/example/types",
+	})
+	require.NoError(t, err)
+
+	structs2, err := schema.ConvertToStruct(spec, schema.ConvertOptions{
+		GoPackagePath: "github.com/example/types",
+		Concurrency:   8,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, string(structs1.Golang), string(structs2.Golang))
+}
+
+func TestConvertConcurrencyReportsFirstError(t *testing.T) {
+	spec := string(wideSpec(50)) + `    Broken1:
+      type: object
+      properties:
+        bad:
+          allOf:
+            - type: string
+    Broken2:
+      type: string
+`
+
+	_, err := schema.ConvertToStruct([]byte(spec), schema.ConvertOptions{
+		GoPackagePath: "github.com/example/types",
+		Concurrency:   8,
+	})
+	require.ErrorContains(t, err, "schema 'Broken1'")
+}
+
+func TestConvertToExamplesConcurrency(t *testing.T) {
+	spec := wideSpec(100)
+
+	page1, err := schema.ConvertToExamples(spec, schema.ExampleOptions{
+		IncludeAll:  true,
+		Concurrency: 8,
+		Seed:        42,
+	})
+	require.NoError(t, err)
+
+	page2, err := schema.ConvertToExamples(spec, schema.ExampleOptions{
+		IncludeAll:  true,
+		Concurrency: 4,
+		Seed:        42,
+	})
+	require.NoError(t, err)
+
+	assert.Len(t, page1.Examples, 101)
+	assert.Equal(t, page1.Examples, page2.Examples)
+}
+
+func BenchmarkConvertConcurrency(b *testing.B) {
+	spec := wideSpec(1000)
+	for _, workers := range []int{1, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, err := schema.Convert(spec, schema.ConvertOptions{
+					PackageName: "testpkg",
+					PackagePath: "github.com/example/proto/v1",
+					Concurrency: workers,
+				})
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
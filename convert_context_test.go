@@ -0,0 +1,76 @@
+package schema_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const contextSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        name:
+          type: string
+`
+
+func TestConvertContext(t *testing.T) {
+	result, err := schema.ConvertContext(context.Background(), []byte(contextSpec), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, string(result.Protobuf), "message User {")
+}
+
+func TestConvertContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := schema.ConvertContext(ctx, []byte(contextSpec), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.ErrorContains(t, err, "conversion cancelled")
+
+	_, err = schema.ConvertToStructContext(ctx, []byte(contextSpec), schema.ConvertOptions{
+		GoPackagePath: "github.com/example/types",
+	})
+	require.ErrorIs(t, err, context.Canceled)
+
+	_, err = schema.ConvertToExamplesContext(ctx, []byte(contextSpec), schema.ExampleOptions{
+		IncludeAll: true,
+	})
+	require.ErrorIs(t, err, context.Canceled)
+
+	_, err = schema.ValidateExamplesContext(ctx, []byte(contextSpec), schema.ValidateOptions{
+		IncludeAll: true,
+	})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestConvertContextDeadlineExceeded(t *testing.T) {
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	_, err := schema.ConvertContext(ctx, []byte(contextSpec), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+	})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.False(t, errors.Is(err, context.Canceled))
+	// The error wraps ctx.Err() rather than being it
+	assert.NotEqual(t, ctx.Err(), err)
+}
@@ -0,0 +1,161 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const decimalSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Payment:
+      type: object
+      properties:
+        amount:
+          type: number
+          format: decimal
+          multipleOf: 0.01
+        rate:
+          type: string
+          format: decimal
+          multipleOf: 0.0001
+        fee:
+          type: number
+          x-money: true
+          minimum: 5
+          maximum: 6
+        history:
+          type: array
+          items:
+            type: number
+            format: decimal
+`
+
+func TestConvertDecimals(t *testing.T) {
+	result, err := schema.Convert([]byte(decimalSpec), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+		Decimals:    &schema.DecimalOptions{},
+	})
+	require.NoError(t, err)
+
+	protobuf := string(result.Protobuf)
+	assert.Contains(t, protobuf, "  string amount = 1 [json_name = \"amount\"];")
+	assert.Contains(t, protobuf, "  string rate = 2 [json_name = \"rate\"];")
+	assert.Contains(t, protobuf, "  string fee = 3 [json_name = \"fee\"];")
+	assert.Contains(t, protobuf, "  repeated string history = 4 [json_name = \"history\"];")
+	for _, warning := range result.Warnings {
+		assert.NotEqual(t, schema.WarningFormatUnknown, warning.Code)
+	}
+}
+
+func TestConvertDecimalsMoney(t *testing.T) {
+	result, err := schema.Convert([]byte(decimalSpec), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+		Decimals:    &schema.DecimalOptions{Money: true},
+	})
+	require.NoError(t, err)
+
+	protobuf := string(result.Protobuf)
+	assert.Contains(t, protobuf, "import \"google/type/money.proto\";")
+	assert.Contains(t, protobuf, "  google.type.Money amount = 1 [json_name = \"amount\"];")
+	assert.Contains(t, protobuf, "  repeated google.type.Money history = 4 [json_name = \"history\"];")
+}
+
+func TestConvertToStructDecimals(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		decimals *schema.DecimalOptions
+		want     []string
+	}{
+		{
+			name:     "default types",
+			decimals: &schema.DecimalOptions{},
+			want: []string{
+				"\tAmount json.Number `json:\"amount\"`\n",
+				"\tRate string `json:\"rate\"`\n",
+				"\tFee json.Number `json:\"fee\"`\n",
+				"\tHistory []json.Number `json:\"history\"`\n",
+			},
+		},
+		{
+			name:     "go type",
+			decimals: &schema.DecimalOptions{GoType: "decimal.Decimal", GoImport: "github.com/shopspring/decimal"},
+			want: []string{
+				"\t\"github.com/shopspring/decimal\"\n",
+				"\tAmount decimal.Decimal `json:\"amount\"`\n",
+				"\tRate decimal.Decimal `json:\"rate\"`\n",
+				"\tHistory []decimal.Decimal `json:\"history\"`\n",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := schema.ConvertToStruct([]byte(decimalSpec), schema.ConvertOptions{
+				GoFormat:      schema.GoFormatNone,
+				GoPackagePath: "github.com/example/types/v1",
+				Decimals:      test.decimals,
+			})
+			require.NoError(t, err)
+
+			for _, want := range test.want {
+				assert.Contains(t, string(result.Golang), want)
+			}
+		})
+	}
+}
+
+func TestConvertDecimalsTypeMappingWins(t *testing.T) {
+	result, err := schema.ConvertToStruct([]byte(decimalSpec), schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
+		GoPackagePath: "github.com/example/types/v1",
+		Decimals:      &schema.DecimalOptions{GoType: "decimal.Decimal", GoImport: "github.com/shopspring/decimal"},
+		TypeMappings: []schema.TypeMapping{
+			{Type: "string", Format: "decimal", Go: "apd.Decimal", GoImport: "github.com/cockroachdb/apd"},
+		},
+	})
+	require.NoError(t, err)
+
+	golang := string(result.Golang)
+	assert.Contains(t, golang, "\tAmount decimal.Decimal `json:\"amount\"`\n")
+	assert.Contains(t, golang, "\tRate apd.Decimal `json:\"rate\"`\n")
+}
+
+func TestConvertDecimalsGoImportRequiresGoType(t *testing.T) {
+	_, err := schema.ConvertToStruct([]byte(decimalSpec), schema.ConvertOptions{
+		GoPackagePath: "github.com/example/types/v1",
+		Decimals:      &schema.DecimalOptions{GoImport: "github.com/shopspring/decimal"},
+	})
+	require.ErrorContains(t, err, "Decimals.GoImport requires Decimals.GoType")
+}
+
+func TestConvertToExamplesDecimalScale(t *testing.T) {
+	result, err := schema.ConvertToExamples([]byte(decimalSpec), schema.ExampleOptions{
+		SchemaNames:  []string{"Payment"},
+		Seed:         42,
+		SelfValidate: true,
+	})
+	require.NoError(t, err)
+
+	var payment struct {
+		Amount  json.Number   `json:"amount"`
+		Rate    string        `json:"rate"`
+		Fee     json.Number   `json:"fee"`
+		History []json.Number `json:"history"`
+	}
+	require.NoError(t, json.Unmarshal(result.Examples["Payment"], &payment))
+
+	assert.Regexp(t, `^\d+\.\d{2}$`, payment.Amount.String())
+	assert.Regexp(t, `^\d+\.\d{4}$`, payment.Rate)
+	assert.Regexp(t, `^(5\.\d{2}|6\.00)$`, payment.Fee.String())
+	for _, value := range payment.History {
+		assert.Regexp(t, `^\d+\.\d{2}$`, value.String())
+	}
+}
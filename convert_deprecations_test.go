@@ -0,0 +1,121 @@
+package schema_test
+
+import (
+	"testing"
+	"time"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const deprecationsSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    LegacyUser:
+      type: object
+      deprecated: true
+      x-sunset: 2026-06-30
+      properties:
+        id:
+          type: string
+    Order:
+      type: object
+      properties:
+        id:
+          type: string
+        legacyCode:
+          type: string
+          deprecated: true
+        shipping:
+          type: object
+          properties:
+            fax:
+              type: string
+              x-sunset: '2026-01-31T00:00:00Z'
+        notes:
+          type: array
+          items:
+            type: string
+            deprecated: true
+        user:
+          $ref: '#/components/schemas/LegacyUser'
+`
+
+func TestConvertDeprecations(t *testing.T) {
+	result, err := schema.Convert([]byte(deprecationsSpec), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []schema.Deprecation{
+		{
+			Schema:     "LegacyUser",
+			Pointer:    "/components/schemas/LegacyUser",
+			Deprecated: true,
+			Sunset:     time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC),
+			Location:   schema.TypeLocationProto,
+		},
+		{
+			Schema:     "Order",
+			Property:   "legacyCode",
+			Pointer:    "/components/schemas/Order/properties/legacyCode",
+			Deprecated: true,
+			Location:   schema.TypeLocationProto,
+		},
+		{
+			Schema:   "Order",
+			Property: "shipping.fax",
+			Pointer:  "/components/schemas/Order/properties/shipping/properties/fax",
+			Sunset:   time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC),
+			Location: schema.TypeLocationProto,
+		},
+		{
+			Schema:     "Order",
+			Property:   "notes[]",
+			Pointer:    "/components/schemas/Order/properties/notes/items",
+			Deprecated: true,
+			Location:   schema.TypeLocationProto,
+		},
+	}, result.Deprecations)
+}
+
+func TestConvertToStructDeprecations(t *testing.T) {
+	result, err := schema.ConvertToStruct([]byte(deprecationsSpec), schema.ConvertOptions{
+		GoPackagePath: "github.com/example/types/v1",
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Deprecations, 4)
+	assert.Equal(t, schema.TypeLocationGolang, result.Deprecations[0].Location)
+}
+
+func TestConvertInvalidSunset(t *testing.T) {
+	given := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        name:
+          type: string
+          x-sunset: next quarter
+`
+	_, err := schema.Convert([]byte(given), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+	})
+	require.EqualError(t, err, "schema 'User': /components/schemas/User/properties/name: "+
+		"x-sunset must be a date (YYYY-MM-DD) or RFC 3339 timestamp, got: next quarter")
+
+	report, err := schema.Lint([]byte(given), schema.LintOptions{})
+	require.NoError(t, err)
+	require.True(t, report.HasErrors())
+	assert.Equal(t, "User", report.Issues[0].Schema)
+}
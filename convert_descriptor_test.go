@@ -0,0 +1,186 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestConvertBuildDescriptor(t *testing.T) {
+	result, err := schema.Convert([]byte(`openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    User:
+      type: object
+      description: A registered user
+      properties:
+        name:
+          type: string
+        createdAt:
+          type: string
+          format: date-time
+        roles:
+          type: array
+          items:
+            type: string
+`), schema.ConvertOptions{
+		PackageName:     "testpkg",
+		PackagePath:     "github.com/example/proto/v1",
+		BuildDescriptor: true,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, result.Descriptor)
+
+	assert.Equal(t, "schema.proto", result.Descriptor.Path())
+	assert.Equal(t, protoreflect.FullName("testpkg"), result.Descriptor.Package())
+
+	user := result.Descriptor.Messages().ByName("User")
+	require.NotNil(t, user)
+	name := user.Fields().ByName("name")
+	require.NotNil(t, name)
+	assert.Equal(t, protoreflect.StringKind, name.Kind())
+	assert.Equal(t, protoreflect.FieldNumber(1), name.Number())
+	assert.Equal(t, protoreflect.FullName("google.protobuf.Timestamp"), user.Fields().ByName("createdAt").Message().FullName())
+	assert.True(t, user.Fields().ByName("roles").IsList())
+
+	// Comments survive as source info
+	comments := result.Descriptor.SourceLocations().ByDescriptor(user).LeadingComments
+	assert.Contains(t, comments, "A registered user")
+}
+
+func TestConvertBuildDescriptorOff(t *testing.T) {
+	result, err := schema.Convert([]byte(`openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        name:
+          type: string
+`), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+	})
+	require.NoError(t, err)
+	assert.Nil(t, result.Descriptor)
+}
+
+// Every proto construct the generator emits must compile
+func TestConvertBuildDescriptorCompilesAllConstructs(t *testing.T) {
+	result, err := schema.Convert([]byte(`openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Order:
+      type: object
+      x-proto-reserved: [20, "legacy_id"]
+      required: [id]
+      properties:
+        id:
+          type: string
+        nickname:
+          type: string
+        status:
+          $ref: '#/components/schemas/Status'
+        priority:
+          type: integer
+          enum: [1, 2, 3]
+        shipping:
+          type: object
+          properties:
+            street:
+              type: string
+        matrix:
+          type: array
+          items:
+            type: array
+            items:
+              type: number
+        metadata:
+          type: object
+        ttl:
+          type: string
+          format: duration
+        count:
+          type: integer
+          x-proto-type: google.protobuf.Int64Value
+        placedAt:
+          type: string
+          format: date-time
+        payload:
+          anyOf:
+            - type: string
+            - type: integer
+    Status:
+      type: integer
+      enum: [0, 1, 2]
+    Payment:
+      type: object
+      properties:
+        card:
+          $ref: '#/components/schemas/Card'
+        bank:
+          $ref: '#/components/schemas/Bank'
+      oneOf:
+        - required: [card]
+        - required: [bank]
+    Card:
+      type: object
+      properties:
+        number:
+          type: string
+    Bank:
+      type: object
+      properties:
+        iban:
+          type: string
+`), schema.ConvertOptions{
+		PackageName:       "testpkg",
+		PackagePath:       "github.com/example/proto/v1",
+		UseProto3Optional: true,
+		WellKnownTypes:    schema.WellKnownTypes{Duration: true, Struct: true, Any: true},
+		BuildDescriptor:   true,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, result.Descriptor)
+
+	assert.NotNil(t, result.Descriptor.Messages().ByName("Order"))
+	assert.NotNil(t, result.Descriptor.Messages().ByName("Payment"))
+	assert.NotNil(t, result.Descriptor.Enums().ByName("Status"))
+}
+
+func TestConvertBuildDescriptorUnresolvedImport(t *testing.T) {
+	_, err := schema.Convert([]byte(`openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Invoice:
+      type: object
+      properties:
+        total:
+          type: string
+          format: decimal
+`), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+		TypeMappings: []schema.TypeMapping{
+			{Type: "string", Format: "decimal", Proto: "google.type.Decimal", ProtoImport: "google/type/decimal.proto"},
+		},
+		BuildDescriptor: true,
+	})
+	require.ErrorContains(t, err, "failed to build descriptor")
+	require.ErrorContains(t, err, "google/type/decimal.proto")
+}
@@ -1,6 +1,7 @@
 package schema_test
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
@@ -188,3 +189,111 @@ components:
 	require.NotEqual(t, -1, posDog)
 	assert.Less(t, posCat, posDog)
 }
+
+const verifySpec = `openapi: 3.0.0
+info:
+  title: Test
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+      discriminator:
+        propertyName: kind
+        mapping:
+          dog: '#/components/schemas/Dog'
+          doggo: '#/components/schemas/Dog'
+          cat: '#/components/schemas/Cat'
+    Dog:
+      type: object
+      properties:
+        kind:
+          type: string
+        collar:
+          type: object
+          properties:
+            size:
+              type: string
+    Cat:
+      type: object
+      properties:
+        kind:
+          type: string
+    Owner:
+      type: object
+      properties:
+        name:
+          type: string
+        address:
+          type: object
+          properties:
+            street:
+              type: string`
+
+func TestConvertVerifyDeterminism(t *testing.T) {
+	for _, concurrency := range []int{1, 8} {
+		t.Run(fmt.Sprintf("concurrency %d", concurrency), func(t *testing.T) {
+			opts := schema.ConvertOptions{
+				PackageName:   "testpkg",
+				PackagePath:   "github.com/example/proto/v1",
+				GoPackagePath: "github.com/example/go/v1",
+				Concurrency:   concurrency,
+			}
+			expected, err := schema.Convert([]byte(verifySpec), opts)
+			require.NoError(t, err)
+
+			opts.VerifyDeterminism = true
+			result, err := schema.Convert([]byte(verifySpec), opts)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected.Protobuf), string(result.Protobuf))
+			assert.Equal(t, string(expected.Golang), string(result.Golang))
+			assert.Equal(t, expected.TypeMap, result.TypeMap)
+
+			structs, err := schema.ConvertToStruct([]byte(verifySpec), opts)
+			require.NoError(t, err)
+			assert.Contains(t, string(structs.Golang), "type Owner struct")
+		})
+	}
+}
+
+func TestConvertVerifyDeterminismErrors(t *testing.T) {
+	// A naming function that counts its calls names nested types differently on
+	// every conversion
+	counting := func() func(parent, property string) string {
+		calls := 0
+		return func(parent, property string) string {
+			calls++
+			return fmt.Sprintf("%s%s%d", parent, strings.ToUpper(property[:1])+property[1:], calls)
+		}
+	}
+
+	opts := schema.ConvertOptions{
+		PackageName:       "testpkg",
+		PackagePath:       "github.com/example/proto/v1",
+		GoPackagePath:     "github.com/example/go/v1",
+		VerifyDeterminism: true,
+		NestedNameFunc:    counting(),
+	}
+	_, err := schema.Convert([]byte(verifySpec), opts)
+	require.ErrorContains(t, err, "output is not deterministic: protobuf output differs between two conversions at line")
+
+	opts.NestedNameFunc = counting()
+	_, err = schema.ConvertToStruct([]byte(verifySpec), opts)
+	require.ErrorContains(t, err, "output is not deterministic: golang output differs between two conversions at line")
+
+	// A reused result is checked against a fresh conversion
+	opts.NestedNameFunc = nil
+	opts.VerifyDeterminism = false
+	opts.BuildManifest = true
+	previous, err := schema.Convert([]byte(verifySpec), opts)
+	require.NoError(t, err)
+	previous.Protobuf = append([]byte("// edited\n"), previous.Protobuf...)
+
+	opts.Previous = previous
+	opts.VerifyDeterminism = true
+	_, err = schema.Convert([]byte(verifySpec), opts)
+	require.ErrorContains(t, err, "protobuf output differs between two conversions at line 1")
+}
@@ -0,0 +1,145 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const exampleModeSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        quantity:
+          type: integer
+          minimum: 1
+        discount:
+          type: integer
+          maximum: 50
+          exclusiveMaximum: true
+        price:
+          type: number
+          minimum: 0.5
+        code:
+          type: string
+          minLength: 3
+        note:
+          type: string
+          maxLength: 5
+        email:
+          type: string
+          format: email
+          maxLength: 20
+        items:
+          type: array
+          minItems: 2
+          items:
+            type: integer
+            minimum: 0
+            maximum: 0
+        tags:
+          type: array
+          maxItems: 3
+          items:
+            type: string
+        name:
+          type: string
+`
+
+func TestConvertToExamplesMode(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		mode     schema.ExampleMode
+		expected map[string]interface{}
+	}{
+		{
+			name: "boundary",
+			mode: schema.ExampleModeBoundary,
+			expected: map[string]interface{}{
+				"quantity": float64(1),
+				"discount": float64(49),
+				"price":    0.5,
+				"code":     3,
+				"note":     5,
+				"email":    "user@example.comxxxx",
+				"items":    []interface{}{float64(0), float64(0)},
+				"tags":     3,
+			},
+		},
+		{
+			name: "invalid",
+			mode: schema.ExampleModeInvalid,
+			expected: map[string]interface{}{
+				"quantity": float64(0),
+				"discount": float64(50),
+				"price":    0.49999999999999994,
+				"code":     2,
+				"note":     6,
+				"email":    "user@example.comxxxxx",
+				"items":    1,
+				"tags":     4,
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := schema.ConvertToExamples([]byte(exampleModeSpec), schema.ExampleOptions{
+				SchemaNames: []string{"Order"},
+				Seed:        42,
+				Mode:        test.mode,
+			})
+			require.NoError(t, err)
+
+			var order map[string]interface{}
+			require.NoError(t, json.Unmarshal(result.Examples["Order"], &order))
+			for field, expected := range test.expected {
+				switch value := order[field].(type) {
+				case string:
+					if length, ok := expected.(int); ok {
+						assert.Len(t, value, length)
+						continue
+					}
+				case []interface{}:
+					if length, ok := expected.(int); ok {
+						assert.Len(t, value, length)
+						continue
+					}
+				}
+				assert.Equal(t, expected, order[field])
+			}
+			// Unconstrained fields are generated as usual
+			assert.Len(t, order["name"], 10)
+		})
+	}
+}
+
+func TestConvertToExamplesModeTypical(t *testing.T) {
+	typical, err := schema.ConvertToExamples([]byte(exampleModeSpec), schema.ExampleOptions{
+		SchemaNames: []string{"Order"},
+		Seed:        42,
+		Mode:        schema.ExampleModeTypical,
+	})
+	require.NoError(t, err)
+
+	unset, err := schema.ConvertToExamples([]byte(exampleModeSpec), schema.ExampleOptions{
+		SchemaNames: []string{"Order"},
+		Seed:        42,
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, string(unset.Examples["Order"]), string(typical.Examples["Order"]))
+}
+
+func TestConvertToExamplesModeInvalidOption(t *testing.T) {
+	_, err := schema.ConvertToExamples([]byte(exampleModeSpec), schema.ExampleOptions{
+		IncludeAll: true,
+		Mode:       "negative",
+	})
+	require.ErrorContains(t, err, `unknown Mode "negative": must be typical, boundary or invalid`)
+}
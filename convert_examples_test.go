@@ -1,6 +1,7 @@
 package schema_test
 
 import (
+	"encoding/json"
 	"testing"
 
 	schema "github.com/duh-rpc/openapi-schema.go"
@@ -1014,6 +1015,150 @@ components:
 	assert.JSONEq(t, `{"code":400,"message":"This is a message"}`, string(result.Examples["ErrorResponse"]))
 }
 
+func TestConvertToExamplesFieldOverridePaths(t *testing.T) {
+	openapi := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    ErrorResponse:
+      type: object
+      properties:
+        code:
+          type: integer
+    AuthError:
+      type: object
+      properties:
+        code:
+          type: integer
+    Product:
+      type: object
+      properties:
+        code:
+          type: integer
+        vendorId:
+          type: string
+    Address:
+      type: object
+      properties:
+        city:
+          type: string
+    User:
+      type: object
+      properties:
+        userId:
+          type: string
+        address:
+          $ref: '#/components/schemas/Address'
+        billing:
+          type: object
+          properties:
+            city:
+              type: string
+`
+	for _, test := range []struct {
+		name      string
+		overrides map[string]interface{}
+		expected  map[string]string
+	}{
+		{
+			name:      "schema path does not leak",
+			overrides: map[string]interface{}{"ErrorResponse.code": 400},
+			expected: map[string]string{
+				"ErrorResponse": `{"code":400}`,
+				"AuthError":     `{"code":6}`,
+			},
+		},
+		{
+			name:      "wildcard schema",
+			overrides: map[string]interface{}{"*Error*.code": 401},
+			expected: map[string]string{
+				"ErrorResponse": `{"code":401}`,
+				"AuthError":     `{"code":401}`,
+			},
+		},
+		{
+			name:      "field name pattern",
+			overrides: map[string]interface{}{"*Id": "id-1"},
+			expected: map[string]string{
+				"Product": `{"code":6,"vendorId":"id-1"}`,
+			},
+		},
+		{
+			name:      "nested path through reference and inline object",
+			overrides: map[string]interface{}{"User.address.city": "Paris", "User.billing.city": "Lyon", "userId": "u-1"},
+			expected: map[string]string{
+				"User":    `{"userId":"u-1","address":{"city":"Paris"},"billing":{"city":"Lyon"}}`,
+				"Address": `{"city":"dl2INvNSQT"}`,
+			},
+		},
+		{
+			name:      "path from referenced schema",
+			overrides: map[string]interface{}{"Address.city": "Paris", "*.*.city": "Lyon"},
+			expected: map[string]string{
+				"User":    `{"userId":"dl2INvNSQT","address":{"city":"Paris"},"billing":{"city":"Lyon"}}`,
+				"Address": `{"city":"Paris"}`,
+			},
+		},
+		{
+			name:      "path wins over plain name",
+			overrides: map[string]interface{}{"code": 500, "Product.code": 404},
+			expected: map[string]string{
+				"Product":       `{"code":404,"vendorId":"dl2INvNSQT"}`,
+				"ErrorResponse": `{"code":500}`,
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			for name, expected := range test.expected {
+				result, err := schema.ConvertToExamples([]byte(openapi), schema.ExampleOptions{
+					SchemaNames:    []string{name},
+					Seed:           42,
+					FieldOverrides: test.overrides,
+				})
+				require.NoError(t, err)
+				assert.JSONEq(t, expected, string(result.Examples[name]))
+			}
+		})
+	}
+}
+
+func TestConvertToExamplesFieldOverrideInvalidPath(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		key     string
+		wantErr string
+	}{
+		{
+			name:    "empty segment",
+			key:     "User..city",
+			wantErr: `invalid FieldOverrides key "User..city": empty path segment`,
+		},
+		{
+			name:    "malformed pattern",
+			key:     "User.[city",
+			wantErr: `invalid FieldOverrides key "User.[city": syntax error in pattern`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := schema.ConvertToExamples([]byte(`openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    User:
+      type: object
+`), schema.ExampleOptions{
+				IncludeAll:     true,
+				FieldOverrides: map[string]interface{}{test.key: "x"},
+			})
+			require.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
+
 func TestConvertToExamplesRandomDefaults(t *testing.T) {
 	for _, test := range []struct {
 		name     string
@@ -1303,6 +1448,207 @@ components:
 	}
 }
 
+func TestConvertToExamplesOverridePrecedence(t *testing.T) {
+	openapi := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    ErrorResponse:
+      type: object
+      properties:
+        code:
+          type: integer
+          example: 404
+        status:
+          type: string
+          default: not_found
+        message:
+          type: string
+    Page:
+      type: object
+      example:
+        total: 3
+        items:
+          - code: 1
+          - code: 2
+      properties:
+        total:
+          type: integer
+        items:
+          type: array
+          items:
+            type: object
+            properties:
+              code:
+                type: integer
+`
+	overrides := map[string]interface{}{"code": 500, "ErrorResponse.status": "error", "Page.total": 10}
+
+	for _, test := range []struct {
+		name       string
+		precedence schema.OverridePrecedence
+		expected   map[string]string
+	}{
+		{
+			name: "schema by default",
+			expected: map[string]string{
+				"ErrorResponse": `{"code":404,"status":"not_found","message":"This is a message"}`,
+				"Page":          `{"total":3,"items":[{"code":1},{"code":2}]}`,
+			},
+		},
+		{
+			name:       "schema",
+			precedence: schema.OverridePrecedenceSchema,
+			expected: map[string]string{
+				"ErrorResponse": `{"code":404,"status":"not_found","message":"This is a message"}`,
+			},
+		},
+		{
+			name:       "override",
+			precedence: schema.OverridePrecedenceOverride,
+			expected: map[string]string{
+				"ErrorResponse": `{"code":500,"status":"error","message":"This is a message"}`,
+				"Page":          `{"total":10,"items":[{"code":500},{"code":500}]}`,
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := schema.ConvertToExamples([]byte(openapi), schema.ExampleOptions{
+				IncludeAll:         true,
+				Seed:               42,
+				FieldOverrides:     overrides,
+				OverridePrecedence: test.precedence,
+			})
+			require.NoError(t, err)
+			for name, expected := range test.expected {
+				assert.JSONEq(t, expected, string(result.Examples[name]))
+			}
+		})
+	}
+}
+
+func TestConvertToExamplesInvalidOverridePrecedence(t *testing.T) {
+	_, err := schema.ConvertToExamples([]byte(`openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    User:
+      type: object
+`), schema.ExampleOptions{
+		IncludeAll:         true,
+		OverridePrecedence: "always",
+	})
+	require.ErrorContains(t, err, `unknown OverridePrecedence "always": must be schema or override`)
+}
+
+func TestConvertToExamplesValueFunc(t *testing.T) {
+	openapi := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Status:
+      type: string
+      enum: [active, inactive]
+    Address:
+      type: object
+      properties:
+        city:
+          type: string
+          description: City name
+    User:
+      type: object
+      properties:
+        id:
+          type: integer
+        code:
+          type: string
+          default: fixed
+        status:
+          $ref: '#/components/schemas/Status'
+        address:
+          $ref: '#/components/schemas/Address'
+        tags:
+          type: array
+          minItems: 2
+          maxItems: 2
+          items:
+            type: string
+            format: hostname
+`
+	var calls []schema.ExampleFieldInfo
+	next := 0
+	valueFunc := func(schemaName, fieldPath string, info schema.ExampleFieldInfo) (interface{}, bool) {
+		calls = append(calls, info)
+		switch fieldPath {
+		case "id":
+			next++
+			return next, true
+		case "address.city":
+			return "Fixture City", true
+		case "code":
+			return "forced", true
+		case "tags":
+			return "host-" + schemaName, true
+		}
+		return nil, false
+	}
+
+	result, err := schema.ConvertToExamples([]byte(openapi), schema.ExampleOptions{
+		SchemaNames:    []string{"User"},
+		Seed:           42,
+		FieldOverrides: map[string]interface{}{"User.id": 7},
+		ValueFunc:      valueFunc,
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":7,"code":"fixed","status":"active","address":{"city":"Fixture City"},"tags":["host-User","host-User"]}`,
+		string(result.Examples["User"]))
+	assert.Equal(t, []schema.ExampleFieldInfo{
+		{Path: "address.city", Field: "city", Schema: "Address", Type: "string", Description: "City name"},
+		{Path: "tags", Field: "tags", Schema: "User", Type: "string", Format: "hostname"},
+		{Path: "tags", Field: "tags", Schema: "User", Type: "string", Format: "hostname"},
+	}, calls)
+
+	result, err = schema.ConvertToExamples([]byte(openapi), schema.ExampleOptions{
+		SchemaNames:        []string{"User"},
+		Seed:               42,
+		ValueFunc:          valueFunc,
+		OverridePrecedence: schema.OverridePrecedenceOverride,
+	})
+	require.NoError(t, err)
+	var user map[string]interface{}
+	require.NoError(t, json.Unmarshal(result.Examples["User"], &user))
+	assert.Equal(t, float64(1), user["id"])
+	assert.Equal(t, "forced", user["code"])
+}
+
+func TestConvertToExamplesValueFuncTypeMismatch(t *testing.T) {
+	result, err := schema.ConvertToExamples([]byte(`openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        age:
+          type: integer
+`), schema.ExampleOptions{
+		SchemaNames: []string{"User"},
+		ValueFunc: func(string, string, schema.ExampleFieldInfo) (interface{}, bool) {
+			return "old", true
+		},
+	})
+	require.NoError(t, err)
+	assert.NotContains(t, result.Examples, "User")
+}
+
 func TestConvertToExamplesFieldOverrideTypeMismatch(t *testing.T) {
 	for _, test := range []struct {
 		name       string
@@ -2980,3 +3326,227 @@ components:
 		assert.Contains(t, result.Examples, "Last")
 	})
 }
+
+func TestExampleResultToYAML(t *testing.T) {
+	result := &schema.ExampleResult{
+		Examples: map[string]json.RawMessage{
+			"User":  json.RawMessage(`{"id":7,"name":"Alice","tags":["a","b"]}`),
+			"Count": json.RawMessage(`"42"`),
+		},
+	}
+
+	out, err := result.ToYAML()
+	require.NoError(t, err)
+
+	assert.Equal(t, `Count: "42"
+User:
+  id: 7
+  name: Alice
+  tags:
+    - a
+    - b
+`, string(out))
+}
+
+func TestExampleResultWriteBackToSpec(t *testing.T) {
+	given := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    # Users of the system
+    User:
+      type: object
+      properties:
+        id:
+          type: integer
+        name:
+          type: string # display name
+      example:
+        id: 1
+    Address:
+      type: object
+      properties:
+        city:
+          type: string
+`
+	result := &schema.ExampleResult{
+		Examples: map[string]json.RawMessage{
+			"User": json.RawMessage(`{"id":7,"name":"Alice"}`),
+		},
+	}
+
+	out, err := result.WriteBackToSpec([]byte(given))
+	require.NoError(t, err)
+
+	assert.Equal(t, `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    # Users of the system
+    User:
+      type: object
+      properties:
+        id:
+          type: integer
+        name:
+          type: string # display name
+      example:
+        id: 7
+        name: Alice
+    Address:
+      type: object
+      properties:
+        city:
+          type: string
+`, string(out))
+
+	t.Run("generated examples round trip", func(t *testing.T) {
+		examples, err := schema.ConvertToExamples([]byte(given), schema.ExampleOptions{
+			IncludeAll: true,
+			Seed:       42,
+		})
+		require.NoError(t, err)
+
+		out, err := examples.WriteBackToSpec([]byte(given))
+		require.NoError(t, err)
+
+		again, err := schema.ConvertToExamples(out, schema.ExampleOptions{IncludeAll: true, Seed: 1})
+		require.NoError(t, err)
+		assert.JSONEq(t, string(examples.Examples["User"]), string(again.Examples["User"]))
+		assert.JSONEq(t, string(examples.Examples["Address"]), string(again.Examples["Address"]))
+	})
+
+	t.Run("missing components", func(t *testing.T) {
+		_, err := result.WriteBackToSpec([]byte("openapi: 3.0.0\n"))
+		require.ErrorContains(t, err, "document has no components/schemas")
+	})
+}
+
+func TestConvertToExamplesSelfValidate(t *testing.T) {
+	openapi := []byte(`openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Order:
+      type: object
+      required: [id, quantity]
+      properties:
+        id:
+          type: string
+          format: uuid
+        quantity:
+          type: integer
+          minimum: 1
+          maximum: 10
+        note:
+          type: string
+          maxLength: 5
+    Currency:
+      type: object
+      properties:
+        code:
+          type: string
+          pattern: '^[A-Z]{3}$'
+`)
+
+	for _, test := range []struct {
+		name string
+		opts schema.ExampleOptions
+	}{
+		{name: "typical", opts: schema.ExampleOptions{SchemaNames: []string{"Order"}, Seed: 42, SelfValidate: true}},
+		{name: "boundary", opts: schema.ExampleOptions{SchemaNames: []string{"Order"}, Seed: 42, SelfValidate: true, Mode: schema.ExampleModeBoundary}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := schema.ConvertToExamples(openapi, test.opts)
+			require.NoError(t, err)
+			assert.Contains(t, result.Examples, "Order")
+		})
+	}
+
+	_, err := schema.ConvertToExamples(openapi, schema.ExampleOptions{
+		SchemaNames:  []string{"Currency"},
+		Seed:         42,
+		SelfValidate: true,
+	})
+	require.ErrorContains(t, err, "generated example for 'Currency' is invalid: /code: ")
+
+	_, err = schema.ConvertToExamples(openapi, schema.ExampleOptions{
+		SchemaNames:  []string{"Currency"},
+		Seed:         42,
+		SelfValidate: true,
+		FieldOverrides: map[string]interface{}{
+			"code": "USD",
+		},
+	})
+	require.NoError(t, err)
+}
+
+func TestConvertToExamplesSelfValidateSortsViolations(t *testing.T) {
+	openapi := []byte(`openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Currency:
+      type: object
+      properties:
+        symbol:
+          type: string
+          pattern: '^[$]$'
+        code:
+          type: string
+          pattern: '^[A-Z]{3}$'
+        name:
+          type: string
+          pattern: '^[A-Z][a-z]+$'
+`)
+
+	var first string
+	for i := 0; i < 10; i++ {
+		_, err := schema.ConvertToExamples(openapi, schema.ExampleOptions{
+			SchemaNames:  []string{"Currency"},
+			Seed:         42,
+			SelfValidate: true,
+		})
+		require.Error(t, err)
+		if i == 0 {
+			first = err.Error()
+			require.Regexp(t, `invalid: /code: .*; /name: .*; /symbol: `, first)
+			continue
+		}
+		assert.Equal(t, first, err.Error())
+	}
+}
+
+func TestConvertToExamplesSelfValidateConflicts(t *testing.T) {
+	openapi := []byte(`openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Order:
+      type: object
+`)
+
+	_, err := schema.ConvertToExamples(openapi, schema.ExampleOptions{
+		IncludeAll:   true,
+		SelfValidate: true,
+		Mode:         schema.ExampleModeInvalid,
+	})
+	require.ErrorContains(t, err, "SelfValidate cannot be combined with Mode invalid")
+
+	_, err = schema.ConvertToExamples(openapi, schema.ExampleOptions{
+		IncludeAll:   true,
+		SelfValidate: true,
+		JSONTagCase:  schema.JSONTagCaseSnake,
+	})
+	require.ErrorContains(t, err, "SelfValidate cannot be combined with JSONTagCase snake")
+}
@@ -0,0 +1,144 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const excludeSchemasSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        id:
+          type: string
+        metadata:
+          $ref: '#/components/schemas/VendorMetadata'
+        audits:
+          type: array
+          items:
+            $ref: '#/components/schemas/VendorAudit'
+    VendorMetadata:
+      type: object
+      x-proto-skip: true
+      properties:
+        trace:
+          type: string
+    VendorAudit:
+      type: object
+      properties:
+        actor:
+          type: string
+`
+
+func TestConvertExcludeSchemas(t *testing.T) {
+	result, err := schema.Convert([]byte(excludeSchemasSpec), schema.ConvertOptions{
+		PackageName:    "testpkg",
+		PackagePath:    "github.com/example/proto/v1",
+		ExcludeSchemas: []string{"VendorAudit"},
+	})
+	require.NoError(t, err)
+
+	proto := string(result.Protobuf)
+	assert.Contains(t, proto, `bytes metadata = 2 [json_name = "metadata"];`)
+	assert.Contains(t, proto, `repeated bytes audits = 3 [json_name = "audits"];`)
+	assert.NotContains(t, proto, "message VendorMetadata")
+	assert.NotContains(t, proto, "message VendorAudit")
+
+	assert.Contains(t, result.TypeMap, "Order")
+	assert.NotContains(t, result.TypeMap, "VendorMetadata")
+	assert.NotContains(t, result.TypeMap, "VendorAudit")
+	assert.Empty(t, result.TypeMap["Order"].Dependencies)
+}
+
+func TestConvertToStructExcludeSchemas(t *testing.T) {
+	result, err := schema.ConvertToStruct([]byte(excludeSchemasSpec), schema.ConvertOptions{
+		GoFormat:       schema.GoFormatNone,
+		GoPackagePath:  "github.com/example/types/v1",
+		ExcludeSchemas: []string{"VendorAudit"},
+	})
+	require.NoError(t, err)
+
+	golang := string(result.Golang)
+	assert.Contains(t, golang, "Metadata json.RawMessage `json:\"metadata\"`")
+	assert.Contains(t, golang, "Audits []json.RawMessage `json:\"audits\"`")
+	assert.NotContains(t, golang, "type VendorMetadata struct")
+	assert.NotContains(t, golang, "type VendorAudit struct")
+}
+
+func TestConvertExcludeSchemasErrors(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		spec    string
+		exclude []string
+		wantErr string
+	}{
+		{
+			name:    "unknown schema",
+			spec:    excludeSchemasSpec,
+			exclude: []string{"Shipment"},
+			wantErr: "excluded schema 'Shipment' not found in components/schemas",
+		},
+		{
+			name: "invalid extension",
+			spec: `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Helper:
+      type: object
+      x-proto-skip: sometimes
+      properties:
+        id:
+          type: string
+`,
+			wantErr: "schema 'Helper': x-proto-skip must be true or false, got: sometimes",
+		},
+		{
+			name: "union variant",
+			spec: `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+      discriminator:
+        propertyName: petType
+    Dog:
+      type: object
+      properties:
+        petType:
+          type: string
+    Cat:
+      type: object
+      properties:
+        petType:
+          type: string
+`,
+			exclude: []string{"Cat"},
+			wantErr: "union variant 'Cat' is excluded from generation",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := schema.Convert([]byte(test.spec), schema.ConvertOptions{
+				PackageName:    "testpkg",
+				PackagePath:    "github.com/example/proto/v1",
+				ExcludeSchemas: test.exclude,
+			})
+			require.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const extraTagsSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    User:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: string
+          x-oapi-codegen-extra-tags:
+            db: user_id
+            validate: required,uuid
+        nickname:
+          type: string
+        address:
+          $ref: '#/components/schemas/Address'
+    Address:
+      type: object
+      properties:
+        street:
+          type: string
+`
+
+func TestConvertToStructExtraTags(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		opts     schema.ConvertOptions
+		wantTags []string
+	}{
+		{
+			name: "extension only",
+			wantTags: []string{
+				"ID string `json:\"id\" db:\"user_id\" validate:\"required,uuid\"`",
+				"Nickname string `json:\"nickname\"`",
+			},
+		},
+		{
+			name: "extra tags",
+			opts: schema.ConvertOptions{ExtraTags: []string{"yaml", "db"}},
+			wantTags: []string{
+				"ID string `json:\"id\" yaml:\"id\" db:\"user_id\" validate:\"required,uuid\"`",
+				"Nickname string `json:\"nickname\" yaml:\"nickname\" db:\"nickname\"`",
+				"Address *Address `json:\"address\" yaml:\"address\" db:\"address\"`",
+				"Street string `json:\"street\" yaml:\"street\" db:\"street\"`",
+			},
+		},
+		{
+			name: "extra tags follow omitempty",
+			opts: schema.ConvertOptions{ExtraTags: []string{"yaml"}, OmitEmpty: schema.OmitEmptyOptional},
+			wantTags: []string{
+				"ID string `json:\"id\" yaml:\"id\" db:\"user_id\" validate:\"required,uuid\"`",
+				"Nickname string `json:\"nickname,omitempty\" yaml:\"nickname,omitempty\"`",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.opts.GoPackagePath = "github.com/example/types"
+			test.opts.GoFormat = schema.GoFormatNone
+			result, err := schema.ConvertToStruct([]byte(extraTagsSpec), test.opts)
+			require.NoError(t, err)
+
+			for _, tag := range test.wantTags {
+				assert.Contains(t, string(result.Golang), tag)
+			}
+		})
+	}
+}
+
+func TestConvertToStructExtraTagsErrors(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		extraTags []string
+		extension string
+		wantErr   string
+	}{
+		{
+			name:      "json tag",
+			extraTags: []string{"json"},
+			wantErr:   "ExtraTags: tag 'json' is always generated",
+		},
+		{
+			name:      "invalid key",
+			extraTags: []string{"my tag"},
+			wantErr:   "ExtraTags: invalid tag key 'my tag'",
+		},
+		{
+			name:      "repeated key",
+			extraTags: []string{"yaml", "yaml"},
+			wantErr:   "ExtraTags: tag 'yaml' is listed twice",
+		},
+		{
+			name:      "extension not a map",
+			extension: "x-oapi-codegen-extra-tags: yaml",
+			wantErr:   "property 'id' in schema 'User': x-oapi-codegen-extra-tags must be a map of tag keys to values",
+		},
+		{
+			name:      "extension value with backquote",
+			extension: "x-oapi-codegen-extra-tags: {db: 'a`b'}",
+			wantErr:   "x-oapi-codegen-extra-tags: tag 'db' must be a string without backquotes",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := schema.ConvertToStruct([]byte(`openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        id:
+          type: string
+          `+test.extension+`
+`), schema.ConvertOptions{
+				GoPackagePath: "github.com/example/types",
+				ExtraTags:     test.extraTags,
+			})
+			require.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fieldNamingSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        userId:
+          type: string
+        HTTPStatus:
+          type: integer
+        created_at:
+          type: string
+        X-Request-ID:
+          type: string
+        homeAddress:
+          type: object
+          properties:
+            zipCode:
+              type: string
+`
+
+func TestConvertProtoFieldNaming(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		naming   schema.ProtoFieldNaming
+		expected string
+	}{
+		{
+			name:   "preserve by default",
+			naming: "",
+			expected: `message User {
+  message HomeAddress {
+    string zipCode = 1 [json_name = "zipCode"];
+  }
+
+  string userId = 1 [json_name = "userId"];
+  int32 HTTPStatus = 2 [json_name = "HTTPStatus"];
+  string created_at = 3 [json_name = "created_at"];
+  string X_Request_ID = 4 [json_name = "X-Request-ID"];
+  HomeAddress homeAddress = 5 [json_name = "homeAddress"];
+}`,
+		},
+		{
+			name:   "snake_case",
+			naming: schema.ProtoFieldNamingSnakeCase,
+			expected: `message User {
+  message HomeAddress {
+    string zip_code = 1 [json_name = "zipCode"];
+  }
+
+  string user_id = 1 [json_name = "userId"];
+  int32 http_status = 2 [json_name = "HTTPStatus"];
+  string created_at = 3 [json_name = "created_at"];
+  string x_request_id = 4 [json_name = "X-Request-ID"];
+  HomeAddress home_address = 5 [json_name = "homeAddress"];
+}`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := schema.Convert([]byte(fieldNamingSpec), schema.ConvertOptions{
+				PackageName:      "testpkg",
+				PackagePath:      "github.com/example/proto/v1",
+				ProtoFieldNaming: test.naming,
+			})
+			require.NoError(t, err)
+			assert.Contains(t, string(result.Protobuf), test.expected)
+		})
+	}
+}
+
+func TestConvertProtoFieldNamingDuplicates(t *testing.T) {
+	result, err := schema.Convert([]byte(`openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        userId:
+          type: string
+        user_id:
+          type: string
+`), schema.ConvertOptions{
+		PackageName:      "testpkg",
+		PackagePath:      "github.com/example/proto/v1",
+		ProtoFieldNaming: schema.ProtoFieldNamingSnakeCase,
+	})
+	require.NoError(t, err)
+
+	// Both properties snake_case to user_id; the second is made unique
+	assert.Contains(t, string(result.Protobuf), `string user_id = 1 [json_name = "userId"];`)
+	assert.Contains(t, string(result.Protobuf), `string user_id_2 = 2 [json_name = "user_id"];`)
+}
+
+func TestConvertProtoFieldNamingInvalid(t *testing.T) {
+	_, err := schema.Convert([]byte(fieldNamingSpec), schema.ConvertOptions{
+		PackageName:      "testpkg",
+		PackagePath:      "github.com/example/proto/v1",
+		ProtoFieldNaming: "camel",
+	})
+	require.ErrorContains(t, err, `unknown ProtoFieldNaming "camel": must be preserve or snake_case`)
+}
+
+const protoFieldNameSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        userId:
+          type: string
+          x-proto-field-name: id
+        emailAddress:
+          type: string
+        billingAddress:
+          $ref: '#/components/schemas/Address'
+          x-proto-field-name: billing
+        homeAddress:
+          type: object
+          properties:
+            zipCode:
+              type: string
+              x-proto-field-name: postcode
+    Address:
+      type: object
+      properties:
+        street:
+          type: string
+`
+
+func TestConvertProtoFieldName(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		naming   schema.ProtoFieldNaming
+		expected string
+	}{
+		{
+			name: "preserve",
+			expected: `message User {
+  message HomeAddress {
+    string postcode = 1 [json_name = "zipCode"];
+  }
+
+  string id = 1 [json_name = "userId"];
+  string emailAddress = 2 [json_name = "emailAddress"];
+  Address billing = 3 [json_name = "billingAddress"];
+  HomeAddress homeAddress = 4 [json_name = "homeAddress"];
+}`,
+		},
+		{
+			name:   "snake_case leaves x-proto-field-name as given",
+			naming: schema.ProtoFieldNamingSnakeCase,
+			expected: `message User {
+  message HomeAddress {
+    string postcode = 1 [json_name = "zipCode"];
+  }
+
+  string id = 1 [json_name = "userId"];
+  string email_address = 2 [json_name = "emailAddress"];
+  Address billing = 3 [json_name = "billingAddress"];
+  HomeAddress home_address = 4 [json_name = "homeAddress"];
+}`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := schema.Convert([]byte(protoFieldNameSpec), schema.ConvertOptions{
+				PackageName:      "testpkg",
+				PackagePath:      "github.com/example/proto/v1",
+				ProtoFieldNaming: test.naming,
+			})
+			require.NoError(t, err)
+			assert.Contains(t, string(result.Protobuf), test.expected)
+			// Deliberate names are not reported as renames
+			assert.Empty(t, result.Renames)
+		})
+	}
+}
+
+func TestConvertProtoFieldNameErrors(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		properties string
+		err        string
+	}{
+		{
+			name: "invalid name",
+			properties: `
+        userId:
+          type: string
+          x-proto-field-name: user-id`,
+			err: "schema 'User': property 'userId' x-proto-field-name must be a proto field name (letters, digits and underscores, starting with a letter), got: user-id",
+		},
+		{
+			name: "not a string",
+			properties: `
+        userId:
+          type: string
+          x-proto-field-name: [id]`,
+			err: "x-proto-field-name must be a proto field name",
+		},
+		{
+			name: "name already used",
+			properties: `
+        id:
+          type: string
+        userId:
+          type: string
+          x-proto-field-name: id`,
+			err: "schema 'User': property 'userId' field name 'id' is already used by another property",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := schema.Convert([]byte(`openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    User:
+      type: object
+      properties:`+test.properties+"\n"), schema.ConvertOptions{
+				PackageName: "testpkg",
+				PackagePath: "github.com/example/proto/v1",
+			})
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertFixSuggestions(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		given     string
+		wantErr   string
+		wantPatch string
+	}{
+		{
+			name: "plural inline object",
+			given: `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        addresses:
+          type: object
+          properties:
+            street:
+              type: string
+`,
+			wantErr: "cannot derive message name from property 'addresses'",
+			wantPatch: `[
+				{"op": "add", "path": "/components/schemas/Address", "value": {"type": "object", "properties": {"street": {"type": "string"}}}},
+				{"op": "replace", "path": "/components/schemas/Order/properties/addresses", "value": {"$ref": "#/components/schemas/Address"}}
+			]`,
+		},
+		{
+			name: "plural inline array item avoids existing component name",
+			given: `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Item:
+      type: object
+    Order:
+      type: object
+      properties:
+        items:
+          type: array
+          items:
+            type: object
+            properties:
+              sku:
+                type: string
+`,
+			wantErr: "cannot derive message name from plural array property 'items'",
+			wantPatch: `[
+				{"op": "add", "path": "/components/schemas/Item2", "value": {"type": "object", "properties": {"sku": {"type": "string"}}}},
+				{"op": "replace", "path": "/components/schemas/Order/properties/items/items", "value": {"$ref": "#/components/schemas/Item2"}}
+			]`,
+		},
+		{
+			name: "missing discriminator with shared property",
+			given: `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+    Dog:
+      type: object
+      properties:
+        age:
+          type: integer
+        petType:
+          type: string
+    Cat:
+      type: object
+      properties:
+        petType:
+          type: string
+`,
+			wantErr: "schema 'Pet': oneOf requires discriminator",
+			wantPatch: `[
+				{"op": "add", "path": "/components/schemas/Pet/discriminator", "value": {"propertyName": "petType"}}
+			]`,
+		},
+		{
+			name: "missing discriminator without shared property",
+			given: `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+    Dog:
+      type: object
+      properties:
+        type:
+          type: string
+    Cat:
+      type: object
+`,
+			wantErr: "schema 'Pet': oneOf requires discriminator",
+			wantPatch: `[
+				{"op": "add", "path": "/components/schemas/Pet/discriminator", "value": {"propertyName": "kind"}},
+				{"op": "add", "path": "/components/schemas/Dog/properties/kind", "value": {"type": "string"}},
+				{"op": "add", "path": "/components/schemas/Cat/properties", "value": {"kind": {"type": "string"}}}
+			]`,
+		},
+		{
+			name: "mixed x-proto-number",
+			given: `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        id:
+          type: string
+          x-proto-number: 1
+        name:
+          type: string
+        email:
+          type: string
+          x-proto-number: 3
+        phone:
+          type: string
+`,
+			wantErr: "x-proto-number must be specified on all fields or none",
+			wantPatch: `[
+				{"op": "add", "path": "/components/schemas/User/properties/name/x-proto-number", "value": 2},
+				{"op": "add", "path": "/components/schemas/User/properties/phone/x-proto-number", "value": 4}
+			]`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := schema.Convert([]byte(test.given), schema.ConvertOptions{
+				PackageName: "testpkg",
+				PackagePath: "github.com/example/proto/v1",
+			})
+			require.ErrorContains(t, err, test.wantErr)
+
+			fix, ok := schema.SuggestedFix(err)
+			require.True(t, ok)
+			assert.NotEmpty(t, fix.Description)
+
+			patch, err := json.Marshal(fix.Patch)
+			require.NoError(t, err)
+			assert.JSONEq(t, test.wantPatch, string(patch))
+
+			fixed, err := schema.ApplyFix([]byte(test.given), fix)
+			require.NoError(t, err)
+
+			_, err = schema.Convert(fixed, schema.ConvertOptions{
+				PackageName: "testpkg",
+				PackagePath: "github.com/example/proto/v1",
+			})
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestConvertNoFixSuggestion(t *testing.T) {
+	given := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        name:
+          allOf:
+            - type: string
+`
+	_, err := schema.Convert([]byte(given), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+	})
+	require.Error(t, err)
+
+	_, ok := schema.SuggestedFix(err)
+	assert.False(t, ok)
+}
+
+func TestApplyFixPreservesComments(t *testing.T) {
+	given := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    # Users of the system
+    User:
+      type: object
+      properties:
+        id:
+          type: string
+          x-proto-number: 1
+        name:
+          type: string
+`
+	fixed, err := schema.ApplyFix([]byte(given), &schema.FixSuggestion{
+		Patch: []schema.PatchOperation{
+			{Op: "add", Path: "/components/schemas/User/properties/name/x-proto-number", Value: 2},
+		},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, string(fixed), "# Users of the system")
+	assert.Contains(t, string(fixed), "        name:\n          type: string\n          x-proto-number: 2\n")
+
+	_, err = schema.ApplyFix([]byte(given), &schema.FixSuggestion{
+		Patch: []schema.PatchOperation{
+			{Op: "replace", Path: "/components/schemas/Missing/type", Value: "object"},
+		},
+	})
+	require.ErrorContains(t, err, "path segment 'Missing' not found")
+}
+
+func TestPatchOperationMarshalValue(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		op       schema.PatchOperation
+		expected string
+	}{
+		{
+			name:     "add null",
+			op:       schema.PatchOperation{Op: "add", Path: "/components/schemas/User/default"},
+			expected: `{"op": "add", "path": "/components/schemas/User/default", "value": null}`,
+		},
+		{
+			name:     "replace null",
+			op:       schema.PatchOperation{Op: "replace", Path: "/components/schemas/User/default"},
+			expected: `{"op": "replace", "path": "/components/schemas/User/default", "value": null}`,
+		},
+		{
+			name:     "test",
+			op:       schema.PatchOperation{Op: "test", Path: "/openapi", Value: "3.0.0"},
+			expected: `{"op": "test", "path": "/openapi", "value": "3.0.0"}`,
+		},
+		{
+			name:     "remove",
+			op:       schema.PatchOperation{Op: "remove", Path: "/components/schemas/User/default"},
+			expected: `{"op": "remove", "path": "/components/schemas/User/default"}`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := json.Marshal(test.op)
+			require.NoError(t, err)
+			assert.JSONEq(t, test.expected, string(data))
+		})
+	}
+}
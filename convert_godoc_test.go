@@ -0,0 +1,136 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const goDocSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Status:
+      description: Lifecycle state of an account.
+      type: string
+      enum: [active, on-hold]
+    Level:
+      type: integer
+      enum: [-1, 1, 2]
+    Account:
+      description: |-
+        An account holds the balances and settings of one customer across every product they have signed up for.
+        - first list item which is long enough that it has to be wrapped onto a second line
+            code that is indented and long enough to pass the width but must never be wrapped by the generator
+      type: object
+      properties:
+        status:
+          $ref: '#/components/schemas/Status'
+        level:
+          $ref: '#/components/schemas/Level'
+        tier:
+          type: string
+          description: Pricing tier.
+          enum: [free, pro]
+        tags:
+          type: array
+          items:
+            type: string
+            enum: [new, vip]
+        note:
+          type: string
+          description: A free-form note attached by support staff, shown on the account page and in exports.
+`
+
+func TestConvertToStructGoDoc(t *testing.T) {
+	result, err := schema.ConvertToStruct([]byte(goDocSpec), schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
+		GoPackagePath: "github.com/example/types",
+	})
+	require.NoError(t, err)
+
+	golang := string(result.Golang)
+	for _, expected := range []string{
+		"// Lifecycle state of an account.\ntype Status string\n\nconst (\n" +
+			"\tStatusActive Status = \"active\"\n\tStatusOnHold Status = \"on-hold\"\n)\n",
+		"type Level int32\n\nconst (\n\tLevelMinus1 Level = -1\n\tLevel1 Level = 1\n\tLevel2 Level = 2\n)\n",
+		"// An account holds the balances and settings of one customer across every\n" +
+			"// product they have signed up for.\n" +
+			"// - first list item which is long enough that it has to be wrapped onto a\n" +
+			"//   second line\n" +
+			"//     code that is indented and long enough to pass the width but must never be wrapped by the generator\n" +
+			"type Account struct {\n",
+		"\tStatus Status `json:\"status\"`\n\tLevel Level `json:\"level\"`\n",
+		"\t// Pricing tier.\n\t// enum: [free, pro]\n\tTier string `json:\"tier\"`\n",
+		"\t// enum: [new, vip]\n\tTags []string `json:\"tags\"`\n",
+		"\t// A free-form note attached by support staff, shown on the account page and\n\t// in exports.\n",
+	} {
+		assert.Contains(t, golang, expected)
+	}
+
+	assert.Equal(t, "Status", result.TypeMap["Status"].GeneratedName)
+	assert.Equal(t, 2, result.TypeMap["Status"].FieldCount)
+	assert.Equal(t, 3, result.TypeMap["Level"].FieldCount)
+}
+
+func TestConvertGoDocProtoEnums(t *testing.T) {
+	given := goDocSpec + `    Owner:
+      type: object
+      properties:
+        status:
+          $ref: '#/components/schemas/Status'
+        level:
+          $ref: '#/components/schemas/Level'
+        pet:
+          $ref: '#/components/schemas/Pet'
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+      discriminator:
+        propertyName: petType
+    Dog:
+      type: object
+      properties:
+        petType:
+          type: string
+    Cat:
+      type: object
+      properties:
+        petType:
+          type: string
+`
+	result, err := schema.Convert([]byte(given), schema.ConvertOptions{
+		PackageName:   "testpkg",
+		PackagePath:   "github.com/example/proto",
+		GoPackagePath: "github.com/example/types",
+	})
+	require.NoError(t, err)
+
+	// Enums outside the Go output are held in their underlying scalar type
+	golang := string(result.Golang)
+	assert.Contains(t, golang, "\t// Lifecycle state of an account.\n\t// enum: [active, on-hold]\n\tStatus string `json:\"status\"`\n")
+	assert.Contains(t, golang, "\t// enum: [-1, 1, 2]\n\tLevel int32 `json:\"level\"`\n")
+	assert.NotContains(t, golang, "type Status")
+}
+
+func TestConvertToStructEnumInvalidType(t *testing.T) {
+	given := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Ratio:
+      type: number
+      enum: [0.5, 1.5]
+`
+	_, err := schema.ConvertToStruct([]byte(given), schema.ConvertOptions{
+		GoPackagePath: "github.com/example/types",
+	})
+	require.ErrorContains(t, err, "schema 'Ratio': enum must have type string or integer")
+}
@@ -0,0 +1,143 @@
+package schema_test
+
+import (
+	"go/format"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const goFormatSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Owner:
+      description: Owns pets.
+      type: object
+      required: [pet]
+      properties:
+        name:
+          type: string
+        pet:
+          $ref: '#/components/schemas/Pet'
+        status:
+          $ref: '#/components/schemas/Status'
+        extra:
+          $ref: '#/components/schemas/Extra'
+    Extra:
+      type: object
+    Status:
+      type: string
+      enum: [active, on-hold]
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+      discriminator:
+        propertyName: petType
+    Dog:
+      type: object
+      properties:
+        petType:
+          type: string
+        bark:
+          type: string
+    Cat:
+      type: object
+      properties:
+        petType:
+          type: string
+`
+
+func TestConvertToStructGoFormat(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		unionStyle schema.UnionStyle
+		goFormat   schema.GoFormat
+	}{
+		{name: "default"},
+		{name: "gofmt", goFormat: schema.GoFormatGofmt},
+		{name: "gofumpt", goFormat: schema.GoFormatGofumpt},
+		{name: "gofumpt interface unions", goFormat: schema.GoFormatGofumpt, unionStyle: schema.UnionStyleInterface},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := schema.ConvertToStruct([]byte(goFormatSpec), schema.ConvertOptions{
+				GoPackagePath:  "github.com/example/types",
+				UnionStyle:     test.unionStyle,
+				GoFormat:       test.goFormat,
+				StrictGoFormat: true,
+			})
+			require.NoError(t, err)
+
+			formatted, err := format.Source(result.Golang)
+			require.NoError(t, err)
+			assert.Equal(t, string(formatted), string(result.Golang))
+		})
+	}
+}
+
+func TestConvertToStructGoFormatNone(t *testing.T) {
+	result, err := schema.ConvertToStruct([]byte(goFormatSpec), schema.ConvertOptions{
+		GoPackagePath: "github.com/example/types",
+		GoFormat:      schema.GoFormatNone,
+	})
+	require.NoError(t, err)
+
+	formatted, err := format.Source(result.Golang)
+	require.NoError(t, err)
+	assert.NotEqual(t, string(formatted), string(result.Golang))
+}
+
+func TestConvertToStructGofumpt(t *testing.T) {
+	result, err := schema.ConvertToStruct([]byte(goFormatSpec), schema.ConvertOptions{
+		GoPackagePath: "github.com/example/types",
+		GoFormat:      schema.GoFormatGofumpt,
+	})
+	require.NoError(t, err)
+
+	golang := string(result.Golang)
+	assert.Contains(t, golang, "import (\n\t\"encoding/json\"\n\t\"fmt\"\n\t\"strings\"\n)\n")
+	assert.Contains(t, golang, "type Extra struct{}\n")
+	assert.Contains(t, golang, "// Owns pets.\ntype Owner struct {\n\tName   string `json:\"name\"`\n")
+	assert.NotContains(t, golang, "{\n\n")
+	assert.NotContains(t, golang, "\n\n\t}")
+}
+
+func TestConvertGoFormatFailure(t *testing.T) {
+	given := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Owner:
+      type: object
+      properties:
+        labels:
+          type: string
+          x-go-type: map[string
+`
+	opts := schema.ConvertOptions{
+		GoPackagePath: "github.com/example/types",
+		GoFormat:      schema.GoFormatGofmt,
+	}
+	result, err := schema.ConvertToStruct([]byte(given), opts)
+	require.NoError(t, err)
+	assert.Contains(t, string(result.Golang), "\tLabels map[string `json:\"labels\"`\n")
+
+	opts.StrictGoFormat = true
+	_, err = schema.ConvertToStruct([]byte(given), opts)
+	require.ErrorContains(t, err, "formatting generated Go:")
+}
+
+func TestConvertGoFormatInvalid(t *testing.T) {
+	_, err := schema.ConvertToStruct([]byte(goFormatSpec), schema.ConvertOptions{
+		GoPackagePath: "github.com/example/types",
+		GoFormat:      "prettier",
+	})
+	require.ErrorContains(t, err, `unknown GoFormat "prettier": must be none, gofmt or gofumpt`)
+}
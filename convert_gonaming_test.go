@@ -0,0 +1,226 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const goNamingSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Product:
+      type: object
+      properties:
+        productId:
+          type: string
+        imageUrls:
+          type: array
+          items:
+            type: string
+        httpStatus:
+          type: integer
+        api_key:
+          type: string
+        sku:
+          type: string
+`
+
+func TestConvertToStructGoInitialisms(t *testing.T) {
+	result, err := schema.ConvertToStruct([]byte(goNamingSpec), schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
+		GoPackagePath: "github.com/example/types/v1",
+	})
+	require.NoError(t, err)
+
+	golang := string(result.Golang)
+	for _, field := range []string{
+		"\tProductID string `json:\"productId\"`\n",
+		"\tImageURLs []string `json:\"imageUrls\"`\n",
+		"\tHTTPStatus int32 `json:\"httpStatus\"`\n",
+		"\tAPIKey string `json:\"api_key\"`\n",
+		"\tSku string `json:\"sku\"`\n",
+	} {
+		assert.Contains(t, golang, field)
+	}
+}
+
+func TestConvertToStructGoNameFunc(t *testing.T) {
+	result, err := schema.ConvertToStruct([]byte(goNamingSpec), schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
+		GoPackagePath: "github.com/example/types/v1",
+		GoNameFunc: func(property string) string {
+			if property == "sku" {
+				return "StockKeepingUnit"
+			}
+			return ""
+		},
+	})
+	require.NoError(t, err)
+
+	golang := string(result.Golang)
+	assert.Contains(t, golang, "\tStockKeepingUnit string `json:\"sku\"`\n")
+	assert.Contains(t, golang, "\tProductID string `json:\"productId\"`\n")
+}
+
+func TestConvertToStructGoFieldName(t *testing.T) {
+	result, err := schema.ConvertToStruct([]byte(`openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Product:
+      type: object
+      properties:
+        id:
+          type: string
+          x-go-field-name: ProductIdentifier
+        sku:
+          type: string
+          x-go-field-name: SKU
+        price:
+          $ref: '#/components/schemas/Money'
+          x-go-field-name: UnitPrice
+        shipping:
+          type: object
+          properties:
+            weight_kg:
+              type: number
+              x-go-field-name: Weight
+    Money:
+      type: object
+      properties:
+        units:
+          type: integer
+`), schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
+		GoPackagePath: "github.com/example/types/v1",
+		// x-go-field-name takes precedence over GoNameFunc
+		GoNameFunc: func(property string) string {
+			if property == "sku" {
+				return "StockKeepingUnit"
+			}
+			return ""
+		},
+	})
+	require.NoError(t, err)
+
+	golang := string(result.Golang)
+	for _, field := range []string{
+		"\tProductIdentifier string `json:\"id\"`\n",
+		"\tSKU string `json:\"sku\"`\n",
+		"\tUnitPrice *Money `json:\"price\"`\n",
+		"\tShipping *ProductShipping `json:\"shipping\"`\n",
+		"\tWeight float64 `json:\"weight_kg\"`\n",
+	} {
+		assert.Contains(t, golang, field)
+	}
+}
+
+func TestConvertGoFieldNameUnion(t *testing.T) {
+	result, err := schema.Convert([]byte(`openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+      discriminator:
+        propertyName: kind
+    Dog:
+      type: object
+      properties:
+        kind:
+          type: string
+          x-go-field-name: Type
+        id:
+          type: string
+          x-go-field-name: ID
+    Cat:
+      type: object
+      properties:
+        kind:
+          type: string
+`), schema.ConvertOptions{
+		GoFormat:    schema.GoFormatNone,
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+	})
+	require.NoError(t, err)
+
+	golang := string(result.Golang)
+	assert.Contains(t, golang, "\tType string `json:\"kind\"`\n")
+	assert.Contains(t, golang, "\tID string `json:\"id\"`\n")
+	// MarshalJSON sets the discriminator through the renamed field
+	assert.Contains(t, golang, "if d := strings.ToLower(v.Type);")
+	assert.Contains(t, golang, "if d := strings.ToLower(v.Kind);")
+}
+
+func TestConvertToStructGoFieldNameErrors(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		properties string
+		err        string
+	}{
+		{
+			name: "unexported",
+			properties: `
+        id:
+          type: string
+          x-go-field-name: identifier`,
+			err: "property 'id' in schema 'Product': x-go-field-name must be an exported Go identifier (letters, digits and underscores, starting with an upper-case letter), got: identifier",
+		},
+		{
+			name: "invalid identifier",
+			properties: `
+        id:
+          type: string
+          x-go-field-name: Product-ID`,
+			err: "x-go-field-name must be an exported Go identifier",
+		},
+		{
+			name: "name already used",
+			properties: `
+        productId:
+          type: string
+        id:
+          type: string
+          x-go-field-name: ProductID`,
+			err: "property 'id' in schema 'Product': Go field name 'ProductID' is already used by another property",
+		},
+		{
+			name: "name taken by a later property",
+			properties: `
+        id:
+          type: string
+          x-go-field-name: ProductID
+        productId:
+          type: string`,
+			err: "property 'productId' in schema 'Product': Go field name 'ProductID' is already used by another property",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := schema.ConvertToStruct([]byte(`openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Product:
+      type: object
+      properties:`+test.properties+"\n"), schema.ConvertOptions{
+				GoPackagePath: "github.com/example/types/v1",
+			})
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
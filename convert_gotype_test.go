@@ -0,0 +1,167 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertToStructGoTypeExtension(t *testing.T) {
+	openapi := []byte(`openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Money:
+      type: object
+      x-go-type: money.Money
+      x-go-import: github.com/example/money
+      properties:
+        amount:
+          type: string
+    Order:
+      type: object
+      properties:
+        id:
+          type: string
+          x-go-type: uuid.UUID
+          x-go-import: github.com/google/uuid
+        subtotal:
+          type: string
+          x-go-type: decimal.Decimal
+          x-go-import: github.com/shopspring/decimal
+        total:
+          $ref: '#/components/schemas/Money'
+        lines:
+          type: array
+          items:
+            $ref: '#/components/schemas/Money'
+        metadata:
+          type: object
+          x-go-type: map[string]string
+`)
+
+	result, err := schema.ConvertToStruct(openapi, schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
+		GoPackagePath: "github.com/example/types/v1",
+	})
+	require.NoError(t, err)
+
+	golang := string(result.Golang)
+	assert.Contains(t, golang, "ID uuid.UUID `json:\"id\"`")
+	assert.Contains(t, golang, "Subtotal decimal.Decimal `json:\"subtotal\"`")
+	assert.Contains(t, golang, "Total money.Money `json:\"total\"`")
+	assert.Contains(t, golang, "Lines []money.Money `json:\"lines\"`")
+	assert.Contains(t, golang, "Metadata map[string]string `json:\"metadata\"`")
+	assert.NotContains(t, golang, "type Money struct")
+	assert.NotContains(t, golang, "type Metadata struct")
+	assert.Contains(t, golang, "\t\"github.com/example/money\"\n\t\"github.com/google/uuid\"\n\t\"github.com/shopspring/decimal\"\n")
+
+	assert.Equal(t, []string{"uses existing Go type money.Money (x-go-type); no struct is generated"},
+		result.TypeMap["Money"].Notes)
+}
+
+func TestConvertGoTypeExtension(t *testing.T) {
+	openapi := []byte(`openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+      discriminator:
+        propertyName: petType
+    Dog:
+      type: object
+      properties:
+        petType:
+          type: string
+        born:
+          type: string
+          x-go-type: civil.Date
+          x-go-import: cloud.google.com/go/civil
+    Cat:
+      type: object
+      properties:
+        petType:
+          type: string
+`)
+
+	result, err := schema.Convert(openapi, schema.ConvertOptions{
+		GoFormat:    schema.GoFormatNone,
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+	})
+	require.NoError(t, err)
+
+	golang := string(result.Golang)
+	assert.Contains(t, golang, "Born civil.Date `json:\"born\"`")
+	assert.Contains(t, golang, "\"cloud.google.com/go/civil\"")
+
+	// The proto side ignores the extension
+	assert.NotContains(t, string(result.Protobuf), "civil")
+}
+
+func TestConvertToStructGoTypeExtensionErrors(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		schemas string
+		wantErr string
+	}{
+		{
+			name: "import without type",
+			schemas: `    Order:
+      type: object
+      properties:
+        id:
+          type: string
+          x-go-import: github.com/google/uuid
+`,
+			wantErr: "property 'id': x-go-import requires x-go-type",
+		},
+		{
+			name: "empty type",
+			schemas: `    Money:
+      type: object
+      x-go-type: ""
+`,
+			wantErr: "schema 'Money': x-go-type must be a non-empty string",
+		},
+		{
+			name: "union variant",
+			schemas: `    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+      discriminator:
+        propertyName: petType
+    Dog:
+      type: object
+      x-go-type: pets.Dog
+      properties:
+        petType:
+          type: string
+    Cat:
+      type: object
+      properties:
+        petType:
+          type: string
+`,
+			wantErr: "union 'Pet': variant 'Dog' cannot use x-go-type",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			openapi := []byte("openapi: 3.0.0\ninfo:\n  title: Test API\n  version: 1.0.0\ncomponents:\n  schemas:\n" + test.schemas)
+			_, err := schema.ConvertToStruct(openapi, schema.ConvertOptions{
+				GoPackagePath: "github.com/example/types/v1",
+			})
+			require.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertHandlers(t *testing.T) {
+	result, err := schema.Convert([]byte(`openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /v1/users.create:
+    post:
+      operationId: createUser
+      summary: creates a user
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/CreateUserRequest'
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/user_record'
+  /v1/users.get:
+    get:
+      responses:
+        '200':
+          description: ok
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/GetUserRequest'
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/user_record'
+components:
+  schemas:
+    CreateUserRequest:
+      type: object
+      properties:
+        name:
+          type: string
+    GetUserRequest:
+      type: object
+      properties:
+        id:
+          type: string
+    user_record:
+      type: object
+      properties:
+        id:
+          type: string
+`), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/users/v1",
+		Handlers:    &schema.HandlerOptions{PackageName: "server", ServiceName: "UserService"},
+	})
+	require.NoError(t, err)
+
+	expected := `package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/duh-rpc/duh-go"
+	users "github.com/example/users/v1"
+)
+
+// Paths of the operations Handler serves
+const (
+	RouteCreateUser = "/v1/users.create"
+	RouteUsersGet   = "/v1/users.get"
+)
+
+// UserService implements the operations Handler serves. Each method fills
+// resp from req; a returned error is sent to the client with duh.ReplyError.
+type UserService interface {
+	// CreateUser creates a user
+	CreateUser(ctx context.Context, req *users.CreateUserRequest, resp *users.UserRecord) error
+	UsersGet(ctx context.Context, req *users.GetUserRequest, resp *users.UserRecord) error
+}
+
+// Handler serves UserService over duh-rpc
+type Handler struct {
+	Service UserService
+}
+
+// NewHandler returns a Handler calling service
+func NewHandler(service UserService) *Handler {
+	return &Handler{Service: service}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		duh.ReplyWithCode(w, r, duh.CodeBadRequest, nil,
+			fmt.Sprintf("http method '%s' not allowed; only POST", r.Method))
+		return
+	}
+
+	switch r.URL.Path {
+	case RouteCreateUser:
+		h.handleCreateUser(w, r)
+		return
+	case RouteUsersGet:
+		h.handleUsersGet(w, r)
+		return
+	}
+	duh.ReplyWithCode(w, r, duh.CodeNotImplemented, nil, "no such method; "+r.URL.Path)
+}
+
+func (h *Handler) handleCreateUser(w http.ResponseWriter, r *http.Request) {
+	var req users.CreateUserRequest
+	if err := duh.ReadRequest(r, &req); err != nil {
+		duh.ReplyError(w, r, err)
+		return
+	}
+	var resp users.UserRecord
+	if err := h.Service.CreateUser(r.Context(), &req, &resp); err != nil {
+		duh.ReplyError(w, r, err)
+		return
+	}
+	duh.Reply(w, r, duh.CodeOK, &resp)
+}
+
+func (h *Handler) handleUsersGet(w http.ResponseWriter, r *http.Request) {
+	var req users.GetUserRequest
+	if err := duh.ReadRequest(r, &req); err != nil {
+		duh.ReplyError(w, r, err)
+		return
+	}
+	var resp users.UserRecord
+	if err := h.Service.UsersGet(r.Context(), &req, &resp); err != nil {
+		duh.ReplyError(w, r, err)
+		return
+	}
+	duh.Reply(w, r, duh.CodeOK, &resp)
+}
+`
+	assert.Equal(t, expected, string(result.Handlers))
+}
+
+func TestConvertHandlersErrors(t *testing.T) {
+	const components = `
+components:
+  schemas:
+    Request:
+      type: object
+      properties:
+        id:
+          type: string
+    Response:
+      type: object
+      properties:
+        name:
+          type: string
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+      discriminator:
+        propertyName: kind
+    Dog:
+      type: object
+      properties:
+        kind:
+          type: string
+    Cat:
+      type: object
+      properties:
+        kind:
+          type: string
+`
+	operation := func(path, request, response string) string {
+		return `
+  ` + path + `:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/` + request + `'
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/` + response + `'`
+	}
+
+	for _, test := range []struct {
+		name    string
+		paths   string
+		opts    schema.HandlerOptions
+		wantErr string
+	}{
+		{
+			name:    "no POST operations",
+			paths:   " {}",
+			wantErr: "handlers: document has no POST operations",
+		},
+		{
+			name:    "Go-located response",
+			paths:   operation("/v1/pets.get", "Request", "Pet"),
+			wantErr: "operation POST /v1/pets.get: response 'Pet' is generated as Go, not as a proto message",
+		},
+		{
+			name: "inline request",
+			paths: `
+  /v1/ping:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+      responses:
+        '200':
+          description: ok`,
+			wantErr: "operation POST /v1/ping: request must reference a schema in components/schemas",
+		},
+		{
+			name:    "duplicate method name",
+			paths:   operation("/v1/a/get", "Request", "Response") + operation("/v2/a/get", "Request", "Response"),
+			wantErr: "operation POST /v2/a/get: method name 'Get' is also used by /v1/a/get",
+		},
+		{
+			name:    "invalid service name",
+			paths:   operation("/v1/get", "Request", "Response"),
+			opts:    schema.HandlerOptions{ServiceName: "service"},
+			wantErr: "handlers: service name 'service' must be an exported Go identifier",
+		},
+		{
+			name:    "service named Handler",
+			paths:   operation("/v1/get", "Request", "Response"),
+			opts:    schema.HandlerOptions{ServiceName: "Handler"},
+			wantErr: "handlers: service name 'Handler' conflicts with the generated handler type",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := schema.Convert([]byte(`openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:`+test.paths+components), schema.ConvertOptions{
+				PackageName: "testpkg",
+				PackagePath: "github.com/example/proto/v1",
+				Handlers:    &test.opts,
+			})
+			require.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package schema_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const headerSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        name:
+          type: string
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+      discriminator:
+        propertyName: petType
+    Dog:
+      type: object
+      properties:
+        petType:
+          type: string
+    Cat:
+      type: object
+      properties:
+        petType:
+          type: string
+`
+
+func TestConvertHeader(t *testing.T) {
+	sum := sha256.Sum256([]byte(headerSpec))
+	generated := "// Code generated by openapi-schema. DO NOT EDIT.\n" +
+		"// source: sha256:" + hex.EncodeToString(sum[:]) + "\n" +
+		"// version: (devel)\n"
+
+	for _, test := range []struct {
+		name     string
+		opts     schema.ConvertOptions
+		expected string
+	}{
+		{
+			name: "none",
+		},
+		{
+			name:     "header comment",
+			opts:     schema.ConvertOptions{HeaderComment: "Copyright 2026 Example Inc.\nLicensed under MIT."},
+			expected: "// Copyright 2026 Example Inc.\n// Licensed under MIT.\n\n",
+		},
+		{
+			name:     "generated header",
+			opts:     schema.ConvertOptions{GeneratedHeader: true},
+			expected: generated + "\n",
+		},
+		{
+			name:     "both",
+			opts:     schema.ConvertOptions{GeneratedHeader: true, HeaderComment: "Copyright 2026 Example Inc."},
+			expected: generated + "//\n// Copyright 2026 Example Inc.\n\n",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.opts.PackageName = "testpkg"
+			test.opts.PackagePath = "github.com/example/proto"
+			test.opts.GoPackagePath = "github.com/example/types"
+			test.opts.BuildDescriptor = true
+			result, err := schema.Convert([]byte(headerSpec), test.opts)
+			require.NoError(t, err)
+
+			assert.True(t, strings.HasPrefix(string(result.Protobuf), test.expected+"syntax = \"proto3\";\n"))
+			assert.True(t, strings.HasPrefix(string(result.Golang), test.expected+"package types\n"))
+
+			// The header is not the package's doc comment
+			file, err := parser.ParseFile(token.NewFileSet(), "types.go", result.Golang, parser.ParseComments)
+			require.NoError(t, err)
+			assert.Nil(t, file.Doc)
+		})
+	}
+}
+
+func TestConvertToStructHeader(t *testing.T) {
+	result, err := schema.ConvertToStruct([]byte(headerSpec), schema.ConvertOptions{
+		GoPackagePath:   "github.com/example/types",
+		GeneratedHeader: true,
+	})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(result.Golang), "// Code generated by openapi-schema. DO NOT EDIT.\n"))
+}
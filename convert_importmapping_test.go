@@ -0,0 +1,119 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const importMappingSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Money:
+      type: object
+      properties:
+        currency:
+          type: string
+        units:
+          type: integer
+    Invoice:
+      type: object
+      properties:
+        total:
+          $ref: '#/components/schemas/Money'
+        lines:
+          type: array
+          items:
+            $ref: '#/components/schemas/Money'
+`
+
+func TestConvertImportMappings(t *testing.T) {
+	result, err := schema.Convert([]byte(importMappingSpec), schema.ConvertOptions{
+		PackageName:   "testpkg",
+		PackagePath:   "github.com/example/proto",
+		GoPackagePath: "github.com/example/types",
+		ImportMappings: map[string]schema.ImportMapping{
+			"Money": {
+				Proto: "acme.common.v1.Money", ProtoImport: "acme/common/v1/money.proto",
+				Go: "commonv1.Money", GoImport: "github.com/acme/common/go/commonv1",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	proto := string(result.Protobuf)
+	assert.Contains(t, proto, "import \"acme/common/v1/money.proto\";\n")
+	assert.Contains(t, proto, "  acme.common.v1.Money total = 1 [json_name = \"total\"];\n")
+	assert.Contains(t, proto, "  repeated acme.common.v1.Money lines = 2 [json_name = \"lines\"];\n")
+	assert.NotContains(t, proto, "message Money")
+	assert.Empty(t, result.Golang)
+}
+
+func TestConvertToStructImportMappings(t *testing.T) {
+	result, err := schema.ConvertToStruct([]byte(importMappingSpec), schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
+		GoPackagePath: "github.com/example/types",
+		ImportMappings: map[string]schema.ImportMapping{
+			"Money": {
+				Proto: "acme.common.v1.Money", ProtoImport: "acme/common/v1/money.proto",
+				Go: "commonv1.Money", GoImport: "github.com/acme/common/go/commonv1",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	golang := string(result.Golang)
+	assert.Contains(t, golang, "\"github.com/acme/common/go/commonv1\"")
+	assert.Contains(t, golang, "\tTotal commonv1.Money `json:\"total\"`\n")
+	assert.Contains(t, golang, "\tLines []commonv1.Money `json:\"lines\"`\n")
+	assert.NotContains(t, golang, "type Money struct")
+}
+
+func TestConvertImportMappingsErrors(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		opts     schema.ConvertOptions
+		expected string
+	}{
+		{
+			name: "missing proto type",
+			opts: schema.ConvertOptions{
+				ImportMappings: map[string]schema.ImportMapping{"Money": {Go: "commonv1.Money"}},
+			},
+			expected: "import mapping for schema 'Money' must set Proto",
+		},
+		{
+			name: "excluded and mapped",
+			opts: schema.ConvertOptions{
+				ExcludeSchemas: []string{"Money"},
+				ImportMappings: map[string]schema.ImportMapping{"Money": {Proto: "acme.common.v1.Money"}},
+			},
+			expected: "schema 'Money' cannot be both excluded and import-mapped",
+		},
+		{
+			name: "unknown schema",
+			opts: schema.ConvertOptions{
+				ImportMappings: map[string]schema.ImportMapping{"Price": {Proto: "acme.common.v1.Price"}},
+			},
+			expected: "import-mapped schema 'Price' not found in components/schemas",
+		},
+		{
+			name: "no go type",
+			opts: schema.ConvertOptions{
+				ImportMappings: map[string]schema.ImportMapping{"Money": {Proto: "acme.common.v1.Money"}},
+			},
+			expected: "schema 'Money' is import-mapped without a Go type",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.opts.GoPackagePath = "github.com/example/types"
+			_, err := schema.ConvertToStruct([]byte(importMappingSpec), test.opts)
+			require.ErrorContains(t, err, test.expected)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const inlineUnionSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Owner:
+      type: object
+      properties:
+        name:
+          type: string
+        pet:
+          description: The owner's favourite pet.
+          oneOf:
+            - $ref: '#/components/schemas/Dog'
+            - $ref: '#/components/schemas/Cat'
+          discriminator:
+            propertyName: petType
+        pets:
+          type: array
+          items:
+            oneOf:
+              - $ref: '#/components/schemas/Dog'
+              - $ref: '#/components/schemas/Cat'
+            discriminator:
+              propertyName: petType
+        home:
+          type: object
+          properties:
+            guard:
+              oneOf:
+                - $ref: '#/components/schemas/Dog'
+                - $ref: '#/components/schemas/Cat'
+              discriminator:
+                propertyName: petType
+                mapping:
+                  dog: '#/components/schemas/Dog'
+                  cat: '#/components/schemas/Cat'
+    Dog:
+      type: object
+      properties:
+        petType:
+          type: string
+        bark:
+          type: string
+    Cat:
+      type: object
+      properties:
+        petType:
+          type: string
+        meow:
+          type: string
+    Address:
+      type: object
+      properties:
+        street:
+          type: string
+`
+
+func TestConvertInlineUnion(t *testing.T) {
+	result, err := schema.Convert([]byte(inlineUnionSpec), schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
+		PackageName:   "testpkg",
+		PackagePath:   "github.com/example/proto",
+		GoPackagePath: "github.com/example/types",
+	})
+	require.NoError(t, err)
+
+	golang := string(result.Golang)
+	assert.Contains(t, golang, "\tPet *OwnerPet `json:\"pet\"`\n")
+	assert.Contains(t, golang, "\tPets []*OwnerPets `json:\"pets\"`\n")
+	assert.Contains(t, golang, "\tGuard *OwnerHomeGuard `json:\"guard\"`\n")
+	assert.Contains(t, golang, "// The owner's favourite pet.\ntype OwnerPet struct {\n\tDog *Dog `json:\"-\"`\n\tCat *Cat `json:\"-\"`\n}\n")
+	assert.Contains(t, golang, "func (u *OwnerPets) UnmarshalJSON(data []byte) error {\n")
+	assert.Contains(t, golang, "func (u OwnerHomeGuard) MarshalJSON() ([]byte, error) {\n")
+	assert.Contains(t, string(result.Protobuf), "message Address {")
+
+	for _, name := range []string{"OwnerPet", "OwnerPets", "OwnerHomeGuard"} {
+		require.Contains(t, result.TypeMap, name)
+		assert.Equal(t, schema.TypeLocationGolang, result.TypeMap[name].Location)
+	}
+	assert.Equal(t, schema.TypeLocationGolang, result.TypeMap["Owner"].Location)
+}
+
+func TestConvertToStructInlineUnion(t *testing.T) {
+	result, err := schema.ConvertToStruct([]byte(inlineUnionSpec), schema.ConvertOptions{
+		GoPackagePath: "github.com/example/types",
+		SchemaNames:   []string{"Owner"},
+	})
+	require.NoError(t, err)
+
+	golang := string(result.Golang)
+	assert.Contains(t, golang, "type Owner struct {\n")
+	assert.Contains(t, golang, "type OwnerPet struct {\n")
+	assert.Contains(t, golang, "type OwnerHomeGuard struct {\n")
+	assert.NotContains(t, golang, "type Address struct")
+}
+
+func TestConvertInlineUnionNameConflict(t *testing.T) {
+	given := inlineUnionSpec + `    OwnerPet:
+      type: object
+      properties:
+        id:
+          type: string
+`
+	_, err := schema.ConvertToStruct([]byte(given), schema.ConvertOptions{
+		GoPackagePath: "github.com/example/types",
+	})
+	require.ErrorContains(t, err, "schema 'Owner': property 'pet': inline oneOf is named 'OwnerPet', which is already a component schema")
+}
@@ -0,0 +1,158 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertJSONSchema(t *testing.T) {
+	result, err := schema.ConvertJSONSchema([]byte(`{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$id": "https://example.com/user-profile.json",
+  "title": "User profile",
+  "type": "object",
+  "properties": {
+    "name": {"type": "string"},
+    "address": {"$ref": "#/$defs/Address"},
+    "manager": {"$ref": "#"}
+  },
+  "$defs": {
+    "Address": {
+      "type": "object",
+      "properties": {
+        "street": {"type": "string"},
+        "country": {"$ref": "#/definitions/Country"}
+      }
+    }
+  },
+  "definitions": {
+    "Country": {
+      "type": "object",
+      "properties": {
+        "code": {"type": "string"}
+      }
+    }
+  }
+}`), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+	})
+	require.NoError(t, err)
+
+	expected := `syntax = "proto3";
+
+package testpkg;
+
+option go_package = "github.com/example/proto/v1";
+
+message Address {
+  string street = 1 [json_name = "street"];
+  Country country = 2 [json_name = "country"];
+}
+
+message Country {
+  string code = 1 [json_name = "code"];
+}
+
+// User profile
+message UserProfile {
+  string name = 1 [json_name = "name"];
+  Address address = 2 [json_name = "address"];
+  UserProfile manager = 3 [json_name = "manager"];
+}
+
+`
+	assert.Equal(t, expected, string(result.Protobuf))
+	assert.Contains(t, result.TypeMap, "UserProfile")
+}
+
+func TestConvertJSONSchemaDefinitionsOnly(t *testing.T) {
+	result, err := schema.ConvertJSONSchema([]byte(`title: Shared models
+$defs:
+  Money:
+    type: object
+    properties:
+      amount:
+        type: integer
+      currency:
+        type: string
+`), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+	})
+	require.NoError(t, err)
+
+	expected := `syntax = "proto3";
+
+package testpkg;
+
+option go_package = "github.com/example/proto/v1";
+
+message Money {
+  int32 amount = 1 [json_name = "amount"];
+  string currency = 2 [json_name = "currency"];
+}
+
+`
+	assert.Equal(t, expected, string(result.Protobuf))
+}
+
+func TestConvertJSONSchemaErrors(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{
+			name:    "empty input",
+			input:   "",
+			wantErr: "json schema input cannot be empty",
+		},
+		{
+			name:    "not an object",
+			input:   "[1, 2]",
+			wantErr: "JSON Schema must be a YAML or JSON object",
+		},
+		{
+			name:    "older draft",
+			input:   `{"$schema": "http://json-schema.org/draft-07/schema#", "$defs": {}}`,
+			wantErr: "unsupported $schema 'http://json-schema.org/draft-07/schema#': must be https://json-schema.org/draft/2020-12/schema",
+		},
+		{
+			name:    "root without title",
+			input:   `{"type": "object", "properties": {"id": {"type": "string"}}}`,
+			wantErr: "root schema requires a title to name it",
+		},
+		{
+			name:    "root conflicts with definition",
+			input:   `{"title": "Money", "type": "object", "$defs": {"Money": {"type": "object"}}}`,
+			wantErr: "root schema 'Money' conflicts with a definition of the same name",
+		},
+		{
+			name:    "duplicate definition",
+			input:   `{"$defs": {"A": {"type": "object"}}, "definitions": {"A": {"type": "object"}}}`,
+			wantErr: "definition 'A' is declared in both $defs and definitions",
+		},
+		{
+			name:    "external reference",
+			input:   `{"$defs": {"A": {"type": "object", "properties": {"b": {"$ref": "other.json#/$defs/B"}}}}}`,
+			wantErr: "unsupported $ref 'other.json#/$defs/B': only #, #/$defs/Name and #/definitions/Name are supported",
+		},
+		{
+			name:    "root reference without root schema",
+			input:   `{"$defs": {"A": {"type": "object", "properties": {"b": {"$ref": "#"}}}}}`,
+			wantErr: "$ref '#' refers to the root schema, which only holds definitions",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := schema.ConvertJSONSchema([]byte(test.input), schema.ConvertOptions{
+				PackageName: "testpkg",
+				PackagePath: "github.com/example/proto/v1",
+			})
+			require.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
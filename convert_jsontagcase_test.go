@@ -0,0 +1,136 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const jsonTagCaseSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+      discriminator:
+        propertyName: petType
+    Dog:
+      type: object
+      properties:
+        petType:
+          type: string
+        HTTPStatus:
+          type: integer
+        created_at:
+          type: string
+        X-Request-ID:
+          type: string
+    Cat:
+      type: object
+      properties:
+        petType:
+          type: string
+`
+
+func TestConvertToStructJSONTagCase(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		tagCase  schema.JSONTagCase
+		wantTags []string
+	}{
+		{
+			name:    "preserve by default",
+			tagCase: "",
+			wantTags: []string{
+				"`json:\"petType\"`",
+				"`json:\"HTTPStatus\"`",
+				"`json:\"created_at\"`",
+				"`json:\"X-Request-ID\"`",
+			},
+		},
+		{
+			name:    "camel",
+			tagCase: schema.JSONTagCaseCamel,
+			wantTags: []string{
+				"`json:\"petType\"`",
+				"`json:\"httpStatus\"`",
+				"`json:\"createdAt\"`",
+				"`json:\"xRequestId\"`",
+			},
+		},
+		{
+			name:    "snake",
+			tagCase: schema.JSONTagCaseSnake,
+			wantTags: []string{
+				"`json:\"pet_type\"`",
+				"`json:\"http_status\"`",
+				"`json:\"created_at\"`",
+				"`json:\"x_request_id\"`",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := schema.ConvertToStruct([]byte(jsonTagCaseSpec), schema.ConvertOptions{
+				GoPackagePath: "github.com/example/types/v1",
+				JSONTagCase:   test.tagCase,
+			})
+			require.NoError(t, err)
+
+			golang := string(result.Golang)
+			for _, tag := range test.wantTags {
+				assert.Contains(t, golang, tag)
+			}
+		})
+	}
+}
+
+func TestConvertToStructJSONTagCaseDiscriminator(t *testing.T) {
+	result, err := schema.ConvertToStruct([]byte(jsonTagCaseSpec), schema.ConvertOptions{
+		GoPackagePath: "github.com/example/types/v1",
+		JSONTagCase:   schema.JSONTagCaseSnake,
+	})
+	require.NoError(t, err)
+
+	// The union must read the same key the variant structs write
+	assert.Contains(t, string(result.Golang), "PetType string `json:\"pet_type\"`")
+}
+
+func TestConvertJSONTagCaseInvalid(t *testing.T) {
+	_, err := schema.Convert([]byte(jsonTagCaseSpec), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+		JSONTagCase: "kebab",
+	})
+	require.ErrorContains(t, err, `unknown JSONTagCase "kebab"`)
+
+	_, err = schema.ConvertToExamples([]byte(jsonTagCaseSpec), schema.ExampleOptions{
+		IncludeAll:  true,
+		JSONTagCase: "kebab",
+	})
+	require.ErrorContains(t, err, `unknown JSONTagCase "kebab"`)
+}
+
+func TestConvertToExamplesJSONTagCase(t *testing.T) {
+	result, err := schema.ConvertToExamples([]byte(jsonTagCaseSpec), schema.ExampleOptions{
+		SchemaNames: []string{"Dog", "Pet"},
+		Seed:        42,
+		JSONTagCase: schema.JSONTagCaseSnake,
+	})
+	require.NoError(t, err)
+
+	for _, name := range []string{"Dog", "Pet"} {
+		var got map[string]interface{}
+		require.NoError(t, json.Unmarshal(result.Examples[name], &got))
+		assert.Contains(t, got, "pet_type", name)
+		assert.Contains(t, got, "http_status", name)
+		assert.Contains(t, got, "x_request_id", name)
+		assert.NotContains(t, got, "petType", name)
+	}
+}
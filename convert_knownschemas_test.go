@@ -0,0 +1,251 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const knownSchemasSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Price:
+      type: object
+      properties:
+        currency_code:
+          type: string
+        units:
+          type: string
+          format: int64
+        nanos:
+          type: integer
+    Day:
+      type: object
+      properties:
+        year:
+          type: integer
+        month:
+          type: integer
+        day:
+          type: integer
+    Location:
+      type: object
+      properties:
+        latitude:
+          type: number
+        longitude:
+          type: number
+        label:
+          type: string
+    Order:
+      type: object
+      properties:
+        total:
+          $ref: '#/components/schemas/Price'
+        due:
+          $ref: '#/components/schemas/Day'
+        where:
+          $ref: '#/components/schemas/Location'
+`
+
+func TestConvertRecognizeKnownSchemas(t *testing.T) {
+	result, err := schema.Convert([]byte(knownSchemasSpec), schema.ConvertOptions{
+		PackageName:           "testpkg",
+		PackagePath:           "github.com/example/proto",
+		RecognizeKnownSchemas: true,
+	})
+	require.NoError(t, err)
+
+	proto := string(result.Protobuf)
+	assert.Contains(t, proto, "import \"google/type/money.proto\";\nimport \"google/type/date.proto\";\n")
+	assert.Contains(t, proto, "  google.type.Money total = 1 [json_name = \"total\"];\n")
+	assert.Contains(t, proto, "  google.type.Date due = 2 [json_name = \"due\"];\n")
+	assert.NotContains(t, proto, "message Price")
+	assert.NotContains(t, proto, "message Day")
+	// An extra property breaks the LatLng signature
+	assert.Contains(t, proto, "message Location {")
+	assert.Contains(t, proto, "  Location where = 3 [json_name = \"where\"];\n")
+}
+
+func TestConvertKnownSchemasNotRecognizedByDefault(t *testing.T) {
+	result, err := schema.Convert([]byte(knownSchemasSpec), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto",
+	})
+	require.NoError(t, err)
+
+	proto := string(result.Protobuf)
+	assert.Contains(t, proto, "message Price {")
+	assert.NotContains(t, proto, "google/type")
+}
+
+func TestConvertCustomKnownSchemas(t *testing.T) {
+	result, err := schema.Convert([]byte(knownSchemasSpec), schema.ConvertOptions{
+		PackageName:           "testpkg",
+		PackagePath:           "github.com/example/proto",
+		RecognizeKnownSchemas: true,
+		KnownSchemas: append(schema.DefaultKnownSchemas(), schema.KnownSchema{
+			ImportMapping: schema.ImportMapping{Proto: "acme.geo.v1.Place", ProtoImport: "acme/geo/v1/place.proto"},
+			Properties:    map[string]string{"latitude": "number", "longitude": "number", "label": "string"},
+		}),
+	})
+	require.NoError(t, err)
+
+	proto := string(result.Protobuf)
+	assert.Contains(t, proto, "import \"acme/geo/v1/place.proto\";\n")
+	assert.Contains(t, proto, "  acme.geo.v1.Place where = 3 [json_name = \"where\"];\n")
+	assert.NotContains(t, proto, "message Location")
+}
+
+func TestConvertProtoImportExtension(t *testing.T) {
+	given := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Amount:
+      type: object
+      x-proto-import: google/type/money.proto
+      properties:
+        currency:
+          type: string
+        value:
+          type: string
+    Tenant:
+      type: object
+      x-proto-import: acme/common/v1/tenant.proto
+      x-proto-type: acme.common.v1.Tenant
+      properties:
+        id:
+          type: string
+    Order:
+      type: object
+      properties:
+        total:
+          $ref: '#/components/schemas/Amount'
+        tenant:
+          $ref: '#/components/schemas/Tenant'
+`
+	result, err := schema.Convert([]byte(given), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto",
+	})
+	require.NoError(t, err)
+
+	proto := string(result.Protobuf)
+	assert.Contains(t, proto, "import \"google/type/money.proto\";\nimport \"acme/common/v1/tenant.proto\";\n")
+	assert.Contains(t, proto, "  google.type.Money total = 1 [json_name = \"total\"];\n")
+	assert.Contains(t, proto, "  acme.common.v1.Tenant tenant = 2 [json_name = \"tenant\"];\n")
+	assert.NotContains(t, proto, "message Amount")
+	assert.NotContains(t, proto, "message Tenant")
+}
+
+func TestConvertToStructKnownSchemas(t *testing.T) {
+	given := knownSchemasSpec + `    Invoice:
+      type: object
+      x-go-type: money.Money
+      x-go-import: github.com/example/money
+      properties:
+        currencyCode:
+          type: string
+        units:
+          type: integer
+        nanos:
+          type: integer
+    Bill:
+      type: object
+      properties:
+        amount:
+          $ref: '#/components/schemas/Invoice'
+`
+	result, err := schema.ConvertToStruct([]byte(given), schema.ConvertOptions{
+		GoFormat:              schema.GoFormatNone,
+		GoPackagePath:         "github.com/example/types",
+		RecognizeKnownSchemas: true,
+	})
+	require.NoError(t, err)
+
+	// Recognised schemas without a Go type are still generated as structs
+	golang := string(result.Golang)
+	assert.Contains(t, golang, "type Price struct {\n")
+	assert.Contains(t, golang, "\tTotal *Price `json:\"total\"`\n")
+	assert.Contains(t, golang, "\tAmount money.Money `json:\"amount\"`\n")
+	assert.Contains(t, golang, "\"github.com/example/money\"")
+	assert.NotContains(t, golang, "type Invoice struct")
+}
+
+func TestConvertKnownSchemasErrors(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		given    string
+		opts     schema.ConvertOptions
+		expected string
+	}{
+		{
+			name: "unknown import",
+			given: `    Amount:
+      type: object
+      x-proto-import: acme/common/v1/amount.proto
+      properties:
+        value:
+          type: string
+`,
+			expected: "schema 'Amount': x-proto-import 'acme/common/v1/amount.proto' is not a known schema import: set x-proto-type",
+		},
+		{
+			name: "empty import",
+			given: `    Amount:
+      type: object
+      x-proto-import: ""
+      properties:
+        value:
+          type: string
+`,
+			expected: "schema 'Amount': x-proto-import must be a non-empty string",
+		},
+		{
+			name: "registry entry without import",
+			opts: schema.ConvertOptions{
+				KnownSchemas: []schema.KnownSchema{{
+					ImportMapping: schema.ImportMapping{Proto: "acme.common.v1.Amount"},
+					Properties:    map[string]string{"value": "string"},
+				}},
+			},
+			expected: "known schema 0 must set Proto and ProtoImport",
+		},
+		{
+			name: "registry entry without signature",
+			opts: schema.ConvertOptions{
+				KnownSchemas: []schema.KnownSchema{{
+					ImportMapping: schema.ImportMapping{Proto: "acme.common.v1.Amount", ProtoImport: "acme/common/v1/amount.proto"},
+				}},
+			},
+			expected: "known schema acme.common.v1.Amount must set Properties",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			given := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        id:
+          type: string
+` + test.given
+			test.opts.PackageName = "testpkg"
+			test.opts.PackagePath = "github.com/example/proto"
+			_, err := schema.Convert([]byte(given), test.opts)
+			require.ErrorContains(t, err, test.expected)
+		})
+	}
+}
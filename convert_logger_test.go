@@ -0,0 +1,121 @@
+package schema_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const loggerSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    user:
+      type: object
+      properties:
+        name:
+          type: string
+    User:
+      type: object
+      properties:
+        status-code:
+          type: integer
+        createdAt:
+          type: string
+          format: date-time
+    Status:
+      type: string
+      enum: [active, inactive]
+`
+
+// captureLogger returns a debug-level logger and a function decoding every record
+// it has received, with timestamps and levels stripped
+func captureLogger(t *testing.T) (*slog.Logger, func() []map[string]any) {
+	t.Helper()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey || a.Key == slog.LevelKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	return logger, func() []map[string]any {
+		var records []map[string]any
+		dec := json.NewDecoder(&buf)
+		for dec.More() {
+			var rec map[string]any
+			require.NoError(t, dec.Decode(&rec))
+			records = append(records, rec)
+		}
+		return records
+	}
+}
+
+func TestConvertLogger(t *testing.T) {
+	logger, records := captureLogger(t)
+
+	_, err := schema.Convert([]byte(loggerSpec), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+		Logger:      logger,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []map[string]any{
+		{"msg": "name renamed", "schema": "User", "kind": "type", "from": "User", "to": "User_2"},
+		{"msg": "name renamed", "schema": "User", "kind": "field", "from": "status-code", "to": "status_code"},
+		{"msg": "import added", "schema": "User", "import": "google/protobuf/timestamp.proto"},
+		{"msg": "schema skipped", "schema": "Status", "reason": "string enum is mapped to string fields"},
+	}, records())
+}
+
+func TestConvertToStructLogger(t *testing.T) {
+	logger, records := captureLogger(t)
+
+	_, err := schema.ConvertToStruct([]byte(loggerSpec), schema.ConvertOptions{
+		GoPackagePath: "github.com/example/types",
+		Logger:        logger,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []map[string]any{
+		{"msg": "import added", "schema": "User", "import": "time"},
+	}, records())
+}
+
+func TestConvertToExamplesLogger(t *testing.T) {
+	const spec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Error:
+      type: object
+      properties:
+        message:
+          type: string
+`
+	logger, records := captureLogger(t)
+
+	_, err := schema.ConvertToExamples([]byte(spec), schema.ExampleOptions{
+		IncludeAll: true,
+		Seed:       1,
+		Logger:     logger,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []map[string]any{
+		{"msg": "heuristic applied", "schema": "Error", "field": "message", "heuristic": "message text"},
+	}, records())
+}
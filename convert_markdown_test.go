@@ -0,0 +1,219 @@
+package schema_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const markdownSpec = `openapi: 3.1.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Order:
+      type: object
+      description: |
+        A placed order.
+        Orders are immutable | once placed.
+      required: [id, status]
+      properties:
+        id:
+          type: string
+          format: uuid
+          readOnly: true
+        status:
+          $ref: '#/components/schemas/Status'
+        quantity:
+          type: integer
+          minimum: 1
+          maximum: 100
+          default: 1
+        code:
+          type: string
+          pattern: '^[A-Z]{3}$'
+          deprecated: true
+          description: Legacy code
+        items:
+          type: array
+          minItems: 1
+          items:
+            type: object
+            required: [sku]
+            properties:
+              sku:
+                type: string
+        shipping:
+          type: object
+          properties:
+            street:
+              type: [string, "null"]
+        payment:
+          $ref: '#/components/schemas/Payment'
+    Status:
+      type: string
+      description: Order status
+      enum: [pending, shipped]
+    Payment:
+      oneOf:
+        - $ref: '#/components/schemas/Card'
+        - $ref: '#/components/schemas/Cash'
+      discriminator:
+        propertyName: kind
+        mapping:
+          card: '#/components/schemas/Card'
+          cash: '#/components/schemas/Cash'
+    Card:
+      type: object
+      required: [kind]
+      properties:
+        kind:
+          type: string
+        number:
+          type: string
+    Cash:
+      type: object
+      required: [kind]
+      properties:
+        kind:
+          type: string
+    Tags:
+      type: array
+      maxItems: 5
+      items:
+        type: string
+`
+
+func TestConvertToMarkdown(t *testing.T) {
+	result, err := schema.ConvertToMarkdown([]byte(markdownSpec), schema.MarkdownOptions{OmitExamples: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Order", "Status", "Payment", "Card", "Cash", "Tags"}, result.Names)
+	assert.Equal(t, `# Order
+
+A placed order.
+Orders are immutable | once placed.
+
+## Fields
+
+| Name | Type | Required | Constraints | Description |
+|------|------|----------|-------------|-------------|
+| `+"`id`"+` | string (uuid) | Yes | read-only |  |
+| `+"`status`"+` | [Status](Status.md) | Yes |  | Order status |
+| `+"`quantity`"+` | integer |  | minimum: 1, maximum: 100, default: `+"`1`"+` |  |
+| `+"`code`"+` | string |  | pattern: `+"`^[A-Z]{3}$`"+` | **Deprecated.** Legacy code |
+| `+"`items`"+` | array of object |  | minItems: 1 |  |
+| `+"`items[].sku`"+` | string | Yes |  |  |
+| `+"`shipping`"+` | object |  |  |  |
+| `+"`shipping.street`"+` | string |  | nullable |  |
+| `+"`payment`"+` | [Payment](Payment.md) |  |  |  |
+`, string(result.Pages["Order"]))
+
+	assert.Equal(t, `# Status
+
+Order status
+
+**Type:** string
+
+## Values
+
+- `+"`\"pending\"`"+`
+- `+"`\"shipped\"`"+`
+
+## Referenced By
+
+- [Order](Order.md)
+`, string(result.Pages["Status"]))
+
+	assert.Equal(t, `# Payment
+
+## Variants
+
+One of:
+
+- [Card](Card.md)
+- [Cash](Cash.md)
+
+Discriminator: `+"`kind`"+`
+
+| Value | Variant |
+|-------|---------|
+| `+"`card`"+` | [Card](Card.md) |
+| `+"`cash`"+` | [Cash](Cash.md) |
+
+## Referenced By
+
+- [Order](Order.md)
+`, string(result.Pages["Payment"]))
+
+	assert.Equal(t, "# Tags\n\n**Type:** array of string\n\n**Constraints:** maxItems: 5\n", string(result.Pages["Tags"]))
+
+	assert.Equal(t, `# Schemas
+
+| Schema | Description |
+|--------|-------------|
+| [Order](Order.md) | A placed order. |
+| [Status](Status.md) | Order status |
+| [Payment](Payment.md) |  |
+| [Card](Card.md) |  |
+| [Cash](Cash.md) |  |
+| [Tags](Tags.md) |  |
+`, string(result.Index))
+}
+
+func TestConvertToMarkdownExamples(t *testing.T) {
+	examples, err := schema.ConvertToExamples([]byte(markdownSpec), schema.ExampleOptions{IncludeAll: true, Seed: 7})
+	require.NoError(t, err)
+
+	result, err := schema.ConvertToMarkdown([]byte(markdownSpec), schema.MarkdownOptions{
+		Examples: &schema.ExampleOptions{Seed: 7},
+	})
+	require.NoError(t, err)
+
+	var indented bytes.Buffer
+	require.NoError(t, json.Indent(&indented, examples.Examples["Card"], "", "  "))
+	assert.Contains(t, string(result.Pages["Card"]), "\n## Example\n\n```json\n"+indented.String()+"\n```\n\n## Referenced By\n")
+
+	// The default options are seeded, so pages are stable
+	first, err := schema.ConvertToMarkdown([]byte(markdownSpec), schema.MarkdownOptions{})
+	require.NoError(t, err)
+	second, err := schema.ConvertToMarkdown([]byte(markdownSpec), schema.MarkdownOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, first.Pages, second.Pages)
+	assert.Contains(t, string(first.Pages["Tags"]), "## Example")
+
+	_, err = schema.ConvertToMarkdown([]byte(markdownSpec), schema.MarkdownOptions{
+		Examples: &schema.ExampleOptions{Mode: "bogus"},
+	})
+	require.ErrorContains(t, err, `failed to generate examples: unknown Mode "bogus"`)
+}
+
+func TestConvertToMarkdownLinks(t *testing.T) {
+	result, err := schema.ConvertToMarkdown([]byte(markdownSpec), schema.MarkdownOptions{
+		SchemaNames:  []string{"Payment"},
+		OmitExamples: true,
+		LinkFunc: func(name string) string {
+			return "/models/" + name
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Payment", "Card", "Cash"}, result.Names)
+	assert.Contains(t, string(result.Pages["Payment"]), "- [Card](/models/Card)\n")
+	// Order has no page, so Payment lists nothing referring to it
+	assert.NotContains(t, string(result.Pages["Payment"]), "Referenced By")
+	assert.Contains(t, string(result.Pages["Cash"]), "## Referenced By\n\n- [Payment](/models/Payment)\n")
+}
+
+func TestConvertToMarkdownContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := schema.ConvertToMarkdownContext(ctx, []byte(markdownSpec), schema.MarkdownOptions{})
+	require.ErrorIs(t, err, context.Canceled)
+}
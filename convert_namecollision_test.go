@@ -0,0 +1,248 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const nameCollisionSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        id:
+          type: string
+    user:
+      type: object
+      properties:
+        email:
+          type: string
+    Address:
+      type: object
+      properties:
+        street:
+          type: string
+    Order:
+      type: object
+      properties:
+        buyer:
+          $ref: '#/components/schemas/user'
+        address:
+          type: object
+          properties:
+            city:
+              type: string
+`
+
+func TestConvertNameCollision(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		strategy schema.NameCollision
+		expected []string
+		renames  []schema.Rename
+	}{
+		{
+			name: "suffix by default",
+			expected: []string{
+				"message User_2 {\n",
+				"  message Address_2 {\n",
+				"  User_2 buyer = 1 [json_name = \"buyer\"];\n  Address_2 address = 2 [json_name = \"address\"];\n",
+			},
+			renames: []schema.Rename{
+				{Schema: "user", Kind: "type", Reason: "collision", From: "User", To: "User_2"},
+				{Schema: "Order", Property: "address", Kind: "type", Reason: "collision", From: "Address", To: "Address_2"},
+			},
+		},
+		{
+			name:     "prefix with schema",
+			strategy: schema.NameCollisionPrefixWithSchema,
+			expected: []string{
+				"message User_2 {\n",
+				"  message OrderAddress {\n",
+				"  User_2 buyer = 1 [json_name = \"buyer\"];\n  OrderAddress address = 2 [json_name = \"address\"];\n",
+			},
+			renames: []schema.Rename{
+				{Schema: "user", Kind: "type", Reason: "collision", From: "User", To: "User_2"},
+				{Schema: "Order", Property: "address", Kind: "type", Reason: "collision", From: "Address", To: "OrderAddress"},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := schema.Convert([]byte(nameCollisionSpec), schema.ConvertOptions{
+				PackageName:     "testpkg",
+				PackagePath:     "github.com/example/proto",
+				OnNameCollision: test.strategy,
+			})
+			require.NoError(t, err)
+
+			for _, expected := range test.expected {
+				assert.Contains(t, string(result.Protobuf), expected)
+			}
+			assert.Equal(t, test.renames, result.Renames)
+			assert.Equal(t, "User_2", result.TypeMap["user"].GeneratedName)
+		})
+	}
+}
+
+func TestConvertNameCollisionError(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		given    string
+		expected string
+	}{
+		{
+			name:     "type",
+			given:    nameCollisionSpec,
+			expected: "schema 'user': type name 'User' is already used by schema 'User'",
+		},
+		{
+			name: "inline type",
+			given: `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Address:
+      type: object
+      properties:
+        street:
+          type: string
+    Order:
+      type: object
+      properties:
+        address:
+          type: object
+          properties:
+            city:
+              type: string
+`,
+			expected: "property 'address': type name 'Address' is already used by schema 'Address'",
+		},
+		{
+			name: "field",
+			given: `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Person:
+      type: object
+      properties:
+        first_name:
+          type: string
+        first-name:
+          type: string
+`,
+			expected: "schema 'Person': property 'first-name' field name 'first_name' is already used by another property",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := schema.Convert([]byte(test.given), schema.ConvertOptions{
+				PackageName:     "testpkg",
+				PackagePath:     "github.com/example/proto",
+				OnNameCollision: schema.NameCollisionError,
+			})
+			require.ErrorContains(t, err, test.expected)
+		})
+	}
+}
+
+func TestConvertFieldRenames(t *testing.T) {
+	given := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Person:
+      type: object
+      properties:
+        first_name:
+          type: string
+        first-name:
+          type: string
+`
+	result, err := schema.Convert([]byte(given), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto",
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, string(result.Protobuf), "  string first_name_2 = 2 [json_name = \"first-name\"];\n")
+	assert.Equal(t, []schema.Rename{
+		{Schema: "Person", Property: "first-name", Kind: "field", Reason: "collision", From: "first-name", To: "first_name_2"},
+	}, result.Renames)
+}
+
+func TestConvertNameCollisionInvalid(t *testing.T) {
+	_, err := schema.Convert([]byte(nameCollisionSpec), schema.ConvertOptions{
+		PackageName:     "testpkg",
+		PackagePath:     "github.com/example/proto",
+		OnNameCollision: "rename",
+	})
+	require.ErrorContains(t, err, `unknown OnNameCollision "rename": must be suffix, error or prefixWithSchema`)
+}
+
+func TestConvertSanitizedFieldRenames(t *testing.T) {
+	given := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Item:
+      type: object
+      properties:
+        a-b:
+          type: string
+        a_b:
+          type: string
+        meta.version:
+          type: string
+        displayName:
+          type: string
+`
+	for _, test := range []struct {
+		name     string
+		naming   schema.ProtoFieldNaming
+		expected []schema.Rename
+	}{
+		{
+			name: "preserve",
+			expected: []schema.Rename{
+				{Schema: "Item", Property: "a-b", Kind: "field", Reason: "sanitized", From: "a-b", To: "a_b"},
+				{Schema: "Item", Property: "a_b", Kind: "field", Reason: "collision", From: "a_b", To: "a_b_2"},
+				{Schema: "Item", Property: "meta.version", Kind: "field", Reason: "sanitized", From: "meta.version", To: "meta_version"},
+			},
+		},
+		{
+			// displayName → display_name is the naming strategy, not sanitizing
+			name:   "snake_case",
+			naming: schema.ProtoFieldNamingSnakeCase,
+			expected: []schema.Rename{
+				{Schema: "Item", Property: "a-b", Kind: "field", Reason: "sanitized", From: "a-b", To: "a_b"},
+				{Schema: "Item", Property: "a_b", Kind: "field", Reason: "collision", From: "a_b", To: "a_b_2"},
+				{Schema: "Item", Property: "meta.version", Kind: "field", Reason: "sanitized", From: "meta.version", To: "meta_version"},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := schema.Convert([]byte(given), schema.ConvertOptions{
+				PackageName:      "testpkg",
+				PackagePath:      "github.com/example/proto",
+				ProtoFieldNaming: test.naming,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, result.Renames)
+		})
+	}
+}
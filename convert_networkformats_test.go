@@ -0,0 +1,120 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const networkFormatsSpec = `openapi: 3.1.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Server:
+      type: object
+      properties:
+        address:
+          type: string
+          format: ipv4
+          description: Public address
+        address6:
+          type: string
+          format: ipv6
+        host:
+          type: string
+          format: hostname
+        aliases:
+          type: array
+          items:
+            type: string
+            format: idn-hostname
+`
+
+func TestValidateExamplesNetworkFormats(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		example string
+		want    []string
+	}{
+		{
+			name: "valid",
+			example: `        address: 192.0.2.1
+        address6: 2001:db8::1
+        host: api.example.com
+        aliases: [bücher.example]
+`,
+		},
+		{
+			name: "invalid",
+			example: `        address: 256.1.1.1
+        address6: 192.0.2.1
+        host: -api.example.com
+        aliases: [ok.example, bad_name.example]
+`,
+			want: []string{
+				"/components/schemas/Server/example/address: '256.1.1.1' is not valid 'ipv4': not an IPv4 address",
+				"/components/schemas/Server/example/address6: '192.0.2.1' is not valid 'ipv6': not an IPv6 address",
+				"/components/schemas/Server/example/aliases/1: 'bad_name.example' is not valid 'idn-hostname': label 'bad_name' contains '_'",
+				"/components/schemas/Server/example/host: '-api.example.com' is not valid 'hostname': label '-api' must not start or end with a hyphen",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			spec := networkFormatsSpec + "      example:\n" + test.example
+			result, err := schema.ValidateExamples([]byte(spec), schema.ValidateOptions{
+				SchemaNames: []string{"Server"},
+			})
+			require.NoError(t, err)
+
+			server := result.Schemas["Server"]
+			var got []string
+			for _, issue := range server.Issues {
+				assert.Equal(t, "format", issue.Class)
+				got = append(got, issue.Path+": "+issue.Message)
+			}
+			assert.Equal(t, test.want, got)
+			assert.Equal(t, len(test.want) == 0, server.Valid)
+		})
+	}
+}
+
+func TestValidateExamplesNetworkFormatsAsserted(t *testing.T) {
+	formats, err := schema.NewFormatRegistry(schema.Format{Name: "iban", Validate: func(string) error { return nil }})
+	require.NoError(t, err)
+
+	// A registry turns format assertions on; each bad value is still reported once
+	spec := networkFormatsSpec + "      example:\n        address: 256.1.1.1\n"
+	result, err := schema.ValidateExamples([]byte(spec), schema.ValidateOptions{
+		SchemaNames: []string{"Server"},
+		Formats:     formats,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Schemas["Server"].Issues, 1)
+	assert.Equal(t, "/components/schemas/Server/example/address", result.Schemas["Server"].Issues[0].Path)
+}
+
+func TestConvertToStructNetworkFormatNotes(t *testing.T) {
+	result, err := schema.ConvertToStruct([]byte(networkFormatsSpec), schema.ConvertOptions{
+		GoPackagePath: "github.com/example/types/v1",
+	})
+	require.NoError(t, err)
+
+	golang := string(result.Golang)
+	assert.Contains(t, golang, "\t// Public address\n\t// format: ipv4 (dotted-quad IPv4 address, e.g. 192.0.2.1)\n\tAddress string")
+	assert.Contains(t, golang, "\t// format: ipv6 (IPv6 address, e.g. 2001:db8::1)\n\tAddress6 string")
+	assert.Contains(t, golang, "\t// format: hostname (RFC 1123 host name)\n\tHost string")
+	assert.Contains(t, golang, "\t// format: idn-hostname (internationalized host name)\n\tAliases []string")
+
+	result, err = schema.ConvertToStruct([]byte(networkFormatsSpec), schema.ConvertOptions{
+		GoPackagePath: "github.com/example/types/v1",
+		TypeMappings: []schema.TypeMapping{
+			{Type: "string", Format: "ipv4", Go: "netip.Addr", GoImport: "net/netip"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, string(result.Golang), "\t// Public address\n\tAddress netip.Addr")
+}
@@ -0,0 +1,103 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const omitEmptySpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+      discriminator:
+        propertyName: petType
+    Dog:
+      type: object
+      required: [petType, name]
+      properties:
+        petType:
+          type: string
+        name:
+          type: string
+        nickname:
+          type: string
+    Cat:
+      type: object
+      properties:
+        petType:
+          type: string
+`
+
+func TestConvertToStructOmitEmpty(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		omitEmpty schema.OmitEmpty
+		wantTags  []string
+	}{
+		{
+			name: "never by default",
+			wantTags: []string{
+				"PetType string `json:\"petType\"`",
+				"Name string `json:\"name\"`",
+				"Nickname string `json:\"nickname\"`",
+			},
+		},
+		{
+			name:      "optional",
+			omitEmpty: schema.OmitEmptyOptional,
+			wantTags: []string{
+				"PetType string `json:\"petType\"`",
+				"Name string `json:\"name\"`",
+				"Nickname string `json:\"nickname,omitempty\"`",
+			},
+		},
+		{
+			name:      "always",
+			omitEmpty: schema.OmitEmptyAlways,
+			wantTags: []string{
+				"PetType string `json:\"petType,omitempty\"`",
+				"Name string `json:\"name,omitempty\"`",
+				"Nickname string `json:\"nickname,omitempty\"`",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := schema.ConvertToStruct([]byte(omitEmptySpec), schema.ConvertOptions{
+				GoFormat:      schema.GoFormatNone,
+				GoPackagePath: "github.com/example/types",
+				OmitEmpty:     test.omitEmpty,
+			})
+			require.NoError(t, err)
+
+			for _, tag := range test.wantTags {
+				assert.Contains(t, string(result.Golang), tag)
+			}
+			// Union wrappers never marshal their variant fields directly
+			assert.Contains(t, string(result.Golang), "Dog *Dog `json:\"-\"`")
+		})
+	}
+}
+
+func TestConvertOmitEmptyInvalid(t *testing.T) {
+	_, err := schema.Convert([]byte(omitEmptySpec), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+		OmitEmpty:   "sometimes",
+	})
+	require.ErrorContains(t, err, `unknown OmitEmpty "sometimes": must be never, optional or always`)
+
+	_, err = schema.ConvertToStruct([]byte(omitEmptySpec), schema.ConvertOptions{
+		GoPackagePath: "github.com/example/types",
+		OmitEmpty:     "sometimes",
+	})
+	require.ErrorContains(t, err, `unknown OmitEmpty "sometimes": must be never, optional or always`)
+}
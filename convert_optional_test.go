@@ -0,0 +1,93 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const optionalSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Level:
+      type: integer
+      enum: [1, 2, 3]
+    User:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: string
+        nickname:
+          type: string
+        age:
+          type: integer
+        level:
+          $ref: '#/components/schemas/Level'
+        tags:
+          type: array
+          items:
+            type: string
+        address:
+          type: object
+          properties:
+            city:
+              type: string
+        createdAt:
+          type: string
+          format: date-time
+`
+
+func TestConvertUseProto3Optional(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		optional bool
+		expected string
+		nested   string
+	}{
+		{
+			name:     "disabled by default",
+			optional: false,
+			expected: `  string id = 1 [json_name = "id"];
+  string nickname = 2 [json_name = "nickname"];
+  int32 age = 3 [json_name = "age"];
+  Level level = 4 [json_name = "level"];
+  repeated string tags = 5 [json_name = "tags"];
+  Address address = 6 [json_name = "address"];
+  google.protobuf.Timestamp createdAt = 7 [json_name = "createdAt"];
+}`,
+			nested: `    string city = 1 [json_name = "city"];`,
+		},
+		{
+			name:     "enabled",
+			optional: true,
+			expected: `  string id = 1 [json_name = "id"];
+  optional string nickname = 2 [json_name = "nickname"];
+  optional int32 age = 3 [json_name = "age"];
+  optional Level level = 4 [json_name = "level"];
+  repeated string tags = 5 [json_name = "tags"];
+  Address address = 6 [json_name = "address"];
+  google.protobuf.Timestamp createdAt = 7 [json_name = "createdAt"];
+}`,
+			nested: `    optional string city = 1 [json_name = "city"];`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := schema.Convert([]byte(optionalSpec), schema.ConvertOptions{
+				PackageName:       "testpkg",
+				PackagePath:       "github.com/example/proto/v1",
+				UseProto3Optional: test.optional,
+			})
+			require.NoError(t, err)
+
+			proto := string(result.Protobuf)
+			assert.Contains(t, proto, test.expected)
+			assert.Contains(t, proto, test.nested)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestConvertProtoOptions(t *testing.T) {
+	given := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    User:
+      type: object
+      x-proto-options:
+        deprecated: true
+      properties:
+        id:
+          type: string
+        address:
+          type: object
+          x-proto-options:
+            deprecated: false
+          properties:
+            street:
+              type: string
+    Role:
+      type: integer
+      enum: [0, 1]
+      x-proto-options:
+        allow_alias: false
+`
+	result, err := schema.Convert([]byte(given), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto",
+		FileOptions: map[string]any{
+			"java_package":        "com.example.api",
+			"java_multiple_files": true,
+			"optimize_for":        schema.ProtoIdentifier("SPEED"),
+			"csharp_namespace":    "Example.Api",
+		},
+		BuildDescriptor: true,
+	})
+	require.NoError(t, err)
+
+	proto := string(result.Protobuf)
+	assert.Contains(t, proto, "option go_package = \"github.com/example/proto\";\n"+
+		"option csharp_namespace = \"Example.Api\";\n"+
+		"option java_multiple_files = true;\n"+
+		"option java_package = \"com.example.api\";\n"+
+		"option optimize_for = SPEED;\n")
+	assert.Contains(t, proto, "message User {\n  option deprecated = true;\n  message Address {\n    option deprecated = false;\n")
+	assert.Contains(t, proto, "enum Role {\n  option allow_alias = false;\n  ROLE_0 = 0;\n")
+	assert.True(t, result.Descriptor.Messages().ByName("User").Options().(*descriptorpb.MessageOptions).GetDeprecated())
+	assert.Equal(t, "com.example.api", result.Descriptor.Options().(*descriptorpb.FileOptions).GetJavaPackage())
+}
+
+func TestConvertProtoOptionsInvalid(t *testing.T) {
+	spec := func(options string) string {
+		return `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    User:
+      type: object
+      x-proto-options: ` + options + `
+      properties:
+        id:
+          type: string
+`
+	}
+
+	for _, test := range []struct {
+		name        string
+		given       string
+		fileOptions map[string]any
+		err         string
+	}{
+		{
+			name:        "go_package",
+			given:       spec("{}"),
+			fileOptions: map[string]any{"go_package": "x"},
+			err:         "FileOptions cannot set go_package: it is derived from PackagePath",
+		},
+		{
+			name:        "file option name",
+			given:       spec("{}"),
+			fileOptions: map[string]any{"java package": "x"},
+			err:         `invalid FileOptions name "java package": must be an option name such as java_package or (my.option)`,
+		},
+		{
+			name:        "file option value",
+			given:       spec("{}"),
+			fileOptions: map[string]any{"java_package": []string{"x"}},
+			err:         `FileOptions "java_package": unsupported value type []string`,
+		},
+		{
+			name:  "schema options not a mapping",
+			given: spec("[deprecated]"),
+			err:   "schema 'User': x-proto-options must be a mapping of option names to values",
+		},
+		{
+			name:  "schema option value",
+			given: spec("{deprecated: [true]}"),
+			err:   "schema 'User': x-proto-options value of 'deprecated' must be a string, number or boolean",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := schema.Convert([]byte(test.given), schema.ConvertOptions{
+				PackageName: "testpkg",
+				PackagePath: "github.com/example/proto",
+				FileOptions: test.fileOptions,
+			})
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const protoStyleSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        id:
+          type: string
+        status_code:
+          type: integer
+          description: HTTP status.
+        userId:
+          type: string
+        line:
+          type: object
+          properties:
+            sku:
+              type: string
+`
+
+func TestConvertProtoStyle(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		style    schema.ProtoStyle
+		expected string
+	}{
+		{
+			name: "default",
+			expected: `message Order {
+  message Line {
+    string sku = 1 [json_name = "sku"];
+  }
+
+  string id = 1 [json_name = "id"];
+  // HTTP status.
+  int32 status_code = 2 [json_name = "status_code"];
+  string userId = 3 [json_name = "userId"];
+  Line line = 4 [json_name = "line"];
+}
+`,
+		},
+		{
+			name:  "four space indent",
+			style: schema.ProtoStyle{Indent: 4},
+			expected: `message Order {
+    message Line {
+        string sku = 1 [json_name = "sku"];
+    }
+
+    string id = 1 [json_name = "id"];
+    // HTTP status.
+    int32 status_code = 2 [json_name = "status_code"];
+`,
+		},
+		{
+			name:  "omit default json_name",
+			style: schema.ProtoStyle{OmitDefaultJSONName: true},
+			expected: `  string id = 1;
+  // HTTP status.
+  int32 status_code = 2 [json_name = "status_code"];
+  string userId = 3;
+  Line line = 4;
+`,
+		},
+		{
+			name:  "blank lines before commented fields",
+			style: schema.ProtoStyle{BlankLines: schema.ProtoBlankLinesCommented},
+			expected: `  string id = 1 [json_name = "id"];
+
+  // HTTP status.
+  int32 status_code = 2 [json_name = "status_code"];
+  string userId = 3 [json_name = "userId"];
+`,
+		},
+		{
+			name:  "blank lines between fields",
+			style: schema.ProtoStyle{BlankLines: schema.ProtoBlankLinesFields},
+			expected: `  string id = 1 [json_name = "id"];
+
+  // HTTP status.
+  int32 status_code = 2 [json_name = "status_code"];
+
+  string userId = 3 [json_name = "userId"];
+
+  Line line = 4 [json_name = "line"];
+}
+`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := schema.Convert([]byte(protoStyleSpec), schema.ConvertOptions{
+				PackageName:     "testpkg",
+				PackagePath:     "github.com/example/proto",
+				ProtoStyle:      test.style,
+				BuildDescriptor: true,
+			})
+			require.NoError(t, err)
+			assert.Contains(t, string(result.Protobuf), test.expected)
+		})
+	}
+}
+
+func TestConvertProtoStyleInvalid(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		style schema.ProtoStyle
+		err   string
+	}{
+		{name: "indent", style: schema.ProtoStyle{Indent: 3}, err: "unknown ProtoStyle.Indent 3: must be 2 or 4"},
+		{name: "blank lines", style: schema.ProtoStyle{BlankLines: "none"}, err: `unknown ProtoStyle.BlankLines "none": must be definitions, commented or fields`},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := schema.Convert([]byte(protoStyleSpec), schema.ConvertOptions{
+				PackageName: "testpkg",
+				PackagePath: "github.com/example/proto",
+				ProtoStyle:  test.style,
+			})
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
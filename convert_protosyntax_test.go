@@ -0,0 +1,74 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestConvertProtoSyntaxEditions(t *testing.T) {
+	given := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    User:
+      type: object
+      x-proto-reserved: [9, "old_name"]
+      required: [id]
+      properties:
+        id:
+          type: string
+        nickname:
+          type: string
+        tags:
+          type: array
+          items:
+            type: string
+        address:
+          $ref: '#/components/schemas/Address'
+        role:
+          type: integer
+          enum: [0, 1]
+    Address:
+      type: object
+      properties:
+        street:
+          type: string
+`
+	result, err := schema.Convert([]byte(given), schema.ConvertOptions{
+		PackageName:       "testpkg",
+		PackagePath:       "github.com/example/proto",
+		ProtoSyntax:       schema.ProtoSyntaxEditions,
+		UseProto3Optional: true,
+		BuildDescriptor:   true,
+	})
+	require.NoError(t, err)
+
+	proto := string(result.Protobuf)
+	assert.Contains(t, proto, "edition = \"2023\";\n\npackage testpkg;\n\noption go_package = \"github.com/example/proto\";\noption features.field_presence = IMPLICIT;\n")
+	assert.Contains(t, proto, "  string id = 1 [json_name = \"id\"];\n")
+	assert.Contains(t, proto, "  string nickname = 2 [json_name = \"nickname\", features.field_presence = EXPLICIT];\n")
+	assert.Contains(t, proto, "  reserved old_name;\n")
+	assert.NotContains(t, proto, "optional ")
+
+	// Field presence matches the proto3 output
+	fields := result.Descriptor.Messages().ByName("User").Fields()
+	assert.Equal(t, protoreflect.Editions, result.Descriptor.Syntax())
+	assert.False(t, fields.ByName("id").HasPresence())
+	assert.True(t, fields.ByName("nickname").HasPresence())
+	assert.True(t, fields.ByName("address").HasPresence())
+}
+
+func TestConvertProtoSyntaxInvalid(t *testing.T) {
+	_, err := schema.Convert([]byte(protoStyleSpec), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto",
+		ProtoSyntax: "proto2",
+	})
+	require.ErrorContains(t, err, `unknown ProtoSyntax "proto2": must be proto3 or editions`)
+}
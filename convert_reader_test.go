@@ -0,0 +1,93 @@
+package schema_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const readerSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        name:
+          type: string
+`
+
+func TestConvertReader(t *testing.T) {
+	expected, err := schema.Convert([]byte(readerSpec), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+	})
+	require.NoError(t, err)
+
+	result, err := schema.ConvertReader(strings.NewReader(readerSpec), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, string(expected.Protobuf), string(result.Protobuf))
+
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "api.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(readerSpec), 0o644))
+
+		f, err := os.Open(path)
+		require.NoError(t, err)
+		defer f.Close()
+
+		result, err := schema.ConvertToStructReader(f, schema.ConvertOptions{
+			GoPackagePath: "github.com/example/types",
+		})
+		require.NoError(t, err)
+		assert.Contains(t, string(result.Golang), "type User struct")
+	})
+
+	t.Run("examples and validation", func(t *testing.T) {
+		examples, err := schema.ConvertToExamplesReader(strings.NewReader(readerSpec), schema.ExampleOptions{
+			IncludeAll: true,
+			Seed:       42,
+		})
+		require.NoError(t, err)
+		assert.Contains(t, examples.Examples, "User")
+
+		validation, err := schema.ValidateExamplesReader(strings.NewReader(readerSpec), schema.ValidateOptions{
+			IncludeAll: true,
+		})
+		require.NoError(t, err)
+		assert.Contains(t, validation.Schemas, "User")
+	})
+
+	t.Run("empty reader", func(t *testing.T) {
+		_, err := schema.ConvertReader(strings.NewReader(""), schema.ConvertOptions{
+			PackageName: "testpkg",
+			PackagePath: "github.com/example/proto/v1",
+		})
+		require.ErrorContains(t, err, "openapi input cannot be empty")
+	})
+
+	t.Run("read failure", func(t *testing.T) {
+		_, err := schema.ConvertReader(failingReader{}, schema.ConvertOptions{
+			PackageName: "testpkg",
+			PackagePath: "github.com/example/proto/v1",
+		})
+		require.ErrorContains(t, err, "failed to read OpenAPI document: boom")
+	})
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("boom")
+}
@@ -0,0 +1,72 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const readOnlySpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        id:
+          type: string
+          readOnly: true
+        name:
+          type: string
+          description: Display name
+        password:
+          type: string
+          writeOnly: true
+        createdAt:
+          type: string
+          format: date-time
+          description: When the user signed up
+          readOnly: true
+`
+
+func TestConvertReadOnlyWriteOnly(t *testing.T) {
+	result, err := schema.Convert([]byte(readOnlySpec), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, string(result.Protobuf), `message User {
+  // Read-only: set by the server; ignored in requests.
+  string id = 1 [json_name = "id"];
+  // Display name
+  string name = 2 [json_name = "name"];
+  // Write-only: accepted in requests; never returned in responses.
+  string password = 3 [json_name = "password"];
+  // When the user signed up
+  // Read-only: set by the server; ignored in requests.
+  google.protobuf.Timestamp createdAt = 4 [json_name = "createdAt"];
+}`)
+
+	info := result.TypeMap["User"]
+	require.NotNil(t, info)
+	assert.Equal(t, []string{"id", "createdAt"}, info.ReadOnly)
+	assert.Equal(t, []string{"password"}, info.WriteOnly)
+}
+
+func TestConvertToStructReadOnlyWriteOnly(t *testing.T) {
+	result, err := schema.ConvertToStruct([]byte(readOnlySpec), schema.ConvertOptions{
+		GoPackagePath: "github.com/example/types/v1",
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, string(result.Golang), "\t// Read-only: set by the server; ignored in requests.\n"+
+		"\tID string `json:\"id\"`\n")
+	assert.Contains(t, string(result.Golang), "\t// Write-only: accepted in requests; never returned in responses.\n"+
+		"\tPassword string `json:\"password\"`\n")
+	assert.Equal(t, []string{"id", "createdAt"}, result.TypeMap["User"].ReadOnly)
+}
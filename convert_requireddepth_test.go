@@ -0,0 +1,104 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const requiredDepthSpec = `openapi: 3.1.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Order:
+      type: object
+      required: [customer]
+      properties:
+        customer:
+          $ref: '#/components/schemas/Customer'
+    Customer:
+      type: object
+      required: [address]
+      properties:
+        address:
+          $ref: '#/components/schemas/Address'
+        notes:
+          type: object
+          properties:
+            text:
+              type: string
+              example: VIP
+    Address:
+      type: object
+      required: [city, country]
+      properties:
+        city:
+          type: string
+          minLength: 2
+        country:
+          type: object
+          required: [code]
+          properties:
+            code:
+              type: string
+              enum: [FR, DE]
+        geo:
+          type: object
+          properties:
+            lat:
+              type: number
+              example: 48.85
+`
+
+func TestConvertToExamplesRequiredDepth(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		depth schema.RequiredDepth
+		want  string
+	}{
+		{
+			name:  "extend",
+			depth: schema.RequiredDepthExtend,
+			want:  `{"customer":{"address":{"city":"Bp","country":{"code":"FR"}}}}`,
+		},
+		{
+			name:  "stub",
+			depth: schema.RequiredDepthStub,
+			want:  `{"customer":{"address":{"city":"xx","country":{"code":"FR"}}}}`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := schema.ConvertToExamples([]byte(requiredDepthSpec), schema.ExampleOptions{
+				SchemaNames:   []string{"Order"},
+				MaxDepth:      2,
+				Seed:          1,
+				SelfValidate:  true,
+				RequiredDepth: test.depth,
+			})
+			require.NoError(t, err)
+			assert.JSONEq(t, test.want, string(result.Examples["Order"]))
+		})
+	}
+}
+
+func TestConvertToExamplesRequiredDepthDrop(t *testing.T) {
+	_, err := schema.ConvertToExamples([]byte(requiredDepthSpec), schema.ExampleOptions{
+		SchemaNames:  []string{"Order"},
+		MaxDepth:     2,
+		Seed:         1,
+		SelfValidate: true,
+	})
+	require.ErrorContains(t, err, "generated example for 'Order' is invalid")
+}
+
+func TestConvertToExamplesRequiredDepthInvalid(t *testing.T) {
+	_, err := schema.ConvertToExamples([]byte(requiredDepthSpec), schema.ExampleOptions{
+		IncludeAll:    true,
+		RequiredDepth: "deeper",
+	})
+	require.ErrorContains(t, err, `unknown RequiredDepth "deeper": must be drop, extend or stub`)
+}
@@ -0,0 +1,147 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const schemaNamesSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Invoice:
+      type: object
+      properties:
+        total:
+          type: number
+    Order:
+      type: object
+      properties:
+        shipping:
+          type: object
+          properties:
+            address:
+              $ref: '#/components/schemas/Address'
+        lineItems:
+          type: array
+          items:
+            $ref: '#/components/schemas/LineItem'
+    LineItem:
+      type: object
+      properties:
+        sku:
+          type: string
+        product:
+          $ref: '#/components/schemas/Product'
+    Product:
+      type: object
+      properties:
+        name:
+          type: string
+    Address:
+      type: object
+      properties:
+        street:
+          type: string
+    Owner:
+      type: object
+      properties:
+        pet:
+          $ref: '#/components/schemas/Pet'
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+      discriminator:
+        propertyName: petType
+    Dog:
+      type: object
+      properties:
+        petType:
+          type: string
+    Cat:
+      type: object
+      properties:
+        petType:
+          type: string
+`
+
+func TestConvertSchemaNames(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		names []string
+		want  []string
+	}{
+		{
+			name:  "transitive dependencies",
+			names: []string{"Order"},
+			want:  []string{"Order", "LineItem", "Product", "Address"},
+		},
+		{
+			name:  "union and its variants",
+			names: []string{"Owner", "Invoice"},
+			want:  []string{"Invoice", "Owner", "Pet", "Dog", "Cat"},
+		},
+		{
+			name:  "variant alone",
+			names: []string{"Dog"},
+			want:  []string{"Dog"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := schema.Convert([]byte(schemaNamesSpec), schema.ConvertOptions{
+				PackageName: "testpkg",
+				PackagePath: "github.com/example/proto/v1",
+				SchemaNames: test.names,
+			})
+			require.NoError(t, err)
+
+			var got []string
+			for name := range result.TypeMap {
+				got = append(got, name)
+			}
+			assert.ElementsMatch(t, test.want, got)
+		})
+	}
+}
+
+func TestConvertSchemaNamesVariantAloneIsProto(t *testing.T) {
+	result, err := schema.Convert([]byte(schemaNamesSpec), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+		SchemaNames: []string{"Dog"},
+	})
+	require.NoError(t, err)
+
+	// Without its union, a variant is an ordinary message
+	assert.Equal(t, schema.TypeLocationProto, result.TypeMap["Dog"].Location)
+	assert.Contains(t, string(result.Protobuf), "message Dog {")
+	assert.Empty(t, result.Golang)
+}
+
+func TestConvertToStructSchemaNames(t *testing.T) {
+	result, err := schema.ConvertToStruct([]byte(schemaNamesSpec), schema.ConvertOptions{
+		GoPackagePath: "github.com/example/types/v1",
+		SchemaNames:   []string{"LineItem"},
+	})
+	require.NoError(t, err)
+
+	golang := string(result.Golang)
+	assert.Contains(t, golang, "type LineItem struct {")
+	assert.Contains(t, golang, "type Product struct {")
+	assert.NotContains(t, golang, "type Order struct {")
+}
+
+func TestConvertSchemaNamesUnknown(t *testing.T) {
+	_, err := schema.Convert([]byte(schemaNamesSpec), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+		SchemaNames: []string{"Order", "Shipment", "Refund"},
+	})
+	require.ErrorContains(t, err, "schema 'Shipment', 'Refund' not found in components/schemas")
+}
@@ -0,0 +1,213 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertServices(t *testing.T) {
+	result, err := schema.Convert([]byte(`openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+x-services:
+  UserService:
+    description: Manages registered users
+    methods:
+      GetUser:
+        description: Returns a single user
+        request: GetUserRequest
+        response: User
+      DeleteUser:
+        request: '#/components/schemas/GetUserRequest'
+        response: google.protobuf.Empty
+      WatchUsers:
+        request: google.protobuf.Empty
+        response: User
+        serverStreaming: true
+      ImportUsers:
+        request: User
+        response: ImportSummary
+        clientStreaming: true
+components:
+  schemas:
+    GetUserRequest:
+      type: object
+      properties:
+        id:
+          type: string
+    User:
+      type: object
+      x-proto-name: Account
+      properties:
+        name:
+          type: string
+    ImportSummary:
+      type: object
+      properties:
+        imported:
+          type: integer
+`), schema.ConvertOptions{
+		PackageName:     "testpkg",
+		PackagePath:     "github.com/example/proto/v1",
+		BuildDescriptor: true,
+	})
+	require.NoError(t, err)
+
+	expected := `syntax = "proto3";
+
+package testpkg;
+
+import "google/protobuf/empty.proto";
+
+option go_package = "github.com/example/proto/v1";
+
+message GetUserRequest {
+  string id = 1 [json_name = "id"];
+}
+
+message Account {
+  string name = 1 [json_name = "name"];
+}
+
+message ImportSummary {
+  int32 imported = 1 [json_name = "imported"];
+}
+
+// Manages registered users
+service UserService {
+  // Returns a single user
+  rpc GetUser(GetUserRequest) returns (Account);
+  rpc DeleteUser(GetUserRequest) returns (google.protobuf.Empty);
+  rpc WatchUsers(google.protobuf.Empty) returns (stream Account);
+  rpc ImportUsers(stream Account) returns (ImportSummary);
+}
+
+`
+	assert.Equal(t, expected, string(result.Protobuf))
+
+	service := result.Descriptor.Services().ByName("UserService")
+	require.NotNil(t, service)
+	assert.Equal(t, 4, service.Methods().Len())
+	assert.True(t, service.Methods().ByName("WatchUsers").IsStreamingServer())
+}
+
+func TestConvertServicesInvalid(t *testing.T) {
+	const schemas = `
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        name:
+          type: string
+    Status:
+      type: integer
+      enum: [0, 1]
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+      discriminator:
+        propertyName: petType
+    Dog:
+      type: object
+      properties:
+        petType:
+          type: string
+    Cat:
+      type: object
+      properties:
+        petType:
+          type: string
+`
+	for _, test := range []struct {
+		name     string
+		services string
+		wantErr  string
+	}{
+		{
+			name:     "not a map",
+			services: "x-services: [UserService]",
+			wantErr:  "x-services must be a map of service names to services",
+		},
+		{
+			name: "unknown schema",
+			services: `x-services:
+  UserService:
+    methods:
+      GetUser:
+        request: GetUserRequest
+        response: User`,
+			wantErr: "service 'UserService' method 'GetUser': request 'GetUserRequest' is not an object schema in components/schemas",
+		},
+		{
+			name: "enum schema",
+			services: `x-services:
+  UserService:
+    methods:
+      GetStatus:
+        request: User
+        response: Status`,
+			wantErr: "service 'UserService' method 'GetStatus': response 'Status' is not an object schema in components/schemas",
+		},
+		{
+			name: "go-located schema",
+			services: `x-services:
+  PetService:
+    methods:
+      GetPet:
+        request: User
+        response: Pet`,
+			wantErr: "service 'PetService' method 'GetPet': response 'Pet' is generated as Go, not as a proto message",
+		},
+		{
+			name: "missing response",
+			services: `x-services:
+  UserService:
+    methods:
+      GetUser:
+        request: User`,
+			wantErr: "service 'UserService' method 'GetUser': response is required",
+		},
+		{
+			name: "no methods",
+			services: `x-services:
+  UserService:
+    description: Empty`,
+			wantErr: "service 'UserService': methods must be a non-empty map of method names to methods",
+		},
+		{
+			name: "name conflict",
+			services: `x-services:
+  User:
+    methods:
+      GetUser:
+        request: User
+        response: User`,
+			wantErr: "x-services: service 'User' conflicts with a message or enum of the same name",
+		},
+		{
+			name: "invalid method name",
+			services: `x-services:
+  UserService:
+    methods:
+      get-user:
+        request: User
+        response: User`,
+			wantErr: "service 'UserService' method 'get-user': invalid method name",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			spec := "openapi: 3.0.0\ninfo:\n  title: Test API\n  version: 1.0.0\n" + test.services + schemas
+			_, err := schema.Convert([]byte(spec), schema.ConvertOptions{
+				PackageName: "testpkg",
+				PackagePath: "github.com/example/proto/v1",
+			})
+			require.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
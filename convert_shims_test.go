@@ -0,0 +1,131 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const shimsSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Owner:
+      type: object
+      properties:
+        name:
+          type: string
+        pet:
+          $ref: '#/components/schemas/Pet'
+    Address:
+      type: object
+      properties:
+        street:
+          type: string
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+        - $ref: '#/components/schemas/Bird'
+      discriminator:
+        propertyName: petType
+    Dog:
+      type: object
+      properties:
+        petType:
+          type: string
+        home:
+          $ref: '#/components/schemas/Address'
+        born:
+          type: string
+          format: date-time
+        weights:
+          type: array
+          items:
+            type: integer
+            format: int16
+        rival:
+          $ref: '#/components/schemas/Bird'
+    Bird:
+      type: object
+      properties:
+        petType:
+          type: string
+    Cat:
+      type: object
+      properties:
+        petType:
+          type: string
+        collar:
+          type: object
+          properties:
+            color:
+              type: string
+`
+
+func TestConvertProtoShims(t *testing.T) {
+	result, err := schema.Convert([]byte(shimsSpec), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+		ProtoShims:  true,
+	})
+	require.NoError(t, err)
+
+	protobuf := string(result.Protobuf)
+	golang := string(result.Golang)
+
+	// Dog's fields all have proto equivalents, so it gets a shim message
+	assert.Contains(t, protobuf, "message DogProto {\n"+
+		"  string petType = 1 [json_name = \"petType\"];\n"+
+		"  Address home = 2 [json_name = \"home\"];\n"+
+		"  google.protobuf.Timestamp born = 3 [json_name = \"born\"];\n"+
+		"  repeated int32 weights = 4 [json_name = \"weights\"];\n"+
+		"  BirdProto rival = 5 [json_name = \"rival\"];\n"+
+		"}")
+	assert.Contains(t, golang, "func (x *Dog) ToProto() *DogProto {")
+	assert.Contains(t, golang, "func DogFromProto(p *DogProto) *Dog {")
+	assert.Contains(t, golang, "\tp.Home = x.Home\n")
+	assert.Contains(t, golang, "\tp.Born = timeToProto(x.Born)\n")
+	assert.Contains(t, golang, "\t\t\tp.Weights[i] = int32(v)\n")
+	assert.Contains(t, golang, "\t\t\tx.Weights[i] = int16(v)\n")
+	assert.Contains(t, golang, "\tp.Rival = x.Rival.ToProto()\n")
+	assert.Contains(t, golang, "\tx.Rival = BirdFromProto(p.Rival)\n")
+	assert.Contains(t, golang, `"google.golang.org/protobuf/types/known/timestamppb"`)
+	assert.Contains(t, result.TypeMap["Dog"].Notes, "converts to and from proto message DogProto")
+
+	// Unions, structs holding a union and inline objects have no proto equivalent
+	for _, name := range []string{"Pet", "Owner", "Cat"} {
+		assert.NotContains(t, protobuf, "message "+name+"Proto", name)
+		assert.NotContains(t, golang, "func (x *"+name+") ToProto()", name)
+	}
+}
+
+func TestConvertProtoShimsDisabled(t *testing.T) {
+	result, err := schema.Convert([]byte(shimsSpec), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+	})
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(result.Protobuf), "DogProto")
+	assert.NotContains(t, string(result.Golang), "ToProto")
+	assert.NotContains(t, string(result.Golang), "timestamppb")
+}
+
+func TestConvertProtoShimsProto3Optional(t *testing.T) {
+	result, err := schema.Convert([]byte(shimsSpec), schema.ConvertOptions{
+		PackageName:       "testpkg",
+		PackagePath:       "github.com/example/proto/v1",
+		ProtoShims:        true,
+		UseProto3Optional: true,
+	})
+	require.NoError(t, err)
+
+	// protoc-gen-go makes optional scalars pointers, so Dog and Bird no longer pair
+	assert.NotContains(t, string(result.Protobuf), "message BirdProto")
+	assert.NotContains(t, string(result.Golang), "ToProto()")
+}
@@ -0,0 +1,103 @@
+package schema_test
+
+import (
+	"regexp"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sortMessagesSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        customer:
+          $ref: '#/components/schemas/Customer'
+        status:
+          $ref: '#/components/schemas/Status'
+    Customer:
+      type: object
+      properties:
+        shipping:
+          type: object
+          properties:
+            address:
+              $ref: '#/components/schemas/Address'
+    Node:
+      type: object
+      properties:
+        edge:
+          $ref: '#/components/schemas/Edge'
+    Edge:
+      type: object
+      properties:
+        node:
+          $ref: '#/components/schemas/Node'
+    Address:
+      type: object
+      properties:
+        street:
+          type: string
+    Status:
+      type: integer
+      enum: [0, 1]
+`
+
+var definitionPattern = regexp.MustCompile(`(?m)^(?:message|enum) (\w+) \{`)
+
+func TestConvertSortMessages(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		order schema.MessageOrder
+		want  []string
+	}{
+		{
+			name:  "insertion by default",
+			order: "",
+			want:  []string{"Order", "Customer", "Node", "Edge", "Address", "Status"},
+		},
+		{
+			name:  "alphabetical",
+			order: schema.MessageOrderAlphabetical,
+			want:  []string{"Address", "Customer", "Edge", "Node", "Order", "Status"},
+		},
+		{
+			// Nested message references count; the Node/Edge cycle is placed last,
+			// broken at Node
+			name:  "topological",
+			order: schema.MessageOrderTopological,
+			want:  []string{"Address", "Customer", "Status", "Order", "Node", "Edge"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := schema.Convert([]byte(sortMessagesSpec), schema.ConvertOptions{
+				PackageName:  "testpkg",
+				PackagePath:  "github.com/example/proto/v1",
+				SortMessages: test.order,
+			})
+			require.NoError(t, err)
+
+			var got []string
+			for _, m := range definitionPattern.FindAllStringSubmatch(string(result.Protobuf), -1) {
+				got = append(got, m[1])
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestConvertSortMessagesInvalid(t *testing.T) {
+	_, err := schema.Convert([]byte(sortMessagesSpec), schema.ConvertOptions{
+		PackageName:  "testpkg",
+		PackagePath:  "github.com/example/proto/v1",
+		SortMessages: "random",
+	})
+	require.ErrorContains(t, err, `unknown SortMessages "random"`)
+}
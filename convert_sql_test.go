@@ -0,0 +1,269 @@
+package schema_test
+
+import (
+	"context"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertToSQL(t *testing.T) {
+	result, err := schema.ConvertToSQL([]byte(`openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    User:
+      type: object
+      description: A registered user
+      required: [id, email, status, nickname]
+      properties:
+        id:
+          type: string
+          format: uuid
+          x-db-primary-key: true
+        email:
+          type: string
+          maxLength: 254
+          description: Login address
+        status:
+          $ref: '#/components/schemas/Status'
+        nickname:
+          type: string
+          nullable: true
+        age:
+          type: integer
+        visits:
+          type: integer
+          format: int64
+        score:
+          type: number
+          format: float
+        rating:
+          type: number
+        balance:
+          $ref: '#/components/schemas/Money'
+        verified:
+          type: boolean
+        createdAt:
+          type: string
+          format: date-time
+        birthday:
+          type: string
+          format: date
+        avatar:
+          type: string
+          format: byte
+        tags:
+          type: array
+          items:
+            type: string
+        address:
+          type: object
+          properties:
+            street:
+              type: string
+        pet:
+          $ref: '#/components/schemas/Pet'
+        password:
+          type: string
+          x-db-skip: true
+    Status:
+      type: string
+      enum: [active, "won't"]
+    Money:
+      type: string
+      x-db-type: numeric(12, 2)
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Order'
+    Order:
+      type: object
+      x-db-table: purchase_order
+      properties:
+        userId:
+          type: string
+          x-db-primary-key: true
+        lineNumber:
+          type: integer
+          x-db-column: line
+          x-db-primary-key: true
+        priority:
+          type: integer
+          enum: [1, 2, null]
+    Audit:
+      type: object
+      x-db-skip: true
+      properties:
+        id:
+          type: string
+`), schema.SQLOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, `-- A registered user
+CREATE TABLE "user" (
+  id uuid,
+  -- Login address
+  email varchar(254) NOT NULL,
+  status text NOT NULL CHECK (status IN ('active', 'won''t')),
+  nickname text,
+  age integer,
+  visits bigint,
+  score real,
+  rating double precision,
+  balance numeric(12, 2),
+  verified boolean,
+  created_at timestamptz,
+  birthday date,
+  avatar bytea,
+  tags jsonb,
+  address jsonb,
+  pet jsonb,
+  PRIMARY KEY (id)
+);
+
+CREATE TABLE purchase_order (
+  user_id text,
+  line integer,
+  priority integer CHECK (priority IN (1, 2)),
+  PRIMARY KEY (user_id, line)
+);
+`, string(result.SQL))
+}
+
+func TestConvertToSQLSchemaNames(t *testing.T) {
+	result, err := schema.ConvertToSQL([]byte(sqlSpec), schema.SQLOptions{
+		Dialect:     schema.SQLDialectPostgres,
+		SchemaNames: []string{"Customer"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "CREATE TABLE customer (\n  customer_id integer\n);\n", string(result.SQL))
+}
+
+const sqlSpec = `openapi: 3.1.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    LineItem:
+      type: object
+      required: [sku]
+      properties:
+        sku:
+          type: string
+        note:
+          type: [string, "null"]
+    Customer:
+      type: object
+      properties:
+        customerId:
+          type: integer
+`
+
+func TestConvertToSQLErrors(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		schemas string
+		opts    schema.SQLOptions
+		wantErr string
+	}{
+		{
+			name:    "unknown dialect",
+			schemas: "    A:\n      type: object\n      properties:\n        id:\n          type: string\n",
+			opts:    schema.SQLOptions{Dialect: "oracle"},
+			wantErr: `unknown Dialect "oracle": must be postgres`,
+		},
+		{
+			name:    "unknown schema name",
+			schemas: "    A:\n      type: object\n      properties:\n        id:\n          type: string\n",
+			opts:    schema.SQLOptions{SchemaNames: []string{"Missing"}},
+			wantErr: "'Missing'",
+		},
+		{
+			name:    "invalid table name",
+			schemas: "    A:\n      type: object\n      x-db-table: my-table\n      properties:\n        id:\n          type: string\n",
+			wantErr: "schema 'A': x-db-table must be an SQL identifier (letters, digits and underscores, not starting with a digit), got: my-table",
+		},
+		{
+			name:    "table name on enum",
+			schemas: "    A:\n      type: string\n      x-db-table: a\n      enum: [x]\n",
+			wantErr: "schema 'A': x-db-table requires an object schema with properties",
+		},
+		{
+			name:    "duplicate table",
+			schemas: "    UserAccount:\n      type: object\n      properties:\n        id:\n          type: string\n    B:\n      type: object\n      x-db-table: user_account\n      properties:\n        id:\n          type: string\n",
+			wantErr: "schema 'B': table 'user_account' is already generated for schema 'UserAccount'; rename one with x-db-table",
+		},
+		{
+			name:    "duplicate column",
+			schemas: "    A:\n      type: object\n      properties:\n        userId:\n          type: string\n        user_id:\n          type: string\n",
+			wantErr: "schema 'A': property 'user_id': column 'user_id' is already generated for property 'userId'; rename one with x-db-column",
+		},
+		{
+			name:    "invalid column type",
+			schemas: "    A:\n      type: object\n      properties:\n        id:\n          type: string\n          x-db-type: \"text; DROP TABLE a\"\n",
+			wantErr: "schema 'A': property 'id': x-db-type must be a column type such as uuid or varchar(64), got: text; DROP TABLE a",
+		},
+		{
+			name:    "invalid primary key",
+			schemas: "    A:\n      type: object\n      properties:\n        id:\n          type: string\n          x-db-primary-key: yes\n",
+			wantErr: "schema 'A': property 'id': x-db-primary-key must be true or false, got: yes",
+		},
+		{
+			name:    "nullable primary key",
+			schemas: "    A:\n      type: object\n      properties:\n        id:\n          type: [string, \"null\"]\n          x-db-primary-key: true\n",
+			wantErr: "schema 'A': property 'id': x-db-primary-key cannot be set on a nullable property",
+		},
+		{
+			name:    "invalid skip",
+			schemas: "    A:\n      type: object\n      x-db-skip: 1\n      properties:\n        id:\n          type: string\n",
+			wantErr: "schema 'A': x-db-skip must be true or false, got: 1",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			spec := "openapi: 3.1.0\ninfo:\n  title: Test API\n  version: 1.0.0\ncomponents:\n  schemas:\n" + test.schemas
+			_, err := schema.ConvertToSQL([]byte(spec), test.opts)
+			require.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
+
+func TestConvertToSQLContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := schema.ConvertToSQLContext(ctx, []byte(sqlSpec), schema.SQLOptions{})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestSQLGenerator(t *testing.T) {
+	registry, err := schema.NewGeneratorRegistry(schema.NewSQLGenerator(schema.SQLOptions{}))
+	require.NoError(t, err)
+
+	result, err := schema.Generate([]byte(sqlSpec), schema.GenerateOptions{
+		Generators: registry,
+		Options:    map[string]map[string]string{"sql": {"dialect": "postgres"}},
+	})
+	require.NoError(t, err)
+
+	want, err := schema.ConvertToSQL([]byte(sqlSpec), schema.SQLOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []schema.OutputFile{{Path: "schema.sql", Content: want.SQL}}, result["sql"])
+	assert.Contains(t, string(want.SQL), "CREATE TABLE line_item (\n  sku text NOT NULL,\n  note text\n);\n")
+
+	_, err = schema.Generate([]byte(sqlSpec), schema.GenerateOptions{
+		Generators: registry,
+		Options:    map[string]map[string]string{"sql": {"dialect": "mysql"}},
+	})
+	require.ErrorContains(t, err, `generator 'sql': unknown Dialect "mysql": must be postgres`)
+
+	_, err = schema.Generate([]byte(sqlSpec), schema.GenerateOptions{
+		Generators: registry,
+		Options:    map[string]map[string]string{"sql": {"schema": "public"}},
+	})
+	require.ErrorContains(t, err, `generator 'sql': unknown option "schema": must be dialect`)
+}
@@ -1062,6 +1062,7 @@ components:
 `
 
 	result, err := schema.Convert([]byte(given), schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
 		PackageName:   "testpkg",
 		PackagePath:   "github.com/example/proto/v1",
 		GoPackagePath: "github.com/example/types/v1",
@@ -1098,7 +1099,7 @@ components:
 	assert.Contains(t, goCode, "Meow string")
 
 	// Check MarshalJSON
-	assert.Contains(t, goCode, "func (u *Pet) MarshalJSON() ([]byte, error)")
+	assert.Contains(t, goCode, "func (u Pet) MarshalJSON() ([]byte, error)")
 
 	// Check UnmarshalJSON
 	assert.Contains(t, goCode, "func (u *Pet) UnmarshalJSON(data []byte) error")
@@ -1269,6 +1270,7 @@ components:
 `
 
 	result, err := schema.Convert([]byte(given), schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
 		PackageName:   "testpkg",
 		PackagePath:   "github.com/example/proto/v1",
 		GoPackagePath: "github.com/example/types/v1",
@@ -1587,6 +1589,7 @@ components:
 `)
 
 	result, err := schema.ConvertToStruct(input, schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
 		GoPackagePath: "github.com/example/models",
 	})
 
@@ -1598,7 +1601,7 @@ components:
 	goCode := string(result.Golang)
 	assert.Contains(t, goCode, "package models")
 	assert.Contains(t, goCode, "type User struct")
-	assert.Contains(t, goCode, "Id")
+	assert.Contains(t, goCode, "ID string")
 	assert.Contains(t, goCode, "Name")
 	assert.Contains(t, goCode, "Age")
 	assert.Contains(t, goCode, "json:\"id\"")
@@ -1667,7 +1670,7 @@ components:
 	assert.Contains(t, goCode, "type Cat struct")
 	assert.Contains(t, goCode, "type Product struct")
 
-	assert.Contains(t, goCode, "func (u *Pet) MarshalJSON()")
+	assert.Contains(t, goCode, "func (u Pet) MarshalJSON()")
 	assert.Contains(t, goCode, "func (u *Pet) UnmarshalJSON(")
 
 	require.Len(t, result.TypeMap, 4)
@@ -1736,7 +1739,7 @@ components:
 
 	goCode := string(result.Golang)
 
-	assert.Contains(t, goCode, "func (u *Shape) MarshalJSON() ([]byte, error)")
+	assert.Contains(t, goCode, "func (u Shape) MarshalJSON() ([]byte, error)")
 	assert.Contains(t, goCode, "func (u *Shape) UnmarshalJSON(data []byte) error")
 
 	assert.Contains(t, goCode, "case \"circle\":")
@@ -1813,9 +1816,9 @@ components:
 	assert.Contains(t, goCode, "type Car struct")
 	assert.Contains(t, goCode, "type Bike struct")
 
-	assert.Contains(t, goCode, "func (u *Pet) MarshalJSON()")
+	assert.Contains(t, goCode, "func (u Pet) MarshalJSON()")
 	assert.Contains(t, goCode, "func (u *Pet) UnmarshalJSON(")
-	assert.Contains(t, goCode, "func (u *Vehicle) MarshalJSON()")
+	assert.Contains(t, goCode, "func (u Vehicle) MarshalJSON()")
 	assert.Contains(t, goCode, "func (u *Vehicle) UnmarshalJSON(")
 
 	require.Len(t, result.TypeMap, 6)
@@ -1891,7 +1894,7 @@ components:
 	assert.Contains(t, goCode, "type Cat struct")
 	assert.Contains(t, goCode, "type Product struct")
 
-	assert.Contains(t, goCode, "func (u *Pet) MarshalJSON()")
+	assert.Contains(t, goCode, "func (u Pet) MarshalJSON()")
 	assert.Contains(t, goCode, "func (u *Pet) UnmarshalJSON(")
 
 	require.Len(t, result.TypeMap, 5)
@@ -2111,7 +2114,7 @@ func main() {
 		fmt.Fprintf(os.Stderr, "user unmarshal error: %v\n", err)
 		os.Exit(1)
 	}
-	if user.Id != "123" || user.Name != "Alice" {
+	if user.ID != "123" || user.Name != "Alice" {
 		fmt.Fprintf(os.Stderr, "user values incorrect\n")
 		os.Exit(1)
 	}
@@ -2313,7 +2316,7 @@ components:
 	assert.Contains(t, goCode, "type Dog struct")
 	assert.Contains(t, goCode, "type Cat struct")
 
-	assert.Contains(t, goCode, "func (u *Pet) MarshalJSON()")
+	assert.Contains(t, goCode, "func (u Pet) MarshalJSON()")
 	assert.Contains(t, goCode, "func (u *Pet) UnmarshalJSON(")
 
 	require.Len(t, result.TypeMap, 3)
@@ -0,0 +1,250 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const jsonSchemaSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    User:
+      type: object
+      required: [id]
+      x-proto-name: Account
+      properties:
+        id:
+          type: string
+          example: u-1
+        age:
+          type: integer
+          minimum: 0
+          exclusiveMinimum: true
+        nickname:
+          type: string
+          nullable: true
+        address:
+          $ref: '#/components/schemas/Address'
+        manager:
+          $ref: '#/components/schemas/User'
+    Address:
+      type: object
+      properties:
+        country:
+          $ref: '#/components/schemas/Country'
+    Country:
+      type: string
+      enum: [US, CA]
+      nullable: true
+`
+
+func TestConvertToJSONSchema(t *testing.T) {
+	result, err := schema.ConvertToJSONSchema([]byte(jsonSchemaSpec), schema.JSONSchemaOptions{
+		BaseURI: "https://example.com/schemas/",
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Schemas, 3)
+
+	user := result.Schemas[0]
+	assert.Equal(t, "User", user.Name)
+	assert.Equal(t, `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$id": "https://example.com/schemas/User.json",
+  "type": "object",
+  "required": [
+    "id"
+  ],
+  "properties": {
+    "id": {
+      "type": "string",
+      "examples": [
+        "u-1"
+      ]
+    },
+    "age": {
+      "type": "integer",
+      "exclusiveMinimum": 0
+    },
+    "nickname": {
+      "type": [
+        "string",
+        "null"
+      ]
+    },
+    "address": {
+      "$ref": "#/$defs/Address"
+    },
+    "manager": {
+      "$ref": "#"
+    }
+  },
+  "$defs": {
+    "Address": {
+      "type": "object",
+      "properties": {
+        "country": {
+          "$ref": "#/$defs/Country"
+        }
+      }
+    },
+    "Country": {
+      "type": [
+        "string",
+        "null"
+      ],
+      "enum": [
+        "US",
+        "CA",
+        null
+      ]
+    }
+  }
+}
+`, string(user.Schema))
+
+	assert.Equal(t, "Address", result.Schemas[1].Name)
+	assert.Equal(t, "Country", result.Schemas[2].Name)
+	assert.Equal(t, `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$id": "https://example.com/schemas/Country.json",
+  "type": [
+    "string",
+    "null"
+  ],
+  "enum": [
+    "US",
+    "CA",
+    null
+  ]
+}
+`, string(result.Schemas[2].Schema))
+}
+
+func TestConvertToJSONSchemaTranslations(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		schema   string
+		expected string
+	}{
+		{
+			name: "nullable reference",
+			schema: `
+      nullable: true
+      allOf:
+        - $ref: '#/components/schemas/Other'`,
+			expected: `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "anyOf": [
+    {
+      "allOf": [
+        {
+          "$ref": "#/$defs/Other"
+        }
+      ]
+    },
+    {
+      "type": "null"
+    }
+  ],
+  "$defs": {
+    "Other": {
+      "type": "string"
+    }
+  }
+}
+`,
+		},
+		{
+			name: "non-exclusive bound",
+			schema: `
+      type: number
+      maximum: 10
+      exclusiveMaximum: false`,
+			expected: `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "number",
+  "maximum": 10
+}
+`,
+		},
+		{
+			name: "openapi keywords dropped",
+			schema: `
+      type: object
+      discriminator:
+        propertyName: kind
+      xml:
+        name: subject
+      externalDocs:
+        url: https://example.com
+      x-go-type: Subject
+      properties:
+        kind:
+          type: string
+        x-total:
+          type: integer`,
+			expected: `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "kind": {
+      "type": "string"
+    },
+    "x-total": {
+      "type": "integer"
+    }
+  }
+}
+`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := schema.ConvertToJSONSchema([]byte(`openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Subject:`+test.schema+`
+    Other:
+      type: string
+`), schema.JSONSchemaOptions{SchemaNames: []string{"Subject"}})
+			require.NoError(t, err)
+			require.Len(t, result.Schemas, 1)
+			assert.Equal(t, test.expected, string(result.Schemas[0].Schema))
+		})
+	}
+}
+
+func TestConvertToJSONSchemaErrors(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		input   string
+		opts    schema.JSONSchemaOptions
+		wantErr string
+	}{
+		{
+			name:    "empty input",
+			wantErr: "openapi input cannot be empty",
+		},
+		{
+			name:    "unknown schema name",
+			input:   jsonSchemaSpec,
+			opts:    schema.JSONSchemaOptions{SchemaNames: []string{"Missing"}},
+			wantErr: "schema 'Missing' not found in components/schemas",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := schema.ConvertToJSONSchema([]byte(test.input), test.opts)
+			require.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
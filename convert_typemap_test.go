@@ -0,0 +1,78 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const typeMapSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Status:
+      type: integer
+      enum: [0, 1, 2]
+    user_address:
+      type: object
+      properties:
+        street:
+          type: string
+        city:
+          type: string
+    Owner:
+      type: object
+      properties:
+        name:
+          type: string
+        home:
+          $ref: '#/components/schemas/user_address'
+        pets:
+          type: array
+          items:
+            $ref: '#/components/schemas/Pet'
+        status:
+          $ref: '#/components/schemas/Status'
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+      discriminator:
+        propertyName: petType
+    Dog:
+      type: object
+      properties:
+        petType:
+          type: string
+    Cat:
+      type: object
+      properties:
+        petType:
+          type: string
+`
+
+func TestConvertTypeMapDetails(t *testing.T) {
+	result, err := schema.Convert([]byte(typeMapSpec), schema.ConvertOptions{
+		PackageName:   "testpkg",
+		PackagePath:   "github.com/example/proto/v1",
+		GoPackagePath: "github.com/example/types/v1",
+	})
+	require.NoError(t, err)
+
+	for name, want := range map[string]schema.TypeInfo{
+		"Status":       {Location: schema.TypeLocationProto, GeneratedName: "Status", FieldCount: 3},
+		"user_address": {Location: schema.TypeLocationProto, GeneratedName: "UserAddress", FieldCount: 2},
+		"Owner": {Location: schema.TypeLocationGolang, Reason: "references union type Pet",
+			GeneratedName: "Owner", FieldCount: 4, Dependencies: []string{"user_address", "Pet", "Status"}},
+		"Pet": {Location: schema.TypeLocationGolang, Reason: "contains oneOf",
+			GeneratedName: "Pet", FieldCount: 2, Dependencies: []string{"Dog", "Cat"}},
+	} {
+		got := result.TypeMap[name]
+		require.NotNil(t, got, name)
+		assert.Equal(t, want, *got, name)
+	}
+}
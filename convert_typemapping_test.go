@@ -0,0 +1,116 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const typeMappingSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Invoice:
+      type: object
+      properties:
+        id:
+          type: string
+          format: uuid
+        total:
+          type: string
+          format: decimal
+        lines:
+          type: array
+          items:
+            type: string
+            format: decimal
+`
+
+var typeMappings = []schema.TypeMapping{
+	{
+		Type:        "string",
+		Format:      "decimal",
+		Proto:       "google.type.Decimal",
+		ProtoImport: "google/type/decimal.proto",
+		Go:          "decimal.Decimal",
+		GoImport:    "github.com/shopspring/decimal",
+	},
+	{
+		Type:     "string",
+		Format:   "uuid",
+		Go:       "uuid.UUID",
+		GoImport: "github.com/google/uuid",
+	},
+}
+
+func TestConvertTypeMappings(t *testing.T) {
+	result, err := schema.Convert([]byte(typeMappingSpec), schema.ConvertOptions{
+		PackageName:  "testpkg",
+		PackagePath:  "github.com/example/proto/v1",
+		TypeMappings: typeMappings,
+	})
+	require.NoError(t, err)
+
+	protobuf := string(result.Protobuf)
+	assert.Contains(t, protobuf, "package testpkg;\n\nimport \"google/type/decimal.proto\";\n\noption go_package")
+	// uuid has no proto mapping, so it keeps the built-in string
+	assert.Contains(t, protobuf, "  string id = 1 [json_name = \"id\"];")
+	assert.Contains(t, protobuf, "  google.type.Decimal total = 2 [json_name = \"total\"];")
+	assert.Contains(t, protobuf, "  repeated google.type.Decimal lines = 3 [json_name = \"lines\"];")
+	assert.Empty(t, result.Warnings)
+}
+
+func TestConvertToStructTypeMappings(t *testing.T) {
+	result, err := schema.ConvertToStruct([]byte(typeMappingSpec), schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
+		GoPackagePath: "github.com/example/types/v1",
+		TypeMappings:  typeMappings,
+	})
+	require.NoError(t, err)
+
+	golang := string(result.Golang)
+	assert.Contains(t, golang, "\t\"strings\"\n\n\t\"github.com/google/uuid\"\n\t\"github.com/shopspring/decimal\"\n")
+	assert.Contains(t, golang, "\tID uuid.UUID `json:\"id\"`\n")
+	assert.Contains(t, golang, "\tTotal decimal.Decimal `json:\"total\"`\n")
+	assert.Contains(t, golang, "\tLines []decimal.Decimal `json:\"lines\"`\n")
+}
+
+func TestConvertTypeMappingsInvalid(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		mappings []schema.TypeMapping
+		wantErr  string
+	}{
+		{
+			name:     "missing type",
+			mappings: []schema.TypeMapping{{Format: "decimal", Go: "decimal.Decimal"}},
+			wantErr:  "type mapping for format 'decimal' must set Type",
+		},
+		{
+			name:     "no target",
+			mappings: []schema.TypeMapping{{Type: "string", Format: "decimal"}},
+			wantErr:  "type mapping for string/decimal must set Proto or Go",
+		},
+		{
+			name: "duplicate",
+			mappings: []schema.TypeMapping{
+				{Type: "string", Format: "uuid", Go: "uuid.UUID"},
+				{Type: "string", Format: "uuid", Go: "string"},
+			},
+			wantErr: "duplicate type mapping for string/uuid",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := schema.Convert([]byte(typeMappingSpec), schema.ConvertOptions{
+				PackageName:  "testpkg",
+				PackagePath:  "github.com/example/proto/v1",
+				TypeMappings: test.mappings,
+			})
+			require.EqualError(t, err, test.wantErr)
+		})
+	}
+}
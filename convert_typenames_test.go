@@ -0,0 +1,122 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const typeNamesSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Address:
+      type: object
+      x-proto-name: PostalAddress
+      properties:
+        street:
+          type: string
+    Owner:
+      type: object
+      properties:
+        home:
+          $ref: '#/components/schemas/Address'
+        pet:
+          $ref: '#/components/schemas/Pet'
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+      discriminator:
+        propertyName: petType
+    Dog:
+      type: object
+      x-go-name: Canine
+      properties:
+        petType:
+          type: string
+        toys:
+          type: array
+          items:
+            $ref: '#/components/schemas/Address'
+    Cat:
+      type: object
+      properties:
+        petType:
+          type: string
+        friend:
+          $ref: '#/components/schemas/Dog'
+`
+
+func TestConvertTypeNameOverrides(t *testing.T) {
+	result, err := schema.Convert([]byte(typeNamesSpec), schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
+		PackageName:   "testpkg",
+		PackagePath:   "github.com/example/proto/v1",
+		GoPackagePath: "github.com/example/types/v1",
+	})
+	require.NoError(t, err)
+
+	protobuf := string(result.Protobuf)
+	golang := string(result.Golang)
+
+	assert.Contains(t, protobuf, "message PostalAddress {")
+	assert.NotContains(t, protobuf, "message Address {")
+
+	// Go references a proto message by its protoc-generated name, and a renamed
+	// struct by its x-go-name
+	assert.Contains(t, golang, "\tHome *PostalAddress `json:\"home\"`\n")
+	assert.Contains(t, golang, "type Canine struct {")
+	assert.Contains(t, golang, "\tToys []*PostalAddress `json:\"toys\"`\n")
+	assert.Contains(t, golang, "\tFriend *Canine `json:\"friend\"`\n")
+	assert.Contains(t, golang, "\tCanine *Canine `json:\"-\"`\n")
+	assert.Contains(t, golang, "\t\tu.Canine = &Canine{}\n")
+	assert.NotContains(t, golang, "type Dog")
+	assert.NotContains(t, golang, "*Dog")
+	// The implicit discriminator value stays the schema name
+	assert.Contains(t, golang, "\t\t\tv.PetType = \"Dog\"\n")
+
+	assert.Equal(t, "PostalAddress", result.TypeMap["Address"].GeneratedName)
+	assert.Equal(t, "Canine", result.TypeMap["Dog"].GeneratedName)
+	assert.Equal(t, "Cat", result.TypeMap["Cat"].GeneratedName)
+}
+
+func TestConvertToStructTypeNameOverrides(t *testing.T) {
+	result, err := schema.ConvertToStruct([]byte(typeNamesSpec), schema.ConvertOptions{
+		GoPackagePath: "github.com/example/types/v1",
+	})
+	require.NoError(t, err)
+
+	// x-proto-name has no effect on Go structs
+	golang := string(result.Golang)
+	assert.Contains(t, golang, "type Address struct {")
+	assert.Contains(t, golang, "\tHome *Address `json:\"home\"`\n")
+	assert.Contains(t, golang, "type Canine struct {")
+	assert.Equal(t, "Address", result.TypeMap["Address"].GeneratedName)
+	assert.Equal(t, "Canine", result.TypeMap["Dog"].GeneratedName)
+}
+
+func TestConvertTypeNameOverrideInvalid(t *testing.T) {
+	given := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Address:
+      type: object
+      x-proto-name: Postal-Address
+      properties:
+        street:
+          type: string
+`
+	_, err := schema.Convert([]byte(given), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+	})
+	require.ErrorContains(t, err, "schema 'Address': x-proto-name must be a type name (letters, digits and underscores, starting with a letter), got: Postal-Address")
+}
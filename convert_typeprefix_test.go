@@ -0,0 +1,79 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const typePrefixSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Status:
+      type: integer
+      enum: [0, 1]
+    Account:
+      type: object
+      x-proto-name: Ledger
+      properties:
+        status:
+          $ref: '#/components/schemas/Status'
+    Owner:
+      type: object
+      properties:
+        pet:
+          $ref: '#/components/schemas/Pet'
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+      discriminator:
+        propertyName: petType
+    Dog:
+      type: object
+      properties:
+        petType:
+          type: string
+    Cat:
+      type: object
+      properties:
+        petType:
+          type: string
+`
+
+func TestConvertTypePrefix(t *testing.T) {
+	result, err := schema.Convert([]byte(typePrefixSpec), schema.ConvertOptions{
+		PackageName:   "testpkg",
+		PackagePath:   "github.com/example/proto",
+		GoPackagePath: "github.com/example/types",
+		TypePrefix:    "Acme",
+	})
+	require.NoError(t, err)
+
+	proto := string(result.Protobuf)
+	assert.Contains(t, proto, "enum AcmeStatus {\n")
+	assert.Contains(t, proto, "message AcmeLedger {\n  AcmeStatus status = 1 [json_name = \"status\"];\n")
+	assert.NotContains(t, proto, "message Account")
+
+	golang := string(result.Golang)
+	assert.Contains(t, golang, "type AcmeOwner struct {\n\tPet *AcmePet `json:\"pet\"`\n}\n")
+	assert.Contains(t, golang, "type AcmePet struct {\n\tAcmeDog *AcmeDog `json:\"-\"`\n\tAcmeCat *AcmeCat `json:\"-\"`\n}\n")
+
+	assert.Equal(t, "AcmeLedger", result.TypeMap["Account"].GeneratedName)
+	assert.Equal(t, "AcmeStatus", result.TypeMap["Status"].GeneratedName)
+	assert.Equal(t, "AcmeOwner", result.TypeMap["Owner"].GeneratedName)
+}
+
+func TestConvertTypePrefixInvalid(t *testing.T) {
+	_, err := schema.Convert([]byte(typePrefixSpec), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto",
+		TypePrefix:  "acme-",
+	})
+	require.ErrorContains(t, err, `invalid TypePrefix "acme-": must be letters, digits and underscores, starting with a letter`)
+}
@@ -0,0 +1,289 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertToTypeScript(t *testing.T) {
+	result, err := schema.ConvertToTypeScript([]byte(`openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    User:
+      type: object
+      description: A registered user
+      required: [id, status]
+      properties:
+        id:
+          type: string
+          readOnly: true
+        status:
+          $ref: '#/components/schemas/Status'
+        age:
+          type: integer
+          format: int64
+        nickname:
+          type: string
+          nullable: true
+          deprecated: true
+        tags:
+          type: array
+          items:
+            type: string
+        shipping:
+          type: object
+          properties:
+            street:
+              type: string
+        labels:
+          type: object
+          additionalProperties:
+            type: string
+        pet:
+          $ref: '#/components/schemas/Pet'
+        "content-type":
+          type: string
+    Status:
+      type: string
+      enum: [active, "in<active>"]
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+      discriminator:
+        propertyName: petType
+        mapping:
+          dog: '#/components/schemas/Dog'
+          puppy: '#/components/schemas/Dog'
+          cat: '#/components/schemas/Cat'
+    Dog:
+      type: object
+      required: [petType]
+      properties:
+        petType:
+          type: string
+        bark:
+          type: boolean
+    Cat:
+      type: object
+      x-go-name: Kitty
+      required: [petType]
+      properties:
+        petType:
+          type: string
+    Shape:
+      oneOf:
+        - type: object
+          properties:
+            radius:
+              type: number
+        - $ref: '#/components/schemas/Dog'
+    Named:
+      allOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Pet'
+`), schema.TypeScriptOptions{})
+	require.NoError(t, err)
+
+	expected := `/** A registered user */
+export interface User {
+  /** Read-only: set by the server; ignored in requests. */
+  readonly id: string;
+  status: Status;
+  age?: number;
+  /** @deprecated */
+  nickname?: string | null;
+  tags?: string[];
+  shipping?: UserShipping;
+  labels?: Record<string, string>;
+  pet?: Pet;
+  "content-type"?: string;
+}
+
+export interface UserShipping {
+  street?: string;
+}
+
+export type Status = "active" | "in<active>";
+
+export type Pet = (Dog & { petType: "dog" | "puppy" }) | (Kitty & { petType: "cat" });
+
+export interface Dog {
+  petType: string;
+  bark?: boolean;
+}
+
+export interface Kitty {
+  petType: string;
+}
+
+export type Shape = { radius?: number } | Dog;
+
+export type Named = Dog & Pet;
+`
+	assert.Equal(t, expected, string(result.TypeScript))
+}
+
+func TestConvertToTypeScriptOptions(t *testing.T) {
+	const openapi = `openapi: 3.1.0
+info:
+  title: Test API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Order:
+      type: object
+      description: |-
+        An order.
+        Totals are in cents.
+      properties:
+        items:
+          type: array
+          items:
+            type: object
+            properties:
+              sku:
+                type: string
+        note:
+          type: [string, "null"]
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+      discriminator:
+        propertyName: kind
+    Dog:
+      type: object
+      properties:
+        kind:
+          type: string
+    Unrelated:
+      type: string
+`
+	for _, test := range []struct {
+		name     string
+		opts     schema.TypeScriptOptions
+		expected string
+	}{
+		{
+			name: "nested names",
+			opts: schema.TypeScriptOptions{
+				SchemaNames: []string{"Order"},
+				NestedNameFunc: func(parent, property string) string {
+					if property == "items" {
+						return "LineItem"
+					}
+					return ""
+				},
+			},
+			expected: `/**
+ * An order.
+ * Totals are in cents.
+ */
+export interface Order {
+  items?: OrderLineItem[];
+  note?: string | null;
+}
+
+export interface OrderLineItem {
+  sku?: string;
+}
+`,
+		},
+		{
+			name: "discriminator without mapping",
+			opts: schema.TypeScriptOptions{SchemaNames: []string{"Pet"}},
+			expected: `export type Pet = (Dog & { kind: "Dog" });
+
+export interface Dog {
+  kind?: string;
+}
+`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := schema.ConvertToTypeScript([]byte(openapi), test.opts)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, string(result.TypeScript))
+		})
+	}
+}
+
+func TestConvertToTypeScriptErrors(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		input   string
+		opts    schema.TypeScriptOptions
+		wantErr string
+	}{
+		{
+			name:    "empty input",
+			wantErr: "openapi input cannot be empty",
+		},
+		{
+			name: "unknown schema name",
+			input: `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    User:
+      type: object
+`,
+			opts:    schema.TypeScriptOptions{SchemaNames: []string{"Missing"}},
+			wantErr: "schema 'Missing' not found in components/schemas",
+		},
+		{
+			name: "invalid type name",
+			input: `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    user-record:
+      type: object
+`,
+			wantErr: "schema 'user-record': 'user-record' is not a valid TypeScript type name; rename it with x-go-name",
+		},
+		{
+			name: "variant missing from mapping",
+			input: `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+      discriminator:
+        propertyName: kind
+        mapping:
+          dog: '#/components/schemas/Dog'
+    Dog:
+      type: object
+    Cat:
+      type: object
+`,
+			wantErr: "schema 'Pet': variant 'Cat' not covered by discriminator mapping",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := schema.ConvertToTypeScript([]byte(test.input), test.opts)
+			require.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
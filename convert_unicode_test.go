@@ -0,0 +1,133 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const unicodeSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Person:
+      type: object
+      properties:
+        prénom:
+          type: string
+        straße:
+          type: string
+        名前:
+          type: string
+        adresse_été:
+          type: object
+          properties:
+            ville:
+              type: string
+`
+
+func TestConvertNonASCIINames(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		mode    schema.NonASCIINames
+		proto   []string
+		golang  []string
+		renames []schema.Rename
+	}{
+		{
+			name: "transliterate by default",
+			proto: []string{
+				"  string prenom = 1 [json_name = \"prénom\"];\n",
+				"  string strasse = 2 [json_name = \"straße\"];\n",
+				"  string u540d_u524d = 3 [json_name = \"名前\"];\n",
+				"  message AdresseEte {\n",
+				"  AdresseEte adresse_ete = 4 [json_name = \"adresse_été\"];\n",
+			},
+			golang: []string{
+				"Prenom string `json:\"prénom\"`",
+				"Strasse string `json:\"straße\"`",
+				"U540dU524d string `json:\"名前\"`",
+				"AdresseEte *PersonAdresseEte `json:\"adresse_été\"`",
+			},
+			renames: []schema.Rename{
+				{Schema: "Person", Property: "prénom", Kind: "field", Reason: "sanitized", From: "prénom", To: "prenom"},
+				{Schema: "Person", Property: "straße", Kind: "field", Reason: "sanitized", From: "straße", To: "strasse"},
+				{Schema: "Person", Property: "名前", Kind: "field", Reason: "sanitized", From: "名前", To: "u540d_u524d"},
+				{Schema: "Person", Property: "adresse_été", Kind: "field", Reason: "sanitized", From: "adresse_été", To: "adresse_ete"},
+			},
+		},
+		{
+			name: "escape",
+			mode: schema.NonASCIIEscape,
+			proto: []string{
+				"  string pr_u00e9_nom = 1 [json_name = \"prénom\"];\n",
+				"  string stra_u00df_e = 2 [json_name = \"straße\"];\n",
+				"  string u540d_u524d = 3 [json_name = \"名前\"];\n",
+				"  message AdresseU00e9TU00e9 {\n",
+			},
+			golang: []string{
+				"PrU00e9Nom string `json:\"prénom\"`",
+				"U540dU524d string `json:\"名前\"`",
+			},
+			renames: []schema.Rename{
+				{Schema: "Person", Property: "prénom", Kind: "field", Reason: "sanitized", From: "prénom", To: "pr_u00e9_nom"},
+				{Schema: "Person", Property: "straße", Kind: "field", Reason: "sanitized", From: "straße", To: "stra_u00df_e"},
+				{Schema: "Person", Property: "名前", Kind: "field", Reason: "sanitized", From: "名前", To: "u540d_u524d"},
+				{Schema: "Person", Property: "adresse_été", Kind: "field", Reason: "sanitized", From: "adresse_été", To: "adresse_u00e9_t_u00e9"},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := schema.Convert([]byte(unicodeSpec), schema.ConvertOptions{
+				GoFormat:      schema.GoFormatNone,
+				PackageName:   "testpkg",
+				PackagePath:   "github.com/example/proto",
+				NonASCIINames: test.mode,
+			})
+			require.NoError(t, err)
+			for _, expected := range test.proto {
+				assert.Contains(t, string(result.Protobuf), expected)
+			}
+			assert.Equal(t, test.renames, result.Renames)
+
+			structs, err := schema.ConvertToStruct([]byte(unicodeSpec), schema.ConvertOptions{
+				GoFormat:      schema.GoFormatNone,
+				PackageName:   "testpkg",
+				GoPackagePath: "github.com/example/types",
+				NonASCIINames: test.mode,
+			})
+			require.NoError(t, err)
+			for _, expected := range test.golang {
+				assert.Contains(t, string(structs.Golang), expected)
+			}
+		})
+	}
+}
+
+func TestConvertNonASCIINamesError(t *testing.T) {
+	opts := schema.ConvertOptions{
+		PackageName:   "testpkg",
+		PackagePath:   "github.com/example/proto",
+		NonASCIINames: schema.NonASCIIError,
+	}
+
+	_, err := schema.Convert([]byte(unicodeSpec), opts)
+	require.ErrorContains(t, err, "schema 'Person': property 'prénom' name contains non-ASCII character 'é' (U+00E9)")
+
+	opts.GoPackagePath = "github.com/example/types"
+	_, err = schema.ConvertToStruct([]byte(unicodeSpec), opts)
+	require.ErrorContains(t, err, "property 'prénom' in schema 'Person': name contains non-ASCII character 'é' (U+00E9)")
+}
+
+func TestConvertNonASCIINamesInvalid(t *testing.T) {
+	_, err := schema.Convert([]byte(unicodeSpec), schema.ConvertOptions{
+		PackageName:   "testpkg",
+		PackagePath:   "github.com/example/proto",
+		NonASCIINames: "ascii",
+	})
+	require.ErrorContains(t, err, `unknown NonASCIINames "ascii": must be transliterate, escape or error`)
+}
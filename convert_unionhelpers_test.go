@@ -0,0 +1,149 @@
+package schema_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const unionHelpersSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+      discriminator:
+        propertyName: petType
+    Dog:
+      type: object
+      properties:
+        petType:
+          type: string
+        bark:
+          type: string
+    Cat:
+      type: object
+      properties:
+        petType:
+          type: string
+        meow:
+          type: string
+`
+
+func TestConvertToStructUnionHelpers(t *testing.T) {
+	result, err := schema.ConvertToStruct([]byte(unionHelpersSpec), schema.ConvertOptions{
+		GoPackagePath: "github.com/example/types",
+	})
+	require.NoError(t, err)
+
+	golang := string(result.Golang)
+	assert.Contains(t, golang, "func NewPetFromDog(v Dog) Pet {\n\treturn Pet{Dog: &v}\n}\n")
+	assert.Contains(t, golang, "func NewPetFromCat(v Cat) Pet {\n")
+	assert.Contains(t, golang, "func (u Pet) Value() (any, string) {\n")
+	assert.Contains(t, golang, "func (u Pet) IsDog() bool {\n\treturn u.Dog != nil\n}\n")
+	assert.Contains(t, golang, "func (u Pet) AsCat() (*Cat, bool) {\n\treturn u.Cat, u.Cat != nil\n}\n")
+}
+
+func TestConvertToStructUnionHelpersNameConflict(t *testing.T) {
+	given := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Setting:
+      oneOf:
+        - $ref: '#/components/schemas/Value'
+        - $ref: '#/components/schemas/Default'
+      discriminator:
+        propertyName: kind
+    Value:
+      type: object
+      properties:
+        kind:
+          type: string
+    Default:
+      type: object
+      properties:
+        kind:
+          type: string
+`
+	result, err := schema.ConvertToStruct([]byte(given), schema.ConvertOptions{
+		GoPackagePath: "github.com/example/types",
+	})
+	require.NoError(t, err)
+
+	golang := string(result.Golang)
+	assert.NotContains(t, golang, ") Value() (any, string)")
+	assert.Contains(t, golang, "func (u Setting) IsValue() bool {\n")
+	assert.Contains(t, golang, "func NewSettingFromValue(v Value) Setting {\n")
+}
+
+func TestConvertUnionHelpersRuntime(t *testing.T) {
+	result, err := schema.ConvertToStruct([]byte(unionHelpersSpec), schema.ConvertOptions{
+		GoPackagePath: "test/types",
+	})
+	require.NoError(t, err)
+
+	tmpDir := t.TempDir()
+	typesDir := filepath.Join(tmpDir, "types")
+	require.NoError(t, os.MkdirAll(typesDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(typesDir, "types.go"), result.Golang, 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module test\ngo 1.21\n"), 0644))
+
+	testProg := `package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"test/types"
+)
+
+func main() {
+	pet := types.NewPetFromDog(types.Dog{PetType: "dog", Bark: "woof"})
+	value, name := pet.Value()
+	fmt.Println(name, value.(*types.Dog).Bark, pet.IsDog(), pet.IsCat())
+
+	dog, ok := pet.AsDog()
+	fmt.Println(dog.Bark, ok)
+	_, ok = pet.AsCat()
+	fmt.Println(ok)
+
+	out, _ := json.Marshal(&pet)
+	fmt.Println(string(out))
+
+	// A constructor's result marshals by value, alone and in a slice
+	out, err := json.Marshal(types.NewPetFromCat(types.Cat{PetType: "cat", Meow: "purr"}))
+	fmt.Println(string(out), err)
+	var decoded types.Pet
+	err = json.Unmarshal(out, &decoded)
+	fmt.Println(decoded.Cat.Meow, decoded.IsDog(), err)
+	out, _ = json.Marshal([]types.Pet{pet})
+	fmt.Println(string(out))
+
+	value, name = types.Pet{}.Value()
+	fmt.Println(value == nil, name == "")
+}
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte(testProg), 0644))
+
+	cmd := exec.Command("go", "run", ".")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, "test program failed:\n%s", string(output))
+	assert.Equal(t, "Dog woof true false\nwoof true\nfalse\n"+
+		`{"petType":"dog","bark":"woof"}`+"\n"+
+		`{"petType":"cat","meow":"purr"} <nil>`+"\n"+
+		"purr false <nil>\n"+
+		`[{"petType":"dog","bark":"woof"}]`+"\n"+
+		"true true\n", string(output))
+}
@@ -0,0 +1,204 @@
+package schema_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const unionMarshalSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+        - $ref: '#/components/schemas/Fish'
+      discriminator:
+        propertyName: petType
+        mapping:
+          canine: '#/components/schemas/Dog'
+          puppy: '#/components/schemas/Dog'
+          feline: '#/components/schemas/Cat'
+          fish: '#/components/schemas/Fish'
+    Dog:
+      type: object
+      properties:
+        petType:
+          type: string
+        bark:
+          type: string
+    Cat:
+      type: object
+      properties:
+        meow:
+          type: string
+    Fish:
+      type: object
+`
+
+func TestConvertToStructUnionMarshalDiscriminator(t *testing.T) {
+	result, err := schema.ConvertToStruct([]byte(unionMarshalSpec), schema.ConvertOptions{
+		GoPackagePath: "github.com/example/types",
+	})
+	require.NoError(t, err)
+
+	golang := string(result.Golang)
+	assert.Contains(t, golang, "\t\tv := *u.Dog\n"+
+		"\t\tif d := strings.ToLower(v.PetType); d != \"canine\" && d != \"puppy\" {\n"+
+		"\t\t\tv.PetType = \"canine\"\n")
+	assert.Contains(t, golang, "\t\t\treturn append([]byte(\"{\\\"petType\\\":\\\"feline\\\",\"), data[1:]...), nil\n")
+	assert.Contains(t, golang, "\t\treturn []byte(\"{\\\"petType\\\":\\\"fish\\\"}\"), nil\n")
+}
+
+func TestConvertUnionMarshalDiscriminatorRuntime(t *testing.T) {
+	result, err := schema.ConvertToStruct([]byte(unionMarshalSpec), schema.ConvertOptions{
+		GoPackagePath: "test/types",
+	})
+	require.NoError(t, err)
+
+	tmpDir := t.TempDir()
+	typesDir := filepath.Join(tmpDir, "types")
+	require.NoError(t, os.MkdirAll(typesDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(typesDir, "types.go"), result.Golang, 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module test\ngo 1.21\n"), 0644))
+
+	testProg := `package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"test/types"
+)
+
+func roundTrip(pet types.Pet) {
+	out, err := json.Marshal(&pet)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	var back types.Pet
+	if err := json.Unmarshal(out, &back); err != nil {
+		fmt.Println(err)
+		return
+	}
+	_, name := back.Value()
+	fmt.Println(string(out), name)
+}
+
+func main() {
+	dog := types.Dog{Bark: "woof"}
+	roundTrip(types.NewPetFromDog(dog))
+	roundTrip(types.NewPetFromDog(types.Dog{PetType: "Puppy", Bark: "yip"}))
+	roundTrip(types.NewPetFromDog(types.Dog{PetType: "cat", Bark: "meow?"}))
+	roundTrip(types.NewPetFromCat(types.Cat{Meow: "purr"}))
+	roundTrip(types.NewPetFromFish(types.Fish{}))
+	fmt.Println(dog.PetType == "")
+}
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte(testProg), 0644))
+
+	cmd := exec.Command("go", "run", ".")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, "test program failed:\n%s", string(output))
+	assert.Equal(t, `{"petType":"canine","bark":"woof"} Dog
+{"petType":"Puppy","bark":"yip"} Dog
+{"petType":"canine","bark":"meow?"} Dog
+{"petType":"feline","meow":"purr"} Cat
+{"petType":"fish"} Fish
+true
+`, string(output))
+}
+
+func TestConvertUnionMarshalDiscriminatorInterfaceRuntime(t *testing.T) {
+	result, err := schema.ConvertToStruct([]byte(unionMarshalSpec), schema.ConvertOptions{
+		GoPackagePath: "test/types",
+		UnionStyle:    schema.UnionStyleInterface,
+	})
+	require.NoError(t, err)
+
+	tmpDir := t.TempDir()
+	typesDir := filepath.Join(tmpDir, "types")
+	require.NoError(t, os.MkdirAll(typesDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(typesDir, "types.go"), result.Golang, 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module test\ngo 1.21\n"), 0644))
+
+	testProg := `package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"test/types"
+)
+
+func roundTrip(pet types.Pet) {
+	out, err := json.Marshal(pet)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	back, err := types.DecodePet(out)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("%s %T\n", out, back)
+}
+
+func main() {
+	dog := &types.Dog{Bark: "woof"}
+	roundTrip(dog)
+	roundTrip(&types.Dog{PetType: "Puppy", Bark: "yip"})
+	roundTrip(&types.Dog{PetType: "cat", Bark: "meow?"})
+	roundTrip(&types.Cat{Meow: "purr"})
+	roundTrip(&types.Fish{})
+	fmt.Println(dog.PetType == "")
+}
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte(testProg), 0644))
+
+	cmd := exec.Command("go", "run", ".")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, "test program failed:\n%s", string(output))
+	assert.Equal(t, `{"petType":"canine","bark":"woof"} *types.Dog
+{"petType":"Puppy","bark":"yip"} *types.Dog
+{"petType":"canine","bark":"meow?"} *types.Dog
+{"petType":"feline","meow":"purr"} *types.Cat
+{"petType":"fish"} *types.Fish
+true
+`, string(output))
+}
+
+func TestConvertToStructUnionMarshalSharedInterfaceVariant(t *testing.T) {
+	given := unionMarshalSpec + `    Companion:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+      discriminator:
+        propertyName: kind
+        mapping:
+          dog: '#/components/schemas/Dog'
+          cat: '#/components/schemas/Cat'
+`
+	result, err := schema.ConvertToStruct([]byte(given), schema.ConvertOptions{
+		GoPackagePath: "github.com/example/types",
+		UnionStyle:    schema.UnionStyleInterface,
+	})
+	require.NoError(t, err)
+
+	// One MarshalJSON cannot write the discriminators of both unions
+	golang := string(result.Golang)
+	assert.NotContains(t, golang, "func (x Dog) MarshalJSON")
+	assert.NotContains(t, golang, "func (x Cat) MarshalJSON")
+	assert.Contains(t, golang, "func (x Fish) MarshalJSON() ([]byte, error) {\n")
+}
@@ -0,0 +1,203 @@
+package schema_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const unionStyleSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+      discriminator:
+        propertyName: petType
+        mapping:
+          dog: '#/components/schemas/Dog'
+          puppy: '#/components/schemas/Dog'
+          cat: '#/components/schemas/Cat'
+    Dog:
+      type: object
+      properties:
+        petType:
+          type: string
+        bark:
+          type: string
+    Cat:
+      type: object
+      properties:
+        petType:
+          type: string
+        meow:
+          type: string
+    Owner:
+      type: object
+      properties:
+        name:
+          type: string
+        pet:
+          $ref: '#/components/schemas/Pet'
+        pets:
+          type: array
+          items:
+            $ref: '#/components/schemas/Pet'
+`
+
+func TestConvertToStructUnionStyleInterface(t *testing.T) {
+	result, err := schema.ConvertToStruct([]byte(unionStyleSpec), schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
+		GoPackagePath: "github.com/example/types",
+		UnionStyle:    schema.UnionStyleInterface,
+	})
+	require.NoError(t, err)
+
+	golang := string(result.Golang)
+	assert.Contains(t, golang, "type Pet interface {\n\tisPet()\n}\n")
+	assert.Contains(t, golang, "func (*Dog) isPet() {}\nfunc (*Cat) isPet() {}\n")
+	assert.Contains(t, golang, "func DecodePet(data []byte) (Pet, error) {\n")
+	assert.Contains(t, golang, "\tcase \"dog\", \"puppy\":\n\t\tvar v Dog\n")
+	assert.Contains(t, golang, "\tPet Pet `json:\"pet\"`\n")
+	assert.Contains(t, golang, "\tPets []Pet `json:\"pets\"`\n")
+	assert.Contains(t, golang, "func (x *Owner) UnmarshalJSON(data []byte) error {\n")
+	assert.Contains(t, golang, "func (x Cat) MarshalJSON() ([]byte, error) {\n\ttype plain Cat\n")
+	assert.NotContains(t, golang, "func (u *Pet) MarshalJSON")
+	assert.NotContains(t, golang, "type Pet struct")
+}
+
+func TestConvertUnionStyleInterfaceRoundTrip(t *testing.T) {
+	result, err := schema.Convert([]byte(unionStyleSpec), schema.ConvertOptions{
+		PackageName:   "testpkg",
+		PackagePath:   "github.com/example/proto",
+		GoPackagePath: "test/types",
+		UnionStyle:    schema.UnionStyleInterface,
+	})
+	require.NoError(t, err)
+
+	tmpDir := t.TempDir()
+	typesDir := filepath.Join(tmpDir, "types")
+	require.NoError(t, os.MkdirAll(typesDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(typesDir, "types.go"), result.Golang, 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module test\ngo 1.21\n"), 0644))
+
+	testProg := `package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"test/types"
+)
+
+func describe(pet types.Pet) string {
+	switch p := pet.(type) {
+	case *types.Dog:
+		return "dog:" + p.Bark
+	case *types.Cat:
+		return "cat:" + p.Meow
+	case nil:
+		return "nil"
+	}
+	return "unknown"
+}
+
+func main() {
+	data := []byte(` + "`" + `{"name":"Ann","pet":{"petType":"Puppy","bark":"yip"},"pets":[{"petType":"cat","meow":"purr"},null]}` + "`" + `)
+	var owner types.Owner
+	if err := json.Unmarshal(data, &owner); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Println(owner.Name, describe(owner.Pet), describe(owner.Pets[0]), describe(owner.Pets[1]))
+
+	out, err := json.Marshal(&owner)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Println(string(out))
+
+	if _, err := types.DecodePet([]byte(` + "`" + `{"petType":"fish"}` + "`" + `)); err != nil {
+		fmt.Println(err)
+	}
+
+	// Variants write their discriminator, so the output decodes back
+	out, err = json.Marshal(types.Owner{Pet: &types.Cat{Meow: "x"}, Pets: []types.Pet{&types.Dog{Bark: "woof"}}})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Println(string(out))
+	var decoded types.Owner
+	if err := json.Unmarshal(out, &decoded); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Println(describe(decoded.Pet), describe(decoded.Pets[0]))
+}
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte(testProg), 0644))
+
+	cmd := exec.Command("go", "run", ".")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, "test program failed:\n%s", string(output))
+	assert.Equal(t, "Ann dog:yip cat:purr nil\n"+
+		`{"name":"Ann","pet":{"petType":"Puppy","bark":"yip"},"pets":[{"petType":"cat","meow":"purr"},null]}`+"\n"+
+		"unknown petType: fish\n"+
+		`{"name":"","pet":{"petType":"cat","meow":"x"},"pets":[{"petType":"dog","bark":"woof"}]}`+"\n"+
+		"cat:x dog:woof\n", string(output))
+}
+
+func TestConvertUnionStyleErrors(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		openapi string
+		opts    schema.ConvertOptions
+		wantErr string
+	}{
+		{
+			name:    "unknown style",
+			openapi: unionStyleSpec,
+			opts:    schema.ConvertOptions{UnionStyle: "pointer"},
+			wantErr: `unknown UnionStyle "pointer": must be struct or interface`,
+		},
+		{
+			name: "nested arrays",
+			openapi: unionStyleSpec + `    Shelter:
+      type: object
+      properties:
+        kennels:
+          type: array
+          items:
+            type: array
+            items:
+              $ref: '#/components/schemas/Pet'
+`,
+			opts:    schema.ConvertOptions{UnionStyle: schema.UnionStyleInterface},
+			wantErr: "property 'kennels' in schema 'Shelter': nested arrays of union types are not supported with the interface union style",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			opts := test.opts
+			opts.GoPackagePath = "github.com/example/types"
+			_, err := schema.ConvertToStruct([]byte(test.openapi), opts)
+			require.ErrorContains(t, err, test.wantErr)
+
+			opts.PackageName = "testpkg"
+			opts.PackagePath = "github.com/example/proto"
+			_, err = schema.Convert([]byte(test.openapi), opts)
+			require.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
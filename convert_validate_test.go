@@ -449,3 +449,293 @@ components:
 	assert.False(t, stringResult.Valid)
 	assert.NotEmpty(t, stringResult.Issues)
 }
+
+func TestValidateExamplesOperationExamples(t *testing.T) {
+	openapi := `
+openapi: 3.1.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /pets/{petId}:
+    parameters:
+      - name: petId
+        in: path
+        required: true
+        schema:
+          type: integer
+        example: abc
+    put:
+      parameters:
+        - name: filter
+          in: query
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [color]
+              example:
+                color: brown
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+            examples:
+              good:
+                value:
+                  name: Rex
+              shared:
+                $ref: '#/components/examples/BadPet'
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+              example:
+                name: 42
+        '204':
+          description: No content
+components:
+  examples:
+    BadPet:
+      value:
+        age: 3
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+`
+
+	result, err := schema.ValidateExamples([]byte(openapi), schema.ValidateOptions{IncludeAll: true})
+	require.NoError(t, err)
+	require.Len(t, result.Examples, 4)
+
+	param := result.Examples["/paths/~1pets~1{petId}/parameters/0"]
+	require.NotNil(t, param)
+	assert.False(t, param.Valid)
+	assert.Equal(t, "/paths/~1pets~1{petId}/parameters/0/schema", param.SchemaPath)
+	require.Len(t, param.Issues, 1)
+	assert.Equal(t, "example", param.Issues[0].ExampleField)
+	assert.Equal(t, "/paths/~1pets~1{petId}/parameters/0/example", param.Issues[0].Path)
+
+	content := result.Examples["/paths/~1pets~1{petId}/put/parameters/0/content/application~1json"]
+	require.NotNil(t, content)
+	assert.True(t, content.Valid)
+	assert.Empty(t, content.Issues)
+
+	body := result.Examples["/paths/~1pets~1{petId}/put/requestBody/content/application~1json"]
+	require.NotNil(t, body)
+	assert.False(t, body.Valid)
+	require.Len(t, body.Issues, 1)
+	assert.Equal(t, "examples.shared", body.Issues[0].ExampleField)
+	assert.Equal(t, "/components/examples/BadPet/value", body.Issues[0].Path)
+	assert.Greater(t, body.Issues[0].Line, 0)
+
+	resp := result.Examples["/paths/~1pets~1{petId}/put/responses/200/content/application~1json"]
+	require.NotNil(t, resp)
+	assert.False(t, resp.Valid)
+	require.NotEmpty(t, resp.Issues)
+	assert.Equal(t, "/paths/~1pets~1{petId}/put/responses/200/content/application~1json/example/name", resp.Issues[0].Path)
+}
+
+func TestValidateExamplesOperationExamplesNeedIncludeAll(t *testing.T) {
+	openapi := `
+openapi: 3.1.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /pets:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+            example:
+              name: 42
+      responses:
+        '204':
+          description: No content
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+      example:
+        name: 42
+`
+
+	result, err := schema.ValidateExamples([]byte(openapi), schema.ValidateOptions{SchemaNames: []string{"Pet"}})
+	require.NoError(t, err)
+	assert.Empty(t, result.Examples)
+	require.Contains(t, result.Schemas, "Pet")
+	require.NotEmpty(t, result.Schemas["Pet"].Issues)
+	assert.Equal(t, "/components/schemas/Pet/example/name", result.Schemas["Pet"].Issues[0].Path)
+}
+
+func TestValidateExamplesSeverityConfiguration(t *testing.T) {
+	openapi := `
+openapi: 3.1.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    User:
+      type: object
+      additionalProperties: false
+      properties:
+        age:
+          type: integer
+      example:
+        age: old
+        nickname: Ada
+`
+
+	for _, test := range []struct {
+		name       string
+		opts       schema.ValidateOptions
+		valid      bool
+		severities map[string]schema.IssueSeverity
+	}{
+		{
+			name:  "defaults",
+			opts:  schema.ValidateOptions{IncludeAll: true},
+			valid: false,
+			severities: map[string]schema.IssueSeverity{
+				"additionalProperty": schema.IssueSeverityError,
+				"type-mismatch":      schema.IssueSeverityError,
+			},
+		},
+		{
+			name: "treat as warning",
+			opts: schema.ValidateOptions{
+				IncludeAll:     true,
+				TreatAsWarning: []string{"additionalProperty", "type-mismatch"},
+			},
+			valid: true,
+			severities: map[string]schema.IssueSeverity{
+				"additionalProperty": schema.IssueSeverityWarning,
+				"type-mismatch":      schema.IssueSeverityWarning,
+			},
+		},
+		{
+			name: "one class as warning",
+			opts: schema.ValidateOptions{
+				IncludeAll:     true,
+				TreatAsWarning: []string{"additionalProperty"},
+				TreatAsError:   []string{"type-mismatch"},
+			},
+			valid: false,
+			severities: map[string]schema.IssueSeverity{
+				"additionalProperty": schema.IssueSeverityWarning,
+				"type-mismatch":      schema.IssueSeverityError,
+			},
+		},
+		{
+			name: "warning threshold",
+			opts: schema.ValidateOptions{
+				IncludeAll:     true,
+				TreatAsWarning: []string{"additionalProperty", "type-mismatch"},
+				Threshold:      schema.IssueSeverityWarning,
+			},
+			valid: false,
+			severities: map[string]schema.IssueSeverity{
+				"additionalProperty": schema.IssueSeverityWarning,
+				"type-mismatch":      schema.IssueSeverityWarning,
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := schema.ValidateExamples([]byte(openapi), test.opts)
+			require.NoError(t, err)
+
+			user := result.Schemas["User"]
+			require.NotNil(t, user)
+			assert.Equal(t, test.valid, user.Valid)
+
+			severities := map[string]schema.IssueSeverity{}
+			for _, issue := range user.Issues {
+				severities[issue.Class] = issue.Severity
+			}
+			assert.Equal(t, test.severities, severities)
+		})
+	}
+}
+
+func TestValidateExamplesSeverityThresholdIgnoresVersionNotice(t *testing.T) {
+	openapi := `
+openapi: 3.0.3
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        name:
+          type: string
+      example:
+        name: Ada
+`
+
+	result, err := schema.ValidateExamples([]byte(openapi), schema.ValidateOptions{
+		IncludeAll: true,
+		Threshold:  schema.IssueSeverityWarning,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Schemas["User"].Issues, 1)
+	assert.Equal(t, "openapi-version", result.Schemas["User"].Issues[0].Class)
+	assert.True(t, result.Schemas["User"].Valid)
+}
+
+func TestValidateExamplesSeverityConfigurationErrors(t *testing.T) {
+	openapi := []byte(`
+openapi: 3.1.0
+info:
+  title: Test API
+  version: 1.0.0
+`)
+
+	for _, test := range []struct {
+		name     string
+		opts     schema.ValidateOptions
+		expected string
+	}{
+		{
+			name:     "unknown class",
+			opts:     schema.ValidateOptions{IncludeAll: true, TreatAsWarning: []string{"typo"}},
+			expected: `unknown issue class "typo" in TreatAsWarning: see IssueClasses`,
+		},
+		{
+			name: "class in both lists",
+			opts: schema.ValidateOptions{
+				IncludeAll:     true,
+				TreatAsWarning: []string{"enum"},
+				TreatAsError:   []string{"enum"},
+			},
+			expected: `issue class "enum" is in both TreatAsWarning and TreatAsError`,
+		},
+		{
+			name:     "unknown threshold",
+			opts:     schema.ValidateOptions{IncludeAll: true, Threshold: "info"},
+			expected: `unknown Threshold "info": must be error or warning`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := schema.ValidateExamples(openapi, test.opts)
+			require.ErrorContains(t, err, test.expected)
+		})
+	}
+	assert.Contains(t, schema.IssueClasses(), "additionalProperty")
+}
@@ -0,0 +1,143 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertWarnings(t *testing.T) {
+	given := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Status:
+      type: string
+      enum: [active, inactive]
+    Account:
+      type: object
+      properties:
+        id:
+          type: string
+          format: uuid
+        code:
+          type: string
+          pattern: '^[A-Z]{3}$'
+          maxLength: 3
+        balance:
+          type: string
+          format: decimal
+        rate:
+          type: number
+          format: decimal128
+        kind:
+          type: string
+          enum: [personal, business]
+        tags:
+          type: array
+          minItems: 1
+          items:
+            type: string
+            minLength: 1
+        status:
+          $ref: '#/components/schemas/Status'
+`
+	result, err := schema.Convert([]byte(given), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []schema.Warning{
+		{
+			Schema:  "Status",
+			Pointer: "/components/schemas/Status",
+			Code:    schema.WarningEnumFlattened,
+			Message: "string enum flattened to string",
+		},
+		{
+			Schema:  "Account",
+			Pointer: "/components/schemas/Account/properties/code",
+			Code:    schema.WarningConstraintDropped,
+			Message: "maxLength, pattern dropped in proto",
+		},
+		{
+			Schema:  "Account",
+			Pointer: "/components/schemas/Account/properties/balance",
+			Code:    schema.WarningFormatUnknown,
+			Message: "format 'decimal' unknown → string",
+		},
+		{
+			Schema:  "Account",
+			Pointer: "/components/schemas/Account/properties/rate",
+			Code:    schema.WarningFormatUnknown,
+			Message: "format 'decimal128' unknown → double",
+		},
+		{
+			Schema:  "Account",
+			Pointer: "/components/schemas/Account/properties/kind",
+			Code:    schema.WarningEnumFlattened,
+			Message: "string enum flattened to string",
+		},
+		{
+			Schema:  "Account",
+			Pointer: "/components/schemas/Account/properties/tags",
+			Code:    schema.WarningConstraintDropped,
+			Message: "minItems dropped in proto",
+		},
+		{
+			Schema:  "Account",
+			Pointer: "/components/schemas/Account/properties/tags/items",
+			Code:    schema.WarningConstraintDropped,
+			Message: "minLength dropped in proto",
+		},
+	}, result.Warnings)
+}
+
+func TestConvertWarningsSkipGoTypes(t *testing.T) {
+	given := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+      discriminator:
+        propertyName: petType
+    Cat:
+      type: object
+      properties:
+        petType:
+          type: string
+    Dog:
+      type: object
+      properties:
+        petType:
+          type: string
+        name:
+          type: string
+          pattern: '^[a-z]+$'
+`
+	result, err := schema.Convert([]byte(given), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result.Warnings)
+
+	result, err = schema.Convert([]byte(given), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+		ProtoShims:  true,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Warnings, 1)
+	assert.Equal(t, "/components/schemas/Dog/properties/name", result.Warnings[0].Pointer)
+}
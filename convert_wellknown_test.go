@@ -0,0 +1,187 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const wellKnownSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Job:
+      type: object
+      properties:
+        timeout:
+          type: string
+          format: duration
+        metadata:
+          type: object
+        labels:
+          type: object
+          additionalProperties: true
+        payload:
+          anyOf:
+            - $ref: '#/components/schemas/Email'
+            - $ref: '#/components/schemas/Sms'
+    Email:
+      type: object
+      properties:
+        to:
+          type: string
+    Sms:
+      type: object
+      properties:
+        number:
+          type: string
+`
+
+func TestConvertWellKnownTypes(t *testing.T) {
+	result, err := schema.Convert([]byte(wellKnownSpec), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+		WellKnownTypes: schema.WellKnownTypes{
+			Duration: true,
+			Struct:   true,
+			Any:      true,
+		},
+	})
+	require.NoError(t, err)
+
+	expected := `syntax = "proto3";
+
+package testpkg;
+
+import "google/protobuf/duration.proto";
+import "google/protobuf/struct.proto";
+import "google/protobuf/any.proto";
+
+option go_package = "github.com/example/proto/v1";
+
+message Job {
+  google.protobuf.Duration timeout = 1 [json_name = "timeout"];
+  google.protobuf.Struct metadata = 2 [json_name = "metadata"];
+  google.protobuf.Struct labels = 3 [json_name = "labels"];
+  google.protobuf.Any payload = 4 [json_name = "payload"];
+}
+
+message Email {
+  string to = 1 [json_name = "to"];
+}
+
+message Sms {
+  string number = 1 [json_name = "number"];
+}
+
+`
+	assert.Equal(t, expected, string(result.Protobuf))
+}
+
+func TestConvertWellKnownTypesDisabled(t *testing.T) {
+	_, err := schema.Convert([]byte(wellKnownSpec), schema.ConvertOptions{
+		PackageName:   "testpkg",
+		PackagePath:   "github.com/example/proto/v1",
+		CollectErrors: true,
+	})
+	require.ErrorContains(t, err, "property 'payload' uses 'anyOf' which is not supported")
+
+	result, err := schema.Convert([]byte(wellKnownSpec), schema.ConvertOptions{
+		PackageName:    "testpkg",
+		PackagePath:    "github.com/example/proto/v1",
+		WellKnownTypes: schema.WellKnownTypes{Struct: true, Any: true},
+	})
+	require.NoError(t, err)
+
+	proto := string(result.Protobuf)
+	assert.Contains(t, proto, "string timeout = 1")
+	assert.NotContains(t, proto, "google/protobuf/duration.proto")
+}
+
+func TestConvertToStructWellKnownTypes(t *testing.T) {
+	result, err := schema.ConvertToStruct([]byte(wellKnownSpec), schema.ConvertOptions{
+		GoFormat:       schema.GoFormatNone,
+		GoPackagePath:  "github.com/example/types/v1",
+		WellKnownTypes: schema.WellKnownTypes{Struct: true, Any: true},
+	})
+	require.NoError(t, err)
+
+	golang := string(result.Golang)
+	assert.Contains(t, golang, "Metadata map[string]any `json:\"metadata\"`")
+	assert.Contains(t, golang, "Labels map[string]any `json:\"labels\"`")
+	assert.Contains(t, golang, "Payload json.RawMessage `json:\"payload\"`")
+}
+
+func TestConvertFreeFormObjects(t *testing.T) {
+	openapi := []byte(`openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Attributes:
+      type: object
+      additionalProperties: true
+    Event:
+      type: object
+      properties:
+        attributes:
+          $ref: '#/components/schemas/Attributes'
+        context:
+          type: object
+          additionalProperties: {}
+        source:
+          type: object
+          additionalProperties:
+            type: string
+`)
+
+	result, err := schema.Convert(openapi, schema.ConvertOptions{
+		GoFormat:       schema.GoFormatNone,
+		PackageName:    "testpkg",
+		PackagePath:    "github.com/example/proto/v1",
+		WellKnownTypes: schema.WellKnownTypes{Struct: true},
+	})
+	require.NoError(t, err)
+
+	proto := string(result.Protobuf)
+	assert.Contains(t, proto, "google.protobuf.Struct attributes = 1")
+	assert.Contains(t, proto, "google.protobuf.Struct context = 2")
+	// Typed additionalProperties is a map, not a free-form object
+	assert.Contains(t, proto, "Source source = 3")
+
+	for _, test := range []struct {
+		name     string
+		goType   schema.FreeFormGoType
+		expected string
+	}{
+		{name: "default", goType: "", expected: "map[string]any"},
+		{name: "map", goType: schema.FreeFormMap, expected: "map[string]any"},
+		{name: "raw message", goType: schema.FreeFormRawMessage, expected: "json.RawMessage"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := schema.ConvertToStruct(openapi, schema.ConvertOptions{
+				GoFormat:       schema.GoFormatNone,
+				GoPackagePath:  "github.com/example/types/v1",
+				WellKnownTypes: schema.WellKnownTypes{Struct: true},
+				FreeFormGoType: test.goType,
+			})
+			require.NoError(t, err)
+
+			golang := string(result.Golang)
+			assert.Contains(t, golang, "Attributes "+test.expected+" `json:\"attributes\"`")
+			assert.Contains(t, golang, "Context "+test.expected+" `json:\"context\"`")
+		})
+	}
+
+	_, err = schema.ConvertToStruct(openapi, schema.ConvertOptions{
+		GoFormat:       schema.GoFormatNone,
+		GoPackagePath:  "github.com/example/types/v1",
+		FreeFormGoType: "interface",
+	})
+	require.ErrorContains(t, err, `unknown FreeFormGoType "interface"`)
+}
@@ -0,0 +1,143 @@
+package schema
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/duh-rpc/openapi-schema.go/internal/parser"
+	"github.com/duh-rpc/openapi-schema.go/internal/proto"
+)
+
+// DependencyAnalysis is the schema dependency graph Convert uses to decide where
+// each type is generated, in a form that can be serialized or rendered with DOT.
+type DependencyAnalysis struct {
+	// Nodes holds one entry per component schema, in document order.
+	Nodes []DependencyNode `json:"nodes"`
+	// Edges holds the references between schemas, grouped by the referencing
+	// schema in node order.
+	Edges []DependencyEdge `json:"edges"`
+}
+
+// DependencyNode is a component schema and where Convert generates it.
+type DependencyNode struct {
+	Name     string       `json:"name"`
+	Location TypeLocation `json:"location"`
+	// Reason explains a golang Location, as in TypeInfo.
+	Reason string `json:"reason,omitempty"`
+	// Variants lists the oneOf variants of a union; empty for other schemas.
+	Variants []string `json:"variants,omitempty"`
+}
+
+// DependencyEdgeKind distinguishes how one schema depends on another.
+type DependencyEdgeKind string
+
+const (
+	// DependencyReference is a $ref from a property or array items.
+	DependencyReference DependencyEdgeKind = "reference"
+	// DependencyVariant links a union to one of its oneOf variants.
+	DependencyVariant DependencyEdgeKind = "variant"
+)
+
+// DependencyEdge records that From depends on To.
+type DependencyEdge struct {
+	From string             `json:"from"`
+	To   string             `json:"to"`
+	Kind DependencyEdgeKind `json:"kind"`
+}
+
+// AnalyzeDependencies builds the dependency graph of the component schemas in
+// openapi, including the transitive closure that places unions and everything
+// referencing them in Go. Schemas Convert would reject still appear, so the
+// graph of a spec can be inspected before it converts; use Lint to find those
+// problems.
+func AnalyzeDependencies(openapi []byte) (_ *DependencyAnalysis, err error) {
+	defer internal.Recover("AnalyzeDependencies", &err)
+
+	if len(openapi) == 0 {
+		return nil, fmt.Errorf("openapi input cannot be empty")
+	}
+
+	doc, err := parser.ParseDocument(openapi)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas, err := doc.Schemas()
+	if err != nil {
+		return nil, err
+	}
+	return analyzeDependencies(schemas)
+}
+
+// analyzeDependencies builds the dependency graph of schemas, as
+// AnalyzeDependencies describes
+func analyzeDependencies(schemas []*parser.SchemaEntry) (*DependencyAnalysis, error) {
+	protoCtx := proto.NewContext()
+	protoCtx.CollectErrors = true
+	graph, err := proto.BuildMessages(schemas, protoCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	goTypes, _, reasons := graph.ComputeTransitiveClosure()
+
+	result := &DependencyAnalysis{Nodes: []DependencyNode{}, Edges: []DependencyEdge{}}
+	for _, entry := range schemas {
+		node := DependencyNode{
+			Name:     entry.Name,
+			Location: TypeLocationProto,
+			Variants: graph.UnionVariants(entry.Name),
+		}
+		if goTypes[entry.Name] {
+			node.Location = TypeLocationGolang
+			node.Reason = reasons[entry.Name]
+		}
+		result.Nodes = append(result.Nodes, node)
+
+		for _, to := range graph.References(entry.Name) {
+			result.Edges = append(result.Edges, DependencyEdge{From: entry.Name, To: to, Kind: DependencyReference})
+		}
+		for _, to := range node.Variants {
+			result.Edges = append(result.Edges, DependencyEdge{From: entry.Name, To: to, Kind: DependencyVariant})
+		}
+	}
+
+	return result, nil
+}
+
+// DOT renders the graph in Graphviz DOT format. Schemas generated as Go are
+// filled, unions are drawn as diamonds and variant edges are dashed.
+func (a *DependencyAnalysis) DOT() string {
+	var result strings.Builder
+	result.WriteString("digraph schemas {\n")
+	result.WriteString("  rankdir=LR;\n")
+	result.WriteString("  node [shape=box];\n")
+
+	for _, node := range a.Nodes {
+		var attrs []string
+		if len(node.Variants) > 0 {
+			attrs = append(attrs, "shape=diamond")
+		}
+		if node.Location == TypeLocationGolang {
+			attrs = append(attrs, "style=filled", `fillcolor="#fde68a"`)
+		}
+		result.WriteString("  " + strconv.Quote(node.Name))
+		if len(attrs) > 0 {
+			result.WriteString(" [" + strings.Join(attrs, ", ") + "]")
+		}
+		result.WriteString(";\n")
+	}
+
+	for _, edge := range a.Edges {
+		result.WriteString(fmt.Sprintf("  %s -> %s", strconv.Quote(edge.From), strconv.Quote(edge.To)))
+		if edge.Kind == DependencyVariant {
+			result.WriteString(" [style=dashed]")
+		}
+		result.WriteString(";\n")
+	}
+
+	result.WriteString("}\n")
+	return result.String()
+}
@@ -0,0 +1,116 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const dependenciesSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Owner:
+      type: object
+      properties:
+        pet:
+          $ref: '#/components/schemas/Pet'
+        homes:
+          type: array
+          items:
+            $ref: '#/components/schemas/Address'
+    Address:
+      type: object
+      properties:
+        street:
+          type: string
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+      discriminator:
+        propertyName: petType
+    Dog:
+      type: object
+      properties:
+        petType:
+          type: string
+    Cat:
+      type: object
+      properties:
+        petType:
+          type: string
+`
+
+func TestAnalyzeDependencies(t *testing.T) {
+	result, err := schema.AnalyzeDependencies([]byte(dependenciesSpec))
+	require.NoError(t, err)
+
+	assert.Equal(t, []schema.DependencyNode{
+		{Name: "Owner", Location: schema.TypeLocationGolang, Reason: "references union type Pet"},
+		{Name: "Address", Location: schema.TypeLocationProto},
+		{Name: "Pet", Location: schema.TypeLocationGolang, Reason: "contains oneOf", Variants: []string{"Dog", "Cat"}},
+		{Name: "Dog", Location: schema.TypeLocationGolang, Reason: "variant of union type Pet"},
+		{Name: "Cat", Location: schema.TypeLocationGolang, Reason: "variant of union type Pet"},
+	}, result.Nodes)
+	assert.Equal(t, []schema.DependencyEdge{
+		{From: "Owner", To: "Pet", Kind: schema.DependencyReference},
+		{From: "Owner", To: "Address", Kind: schema.DependencyReference},
+		{From: "Pet", To: "Dog", Kind: schema.DependencyVariant},
+		{From: "Pet", To: "Cat", Kind: schema.DependencyVariant},
+	}, result.Edges)
+
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `{"from":"Pet","to":"Dog","kind":"variant"}`)
+}
+
+func TestAnalyzeDependenciesDOT(t *testing.T) {
+	result, err := schema.AnalyzeDependencies([]byte(dependenciesSpec))
+	require.NoError(t, err)
+
+	assert.Equal(t, `digraph schemas {
+  rankdir=LR;
+  node [shape=box];
+  "Owner" [style=filled, fillcolor="#fde68a"];
+  "Address";
+  "Pet" [shape=diamond, style=filled, fillcolor="#fde68a"];
+  "Dog" [style=filled, fillcolor="#fde68a"];
+  "Cat" [style=filled, fillcolor="#fde68a"];
+  "Owner" -> "Pet";
+  "Owner" -> "Address";
+  "Pet" -> "Dog" [style=dashed];
+  "Pet" -> "Cat" [style=dashed];
+}
+`, result.DOT())
+}
+
+func TestAnalyzeDependenciesInvalidSchema(t *testing.T) {
+	given := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        items:
+          type: array
+          items:
+            $ref: '#/components/schemas/Item'
+    Item:
+      type: object
+      allOf:
+        - $ref: '#/components/schemas/Order'
+`
+	result, err := schema.AnalyzeDependencies([]byte(given))
+	require.NoError(t, err)
+	assert.Len(t, result.Nodes, 2)
+	assert.Equal(t, []schema.DependencyEdge{{From: "Order", To: "Item", Kind: schema.DependencyReference}}, result.Edges)
+}
@@ -0,0 +1,122 @@
+package schema
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/duh-rpc/openapi-schema.go/internal/parser"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// Deprecation describes a schema or property marked `deprecated: true` or given
+// an `x-sunset` removal date, for governance tooling that tracks removals.
+type Deprecation struct {
+	Schema string `json:"schema"`
+	// Property is the dotted path to the deprecated property within Schema
+	// (e.g. "address.street", with "[]" marking array items); empty when the
+	// whole schema is deprecated.
+	Property string `json:"property,omitempty"`
+	Pointer  string `json:"pointer"`
+	// Deprecated reports whether the node sets `deprecated: true`. An x-sunset
+	// date without it is still reported, since a removal date implies deprecation.
+	Deprecated bool `json:"deprecated"`
+	// Sunset is the x-sunset removal date; zero when none is given. It is written
+	// as a date (2026-01-31) or an RFC 3339 timestamp.
+	Sunset time.Time `json:"sunset,omitzero"`
+	// Location is where the schema is generated. Empty for schemas absent from
+	// the TypeMap, such as string enums.
+	Location TypeLocation `json:"location,omitempty"`
+}
+
+// collectDeprecations walks every schema and its inline properties and array
+// items, in document order. Referenced schemas report their own deprecation.
+func collectDeprecations(schemas []*parser.SchemaEntry, typeMap map[string]*TypeInfo) ([]Deprecation, error) {
+	var result []Deprecation
+	for _, entry := range schemas {
+		s := entry.Proxy.Schema()
+		if s == nil {
+			continue
+		}
+
+		var location TypeLocation
+		if info, ok := typeMap[entry.Name]; ok {
+			location = info.Location
+		}
+
+		walk := deprecationWalker{schema: entry.Name, location: location, result: &result}
+		if err := walk.visit(s, "", []string{"components", "schemas", entry.Name}); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// deprecationWalker collects the deprecations of one component schema
+type deprecationWalker struct {
+	schema   string
+	location TypeLocation
+	result   *[]Deprecation
+}
+
+func (w deprecationWalker) visit(s *base.Schema, property string, pointer []string) error {
+	deprecated := s.Deprecated != nil && *s.Deprecated
+	sunset, err := sunsetOf(s)
+	if err != nil {
+		return fmt.Errorf("schema '%s': %s: %w", w.schema, internal.JSONPointer(pointer...), err)
+	}
+
+	if deprecated || !sunset.IsZero() {
+		*w.result = append(*w.result, Deprecation{
+			Schema:     w.schema,
+			Property:   property,
+			Pointer:    internal.JSONPointer(pointer...),
+			Deprecated: deprecated,
+			Sunset:     sunset,
+			Location:   w.location,
+		})
+	}
+
+	if s.Properties != nil {
+		for name, proxy := range s.Properties.FromOldest() {
+			child := proxy.Schema()
+			if proxy.IsReference() || child == nil {
+				continue
+			}
+			path := name
+			if property != "" {
+				path = property + "." + name
+			}
+			if err := w.visit(child, path, append(pointer[:len(pointer):len(pointer)], "properties", name)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if s.Items != nil && s.Items.IsA() && s.Items.A != nil && !s.Items.A.IsReference() {
+		if child := s.Items.A.Schema(); child != nil {
+			return w.visit(child, property+"[]", append(pointer[:len(pointer):len(pointer)], "items"))
+		}
+	}
+
+	return nil
+}
+
+// sunsetOf parses the x-sunset extension of s; zero when absent
+func sunsetOf(s *base.Schema) (time.Time, error) {
+	if s.Extensions == nil {
+		return time.Time{}, nil
+	}
+
+	node, found := s.Extensions.Get("x-sunset")
+	if !found || node == nil {
+		return time.Time{}, nil
+	}
+
+	for _, layout := range []string{time.DateOnly, time.RFC3339} {
+		if t, err := time.Parse(layout, node.Value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("x-sunset must be a date (YYYY-MM-DD) or RFC 3339 timestamp, got: %s", node.Value)
+}
@@ -0,0 +1,68 @@
+package schema
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+)
+
+// convertVerified converts openapi twice, the second time without Previous so
+// a reused result is checked against a fresh conversion, and fails when the
+// outputs differ
+func convertVerified(ctx context.Context, openapi []byte, opts ConvertOptions) (*ConvertResult, error) {
+	opts.VerifyDeterminism = false
+	result, err := ConvertContext(ctx, openapi, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.Previous = nil
+	again, err := ConvertContext(ctx, openapi, opts)
+	if err != nil {
+		return nil, fmt.Errorf("output is not deterministic: second conversion failed: %w", err)
+	}
+
+	if err := compareOutput("protobuf", result.Protobuf, again.Protobuf); err != nil {
+		return nil, err
+	}
+	if err := compareOutput("golang", result.Golang, again.Golang); err != nil {
+		return nil, err
+	}
+	if err := compareOutput("handlers", result.Handlers, again.Handlers); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// convertToStructVerified is convertVerified for ConvertToStruct
+func convertToStructVerified(ctx context.Context, openapi []byte, opts ConvertOptions) (*StructResult, error) {
+	opts.VerifyDeterminism = false
+	result, err := ConvertToStructContext(ctx, openapi, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	again, err := ConvertToStructContext(ctx, openapi, opts)
+	if err != nil {
+		return nil, fmt.Errorf("output is not deterministic: second conversion failed: %w", err)
+	}
+
+	if err := compareOutput("golang", result.Golang, again.Golang); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// compareOutput fails when two conversions generated different output, naming
+// the first line that differs
+func compareOutput(output string, first, second []byte) error {
+	if bytes.Equal(first, second) {
+		return nil
+	}
+	a, b := bytes.Split(first, []byte("\n")), bytes.Split(second, []byte("\n"))
+	line := 0
+	for line < len(a) && line < len(b) && bytes.Equal(a[line], b[line]) {
+		line++
+	}
+	return fmt.Errorf("output is not deterministic: %s output differs between two conversions at line %d", output, line+1)
+}
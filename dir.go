@@ -0,0 +1,181 @@
+package schema
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+)
+
+// DirResult holds the outcome of converting every spec matched by ConvertDir.
+type DirResult struct {
+	// Files holds one entry per matched spec, sorted by path.
+	Files []*FileResult
+	// Converted and Failed count the files that did and did not convert.
+	Converted int
+	Failed    int
+	// Conflicts maps each generated type name produced by more than one file to
+	// those files, in path order. With shared options every file targets the same
+	// package, so these types would collide when compiled together.
+	Conflicts map[string][]string
+}
+
+// FileResult is the conversion outcome for one spec file.
+type FileResult struct {
+	Path   string
+	Result *ConvertResult // nil when Err is set
+	Err    error
+}
+
+// Err combines the errors of every failed file, each prefixed with its path, or
+// returns nil when all files converted.
+func (r *DirResult) Err() error {
+	var errs []error
+	for _, f := range r.Files {
+		if f.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", f.Path, f.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ConvertDir converts every spec in fsys matching glob with the same options,
+// the way monorepos keep one spec per service. Files are converted concurrently
+// (opts.Concurrency files at a time) and share opts, including TypeMappings.
+//
+// glob uses path.Match syntax, extended so a "**" segment matches any number of
+// directories (e.g. "services/**/openapi.yaml"). A file that fails to convert is
+// recorded in its FileResult and does not stop the others; an error is returned
+// only for an invalid glob, an unreadable directory or file, or when no file
+// matches.
+func ConvertDir(fsys fs.FS, glob string, opts ConvertOptions) (*DirResult, error) {
+	return ConvertDirContext(context.Background(), fsys, glob, opts)
+}
+
+// ConvertDirContext is like ConvertDir but stops early with an error wrapping
+// ctx.Err() when ctx is cancelled or its deadline expires.
+func ConvertDirContext(ctx context.Context, fsys fs.FS, glob string, opts ConvertOptions) (_ *DirResult, err error) {
+	defer internal.Recover("ConvertDir", &err)
+
+	if opts.Previous != nil {
+		return nil, fmt.Errorf("Previous is not supported by ConvertDir: each file has its own result")
+	}
+
+	paths, err := globFS(fsys, glob)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no spec files match '%s'", glob)
+	}
+
+	files := make([]*FileResult, len(paths))
+	err = internal.ParallelEach(opts.Concurrency, len(paths), func(i int) error {
+		if err := internal.Cancelled(ctx); err != nil {
+			return err
+		}
+
+		openapi, err := fs.ReadFile(fsys, paths[i])
+		if err != nil {
+			return err
+		}
+
+		result, err := ConvertContext(ctx, openapi, opts)
+		if ctxErr := internal.Cancelled(ctx); ctxErr != nil {
+			return ctxErr
+		}
+		files[i] = &FileResult{Path: paths[i], Result: result, Err: err}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DirResult{Files: files, Conflicts: map[string][]string{}}
+	definedBy := make(map[string][]string)
+	for _, f := range files {
+		if f.Err != nil {
+			result.Failed++
+			continue
+		}
+		result.Converted++
+		for name := range f.Result.TypeMap {
+			definedBy[name] = append(definedBy[name], f.Path)
+		}
+	}
+	for name, files := range definedBy {
+		if len(files) > 1 {
+			result.Conflicts[name] = files
+		}
+	}
+
+	return result, nil
+}
+
+// globFS returns the sorted paths of the regular files in fsys matching pattern
+func globFS(fsys fs.FS, pattern string) ([]string, error) {
+	if !strings.Contains(pattern, "**") {
+		matches, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid glob '%s': %w", pattern, err)
+		}
+		var files []string
+		for _, m := range matches {
+			if info, err := fs.Stat(fsys, m); err == nil && info.Mode().IsRegular() {
+				files = append(files, m)
+			}
+		}
+		return files, nil
+	}
+
+	segments := strings.Split(pattern, "/")
+	for _, segment := range segments {
+		if _, err := path.Match(segment, ""); err != nil {
+			return nil, fmt.Errorf("invalid glob '%s': %w", pattern, err)
+		}
+	}
+
+	var files []string
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() && matchSegments(segments, strings.Split(name, "/")) {
+			files = append(files, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// matchSegments matches path segments against pattern segments, where a "**"
+// pattern segment matches zero or more path segments
+func matchSegments(pattern, name []string) bool {
+	if len(pattern) == 0 {
+		return len(name) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(name); i++ {
+			if matchSegments(pattern[1:], name[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(name) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], name[0]); !ok {
+		return false
+	}
+	return matchSegments(pattern[1:], name[1:])
+}
@@ -0,0 +1,113 @@
+package schema_test
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func specWith(schemas string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(`openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+` + schemas)}
+}
+
+func TestConvertDir(t *testing.T) {
+	fsys := fstest.MapFS{
+		"services/users/openapi.yaml": specWith(`    User:
+      type: object
+      properties:
+        id:
+          type: string
+    Error:
+      type: object
+      properties:
+        message:
+          type: string
+`),
+		"services/orders/v1/openapi.yaml": specWith(`    Order:
+      type: object
+      properties:
+        id:
+          type: string
+    Error:
+      type: object
+      properties:
+        code:
+          type: integer
+`),
+		"services/broken/openapi.yaml": specWith(`    Broken:
+      allOf:
+        - type: object
+`),
+		"services/users/README.md": {Data: []byte("not a spec")},
+	}
+
+	result, err := schema.ConvertDir(fsys, "services/**/openapi.yaml", schema.ConvertOptions{
+		PackageName: "api",
+		PackagePath: "github.com/example/api/v1",
+		Concurrency: 4,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, result.Files, 3)
+	assert.Equal(t, "services/broken/openapi.yaml", result.Files[0].Path)
+	assert.Equal(t, "services/orders/v1/openapi.yaml", result.Files[1].Path)
+	assert.Equal(t, "services/users/openapi.yaml", result.Files[2].Path)
+
+	assert.Nil(t, result.Files[0].Result)
+	assert.ErrorContains(t, result.Files[0].Err, "allOf")
+	assert.Contains(t, string(result.Files[1].Result.Protobuf), "message Order {")
+	assert.Contains(t, string(result.Files[2].Result.Protobuf), "message User {")
+
+	assert.Equal(t, 2, result.Converted)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, map[string][]string{
+		"Error": {"services/orders/v1/openapi.yaml", "services/users/openapi.yaml"},
+	}, result.Conflicts)
+	assert.ErrorContains(t, result.Err(), "services/broken/openapi.yaml: schema 'Broken'")
+}
+
+func TestConvertDirGlob(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.yaml":     specWith("    A:\n      type: object\n"),
+		"sub/b.yaml": specWith("    B:\n      type: object\n"),
+	}
+	opts := schema.ConvertOptions{PackageName: "api", PackagePath: "github.com/example/api/v1"}
+
+	result, err := schema.ConvertDir(fsys, "*.yaml", opts)
+	require.NoError(t, err)
+	require.Len(t, result.Files, 1)
+	assert.Equal(t, "a.yaml", result.Files[0].Path)
+	assert.NoError(t, result.Err())
+
+	result, err = schema.ConvertDir(fsys, "**/*.yaml", opts)
+	require.NoError(t, err)
+	require.Len(t, result.Files, 2)
+
+	_, err = schema.ConvertDir(fsys, "*.json", opts)
+	require.EqualError(t, err, "no spec files match '*.json'")
+
+	_, err = schema.ConvertDir(fsys, "[", opts)
+	require.ErrorContains(t, err, "invalid glob '['")
+}
+
+func TestConvertDirContextCancelled(t *testing.T) {
+	fsys := fstest.MapFS{"a.yaml": specWith("    A:\n      type: object\n")}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := schema.ConvertDirContext(ctx, fsys, "*.yaml", schema.ConvertOptions{
+		PackageName: "api",
+		PackagePath: "github.com/example/api/v1",
+	})
+	require.ErrorIs(t, err, context.Canceled)
+}
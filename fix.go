@@ -0,0 +1,53 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+)
+
+// FixSuggestion is a machine-applyable fix for a conversion error, expressed as
+// an RFC 6902 JSON Patch against the OpenAPI document.
+//
+// Suggestions are attached for these error classes:
+//   - inline object or integer enum under a plural property name: the inline
+//     schema is moved to components/schemas and replaced with a $ref
+//   - top-level oneOf with $ref variants but no discriminator: a discriminator is added on a
+//     string property shared by all variants, or on a new property added to each
+//   - x-proto-number on some but not all properties: the missing numbers are
+//     added, keeping existing ones so annotated fields stay wire-compatible
+type FixSuggestion = internal.FixSuggestion
+
+// PatchOperation is a single RFC 6902 JSON Patch operation ("add" or "replace").
+type PatchOperation = internal.PatchOperation
+
+// FixableError is returned (possibly wrapped) by Convert and ConvertToStruct when
+// a FixSuggestion is available. Use SuggestedFix or errors.As to retrieve it.
+type FixableError = internal.FixableError
+
+// SuggestedFix returns the fix attached to err, if any.
+func SuggestedFix(err error) (*FixSuggestion, bool) {
+	var fixable *FixableError
+	if errors.As(err, &fixable) && fixable.Fix != nil {
+		return fixable.Fix, true
+	}
+	return nil, false
+}
+
+// ApplyFix applies a FixSuggestion to an OpenAPI document. The document is
+// round-tripped through yaml.Node so comments and key order are preserved; JSON
+// input is accepted but the result is always emitted as block-style YAML.
+func ApplyFix(openapi []byte, fix *FixSuggestion) (_ []byte, err error) {
+	defer internal.Recover("ApplyFix", &err)
+
+	if len(openapi) == 0 {
+		return nil, fmt.Errorf("openapi input cannot be empty")
+	}
+
+	if fix == nil {
+		return nil, fmt.Errorf("fix cannot be nil")
+	}
+
+	return internal.ApplyPatch(openapi, fix.Patch)
+}
@@ -0,0 +1,147 @@
+package schema
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/duh-rpc/openapi-schema.go/internal/example"
+)
+
+// Format describes a custom string format, such as "iban", "semver" or
+// "duration", for every subsystem at once: how ConvertToExamples generates it,
+// the Go and proto types Convert and ConvertToStruct generate for it, and how
+// ValidateExamples and NewValidator check it.
+type Format struct {
+	Name string // The OpenAPI format, e.g. "iban"
+	// Example generates an example value, drawing any randomness from r so output
+	// stays deterministic for a seed. nil leaves unknown formats to a random string.
+	Example func(r *rand.Rand) string
+	// Go and Proto name the types generated for the format, with the package
+	// and .proto file declaring them, as TypeMapping does. A TypeMapping for
+	// the same format wins.
+	Go          string // Go type, e.g. "iban.IBAN"
+	GoImport    string // Go package declaring Go, e.g. "github.com/acme/iban"
+	Proto       string // proto3 type, e.g. "acme.type.IBAN"
+	ProtoImport string // .proto file declaring Proto, e.g. "acme/type/iban.proto"
+	// Validate reports why value is not of the format, or nil. Setting it turns
+	// format assertions on, so built-in formats such as email and uuid are then
+	// checked too.
+	Validate func(value string) error
+}
+
+// FormatRegistry holds custom formats by name. Register formats before passing
+// the registry to options; a nil registry holds none.
+type FormatRegistry struct {
+	formats map[string]Format
+}
+
+// NewFormatRegistry returns a registry holding formats, or the first error
+// Register reports for them.
+func NewFormatRegistry(formats ...Format) (*FormatRegistry, error) {
+	r := &FormatRegistry{formats: make(map[string]Format, len(formats))}
+	for _, f := range formats {
+		if err := r.Register(f); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Register adds f to the registry. It rejects formats without a name or
+// anything to apply, and names registered before.
+func (r *FormatRegistry) Register(f Format) error {
+	if f.Name == "" {
+		return fmt.Errorf("format must set Name")
+	}
+	if f.Example == nil && f.Go == "" && f.Proto == "" && f.Validate == nil {
+		return fmt.Errorf("format '%s' must set Example, Go, Proto or Validate", f.Name)
+	}
+	if _, ok := r.formats[f.Name]; ok {
+		return fmt.Errorf("format '%s' is already registered", f.Name)
+	}
+	if r.formats == nil {
+		r.formats = make(map[string]Format)
+	}
+	r.formats[f.Name] = f
+	return nil
+}
+
+// Lookup returns the format registered as name
+func (r *FormatRegistry) Lookup(name string) (Format, bool) {
+	if r == nil {
+		return Format{}, false
+	}
+	f, ok := r.formats[name]
+	return f, ok
+}
+
+// sorted returns the registered formats in name order
+func (r *FormatRegistry) sorted() []Format {
+	if r == nil {
+		return nil
+	}
+	names := make([]string, 0, len(r.formats))
+	for name := range r.formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	formats := make([]Format, len(names))
+	for i, name := range names {
+		formats[i] = r.formats[name]
+	}
+	return formats
+}
+
+// providers appends an example provider for each format with
+// an Example to providers
+func (r *FormatRegistry) providers(providers []ExampleProvider) []ExampleProvider {
+	formats := r.sorted()
+	if len(formats) == 0 {
+		return providers
+	}
+	result := append([]ExampleProvider(nil), providers...)
+	for _, f := range formats {
+		if f.Example != nil {
+			result = append(result, example.Provider{Name: "format " + f.Name, Formats: []string{f.Name}, Generate: f.Example})
+		}
+	}
+	return result
+}
+
+// typeMappings appends a string TypeMapping for each format with a Go or proto
+// type to mappings, unless mappings already map its format
+func (r *FormatRegistry) typeMappings(mappings []TypeMapping) []TypeMapping {
+	formats := r.sorted()
+	if len(formats) == 0 {
+		return mappings
+	}
+	result := append([]TypeMapping(nil), mappings...)
+	for _, f := range formats {
+		if (f.Go == "" && f.Proto == "") || internal.FindTypeMapping(mappings, "string", f.Name) != nil {
+			continue
+		}
+		result = append(result, TypeMapping{
+			Type:        "string",
+			Format:      f.Name,
+			Proto:       f.Proto,
+			ProtoImport: f.ProtoImport,
+			Go:          f.Go,
+			GoImport:    f.GoImport,
+		})
+	}
+	return result
+}
+
+// validators returns the Validate function of each format that has one, by name
+func (r *FormatRegistry) validators() map[string]func(string) error {
+	validators := make(map[string]func(string) error)
+	for _, f := range r.sorted() {
+		if f.Validate != nil {
+			validators[f.Name] = f.Validate
+		}
+	}
+	return validators
+}
@@ -0,0 +1,227 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const formatsSpec = `openapi: 3.1.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /accounts:
+    post:
+      operationId: createAccount
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Account'
+      responses:
+        '201':
+          description: Created
+components:
+  schemas:
+    Account:
+      type: object
+      properties:
+        iban:
+          type: string
+          format: iban
+        release:
+          type: string
+          format: semver
+`
+
+func newFormats(t *testing.T) *schema.FormatRegistry {
+	formats, err := schema.NewFormatRegistry(
+		schema.Format{
+			Name: "iban",
+			Example: func(r *rand.Rand) string {
+				return fmt.Sprintf("GB%02dNWBK6016133192%04d", r.Intn(100), r.Intn(10000))
+			},
+			Go:       "iban.IBAN",
+			GoImport: "github.com/acme/iban",
+			Validate: func(value string) error {
+				if !strings.HasPrefix(value, "GB") || len(value) != 22 {
+					return fmt.Errorf("'%s' is not an IBAN", value)
+				}
+				return nil
+			},
+		},
+		schema.Format{
+			Name:        "semver",
+			Example:     func(r *rand.Rand) string { return fmt.Sprintf("1.%d.0", r.Intn(10)) },
+			Proto:       "acme.type.SemVer",
+			ProtoImport: "acme/type/semver.proto",
+		},
+	)
+	require.NoError(t, err)
+	return formats
+}
+
+func TestFormatRegistryExamples(t *testing.T) {
+	result, err := schema.ConvertToExamples([]byte(formatsSpec), schema.ExampleOptions{
+		IncludeAll:   true,
+		Seed:         7,
+		SelfValidate: true,
+		Formats:      newFormats(t),
+	})
+	require.NoError(t, err)
+
+	var account map[string]string
+	require.NoError(t, json.Unmarshal(result.Examples["Account"], &account))
+	assert.Regexp(t, `^GB\d{2}NWBK6016133192\d{4}$`, account["iban"])
+	assert.Regexp(t, `^1\.\d\.0$`, account["release"])
+}
+
+func TestFormatRegistryTypes(t *testing.T) {
+	result, err := schema.Convert([]byte(formatsSpec), schema.ConvertOptions{
+		GoFormat:    schema.GoFormatNone,
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+		Formats:     newFormats(t),
+	})
+	require.NoError(t, err)
+
+	protobuf := string(result.Protobuf)
+	assert.Contains(t, protobuf, "import \"acme/type/semver.proto\";")
+	// iban has no proto type, so it keeps the built-in string
+	assert.Contains(t, protobuf, "  string iban = 1 [json_name = \"iban\"];")
+	assert.Contains(t, protobuf, "  acme.type.SemVer release = 2 [json_name = \"release\"];")
+
+	structs, err := schema.ConvertToStruct([]byte(formatsSpec), schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
+		GoPackagePath: "github.com/example/types/v1",
+		Formats:       newFormats(t),
+	})
+	require.NoError(t, err)
+
+	golang := string(structs.Golang)
+	assert.Contains(t, golang, "\"github.com/acme/iban\"")
+	assert.Contains(t, golang, "\tIban iban.IBAN `json:\"iban\"`\n")
+}
+
+func TestFormatRegistryTypeMappingsWin(t *testing.T) {
+	result, err := schema.ConvertToStruct([]byte(formatsSpec), schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
+		GoPackagePath: "github.com/example/types/v1",
+		Formats:       newFormats(t),
+		TypeMappings: []schema.TypeMapping{
+			{Type: "string", Format: "iban", Go: "string"},
+		},
+	})
+	require.NoError(t, err)
+
+	golang := string(result.Golang)
+	assert.NotContains(t, golang, "github.com/acme/iban")
+	assert.Contains(t, golang, "\tIban string `json:\"iban\"`\n")
+}
+
+func TestFormatRegistryValidateExamples(t *testing.T) {
+	spec := formatsSpec + `      example:
+        iban: DE89370400440532013000
+        release: 1.0.0
+`
+	result, err := schema.ValidateExamples([]byte(spec), schema.ValidateOptions{
+		SchemaNames: []string{"Account"},
+		Formats:     newFormats(t),
+	})
+	require.NoError(t, err)
+
+	account := result.Schemas["Account"]
+	assert.False(t, account.Valid)
+	require.Len(t, account.Issues, 1)
+	assert.Equal(t, "/components/schemas/Account/example/iban", account.Issues[0].Path)
+	assert.Equal(t, "format", account.Issues[0].Class)
+	assert.Contains(t, account.Issues[0].Message, "'DE89370400440532013000' is not an IBAN")
+
+	// Without the registry the format is unknown and not checked
+	result, err = schema.ValidateExamples([]byte(spec), schema.ValidateOptions{
+		SchemaNames: []string{"Account"},
+	})
+	require.NoError(t, err)
+	assert.True(t, result.Schemas["Account"].Valid)
+}
+
+func TestFormatRegistryValidator(t *testing.T) {
+	validator, err := schema.NewValidator([]byte(formatsSpec), schema.ValidatorOptions{
+		Formats: newFormats(t),
+	})
+	require.NoError(t, err)
+
+	handler := validator.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	for _, test := range []struct {
+		name string
+		body string
+		code int
+	}{
+		{name: "valid", body: `{"iban":"GB82NWBK60161331926819"}`, code: http.StatusCreated},
+		{name: "invalid", body: `{"iban":"DE89370400440532013000"}`, code: http.StatusBadRequest},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/accounts", strings.NewReader(test.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			assert.Equal(t, test.code, rec.Code)
+		})
+	}
+}
+
+func TestFormatRegistryRegisterErrors(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		formats []schema.Format
+		wantErr string
+	}{
+		{
+			name:    "missing name",
+			formats: []schema.Format{{Go: "iban.IBAN"}},
+			wantErr: "format must set Name",
+		},
+		{
+			name:    "nothing to apply",
+			formats: []schema.Format{{Name: "iban"}},
+			wantErr: "format 'iban' must set Example, Go, Proto or Validate",
+		},
+		{
+			name:    "duplicate",
+			formats: []schema.Format{{Name: "iban", Go: "iban.IBAN"}, {Name: "iban", Go: "string"}},
+			wantErr: "format 'iban' is already registered",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := schema.NewFormatRegistry(test.formats...)
+			require.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
+
+func TestFormatRegistryLookup(t *testing.T) {
+	formats := newFormats(t)
+
+	format, ok := formats.Lookup("semver")
+	require.True(t, ok)
+	assert.Equal(t, "acme.type.SemVer", format.Proto)
+
+	_, ok = formats.Lookup("duration")
+	assert.False(t, ok)
+
+	var none *schema.FormatRegistry
+	_, ok = none.Lookup("semver")
+	assert.False(t, ok)
+}
@@ -0,0 +1,98 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"go.yaml.in/yaml/v4"
+)
+
+// FragmentSchemaName is the component name a fragment is validated under; it is
+// the schema name that appears in ValidateSchemaFragment messages.
+const FragmentSchemaName = "Fragment"
+
+// fragmentDocument hosts a fragment as its only component schema
+const fragmentDocument = `openapi: 3.1.0
+info:
+  title: fragment
+  version: 0.0.0
+paths: {}
+components:
+  schemas: {}
+`
+
+// ValidateSchemaFragment checks a standalone schema object (YAML or JSON, not a
+// full OpenAPI document) against the rules Convert applies to a component schema,
+// so an editor can lint a snippet as it is typed. Issue pointers are relative to
+// the fragment root ("" for the fragment itself, "/properties/id" for a property)
+// and messages name the schema FragmentSchemaName.
+//
+// A fragment stands alone, so each component it references through
+// #/components/schemas/... is stood in for by an empty object schema; the
+// referenced schemas themselves are not checked. Other references cannot resolve
+// and fail the whole call. Fix suggestions are omitted because they patch a
+// whole document.
+//
+// An error is returned when the fragment is empty or not a YAML/JSON mapping.
+func ValidateSchemaFragment(yamlFragment []byte) (_ *LintReport, err error) {
+	defer internal.Recover("ValidateSchemaFragment", &err)
+
+	if len(yamlFragment) == 0 {
+		return nil, fmt.Errorf("schema fragment cannot be empty")
+	}
+
+	var fragment yaml.Node
+	if err := yaml.Unmarshal(yamlFragment, &fragment); err != nil {
+		return nil, fmt.Errorf("failed to parse schema fragment: %w", err)
+	}
+	if len(fragment.Content) == 0 || fragment.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("schema fragment must be a YAML or JSON object")
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(fragmentDocument), &doc); err != nil {
+		return nil, err
+	}
+	schemas := internal.MappingValue(internal.MappingValue(doc.Content[0], "components"), "schemas")
+	internal.SetMappingValue(schemas, FragmentSchemaName, fragment.Content[0])
+	for _, name := range componentRefs(fragment.Content[0], nil) {
+		if internal.MappingValue(schemas, name) == nil {
+			stub := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			internal.SetMappingValue(stub, "type", internal.StringNode("object"))
+			internal.SetMappingValue(schemas, name, stub)
+		}
+	}
+	internal.ClearFlowStyle(&doc)
+
+	openapi, err := internal.EncodeYAML(&doc)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := Lint(openapi, LintOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := internal.JSONPointer("components", "schemas", FragmentSchemaName)
+	for i := range report.Issues {
+		report.Issues[i].Pointer = strings.TrimPrefix(report.Issues[i].Pointer, prefix)
+		report.Issues[i].Fix = nil
+	}
+	return report, nil
+}
+
+// componentRefs appends the component names referenced anywhere under node
+func componentRefs(node *yaml.Node, names []string) []string {
+	const prefix = "#/components/schemas/"
+	if node.Kind == yaml.MappingNode {
+		if ref := internal.MappingValue(node, "$ref"); ref != nil && strings.HasPrefix(ref.Value, prefix) {
+			names = append(names, strings.TrimPrefix(ref.Value, prefix))
+		}
+	}
+	for _, child := range node.Content {
+		names = componentRefs(child, names)
+	}
+	return names
+}
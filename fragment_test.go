@@ -0,0 +1,107 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSchemaFragment(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		fragment string
+		want     []schema.LintIssue
+	}{
+		{
+			name: "valid",
+			fragment: `type: object
+properties:
+  id:
+    type: string
+`,
+			want: []schema.LintIssue{},
+		},
+		{
+			name:     "json",
+			fragment: `{"type": "object", "properties": {"code": {"type": "string", "pattern": "^[A-Z]+$"}}}`,
+			want: []schema.LintIssue{
+				{
+					Severity: schema.LintWarning,
+					Schema:   schema.FragmentSchemaName,
+					Pointer:  "/properties/code",
+					Code:     schema.WarningConstraintDropped,
+					Message:  "pattern dropped in proto",
+				},
+			},
+		},
+		{
+			name: "unsupported keyword",
+			fragment: `allOf:
+  - type: object
+`,
+			want: []schema.LintIssue{
+				{
+					Severity: schema.LintError,
+					Schema:   schema.FragmentSchemaName,
+					Pointer:  "",
+					Message:  "schema 'Fragment': uses 'allOf' which is not supported",
+				},
+			},
+		},
+		{
+			name: "plural inline array item",
+			fragment: `type: object
+properties:
+  addresses:
+    type: array
+    items:
+      type: object
+      properties:
+        street:
+          type: string
+`,
+			want: []schema.LintIssue{
+				{
+					Severity: schema.LintError,
+					Schema:   schema.FragmentSchemaName,
+					Pointer:  "",
+					Message: "schema 'Fragment': cannot derive message name from plural array property " +
+						"'addresses'; use singular form or $ref",
+				},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			report, err := schema.ValidateSchemaFragment([]byte(test.fragment))
+			require.NoError(t, err)
+			assert.Equal(t, test.want, report.Issues)
+		})
+	}
+}
+
+func TestValidateSchemaFragmentInvalid(t *testing.T) {
+	_, err := schema.ValidateSchemaFragment(nil)
+	require.EqualError(t, err, "schema fragment cannot be empty")
+
+	_, err = schema.ValidateSchemaFragment([]byte("- type: string\n"))
+	require.EqualError(t, err, "schema fragment must be a YAML or JSON object")
+
+	_, err = schema.ValidateSchemaFragment([]byte("type: [object"))
+	require.ErrorContains(t, err, "failed to parse schema fragment")
+}
+
+func TestValidateSchemaFragmentReferences(t *testing.T) {
+	report, err := schema.ValidateSchemaFragment([]byte(`type: object
+properties:
+  owner:
+    $ref: '#/components/schemas/User'
+  tags:
+    type: array
+    items:
+      $ref: '#/components/schemas/Tag'
+`))
+	require.NoError(t, err)
+	assert.Empty(t, report.Issues)
+}
@@ -0,0 +1,211 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fuzzSeeds are the specs every fuzz target starts from, alongside the corpus
+// in testdata/fuzz
+var fuzzSeeds = []string{
+	`openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    User:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: string
+          format: uuid
+        tags:
+          type: array
+          items:
+            type: string
+        status:
+          type: string
+          enum: [active, inactive]
+`,
+	`openapi: 3.1.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+      discriminator:
+        propertyName: petType
+    Dog:
+      type: object
+      properties:
+        petType:
+          type: string
+        bark:
+          type: string
+    Cat:
+      type: object
+      properties:
+        petType:
+          type: string
+        friends:
+          type: array
+          items:
+            $ref: '#/components/schemas/Cat'
+`,
+	`openapi: 3.1.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Base:
+      type: object
+      properties:
+        id:
+          type: integer
+    Derived:
+      allOf:
+        - $ref: '#/components/schemas/Base'
+        - type: object
+          properties:
+            amount:
+              type: [number, "null"]
+              minimum: 1
+              maximum: 10
+`,
+}
+
+func FuzzConvert(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, openapi []byte) {
+		result, err := schema.Convert(openapi, schema.ConvertOptions{
+			PackageName:   "testpkg",
+			PackagePath:   "github.com/example/proto/v1",
+			GoPackagePath: "github.com/example/types/v1",
+		})
+		if err == nil {
+			require.NotNil(t, result)
+		}
+	})
+}
+
+func FuzzConvertToExamples(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, openapi []byte) {
+		result, err := schema.ConvertToExamples(openapi, schema.ExampleOptions{
+			IncludeAll: true,
+			MaxDepth:   3,
+			Seed:       1,
+		})
+		if err == nil {
+			require.NotNil(t, result)
+		}
+	})
+}
+
+func TestConvertMalformedSchemas(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		schema  string
+		wantErr string
+	}{
+		{
+			name:    "items empty list",
+			schema:  "      type: array\n      items: []\n",
+			wantErr: "line 9, column 7: items must be a schema, not a list",
+		},
+		{
+			name:    "additionalProperties list",
+			schema:  "      type: object\n      additionalProperties: [{type: string}]\n",
+			wantErr: "line 9, column 7: additionalProperties must be a schema, not a list",
+		},
+		{
+			name:    "allOf scalar",
+			schema:  "      allOf: 5\n",
+			wantErr: "line 8, column 7: allOf must be a list of schemas",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			openapi := malformedSpec(test.schema)
+			_, err := schema.Convert(openapi, schema.ConvertOptions{
+				PackageName: "testpkg",
+				PackagePath: "github.com/example/proto/v1",
+			})
+			require.ErrorContains(t, err, test.wantErr)
+
+			_, err = schema.ConvertToExamples(openapi, schema.ExampleOptions{IncludeAll: true})
+			require.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
+
+func TestConvertMalformedSchemaValues(t *testing.T) {
+	openapi := malformedSpec("      type: object\n      properties:\n        items:\n          type: array\n          items: {type: string}\n      example:\n        items: []\n")
+	_, err := schema.Convert(openapi, schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+	})
+	require.NoError(t, err)
+}
+
+func TestValidateExamplesUnresolvedSchema(t *testing.T) {
+	_, err := schema.ValidateExamples(malformedSpec("      type: array\n      items: null\n"), schema.ValidateOptions{IncludeAll: true})
+	require.ErrorContains(t, err, "schema 'Malformed': failed to resolve schema")
+}
+
+func TestConvertToExamplesOversizedSchemas(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		schema string
+		mode   schema.ExampleMode
+	}{
+		{
+			name:   "minItems",
+			schema: "      type: array\n      items: {type: string}\n      minItems: 9000000000000000000\n",
+		},
+		{
+			name:   "minLength",
+			schema: "      type: string\n      minLength: 9000000000000000000\n",
+		},
+		{
+			name:   "maxLength boundary",
+			schema: "      type: string\n      maxLength: 9000000000000000000\n",
+			mode:   schema.ExampleModeBoundary,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := schema.ConvertToExamples(malformedSpec(test.schema), schema.ExampleOptions{
+				IncludeAll: true,
+				Seed:       1,
+				Mode:       test.mode,
+			})
+			require.NoError(t, err)
+			assert.NotContains(t, result.Examples, "Malformed")
+		})
+	}
+
+	result, err := schema.ConvertToExamples(malformedSpec("      type: string\n      minLength: 3\n      maxLength: 9000000000000000000\n"), schema.ExampleOptions{
+		IncludeAll: true,
+		Seed:       1,
+	})
+	require.NoError(t, err)
+	assert.Contains(t, result.Examples, "Malformed")
+}
+
+// malformedSpec returns a spec whose one schema, Malformed, has the given body
+func malformedSpec(body string) []byte {
+	return []byte("openapi: 3.1.0\ninfo:\n  title: Test API\n  version: 1.0.0\ncomponents:\n  schemas:\n    Malformed:\n" + body)
+}
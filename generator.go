@@ -0,0 +1,228 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+)
+
+// Generator is a backend producing its own output from a spec, such as Kotlin
+// classes, Rust structs or SQL DDL, reusing the parsing, classification and
+// naming of this package. Register generators in a GeneratorRegistry and run
+// them with Generate.
+type Generator interface {
+	// Name identifies the generator in a registry, e.g. "sql"
+	Name() string
+	// Generate returns the files generated for model
+	Generate(model *Model) ([]OutputFile, error)
+}
+
+// OutputFile is a file a Generator produces
+type OutputFile struct {
+	// Path is relative to the output directory, slash-separated and without
+	// "..", e.g. "models/User.kt"
+	Path    string
+	Content []byte
+}
+
+// Model is what a Generator generates from: the parsed spec as Parse returns
+// it, the options given for the generator, and the naming rules the built-in
+// backends apply.
+type Model struct {
+	*Document
+	// Options holds the GenerateOptions.Options given for the generator; nil
+	// when none are.
+	Options map[string]string
+
+	ctx context.Context
+}
+
+// Context returns the context Generate was called with. Long-running
+// generators should stop with its error once it is done.
+func (m *Model) Context() context.Context {
+	return m.ctx
+}
+
+// PascalCase converts a name to PascalCase, as proto message names are
+// written. Examples: user_id → UserId, shippingAddress → ShippingAddress
+func (m *Model) PascalCase(name string) string {
+	return internal.ToPascalCase(name)
+}
+
+// CamelCase converts a name to camelCase, as JSONTagCaseCamel writes keys.
+// Examples: created_at → createdAt, X-Request-ID → xRequestId
+func (m *Model) CamelCase(name string) string {
+	return internal.ApplyJSONCase(name, internal.JSONCaseCamel)
+}
+
+// SnakeCase converts a name to snake_case, as JSONTagCaseSnake writes keys.
+// Examples: userId → user_id, HTTPStatus → http_status
+func (m *Model) SnakeCase(name string) string {
+	return internal.ApplyJSONCase(name, internal.JSONCaseSnake)
+}
+
+// GoName converts a name to an exported Go identifier with Go initialisms, as
+// Go struct fields are named. Examples: userId → UserID, avatar_url → AvatarURL
+func (m *Model) GoName(name string) string {
+	return internal.ToGoName(name)
+}
+
+// Singular makes the last word of a name singular, as array item messages
+// are named. Examples: addresses → address, lineItems → lineItem
+func (m *Model) Singular(name string) string {
+	return internal.Singularize(name)
+}
+
+// GeneratorRegistry holds generators by name. A nil registry holds none.
+type GeneratorRegistry struct {
+	generators map[string]Generator
+}
+
+// NewGeneratorRegistry returns a registry holding generators, or the first
+// error Register reports for them.
+func NewGeneratorRegistry(generators ...Generator) (*GeneratorRegistry, error) {
+	r := &GeneratorRegistry{generators: make(map[string]Generator, len(generators))}
+	for _, g := range generators {
+		if err := r.Register(g); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Register adds g to the registry. It rejects nil generators, generators
+// without a name, and names registered before.
+func (r *GeneratorRegistry) Register(g Generator) error {
+	if g == nil {
+		return fmt.Errorf("generator must not be nil")
+	}
+	if g.Name() == "" {
+		return fmt.Errorf("generator must return a Name")
+	}
+	if _, ok := r.generators[g.Name()]; ok {
+		return fmt.Errorf("generator '%s' is already registered", g.Name())
+	}
+	if r.generators == nil {
+		r.generators = make(map[string]Generator)
+	}
+	r.generators[g.Name()] = g
+	return nil
+}
+
+// Lookup returns the generator registered as name
+func (r *GeneratorRegistry) Lookup(name string) (Generator, bool) {
+	if r == nil {
+		return nil, false
+	}
+	g, ok := r.generators[name]
+	return g, ok
+}
+
+// Names returns the names of the registered generators in sorted order
+func (r *GeneratorRegistry) Names() []string {
+	if r == nil {
+		return nil
+	}
+	names := make([]string, 0, len(r.generators))
+	for name := range r.generators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GenerateOptions configures Generate
+type GenerateOptions struct {
+	// Generators holds the generators to run from. Required.
+	Generators *GeneratorRegistry
+	// Names selects the generators to run, in order. Empty runs every
+	// registered generator, in name order.
+	Names []string
+	// Options holds options by generator name, passed to it as Model.Options.
+	Options map[string]map[string]string
+}
+
+// Generate parses openapi once and runs the selected generators on it,
+// returning the files of each by generator name. Output paths must be relative,
+// clean and unique within a generator.
+func Generate(openapi []byte, opts GenerateOptions) (map[string][]OutputFile, error) {
+	return GenerateContext(context.Background(), openapi, opts)
+}
+
+// GenerateContext is like Generate but stops with an error wrapping ctx.Err()
+// when ctx is cancelled between generators, and passes ctx to each as
+// Model.Context.
+func GenerateContext(ctx context.Context, openapi []byte, opts GenerateOptions) (_ map[string][]OutputFile, err error) {
+	defer internal.Recover("Generate", &err)
+
+	names := opts.Names
+	if len(names) == 0 {
+		names = opts.Generators.Names()
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no generators to run: GenerateOptions.Generators is empty")
+	}
+
+	generators := make([]Generator, len(names))
+	for i, name := range names {
+		g, ok := opts.Generators.Lookup(name)
+		if !ok {
+			return nil, fmt.Errorf("generator '%s' is not registered", name)
+		}
+		generators[i] = g
+	}
+
+	for name := range opts.Options {
+		if _, ok := opts.Generators.Lookup(name); !ok {
+			return nil, fmt.Errorf("options given for generator '%s', which is not registered", name)
+		}
+	}
+
+	if err := internal.Cancelled(ctx); err != nil {
+		return nil, err
+	}
+
+	doc, err := Parse(openapi)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]OutputFile, len(generators))
+	for _, g := range generators {
+		if err := internal.Cancelled(ctx); err != nil {
+			return nil, err
+		}
+
+		files, err := g.Generate(&Model{Document: doc, Options: opts.Options[g.Name()], ctx: ctx})
+		if err != nil {
+			return nil, fmt.Errorf("generator '%s': %w", g.Name(), err)
+		}
+		if err := checkOutputFiles(files); err != nil {
+			return nil, fmt.Errorf("generator '%s': %w", g.Name(), err)
+		}
+		result[g.Name()] = files
+	}
+
+	return result, nil
+}
+
+// checkOutputFiles reports the first file whose path is empty, absolute,
+// unclean or escapes the output directory, or repeats an earlier path
+func checkOutputFiles(files []OutputFile) error {
+	seen := make(map[string]bool, len(files))
+	for _, f := range files {
+		if f.Path == "" || path.IsAbs(f.Path) || path.Clean(f.Path) != f.Path ||
+			f.Path == ".." || strings.HasPrefix(f.Path, "../") || strings.Contains(f.Path, `\`) {
+			return fmt.Errorf("output path %q must be relative, clean and slash-separated", f.Path)
+		}
+		if seen[f.Path] {
+			return fmt.Errorf("output path %q is generated twice", f.Path)
+		}
+		seen[f.Path] = true
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package schema_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const generatorSpec = `openapi: 3.1.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    LineItem:
+      type: object
+      required: [sku]
+      properties:
+        sku:
+          type: string
+        unitPrice:
+          type: number
+    Customer:
+      type: object
+      properties:
+        customerId:
+          type: integer
+`
+
+// sqlGenerator writes a CREATE TABLE statement per object schema
+type sqlGenerator struct{}
+
+func (sqlGenerator) Name() string { return "sql" }
+
+func (sqlGenerator) Generate(model *schema.Model) ([]schema.OutputFile, error) {
+	var ddl strings.Builder
+	for _, entry := range model.Schemas {
+		ddl.WriteString(fmt.Sprintf("CREATE TABLE %s%s (\n", model.Options["prefix"], model.SnakeCase(entry.Name)))
+		var columns []string
+		for name, prop := range entry.Schema.Properties.FromOldest() {
+			column := "  " + model.SnakeCase(name) + " " + map[string]string{"string": "TEXT", "integer": "BIGINT", "number": "NUMERIC"}[prop.Schema().Type[0]]
+			if entry.Schema.Required != nil && contains(entry.Schema.Required, name) {
+				column += " NOT NULL"
+			}
+			columns = append(columns, column)
+		}
+		ddl.WriteString(strings.Join(columns, ",\n") + "\n);\n")
+	}
+	return []schema.OutputFile{{Path: "schema.sql", Content: []byte(ddl.String())}}, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// funcGenerator adapts a function to schema.Generator
+type funcGenerator struct {
+	name     string
+	generate func(model *schema.Model) ([]schema.OutputFile, error)
+}
+
+func (g funcGenerator) Name() string { return g.name }
+
+func (g funcGenerator) Generate(model *schema.Model) ([]schema.OutputFile, error) {
+	return g.generate(model)
+}
+
+func TestGenerate(t *testing.T) {
+	registry, err := schema.NewGeneratorRegistry(sqlGenerator{}, funcGenerator{
+		name: "names",
+		generate: func(model *schema.Model) ([]schema.OutputFile, error) {
+			var names []string
+			for _, entry := range model.Schemas {
+				names = append(names, model.PascalCase(entry.Name)+" "+model.Singular("lineItems")+" "+model.GoName("customerId")+" "+model.CamelCase("unit_price"))
+			}
+			return []schema.OutputFile{{Path: "docs/names.txt", Content: []byte(strings.Join(names, "\n"))}}, nil
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"names", "sql"}, registry.Names())
+
+	result, err := schema.Generate([]byte(generatorSpec), schema.GenerateOptions{
+		Generators: registry,
+		Options:    map[string]map[string]string{"sql": {"prefix": "app_"}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []schema.OutputFile{{Path: "schema.sql", Content: []byte(`CREATE TABLE app_line_item (
+  sku TEXT NOT NULL,
+  unit_price NUMERIC
+);
+CREATE TABLE app_customer (
+  customer_id BIGINT
+);
+`)}}, result["sql"])
+	assert.Equal(t, "LineItem lineItem CustomerID unitPrice\nCustomer lineItem CustomerID unitPrice", string(result["names"][0].Content))
+
+	result, err = schema.Generate([]byte(generatorSpec), schema.GenerateOptions{
+		Generators: registry,
+		Names:      []string{"names"},
+	})
+	require.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Contains(t, result, "names")
+}
+
+func TestGeneratorRegistryErrors(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		generators []schema.Generator
+		wantErr    string
+	}{
+		{
+			name:       "nil",
+			generators: []schema.Generator{nil},
+			wantErr:    "generator must not be nil",
+		},
+		{
+			name:       "no name",
+			generators: []schema.Generator{funcGenerator{}},
+			wantErr:    "generator must return a Name",
+		},
+		{
+			name:       "duplicate",
+			generators: []schema.Generator{sqlGenerator{}, sqlGenerator{}},
+			wantErr:    "generator 'sql' is already registered",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := schema.NewGeneratorRegistry(test.generators...)
+			require.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	output := func(files ...schema.OutputFile) schema.Generator {
+		return funcGenerator{name: "out", generate: func(*schema.Model) ([]schema.OutputFile, error) {
+			return files, nil
+		}}
+	}
+
+	for _, test := range []struct {
+		name      string
+		generator schema.Generator
+		opts      schema.GenerateOptions
+		wantErr   string
+	}{
+		{
+			name:    "no generators",
+			wantErr: "no generators to run: GenerateOptions.Generators is empty",
+		},
+		{
+			name:      "unknown name",
+			generator: sqlGenerator{},
+			opts:      schema.GenerateOptions{Names: []string{"kotlin"}},
+			wantErr:   "generator 'kotlin' is not registered",
+		},
+		{
+			name:      "options for unknown generator",
+			generator: sqlGenerator{},
+			opts:      schema.GenerateOptions{Options: map[string]map[string]string{"rust": {}}},
+			wantErr:   "options given for generator 'rust', which is not registered",
+		},
+		{
+			name: "generator error",
+			generator: funcGenerator{name: "fails", generate: func(*schema.Model) ([]schema.OutputFile, error) {
+				return nil, errors.New("unsupported schema")
+			}},
+			wantErr: "generator 'fails': unsupported schema",
+		},
+		{
+			name:      "escaping path",
+			generator: output(schema.OutputFile{Path: "../out.sql"}),
+			wantErr:   `generator 'out': output path "../out.sql" must be relative, clean and slash-separated`,
+		},
+		{
+			name:      "absolute path",
+			generator: output(schema.OutputFile{Path: "/etc/out.sql"}),
+			wantErr:   `output path "/etc/out.sql" must be relative, clean and slash-separated`,
+		},
+		{
+			name:      "unclean path",
+			generator: output(schema.OutputFile{Path: "a/./b.sql"}),
+			wantErr:   `output path "a/./b.sql" must be relative, clean and slash-separated`,
+		},
+		{
+			name:      "duplicate path",
+			generator: output(schema.OutputFile{Path: "a.sql"}, schema.OutputFile{Path: "a.sql"}),
+			wantErr:   `output path "a.sql" is generated twice`,
+		},
+		{
+			name: "panic",
+			generator: funcGenerator{name: "panics", generate: func(*schema.Model) ([]schema.OutputFile, error) {
+				panic("generator exploded")
+			}},
+			wantErr: "Generate: recovered from panic: generator exploded",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if test.generator != nil {
+				registry, err := schema.NewGeneratorRegistry(test.generator)
+				require.NoError(t, err)
+				test.opts.Generators = registry
+			}
+			_, err := schema.Generate([]byte(generatorSpec), test.opts)
+			require.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
+
+func TestGenerateContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	registry, err := schema.NewGeneratorRegistry(
+		funcGenerator{name: "first", generate: func(model *schema.Model) ([]schema.OutputFile, error) {
+			assert.Equal(t, ctx, model.Context())
+			cancel()
+			return nil, nil
+		}},
+		funcGenerator{name: "second", generate: func(*schema.Model) ([]schema.OutputFile, error) {
+			t.Fatal("second generator ran after cancellation")
+			return nil, nil
+		}},
+	)
+	require.NoError(t, err)
+
+	_, err = schema.GenerateContext(ctx, []byte(generatorSpec), schema.GenerateOptions{Generators: registry})
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,117 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"log/slog"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/duh-rpc/openapi-schema.go/internal/golang"
+	"github.com/duh-rpc/openapi-schema.go/internal/parser"
+)
+
+// HandlerOptions configures the duh-rpc handler scaffolding Convert generates
+// into ConvertResult.Handlers.
+type HandlerOptions struct {
+	// PackageName is the Go package of the generated handler. Empty → "handler".
+	PackageName string
+	// ServiceName names the interface the handler calls, with one method per
+	// operation. Empty → "Service".
+	ServiceName string
+}
+
+// buildHandlerRoutes returns a route for each POST operation of doc, whose
+// request body and 200 response must reference component schemas generated as
+// proto messages. duh-rpc serves every operation over POST, so other operations
+// are skipped.
+func buildHandlerRoutes(doc *parser.Document, typeMap map[string]*TypeInfo, logger *slog.Logger) ([]*golang.HandlerRoute, error) {
+	// message returns the proto message generated for a request or response schema
+	message := func(role, name string) (string, error) {
+		if name == "" {
+			return "", fmt.Errorf("%s must reference a schema in components/schemas", role)
+		}
+		info := typeMap[name]
+		switch {
+		case info == nil:
+			return "", fmt.Errorf("%s '%s' is not generated", role, name)
+		case info.Location != TypeLocationProto:
+			return "", fmt.Errorf("%s '%s' is generated as Go, not as a proto message", role, name)
+		}
+		return info.GeneratedName, nil
+	}
+
+	var routes []*golang.HandlerRoute
+	var errs []error
+	seen := make(map[string]string)
+	for _, op := range doc.Operations() {
+		if op.Method != "POST" {
+			logger.Debug(internal.LogOperationSkipped, "method", op.Method, "path", op.Path,
+				"reason", "duh-rpc handlers serve POST only")
+			continue
+		}
+
+		name := golang.HandlerRouteName(op.OperationID, op.Path)
+		if !token.IsIdentifier(name) {
+			errs = append(errs, fmt.Errorf("operation POST %s: cannot derive a method name; set operationId", op.Path))
+			continue
+		}
+		if path, ok := seen[name]; ok {
+			errs = append(errs, fmt.Errorf("operation POST %s: method name '%s' is also used by %s", op.Path, name, path))
+			continue
+		}
+		seen[name] = op.Path
+
+		request, err := message("request", op.RequestSchema)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("operation POST %s: %w", op.Path, err))
+			continue
+		}
+		response, err := message("response", op.ResponseSchema)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("operation POST %s: %w", op.Path, err))
+			continue
+		}
+
+		routes = append(routes, &golang.HandlerRoute{
+			Name:     name,
+			Path:     op.Path,
+			Summary:  op.Summary,
+			Request:  request,
+			Response: response,
+		})
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	if len(routes) == 0 {
+		return nil, fmt.Errorf("handlers: document has no POST operations")
+	}
+	return routes, nil
+}
+
+// generateHandlers renders the handler for doc's POST operations, importing the
+// protoc-generated types from typesPath
+func generateHandlers(doc *parser.Document, opts HandlerOptions, typesPath string, typeMap map[string]*TypeInfo, logger *slog.Logger) ([]byte, error) {
+	if opts.PackageName == "" {
+		opts.PackageName = "handler"
+	}
+	if opts.ServiceName == "" {
+		opts.ServiceName = "Service"
+	}
+	if !token.IsIdentifier(opts.PackageName) {
+		return nil, fmt.Errorf("handlers: invalid package name '%s'", opts.PackageName)
+	}
+	if !token.IsIdentifier(opts.ServiceName) || !token.IsExported(opts.ServiceName) {
+		return nil, fmt.Errorf("handlers: service name '%s' must be an exported Go identifier", opts.ServiceName)
+	}
+	if opts.ServiceName == "Handler" {
+		return nil, fmt.Errorf("handlers: service name 'Handler' conflicts with the generated handler type")
+	}
+
+	routes, err := buildHandlerRoutes(doc, typeMap, logger)
+	if err != nil {
+		return nil, err
+	}
+	return golang.GenerateHandlers(opts.PackageName, opts.ServiceName, typesPath, routes)
+}
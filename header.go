@@ -0,0 +1,66 @@
+package schema
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"runtime/debug"
+	"strings"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+)
+
+// modulePath is this module's path, looked up in the build info for its version
+const modulePath = "github.com/duh-rpc/openapi-schema.go"
+
+// fileHeader returns the comment block starting each generated file, ending in
+// a blank line, or nil when opts asks for none. openapi is the spec as given,
+// before any rewriting, so its hash matches the file on disk.
+func fileHeader(openapi []byte, opts ConvertOptions) []byte {
+	var text []string
+	if opts.GeneratedHeader {
+		sum := sha256.Sum256(openapi)
+		text = append(text,
+			"Code generated by openapi-schema. DO NOT EDIT.",
+			"source: sha256:"+hex.EncodeToString(sum[:]),
+			"version: "+toolVersion())
+	}
+	if strings.TrimSpace(opts.HeaderComment) != "" {
+		if len(text) > 0 {
+			text = append(text, "")
+		}
+		text = append(text, opts.HeaderComment)
+	}
+	if len(text) == 0 {
+		return nil
+	}
+	return []byte(internal.CommentStyle{}.Format(strings.Join(text, "\n"), "") + "\n")
+}
+
+// withHeader prepends header to a generated file; an empty file stays empty
+func withHeader(header, file []byte) []byte {
+	if len(header) == 0 || len(file) == 0 {
+		return file
+	}
+	return append(append([]byte(nil), header...), file...)
+}
+
+// toolVersion returns the version of this module the binary was built with, or
+// "(devel)" when it is unknown, as when built from within the module itself
+func toolVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "(devel)"
+	}
+	if info.Main.Path == modulePath && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == modulePath {
+			if dep.Replace != nil && dep.Replace.Version != "" {
+				return dep.Replace.Version
+			}
+			return dep.Version
+		}
+	}
+	return "(devel)"
+}
@@ -0,0 +1,43 @@
+package internal
+
+import (
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+const (
+	readOnlyNote  = "Read-only: set by the server; ignored in requests."
+	writeOnlyNote = "Write-only: accepted in requests; never returned in responses."
+)
+
+// IsReadOnly reports whether schema sets `readOnly: true`
+func IsReadOnly(schema *base.Schema) bool {
+	return schema != nil && schema.ReadOnly != nil && *schema.ReadOnly
+}
+
+// IsWriteOnly reports whether schema sets `writeOnly: true`
+func IsWriteOnly(schema *base.Schema) bool {
+	return schema != nil && schema.WriteOnly != nil && *schema.WriteOnly
+}
+
+// WithAccessNote appends a line noting a property's readOnly or writeOnly
+// marker to its field comment, so generated code documents which direction
+// carries the field.
+func WithAccessNote(description string, schema *base.Schema) string {
+	var note string
+	switch {
+	case IsReadOnly(schema):
+		note = readOnlyNote
+	case IsWriteOnly(schema):
+		note = writeOnlyNote
+	default:
+		return description
+	}
+
+	description = strings.TrimRight(description, "\n")
+	if strings.TrimSpace(description) == "" {
+		return note
+	}
+	return description + "\n" + note
+}
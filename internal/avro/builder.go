@@ -0,0 +1,409 @@
+package avro
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/duh-rpc/openapi-schema.go/internal/parser"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"go.yaml.in/yaml/v4"
+)
+
+// File is the .avsc of a component schema
+type File struct {
+	Name string // Component schema name
+	JSON []byte
+}
+
+// Context holds the state of an Avro generation run
+type Context struct {
+	Files     []*File
+	Ctx       context.Context // checked between schemas; nil → never cancelled
+	Namespace string          // set on the top-level type of each file; empty → none
+
+	// entries maps component schema names to their entries
+	entries map[string]*parser.SchemaEntry
+	// defined holds the named types defined so far in the file being built;
+	// later uses refer to them by name
+	defined map[string]bool
+	// resolving holds the referenced schemas being written inline, to reject
+	// cycles only a named type could close
+	resolving map[string]bool
+}
+
+// NewContext creates a new Context
+func NewContext() *Context {
+	return &Context{Files: []*File{}}
+}
+
+// avroName matches Avro names: record, enum and field names and enum symbols
+var avroName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// IsNamespace reports whether namespace is a dot-separated sequence of Avro
+// names, such as com.example.events
+func IsNamespace(namespace string) bool {
+	return avroNamespace.MatchString(namespace)
+}
+
+// avroNamespace matches dot-separated Avro names
+var avroNamespace = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*$`)
+
+// BuildFiles writes an .avsc for each entry that is an object with properties,
+// an enum or a oneOf or anyOf union, in document order. Each file is
+// self-contained: a record or enum it uses is defined where it is first used
+// and referred to by name after that. Other schemas, such as scalars and
+// arrays, are written inline where they are used.
+func BuildFiles(entries []*parser.SchemaEntry, ctx *Context) error {
+	ctx.entries = make(map[string]*parser.SchemaEntry, len(entries))
+	for _, entry := range entries {
+		ctx.entries[entry.Name] = entry
+	}
+
+	for _, entry := range entries {
+		if err := internal.Cancelled(ctx.Ctx); err != nil {
+			return err
+		}
+		schema := entry.Proxy.Schema()
+		if schema == nil {
+			return internal.SchemaError(entry.Name, "schema is nil")
+		}
+		if !isRecord(schema) && !isEnum(schema) && len(schema.OneOf) == 0 && len(schema.AnyOf) == 0 {
+			continue
+		}
+
+		ctx.defined, ctx.resolving = map[string]bool{}, map[string]bool{entry.Name: true}
+		typ, err := ctx.named(entry.Name, schema)
+		if err != nil {
+			return internal.SchemaError(entry.Name, err.Error())
+		}
+		typ = ctx.namespaced(typ)
+
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(typ); err != nil {
+			return internal.SchemaError(entry.Name, err.Error())
+		}
+		ctx.Files = append(ctx.Files, &File{Name: entry.Name, JSON: buf.Bytes()})
+	}
+	return nil
+}
+
+// namespaced sets the namespace on the top-level type of a file: on the named
+// type, or on each named branch of a union, which the types nested in them
+// inherit
+func (ctx *Context) namespaced(typ any) any {
+	if ctx.Namespace == "" {
+		return typ
+	}
+	switch t := typ.(type) {
+	case object:
+		if t.get("type") == "record" || t.get("type") == "enum" {
+			return t.insert(2, "namespace", ctx.Namespace)
+		}
+	case []any:
+		for i, branch := range t {
+			t[i] = ctx.namespaced(branch)
+		}
+	}
+	return typ
+}
+
+// named returns the type of a component schema, or of an inline object or enum
+// named typeName: its definition when first used in the file, its name after
+func (ctx *Context) named(typeName string, schema *base.Schema) (any, error) {
+	switch {
+	case isRecord(schema):
+		return ctx.record(typeName, schema)
+	case isEnum(schema):
+		return ctx.enum(typeName, schema)
+	}
+	return ctx.typeOf(typeName, schema)
+}
+
+// define marks typeName defined in the file, reporting whether it already was
+func (ctx *Context) define(typeName string) (bool, error) {
+	if !avroName.MatchString(typeName) {
+		return false, fmt.Errorf("'%s' is not a valid Avro name", typeName)
+	}
+	if ctx.defined[typeName] {
+		return true, nil
+	}
+	ctx.defined[typeName] = true
+	return false, nil
+}
+
+// record returns the record type of an object schema
+func (ctx *Context) record(typeName string, schema *base.Schema) (any, error) {
+	if defined, err := ctx.define(typeName); err != nil || defined {
+		return typeName, err
+	}
+
+	fields := make([]any, 0, schema.Properties.Len())
+	for propName, propProxy := range schema.Properties.FromOldest() {
+		if !avroName.MatchString(propName) {
+			return nil, fmt.Errorf("property '%s' is not a valid Avro field name", propName)
+		}
+		typ, nullable, err := ctx.property(typeName, propName, propProxy)
+		if err != nil {
+			return nil, fmt.Errorf("property '%s': %w", propName, err)
+		}
+
+		field := object{{"name", propName}}
+		if nullable || !slices.Contains(schema.Required, propName) {
+			field = append(field, member{"type", nullableType(typ)}, member{"default", nil})
+		} else {
+			field = append(field, member{"type", typ})
+		}
+		if description := propProxy.Schema().Description; description != "" {
+			field = append(field, member{"doc", description})
+		}
+		fields = append(fields, field)
+	}
+
+	typ := object{{"type", "record"}, {"name", typeName}}
+	if schema.Description != "" {
+		typ = append(typ, member{"doc", schema.Description})
+	}
+	return append(typ, member{"fields", fields}), nil
+}
+
+// enum returns the enum type of a string enum schema
+func (ctx *Context) enum(typeName string, schema *base.Schema) (any, error) {
+	if defined, err := ctx.define(typeName); err != nil || defined {
+		return typeName, err
+	}
+
+	symbols := make([]any, 0, len(schema.Enum))
+	for _, value := range schema.Enum {
+		if value.Tag == "!!null" {
+			continue
+		}
+		if value.Kind != yaml.ScalarNode || value.Tag != "!!str" || !avroName.MatchString(value.Value) {
+			return nil, fmt.Errorf("enum value '%s' is not a valid Avro symbol", value.Value)
+		}
+		symbols = append(symbols, value.Value)
+	}
+
+	typ := object{{"type", "enum"}, {"name", typeName}}
+	if schema.Description != "" {
+		typ = append(typ, member{"doc", schema.Description})
+	}
+	return append(typ, member{"symbols", symbols}), nil
+}
+
+// property returns the type of a property of record parent and whether it is
+// nullable. Inline objects and enums are named after the record and the
+// property, as Go names their structs (Order.shipping → OrderShipping).
+func (ctx *Context) property(parent, propName string, proxy *base.SchemaProxy) (any, bool, error) {
+	schema := proxy.Schema()
+	if schema == nil {
+		return nil, false, fmt.Errorf("schema is nil")
+	}
+	typ, err := ctx.proxyType(parent+internal.ToGoName(propName), proxy)
+	return typ, isNullable(schema), err
+}
+
+// proxyType returns the type of proxy: the type of the component schema it
+// references, or of its inline schema, named typeName if it is a record or enum
+func (ctx *Context) proxyType(typeName string, proxy *base.SchemaProxy) (any, error) {
+	if proxy.IsReference() {
+		refName, err := internal.ExtractReferenceName(proxy.GetReference())
+		if err != nil {
+			return nil, err
+		}
+		entry, ok := ctx.entries[refName]
+		if !ok {
+			return nil, fmt.Errorf("reference to unknown schema '%s'", refName)
+		}
+		schema := entry.Proxy.Schema()
+		if schema == nil {
+			return nil, fmt.Errorf("schema '%s' is nil", refName)
+		}
+		if isRecord(schema) || isEnum(schema) {
+			return ctx.named(refName, schema)
+		}
+		if ctx.resolving[refName] {
+			return nil, fmt.Errorf("circular reference to '%s': only records can refer to themselves in Avro", refName)
+		}
+		ctx.resolving[refName] = true
+		defer delete(ctx.resolving, refName)
+		return ctx.typeOf(refName, schema)
+	}
+
+	schema := proxy.Schema()
+	if schema == nil {
+		return nil, fmt.Errorf("schema is nil")
+	}
+	if _, ok := ctx.entries[typeName]; ok && (isRecord(schema) || isEnum(schema)) {
+		return nil, fmt.Errorf("inline type '%s' has the name of a component schema", typeName)
+	}
+	return ctx.named(typeName, schema)
+}
+
+// typeOf returns the type of a schema that is neither a record nor an enum
+func (ctx *Context) typeOf(typeName string, schema *base.Schema) (any, error) {
+	switch {
+	case len(schema.OneOf) > 0:
+		return ctx.union(typeName, schema.OneOf)
+	case len(schema.AnyOf) > 0:
+		return ctx.union(typeName, schema.AnyOf)
+	case len(schema.AllOf) > 0:
+		return nil, fmt.Errorf("allOf has no Avro equivalent")
+	case len(schema.Enum) > 0:
+		return nil, fmt.Errorf("only string enums have an Avro equivalent")
+	}
+
+	var types []string
+	for _, t := range schema.Type {
+		if t != "null" {
+			types = append(types, t)
+		}
+	}
+	switch len(types) {
+	case 0:
+		return nil, fmt.Errorf("schema has no type")
+	case 1:
+	default:
+		return nil, fmt.Errorf("schemas of several types have no Avro equivalent; use oneOf")
+	}
+
+	switch types[0] {
+	case "string":
+		switch schema.Format {
+		case "date-time":
+			return object{{"type", "long"}, {"logicalType", "timestamp-millis"}}, nil
+		case "date":
+			return object{{"type", "int"}, {"logicalType", "date"}}, nil
+		case "uuid":
+			return object{{"type", "string"}, {"logicalType", "uuid"}}, nil
+		case "byte", "binary":
+			return "bytes", nil
+		}
+		return "string", nil
+	case "integer":
+		if schema.Format == "int64" {
+			return "long", nil
+		}
+		return "int", nil
+	case "number":
+		if schema.Format == "float" {
+			return "float", nil
+		}
+		return "double", nil
+	case "boolean":
+		return "boolean", nil
+	case "array":
+		if schema.Items == nil || !schema.Items.IsA() || schema.Items.A == nil {
+			return nil, fmt.Errorf("array has no items schema")
+		}
+		items, err := ctx.proxyType(typeName+"Item", schema.Items.A)
+		if err != nil {
+			return nil, err
+		}
+		if itemSchema := schema.Items.A.Schema(); itemSchema != nil && isNullable(itemSchema) {
+			items = nullableType(items)
+		}
+		return object{{"type", "array"}, {"items", items}}, nil
+	case "object":
+		additional := schema.AdditionalProperties
+		if additional == nil || !additional.IsA() || additional.A == nil {
+			return nil, fmt.Errorf("objects without properties or an additionalProperties schema have no Avro equivalent")
+		}
+		values, err := ctx.proxyType(typeName+"Value", additional.A)
+		if err != nil {
+			return nil, err
+		}
+		return object{{"type", "map"}, {"values", values}}, nil
+	}
+	return nil, fmt.Errorf("unsupported type: %s", types[0])
+}
+
+// union returns the Avro union of variants. Avro tells the branches apart by
+// type, so variants must be of distinct types, and records or enums defined
+// elsewhere; any discriminator is dropped.
+func (ctx *Context) union(typeName string, variants []*base.SchemaProxy) (any, error) {
+	branches := make([]any, 0, len(variants))
+	seen := make(map[string]bool, len(variants))
+	for i, variant := range variants {
+		if !variant.IsReference() {
+			if schema := variant.Schema(); schema != nil && (isRecord(schema) || isEnum(schema)) {
+				return nil, fmt.Errorf("variant %d: inline objects and enums cannot be union branches; move them to components/schemas", i)
+			}
+		}
+		typ, err := ctx.proxyType(typeName, variant)
+		if err != nil {
+			return nil, fmt.Errorf("variant %d: %w", i, err)
+		}
+		key := branchKey(typ)
+		if key == "union" {
+			return nil, fmt.Errorf("variant %d: unions cannot be nested", i)
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("variant %d: union already has a '%s' branch", i, key)
+		}
+		seen[key] = true
+		branches = append(branches, typ)
+	}
+	return branches, nil
+}
+
+// branchKey identifies a union branch: the name of a named type, else its type
+func branchKey(typ any) string {
+	switch t := typ.(type) {
+	case string:
+		return t
+	case []any:
+		return "union"
+	case object:
+		if t.get("type") == "record" || t.get("type") == "enum" {
+			return t.get("name").(string)
+		}
+		return t.get("type").(string)
+	}
+	return ""
+}
+
+// nullableType returns the union of null and typ, null first so a default of
+// null is valid
+func nullableType(typ any) any {
+	if branches, ok := typ.([]any); ok {
+		if slices.Contains(branches, any("null")) {
+			return branches
+		}
+		return append([]any{"null"}, branches...)
+	}
+	return []any{"null", typ}
+}
+
+// isRecord reports whether schema is a plain object with properties
+func isRecord(schema *base.Schema) bool {
+	if len(schema.Enum) > 0 || len(schema.OneOf) > 0 || len(schema.AnyOf) > 0 || len(schema.AllOf) > 0 {
+		return false
+	}
+	return schema.Properties != nil && schema.Properties.Len() > 0 &&
+		(len(schema.Type) == 0 || (len(schema.Type) == 1 && schema.Type[0] == "object"))
+}
+
+// isEnum reports whether schema is a string enum
+func isEnum(schema *base.Schema) bool {
+	return len(schema.Enum) > 0 && slices.Contains(schema.Type, "string")
+}
+
+// isNullable reports whether schema admits null, through OpenAPI 3.0's
+// nullable, a 3.1 "null" type or a null enum value
+func isNullable(schema *base.Schema) bool {
+	if schema.Nullable != nil && *schema.Nullable {
+		return true
+	}
+	if len(schema.Type) > 1 && slices.Contains(schema.Type, "null") {
+		return true
+	}
+	return slices.ContainsFunc(schema.Enum, func(value *yaml.Node) bool { return value.Tag == "!!null" })
+}
@@ -0,0 +1,58 @@
+package avro
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// member is a key and value of an object
+type member struct {
+	key   string
+	value any
+}
+
+// object is a JSON object whose keys are written in order, so .avsc files read
+// type and name first and are stable from run to run
+type object []member
+
+// get returns the value of key; nil when absent
+func (o object) get(key string) any {
+	for _, m := range o {
+		if m.key == key {
+			return m.value
+		}
+	}
+	return nil
+}
+
+// insert returns o with key set to value at index i
+func (o object) insert(i int, key string, value any) object {
+	return append(o[:i:i], append(object{{key, value}}, o[i:]...)...)
+}
+
+// MarshalJSON writes the members of o in order
+func (o object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, m := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(m.key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+
+		var value bytes.Buffer
+		encoder := json.NewEncoder(&value)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(m.value); err != nil {
+			return nil, err
+		}
+		buf.Write(bytes.TrimRight(value.Bytes(), "\n"))
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
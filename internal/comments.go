@@ -0,0 +1,181 @@
+package internal
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// CommentStyle controls how descriptions are written as comments in generated
+// code
+type CommentStyle struct {
+	// Width is the column comment lines wrap at, a tab indent counting as four
+	// columns; 0 leaves lines whole
+	Width int
+	// StripMarkdown removes markdown markup from descriptions, keeping the text
+	// it formats
+	StripMarkdown bool
+}
+
+// SchemaDoc returns the text documenting schema in generated comments: its
+// title as a heading, separated from its description by a blank line, then
+// "See: <url>" for its externalDocs. A title repeating the description is
+// left out.
+func SchemaDoc(schema *base.Schema) string {
+	if schema == nil {
+		return ""
+	}
+
+	var parts []string
+	title := strings.TrimSpace(schema.Title)
+	description := strings.Trim(schema.Description, "\n")
+	if title != "" && title != strings.TrimSpace(description) {
+		parts = append(parts, title)
+	}
+	if strings.TrimSpace(description) != "" {
+		parts = append(parts, description)
+	}
+	if schema.ExternalDocs != nil && schema.ExternalDocs.URL != "" {
+		parts = append(parts, "See: "+schema.ExternalDocs.URL)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// Format writes description as a comment indented by indent, one line comment
+// per line of the description and "//" for blank lines. Carriage returns and
+// leading or trailing blank lines are dropped. Lines longer than Width are
+// wrapped at spaces, except indented (code) lines; a list item's continuation
+// lines are indented under its text.
+func (c CommentStyle) Format(description, indent string) string {
+	description = strings.ReplaceAll(description, "\r\n", "\n")
+	description = strings.ReplaceAll(description, "\r", "\n")
+	if c.StripMarkdown {
+		description = StripMarkdown(description)
+	}
+
+	lines := strings.Split(description, "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+
+	width := 0
+	if c.Width > 0 {
+		width = c.Width - len("// ") - len(strings.ReplaceAll(indent, "\t", "    "))
+	}
+
+	var result strings.Builder
+	for _, line := range lines {
+		trimmed := strings.TrimRight(line, " \t")
+		if trimmed == "" {
+			result.WriteString(indent)
+			result.WriteString("//\n")
+			continue
+		}
+		for _, wrapped := range wrapCommentLine(trimmed, width) {
+			result.WriteString(indent)
+			result.WriteString("// ")
+			result.WriteString(wrapped)
+			result.WriteString("\n")
+		}
+	}
+	return result.String()
+}
+
+// listMarker matches the marker starting a markdown list item
+var listMarker = regexp.MustCompile(`^([-*+]|\d+[.)]) `)
+
+// wrapCommentLine splits line at spaces into lines of at most width runes; a
+// word longer than width stays whole, as does every line when width <= 0
+func wrapCommentLine(line string, width int) []string {
+	if width <= 0 || utf8.RuneCountInString(line) <= width || line[0] == ' ' || line[0] == '\t' {
+		return []string{line}
+	}
+
+	hang := ""
+	if marker := listMarker.FindString(line); marker != "" {
+		hang = strings.Repeat(" ", len(marker))
+	}
+
+	var lines []string
+	current := ""
+	for _, word := range strings.Fields(line) {
+		switch {
+		case current == "":
+			current = word
+		case utf8.RuneCountInString(current)+1+utf8.RuneCountInString(word) <= width:
+			current += " " + word
+		default:
+			lines = append(lines, current)
+			current = hang + word
+		}
+	}
+	return append(lines, current)
+}
+
+var (
+	markdownHeading    = regexp.MustCompile(`^#{1,6}\s+`)
+	markdownTrailHash  = regexp.MustCompile(`\s+#+$`)
+	markdownQuote      = regexp.MustCompile(`^>\s?`)
+	markdownBullet     = regexp.MustCompile(`^(\s*)[*+] `)
+	markdownRule       = regexp.MustCompile(`^\s*([-*_]\s*){3,}$`)
+	markdownImage      = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	markdownLink       = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)[^)]*\)`)
+	markdownAutoLink   = regexp.MustCompile(`<((?:https?|mailto):[^>\s]+)>`)
+	markdownCode       = regexp.MustCompile("`+([^`]+)`+")
+	markdownStrong     = regexp.MustCompile(`\*\*([^*\s](?:[^*]*[^*\s])?)\*\*|\b__([^_\s](?:[^_]*[^_\s])?)__\b`)
+	markdownEmphasis   = regexp.MustCompile(`(^|[^\w*])\*([^*\s](?:[^*]*[^*\s])?)\*`)
+	markdownUnderscore = regexp.MustCompile(`\b_([^_\s](?:[^_]*[^_\s])?)_\b`)
+)
+
+// StripMarkdown removes markdown markup from text, keeping the text it
+// formats: heading markers, block quotes, horizontal rules, emphasis and inline
+// code markers go, links become "text (url)", images their alt text, and "*"
+// or "+" bullets become "-". Fenced code blocks lose their fences and are
+// indented by four spaces, so they are kept whole when wrapped.
+func StripMarkdown(text string) string {
+	lines := strings.Split(text, "\n")
+	result := make([]string, 0, len(lines))
+	fenced := false
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fenced = !fenced
+			continue
+		}
+		if fenced {
+			result = append(result, "    "+line)
+			continue
+		}
+		if strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t") {
+			result = append(result, line)
+			continue
+		}
+		if markdownRule.MatchString(line) {
+			result = append(result, "")
+			continue
+		}
+
+		if markdownHeading.MatchString(line) {
+			line = markdownTrailHash.ReplaceAllString(markdownHeading.ReplaceAllString(line, ""), "")
+		}
+		line = markdownQuote.ReplaceAllString(line, "")
+		line = markdownBullet.ReplaceAllString(line, "$1- ")
+		line = markdownImage.ReplaceAllString(line, "$1")
+		line = markdownLink.ReplaceAllString(line, "$1 ($2)")
+		line = markdownAutoLink.ReplaceAllString(line, "$1")
+		line = markdownCode.ReplaceAllString(line, "$1")
+		line = markdownStrong.ReplaceAllString(line, "$1$2")
+		line = markdownEmphasis.ReplaceAllString(line, "$1$2")
+		line = markdownUnderscore.ReplaceAllString(line, "$1")
+		result = append(result, line)
+	}
+	return strings.Join(result, "\n")
+}
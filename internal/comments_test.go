@@ -0,0 +1,71 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStripMarkdown(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		given    string
+		expected string
+	}{
+		{name: "heading", given: "## Overview ##", expected: "Overview"},
+		{name: "emphasis", given: "a **bold**, *italic* and _quiet_ word", expected: "a bold, italic and quiet word"},
+		{name: "snake case", given: "set max_retry_count to 2*3*4", expected: "set max_retry_count to 2*3*4"},
+		{name: "inline code", given: "call `Get()` first", expected: "call Get() first"},
+		{name: "link", given: "see [the docs](https://example.com/docs \"Docs\")", expected: "see the docs (https://example.com/docs)"},
+		{name: "image", given: "![diagram](d.png) above", expected: "diagram above"},
+		{name: "autolink", given: "at <https://example.com>", expected: "at https://example.com"},
+		{name: "bullets", given: "* one\n+ two\n- three", expected: "- one\n- two\n- three"},
+		{name: "quote", given: "> quoted", expected: "quoted"},
+		{name: "rule", given: "above\n---\nbelow", expected: "above\n\nbelow"},
+		{name: "code fence", given: "Example:\n```json\n{\"a\": *b*}\n```", expected: "Example:\n    {\"a\": *b*}"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, internal.StripMarkdown(test.given))
+		})
+	}
+}
+
+func TestCommentStyleFormat(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		style    internal.CommentStyle
+		given    string
+		indent   string
+		expected string
+	}{
+		{
+			name:     "line per line",
+			given:    "\nFirst line.\r\n\r\nSecond line.  \n\n",
+			indent:   "  ",
+			expected: "  // First line.\n  //\n  // Second line.\n",
+		},
+		{
+			name:     "blank",
+			given:    " \n\n",
+			expected: "",
+		},
+		{
+			name:     "wrapped",
+			style:    internal.CommentStyle{Width: 20},
+			given:    "one two three four five six\n    indented code is kept whole\n1. list item that wraps",
+			expected: "// one two three\n// four five six\n//     indented code is kept whole\n// 1. list item that\n//    wraps\n",
+		},
+		{
+			name:     "tab indent counts four columns",
+			style:    internal.CommentStyle{Width: 20},
+			given:    "one two three four",
+			indent:   "\t",
+			expected: "\t// one two three\n\t// four\n",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.style.Format(test.given, test.indent))
+		})
+	}
+}
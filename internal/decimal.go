@@ -0,0 +1,59 @@
+package internal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// DefaultDecimalScale is the number of fraction digits of decimal values whose
+// multipleOf does not give one
+const DefaultDecimalScale = 2
+
+// DecimalOptions opts into mapping decimal values, which float64 cannot hold
+// exactly, onto exact types. Decimal values are numbers and strings with format
+// "decimal", and those marked x-money: true.
+type DecimalOptions struct {
+	// Money maps decimal values to google.type.Money in proto rather than string
+	Money bool
+	// GoType is the Go type of decimal values, e.g. "decimal.Decimal", imported
+	// from GoImport. Empty → json.Number for numbers and string for strings.
+	GoType   string
+	GoImport string
+}
+
+// ValidateDecimalOptions rejects a GoImport without the GoType it imports
+func ValidateDecimalOptions(opts *DecimalOptions) error {
+	if opts != nil && opts.GoImport != "" && opts.GoType == "" {
+		return fmt.Errorf("Decimals.GoImport requires Decimals.GoType")
+	}
+	return nil
+}
+
+// IsDecimal reports whether schema is a number or string holding a decimal
+// value: format "decimal" or x-money: true
+func IsDecimal(schema *base.Schema) bool {
+	if schema == nil || (!Contains(schema.Type, "number") && !Contains(schema.Type, "string")) {
+		return false
+	}
+	return schema.Format == "decimal" || extensionString(schema, "x-money") == "true"
+}
+
+// DecimalScale returns the number of fraction digits of decimal schema: that of
+// a multipleOf such as 0.01 or 1, else DefaultDecimalScale
+func DecimalScale(schema *base.Schema) int {
+	if schema.MultipleOf == nil {
+		return DefaultDecimalScale
+	}
+	step := strconv.FormatFloat(*schema.MultipleOf, 'f', -1, 64)
+	if step == "1" {
+		return 0
+	}
+	fraction, ok := strings.CutPrefix(step, "0.")
+	if !ok || strings.TrimLeft(fraction, "0") != "1" {
+		return DefaultDecimalScale
+	}
+	return len(fraction)
+}
@@ -2,6 +2,8 @@ package internal
 
 import (
 	"fmt"
+	"slices"
+	"sort"
 
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 )
@@ -10,6 +12,7 @@ import (
 type DependencyGraph struct {
 	schemas       map[string]*base.SchemaProxy
 	edges         map[string][]string // from -> []to dependencies
+	dependents    map[string][]string // to -> []from, the reverse of edges
 	hasUnion      map[string]bool
 	unionReasons  map[string]string
 	unionVariants map[string][]string // union name -> variant names
@@ -20,6 +23,7 @@ func NewDependencyGraph() *DependencyGraph {
 	return &DependencyGraph{
 		schemas:       make(map[string]*base.SchemaProxy),
 		edges:         make(map[string][]string),
+		dependents:    make(map[string][]string),
 		hasUnion:      make(map[string]bool),
 		unionReasons:  make(map[string]string),
 		unionVariants: make(map[string][]string),
@@ -38,6 +42,7 @@ func (g *DependencyGraph) AddDependency(from, to string) {
 		g.edges[from] = make([]string, 0)
 	}
 	g.edges[from] = append(g.edges[from], to)
+	g.dependents[to] = append(g.dependents[to], from)
 }
 
 // MarkUnion marks a schema as containing a union with the given reason and variant names
@@ -47,64 +52,58 @@ func (g *DependencyGraph) MarkUnion(schemaName, reason string, variants []string
 	g.unionVariants[schemaName] = variants
 }
 
-// ComputeTransitiveClosure performs BFS to find all schemas that should be Go-only
+// ComputeTransitiveClosure performs BFS over reverse dependency edges to find all
+// schemas that should be Go-only, visiting each schema and edge once (O(V+E)).
+// Unions are seeded in name order so reasons are stable across runs.
 // Returns goTypes (Go-only schemas), protoTypes (proto schemas), and reasons
 func (g *DependencyGraph) ComputeTransitiveClosure() (goTypes, protoTypes map[string]bool, reasons map[string]string) {
 	goTypes = make(map[string]bool)
 	reasons = make(map[string]string)
 	rootCause := make(map[string]string) // tracks root union type for each Go-only type
-	visited := make(map[string]bool)
+
+	unions := make([]string, 0, len(g.unionReasons))
+	for name := range g.unionReasons {
+		unions = append(unions, name)
+	}
+	sort.Strings(unions)
 
 	// Mark direct union types
-	for name, reason := range g.unionReasons {
+	queue := make([]string, 0, len(g.schemas))
+	for _, name := range unions {
 		goTypes[name] = true
-		reasons[name] = reason
+		reasons[name] = g.unionReasons[name]
 		rootCause[name] = name // union types are their own root cause
-		visited[name] = true
+		queue = append(queue, name)
 	}
 
 	// Mark union variants
-	for unionName, variants := range g.unionVariants {
-		for _, variant := range variants {
+	for _, unionName := range unions {
+		for _, variant := range g.unionVariants[unionName] {
 			if !goTypes[variant] {
 				goTypes[variant] = true
 				reasons[variant] = fmt.Sprintf("variant of union type %s", unionName)
 				rootCause[variant] = unionName // root cause is the union containing this variant
-				visited[variant] = true
+				queue = append(queue, variant)
 			}
 		}
 	}
 
 	// BFS to find all types referencing Go-only types
-	queue := make([]string, 0)
-	for name := range goTypes {
-		queue = append(queue, name)
-	}
+	for head := 0; head < len(queue); head++ {
+		current := queue[head]
 
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
-
-		// Find all types that depend on (reference) current
-		for from, deps := range g.edges {
-			if visited[from] {
+		for _, from := range g.dependents[current] {
+			if goTypes[from] {
 				continue
 			}
 
-			// Check if 'from' references 'current'
-			for _, to := range deps {
-				if to == current {
-					// Mark 'from' as Go-only because it references a Go-only type
-					goTypes[from] = true
-					// Use the root cause union type, not the immediate dependency
-					unionType := rootCause[current]
-					reasons[from] = fmt.Sprintf("references union type %s", unionType)
-					rootCause[from] = unionType // propagate root cause
-					visited[from] = true
-					queue = append(queue, from)
-					break
-				}
-			}
+			// Mark 'from' as Go-only because it references a Go-only type, using the
+			// root cause union type rather than the immediate dependency
+			unionType := rootCause[current]
+			goTypes[from] = true
+			reasons[from] = fmt.Sprintf("references union type %s", unionType)
+			rootCause[from] = unionType // propagate root cause
+			queue = append(queue, from)
 		}
 	}
 
@@ -119,6 +118,35 @@ func (g *DependencyGraph) ComputeTransitiveClosure() (goTypes, protoTypes map[st
 	return goTypes, protoTypes, reasons
 }
 
+// Dependencies returns the schemas name references directly, through its
+// properties, array items or oneOf variants, in first-reference order
+func (g *DependencyGraph) Dependencies(name string) []string {
+	deps := g.References(name)
+	for _, variant := range g.unionVariants[name] {
+		if !slices.Contains(deps, variant) {
+			deps = append(deps, variant)
+		}
+	}
+	return deps
+}
+
+// References returns the schemas name references through its properties and
+// array items, in first-reference order
+func (g *DependencyGraph) References(name string) []string {
+	var refs []string
+	for _, to := range g.edges[name] {
+		if !slices.Contains(refs, to) {
+			refs = append(refs, to)
+		}
+	}
+	return refs
+}
+
+// UnionVariants returns the oneOf variants of a union schema; nil for other schemas
+func (g *DependencyGraph) UnionVariants(name string) []string {
+	return g.unionVariants[name]
+}
+
 // Schemas returns the schemas map for external package access
 func (g *DependencyGraph) Schemas() map[string]*base.SchemaProxy {
 	return g.schemas
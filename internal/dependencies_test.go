@@ -1,9 +1,11 @@
 package internal_test
 
 import (
+	"fmt"
 	"testing"
 
 	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/duh-rpc/openapi-schema.go/internal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -462,3 +464,59 @@ components:
 	assert.NotContains(t, goCode, "type Toy struct")
 	assert.NotContains(t, goCode, "type Food struct")
 }
+
+// TestComputeTransitiveClosureChain validates propagation along a long reference
+// chain and that the reason names the root union, not the nearest Go type
+func TestComputeTransitiveClosureChain(t *testing.T) {
+	graph := chainGraph(500)
+
+	goTypes, protoTypes, reasons := graph.ComputeTransitiveClosure()
+
+	assert.Len(t, goTypes, 501)
+	assert.Empty(t, protoTypes)
+	assert.Equal(t, "contains oneOf", reasons["Union"])
+	assert.Equal(t, "references union type Union", reasons["Chain0"])
+	assert.Equal(t, "references union type Union", reasons["Chain499"])
+}
+
+func TestDependencyGraphDependencies(t *testing.T) {
+	graph := internal.NewDependencyGraph()
+	graph.MarkUnion("Pet", "contains oneOf", []string{"Dog", "Cat"})
+	graph.AddDependency("Pet", "Dog")
+	graph.AddDependency("Owner", "Address")
+	graph.AddDependency("Owner", "Pet")
+	graph.AddDependency("Owner", "Address")
+
+	assert.Equal(t, []string{"Address", "Pet"}, graph.Dependencies("Owner"))
+	assert.Equal(t, []string{"Dog", "Cat"}, graph.Dependencies("Pet"))
+	assert.Empty(t, graph.Dependencies("Address"))
+}
+
+// chainGraph builds Chain{n-1} -> ... -> Chain0 -> Union, the worst case for a
+// closure that rescans every edge per visited type
+func chainGraph(n int) *internal.DependencyGraph {
+	graph := internal.NewDependencyGraph()
+	_ = graph.AddSchema("Union", nil)
+	graph.MarkUnion("Union", "contains oneOf", nil)
+
+	prev := "Union"
+	for i := 0; i < n; i++ {
+		name := fmt.Sprintf("Chain%d", i)
+		_ = graph.AddSchema(name, nil)
+		graph.AddDependency(name, prev)
+		prev = name
+	}
+	return graph
+}
+
+func BenchmarkComputeTransitiveClosure(b *testing.B) {
+	for _, n := range []int{100, 1000, 10000} {
+		graph := chainGraph(n)
+		b.Run(fmt.Sprintf("chain=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				graph.ComputeTransitiveClosure()
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package internal
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // SchemaError creates an error with schema context.
 // Format: schema '<name>': <message>
@@ -25,3 +28,15 @@ func UnsupportedError(schemaName, propertyName, feature string) error {
 func UnsupportedSchemaError(schemaName, feature string) error {
 	return fmt.Errorf("schema '%s': uses '%s' which is not supported", schemaName, feature)
 }
+
+// Cancelled returns ctx.Err() wrapped with conversion context, or nil when ctx is
+// nil or still active. The cause is wrapped so errors.Is(err, context.Canceled) holds.
+func Cancelled(ctx context.Context) error {
+	if ctx == nil {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("conversion cancelled: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package example
+
+import "github.com/pb33f/libopenapi/datamodel/high/base"
+
+// Array item strategies: how many items arrays get within minItems and maxItems
+const (
+	ArrayItemsRandom = "random" // a random count within range
+	ArrayItemsMin    = "min"    // the fewest the schema allows, at least one
+	ArrayItemsMax    = "max"    // the most the schema allows
+	ArrayItemsFixed  = "fixed"  // ArrayItemCount, clamped into range
+)
+
+// itemCount picks the number of items for an array whose range, after the
+// one-item default, is minItems to maxItems
+func (ctx *ExampleContext) itemCount(schema *base.Schema, minItems, maxItems int) int {
+	switch ctx.arrayItems {
+	case ArrayItemsMin:
+		return minItems
+	case ArrayItemsMax:
+		return maxItems
+	case ArrayItemsFixed:
+		count := ctx.arrayItemCount
+		if schema.MinItems != nil && count < int(*schema.MinItems) {
+			count = int(*schema.MinItems)
+		}
+		if schema.MaxItems != nil && count > int(*schema.MaxItems) {
+			count = int(*schema.MaxItems)
+		}
+		return min(count, maxGeneratedSize)
+	}
+	if maxItems > minItems {
+		return ctx.rand.Intn(maxItems-minItems+1) + minItems
+	}
+	return minItems
+}
@@ -0,0 +1,50 @@
+package example
+
+import (
+	"encoding/base64"
+	"strings"
+)
+
+// defaultBase64Groups is the number of 4-character base64 groups, 3 bytes each,
+// in byte and binary examples without length constraints
+const defaultBase64Groups = 4
+
+// binaryDataURLPrefix starts the data URLs of binary examples
+const binaryDataURLPrefix = "data:application/octet-stream;base64,"
+
+// base64Value returns random base64 content for a byte or binary string, as a
+// data URL for binary ones when ctx.dataURLs is set. Whole groups of 3 bytes
+// encode without padding, so the length fits minLength and maxLength exactly;
+// ok is false when no whole number of groups does.
+func (ctx *ExampleContext) base64Value(format string, minLength, maxLength int) (string, bool) {
+	prefix := ""
+	if format == "binary" && ctx.dataURLs {
+		prefix = binaryDataURLPrefix
+	}
+
+	// Lengths count the prefix too, which is ASCII
+	groups := defaultBase64Groups
+	lower := (minLength - len(prefix) + 3) / 4
+	if lower > groups {
+		groups = lower
+	}
+	if maxLength > 0 {
+		upper := (maxLength - len(prefix)) / 4
+		if upper < lower || upper < 0 {
+			return "", false
+		}
+		if groups > upper {
+			groups = upper
+		}
+	}
+
+	data := make([]byte, 3*groups)
+	for i := range data {
+		data[i] = byte(ctx.rand.Intn(256))
+	}
+
+	var b strings.Builder
+	b.WriteString(prefix)
+	b.WriteString(base64.StdEncoding.EncodeToString(data))
+	return b.String(), true
+}
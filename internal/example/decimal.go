@@ -0,0 +1,75 @@
+package example
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// decimalValue returns a decimal with exactly the scale of decimal schema, a
+// number within its minimum and maximum (default 1 to 100) written with every
+// fraction digit, so 12.30 keeps its trailing zero. ok is false when no value of
+// that scale lies within the range.
+func (ctx *ExampleContext) decimalValue(schema *base.Schema) (string, bool) {
+	scale := internal.DecimalScale(schema)
+	factor := math.Pow10(scale)
+
+	min, max := 1.0, 100.0
+	if schema.Minimum != nil || schema.Maximum != nil {
+		min, max = 0, 100
+	}
+	if schema.Minimum != nil {
+		min = *schema.Minimum
+	}
+	if schema.Maximum != nil {
+		max = *schema.Maximum
+	}
+
+	// Pick a whole number of the smallest unit, e.g. cents, within the range
+	low, high := int64(math.Ceil(min*factor)), int64(math.Floor(max*factor))
+	if low > high {
+		return "", false
+	}
+	return formatUnits(low+ctx.rand.Int63n(high-low+1), scale), true
+}
+
+// formatUnits writes units of 10^-scale as a decimal with scale fraction digits
+func formatUnits(units int64, scale int) string {
+	sign := ""
+	if units < 0 {
+		sign, units = "-", -units
+	}
+	digits := strconv.FormatInt(units, 10)
+	if scale == 0 {
+		return sign + digits
+	}
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+	point := len(digits) - scale
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// decimalNumber returns decimalValue as a JSON number, which keeps its digits
+func (ctx *ExampleContext) decimalNumber(schema *base.Schema) (json.Number, bool) {
+	value, ok := ctx.decimalValue(schema)
+	return json.Number(value), ok
+}
+
+// servesFormat reports whether a provider serves format, which then generates
+// decimal strings in place of decimalValue
+func (ctx *ExampleContext) servesFormat(format string) bool {
+	if format == "" {
+		return false
+	}
+	for _, p := range ctx.providers {
+		if internal.Contains(p.Formats, format) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package example
+
+import (
+	"math"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// Example generation modes
+const (
+	ModeTypical  = "typical"  // values comfortably inside every constraint
+	ModeBoundary = "boundary" // constrained fields at their minimum or maximum
+	ModeInvalid  = "invalid"  // constrained fields just outside their range
+)
+
+// bound is one end of a numeric range
+type bound struct {
+	value     float64
+	exclusive bool
+}
+
+// numericBounds returns the lower and upper bounds schema sets, nil when unset.
+// exclusiveMinimum and exclusiveMaximum are booleans qualifying minimum and
+// maximum in OpenAPI 3.0, and bounds of their own in 3.1.
+func numericBounds(schema *base.Schema) (lo, hi *bound) {
+	if schema.Minimum != nil {
+		lo = &bound{value: *schema.Minimum}
+	}
+	if ex := schema.ExclusiveMinimum; ex != nil {
+		if !ex.IsA() {
+			lo = &bound{value: ex.B, exclusive: true}
+		} else if ex.A && lo != nil {
+			lo.exclusive = true
+		}
+	}
+	if schema.Maximum != nil {
+		hi = &bound{value: *schema.Maximum}
+	}
+	if ex := schema.ExclusiveMaximum; ex != nil {
+		if !ex.IsA() {
+			hi = &bound{value: ex.B, exclusive: true}
+		} else if ex.A && hi != nil {
+			hi.exclusive = true
+		}
+	}
+	return lo, hi
+}
+
+// edgeNumber returns, in ModeBoundary, the smallest or largest value schema
+// allows, and in ModeInvalid, the nearest value beyond one end of its range,
+// picking the end at random when both are set. Integers step by one, numbers
+// by the next representable float64.
+func (ctx *ExampleContext) edgeNumber(schema *base.Schema, integer bool) (float64, bool) {
+	if ctx.mode != ModeBoundary && ctx.mode != ModeInvalid {
+		return 0, false
+	}
+
+	lo, hi := numericBounds(schema)
+	var candidates []float64
+	if lo != nil {
+		candidates = append(candidates, edgeValue(*lo, -1, integer, ctx.mode == ModeInvalid))
+	}
+	if hi != nil {
+		candidates = append(candidates, edgeValue(*hi, 1, integer, ctx.mode == ModeInvalid))
+	}
+	if len(candidates) == 0 {
+		return 0, false
+	}
+	return candidates[ctx.rand.Intn(len(candidates))], true
+}
+
+// edgeValue returns the allowed value nearest b, or with invalid the nearest
+// value beyond it. direction is -1 for a lower bound and 1 for an upper one.
+func edgeValue(b bound, direction float64, integer, invalid bool) float64 {
+	var allowed float64
+	switch {
+	case integer && b.exclusive && direction < 0:
+		allowed = math.Floor(b.value) + 1
+	case integer && b.exclusive:
+		allowed = math.Ceil(b.value) - 1
+	case integer && direction < 0:
+		allowed = math.Ceil(b.value)
+	case integer:
+		allowed = math.Floor(b.value)
+	case b.exclusive:
+		allowed = math.Nextafter(b.value, -direction*math.Inf(1))
+	default:
+		allowed = b.value
+	}
+	if !invalid {
+		return allowed
+	}
+	if integer {
+		return allowed + direction
+	}
+	return math.Nextafter(allowed, direction*math.Inf(1))
+}
+
+// edgeCount returns, in ModeBoundary, min or max, and in ModeInvalid, one less
+// than a positive min or one more than max, for lengths and item counts. It
+// picks at random when both ends apply, and reports false when neither does.
+func (ctx *ExampleContext) edgeCount(min, max *int64) (int, bool) {
+	var candidates []int
+	switch ctx.mode {
+	case ModeBoundary:
+		if min != nil {
+			candidates = append(candidates, int(*min))
+		}
+		if max != nil {
+			candidates = append(candidates, int(*max))
+		}
+	case ModeInvalid:
+		if min != nil && *min > 0 {
+			candidates = append(candidates, int(*min)-1)
+		}
+		if max != nil {
+			candidates = append(candidates, int(*max)+1)
+		}
+	}
+	if len(candidates) == 0 {
+		return 0, false
+	}
+	return candidates[ctx.rand.Intn(len(candidates))], true
+}
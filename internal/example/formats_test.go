@@ -123,6 +123,30 @@ components:
 			schema:   "Server",
 			expected: `{"host":"example.com"}`,
 		},
+		{
+			name: "network formats",
+			openapi: `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Server:
+      type: object
+      properties:
+        address:
+          type: string
+          format: ipv4
+        address6:
+          type: string
+          format: ipv6
+        domain:
+          type: string
+          format: idn-hostname
+`,
+			schema:   "Server",
+			expected: `{"address":"192.0.2.1","address6":"2001:db8::1","domain":"bücher.example"}`,
+		},
 	} {
 		t.Run(test.name, func(t *testing.T) {
 			result, err := schema.ConvertToExamples([]byte(test.openapi), schema.ExampleOptions{
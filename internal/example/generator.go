@@ -1,8 +1,11 @@
 package example
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
+	"log/slog"
 	"math"
 	"math/rand"
 	"strconv"
@@ -21,24 +24,84 @@ type ExampleContext struct {
 	depth          int                            // Current nesting depth
 	maxDepth       int                            // Maximum allowed depth
 	rand           *rand.Rand                     // Random number generator (seeded for determinism)
-	fieldOverrides map[string]interface{}         // Field name to value overrides
+	fieldOverrides map[string]interface{}         // Field name, path or pattern to value overrides
+	overrides      []override                     // fieldOverrides keys that are paths or patterns, in match order
+	fields         []fieldSegment                 // Path to the value being generated, for path overrides
+	overrideFirst  bool                           // fieldOverrides beat the spec's example and default values
+	valueFunc      ValueFunc                      // Computes scalar values after fieldOverrides; may be nil
+	mode           string                         // ModeTypical, ModeBoundary or ModeInvalid
+	logger         *slog.Logger                   // Receives debug events; never nil
+	schema         string                         // Top-level schema being generated, for log attribution
+	jsonCase       string                         // Casing applied to generated property keys
+	providers      []Provider                     // Realistic string values by field name or format
+	dataURLs       bool                           // Binary strings are data URLs rather than bare base64
+	circular       string                         // CircularOmit, CircularNull or CircularTruncatedObject
+	truncations    []Truncation                   // Where the example being generated was cut short
+	requiredDepth  string                         // RequiredDepthDrop, RequiredDepthExtend or RequiredDepthStub
+	required       bool                           // The property being generated is required
+	arrayItems     string                         // ArrayItemsRandom, ArrayItemsMin, ArrayItemsMax or ArrayItemsFixed
+	arrayItemCount int                            // Item count under ArrayItemsFixed
+}
+
+// Options configures example generation
+type Options struct {
+	MaxDepth       int                    // Maximum allowed depth
+	Seed           int64                  // Random seed for deterministic generation
+	FieldOverrides map[string]interface{} // Field name, path or pattern to value overrides; see ValidateOverrides
+	// OverridePrecedence is OverridePrecedenceSchema or OverridePrecedenceOverride;
+	// "" → OverridePrecedenceSchema
+	OverridePrecedence string
+	// ValueFunc computes scalar values, after FieldOverrides and with the same
+	// precedence; nil → none
+	ValueFunc ValueFunc
+	Mode      string          // ModeTypical, ModeBoundary or ModeInvalid; "" → ModeTypical
+	Ctx       context.Context // Checked between schemas; nil → never cancelled
+	// Concurrency is the number of schemas generated in parallel; <= 1 is sequential.
+	// Parallel generation seeds a private generator per schema from Seed and the
+	// schema name, so output is deterministic but differs from sequential output.
+	Concurrency int
+	Logger      *slog.Logger // Receives debug events; nil → discarded
+	JSONCase    string       // Casing applied to property keys; see internal.ApplyJSONCase
+	Providers   []Provider   // Realistic string values, consulted before DefaultProviders
+	Locale      string       // Adds the LocaleProviders of the locale after Providers; "" → none
+	// BinaryDataURLs writes format binary strings as application/octet-stream
+	// base64 data URLs
+	BinaryDataURLs bool
+	// CircularStrategy is what examples hold where a circular reference or
+	// MaxDepth cuts generation short: CircularOmit, CircularNull or
+	// CircularTruncatedObject; "" → CircularOmit
+	CircularStrategy string
+	// RequiredDepth is what becomes of required properties MaxDepth would cut
+	// short: RequiredDepthDrop, RequiredDepthExtend or RequiredDepthStub; "" →
+	// RequiredDepthDrop
+	RequiredDepth string
+	// ArrayItems is how many items arrays get within minItems and maxItems:
+	// ArrayItemsRandom, ArrayItemsMin, ArrayItemsMax or ArrayItemsFixed; "" →
+	// ArrayItemsRandom
+	ArrayItems     string
+	ArrayItemCount int // Item count under ArrayItemsFixed
+}
+
+// Result holds generated examples and where each was cut short
+type Result struct {
+	Examples    map[string]json.RawMessage // schema name → JSON example
+	Truncations map[string][]Truncation    // schema name → truncations, for examples with any
 }
 
 // GenerateExamples generates JSON examples for specified schemas
-func GenerateExamples(entries []*parser.SchemaEntry, schemaNames []string, maxDepth int, seed int64, fieldOverrides map[string]interface{}) (map[string]json.RawMessage, error) {
-	schemaMap := make(map[string]*parser.SchemaEntry)
-	for _, entry := range entries {
-		schemaMap[entry.Name] = entry
+func GenerateExamples(entries []*parser.SchemaEntry, schemaNames []string, opts Options) (map[string]json.RawMessage, error) {
+	result, err := Generate(entries, schemaNames, opts)
+	if err != nil {
+		return nil, err
 	}
+	return result.Examples, nil
+}
 
-	ctx := &ExampleContext{
-		schemas:        schemaMap,
-		path:           make([]string, 0),
-		depth:          0,
-		maxDepth:       maxDepth,
-		rand:           rand.New(rand.NewSource(seed)),
-		fieldOverrides: fieldOverrides,
-	}
+// Generate generates JSON examples for specified schemas, recording where each
+// was cut short
+func Generate(entries []*parser.SchemaEntry, schemaNames []string, opts Options) (*Result, error) {
+	ctx := newExampleContext(entries, opts)
+	schemaMap := ctx.schemas
 
 	targetSchemas := entries
 	if len(schemaNames) > 0 {
@@ -50,37 +113,138 @@ func GenerateExamples(entries []*parser.SchemaEntry, schemaNames []string, maxDe
 		}
 	}
 
-	result := make(map[string]json.RawMessage)
+	if opts.Concurrency > 1 {
+		return generateParallel(targetSchemas, ctx, opts)
+	}
+
+	result := &Result{Examples: make(map[string]json.RawMessage), Truncations: make(map[string][]Truncation)}
 	for _, entry := range targetSchemas {
-		ctx.path = make([]string, 0)
-		ctx.depth = 0
+		if err := internal.Cancelled(opts.Ctx); err != nil {
+			return nil, err
+		}
 
-		value, err := generateExample(entry.Name, entry.Proxy, ctx)
-		if err != nil {
-			continue
+		if example, ok := generateSchemaJSON(entry, ctx); ok {
+			result.add(entry.Name, example, ctx.truncations)
 		}
+	}
 
-		jsonBytes, err := json.Marshal(value)
-		if err != nil {
-			continue
+	return result, nil
+}
+
+// add records the example of schema name and where it was cut short
+func (r *Result) add(name string, example json.RawMessage, truncations []Truncation) {
+	r.Examples[name] = example
+	if len(truncations) > 0 {
+		r.Truncations[name] = truncations
+	}
+}
+
+// newExampleContext creates a generation context over all component schemas
+func newExampleContext(entries []*parser.SchemaEntry, opts Options) *ExampleContext {
+	schemaMap := make(map[string]*parser.SchemaEntry)
+	for _, entry := range entries {
+		schemaMap[entry.Name] = entry
+	}
+
+	providers := append([]Provider(nil), opts.Providers...)
+	if opts.Locale != "" {
+		providers = append(providers, LocaleProviders(opts.Locale)...)
+	}
+	providers = append(providers, DefaultProviders()...)
+
+	return &ExampleContext{
+		schemas:        schemaMap,
+		path:           make([]string, 0),
+		depth:          0,
+		maxDepth:       opts.MaxDepth,
+		rand:           rand.New(rand.NewSource(opts.Seed)),
+		fieldOverrides: opts.FieldOverrides,
+		overrides:      parseOverrides(opts.FieldOverrides),
+		overrideFirst:  opts.OverridePrecedence == OverridePrecedenceOverride,
+		valueFunc:      opts.ValueFunc,
+		mode:           opts.Mode,
+		logger:         internal.LoggerOrDiscard(opts.Logger),
+		jsonCase:       opts.JSONCase,
+		providers:      providers,
+		dataURLs:       opts.BinaryDataURLs,
+		circular:       opts.CircularStrategy,
+		requiredDepth:  opts.RequiredDepth,
+		arrayItems:     opts.ArrayItems,
+		arrayItemCount: opts.ArrayItemCount,
+	}
+}
+
+// generateParallel generates each target schema on its own worker with a private
+// context and generator seeded from opts.Seed and the schema name.
+func generateParallel(targets []*parser.SchemaEntry, shared *ExampleContext, opts Options) (*Result, error) {
+	examples := make([]json.RawMessage, len(targets))
+	truncations := make([][]Truncation, len(targets))
+	err := internal.ParallelEach(opts.Concurrency, len(targets), func(i int) error {
+		if err := internal.Cancelled(opts.Ctx); err != nil {
+			return err
 		}
 
-		result[entry.Name] = json.RawMessage(jsonBytes)
+		ctx := *shared
+		ctx.rand = rand.New(rand.NewSource(opts.Seed ^ nameSeed(targets[i].Name)))
+		if example, ok := generateSchemaJSON(targets[i], &ctx); ok {
+			examples[i], truncations[i] = example, ctx.truncations
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	result := &Result{Examples: make(map[string]json.RawMessage), Truncations: make(map[string][]Truncation)}
+	for i, example := range examples {
+		if example != nil {
+			result.add(targets[i].Name, example, truncations[i])
+		}
+	}
 	return result, nil
 }
 
+// generateSchemaJSON generates and marshals the example for one top-level schema.
+// Schemas that fail to generate are skipped so one bad schema does not hide the rest.
+func generateSchemaJSON(entry *parser.SchemaEntry, ctx *ExampleContext) (json.RawMessage, bool) {
+	ctx.path = make([]string, 0)
+	ctx.fields = nil
+	ctx.depth = 0
+	ctx.schema = entry.Name
+	ctx.truncations = nil
+
+	value, err := generateExample(entry.Name, entry.Proxy, ctx)
+	if err != nil {
+		ctx.logger.Debug(internal.LogSchemaSkipped, "schema", entry.Name, "reason", err.Error())
+		return nil, false
+	}
+
+	jsonBytes, err := json.Marshal(value)
+	if err != nil {
+		ctx.logger.Debug(internal.LogSchemaSkipped, "schema", entry.Name, "reason", err.Error())
+		return nil, false
+	}
+
+	return json.RawMessage(jsonBytes), true
+}
+
+// nameSeed derives a stable per-schema seed component from the schema name
+func nameSeed(name string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+	return int64(h.Sum64())
+}
+
 // generateExample generates a JSON example for a single schema
 func generateExample(name string, proxy *base.SchemaProxy, ctx *ExampleContext) (interface{}, error) {
 	for _, p := range ctx.path {
 		if p == name {
-			return nil, nil
+			return ctx.truncate(ctx.componentName(name), TruncatedCircular), nil
 		}
 	}
 
 	if ctx.depth >= ctx.maxDepth {
-		return nil, nil
+		return ctx.truncateDepth(name, proxy.Schema()), nil
 	}
 
 	ctx.path = append(ctx.path, name)
@@ -88,6 +252,10 @@ func generateExample(name string, proxy *base.SchemaProxy, ctx *ExampleContext)
 		ctx.path = ctx.path[:len(ctx.path)-1]
 	}()
 
+	if entry, ok := ctx.schemas[name]; ok && entry.Proxy == proxy {
+		defer ctx.enterSchema(name)()
+	}
+
 	schema := proxy.Schema()
 	if schema == nil {
 		return nil, fmt.Errorf("schema %s is nil", name)
@@ -95,12 +263,12 @@ func generateExample(name string, proxy *base.SchemaProxy, ctx *ExampleContext)
 
 	// Check for schema-level example (highest priority for objects/arrays)
 	if schema.Example != nil {
-		return decodeYAMLNode(schema.Example)
+		return ctx.exampleValue(name, schema.Example)
 	}
 
 	// Check for schema-level examples array (OpenAPI 3.1 format)
 	if len(schema.Examples) > 0 && schema.Examples[0] != nil {
-		return decodeYAMLNode(schema.Examples[0])
+		return ctx.exampleValue(name, schema.Examples[0])
 	}
 
 	if proxy.IsReference() {
@@ -153,6 +321,13 @@ func generateExample(name string, proxy *base.SchemaProxy, ctx *ExampleContext)
 
 // generateScalarValue generates a value for a scalar type with constraints
 func generateScalarValue(fieldName string, schema *base.Schema, typ, format string, ctx *ExampleContext) (interface{}, error) {
+	// Field overrides come after example and default, unless they take precedence
+	if ctx.overrideFirst {
+		if value, ok, err := ctx.overrideValue(fieldName, schema, typ); ok || err != nil {
+			return value, err
+		}
+	}
+
 	if schema.Example != nil {
 		return extractYAMLNodeValue(schema.Example), nil
 	}
@@ -166,44 +341,9 @@ func generateScalarValue(fieldName string, schema *base.Schema, typ, format stri
 		return extractYAMLNodeValue(schema.Default), nil
 	}
 
-	// Check field overrides (after Example and Default, before type generation)
-	if ctx.fieldOverrides != nil {
-		if overrideValue, ok := ctx.fieldOverrides[fieldName]; ok {
-			// Validate type matches schema type
-			switch typ {
-			case "integer":
-				switch v := overrideValue.(type) {
-				case int:
-					return v, nil
-				case float64:
-					// JSON unmarshaling produces float64 for all numbers
-					if math.Mod(v, 1.0) == 0 {
-						return int(v), nil
-					}
-					return nil, fmt.Errorf("field override for '%s' has wrong type: expected integer, got float with decimal", fieldName)
-				default:
-					return nil, fmt.Errorf("field override for '%s' has wrong type: expected integer, got %T", fieldName, overrideValue)
-				}
-			case "number":
-				switch v := overrideValue.(type) {
-				case int:
-					return float64(v), nil
-				case float64:
-					return v, nil
-				default:
-					return nil, fmt.Errorf("field override for '%s' has wrong type: expected number, got %T", fieldName, overrideValue)
-				}
-			case "string":
-				if v, ok := overrideValue.(string); ok {
-					return v, nil
-				}
-				return nil, fmt.Errorf("field override for '%s' has wrong type: expected string, got %T", fieldName, overrideValue)
-			case "boolean":
-				if v, ok := overrideValue.(bool); ok {
-					return v, nil
-				}
-				return nil, fmt.Errorf("field override for '%s' has wrong type: expected boolean, got %T", fieldName, overrideValue)
-			}
+	if !ctx.overrideFirst {
+		if value, ok, err := ctx.overrideValue(fieldName, schema, typ); ok || err != nil {
+			return value, err
 		}
 	}
 
@@ -212,16 +352,20 @@ func generateScalarValue(fieldName string, schema *base.Schema, typ, format stri
 		min := 0
 		max := 100
 		if schema.Minimum != nil {
-			min = int(*schema.Minimum)
+			min = clampInt(*schema.Minimum)
 		}
 		if schema.Maximum != nil {
-			max = int(*schema.Maximum)
+			max = clampInt(*schema.Maximum)
 		}
 
 		if min > max {
 			return nil, fmt.Errorf("invalid schema: minimum > maximum")
 		}
 
+		if value, ok := ctx.edgeNumber(schema, true); ok {
+			return int(value), nil
+		}
+
 		if schema.Minimum != nil || schema.Maximum != nil {
 			return ctx.rand.Intn(max-min+1) + min, nil
 		}
@@ -241,12 +385,27 @@ func generateScalarValue(fieldName string, schema *base.Schema, typ, format stri
 			return nil, fmt.Errorf("invalid schema: minimum > maximum")
 		}
 
+		if value, ok := ctx.edgeNumber(schema, false); ok {
+			return value, nil
+		}
+
+		if internal.IsDecimal(schema) {
+			if value, ok := ctx.decimalNumber(schema); ok {
+				return value, nil
+			}
+		}
+
 		if schema.Minimum != nil || schema.Maximum != nil {
 			return ctx.rand.Float64()*(max-min) + min, nil
 		}
 		return ctx.rand.Float64()*99.0 + 1.0, nil
 
 	case "string":
+		if internal.IsDecimal(schema) && !ctx.servesFormat(format) {
+			if value, ok := ctx.decimalValue(schema); ok {
+				return value, nil
+			}
+		}
 		return generateStringValue(fieldName, schema, format, ctx)
 
 	case "boolean":
@@ -257,6 +416,109 @@ func generateScalarValue(fieldName string, schema *base.Schema, typ, format stri
 	}
 }
 
+// overrideValue returns the value FieldOverrides, or else ValueFunc, gives the
+// field being generated, when either does, checked against the schema type typ
+func (ctx *ExampleContext) overrideValue(fieldName string, schema *base.Schema, typ string) (interface{}, bool, error) {
+	if value, key, ok := ctx.override(fieldName); ok {
+		return checkedValue(value, typ, fmt.Sprintf("field override for '%s'", key))
+	}
+	if ctx.valueFunc != nil {
+		info := ctx.fieldInfo(fieldName, schema, typ)
+		if value, ok := ctx.valueFunc(ctx.schema, info.Path, info); ok {
+			return checkedValue(value, typ, fmt.Sprintf("ValueFunc value for '%s'", strings.TrimSuffix(ctx.schema+"."+info.Path, ".")))
+		}
+	}
+	return nil, false, nil
+}
+
+// checkedValue returns value, converted where JSON decoding would have changed
+// its type, when it suits the schema type typ; source names it in errors
+func checkedValue(value interface{}, typ, source string) (interface{}, bool, error) {
+	switch typ {
+	case "integer":
+		switch v := value.(type) {
+		case int:
+			return v, true, nil
+		case float64:
+			// JSON unmarshaling produces float64 for all numbers
+			if math.Mod(v, 1.0) == 0 {
+				return int(v), true, nil
+			}
+			return nil, true, fmt.Errorf("%s has wrong type: expected integer, got float with decimal", source)
+		default:
+			return nil, true, fmt.Errorf("%s has wrong type: expected integer, got %T", source, value)
+		}
+	case "number":
+		switch v := value.(type) {
+		case int:
+			return float64(v), true, nil
+		case float64:
+			return v, true, nil
+		default:
+			return nil, true, fmt.Errorf("%s has wrong type: expected number, got %T", source, value)
+		}
+	case "string":
+		if v, ok := value.(string); ok {
+			return v, true, nil
+		}
+		return nil, true, fmt.Errorf("%s has wrong type: expected string, got %T", source, value)
+	case "boolean":
+		if v, ok := value.(bool); ok {
+			return v, true, nil
+		}
+		return nil, true, fmt.Errorf("%s has wrong type: expected boolean, got %T", source, value)
+	}
+	return nil, false, nil
+}
+
+// exampleValue decodes an example given in the spec for the schema or property
+// name. When overrides take precedence, the values within it that a
+// FieldOverrides key matches are replaced, as given.
+func (ctx *ExampleContext) exampleValue(name string, node *yaml.Node) (interface{}, error) {
+	value, err := decodeYAMLNode(node)
+	if err != nil || !ctx.overrideFirst {
+		return value, err
+	}
+	return ctx.overrideWithin(name, value), nil
+}
+
+// overrideWithin replaces the scalars of value, found under name, that a
+// FieldOverrides key matches, descending into objects and arrays
+func (ctx *ExampleContext) overrideWithin(name string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			leave := ctx.enterProperty(key)
+			v[key] = ctx.overrideWithin(key, item)
+			leave()
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = ctx.overrideWithin(name, item)
+		}
+		return v
+	}
+	if override, _, ok := ctx.override(name); ok {
+		return override
+	}
+	return value
+}
+
+// formatTemplates are the example values of the string formats generation knows
+var formatTemplates = map[string]string{
+	"email":        "user@example.com",
+	"uuid":         "123e4567-e89b-12d3-a456-426614174000",
+	"uri":          "https://example.com",
+	"url":          "https://example.com",
+	"date":         "2024-01-15",
+	"date-time":    "2024-01-15T10:30:00Z",
+	"hostname":     "example.com",
+	"idn-hostname": "bücher.example",
+	"ipv4":         "192.0.2.1",
+	"ipv6":         "2001:db8::1",
+}
+
 // generateStringValue generates string value honoring format and length constraints
 func generateStringValue(fieldName string, schema *base.Schema, format string, ctx *ExampleContext) (string, error) {
 	var minLength int
@@ -273,44 +535,31 @@ func generateStringValue(fieldName string, schema *base.Schema, format string, c
 	if minLength > 0 && maxLength > 0 && minLength > maxLength {
 		return "", fmt.Errorf("invalid schema: minLength > maxLength")
 	}
-
-	lowerFieldName := strings.ToLower(fieldName)
-	if lowerFieldName == "cursor" || lowerFieldName == "first" || lowerFieldName == "after" {
-		const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789+/"
-		length := ctx.rand.Intn(17) + 16
-		result := make([]byte, length)
-		for i := range result {
-			result[i] = charset[ctx.rand.Intn(len(charset))]
-		}
-		return string(result), nil
+	if err := checkSize("minLength", minLength); err != nil {
+		return "", err
 	}
-
-	if lowerFieldName == "error" {
-		return "An error occurred", nil
+	maxLength = min(maxLength, maxGeneratedSize)
+	edgeLength, edge := ctx.edgeCount(schema.MinLength, schema.MaxLength)
+	if err := checkSize("maxLength", edgeLength); edge && err != nil {
+		return "", err
 	}
 
-	if lowerFieldName == "message" {
-		return "This is a message", nil
+	// A provider serving the format wins over the built-in template, which wins
+	// over a provider matched by field name only
+	template, known := formatTemplates[format]
+	if !known && !edge && (format == "byte" || format == "binary") {
+		template, known = ctx.base64Value(format, minLength, maxLength)
+	}
+	if p, ok := provider(ctx.providers, fieldName, format); ok && (!known || internal.Contains(p.Formats, format)) {
+		ctx.logger.Debug(internal.LogHeuristicApplied, "schema", ctx.schema, "field", fieldName, "heuristic", p.Name)
+		template, known = p.Generate(ctx.rand), true
 	}
 
-	var template string
-
-	switch format {
-	case "email":
-		template = "user@example.com"
-	case "uuid":
-		template = "123e4567-e89b-12d3-a456-426614174000"
-	case "uri", "url":
-		template = "https://example.com"
-	case "date":
-		template = "2024-01-15"
-	case "date-time":
-		template = "2024-01-15T10:30:00Z"
-	case "hostname":
-		template = "example.com"
-	default:
+	if !known {
 		length := 10
-		if minLength > 0 {
+		if edge {
+			length = edgeLength
+		} else if minLength > 0 {
 			if maxLength > 0 {
 				length = ctx.rand.Intn(maxLength-minLength+1) + minLength
 			} else {
@@ -328,18 +577,28 @@ func generateStringValue(fieldName string, schema *base.Schema, format string, c
 		return string(result), nil
 	}
 
-	if minLength > 0 && len(template) < minLength {
-		padding := minLength - len(template)
+	// Lengths count characters, as JSON Schema does, so values in other scripts
+	// are never cut mid-character
+	runes := []rune(template)
+	if edge {
+		for len(runes) < edgeLength {
+			runes = append(runes, 'x')
+		}
+		return string(runes[:edgeLength]), nil
+	}
+
+	if minLength > 0 && len(runes) < minLength {
+		padding := minLength - len(runes)
 		for i := 0; i < padding; i++ {
-			template += "x"
+			runes = append(runes, 'x')
 		}
 	}
 
-	if maxLength > 0 && len(template) > maxLength {
-		template = template[:maxLength]
+	if maxLength > 0 && len(runes) > maxLength {
+		runes = runes[:maxLength]
 	}
 
-	return template, nil
+	return string(runes), nil
 }
 
 // generateArrayExample generates example for array schema
@@ -360,8 +619,27 @@ func generateArrayExample(schema *base.Schema, propertyName string, ctx *Example
 			return nil, fmt.Errorf("invalid schema: minItems > maxItems")
 		}
 	}
+	if err := checkSize("minItems", minItems); err != nil {
+		return nil, err
+	}
+	maxItems = min(maxItems, maxGeneratedSize)
+
+	numItems := -1
+	// Boundary and invalid examples use an edge count, ahead of ArrayItems
+	if count, ok := ctx.edgeCount(schema.MinItems, schema.MaxItems); ok {
+		if err := checkSize("maxItems", count); err != nil {
+			return nil, err
+		}
+		numItems = count
+	}
 
 	if ctx.depth >= ctx.maxDepth {
+		ctx.truncate(itemsComponent(schema), TruncatedMaxDepth)
+		if ctx.required && ctx.requiredDepth == RequiredDepthStub {
+			if items, ok := stubValue(schema, map[*base.Schema]bool{}).([]interface{}); ok {
+				return items, nil
+			}
+		}
 		return []interface{}{}, nil
 	}
 
@@ -370,10 +648,8 @@ func generateArrayExample(schema *base.Schema, propertyName string, ctx *Example
 		ctx.depth--
 	}()
 
-	// Generate random number of items between minItems and maxItems
-	numItems := minItems
-	if maxItems > minItems {
-		numItems = ctx.rand.Intn(maxItems-minItems+1) + minItems
+	if numItems < 0 {
+		numItems = ctx.itemCount(schema, minItems, maxItems)
 	}
 
 	itemProxy := schema.Items.A
@@ -393,9 +669,9 @@ func generateArrayExample(schema *base.Schema, propertyName string, ctx *Example
 }
 
 // generateObjectExample generates example for object schema
-func generateObjectExample(schema *base.Schema, name string, ctx *ExampleContext) (map[string]interface{}, error) {
+func generateObjectExample(schema *base.Schema, name string, ctx *ExampleContext) (interface{}, error) {
 	if ctx.depth >= ctx.maxDepth {
-		return nil, nil
+		return ctx.truncateDepth(name, schema), nil
 	}
 
 	result := make(map[string]interface{})
@@ -407,13 +683,13 @@ func generateObjectExample(schema *base.Schema, name string, ctx *ExampleContext
 
 	if schema.Properties != nil {
 		for propName, propProxy := range schema.Properties.FromOldest() {
-			propValue, err := generatePropertyValue(propName, propProxy, ctx)
+			propValue, err := ctx.generateProperty(schema, propName, propProxy)
 			if err != nil {
 				return nil, err
 			}
 
 			if propValue != nil {
-				result[propName] = propValue
+				result[internal.ApplyJSONCase(propName, ctx.jsonCase)] = propValue
 			}
 		}
 	}
@@ -522,12 +798,12 @@ func generateAllOfExample(schema *base.Schema, name string, ctx *ExampleContext)
 		}()
 
 		for propName, propProxy := range schema.Properties.FromOldest() {
-			propValue, err := generatePropertyValue(propName, propProxy, ctx)
+			propValue, err := ctx.generateProperty(schema, propName, propProxy)
 			if err != nil {
 				return nil, err
 			}
 			if propValue != nil {
-				result[propName] = propValue
+				result[internal.ApplyJSONCase(propName, ctx.jsonCase)] = propValue
 			}
 		}
 	}
@@ -584,7 +860,7 @@ func generateFirstVariantExample(variants []*base.SchemaProxy, discriminator *ba
 	if discriminator != nil && discriminator.PropertyName != "" {
 		if resultMap, ok := result.(map[string]interface{}); ok {
 			discriminatorValue := resolveDiscriminatorValue(variant, discriminator)
-			resultMap[discriminator.PropertyName] = discriminatorValue
+			resultMap[internal.ApplyJSONCase(discriminator.PropertyName, ctx.jsonCase)] = discriminatorValue
 			return resultMap, nil
 		}
 	}
@@ -614,6 +890,16 @@ func resolveDiscriminatorValue(variant *base.SchemaProxy, discriminator *base.Di
 	return ""
 }
 
+// generateProperty generates property name of object schema, noting whether
+// schema requires it, which decides how RequiredDepth treats it
+func (ctx *ExampleContext) generateProperty(schema *base.Schema, name string, proxy *base.SchemaProxy) (interface{}, error) {
+	required := internal.Contains(schema.Required, name)
+	defer ctx.enterProperty(name)()
+	defer ctx.enterRequired(required)()
+	defer ctx.deepen(required)()
+	return generatePropertyValue(name, proxy, ctx)
+}
+
 // generatePropertyValue generates example value for object property
 func generatePropertyValue(propertyName string, propProxy *base.SchemaProxy, ctx *ExampleContext) (interface{}, error) {
 	schema := propProxy.Schema()
@@ -630,7 +916,7 @@ func generatePropertyValue(propertyName string, propProxy *base.SchemaProxy, ctx
 
 		for _, p := range ctx.path {
 			if p == refName {
-				return nil, nil
+				return ctx.truncate(refName, TruncatedCircular), nil
 			}
 		}
 
@@ -644,10 +930,10 @@ func generatePropertyValue(propertyName string, propProxy *base.SchemaProxy, ctx
 
 	// Check for explicit example on this property (for non-scalar types)
 	if schema.Example != nil {
-		return decodeYAMLNode(schema.Example)
+		return ctx.exampleValue(propertyName, schema.Example)
 	}
 	if len(schema.Examples) > 0 && schema.Examples[0] != nil {
-		return decodeYAMLNode(schema.Examples[0])
+		return ctx.exampleValue(propertyName, schema.Examples[0])
 	}
 
 	if len(schema.Type) > 0 && internal.Contains(schema.Type, "array") {
@@ -0,0 +1,33 @@
+package example
+
+import (
+	"fmt"
+	"math"
+)
+
+// maxGeneratedSize bounds the characters of generated strings and the items of
+// generated arrays, so a huge minLength or maxItems cannot exhaust memory
+const maxGeneratedSize = 1 << 20
+
+// maxGeneratedInt bounds generated integers to those a float64 holds exactly,
+// so the span between any minimum and maximum fits in an int
+const maxGeneratedInt = 1 << 53
+
+// checkSize reports an error when the schema requires more than
+// maxGeneratedSize characters or items, which no example within it can meet
+func checkSize(keyword string, size int) error {
+	if size > maxGeneratedSize {
+		return fmt.Errorf("invalid schema: %s %d exceeds the %d examples are limited to", keyword, size, maxGeneratedSize)
+	}
+	return nil
+}
+
+// clampSize bounds a length or item count to 0 through maxGeneratedSize
+func clampSize(size int64) int {
+	return int(min(max(size, 0), maxGeneratedSize))
+}
+
+// clampInt converts a minimum or maximum to an int within maxGeneratedInt
+func clampInt(value float64) int {
+	return int(math.Min(math.Max(value, -maxGeneratedInt), maxGeneratedInt))
+}
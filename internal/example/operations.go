@@ -0,0 +1,157 @@
+package example
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/duh-rpc/openapi-schema.go/internal/parser"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// OperationValue holds the generated parameters and bodies of one operation
+type OperationValue struct {
+	Parameters []*ParameterValue
+	Request    *BodyValue       // nil without a request body
+	Responses  []*ResponseValue // in document order, default last
+}
+
+// ResponseValue is the generated body of the response for one status code
+type ResponseValue struct {
+	Status string // status code, range such as "4XX", or "default"
+	Body   *BodyValue
+}
+
+// BodyValue is a generated request or response body
+type BodyValue struct {
+	MediaType string
+	Value     json.RawMessage
+}
+
+// GenerateOperation generates values for the parameters of op, its request body
+// and the body of each response that has content. Bodies use the first JSON
+// media type, or else the first media type. A media type's own example wins,
+// then its first named example, then a value generated from its schema.
+func GenerateOperation(entries []*parser.SchemaEntry, op *parser.OperationEntry, opts Options) (*OperationValue, error) {
+	ctx := newExampleContext(entries, opts)
+
+	params, err := generateParameters(op.Parameters, ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	result := &OperationValue{Parameters: params, Responses: []*ResponseValue{}}
+
+	if op.RequestBody != nil {
+		result.Request, err = generateBody(op.RequestBody.Content, op.OperationID, ctx)
+		if err != nil {
+			return nil, fmt.Errorf("request body: %w", err)
+		}
+	}
+
+	if op.Responses == nil {
+		return result, nil
+	}
+	responses := orderedmap.New[string, *v3.Response]()
+	if op.Responses.Codes != nil {
+		for status, resp := range op.Responses.Codes.FromOldest() {
+			responses.Set(status, resp)
+		}
+	}
+	if op.Responses.Default != nil {
+		responses.Set("default", op.Responses.Default)
+	}
+
+	for status, resp := range responses.FromOldest() {
+		if err := internal.Cancelled(opts.Ctx); err != nil {
+			return nil, err
+		}
+		if resp == nil {
+			continue
+		}
+
+		body, err := generateBody(resp.Content, op.OperationID, ctx)
+		if err != nil {
+			return nil, fmt.Errorf("response %s: %w", status, err)
+		}
+		if body != nil {
+			result.Responses = append(result.Responses, &ResponseValue{Status: status, Body: body})
+		}
+	}
+	return result, nil
+}
+
+// generateBody generates the body for the preferred media type of content, or
+// returns nil when content has none. Bodies referencing a component schema are
+// generated as that schema; inline ones are attributed to operationID.
+func generateBody(content *orderedmap.Map[string, *v3.MediaType], operationID string, ctx *ExampleContext) (*BodyValue, error) {
+	mediaType, media := preferredMedia(content)
+	if media == nil {
+		return nil, nil
+	}
+
+	ctx.path = make([]string, 0)
+	ctx.fields = nil
+	ctx.depth = 0
+	ctx.schema = operationID
+
+	value, err := mediaValue(media, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal value: %w", err)
+	}
+	return &BodyValue{MediaType: mediaType, Value: raw}, nil
+}
+
+// mediaValue returns the example value for media
+func mediaValue(media *v3.MediaType, ctx *ExampleContext) (interface{}, error) {
+	if media.Example != nil {
+		return decodeYAMLNode(media.Example)
+	}
+
+	if media.Examples != nil {
+		for _, example := range media.Examples.FromOldest() {
+			if example != nil && example.Value != nil {
+				return decodeYAMLNode(example.Value)
+			}
+		}
+	}
+
+	if media.Schema == nil {
+		return nil, fmt.Errorf("has no schema")
+	}
+
+	if media.Schema.IsReference() {
+		if name, err := internal.ExtractReferenceName(media.Schema.GetReference()); err == nil {
+			ctx.schema = name
+		}
+	}
+	return generatePropertyValue("body", media.Schema, ctx)
+}
+
+// preferredMedia returns the first JSON media type of content (application/json
+// or a +json suffix), or else its first media type
+func preferredMedia(content *orderedmap.Map[string, *v3.MediaType]) (string, *v3.MediaType) {
+	if content == nil {
+		return "", nil
+	}
+
+	var firstType string
+	var first *v3.MediaType
+	for mediaType, media := range content.FromOldest() {
+		if media == nil {
+			continue
+		}
+		if internal.IsJSONMediaType(mediaType) {
+			return mediaType, media
+		}
+		if first == nil {
+			firstType, first = mediaType, media
+		}
+	}
+	return firstType, first
+}
@@ -0,0 +1,151 @@
+package example_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const operationsSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /pets/{petId}:
+    parameters:
+      - name: petId
+        in: path
+        required: true
+        schema:
+          type: integer
+          minimum: 7
+          maximum: 7
+    put:
+      operationId: updatePet
+      parameters:
+        - name: dryRun
+          in: query
+          schema:
+            type: boolean
+            example: true
+      requestBody:
+        content:
+          text/plain:
+            schema:
+              type: string
+          application/merge-patch+json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '200':
+          description: Updated
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+        '204':
+          description: No content
+        '4XX':
+          description: Client error
+          content:
+            application/problem+json:
+              example:
+                title: Not Found
+                status: 404
+        default:
+          description: Error
+          content:
+            application/json:
+              examples:
+                first:
+                  value:
+                    message: failed
+              schema:
+                type: object
+    delete:
+      operationId: deletePet
+      responses:
+        '204':
+          description: Deleted
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+          example: Rex
+        tags:
+          type: array
+          minItems: 1
+          maxItems: 1
+          items:
+            type: string
+            enum: [good]
+`
+
+func TestConvertToOperationExamples(t *testing.T) {
+	result, err := schema.ConvertToOperationExamples([]byte(operationsSpec), schema.OperationExampleOptions{Seed: 1})
+	require.NoError(t, err)
+	require.Len(t, result.Operations, 2)
+
+	op := result.Operations[0]
+	assert.Equal(t, "updatePet", op.OperationID)
+	assert.Equal(t, "PUT", op.Method)
+	assert.Equal(t, "/pets/{petId}", op.Path)
+	assert.Equal(t, "/pets/7?dryRun=true", op.Parameters.URL)
+
+	require.NotNil(t, op.Request)
+	assert.Equal(t, "application/merge-patch+json", op.Request.MediaType)
+	assert.JSONEq(t, `{"name": "Rex", "tags": ["good"]}`, string(op.Request.Value))
+
+	require.Len(t, op.Responses, 3)
+	assert.Equal(t, "application/json", op.Responses["200"].MediaType)
+	assert.JSONEq(t, `{"name": "Rex", "tags": ["good"]}`, string(op.Responses["200"].Value))
+	assert.NotContains(t, op.Responses, "204")
+	assert.Equal(t, "application/problem+json", op.Responses["4XX"].MediaType)
+	assert.JSONEq(t, `{"title": "Not Found", "status": 404}`, string(op.Responses["4XX"].Value))
+	assert.JSONEq(t, `{"message": "failed"}`, string(op.Responses["default"].Value))
+
+	del := result.Operations[1]
+	assert.Equal(t, "/pets/7", del.Parameters.URL)
+	assert.Nil(t, del.Request)
+	assert.Empty(t, del.Responses)
+}
+
+func TestConvertToOperationExamplesOptions(t *testing.T) {
+	result, err := schema.ConvertToOperationExamples([]byte(operationsSpec), schema.OperationExampleOptions{
+		OperationIDs:       []string{"updatePet"},
+		Seed:               1,
+		FieldOverrides:     map[string]interface{}{"Pet.name": "Fido"},
+		OverridePrecedence: schema.OverridePrecedenceOverride,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Operations, 1)
+	assert.JSONEq(t, `{"name": "Fido", "tags": ["good"]}`, string(result.Operations[0].Request.Value))
+
+	_, err = schema.ConvertToOperationExamples([]byte(operationsSpec), schema.OperationExampleOptions{Mode: "negative"})
+	require.ErrorContains(t, err, `unknown Mode "negative"`)
+}
+
+func TestConvertToOperationExamplesMissingSchema(t *testing.T) {
+	openapi := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /pets:
+    post:
+      operationId: createPet
+      requestBody:
+        content:
+          application/json: {}
+      responses:
+        '200':
+          description: OK
+`
+	_, err := schema.ConvertToOperationExamples([]byte(openapi), schema.OperationExampleOptions{Seed: 1})
+	require.ErrorContains(t, err, "operation POST /pets: request body: has no schema")
+}
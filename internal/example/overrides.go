@@ -0,0 +1,188 @@
+package example
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// Precedence of FieldOverrides over the example and default values of the spec
+const (
+	OverridePrecedenceSchema   = "schema"   // the spec's example and default values win
+	OverridePrecedenceOverride = "override" // FieldOverrides win, within examples too
+)
+
+// override is a FieldOverrides key that is a path, such as "User.address.city",
+// or a wildcard pattern, such as "*.code" or "*Id"
+type override struct {
+	key      string
+	segments []string // the key split on dots; one segment for a field name pattern
+	wildcard bool     // the key holds pattern characters
+}
+
+// fieldSegment is one step of the path to the value being generated: a
+// component schema entered, or a property of the schema before it
+type fieldSegment struct {
+	name   string
+	schema bool
+}
+
+// ValueFunc computes the value of a scalar field of the top-level schema
+// schemaName, at fieldPath ("address.city", or "" for a scalar schema itself).
+// It returns false to leave the value to generation.
+type ValueFunc func(schemaName, fieldPath string, info FieldInfo) (interface{}, bool)
+
+// FieldInfo describes the scalar field a ValueFunc is asked for
+type FieldInfo struct {
+	Path   string // dotted property path from the top-level schema; "" for the schema itself
+	Field  string // the property name, or the schema name for a scalar schema
+	Schema string // the component schema declaring the property
+	Type   string // OpenAPI type: string, integer, number or boolean
+	Format string
+	// Description is the field's schema description
+	Description string
+}
+
+// ValidateOverrides rejects FieldOverrides keys with empty path segments or
+// malformed patterns
+func ValidateOverrides(overrides map[string]interface{}) error {
+	for key := range overrides {
+		for _, segment := range strings.Split(key, ".") {
+			if segment == "" {
+				return fmt.Errorf("invalid FieldOverrides key %q: empty path segment", key)
+			}
+			if _, err := path.Match(segment, ""); err != nil {
+				return fmt.Errorf("invalid FieldOverrides key %q: %w", key, err)
+			}
+		}
+	}
+	return nil
+}
+
+// parseOverrides returns the keys of overrides that are not plain field names,
+// in the order they are tried: paths before field name patterns, literal paths
+// before wildcard ones, and longer paths first
+func parseOverrides(overrides map[string]interface{}) []override {
+	var parsed []override
+	for key := range overrides {
+		o := override{key: key, segments: strings.Split(key, "."), wildcard: strings.ContainsAny(key, `*?[\`)}
+		if len(o.segments) > 1 || o.wildcard {
+			parsed = append(parsed, o)
+		}
+	}
+	sort.Slice(parsed, func(i, j int) bool {
+		a, b := parsed[i], parsed[j]
+		if (len(a.segments) > 1) != (len(b.segments) > 1) {
+			return len(a.segments) > 1
+		}
+		if a.wildcard != b.wildcard {
+			return !a.wildcard
+		}
+		if len(a.segments) != len(b.segments) {
+			return len(a.segments) > len(b.segments)
+		}
+		return a.key < b.key
+	})
+	return parsed
+}
+
+// override returns the FieldOverrides value for the field being generated and
+// the key that matched. A path key matches when it names the properties leading
+// to the field from any component schema entered on the way, so both
+// "User.address.city" and "Address.city" reach the city of a User's Address.
+// Paths win over a plain field name, which wins over a field name pattern.
+func (ctx *ExampleContext) override(fieldName string) (interface{}, string, bool) {
+	if len(ctx.fieldOverrides) == 0 {
+		return nil, "", false
+	}
+
+	for _, o := range ctx.overrides {
+		if len(o.segments) > 1 && ctx.matchesPath(o) {
+			return ctx.fieldOverrides[o.key], o.key, true
+		}
+	}
+	if value, ok := ctx.fieldOverrides[fieldName]; ok {
+		return value, fieldName, true
+	}
+	for _, o := range ctx.overrides {
+		if len(o.segments) == 1 {
+			if ok, _ := path.Match(o.key, fieldName); ok {
+				return ctx.fieldOverrides[o.key], o.key, true
+			}
+		}
+	}
+	return nil, "", false
+}
+
+// matchesPath reports whether o names the properties from one of the schemas
+// in ctx.fields to the field being generated
+func (ctx *ExampleContext) matchesPath(o override) bool {
+	for i, start := range ctx.fields {
+		if !start.schema {
+			continue
+		}
+		candidate := []string{start.name}
+		for _, segment := range ctx.fields[i+1:] {
+			if !segment.schema {
+				candidate = append(candidate, segment.name)
+			}
+		}
+		if matchSegments(o.segments, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSegments reports whether each pattern matches the name at its position
+func matchSegments(patterns, names []string) bool {
+	if len(patterns) != len(names) {
+		return false
+	}
+	for i, pattern := range patterns {
+		if ok, _ := path.Match(pattern, names[i]); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldInfo describes the scalar field being generated, of type typ and
+// described by schema
+func (ctx *ExampleContext) fieldInfo(fieldName string, schema *base.Schema, typ string) FieldInfo {
+	info := FieldInfo{Field: fieldName, Schema: ctx.schema, Type: typ, Format: schema.Format, Description: schema.Description}
+
+	var props []string
+	declaring := ctx.schema
+	for _, segment := range ctx.fields {
+		if segment.schema {
+			declaring = segment.name
+			continue
+		}
+		props = append(props, segment.name)
+		info.Field, info.Schema = segment.name, declaring
+	}
+	info.Path = strings.Join(props, ".")
+	return info
+}
+
+// enterSchema records that generation entered component schema name, returning
+// the function that leaves it
+func (ctx *ExampleContext) enterSchema(name string) func() {
+	return ctx.enter(fieldSegment{name: name, schema: true})
+}
+
+// enterProperty records that generation descended into property name of the
+// current schema, returning the function that leaves it
+func (ctx *ExampleContext) enterProperty(name string) func() {
+	return ctx.enter(fieldSegment{name: name})
+}
+
+// enter pushes segment onto ctx.fields, returning the function that pops it
+func (ctx *ExampleContext) enter(segment fieldSegment) func() {
+	ctx.fields = append(ctx.fields, segment)
+	return func() { ctx.fields = ctx.fields[:len(ctx.fields)-1] }
+}
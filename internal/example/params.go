@@ -0,0 +1,312 @@
+package example
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/duh-rpc/openapi-schema.go/internal/parser"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// ParameterValue is a generated parameter value and its serialized form
+type ParameterValue struct {
+	Name    string
+	In      string // path, query, header or cookie
+	Style   string // effective style, defaulted per location when the spec omits it
+	Explode bool   // effective explode, defaulted per style when the spec omits it
+	Value   json.RawMessage
+	// Serialized is the value rendered per Style and Explode. Styles that carry the
+	// parameter name (matrix, form, spaceDelimited, pipeDelimited, deepObject)
+	// include it, so query values can be joined with '&' as-is.
+	Serialized string
+}
+
+// GenerateParameters generates a value for each parameter in order. A parameter's
+// own example wins, then its first named example, then a value generated from its
+// schema (or its first content media type schema, serialized as JSON).
+func GenerateParameters(entries []*parser.SchemaEntry, params []*v3.Parameter, opts Options) ([]*ParameterValue, error) {
+	return generateParameters(params, newExampleContext(entries, opts), opts)
+}
+
+// generateParameters generates a value for each parameter in order
+func generateParameters(params []*v3.Parameter, ctx *ExampleContext, opts Options) ([]*ParameterValue, error) {
+	values := make([]*ParameterValue, 0, len(params))
+	for _, param := range params {
+		if err := internal.Cancelled(opts.Ctx); err != nil {
+			return nil, err
+		}
+
+		value, err := generateParameter(param, ctx)
+		if err != nil {
+			return nil, fmt.Errorf("parameter '%s' in %s: %w", param.Name, param.In, err)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// generateParameter picks and serializes the value for one parameter
+func generateParameter(param *v3.Parameter, ctx *ExampleContext) (*ParameterValue, error) {
+	ctx.path = make([]string, 0)
+	ctx.depth = 0
+	ctx.schema = param.Name
+
+	value, content, err := parameterValue(param, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal value: %w", err)
+	}
+
+	style, explode := parameterStyle(param)
+	result := &ParameterValue{
+		Name:    param.Name,
+		In:      param.In,
+		Style:   style,
+		Explode: explode,
+		Value:   raw,
+	}
+
+	// Content parameters are a single serialized document, not a styled value
+	if content {
+		value = string(raw)
+	}
+
+	result.Serialized, err = SerializeParameter(param.Name, param.In, style, explode, param.AllowReserved, value)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// parameterValue returns the example value for param and whether it came from
+// the parameter's content rather than its schema
+func parameterValue(param *v3.Parameter, ctx *ExampleContext) (interface{}, bool, error) {
+	content := param.Schema == nil && param.Content != nil && param.Content.Len() > 0
+
+	if param.Example != nil {
+		value, err := decodeYAMLNode(param.Example)
+		return value, content, err
+	}
+
+	if param.Examples != nil {
+		for _, example := range param.Examples.FromOldest() {
+			if example != nil && example.Value != nil {
+				value, err := decodeYAMLNode(example.Value)
+				return value, content, err
+			}
+		}
+	}
+
+	proxy := param.Schema
+	if content {
+		for _, media := range param.Content.FromOldest() {
+			if media != nil && media.Schema != nil {
+				proxy = media.Schema
+				break
+			}
+		}
+	}
+
+	if proxy == nil {
+		return nil, false, fmt.Errorf("has no schema")
+	}
+
+	value, err := generatePropertyValue(param.Name, proxy, ctx)
+	return value, content, err
+}
+
+// parameterStyle returns the effective style and explode, applying the defaults
+// from the OpenAPI specification when they are omitted
+func parameterStyle(param *v3.Parameter) (string, bool) {
+	style := param.Style
+	if style == "" {
+		switch param.In {
+		case "query", "cookie":
+			style = "form"
+		default:
+			style = "simple"
+		}
+	}
+
+	explode := style == "form"
+	if param.Explode != nil {
+		explode = *param.Explode
+	}
+	return style, explode
+}
+
+// SerializeParameter renders value per the OpenAPI style and explode rules.
+// Path values are percent-encoded as path segments and query values as query
+// components (reserved characters are left alone when allowReserved is set);
+// header and cookie values are not encoded.
+func SerializeParameter(name, in, style string, explode, allowReserved bool, value interface{}) (string, error) {
+	escape := func(s string) string { return s }
+	switch in {
+	case "path":
+		escape = url.PathEscape
+	case "query":
+		escape = func(s string) string { return queryEscape(s, allowReserved) }
+	}
+
+	switch v := value.(type) {
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = escape(scalarString(item))
+		}
+		return serializeArray(name, style, explode, items)
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		pairs := make([][2]string, len(keys))
+		for i, key := range keys {
+			pairs[i] = [2]string{escape(key), escape(scalarString(v[key]))}
+		}
+		return serializeObject(name, style, explode, pairs)
+	default:
+		return serializePrimitive(name, style, escape(scalarString(v)))
+	}
+}
+
+func serializePrimitive(name, style, value string) (string, error) {
+	switch style {
+	case "simple":
+		return value, nil
+	case "label":
+		return "." + value, nil
+	case "matrix":
+		return ";" + name + "=" + value, nil
+	case "form", "spaceDelimited", "pipeDelimited":
+		return name + "=" + value, nil
+	}
+	return "", fmt.Errorf("style '%s' cannot serialize a primitive value", style)
+}
+
+func serializeArray(name, style string, explode bool, items []string) (string, error) {
+	switch style {
+	case "simple":
+		return strings.Join(items, ","), nil
+	case "label":
+		if explode {
+			return "." + strings.Join(items, "."), nil
+		}
+		return "." + strings.Join(items, ","), nil
+	case "matrix":
+		if explode {
+			return prefixEach(";"+name+"=", items, ""), nil
+		}
+		return ";" + name + "=" + strings.Join(items, ","), nil
+	case "form":
+		if explode {
+			return prefixEach(name+"=", items, "&"), nil
+		}
+		return name + "=" + strings.Join(items, ","), nil
+	case "spaceDelimited":
+		if explode {
+			return prefixEach(name+"=", items, "&"), nil
+		}
+		return name + "=" + strings.Join(items, "%20"), nil
+	case "pipeDelimited":
+		if explode {
+			return prefixEach(name+"=", items, "&"), nil
+		}
+		return name + "=" + strings.Join(items, "|"), nil
+	}
+	return "", fmt.Errorf("style '%s' cannot serialize an array value", style)
+}
+
+func serializeObject(name, style string, explode bool, pairs [][2]string) (string, error) {
+	flat := make([]string, 0, len(pairs)*2)
+	assigned := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		flat = append(flat, pair[0], pair[1])
+		assigned = append(assigned, pair[0]+"="+pair[1])
+	}
+
+	switch style {
+	case "simple":
+		if explode {
+			return strings.Join(assigned, ","), nil
+		}
+		return strings.Join(flat, ","), nil
+	case "label":
+		if explode {
+			return "." + strings.Join(assigned, "."), nil
+		}
+		return "." + strings.Join(flat, ","), nil
+	case "matrix":
+		if explode {
+			return prefixEach(";", assigned, ""), nil
+		}
+		return ";" + name + "=" + strings.Join(flat, ","), nil
+	case "form":
+		if explode {
+			return strings.Join(assigned, "&"), nil
+		}
+		return name + "=" + strings.Join(flat, ","), nil
+	case "spaceDelimited":
+		return name + "=" + strings.Join(flat, "%20"), nil
+	case "pipeDelimited":
+		return name + "=" + strings.Join(flat, "|"), nil
+	case "deepObject":
+		parts := make([]string, len(pairs))
+		for i, pair := range pairs {
+			parts[i] = name + "[" + pair[0] + "]=" + pair[1]
+		}
+		return strings.Join(parts, "&"), nil
+	}
+	return "", fmt.Errorf("style '%s' cannot serialize an object value", style)
+}
+
+// prefixEach concatenates prefix+item for every item, separated by sep
+func prefixEach(prefix string, items []string, sep string) string {
+	parts := make([]string, len(items))
+	for i, item := range items {
+		parts[i] = prefix + item
+	}
+	return strings.Join(parts, sep)
+}
+
+// scalarString renders a value as it appears inside a serialized parameter.
+// Strings are used verbatim; everything else, including nested structures that
+// styles cannot express, is rendered as JSON.
+func scalarString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(raw)
+}
+
+// queryEscape percent-encodes a query component using %20 for spaces. With
+// allowReserved, RFC 3986 reserved characters are left as-is.
+func queryEscape(s string, allowReserved bool) string {
+	escaped := strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+	if !allowReserved {
+		return escaped
+	}
+
+	for _, c := range ":/?#[]@!$&'()*+,;=" {
+		escaped = strings.ReplaceAll(escaped, url.QueryEscape(string(c)), string(c))
+	}
+	return escaped
+}
@@ -0,0 +1,161 @@
+package example_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/duh-rpc/openapi-schema.go/internal/example"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSerializeParameterStyles(t *testing.T) {
+	array := []interface{}{3, 4, 5}
+	object := map[string]interface{}{"role": "admin", "firstName": "Alex"}
+
+	for _, test := range []struct {
+		name     string
+		in       string
+		style    string
+		explode  bool
+		value    interface{}
+		expected string
+	}{
+		{name: "simple primitive", in: "path", style: "simple", value: 5, expected: "5"},
+		{name: "simple array", in: "path", style: "simple", value: array, expected: "3,4,5"},
+		{name: "simple object", in: "path", style: "simple", value: object, expected: "firstName,Alex,role,admin"},
+		{name: "simple object explode", in: "path", style: "simple", explode: true, value: object, expected: "firstName=Alex,role=admin"},
+		{name: "label primitive", in: "path", style: "label", value: 5, expected: ".5"},
+		{name: "label array", in: "path", style: "label", value: array, expected: ".3,4,5"},
+		{name: "label array explode", in: "path", style: "label", explode: true, value: array, expected: ".3.4.5"},
+		{name: "label object explode", in: "path", style: "label", explode: true, value: object, expected: ".firstName=Alex.role=admin"},
+		{name: "matrix primitive", in: "path", style: "matrix", value: 5, expected: ";id=5"},
+		{name: "matrix array", in: "path", style: "matrix", value: array, expected: ";id=3,4,5"},
+		{name: "matrix array explode", in: "path", style: "matrix", explode: true, value: array, expected: ";id=3;id=4;id=5"},
+		{name: "matrix object", in: "path", style: "matrix", value: object, expected: ";id=firstName,Alex,role,admin"},
+		{name: "matrix object explode", in: "path", style: "matrix", explode: true, value: object, expected: ";firstName=Alex;role=admin"},
+		{name: "form primitive", in: "query", style: "form", explode: true, value: "blue", expected: "id=blue"},
+		{name: "form array", in: "query", style: "form", value: array, expected: "id=3,4,5"},
+		{name: "form array explode", in: "query", style: "form", explode: true, value: array, expected: "id=3&id=4&id=5"},
+		{name: "form object", in: "query", style: "form", value: object, expected: "id=firstName,Alex,role,admin"},
+		{name: "form object explode", in: "query", style: "form", explode: true, value: object, expected: "firstName=Alex&role=admin"},
+		{name: "spaceDelimited array", in: "query", style: "spaceDelimited", value: array, expected: "id=3%204%205"},
+		{name: "pipeDelimited array", in: "query", style: "pipeDelimited", value: array, expected: "id=3|4|5"},
+		{name: "deepObject", in: "query", style: "deepObject", explode: true, value: object, expected: "id[firstName]=Alex&id[role]=admin"},
+		{name: "query escaping", in: "query", style: "form", explode: true, value: "a b&c", expected: "id=a%20b%26c"},
+		{name: "path escaping", in: "path", style: "simple", value: "a/b c", expected: "a%2Fb%20c"},
+		{name: "header not escaped", in: "header", style: "simple", value: "a b", expected: "a b"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := example.SerializeParameter("id", test.in, test.style, test.explode, false, test.value)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+
+	_, err := example.SerializeParameter("id", "query", "deepObject", true, false, 5)
+	require.ErrorContains(t, err, "style 'deepObject' cannot serialize a primitive value")
+
+	actual, err := example.SerializeParameter("id", "query", "form", true, true, "a/b?c")
+	require.NoError(t, err)
+	assert.Equal(t, "id=a/b?c", actual)
+}
+
+func TestGenerateParameterExamples(t *testing.T) {
+	openapi := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /pets/{petId}:
+    parameters:
+      - name: petId
+        in: path
+        required: true
+        schema:
+          type: integer
+          minimum: 7
+          maximum: 7
+      - name: X-Trace
+        in: header
+        schema:
+          type: string
+    get:
+      operationId: getPet
+      parameters:
+        - name: X-Trace
+          in: header
+          example: trace-1
+        - name: fields
+          in: query
+          schema:
+            type: array
+            items:
+              $ref: '#/components/schemas/Field'
+        - name: filter
+          in: query
+          style: deepObject
+          explode: true
+          schema:
+            type: object
+            properties:
+              color:
+                type: string
+                example: brown
+        - name: session
+          in: cookie
+          schema:
+            type: string
+            default: abc
+        - name: meta
+          in: query
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  tag:
+                    type: string
+                    enum: [x]
+    delete:
+      operationId: deletePet
+components:
+  schemas:
+    Field:
+      type: string
+      enum: [name]
+`
+	result, err := schema.GenerateParameterExamples([]byte(openapi), schema.ParameterExampleOptions{Seed: 1})
+	require.NoError(t, err)
+	require.Len(t, result.Operations, 2)
+
+	op := result.Operations[0]
+	assert.Equal(t, "getPet", op.OperationID)
+	assert.Equal(t, "GET", op.Method)
+	assert.Equal(t, "/pets/{petId}", op.Path)
+	assert.Equal(t, "/pets/7?fields=name&filter[color]=brown&meta=%7B%22tag%22%3A%22x%22%7D", op.URL)
+	assert.Equal(t, map[string]string{"X-Trace": "trace-1"}, op.Headers)
+	assert.Equal(t, "session=abc", op.Cookie)
+
+	require.Len(t, op.Parameters, 6)
+	assert.Equal(t, "petId", op.Parameters[0].Name)
+	assert.Equal(t, "simple", op.Parameters[0].Style)
+	assert.False(t, op.Parameters[0].Explode)
+	assert.JSONEq(t, "7", string(op.Parameters[0].Value))
+	assert.Equal(t, "fields", op.Parameters[2].Name)
+	assert.Equal(t, "form", op.Parameters[2].Style)
+	assert.True(t, op.Parameters[2].Explode)
+	assert.JSONEq(t, `{"tag": "x"}`, string(op.Parameters[5].Value))
+
+	del := result.Operations[1]
+	assert.Equal(t, "DELETE", del.Method)
+	assert.Equal(t, "/pets/7", del.URL)
+
+	filtered, err := schema.GenerateParameterExamples([]byte(openapi), schema.ParameterExampleOptions{
+		OperationIDs: []string{"deletePet"},
+		Seed:         1,
+	})
+	require.NoError(t, err)
+	require.Len(t, filtered.Operations, 1)
+	assert.Equal(t, "deletePet", filtered.Operations[0].OperationID)
+}
@@ -0,0 +1,238 @@
+package example
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+)
+
+// Provider generates realistic values for string fields it recognises, by field
+// name or by format
+type Provider struct {
+	// Name identifies the provider in errors and in heuristic log events
+	Name string
+	// Fields lists the field names the provider serves, compared ignoring case,
+	// underscores and hyphens (phoneNumber matches phone_number)
+	Fields []string
+	// Formats lists the string formats the provider serves. A format match is
+	// preferred to a field name match, and to the built-in format templates.
+	Formats []string
+	// Generate returns a value, drawing any randomness from r so output stays
+	// deterministic for a seed. minLength and maxLength still apply to it.
+	Generate func(r *rand.Rand) string
+}
+
+// ValidateProviders rejects providers without a name, a generator or anything
+// to match
+func ValidateProviders(providers []Provider) error {
+	for i, p := range providers {
+		if p.Name == "" {
+			return fmt.Errorf("example provider %d must set Name", i)
+		}
+		if p.Generate == nil {
+			return fmt.Errorf("example provider '%s' must set Generate", p.Name)
+		}
+		if len(p.Fields) == 0 && len(p.Formats) == 0 {
+			return fmt.Errorf("example provider '%s' must set Fields or Formats", p.Name)
+		}
+	}
+	return nil
+}
+
+// DefaultProviders returns the providers always consulted, after any configured:
+// the cursor, error and message heuristics
+func DefaultProviders() []Provider {
+	return []Provider{
+		{Name: "cursor token", Fields: []string{"cursor", "first", "after"}, Generate: cursorToken},
+		{Name: "error message", Fields: []string{"error"}, Generate: constant("An error occurred")},
+		{Name: "message text", Fields: []string{"message"}, Generate: constant("This is a message")},
+	}
+}
+
+// Locales LocaleProviders has fake data for
+const (
+	LocaleEnUS = "en-US"
+	LocaleDeDE = "de-DE"
+	LocaleJaJP = "ja-JP"
+)
+
+// FakeDataProviders returns providers of realistic en-US fake data, as
+// LocaleProviders does
+func FakeDataProviders() []Provider {
+	return LocaleProviders(LocaleEnUS)
+}
+
+// LocaleProviders returns providers of realistic fake data following locale
+// (LocaleEnUS when unknown): person names, street addresses, cities, postal
+// codes, phone numbers, ISO 3166 country codes, ISO 4217 currency codes, dates
+// for display and lorem ipsum text
+func LocaleProviders(locale string) []Provider {
+	data, ok := locales[locale]
+	if !ok {
+		data = locales[LocaleEnUS]
+	}
+	return []Provider{
+		{Name: "first name", Fields: []string{"firstName", "givenName"}, Generate: pick(data.firstNames...)},
+		{Name: "last name", Fields: []string{"lastName", "familyName", "surname"}, Generate: pick(data.lastNames...)},
+		{Name: "full name", Fields: []string{"name", "fullName", "displayName"}, Generate: data.fullName},
+		{Name: "street address", Fields: []string{"street", "streetAddress", "address", "addressLine1"}, Generate: data.streetAddress},
+		{Name: "city", Fields: []string{"city", "town"}, Generate: pick(data.cities...)},
+		{Name: "postal code", Fields: []string{"postalCode", "postcode", "zip", "zipCode"}, Generate: data.postalCode},
+		{Name: "phone number", Fields: []string{"phone", "phoneNumber", "mobile", "telephone"}, Formats: []string{"phone"}, Generate: data.phoneNumber},
+		{Name: "country code", Fields: []string{"country", "countryCode"}, Generate: constant(data.country)},
+		{Name: "currency code", Fields: []string{"currency", "currencyCode"}, Generate: constant(data.currency)},
+		// format: date and date-time values stay RFC 3339, as the formats require
+		{Name: "display date", Fields: []string{"date", "birthday", "birthDate", "dateOfBirth", "displayDate"},
+			Generate: constant(exampleDate.Format(data.dateLayout))},
+		{Name: "lorem text", Fields: []string{"description", "summary", "comment", "notes", "bio", "content"}, Generate: lorem},
+	}
+}
+
+// provider returns the first provider serving format, or else the first serving
+// fieldName
+func provider(providers []Provider, fieldName, format string) (Provider, bool) {
+	if format != "" {
+		for _, p := range providers {
+			if internal.Contains(p.Formats, format) {
+				return p, true
+			}
+		}
+	}
+	folded := internal.FoldName(fieldName)
+	for _, p := range providers {
+		for _, field := range p.Fields {
+			if internal.FoldName(field) == folded {
+				return p, true
+			}
+		}
+	}
+	return Provider{}, false
+}
+
+// locale holds the fake data of one locale
+type locale struct {
+	firstNames []string
+	lastNames  []string
+	streets    []string
+	cities     []string
+	country    string // ISO 3166-1 alpha-2
+	currency   string // ISO 4217
+	dateLayout string // time layout of dates for display
+	// familyFirst writes full names family name first
+	familyFirst bool
+	// address formats a street address from a house number and street
+	address func(r *rand.Rand, street string) string
+	// postalCode and phoneNumber generate values in the locale's formats
+	postalCode  func(r *rand.Rand) string
+	phoneNumber func(r *rand.Rand) string
+}
+
+// exampleDate is the date display dates show, matching the format: date template
+var exampleDate = time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)
+
+var locales = map[string]locale{
+	LocaleEnUS: {
+		firstNames: []string{"Ada", "Grace", "Alan", "Linus", "Margaret", "Dennis", "Barbara", "Ken"},
+		lastNames:  []string{"Lovelace", "Hopper", "Turing", "Torvalds", "Hamilton", "Ritchie", "Liskov", "Thompson"},
+		streets:    []string{"Main Street", "Oak Avenue", "Maple Road", "Elm Lane", "Park Boulevard", "Cedar Drive"},
+		cities:     []string{"Springfield", "Portland", "Austin", "Denver", "Boston", "Seattle"},
+		country:    "US",
+		currency:   "USD",
+		dateLayout: "01/02/2006",
+		address: func(r *rand.Rand, street string) string {
+			return fmt.Sprintf("%d %s", r.Intn(999)+1, street)
+		},
+		postalCode: digits(5),
+		// 555-01xx numbers are reserved for fiction
+		phoneNumber: func(r *rand.Rand) string { return "+1 202-555-01" + digits(2)(r) },
+	},
+	LocaleDeDE: {
+		firstNames: []string{"Anna", "Lukas", "Leonie", "Felix", "Marie", "Jonas", "Sophie", "Paul"},
+		lastNames:  []string{"Müller", "Schmidt", "Schneider", "Fischer", "Weber", "Meyer", "Wagner", "Becker"},
+		streets:    []string{"Hauptstraße", "Bahnhofstraße", "Gartenweg", "Schulstraße", "Lindenallee", "Bergstraße"},
+		cities:     []string{"Berlin", "München", "Hamburg", "Köln", "Leipzig", "Dresden"},
+		country:    "DE",
+		currency:   "EUR",
+		dateLayout: "02.01.2006",
+		address: func(r *rand.Rand, street string) string {
+			return fmt.Sprintf("%s %d", street, r.Intn(99)+1)
+		},
+		postalCode:  digits(5),
+		phoneNumber: func(r *rand.Rand) string { return "+49 30 " + digits(7)(r) },
+	},
+	LocaleJaJP: {
+		firstNames:  []string{"太郎", "花子", "健", "美咲", "翔", "さくら", "大輔", "陽菜"},
+		lastNames:   []string{"佐藤", "鈴木", "高橋", "田中", "伊藤", "渡辺", "山本", "中村"},
+		streets:     []string{"丸の内", "銀座", "梅田", "栄", "天神", "中央"},
+		cities:      []string{"東京都千代田区", "大阪府大阪市", "京都府京都市", "神奈川県横浜市", "愛知県名古屋市", "福岡県福岡市"},
+		country:     "JP",
+		currency:    "JPY",
+		dateLayout:  "2006/01/02",
+		familyFirst: true,
+		address: func(r *rand.Rand, street string) string {
+			return fmt.Sprintf("%s%d-%d-%d", street, r.Intn(9)+1, r.Intn(20)+1, r.Intn(30)+1)
+		},
+		postalCode:  func(r *rand.Rand) string { return digits(3)(r) + "-" + digits(4)(r) },
+		phoneNumber: func(r *rand.Rand) string { return "+81 3-" + digits(4)(r) + "-" + digits(4)(r) },
+	},
+}
+
+var loremWords = strings.Fields("lorem ipsum dolor sit amet consectetur adipiscing elit sed do eiusmod tempor incididunt ut labore et dolore magna aliqua")
+
+// constant returns a generator always returning value
+func constant(value string) func(*rand.Rand) string {
+	return func(*rand.Rand) string { return value }
+}
+
+// pick returns a generator choosing one of values
+func pick(values ...string) func(*rand.Rand) string {
+	return func(r *rand.Rand) string { return values[r.Intn(len(values))] }
+}
+
+// digits returns a generator of n random decimal digits
+func digits(n int) func(*rand.Rand) string {
+	return func(r *rand.Rand) string {
+		result := make([]byte, n)
+		for i := range result {
+			result[i] = byte('0' + r.Intn(10))
+		}
+		return string(result)
+	}
+}
+
+// cursorToken returns an opaque pagination token of 16 to 32 base64 characters
+func cursorToken(r *rand.Rand) string {
+	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789+/"
+	result := make([]byte, r.Intn(17)+16)
+	for i := range result {
+		result[i] = charset[r.Intn(len(charset))]
+	}
+	return string(result)
+}
+
+// fullName returns a first and last name, in the locale's order
+func (l locale) fullName(r *rand.Rand) string {
+	first, last := pick(l.firstNames...)(r), pick(l.lastNames...)(r)
+	if l.familyFirst {
+		return last + " " + first
+	}
+	return first + " " + last
+}
+
+// streetAddress returns a street and house number, in the locale's order
+func (l locale) streetAddress(r *rand.Rand) string {
+	return l.address(r, pick(l.streets...)(r))
+}
+
+// lorem returns a sentence of 8 to 15 lorem ipsum words
+func lorem(r *rand.Rand) string {
+	words := make([]string, r.Intn(8)+8)
+	for i := range words {
+		words[i] = loremWords[r.Intn(len(loremWords))]
+	}
+	sentence := strings.Join(words, " ")
+	return strings.ToUpper(sentence[:1]) + sentence[1:] + "."
+}
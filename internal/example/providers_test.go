@@ -0,0 +1,249 @@
+package example_test
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const providersSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Customer:
+      type: object
+      properties:
+        full_name:
+          type: string
+        phoneNumber:
+          type: string
+        country-code:
+          type: string
+        currency:
+          type: string
+        bio:
+          type: string
+        contact:
+          type: string
+          format: phone
+        email:
+          type: string
+          format: email
+        zip:
+          type: string
+          maxLength: 3
+        cursor:
+          type: string
+        nickname:
+          type: string
+`
+
+func TestConvertToExamplesFakeDataProviders(t *testing.T) {
+	result, err := schema.ConvertToExamples([]byte(providersSpec), schema.ExampleOptions{
+		SchemaNames: []string{"Customer"},
+		Seed:        42,
+		Providers:   schema.FakeDataProviders(),
+	})
+	require.NoError(t, err)
+
+	var value map[string]interface{}
+	require.NoError(t, json.Unmarshal(result.Examples["Customer"], &value))
+
+	assert.Regexp(t, `^[A-Z][a-z]+ [A-Z][a-z]+$`, value["full_name"])
+	assert.Regexp(t, `^\+1 202-555-01\d{2}$`, value["phoneNumber"])
+	assert.Regexp(t, `^\+1 202-555-01\d{2}$`, value["contact"])
+	assert.Equal(t, "US", value["country-code"])
+	assert.Equal(t, "USD", value["currency"])
+	assert.Regexp(t, `^[A-Z][a-z ]+\.$`, value["bio"])
+	assert.Equal(t, "user@example.com", value["email"])
+	assert.Regexp(t, `^\d{3}$`, value["zip"])
+	assert.Regexp(t, `^[A-Za-z0-9+/]{16,32}$`, value["cursor"])
+	assert.Len(t, value["nickname"], 10)
+}
+
+func TestConvertToExamplesLocale(t *testing.T) {
+	spec := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Customer:
+      type: object
+      properties:
+        name:
+          type: string
+        street:
+          type: string
+        postalCode:
+          type: string
+        phone:
+          type: string
+        country:
+          type: string
+        currency:
+          type: string
+        birthday:
+          type: string
+        createdAt:
+          type: string
+          format: date
+        city:
+          type: string
+          maxLength: 3
+`
+	for _, test := range []struct {
+		name     string
+		locale   schema.Locale
+		patterns map[string]string
+	}{
+		{
+			name:   "en-US",
+			locale: schema.LocaleEnUS,
+			patterns: map[string]string{
+				"name":       `^[A-Z][a-z]+ [A-Z][a-z]+$`,
+				"street":     `^\d+ [A-Z][a-z]+ [A-Z][a-z]+$`,
+				"postalCode": `^\d{5}$`,
+				"phone":      `^\+1 202-555-01\d{2}$`,
+				"country":    `^US$`,
+				"currency":   `^USD$`,
+				"birthday":   `^01/15/2024$`,
+				"createdAt":  `^2024-01-15$`,
+				"city":       `^[A-Z][a-z]{2}$`,
+			},
+		},
+		{
+			name:   "de-DE",
+			locale: schema.LocaleDeDE,
+			patterns: map[string]string{
+				"name":       `^[A-Z]\pL+ [A-Z]\pL+$`,
+				"street":     `^[A-Z]\pL+ \d+$`,
+				"postalCode": `^\d{5}$`,
+				"phone":      `^\+49 30 \d{7}$`,
+				"country":    `^DE$`,
+				"currency":   `^EUR$`,
+				"birthday":   `^15\.01\.2024$`,
+				"createdAt":  `^2024-01-15$`,
+				"city":       `^[A-Z]\pL{2}$`,
+			},
+		},
+		{
+			name:   "ja-JP",
+			locale: schema.LocaleJaJP,
+			patterns: map[string]string{
+				"name":       `^\p{Han}+ [\p{Han}\p{Hiragana}]+$`,
+				"street":     `^\p{Han}+[\p{Han}\p{Hiragana}]*\d-\d+-\d+$`,
+				"postalCode": `^\d{3}-\d{4}$`,
+				"phone":      `^\+81 3-\d{4}-\d{4}$`,
+				"country":    `^JP$`,
+				"currency":   `^JPY$`,
+				"birthday":   `^2024/01/15$`,
+				"createdAt":  `^2024-01-15$`,
+				"city":       `^\p{Han}{3}$`,
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := schema.ConvertToExamples([]byte(spec), schema.ExampleOptions{
+				SchemaNames: []string{"Customer"},
+				Seed:        42,
+				Locale:      test.locale,
+			})
+			require.NoError(t, err)
+
+			var value map[string]interface{}
+			require.NoError(t, json.Unmarshal(result.Examples["Customer"], &value))
+			for field, pattern := range test.patterns {
+				assert.Regexp(t, pattern, value[field])
+			}
+		})
+	}
+}
+
+func TestConvertToExamplesInvalidLocale(t *testing.T) {
+	_, err := schema.ConvertToExamples([]byte(providersSpec), schema.ExampleOptions{
+		IncludeAll: true,
+		Locale:     "fr-FR",
+	})
+	require.ErrorContains(t, err, `unknown Locale "fr-FR": must be en-US, de-DE or ja-JP`)
+}
+
+func TestConvertToExamplesCustomProvider(t *testing.T) {
+	providers := append(schema.FakeDataProviders(),
+		schema.ExampleProvider{
+			Name:     "nickname",
+			Fields:   []string{"nickname"},
+			Generate: func(*rand.Rand) string { return "Ace" },
+		},
+		schema.ExampleProvider{
+			Name:     "email",
+			Formats:  []string{"email"},
+			Generate: func(*rand.Rand) string { return "customer@shop.test" },
+		},
+	)
+
+	result, err := schema.ConvertToExamples([]byte(providersSpec), schema.ExampleOptions{
+		SchemaNames: []string{"Customer"},
+		Seed:        42,
+		Providers:   providers,
+	})
+	require.NoError(t, err)
+
+	var value map[string]interface{}
+	require.NoError(t, json.Unmarshal(result.Examples["Customer"], &value))
+	assert.Equal(t, "Ace", value["nickname"])
+	assert.Equal(t, "customer@shop.test", value["email"])
+	assert.Regexp(t, `^[A-Z][a-z]+ [A-Z][a-z]+$`, value["full_name"])
+}
+
+func TestConvertToExamplesProvidersDeterministic(t *testing.T) {
+	opts := schema.ExampleOptions{
+		SchemaNames: []string{"Customer"},
+		Seed:        7,
+		Providers:   schema.FakeDataProviders(),
+	}
+
+	first, err := schema.ConvertToExamples([]byte(providersSpec), opts)
+	require.NoError(t, err)
+	second, err := schema.ConvertToExamples([]byte(providersSpec), opts)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(first.Examples["Customer"]), string(second.Examples["Customer"]))
+}
+
+func TestConvertToExamplesInvalidProvider(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		provider schema.ExampleProvider
+		wantErr  string
+	}{
+		{
+			name:     "no name",
+			provider: schema.ExampleProvider{Fields: []string{"a"}, Generate: func(*rand.Rand) string { return "" }},
+			wantErr:  "example provider 0 must set Name",
+		},
+		{
+			name:     "no generate",
+			provider: schema.ExampleProvider{Name: "a", Fields: []string{"a"}},
+			wantErr:  "example provider 'a' must set Generate",
+		},
+		{
+			name:     "nothing to match",
+			provider: schema.ExampleProvider{Name: "a", Generate: func(*rand.Rand) string { return "" }},
+			wantErr:  "example provider 'a' must set Fields or Formats",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := schema.ConvertToExamples([]byte(providersSpec), schema.ExampleOptions{
+				IncludeAll: true,
+				Providers:  []schema.ExampleProvider{test.provider},
+			})
+			require.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
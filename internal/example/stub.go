@@ -0,0 +1,141 @@
+package example
+
+import (
+	"strings"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// Required depth strategies: what becomes of required properties past MaxDepth
+const (
+	RequiredDepthDrop   = "drop"   // cut short like any other property
+	RequiredDepthExtend = "extend" // generated past MaxDepth, for required properties only
+	RequiredDepthStub   = "stub"   // replaced by the smallest value their schema allows
+)
+
+// deepen lets the required property about to be generated pass MaxDepth under
+// RequiredDepthExtend, returning the function that restores the limit. Only
+// required properties reach past it, as optional ones below are cut as usual.
+func (ctx *ExampleContext) deepen(required bool) func() {
+	if !required || ctx.requiredDepth != RequiredDepthExtend || ctx.depth < ctx.maxDepth {
+		return func() {}
+	}
+	maxDepth := ctx.maxDepth
+	ctx.maxDepth = ctx.depth + 1
+	return func() { ctx.maxDepth = maxDepth }
+}
+
+// enterRequired records whether the property about to be generated is
+// required, returning the function that restores the previous state
+func (ctx *ExampleContext) enterRequired(required bool) func() {
+	previous := ctx.required
+	ctx.required = required
+	return func() { ctx.required = previous }
+}
+
+// truncateDepth records that MaxDepth cut schema, the component name, short,
+// returning a stub when the value is required under RequiredDepthStub, and
+// else what truncate puts in its place
+func (ctx *ExampleContext) truncateDepth(name string, schema *base.Schema) interface{} {
+	value := ctx.truncate(ctx.componentName(name), TruncatedMaxDepth)
+	if ctx.required && ctx.requiredDepth == RequiredDepthStub && schema != nil {
+		return stubValue(schema, map[*base.Schema]bool{})
+	}
+	return value
+}
+
+// stubValue returns the smallest value schema allows: its example or default,
+// the first enum value, objects of their required properties only, arrays of
+// minItems items, strings of minLength characters (or a format template), and
+// the number nearest zero within range. seen breaks cycles of required
+// properties, which no finite value satisfies, with an empty object.
+func stubValue(schema *base.Schema, seen map[*base.Schema]bool) interface{} {
+	if seen[schema] {
+		return map[string]interface{}{}
+	}
+	seen[schema] = true
+	defer delete(seen, schema)
+
+	switch {
+	case schema.Example != nil:
+		return extractYAMLNodeValue(schema.Example)
+	case schema.Default != nil:
+		return extractYAMLNodeValue(schema.Default)
+	case len(schema.Enum) > 0:
+		return extractYAMLNodeValue(schema.Enum[0])
+	}
+
+	variants := schema.OneOf
+	if len(variants) == 0 {
+		variants = schema.AnyOf
+	}
+
+	switch {
+	case internal.Contains(schema.Type, "object") || (len(schema.Type) == 0 && (len(schema.AllOf) > 0 || len(variants) > 0)):
+		result := make(map[string]interface{})
+		members := append([]*base.SchemaProxy(nil), schema.AllOf...)
+		if len(variants) > 0 {
+			members = append(members, variants[0])
+		}
+		for _, member := range members {
+			if s := member.Schema(); s != nil {
+				if stub, ok := stubValue(s, seen).(map[string]interface{}); ok {
+					for k, v := range stub {
+						result[k] = v
+					}
+				}
+			}
+		}
+		for _, name := range schema.Required {
+			if schema.Properties == nil {
+				break
+			}
+			if proxy := schema.Properties.GetOrZero(name); proxy != nil && proxy.Schema() != nil {
+				result[name] = stubValue(proxy.Schema(), seen)
+			}
+		}
+		return result
+
+	case internal.Contains(schema.Type, "array"):
+		items := []interface{}{}
+		if schema.MinItems != nil && schema.Items != nil && schema.Items.A != nil && schema.Items.A.Schema() != nil {
+			for i := 0; i < clampSize(*schema.MinItems); i++ {
+				items = append(items, stubValue(schema.Items.A.Schema(), seen))
+			}
+		}
+		return items
+
+	case internal.Contains(schema.Type, "string"):
+		if template, ok := formatTemplates[schema.Format]; ok {
+			return template
+		}
+		if schema.MinLength != nil {
+			return strings.Repeat("x", clampSize(*schema.MinLength))
+		}
+		return ""
+
+	case internal.Contains(schema.Type, "integer"):
+		return int(stubNumber(schema, true))
+
+	case internal.Contains(schema.Type, "number"):
+		return stubNumber(schema, false)
+
+	case internal.Contains(schema.Type, "boolean"):
+		return false
+	}
+	return nil
+}
+
+// stubNumber returns zero when schema allows it, else the end of its range
+// nearest zero
+func stubNumber(schema *base.Schema, integer bool) float64 {
+	lo, hi := numericBounds(schema)
+	if lo != nil && (lo.value > 0 || (lo.value == 0 && lo.exclusive)) {
+		return edgeValue(*lo, -1, integer, false)
+	}
+	if hi != nil && (hi.value < 0 || (hi.value == 0 && hi.exclusive)) {
+		return edgeValue(*hi, 1, integer, false)
+	}
+	return 0
+}
@@ -0,0 +1,77 @@
+package example
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// Circular strategies: what an example holds where generation is cut short
+const (
+	CircularOmit            = "omit"            // the property or item is left out
+	CircularNull            = "null"            // the value is null
+	CircularTruncatedObject = "truncatedObject" // the value is an empty object
+)
+
+// Truncation reasons
+const (
+	TruncatedCircular = "circular"  // a schema references itself, directly or not
+	TruncatedMaxDepth = "max-depth" // nesting reached MaxDepth
+)
+
+// Truncation records one place generation was cut short
+type Truncation struct {
+	Path   string `json:"path"`             // dotted property path from the top-level schema, as in FieldInfo
+	Schema string `json:"schema,omitempty"` // schema whose generation was cut, when it is a component
+	Reason string `json:"reason"`           // TruncatedCircular or TruncatedMaxDepth
+}
+
+// truncate records a truncation of schema at the current path for reason,
+// returning the value ctx.circular puts in its place: nil to omit it, null or
+// an empty object
+func (ctx *ExampleContext) truncate(schema, reason string) interface{} {
+	ctx.truncations = append(ctx.truncations, Truncation{Path: ctx.fieldPath(), Schema: schema, Reason: reason})
+
+	switch ctx.circular {
+	case CircularNull:
+		return json.RawMessage("null")
+	case CircularTruncatedObject:
+		return map[string]interface{}{}
+	}
+	return nil
+}
+
+// fieldPath returns the dotted property path to the value being generated
+func (ctx *ExampleContext) fieldPath() string {
+	var props []string
+	for _, segment := range ctx.fields {
+		if !segment.schema {
+			props = append(props, segment.name)
+		}
+	}
+	return strings.Join(props, ".")
+}
+
+// componentName returns name when it names a component schema, and "" for the
+// property and composition names generation also passes around
+func (ctx *ExampleContext) componentName(name string) string {
+	if _, ok := ctx.schemas[name]; ok {
+		return name
+	}
+	return ""
+}
+
+// itemsComponent returns the component schema array schema's items reference,
+// or ""
+func itemsComponent(schema *base.Schema) string {
+	if schema.Items == nil || schema.Items.A == nil || !schema.Items.A.IsReference() {
+		return ""
+	}
+	name, err := internal.ExtractReferenceName(schema.Items.A.GetReference())
+	if err != nil {
+		return ""
+	}
+	return name
+}
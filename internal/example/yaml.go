@@ -0,0 +1,89 @@
+package example
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"go.yaml.in/yaml/v4"
+)
+
+// MarshalYAML renders generated examples as a single YAML document keyed by schema
+// name. Keys are emitted in sorted order so the output is stable across runs.
+func MarshalYAML(examples map[string]json.RawMessage) ([]byte, error) {
+	names := make([]string, 0, len(examples))
+	for name := range examples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	root := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for _, name := range names {
+		value, err := exampleNode(examples[name])
+		if err != nil {
+			return nil, fmt.Errorf("schema '%s': %w", name, err)
+		}
+		root.Content = append(root.Content, internal.StringNode(name), value)
+	}
+
+	return internal.EncodeYAML(&yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}})
+}
+
+// InjectExamples writes each example into the `example` field of its schema under
+// components/schemas, replacing any existing value. The document is round-tripped
+// through yaml.Node so comments and key order are preserved. JSON input is accepted
+// but the result is always emitted as block-style YAML.
+func InjectExamples(openapi []byte, examples map[string]json.RawMessage) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(openapi, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: document is empty")
+	}
+	internal.ClearFlowStyle(&doc)
+
+	schemas := internal.MappingValue(internal.MappingValue(doc.Content[0], "components"), "schemas")
+	if schemas == nil || schemas.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("document has no components/schemas")
+	}
+
+	for i := 0; i+1 < len(schemas.Content); i += 2 {
+		name := schemas.Content[i].Value
+		raw, ok := examples[name]
+		if !ok {
+			continue
+		}
+
+		schemaNode := schemas.Content[i+1]
+		if schemaNode.Kind != yaml.MappingNode {
+			continue
+		}
+
+		value, err := exampleNode(raw)
+		if err != nil {
+			return nil, fmt.Errorf("schema '%s': %w", name, err)
+		}
+		internal.SetMappingValue(schemaNode, "example", value)
+	}
+
+	return internal.EncodeYAML(&doc)
+}
+
+// exampleNode converts a JSON example into a block-style yaml.Node
+func exampleNode(raw json.RawMessage) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode example: %w", err)
+	}
+
+	if len(doc.Content) == 0 {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+	}
+
+	node := doc.Content[0]
+	internal.ClearStyle(node)
+	return node, nil
+}
@@ -0,0 +1,163 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// PatchOperation is a single RFC 6902 JSON Patch operation. Only "add" and
+// "replace" are produced by the converter.
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// MarshalJSON always writes the value of "add", "replace" and "test", which
+// RFC 6902 requires even when it is null, and omits it from other operations
+func (op PatchOperation) MarshalJSON() ([]byte, error) {
+	type plain PatchOperation
+	switch op.Op {
+	case "add", "replace", "test":
+		return json.Marshal(struct {
+			Op    string      `json:"op"`
+			Path  string      `json:"path"`
+			Value interface{} `json:"value"`
+		}{op.Op, op.Path, op.Value})
+	}
+	return json.Marshal(plain(op))
+}
+
+// FixSuggestion is a machine-applyable edit to the OpenAPI document that resolves
+// a conversion error.
+type FixSuggestion struct {
+	Description string           `json:"description"`
+	Patch       []PatchOperation `json:"patch"`
+}
+
+// FixableError is a conversion error with a suggested fix attached. Its message
+// is exactly that of the wrapped error.
+type FixableError struct {
+	Err error
+	Fix *FixSuggestion
+}
+
+func (e *FixableError) Error() string { return e.Err.Error() }
+
+func (e *FixableError) Unwrap() error { return e.Err }
+
+// WithFix attaches fix to err, returning err unchanged when fix is nil
+func WithFix(err error, fix *FixSuggestion) error {
+	if fix == nil {
+		return err
+	}
+	return &FixableError{Err: err, Fix: fix}
+}
+
+// JSONPointer joins segments into an RFC 6901 pointer, escaping '~' and '/'
+func JSONPointer(segments ...string) string {
+	var b strings.Builder
+	for _, segment := range segments {
+		b.WriteByte('/')
+		b.WriteString(strings.ReplaceAll(strings.ReplaceAll(segment, "~", "~0"), "/", "~1"))
+	}
+	return b.String()
+}
+
+// ApplyPatch applies add and replace operations to a YAML or JSON document. The
+// document is round-tripped through yaml.Node so comments and key order are
+// preserved; JSON input is emitted as block-style YAML.
+func ApplyPatch(document []byte, patch []PatchOperation) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(document, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: document is empty")
+	}
+	ClearFlowStyle(&doc)
+
+	for _, op := range patch {
+		if err := applyOperation(doc.Content[0], op); err != nil {
+			return nil, fmt.Errorf("patch %s %s: %w", op.Op, op.Path, err)
+		}
+	}
+
+	return EncodeYAML(&doc)
+}
+
+// applyOperation performs one add or replace against root
+func applyOperation(root *yaml.Node, op PatchOperation) error {
+	if op.Op != "add" && op.Op != "replace" {
+		return fmt.Errorf("unsupported operation")
+	}
+
+	if op.Path == "" || op.Path[0] != '/' {
+		return fmt.Errorf("path must be a non-empty JSON pointer")
+	}
+
+	segments := strings.Split(op.Path[1:], "/")
+	for i, segment := range segments {
+		segments[i] = strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")
+	}
+
+	parent := root
+	for _, segment := range segments[:len(segments)-1] {
+		parent = childNode(parent, segment)
+		if parent == nil {
+			return fmt.Errorf("path segment '%s' not found", segment)
+		}
+	}
+
+	var value yaml.Node
+	if err := value.Encode(op.Value); err != nil {
+		return fmt.Errorf("failed to encode value: %w", err)
+	}
+	ClearStyle(&value)
+
+	last := segments[len(segments)-1]
+	switch parent.Kind {
+	case yaml.MappingNode:
+		if op.Op == "replace" && MappingValue(parent, last) == nil {
+			return fmt.Errorf("path segment '%s' not found", last)
+		}
+		SetMappingValue(parent, last, &value)
+	case yaml.SequenceNode:
+		if last == "-" && op.Op == "add" {
+			parent.Content = append(parent.Content, &value)
+			return nil
+		}
+		index, err := strconv.Atoi(last)
+		if err != nil || index < 0 || index > len(parent.Content) || (op.Op == "replace" && index == len(parent.Content)) {
+			return fmt.Errorf("invalid array index '%s'", last)
+		}
+		if op.Op == "replace" {
+			parent.Content[index] = &value
+			return nil
+		}
+		parent.Content = append(parent.Content[:index], append([]*yaml.Node{&value}, parent.Content[index:]...)...)
+	default:
+		return fmt.Errorf("cannot add to a scalar")
+	}
+	return nil
+}
+
+// childNode returns the child of a mapping (by key) or sequence (by index), or nil
+func childNode(node *yaml.Node, segment string) *yaml.Node {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return MappingValue(node, segment)
+	case yaml.SequenceNode:
+		index, err := strconv.Atoi(segment)
+		if err != nil || index < 0 || index >= len(node.Content) {
+			return nil
+		}
+		return node.Content[index]
+	}
+	return nil
+}
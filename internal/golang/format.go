@@ -0,0 +1,184 @@
+package golang
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"sort"
+)
+
+// Formatting styles for FormatSource
+const (
+	FormatNone    = "none"    // source as generated
+	FormatGofmt   = "gofmt"   // go/format
+	FormatGofumpt = "gofumpt" // go/format plus a subset of the gofumpt rules
+)
+
+// FormatSource formats generated Go source in style; "" is FormatGofmt and
+// FormatNone returns src unchanged. FormatGofumpt applies, after gofmt, the
+// subset of the gofumpt rules that generated code can break: no empty lines at
+// the start or end of a block, field list or parenthesised declaration, empty
+// field lists on one line (struct{}), and no empty line between an assignment
+// to err and the `if err != nil` check that follows it. It is not gofumpt, whose
+// other rules generated code already follows or does not need.
+func FormatSource(src []byte, style string) ([]byte, error) {
+	if style == FormatNone {
+		return src, nil
+	}
+
+	out, err := format.Source(src)
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated Go: %w", err)
+	}
+	if style != FormatGofumpt {
+		return out, nil
+	}
+
+	out, err = gofumpt(out)
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated Go: %w", err)
+	}
+	return out, nil
+}
+
+// gofumpt applies the subset of the gofumpt rules described on FormatSource to
+// gofmt'd src
+func gofumpt(src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	tf := fset.File(file.Pos())
+	lines := bytes.SplitAfter(src, []byte("\n"))
+
+	// Edits are blank lines to drop (by 1-based line number) and empty field
+	// lists to collapse (by byte offset range)
+	drop := make(map[int]bool)
+	type collapse struct{ from, to int }
+	var collapses []collapse
+
+	blank := func(line int) bool {
+		return line >= 1 && line <= len(lines) && len(bytes.TrimSpace(lines[line-1])) == 0
+	}
+	// trim drops the empty lines just inside an opening and closing delimiter
+	// that end and start their lines
+	trim := func(open, close token.Pos) {
+		if !open.IsValid() || !close.IsValid() {
+			return
+		}
+		openLine, closeLine := tf.Line(open), tf.Line(close)
+		if openLine == closeLine || !bytes.HasSuffix(bytes.TrimSpace(lines[openLine-1]), src[tf.Offset(open):tf.Offset(open)+1]) {
+			return
+		}
+		if len(bytes.TrimSpace(lines[closeLine-1][:tf.Offset(close)-tf.Offset(tf.LineStart(closeLine))])) != 0 {
+			return
+		}
+		for line := openLine + 1; line < closeLine && blank(line); line++ {
+			drop[line] = true
+		}
+		for line := closeLine - 1; line > openLine && blank(line); line-- {
+			drop[line] = true
+		}
+	}
+	hasComment := func(from, to token.Pos) bool {
+		for _, group := range file.Comments {
+			if group.Pos() < to && group.End() > from {
+				return true
+			}
+		}
+		return false
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.GenDecl:
+			trim(n.Lparen, n.Rparen)
+		case *ast.BlockStmt:
+			trim(n.Lbrace, n.Rbrace)
+			for i := 1; i < len(n.List); i++ {
+				if isErrCheck(n.List[i]) && assignsErr(n.List[i-1]) && !hasComment(n.List[i-1].End(), n.List[i].Pos()) {
+					for line := tf.Line(n.List[i].Pos()) - 1; line > tf.Line(n.List[i-1].End()) && blank(line); line-- {
+						drop[line] = true
+					}
+				}
+			}
+		case *ast.CompositeLit:
+			trim(n.Lbrace, n.Rbrace)
+		case *ast.StructType:
+			trim(n.Fields.Opening, n.Fields.Closing)
+			if len(n.Fields.List) == 0 && !hasComment(n.Fields.Opening, n.Fields.Closing) {
+				collapses = append(collapses, collapse{tf.Offset(n.Struct) + len("struct"), tf.Offset(n.Fields.Closing) + 1})
+			}
+		case *ast.InterfaceType:
+			trim(n.Methods.Opening, n.Methods.Closing)
+			if len(n.Methods.List) == 0 && !hasComment(n.Methods.Opening, n.Methods.Closing) {
+				collapses = append(collapses, collapse{tf.Offset(n.Interface) + len("interface"), tf.Offset(n.Methods.Closing) + 1})
+			}
+		}
+		return true
+	})
+
+	// Blank lines go first, reparsing for the collapses as line numbers shift
+	if len(drop) > 0 {
+		out, err := format.Source(dropLines(lines, drop))
+		if err != nil {
+			return nil, err
+		}
+		return gofumpt(out)
+	}
+	if len(collapses) == 0 {
+		return src, nil
+	}
+
+	// Collapse from the end so earlier offsets stay valid
+	sort.Slice(collapses, func(i, j int) bool { return collapses[i].from > collapses[j].from })
+	edited := append([]byte(nil), src...)
+	for _, c := range collapses {
+		edited = append(edited[:c.from], append([]byte("{}"), edited[c.to:]...)...)
+	}
+	return format.Source(edited)
+}
+
+// dropLines joins lines, leaving out the 1-based line numbers in drop
+func dropLines(lines [][]byte, drop map[int]bool) []byte {
+	var buf bytes.Buffer
+	for i, line := range lines {
+		if !drop[i+1] {
+			buf.Write(line)
+		}
+	}
+	return buf.Bytes()
+}
+
+// isErrCheck reports whether stmt is `if err != nil {` without an init statement
+func isErrCheck(stmt ast.Stmt) bool {
+	ifStmt, ok := stmt.(*ast.IfStmt)
+	if !ok || ifStmt.Init != nil {
+		return false
+	}
+	cond, ok := ifStmt.Cond.(*ast.BinaryExpr)
+	if !ok || cond.Op != token.NEQ {
+		return false
+	}
+	x, xok := cond.X.(*ast.Ident)
+	y, yok := cond.Y.(*ast.Ident)
+	return xok && yok && x.Name == "err" && y.Name == "nil"
+}
+
+// assignsErr reports whether stmt assigns to err
+func assignsErr(stmt ast.Stmt) bool {
+	assign, ok := stmt.(*ast.AssignStmt)
+	if !ok {
+		return false
+	}
+	for _, lhs := range assign.Lhs {
+		if ident, ok := lhs.(*ast.Ident); ok && ident.Name == "err" {
+			return true
+		}
+	}
+	return false
+}
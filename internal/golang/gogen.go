@@ -2,7 +2,11 @@ package golang
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -12,7 +16,9 @@ import (
 // GenerateGo produces Go source code from GoStruct IR with custom JSON marshaling
 func GenerateGo(ctx *GoContext) ([]byte, error) {
 	funcMap := template.FuncMap{
-		"renderStruct": renderStruct,
+		"renderStruct":    func(s *GoStruct) string { return renderStruct(s, ctx.Comments) },
+		"renderShim":      renderShim,
+		"shimTimeHelpers": func() string { return shimTimeHelpers },
 	}
 
 	tmpl, err := template.New("go").Funcs(funcMap).Parse(goTemplate)
@@ -24,7 +30,16 @@ func GenerateGo(ctx *GoContext) ([]byte, error) {
 		PackageName: ctx.PackageName,
 		Structs:     ctx.Structs,
 		NeedsTime:   ctx.NeedsTime,
+		Shims:       ctx.Shims,
+		ShimsTime:   usesTime(ctx.Shims),
+	}
+
+	// Packages outside the standard library form their own import group
+	data.Imports = append(data.Imports, ctx.Imports...)
+	if data.ShimsTime && !slices.Contains(data.Imports, timestamppbImport) {
+		data.Imports = append(data.Imports, timestamppbImport)
 	}
+	sort.Strings(data.Imports)
 
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, data); err != nil {
@@ -42,25 +57,40 @@ import (
 {{if .NeedsTime}}	"strings"
 	"time"
 {{else}}	"strings"
-{{end}}
+{{end}}{{if .Imports}}
+{{range .Imports}}	"{{.}}"
+{{end}}{{end}}
 )
 {{range .Structs}}
-{{renderStruct .}}{{end}}
+{{renderStruct .}}{{end}}{{range .Shims}}
+{{renderShim .}}{{end}}{{if .ShimsTime}}{{shimTimeHelpers}}{{end}}
 `
 
 type goTemplateData struct {
 	PackageName string
 	Structs     []*GoStruct
 	NeedsTime   bool
+	Shims       []*Shim
+	ShimsTime   bool
+	Imports     []string
 }
 
 // renderStruct renders struct definition with fields, add MarshalJSON/UnmarshalJSON for unions
-func renderStruct(s *GoStruct) string {
+func renderStruct(s *GoStruct, comments internal.CommentStyle) string {
 	var result strings.Builder
 
 	// Add struct comment if present
 	if s.Description != "" {
-		result.WriteString(formatGoComment(s.Description, ""))
+		result.WriteString(comments.Format(s.Description, ""))
+	}
+
+	if s.UnionInterface {
+		result.WriteString(renderUnionInterface(s))
+		return result.String()
+	}
+	if s.EnumType != "" {
+		result.WriteString(renderEnum(s))
+		return result.String()
 	}
 
 	// Struct definition
@@ -68,7 +98,7 @@ func renderStruct(s *GoStruct) string {
 
 	// Render fields
 	for _, field := range s.Fields {
-		result.WriteString(renderField(field, "\t"))
+		result.WriteString(renderField(field, "\t", comments))
 	}
 
 	result.WriteString("}\n")
@@ -79,18 +109,32 @@ func renderStruct(s *GoStruct) string {
 		result.WriteString(renderUnionMarshal(s))
 		result.WriteString("\n")
 		result.WriteString(renderUnionUnmarshal(s))
+		result.WriteString("\n")
+		result.WriteString(renderUnionHelpers(s))
+	}
+
+	// Variants of interface-style unions write their discriminator
+	if s.Discriminates != nil {
+		result.WriteString("\n")
+		result.WriteString(renderVariantDiscriminator(s))
+	}
+
+	// Interface-style union fields are decoded through the union's Decode function
+	if slices.ContainsFunc(s.Fields, func(f *GoField) bool { return f.Union != "" }) {
+		result.WriteString("\n")
+		result.WriteString(renderUnionFieldsUnmarshal(s))
 	}
 
 	return result.String()
 }
 
 // renderField renders individual field with JSON tag and pointer notation
-func renderField(f *GoField, indent string) string {
+func renderField(f *GoField, indent string, comments internal.CommentStyle) string {
 	var result strings.Builder
 
 	// Add field comment if present
 	if f.Description != "" {
-		result.WriteString(formatGoComment(f.Description, indent))
+		result.WriteString(comments.Format(f.Description, indent))
 	}
 
 	result.WriteString(indent)
@@ -98,7 +142,15 @@ func renderField(f *GoField, indent string) string {
 
 	// Add JSON tag
 	if f.JSONName != "" {
-		result.WriteString(fmt.Sprintf(" `json:\"%s\"`", f.JSONName))
+		tag := f.JSONName
+		if f.OmitEmpty {
+			tag += ",omitempty"
+		}
+		result.WriteString(fmt.Sprintf(" `json:\"%s\"", tag))
+		for _, t := range f.Tags {
+			result.WriteString(fmt.Sprintf(" %s:%s", t.Key, strconv.Quote(t.Value)))
+		}
+		result.WriteString("`")
 	}
 
 	result.WriteString("\n")
@@ -106,11 +158,13 @@ func renderField(f *GoField, indent string) string {
 	return result.String()
 }
 
-// renderUnionMarshal generates MarshalJSON for union - check which variant is non-nil, marshal that variant
+// renderUnionMarshal generates MarshalJSON for union - check which variant is non-nil, marshal that variant.
+// The receiver is a value so unions held by value, as constructors return
+// them, marshal too.
 func renderUnionMarshal(s *GoStruct) string {
 	var result strings.Builder
 
-	result.WriteString(fmt.Sprintf("func (u *%s) MarshalJSON() ([]byte, error) {\n", s.Name))
+	result.WriteString(fmt.Sprintf("func (u %s) MarshalJSON() ([]byte, error) {\n", s.Name))
 
 	// Count non-nil variants to ensure exactly one is set
 	result.WriteString("\tcount := 0\n")
@@ -123,10 +177,12 @@ func renderUnionMarshal(s *GoStruct) string {
 	result.WriteString(fmt.Sprintf("\t\treturn nil, fmt.Errorf(\"%s: multiple variants set\")\n", s.Name))
 	result.WriteString("\t}\n\n")
 
-	// Check each variant pointer and marshal the non-nil one
+	// Check each variant pointer and marshal the non-nil one, with a
+	// discriminator value that maps back to it
+	_, cases := variantCases(s)
 	for _, field := range s.Fields {
 		result.WriteString(fmt.Sprintf("\tif u.%s != nil {\n", field.Name))
-		result.WriteString(fmt.Sprintf("\t\treturn json.Marshal(u.%s)\n", field.Name))
+		result.WriteString(renderVariantMarshal(s, field.Name, cases[field.Name], "\t\t", "*u."+field.Name, "u."+field.Name))
 		result.WriteString("\t}\n")
 	}
 
@@ -137,6 +193,89 @@ func renderUnionMarshal(s *GoStruct) string {
 	return result.String()
 }
 
+// renderVariantMarshal renders the body, indented by indent, marshaling the
+// variant of union s, whose value and pointer are the Go expressions given. A
+// discriminator field whose value (case-insensitively) is none of values,
+// those mapped to the variant, is set to the variant's DiscriminatorValues
+// entry on a copy; a variant without the field has the discriminator property
+// prepended to its JSON.
+func renderVariantMarshal(s *GoStruct, variant string, values []string, indent, value, pointer string) string {
+	var result strings.Builder
+	line := func(format string, args ...any) {
+		result.WriteString(indent + fmt.Sprintf(format, args...) + "\n")
+	}
+
+	discriminator, found := s.DiscriminatorValues[variant]
+	if !found {
+		line("return json.Marshal(%s)", pointer)
+		return result.String()
+	}
+
+	if field := s.DiscriminatorFields[variant]; field != "" {
+		conditions := make([]string, len(values))
+		for i, v := range values {
+			conditions[i] = "d != " + v
+		}
+		line("v := %s", value)
+		line("if d := strings.ToLower(v.%s); %s {", field, strings.Join(conditions, " && "))
+		line("\tv.%s = %s", field, strconv.Quote(discriminator))
+		line("}")
+		line("return json.Marshal(&v)")
+		return result.String()
+	}
+
+	key, _ := json.Marshal(s.Discriminator)
+	encoded, _ := json.Marshal(discriminator)
+	pair := string(key) + ":" + string(encoded)
+	line("data, err := json.Marshal(%s)", pointer)
+	line("if err != nil {")
+	line("\treturn nil, err")
+	line("}")
+	line("if len(data) > 2 {")
+	line("\treturn append([]byte(%s), data[1:]...), nil", strconv.Quote("{"+pair+","))
+	line("}")
+	line("return []byte(%s), nil", strconv.Quote("{"+pair+"}"))
+	return result.String()
+}
+
+// renderVariantDiscriminator generates MarshalJSON for a variant of an
+// interface-style union, writing a discriminator value that maps back to it
+// as a struct-style union's MarshalJSON does. The receiver is a value so
+// variants held by value marshal too.
+func renderVariantDiscriminator(s *GoStruct) string {
+	var result strings.Builder
+	union := s.Discriminates
+	_, cases := variantCases(union)
+
+	result.WriteString(fmt.Sprintf("// MarshalJSON writes the %s Decode%s maps back to %s\n", union.Discriminator, union.Name, s.Name))
+	result.WriteString(fmt.Sprintf("func (x %s) MarshalJSON() ([]byte, error) {\n", s.Name))
+	result.WriteString(fmt.Sprintf("\ttype plain %s\n", s.Name))
+	result.WriteString(renderVariantMarshal(union, s.Name, cases[s.Name], "\t", "plain(x)", "plain(x)"))
+	result.WriteString("}\n")
+	return result.String()
+}
+
+// variantCases groups the discriminator values of union s by the variant they
+// map to, as quoted lowercase Go strings in sorted order; variants lists the
+// variants in the order of their first value
+func variantCases(s *GoStruct) (variants []string, cases map[string][]string) {
+	discValues := make([]string, 0, len(s.DiscriminatorMap))
+	for discValue := range s.DiscriminatorMap {
+		discValues = append(discValues, discValue)
+	}
+	sort.Strings(discValues)
+
+	cases = make(map[string][]string)
+	for _, discValue := range discValues {
+		variant := s.DiscriminatorMap[discValue]
+		if cases[variant] == nil {
+			variants = append(variants, variant)
+		}
+		cases[variant] = append(cases[variant], strconv.Quote(discValue))
+	}
+	return variants, cases
+}
+
 // renderUnionUnmarshal generates UnmarshalJSON for union - read discriminator, unmarshal into correct variant
 func renderUnionUnmarshal(s *GoStruct) string {
 	var result strings.Builder
@@ -144,7 +283,7 @@ func renderUnionUnmarshal(s *GoStruct) string {
 	result.WriteString(fmt.Sprintf("func (u *%s) UnmarshalJSON(data []byte) error {\n", s.Name))
 
 	// Create anonymous struct to read discriminator
-	discriminatorFieldName := internal.ToPascalCase(s.Discriminator)
+	discriminatorFieldName := internal.ToGoName(s.Discriminator)
 	result.WriteString("\tvar discriminator struct {\n")
 	result.WriteString(fmt.Sprintf("\t\t%s string `json:\"%s\"`\n", discriminatorFieldName, s.Discriminator))
 	result.WriteString("\t}\n")
@@ -162,8 +301,15 @@ func renderUnionUnmarshal(s *GoStruct) string {
 	// Switch on discriminator value (case-insensitive)
 	result.WriteString(fmt.Sprintf("\tswitch strings.ToLower(discriminator.%s) {\n", discriminatorFieldName))
 
-	// Generate case for each discriminator value
-	for discValue, typeName := range s.DiscriminatorMap {
+	// Generate case for each discriminator value in a stable order
+	discValues := make([]string, 0, len(s.DiscriminatorMap))
+	for discValue := range s.DiscriminatorMap {
+		discValues = append(discValues, discValue)
+	}
+	sort.Strings(discValues)
+
+	for _, discValue := range discValues {
+		typeName := s.DiscriminatorMap[discValue]
 		result.WriteString(fmt.Sprintf("\tcase \"%s\":\n", discValue))
 		result.WriteString(fmt.Sprintf("\t\tu.%s = &%s{}\n", typeName, typeName))
 		result.WriteString(fmt.Sprintf("\t\treturn json.Unmarshal(data, u.%s)\n", typeName))
@@ -179,26 +325,191 @@ func renderUnionUnmarshal(s *GoStruct) string {
 	return result.String()
 }
 
-// formatGoComment formats a description as a Go comment with indentation
-func formatGoComment(description, indent string) string {
-	if strings.TrimSpace(description) == "" {
-		return ""
+// renderUnionHelpers generates a constructor per variant, Value returning the
+// variant that is set, and Is/As accessors per variant. A method named like a
+// variant field would not compile, so such a method is left out.
+func renderUnionHelpers(s *GoStruct) string {
+	var result strings.Builder
+
+	fields := make(map[string]bool, len(s.Fields))
+	for _, field := range s.Fields {
+		fields[field.Name] = true
+	}
+
+	for _, field := range s.Fields {
+		result.WriteString(fmt.Sprintf("// New%sFrom%s returns a %s holding v\n", s.Name, field.Name, s.Name))
+		result.WriteString(fmt.Sprintf("func New%sFrom%s(v %s) %s {\n", s.Name, field.Name, field.Name, s.Name))
+		result.WriteString(fmt.Sprintf("\treturn %s{%s: &v}\n", s.Name, field.Name))
+		result.WriteString("}\n\n")
+	}
+
+	if !fields["Value"] {
+		result.WriteString("// Value returns the variant that is set and its type name; nil and \"\" when\n")
+		result.WriteString("// none is\n")
+		result.WriteString(fmt.Sprintf("func (u %s) Value() (any, string) {\n", s.Name))
+		for _, field := range s.Fields {
+			result.WriteString(fmt.Sprintf("\tif u.%s != nil {\n", field.Name))
+			result.WriteString(fmt.Sprintf("\t\treturn u.%s, \"%s\"\n", field.Name, field.Name))
+			result.WriteString("\t}\n")
+		}
+		result.WriteString("\treturn nil, \"\"\n")
+		result.WriteString("}\n")
+	}
+
+	for _, field := range s.Fields {
+		if !fields["Is"+field.Name] {
+			result.WriteString(fmt.Sprintf("\n// Is%s reports whether the %s variant is set\n", field.Name, field.Name))
+			result.WriteString(fmt.Sprintf("func (u %s) Is%s() bool {\n", s.Name, field.Name))
+			result.WriteString(fmt.Sprintf("\treturn u.%s != nil\n", field.Name))
+			result.WriteString("}\n")
+		}
+
+		if !fields["As"+field.Name] {
+			result.WriteString(fmt.Sprintf("\n// As%s returns the %s variant and whether it is set\n", field.Name, field.Name))
+			result.WriteString(fmt.Sprintf("func (u %s) As%s() (%s, bool) {\n", s.Name, field.Name, field.Type))
+			result.WriteString(fmt.Sprintf("\treturn u.%s, u.%s != nil\n", field.Name, field.Name))
+			result.WriteString("}\n")
+		}
+	}
+
+	return result.String()
+}
+
+// renderUnionInterface renders a union as a sealed interface, the variants'
+// implementations of it and a Decode function choosing the variant by
+// discriminator
+func renderUnionInterface(s *GoStruct) string {
+	var result strings.Builder
+	marker := "is" + s.Name
+
+	result.WriteString(fmt.Sprintf("type %s interface {\n\t%s()\n}\n\n", s.Name, marker))
+	for _, variant := range s.UnionVariants {
+		result.WriteString(fmt.Sprintf("func (*%s) %s() {}\n", variant, marker))
 	}
 
-	lines := strings.Split(description, "\n")
+	discriminatorFieldName := internal.ToGoName(s.Discriminator)
+	result.WriteString(fmt.Sprintf("\n// Decode%s decodes JSON into the %s variant named by its %s; JSON null\n", s.Name, s.Name, s.Discriminator))
+	result.WriteString("// decodes to nil\n")
+	result.WriteString(fmt.Sprintf("func Decode%s(data []byte) (%s, error) {\n", s.Name, s.Name))
+	result.WriteString("\tif string(data) == \"null\" {\n")
+	result.WriteString("\t\treturn nil, nil\n")
+	result.WriteString("\t}\n\n")
+	result.WriteString("\tvar discriminator struct {\n")
+	result.WriteString(fmt.Sprintf("\t\t%s string `json:\"%s\"`\n", discriminatorFieldName, s.Discriminator))
+	result.WriteString("\t}\n")
+	result.WriteString("\tif err := json.Unmarshal(data, &discriminator); err != nil {\n")
+	result.WriteString("\t\treturn nil, err\n")
+	result.WriteString("\t}\n\n")
+
+	// Switch on discriminator value (case-insensitive), in a stable order
+	result.WriteString(fmt.Sprintf("\tswitch strings.ToLower(discriminator.%s) {\n", discriminatorFieldName))
+	// Values mapped to the same variant share a case
+	variants, cases := variantCases(s)
+	for _, variant := range variants {
+		result.WriteString(fmt.Sprintf("\tcase %s:\n", strings.Join(cases[variant], ", ")))
+		result.WriteString(fmt.Sprintf("\t\tvar v %s\n", variant))
+		result.WriteString("\t\tif err := json.Unmarshal(data, &v); err != nil {\n")
+		result.WriteString("\t\t\treturn nil, err\n")
+		result.WriteString("\t\t}\n")
+		result.WriteString("\t\treturn &v, nil\n")
+	}
+
+	result.WriteString("\tdefault:\n")
+	result.WriteString(fmt.Sprintf("\t\treturn nil, fmt.Errorf(\"unknown %s: %%s\", discriminator.%s)\n", s.Discriminator, discriminatorFieldName))
+	result.WriteString("\t}\n")
+	result.WriteString("}\n")
+
+	return result.String()
+}
+
+// renderUnionFieldsUnmarshal generates UnmarshalJSON for a struct holding
+// interface-style unions - decode the other fields as usual, hold the unions'
+// JSON back and decode it with their Decode functions
+func renderUnionFieldsUnmarshal(s *GoStruct) string {
 	var result strings.Builder
 
-	for _, line := range lines {
-		trimmed := strings.TrimRight(line, " \t")
-		result.WriteString(indent)
-		if trimmed == "" {
-			result.WriteString("//\n")
+	result.WriteString(fmt.Sprintf("func (x *%s) UnmarshalJSON(data []byte) error {\n", s.Name))
+	result.WriteString(fmt.Sprintf("\ttype plain %s\n", s.Name))
+	result.WriteString("\taux := struct {\n")
+	result.WriteString("\t\t*plain\n")
+	for _, field := range s.Fields {
+		if field.Union == "" {
+			continue
+		}
+		rawType := "json.RawMessage"
+		if strings.HasPrefix(field.Type, "[]") {
+			rawType = "[]json.RawMessage"
+		}
+		result.WriteString(fmt.Sprintf("\t\t%s %s `json:\"%s\"`\n", field.Name, rawType, field.JSONName))
+	}
+	result.WriteString("\t}{plain: (*plain)(x)}\n")
+	result.WriteString("\terr := json.Unmarshal(data, &aux)\n")
+	result.WriteString("\tif err != nil {\n")
+	result.WriteString("\t\treturn err\n")
+	result.WriteString("\t}\n")
+
+	for _, field := range s.Fields {
+		if field.Union == "" {
+			continue
+		}
+		result.WriteString("\n")
+		result.WriteString(fmt.Sprintf("\tif aux.%s != nil {\n", field.Name))
+		if strings.HasPrefix(field.Type, "[]") {
+			result.WriteString(fmt.Sprintf("\t\tx.%s = make(%s, len(aux.%s))\n", field.Name, field.Type, field.Name))
+			result.WriteString(fmt.Sprintf("\t\tfor i, raw := range aux.%s {\n", field.Name))
+			result.WriteString(fmt.Sprintf("\t\t\tif x.%s[i], err = Decode%s(raw); err != nil {\n", field.Name, field.Union))
+			result.WriteString("\t\t\t\treturn err\n")
+			result.WriteString("\t\t\t}\n")
+			result.WriteString("\t\t}\n")
 		} else {
-			result.WriteString("// ")
-			result.WriteString(trimmed)
-			result.WriteString("\n")
+			result.WriteString(fmt.Sprintf("\t\tif x.%s, err = Decode%s(aux.%s); err != nil {\n", field.Name, field.Union, field.Name))
+			result.WriteString("\t\t\treturn err\n")
+			result.WriteString("\t\t}\n")
+		}
+		result.WriteString("\t}\n")
+	}
+	result.WriteString("\n")
+	result.WriteString("\treturn nil\n")
+	result.WriteString("}\n")
+
+	return result.String()
+}
+
+// renderEnum renders an enum schema as a named scalar type and a constant per
+// value, named after the type and the value (StatusActive, Level2, LevelMinus1)
+func renderEnum(s *GoStruct) string {
+	var result strings.Builder
+
+	result.WriteString(fmt.Sprintf("type %s %s\n", s.Name, s.EnumType))
+	if len(s.EnumValues) == 0 {
+		return result.String()
+	}
+
+	result.WriteString("\nconst (\n")
+	seen := make(map[string]bool, len(s.EnumValues))
+	for i, value := range s.EnumValues {
+		suffix := internal.ToGoName(value)
+		literal := strconv.Quote(value)
+		if s.EnumType != "string" {
+			suffix = strings.Replace(value, "-", "Minus", 1)
+			literal = value
+		}
+		if suffix == "" {
+			suffix = fmt.Sprintf("Value%d", i+1)
 		}
+		name := s.Name + suffix
+		if seen[name] {
+			name = fmt.Sprintf("%s%d", name, i+1)
+		}
+		seen[name] = true
+
+		result.WriteString(fmt.Sprintf("\t%s %s = %s\n", name, s.Name, literal))
 	}
+	result.WriteString(")\n")
 
 	return result.String()
 }
+
+// DefaultCommentWidth is the column generated doc comments wrap at unless
+// GoContext.Comments sets another
+const DefaultCommentWidth = 80
@@ -1,26 +1,65 @@
 package golang
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
 	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/duh-rpc/openapi-schema.go/internal"
 	"github.com/duh-rpc/openapi-schema.go/internal/parser"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"go.yaml.in/yaml/v4"
 )
 
 // GoStruct represents a Go struct definition with union metadata
 type GoStruct struct {
 	Name             string
+	OriginalSchema   string // component schema the struct is built from; empty for inline objects
 	Description      string
 	Fields           []*GoField
 	IsUnion          bool
 	UnionVariants    []string
 	Discriminator    string
 	DiscriminatorMap map[string]string // discriminator value -> type name (lowercase keys)
+	// DiscriminatorValues holds the value MarshalJSON writes for each variant
+	// type whose own value does not map to it: the first mapped to it, or the
+	// variant's schema name without an explicit mapping
+	DiscriminatorValues map[string]string
+	// DiscriminatorFields names each variant's string field holding the
+	// discriminator; a variant without the property is absent, and MarshalJSON
+	// adds the property to its JSON
+	DiscriminatorFields map[string]string
+	// UnionInterface renders the union as a sealed interface its variants
+	// implement, with a Decode function, instead of a struct of variant pointers
+	UnionInterface bool
+	// Discriminates is the interface-style union whose discriminator the
+	// struct's MarshalJSON writes, the struct being one of its variants; nil
+	// when the struct is a variant of no such union, or of several
+	Discriminates *GoStruct
+	// EnumType is the underlying Go type of an enum schema ("string", "int32",
+	// ...), declared with a constant per value in EnumValues; empty for structs
+	EnumType   string
+	EnumValues []string
 }
 
+// Union representations for GoContext.UnionStyle
+const (
+	UnionStyleStruct    = "struct"    // struct with a pointer field per variant
+	UnionStyleInterface = "interface" // sealed interface implemented by the variants
+)
+
+// Field selections for GoContext.OmitEmpty
+const (
+	OmitEmptyNever    = "never"    // no field has omitempty
+	OmitEmptyOptional = "optional" // fields whose property is not required
+	OmitEmptyAlways   = "always"   // every field
+)
+
 // GoField represents a struct field with Go type, JSON tag, pointer flag
 type GoField struct {
 	Name        string
@@ -28,6 +67,18 @@ type GoField struct {
 	JSONName    string
 	Description string
 	IsPointer   bool
+	OmitEmpty   bool // json tag gains ,omitempty
+	// Tags follow the json tag, in order
+	Tags []StructTag
+	// Union names the interface-style union the field holds, directly or as
+	// slice elements; the enclosing struct decodes it with UnmarshalJSON
+	Union string
+}
+
+// StructTag is a struct tag key and its unquoted value
+type StructTag struct {
+	Key   string
+	Value string
 }
 
 // GoContext holds state during Go code generation including package name
@@ -35,7 +86,68 @@ type GoContext struct {
 	Tracker     *internal.NameTracker
 	Structs     []*GoStruct
 	PackageName string
-	NeedsTime   bool // Flag for time.Time import
+	NeedsTime   bool            // Flag for time.Time import
+	Ctx         context.Context // checked between schemas; nil → never cancelled
+	Concurrency int             // workers used to build structs; <= 1 → sequential
+	Logger      *slog.Logger    // receives debug events about generation decisions
+	// CollectErrors records per-schema errors in Errors, in schema order, instead
+	// of stopping at the first one
+	CollectErrors bool
+	Errors        []error
+	ErrorSchemas  []string // schema each entry of Errors belongs to
+	// JSONTagCase rewrites property names in json tags; see internal.ApplyJSONCase
+	JSONTagCase string
+	// OmitEmpty selects the fields whose json tag has omitempty: OmitEmptyNever
+	// (or empty), OmitEmptyOptional or OmitEmptyAlways
+	OmitEmpty string
+	// ExtraTags are tag keys every field gets after json, with the json tag's value
+	ExtraTags []string
+	// UnionStyle selects how unions are rendered: UnionStyleStruct (or empty) or
+	// UnionStyleInterface
+	UnionStyle string
+	// Comments controls how descriptions are written as doc comments; wrapped
+	// at DefaultCommentWidth unless set
+	Comments internal.CommentStyle
+	// TypeMappings override the built-in scalar mapping; Imports collects the
+	// packages they require, in first-use order
+	TypeMappings []internal.TypeMapping
+	Imports      []string
+	// Shims are rendered as ToProto/FromProto functions after the structs; see BuildShims
+	Shims []*Shim
+	// WellKnownTypes mirrors the proto mapping of free-form objects and anyOf
+	WellKnownTypes internal.WellKnownTypes
+	// Decimals maps decimal values onto exact Go types; nil → built-in scalars
+	Decimals *internal.DecimalOptions
+	// FreeFormType is the Go type of free-form objects when WellKnownTypes.Struct
+	// is set; "" → map[string]any
+	FreeFormType string
+	// External maps schemas replaced by an x-go-type extension to that Go type;
+	// no struct is generated for them
+	External map[string]string
+	// Opaque names component schemas excluded from generation; references to
+	// them become json.RawMessage fields
+	Opaque map[string]bool
+	// Imported maps component schemas generated from another spec, also listed
+	// in Opaque, to the types references use instead
+	Imported map[string]internal.ImportMapping
+
+	// NestedNameFunc names inline structs as it names nested proto messages; the
+	// result is prefixed with the enclosing struct's name
+	NestedNameFunc func(parent, property string) string
+	// GoNameFunc overrides the Go identifier derived from a property name, for
+	// struct fields and inline struct names; "" → internal.ToGoName
+	GoNameFunc func(property string) string
+	// NonASCIINames handles non-ASCII characters in property names, as for proto
+	// field names; "" → internal.NonASCIITransliterate
+	NonASCIINames string
+
+	scope     string                    // struct whose fields are being built, prefixing inline struct names
+	scopeName string                    // scope's name as a nested proto message, passed to NestedNameFunc
+	graph     *internal.DependencyGraph // for unions nested in inline objects
+	goTypes   map[string]bool           // schemas generated as Go structs, so references use x-go-name
+	schema    string                    // component schema being built
+	reserved  map[string]bool           // struct names taken by other schemas, which inline structs must not reuse
+	inline    map[string]bool           // inline struct names the schema being built has taken
 }
 
 // NewGoContext initializes empty context with package name
@@ -45,29 +157,174 @@ func NewGoContext(packageName string) *GoContext {
 		Structs:     []*GoStruct{},
 		PackageName: packageName,
 		NeedsTime:   false,
+		Logger:      internal.LoggerOrDiscard(nil),
+		Comments:    internal.CommentStyle{Width: DefaultCommentWidth},
 	}
 }
 
+// clone returns a copy of ctx with its options, for building one schema, and
+// none of the results it collects
+func (ctx *GoContext) clone() *GoContext {
+	local := *ctx
+	local.Structs = nil
+	local.NeedsTime = false
+	local.Errors, local.ErrorSchemas = nil, nil
+	local.Imports = nil
+	local.Shims = nil
+	local.External = nil
+	local.scope = ""
+	local.inline = nil
+	return &local
+}
+
 // BuildGoStructs processes schemas marked as Go-only, build GoStruct for each
 func BuildGoStructs(entries []*parser.SchemaEntry, goTypes map[string]bool, graph *internal.DependencyGraph, ctx *GoContext) error {
 	// Build Go structs for all types marked as Go-only
+	selected := make([]*parser.SchemaEntry, 0, len(entries))
 	for _, entry := range entries {
-		// Skip if not a Go type
-		if !goTypes[entry.Name] {
-			continue
+		if goTypes[entry.Name] {
+			selected = append(selected, entry)
 		}
+	}
 
-		goStruct, err := buildGoStruct(entry.Name, entry.Proxy, graph, ctx)
+	// Inline structs are named after their enclosing struct, so must steer clear
+	// of the structs of component schemas: Order.item is not OrderItem when
+	// OrderItem is a schema
+	reserved := make(map[string]bool, len(selected))
+	for _, entry := range selected {
+		reserved[goStructName(entry)] = true
+	}
+
+	// Each struct is built independently into its own slot, with a private
+	// context so the NeedsTime flag is never written concurrently.
+	structs := make([]*GoStruct, len(selected))
+	locals := make([]*GoContext, len(selected))
+	errs := make([]error, len(selected))
+	build := func(i int, reserved map[string]bool) error {
+		if err := internal.Cancelled(ctx.Ctx); err != nil {
+			return err
+		}
+
+		local := ctx.clone()
+		local.scopeName, local.graph, local.goTypes = selected[i].Name, graph, goTypes
+		local.schema, local.reserved = selected[i].Name, reserved
+		locals[i] = local
+
+		// A schema forced to an existing Go type needs no struct of its own
+		if schema := selected[i].Proxy.Schema(); schema != nil {
+			external, err := goTypeOverride(schema, local)
+			if err != nil {
+				err = fmt.Errorf("schema '%s': %w", selected[i].Name, err)
+				if ctx.CollectErrors {
+					errs[i] = err
+					return nil
+				}
+				return err
+			}
+			if external != "" {
+				local.External = map[string]string{selected[i].Name: external}
+				return nil
+			}
+		}
+
+		goStruct, err := buildGoStruct(selected[i].Name, selected[i].Proxy, graph, local)
 		if err != nil {
+			if ctx.CollectErrors {
+				errs[i] = err
+				return nil
+			}
 			return err
 		}
 
-		ctx.Structs = append(ctx.Structs, goStruct)
+		structs[i] = goStruct
+		return nil
+	}
+	err := internal.ParallelEach(ctx.Concurrency, len(selected), func(i int) error {
+		return build(i, reserved)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Inline structs of different schemas may also share a name, as
+	// Order.itemDetail and OrderItem.detail do; a schema taking a name an
+	// earlier one took is built again around the names taken before it
+	taken := maps.Clone(reserved)
+	for i := range selected {
+		if errs[i] == nil && takesAny(locals[i].inline, taken) {
+			if err := build(i, maps.Clone(taken)); err != nil {
+				return err
+			}
+		}
+		for name := range locals[i].inline {
+			taken[name] = true
+		}
+	}
+
+	for i, goStruct := range structs {
+		if errs[i] != nil {
+			ctx.Errors = append(ctx.Errors, errs[i])
+			ctx.ErrorSchemas = append(ctx.ErrorSchemas, selected[i].Name)
+			continue
+		}
+
+		for name, goType := range locals[i].External {
+			if ctx.External == nil {
+				ctx.External = make(map[string]string)
+			}
+			ctx.External[name] = goType
+		}
+		if goStruct != nil {
+			// Structs for inline objects follow the struct that declares them
+			ctx.Structs = append(ctx.Structs, goStruct)
+			ctx.Structs = append(ctx.Structs, locals[i].Structs...)
+		}
+		if locals[i].NeedsTime && !ctx.NeedsTime {
+			ctx.Logger.Debug(internal.LogImportAdded, "schema", selected[i].Name, "import", "time")
+		}
+		ctx.NeedsTime = ctx.NeedsTime || locals[i].NeedsTime
+		for _, imp := range locals[i].Imports {
+			if !slices.Contains(ctx.Imports, imp) {
+				ctx.Logger.Debug(internal.LogImportAdded, "schema", selected[i].Name, "import", imp)
+				ctx.Imports = append(ctx.Imports, imp)
+			}
+		}
 	}
+	linkVariants(ctx.Structs)
 
 	return nil
 }
 
+// linkVariants sets Discriminates on the variants of interface-style unions,
+// so they write the discriminator a Decode function reads back. A variant
+// without a discriminator value, or of several unions, which one MarshalJSON
+// cannot serve, is marshaled unchanged.
+func linkVariants(structs []*GoStruct) {
+	byName := make(map[string]*GoStruct, len(structs))
+	unions := make(map[string]int)
+	for _, s := range structs {
+		if !s.IsUnion && s.EnumType == "" {
+			byName[s.Name] = s
+		}
+		if s.UnionInterface {
+			for _, variant := range s.UnionVariants {
+				unions[variant]++
+			}
+		}
+	}
+
+	for _, s := range structs {
+		if !s.UnionInterface {
+			continue
+		}
+		for _, variant := range s.UnionVariants {
+			if _, found := s.DiscriminatorValues[variant]; found && unions[variant] == 1 && byName[variant] != nil {
+				byName[variant].Discriminates = s
+			}
+		}
+	}
+}
+
 // buildGoStruct builds Go struct - if oneOf present, create union wrapper; otherwise regular struct
 func buildGoStruct(name string, proxy *base.SchemaProxy, graph *internal.DependencyGraph, ctx *GoContext) (*GoStruct, error) {
 	schema := proxy.Schema()
@@ -75,17 +332,43 @@ func buildGoStruct(name string, proxy *base.SchemaProxy, graph *internal.Depende
 		return nil, fmt.Errorf("schema for '%s' is nil", name)
 	}
 
+	// x-go-name renames the struct without renaming the schema
+	structName, err := internal.NameOverride(schema, "x-go-name")
+	if err != nil {
+		return nil, internal.SchemaError(name, err.Error())
+	}
+	if structName == "" {
+		structName = name
+	}
+
 	goStruct := &GoStruct{
-		Name:        name,
-		Description: schema.Description,
-		Fields:      make([]*GoField, 0),
+		Name:           structName,
+		OriginalSchema: name,
+		Description:    internal.SchemaDoc(schema),
+		Fields:         make([]*GoField, 0),
 	}
 
+	// An enum schema is a named scalar type with a constant per value
+	if internal.IsEnumSchema(schema) {
+		enumType, err := ctx.enumType(schema)
+		if err != nil {
+			return nil, internal.SchemaError(name, err.Error())
+		}
+		goStruct.EnumType = enumType
+		goStruct.EnumValues = enumValues(schema)
+		return goStruct, nil
+	}
+
+	scope := ctx.scope
+	ctx.scope = structName
+	defer func() { ctx.scope = scope }()
+
 	// Check if this is a union type (schema-level oneOf)
 	if len(schema.OneOf) > 0 {
 		// This is a union wrapper - create pointer fields for each variant
 		goStruct.IsUnion = true
-		goStruct.Discriminator = schema.Discriminator.PropertyName
+		goStruct.UnionInterface = ctx.UnionStyle == UnionStyleInterface
+		goStruct.Discriminator = internal.ApplyJSONCase(schema.Discriminator.PropertyName, ctx.JSONTagCase)
 
 		variants := internal.ExtractVariantNames(schema.OneOf)
 		goStruct.UnionVariants = variants
@@ -98,12 +381,40 @@ func buildGoStruct(name string, proxy *base.SchemaProxy, graph *internal.Depende
 		goStruct.DiscriminatorMap = discriminatorMap
 
 		// Create pointer field for each variant
-		for _, variantName := range variants {
+		for i, variantName := range variants {
+			var variantSchema *base.Schema
+			if variant := graph.Schemas()[variantName]; variant != nil {
+				variantSchema = variant.Schema()
+			}
+			if variantSchema != nil && variantSchema.Extensions != nil {
+				if _, found := variantSchema.Extensions.Get("x-go-type"); found {
+					return nil, fmt.Errorf("union '%s': variant '%s' cannot use x-go-type; union variants must be generated structs", name, variantName)
+				}
+			}
+
+			// Variants renamed with x-go-name are referenced by their struct name
+			typeName, err := ctx.refTypeName(variantName, variantSchema)
+			if err != nil {
+				return nil, fmt.Errorf("union '%s': variant '%s': %w", name, variantName, err)
+			}
+			if typeName != variantName {
+				variants[i] = typeName
+				for value, mapped := range discriminatorMap {
+					if mapped == variantName {
+						discriminatorMap[value] = typeName
+					}
+				}
+			}
+
+			if err := ctx.addDiscriminatorValue(goStruct, schema, variantName, typeName, variantSchema); err != nil {
+				return nil, fmt.Errorf("union '%s': variant '%s': %w", name, variantName, err)
+			}
+
 			goStruct.Fields = append(goStruct.Fields, &GoField{
-				Name:      variantName,
-				Type:      "*" + variantName, // Always pointer
-				JSONName:  "-",               // Union types don't marshal fields directly
-				IsPointer: false,             // Pointer already in Type string
+				Name:      typeName,
+				Type:      "*" + typeName, // Always pointer
+				JSONName:  "-",            // Union types don't marshal fields directly
+				IsPointer: false,          // Pointer already in Type string
 			})
 		}
 
@@ -116,6 +427,10 @@ func buildGoStruct(name string, proxy *base.SchemaProxy, graph *internal.Depende
 		return goStruct, nil
 	}
 
+	// used holds the field names taken, and explicit those set by
+	// x-go-field-name, which may not be shared with another field
+	used := make(map[string]bool)
+	explicit := make(map[string]bool)
 	for propName, propProxy := range schema.Properties.FromOldest() {
 		// Get Go type for this property
 		propSchema := propProxy.Schema()
@@ -128,21 +443,89 @@ func buildGoStruct(name string, proxy *base.SchemaProxy, graph *internal.Depende
 			return nil, fmt.Errorf("failed to map type for property '%s' in schema '%s': %w", propName, name, err)
 		}
 
-		// Convert property name to Go field name (PascalCase)
-		fieldName := internal.ToPascalCase(propName)
+		// Convert property name to Go field name (PascalCase with initialisms)
+		fieldName, isExplicit, err := ctx.fieldName(schema, propName)
+		if err != nil {
+			return nil, fmt.Errorf("property '%s' in schema '%s': %w", propName, name, err)
+		}
+		if used[fieldName] && (isExplicit || explicit[fieldName]) {
+			return nil, fmt.Errorf("property '%s' in schema '%s': Go field name '%s' is already used by another property", propName, name, fieldName)
+		}
+		used[fieldName] = true
+		explicit[fieldName] = explicit[fieldName] || isExplicit
 
-		goStruct.Fields = append(goStruct.Fields, &GoField{
+		field := &GoField{
 			Name:        fieldName,
 			Type:        typeName,
-			JSONName:    propName, // Original OpenAPI property name
-			Description: propSchema.Description,
+			JSONName:    internal.ApplyJSONCase(propName, ctx.JSONTagCase), // OpenAPI property name in wire casing
+			Description: ctx.withFormatNote(ctx.withEnumNote(internal.WithAccessNote(internal.SchemaDoc(propSchema), propSchema), propProxy), propSchema),
 			IsPointer:   isPointer, // Not used if Type already has *
-		})
+			OmitEmpty: ctx.OmitEmpty == OmitEmptyAlways ||
+				(ctx.OmitEmpty == OmitEmptyOptional && !slices.Contains(schema.Required, propName)),
+		}
+
+		// A referenced schema's extensions describe the schema, not this field
+		var extension *base.Schema
+		if !propProxy.IsReference() {
+			extension = propSchema
+		}
+		if field.Union, err = ctx.fieldUnion(propProxy); err != nil {
+			return nil, fmt.Errorf("property '%s' in schema '%s': %w", propName, name, err)
+		}
+		if field.Tags, err = ctx.fieldTags(field, extension); err != nil {
+			return nil, fmt.Errorf("property '%s' in schema '%s': %w", propName, name, err)
+		}
+		goStruct.Fields = append(goStruct.Fields, field)
 	}
 
 	return goStruct, nil
 }
 
+// addDiscriminatorValue records the discriminator value union's MarshalJSON
+// writes for a variant, and the variant's field holding it. A variant whose
+// discriminator property is not a plain string is marshaled unchanged.
+func (ctx *GoContext) addDiscriminatorValue(union *GoStruct, schema *base.Schema, variantName, typeName string, variantSchema *base.Schema) error {
+	value := variantName
+	if !schema.Discriminator.Mapping.IsZero() {
+		value = ""
+		for mapped, ref := range schema.Discriminator.Mapping.FromOldest() {
+			if name, err := internal.ExtractReferenceName(ref); err == nil && name == variantName {
+				value = mapped
+				break
+			}
+		}
+	}
+
+	property := schema.Discriminator.PropertyName
+	field := ""
+	if variantSchema != nil && variantSchema.Properties != nil {
+		if proxy, found := variantSchema.Properties.Get(property); found {
+			propSchema := proxy.Schema()
+			if propSchema == nil || proxy.IsReference() || !internal.Contains(propSchema.Type, "string") {
+				return nil
+			}
+			// A scratch context keeps the probe from recording imports
+			goType, _, err := goType(propSchema, property, proxy, &GoContext{TypeMappings: ctx.TypeMappings, WellKnownTypes: ctx.WellKnownTypes, Decimals: ctx.Decimals})
+			if err != nil || goType != "string" {
+				return nil
+			}
+			if field, _, err = ctx.fieldName(variantSchema, property); err != nil {
+				return nil
+			}
+		}
+	}
+
+	if union.DiscriminatorValues == nil {
+		union.DiscriminatorValues = make(map[string]string)
+		union.DiscriminatorFields = make(map[string]string)
+	}
+	union.DiscriminatorValues[typeName] = value
+	if field != "" {
+		union.DiscriminatorFields[typeName] = field
+	}
+	return nil
+}
+
 // buildDiscriminatorMap builds map from discriminator values to type names
 func buildDiscriminatorMap(schema *base.Schema, variants []string, schemas map[string]*base.SchemaProxy) (map[string]string, error) {
 	mapping := make(map[string]string)
@@ -234,6 +617,45 @@ func buildDiscriminatorMap(schema *base.Schema, variants []string, schemas map[s
 
 // goType maps OpenAPI type to Go type using type mapping table
 func goType(schema *base.Schema, propertyName string, propProxy *base.SchemaProxy, ctx *GoContext) (string, bool, error) {
+	// References to schemas generated from another spec use the mapped Go type,
+	// and references to excluded schemas keep the schema's JSON undecoded
+	if propProxy.IsReference() {
+		name, err := internal.ExtractReferenceName(propProxy.GetReference())
+		if mapping, ok := ctx.Imported[name]; err == nil && ok {
+			if mapping.Go == "" {
+				return "", false, fmt.Errorf("property '%s': schema '%s' is import-mapped without a Go type", propertyName, name)
+			}
+			if mapping.GoImport != "" && !slices.Contains(ctx.Imports, mapping.GoImport) {
+				ctx.Imports = append(ctx.Imports, mapping.GoImport)
+			}
+			return mapping.Go, false, nil
+		}
+		if err == nil && ctx.Opaque[name] {
+			return "json.RawMessage", false, nil
+		}
+	}
+
+	// An x-go-type on the property, or on the schema it references, wins
+	override, err := goTypeOverride(schema, ctx)
+	if err != nil {
+		return "", false, fmt.Errorf("property '%s': %w", propertyName, err)
+	}
+	if override != "" {
+		return override, false, nil
+	}
+
+	// Properties proto maps to Struct or Any hold arbitrary JSON in Go; this
+	// includes references to free-form component schemas
+	if ctx.WellKnownTypes.Struct && internal.IsFreeFormObject(schema) {
+		if ctx.FreeFormType != "" {
+			return ctx.FreeFormType, false, nil
+		}
+		return "map[string]any", false, nil
+	}
+	if ctx.WellKnownTypes.Any && internal.IsUndiscriminatedAnyOf(schema) {
+		return "json.RawMessage", false, nil
+	}
+
 	// Check if it's a reference first
 	if propProxy.IsReference() {
 		ref := propProxy.GetReference()
@@ -241,13 +663,34 @@ func goType(schema *base.Schema, propertyName string, propProxy *base.SchemaProx
 		if err != nil {
 			return "", false, fmt.Errorf("property '%s': %w", propertyName, err)
 		}
+		// An enum generated in Go is referenced by value; any other holds its
+		// values in the scalar type underlying it
+		if internal.IsEnumSchema(schema) && !ctx.goTypes[typeName] {
+			scalarType, err := ctx.enumType(schema)
+			if err != nil {
+				return "", false, fmt.Errorf("property '%s': %w", propertyName, err)
+			}
+			return scalarType, false, nil
+		}
+		enum := internal.IsEnumSchema(schema)
+		typeName, err = ctx.refTypeName(typeName, propProxy.Schema())
+		if err != nil {
+			return "", false, fmt.Errorf("property '%s': %w", propertyName, err)
+		}
+		if enum {
+			return typeName, false, nil
+		}
+		// An interface already holds a pointer to the variant
+		if ctx.interfaceUnion(propProxy) {
+			return typeName, false, nil
+		}
 		// Objects/refs are always pointers in Go
 		return "*" + typeName, false, nil
 	}
 
 	// Check if it's an array
 	if len(schema.Type) > 0 && internal.Contains(schema.Type, "array") {
-		arrayType, err := mapGoArrayType(schema, propProxy, ctx)
+		arrayType, err := mapGoArrayType(schema, propertyName, propProxy, ctx)
 		if err != nil {
 			return "", false, err
 		}
@@ -256,8 +699,10 @@ func goType(schema *base.Schema, propertyName string, propProxy *base.SchemaProx
 
 	// Check if it's an inline object
 	if len(schema.Type) > 0 && internal.Contains(schema.Type, "object") {
-		// For inline objects, derive type name from property name
-		typeName := internal.ToPascalCase(propertyName)
+		typeName, err := buildInlineStruct(propertyName, propProxy, ctx)
+		if err != nil {
+			return "", false, err
+		}
 		return "*" + typeName, false, nil
 	}
 
@@ -285,6 +730,10 @@ func goType(schema *base.Schema, propertyName string, propProxy *base.SchemaProx
 	}
 	format := schema.Format
 
+	if decimal, ok := ctx.decimalType(schema, typ); ok {
+		return decimal, false, nil
+	}
+
 	scalarType, err := mapGoScalarType(typ, format, ctx)
 	if err != nil {
 		return "", false, err
@@ -293,8 +742,72 @@ func goType(schema *base.Schema, propertyName string, propProxy *base.SchemaProx
 	return scalarType, false, nil
 }
 
+// goTypeOverride returns the Go type named by the x-go-type extension of schema,
+// recording its x-go-import in ctx.Imports; empty when the extension is absent.
+// The type is used verbatim, so "*decimal.Decimal" yields a pointer field.
+func goTypeOverride(schema *base.Schema, ctx *GoContext) (string, error) {
+	if schema.Extensions == nil {
+		return "", nil
+	}
+
+	typeNode, hasType := schema.Extensions.Get("x-go-type")
+	importNode, hasImport := schema.Extensions.Get("x-go-import")
+	if !hasType || typeNode == nil {
+		if hasImport {
+			return "", fmt.Errorf("x-go-import requires x-go-type")
+		}
+		return "", nil
+	}
+
+	goType := strings.TrimSpace(typeNode.Value)
+	if typeNode.Kind != yaml.ScalarNode || goType == "" {
+		return "", fmt.Errorf("x-go-type must be a non-empty string")
+	}
+
+	if hasImport && importNode != nil {
+		imp := strings.TrimSpace(importNode.Value)
+		if importNode.Kind != yaml.ScalarNode || imp == "" {
+			return "", fmt.Errorf("x-go-import must be a non-empty string")
+		}
+		if !slices.Contains(ctx.Imports, imp) {
+			ctx.Imports = append(ctx.Imports, imp)
+		}
+	}
+
+	return goType, nil
+}
+
+// decimalType returns the Go type of decimal schemas when ctx.Decimals is set:
+// its GoType, or else json.Number for numbers and string for strings, keeping
+// every digit. A TypeMapping for the type and format wins.
+func (ctx *GoContext) decimalType(schema *base.Schema, typ string) (string, bool) {
+	if ctx.Decimals == nil || !internal.IsDecimal(schema) {
+		return "", false
+	}
+	if m := internal.FindTypeMapping(ctx.TypeMappings, typ, schema.Format); m != nil && m.Go != "" {
+		return "", false
+	}
+	if ctx.Decimals.GoType != "" {
+		if imp := ctx.Decimals.GoImport; imp != "" && !slices.Contains(ctx.Imports, imp) {
+			ctx.Imports = append(ctx.Imports, imp)
+		}
+		return ctx.Decimals.GoType, true
+	}
+	if typ == "number" {
+		return "json.Number", true
+	}
+	return "string", true
+}
+
 // mapGoScalarType maps OpenAPI scalars using type table
 func mapGoScalarType(typ, format string, ctx *GoContext) (string, error) {
+	if m := internal.FindTypeMapping(ctx.TypeMappings, typ, format); m != nil && m.Go != "" {
+		if m.GoImport != "" && !slices.Contains(ctx.Imports, m.GoImport) {
+			ctx.Imports = append(ctx.Imports, m.GoImport)
+		}
+		return m.Go, nil
+	}
+
 	switch typ {
 	case "integer":
 		switch format {
@@ -353,8 +866,294 @@ func mapGoScalarType(typ, format string, ctx *GoContext) (string, error) {
 	}
 }
 
-// mapGoArrayType maps arrays to Go slices
-func mapGoArrayType(schema *base.Schema, propProxy *base.SchemaProxy, ctx *GoContext) (string, error) {
+// refTypeName returns the Go type a reference to the component schema refName
+// uses: the x-go-name of a schema generated as a Go struct, the protoc-gen-go
+// name of a proto message's x-proto-name, or refName when neither is set
+func (ctx *GoContext) refTypeName(refName string, resolved *base.Schema) (string, error) {
+	if ctx.goTypes[refName] {
+		override, err := internal.NameOverride(resolved, "x-go-name")
+		if err != nil || override == "" {
+			return refName, err
+		}
+		return override, nil
+	}
+
+	override, err := internal.NameOverride(resolved, "x-proto-name")
+	if err != nil || override == "" {
+		return refName, err
+	}
+	return protocGoName(override), nil
+}
+
+// enumType returns the Go type holding the values of enum schema: the scalar
+// type of its (non-null) type, which must be string or integer
+func (ctx *GoContext) enumType(schema *base.Schema) (string, error) {
+	var types []string
+	for _, t := range schema.Type {
+		if t != "null" {
+			types = append(types, t)
+		}
+	}
+	if len(types) != 1 || (types[0] != "string" && types[0] != "integer") {
+		return "", fmt.Errorf("enum must have type string or integer")
+	}
+	return mapGoScalarType(types[0], schema.Format, ctx)
+}
+
+// enumValues returns the values of an enum schema in order, without null
+func enumValues(schema *base.Schema) []string {
+	values := make([]string, 0, len(schema.Enum))
+	for _, value := range schema.Enum {
+		if value != nil && value.Tag != "!!null" {
+			values = append(values, value.Value)
+		}
+	}
+	return values
+}
+
+// withFormatNote appends the network format of a string property, or of the
+// items of an array property, to its field comment, unless a TypeMapping gives
+// the format a Go type of its own
+func (ctx *GoContext) withFormatNote(description string, schema *base.Schema) string {
+	for schema != nil && internal.Contains(schema.Type, "array") && schema.Items != nil && schema.Items.A != nil {
+		schema = schema.Items.A.Schema()
+	}
+	if schema == nil || !internal.Contains(schema.Type, "string") || !internal.IsNetworkFormat(schema.Format) {
+		return description
+	}
+	if m := internal.FindTypeMapping(ctx.TypeMappings, "string", schema.Format); m != nil && m.Go != "" {
+		return description
+	}
+	return internal.WithFormatNote(description, schema.Format)
+}
+
+// withEnumNote appends the values of the enum a field holds, directly or as
+// array items, to its description, as the proto output does, unless the enum
+// is generated as a Go type whose constants list them
+func (ctx *GoContext) withEnumNote(description string, proxy *base.SchemaProxy) string {
+	schema := proxy.Schema()
+	for schema != nil && !internal.IsEnumSchema(schema) && internal.Contains(schema.Type, "array") && schema.Items != nil && schema.Items.A != nil {
+		proxy = schema.Items.A
+		schema = proxy.Schema()
+	}
+	if schema == nil || !internal.IsEnumSchema(schema) {
+		return description
+	}
+	if proxy.IsReference() {
+		if name, err := internal.ExtractReferenceName(proxy.GetReference()); err == nil && ctx.goTypes[name] {
+			return description
+		}
+	}
+
+	note := "enum: [" + strings.Join(enumValues(schema), ", ") + "]"
+	description = strings.TrimRight(description, "\n")
+	if strings.TrimSpace(description) == "" {
+		return note
+	}
+	return description + "\n" + note
+}
+
+// interfaceUnion reports whether proxy references a union rendered as an
+// interface; unions replaced by x-go-type or excluded are not
+func (ctx *GoContext) interfaceUnion(proxy *base.SchemaProxy) bool {
+	if ctx.UnionStyle != UnionStyleInterface || ctx.graph == nil || !proxy.IsReference() {
+		return false
+	}
+	name, err := internal.ExtractReferenceName(proxy.GetReference())
+	if err != nil || ctx.graph.UnionVariants(name) == nil || ctx.Opaque[name] {
+		return false
+	}
+	if schema := proxy.Schema(); schema != nil && schema.Extensions != nil {
+		if _, found := schema.Extensions.Get("x-go-type"); found {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldUnion returns the Go name of the interface-style union a property holds,
+// directly or as array items; empty for other properties. Unions nested deeper
+// have no generated decoding and are an error.
+func (ctx *GoContext) fieldUnion(proxy *base.SchemaProxy) (string, error) {
+	if ctx.UnionStyle != UnionStyleInterface {
+		return "", nil
+	}
+	for depth := 0; proxy != nil; depth++ {
+		if ctx.interfaceUnion(proxy) {
+			if depth > 1 {
+				return "", fmt.Errorf("nested arrays of union types are not supported with the interface union style")
+			}
+			name, _ := internal.ExtractReferenceName(proxy.GetReference())
+			return ctx.refTypeName(name, proxy.Schema())
+		}
+		schema := proxy.Schema()
+		if schema == nil || proxy.IsReference() || !internal.Contains(schema.Type, "array") || schema.Items == nil {
+			return "", nil
+		}
+		proxy = schema.Items.A
+	}
+	return "", nil
+}
+
+// fieldTags returns the tags field gets after json: one per ctx.ExtraTags key
+// with the json tag's value, then those of the x-oapi-codegen-extra-tags
+// extension of schema, which replace ExtraTags of the same key
+func (ctx *GoContext) fieldTags(field *GoField, schema *base.Schema) ([]StructTag, error) {
+	value := field.JSONName
+	if field.OmitEmpty {
+		value += ",omitempty"
+	}
+	tags := make([]StructTag, 0, len(ctx.ExtraTags))
+	for _, key := range ctx.ExtraTags {
+		tags = append(tags, StructTag{Key: key, Value: value})
+	}
+
+	if schema == nil || schema.Extensions == nil {
+		return tags, nil
+	}
+	node, found := schema.Extensions.Get("x-oapi-codegen-extra-tags")
+	if !found || node == nil {
+		return tags, nil
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("x-oapi-codegen-extra-tags must be a map of tag keys to values")
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i].Value, node.Content[i+1]
+		if err := ValidateTagKey(key); err != nil {
+			return nil, fmt.Errorf("x-oapi-codegen-extra-tags: %w", err)
+		}
+		if value.Kind != yaml.ScalarNode || strings.Contains(value.Value, "`") {
+			return nil, fmt.Errorf("x-oapi-codegen-extra-tags: tag '%s' must be a string without backquotes", key)
+		}
+		index := slices.IndexFunc(tags, func(t StructTag) bool { return t.Key == key })
+		if index < 0 {
+			tags = append(tags, StructTag{Key: key, Value: value.Value})
+			continue
+		}
+		tags[index].Value = value.Value
+	}
+	return tags, nil
+}
+
+// ValidateTagKey reports an error for struct tag keys reflect.StructTag cannot
+// look up, and for json, whose tag is always generated
+func ValidateTagKey(key string) error {
+	if key == "json" {
+		return fmt.Errorf("tag 'json' is always generated")
+	}
+	if key == "" || strings.ContainsFunc(key, func(r rune) bool {
+		return r <= ' ' || r == ':' || r == '"' || r == 0x7f
+	}) {
+		return fmt.Errorf("invalid tag key '%s'", key)
+	}
+	return nil
+}
+
+// goName returns the Go identifier for a property name. Non-ASCII characters
+// are made ASCII under ctx.NonASCIINames first, so CJK and other uncased names
+// still give exported identifiers.
+func (ctx *GoContext) goName(property string) (string, error) {
+	if ctx.GoNameFunc != nil {
+		if name := ctx.GoNameFunc(property); name != "" {
+			return name, nil
+		}
+	}
+	ascii, err := internal.ASCIIName(property, ctx.NonASCIINames)
+	if err != nil {
+		return "", err
+	}
+	return internal.ToGoName(ascii), nil
+}
+
+// fieldName returns the Go field name of property propName of schema: its
+// x-go-field-name, used as given, or else its goName. explicit reports the
+// former.
+func (ctx *GoContext) fieldName(schema *base.Schema, propName string) (name string, explicit bool, err error) {
+	node := internal.PropertyExtension(schema, propName, "x-go-field-name")
+	if node == nil {
+		name, err = ctx.goName(propName)
+		return name, false, err
+	}
+	if node.Kind != yaml.ScalarNode || !internal.IsTypeIdentifier(node.Value) || !unicode.IsUpper(rune(node.Value[0])) {
+		return "", false, fmt.Errorf("x-go-field-name must be an exported Go identifier (letters, digits and underscores, starting with an upper-case letter), got: %s", node.Value)
+	}
+	return node.Value, true, nil
+}
+
+// buildInlineStruct builds the struct for an inline object property or array
+// item, named after the enclosing struct and the property (Order.shipping →
+// OrderShipping) since Go has no nested types. The proto builder has already
+// rejected plural property names NestedNameFunc does not name. The struct is
+// added to ctx.Structs.
+func buildInlineStruct(propertyName string, proxy *base.SchemaProxy, ctx *GoContext) (string, error) {
+	name, err := ctx.goName(propertyName)
+	if err != nil {
+		return "", fmt.Errorf("property '%s': %w", propertyName, err)
+	}
+	if ctx.NestedNameFunc != nil {
+		if custom := ctx.NestedNameFunc(ctx.scopeName, propertyName); custom != "" {
+			name = custom
+		}
+	}
+	typeName := ctx.uniqueStructName(ctx.scope + name)
+
+	scopeName := ctx.scopeName
+	ctx.scopeName = name
+	defer func() { ctx.scopeName = scopeName }()
+
+	// Reserve the slot first so the struct precedes any declared inside it
+	slot := len(ctx.Structs)
+	ctx.Structs = append(ctx.Structs, nil)
+	goStruct, err := buildGoStruct(typeName, proxy, ctx.graph, ctx)
+	if err != nil {
+		return "", err
+	}
+	ctx.Structs[slot] = goStruct
+	return typeName, nil
+}
+
+// uniqueStructName returns name, or name with a numeric suffix (_2, _3, etc.)
+// when another struct has it, and takes it for an inline struct
+func (ctx *GoContext) uniqueStructName(name string) string {
+	unique := name
+	for n := 2; ctx.reserved[unique] || ctx.inline[unique]; n++ {
+		unique = fmt.Sprintf("%s_%d", name, n)
+	}
+	if unique != name {
+		ctx.Logger.Debug(internal.LogNameRenamed, "schema", ctx.schema, "kind", "type", "from", name, "to", unique)
+	}
+	if ctx.inline == nil {
+		ctx.inline = make(map[string]bool)
+	}
+	ctx.inline[unique] = true
+	return unique
+}
+
+// takesAny reports whether names holds a name taken holds
+func takesAny(names, taken map[string]bool) bool {
+	for name := range names {
+		if taken[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// goStructName returns the name of the struct built for entry: its x-go-name,
+// or else the schema name
+func goStructName(entry *parser.SchemaEntry) string {
+	if schema := entry.Proxy.Schema(); schema != nil {
+		if name, err := internal.NameOverride(schema, "x-go-name"); err == nil && name != "" {
+			return name
+		}
+	}
+	return entry.Name
+}
+
+// mapGoArrayType maps arrays to Go slices; inline object items are named after
+// the array property
+func mapGoArrayType(schema *base.Schema, propertyName string, propProxy *base.SchemaProxy, ctx *GoContext) (string, error) {
 	// Check if Items is defined
 	if schema.Items == nil || schema.Items.A == nil {
 		return "", fmt.Errorf("array must have items defined")
@@ -370,7 +1169,7 @@ func mapGoArrayType(schema *base.Schema, propProxy *base.SchemaProxy, ctx *GoCon
 	}
 
 	// Get element type
-	elementType, _, err := goType(itemsSchema, "item", itemsProxy, ctx)
+	elementType, _, err := goType(itemsSchema, propertyName, itemsProxy, ctx)
 	if err != nil {
 		return "", err
 	}
@@ -61,6 +61,7 @@ components:
           type: integer` + formatLine
 
 			result, err := schema.Convert([]byte(given), schema.ConvertOptions{
+				GoFormat:      schema.GoFormatNone,
 				GoPackagePath: "github.com/example/types/v1",
 				PackageName:   "testpkg",
 				PackagePath:   "github.com/example/proto/v1",
@@ -120,6 +121,7 @@ components:
           type: number` + formatLine
 
 			result, err := schema.Convert([]byte(given), schema.ConvertOptions{
+				GoFormat:      schema.GoFormatNone,
 				GoPackagePath: "github.com/example/types/v1",
 				PackageName:   "testpkg",
 				PackagePath:   "github.com/example/proto/v1",
@@ -185,6 +187,7 @@ components:
           type: string` + formatLine
 
 			result, err := schema.Convert([]byte(given), schema.ConvertOptions{
+				GoFormat:      schema.GoFormatNone,
 				GoPackagePath: "github.com/example/types/v1",
 				PackageName:   "testpkg",
 				PackagePath:   "github.com/example/proto/v1",
@@ -250,6 +253,7 @@ components:
             ` + test.itemsType
 
 			result, err := schema.Convert([]byte(given), schema.ConvertOptions{
+				GoFormat:      schema.GoFormatNone,
 				GoPackagePath: "github.com/example/types/v1",
 				PackageName:   "testpkg",
 				PackagePath:   "github.com/example/proto/v1",
@@ -304,6 +308,7 @@ components:
 `
 
 	result, err := schema.Convert([]byte(given), schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
 		GoPackagePath: "github.com/example/types/v1",
 		PackageName:   "testpkg",
 		PackagePath:   "github.com/example/proto/v1",
@@ -355,6 +360,7 @@ components:
 `
 
 	result, err := schema.Convert([]byte(given), schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
 		GoPackagePath: "github.com/example/types/v1",
 		PackageName:   "testpkg",
 		PackagePath:   "github.com/example/proto/v1",
@@ -456,6 +462,7 @@ components:
 `
 
 	result, err := schema.Convert([]byte(given), schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
 		GoPackagePath: "github.com/example/types/v1",
 		PackageName:   "testpkg",
 		PackagePath:   "github.com/example/proto/v1",
@@ -478,7 +485,7 @@ components:
 	assert.Contains(t, goCode, "Float64Val float64")
 	assert.Contains(t, goCode, "StringVal string")
 	assert.Contains(t, goCode, "EmailVal string")
-	assert.Contains(t, goCode, "UuidVal string")
+	assert.Contains(t, goCode, "UUIDVal string")
 	assert.Contains(t, goCode, "PasswordVal string")
 	assert.Contains(t, goCode, "DateVal time.Time")
 	assert.Contains(t, goCode, "DateTimeVal time.Time")
@@ -1,6 +1,7 @@
 package golang_test
 
 import (
+	"strings"
 	"testing"
 
 	schema "github.com/duh-rpc/openapi-schema.go"
@@ -39,6 +40,7 @@ components:
 `
 
 	result, err := schema.Convert([]byte(given), schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
 		GoPackagePath: "github.com/example/types/v1",
 		PackageName:   "testpkg",
 		PackagePath:   "github.com/example/proto/v1",
@@ -109,6 +111,7 @@ components:
 `
 
 	result, err := schema.Convert([]byte(given), schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
 		GoPackagePath: "github.com/example/types/v1",
 		PackageName:   "testpkg",
 		PackagePath:   "github.com/example/proto/v1",
@@ -223,6 +226,7 @@ components:
 `
 
 	result, err := schema.Convert([]byte(given), schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
 		GoPackagePath: "github.com/example/types/v1",
 		PackageName:   "testpkg",
 		PackagePath:   "github.com/example/proto/v1",
@@ -277,6 +281,7 @@ components:
 `
 
 	result, err := schema.Convert([]byte(given), schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
 		GoPackagePath: "github.com/example/types/v1",
 		PackageName:   "testpkg",
 		PackagePath:   "github.com/example/proto/v1",
@@ -332,6 +337,7 @@ components:
 `
 
 	result, err := schema.Convert([]byte(given), schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
 		GoPackagePath: "github.com/example/types/v1",
 		PackageName:   "testpkg",
 		PackagePath:   "github.com/example/proto/v1",
@@ -354,3 +360,151 @@ components:
 	assert.Contains(t, goCode, "type Bird struct")
 	assert.Contains(t, goCode, "Chirp string")
 }
+
+// TestGoInlineObjectStructs validates inline objects and inline array items become structs
+func TestGoInlineObjectStructs(t *testing.T) {
+	given := `openapi: 3.0.0
+info:
+  title: Test
+  version: 1.0.0
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        shipping:
+          type: object
+          properties:
+            street:
+              type: string
+            geo:
+              type: object
+              properties:
+                lat:
+                  type: number
+        line:
+          type: array
+          items:
+            type: object
+            properties:
+              sku:
+                type: string
+              quantity:
+                type: integer
+    Invoice:
+      type: object
+      properties:
+        line:
+          type: array
+          items:
+            type: object
+            properties:
+              amount:
+                type: number
+`
+
+	result, err := schema.ConvertToStruct([]byte(given), schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
+		GoPackagePath: "github.com/example/types/v1",
+	})
+	require.NoError(t, err)
+
+	goCode := string(result.Golang)
+
+	assert.Contains(t, goCode, "Shipping *OrderShipping `json:\"shipping\"`")
+	assert.Contains(t, goCode, "Geo *OrderShippingGeo `json:\"geo\"`")
+	assert.Contains(t, goCode, "Line []*OrderLine `json:\"line\"`")
+	assert.Contains(t, goCode, "Line []*InvoiceLine `json:\"line\"`")
+	assert.Contains(t, goCode, "Sku string `json:\"sku\"`")
+	assert.Contains(t, goCode, "Amount float64 `json:\"amount\"`")
+
+	// Each inline struct follows the struct declaring it
+	order := strings.Index(goCode, "type Order struct")
+	shipping := strings.Index(goCode, "type OrderShipping struct")
+	geo := strings.Index(goCode, "type OrderShippingGeo struct")
+	line := strings.Index(goCode, "type OrderLine struct")
+	require.True(t, order >= 0 && shipping >= 0 && geo >= 0 && line >= 0)
+	assert.True(t, order < shipping && shipping < geo && geo < line)
+	assert.Contains(t, goCode, "type InvoiceLine struct")
+}
+
+// TestGoInlineArrayItemPluralName validates plural array properties are rejected for inline items
+func TestGoInlineArrayItemPluralName(t *testing.T) {
+	given := `openapi: 3.0.0
+info:
+  title: Test
+  version: 1.0.0
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        lines:
+          type: array
+          items:
+            type: object
+            properties:
+              sku:
+                type: string
+`
+
+	_, err := schema.ConvertToStruct([]byte(given), schema.ConvertOptions{
+		GoPackagePath: "github.com/example/types/v1",
+	})
+	require.ErrorContains(t, err, "cannot derive message name from plural array property 'lines'")
+}
+
+// TestGoInlineObjectStructNameCollision validates inline structs are renamed
+// rather than redeclaring a struct of another schema or inline object
+func TestGoInlineObjectStructNameCollision(t *testing.T) {
+	given := `openapi: 3.0.0
+info:
+  title: Test
+  version: 1.0.0
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        item:
+          type: object
+          properties:
+            sku:
+              type: string
+        itemDetail:
+          type: object
+          properties:
+            note:
+              type: string
+    OrderItem:
+      type: object
+      properties:
+        quantity:
+          type: integer
+        detail:
+          type: object
+          properties:
+            color:
+              type: string
+`
+
+	for _, concurrency := range []int{1, 4} {
+		result, err := schema.ConvertToStruct([]byte(given), schema.ConvertOptions{
+			GoFormat:      schema.GoFormatNone,
+			GoPackagePath: "github.com/example/types/v1",
+			Concurrency:   concurrency,
+		})
+		require.NoError(t, err)
+
+		goCode := string(result.Golang)
+
+		assert.Contains(t, goCode, "Item *OrderItem_2 `json:\"item\"`")
+		assert.Contains(t, goCode, "ItemDetail *OrderItemDetail `json:\"itemDetail\"`")
+		assert.Contains(t, goCode, "Detail *OrderItemDetail_2 `json:\"detail\"`")
+		for _, name := range []string{"OrderItem", "OrderItem_2", "OrderItemDetail", "OrderItemDetail_2"} {
+			assert.Equal(t, 1, strings.Count(goCode, "type "+name+" struct"), name)
+		}
+		assert.Regexp(t, "type OrderItem_2 struct \\{\\s+Sku string", goCode)
+		assert.Regexp(t, "type OrderItemDetail_2 struct \\{\\s+Color string", goCode)
+	}
+}
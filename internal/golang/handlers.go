@@ -0,0 +1,145 @@
+package golang
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+	"text/template"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+)
+
+// duhImport is the duh-rpc runtime the generated handler calls
+const duhImport = "github.com/duh-rpc/duh-go"
+
+// HandlerRoute is one duh-rpc operation served by a generated handler
+type HandlerRoute struct {
+	Name     string // service method, and suffix of the Route constant
+	Path     string
+	Summary  string
+	Request  string // proto message name of the request
+	Response string // proto message name of the response
+}
+
+// HandlerRouteName derives a route's Go name from its operationId, or from the
+// last segment of its path when there is none: users.create → UsersCreate
+func HandlerRouteName(operationID, path string) string {
+	name := operationID
+	if name == "" {
+		name = path[strings.LastIndex(path, "/")+1:]
+	}
+	name = strings.NewReplacer(".", "_", "-", "_", " ", "_").Replace(name)
+	return internal.ToPascalCase(name)
+}
+
+// GenerateHandlers renders a duh-rpc handler in packageName serving routes. The
+// handler routes POST requests by path to a serviceName interface, whose methods
+// take the protoc-generated request and response types from typesPath.
+func GenerateHandlers(packageName, serviceName, typesPath string, routes []*HandlerRoute) ([]byte, error) {
+	tmpl, err := template.New("handlers").Funcs(template.FuncMap{
+		"goType": protocGoName,
+		"quote":  func(s string) string { return fmt.Sprintf("%q", s) },
+	}).Parse(handlersTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse handler template: %w", err)
+	}
+
+	data := struct {
+		PackageName  string
+		ServiceName  string
+		TypesPackage string
+		TypesPath    string
+		DuhImport    string
+		Routes       []*HandlerRoute
+	}{
+		PackageName:  packageName,
+		ServiceName:  serviceName,
+		TypesPackage: ExtractPackageName(typesPath),
+		TypesPath:    typesPath,
+		DuhImport:    duhImport,
+		Routes:       routes,
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute handler template: %w", err)
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format handlers: %w", err)
+	}
+	return formatted, nil
+}
+
+const handlersTemplate = `package {{.PackageName}}
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"{{.DuhImport}}"
+	{{.TypesPackage}} "{{.TypesPath}}"
+)
+
+// Paths of the operations Handler serves
+const (
+{{- range .Routes}}
+	Route{{.Name}} = {{quote .Path}}
+{{- end}}
+)
+
+// {{.ServiceName}} implements the operations Handler serves. Each method fills
+// resp from req; a returned error is sent to the client with duh.ReplyError.
+type {{.ServiceName}} interface {
+{{- range .Routes}}
+{{- if .Summary}}
+	// {{.Name}} {{.Summary}}
+{{- end}}
+	{{.Name}}(ctx context.Context, req *{{$.TypesPackage}}.{{goType .Request}}, resp *{{$.TypesPackage}}.{{goType .Response}}) error
+{{- end}}
+}
+
+// Handler serves {{.ServiceName}} over duh-rpc
+type Handler struct {
+	Service {{.ServiceName}}
+}
+
+// NewHandler returns a Handler calling service
+func NewHandler(service {{.ServiceName}}) *Handler {
+	return &Handler{Service: service}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		duh.ReplyWithCode(w, r, duh.CodeBadRequest, nil,
+			fmt.Sprintf("http method '%s' not allowed; only POST", r.Method))
+		return
+	}
+
+	switch r.URL.Path {
+{{- range .Routes}}
+	case Route{{.Name}}:
+		h.handle{{.Name}}(w, r)
+		return
+{{- end}}
+	}
+	duh.ReplyWithCode(w, r, duh.CodeNotImplemented, nil, "no such method; "+r.URL.Path)
+}
+{{range .Routes}}
+func (h *Handler) handle{{.Name}}(w http.ResponseWriter, r *http.Request) {
+	var req {{$.TypesPackage}}.{{goType .Request}}
+	if err := duh.ReadRequest(r, &req); err != nil {
+		duh.ReplyError(w, r, err)
+		return
+	}
+	var resp {{$.TypesPackage}}.{{goType .Response}}
+	if err := h.Service.{{.Name}}(r.Context(), &req, &resp); err != nil {
+		duh.ReplyError(w, r, err)
+		return
+	}
+	duh.Reply(w, r, duh.CodeOK, &resp)
+}
+{{end}}`
@@ -0,0 +1,384 @@
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/duh-rpc/openapi-schema.go/internal/proto"
+)
+
+// ShimSuffix is appended to a Go-located struct's name to name the proto message
+// generated as its protoc counterpart (Dog → DogProto).
+const ShimSuffix = "Proto"
+
+// timestamppbImport provides the Timestamp type shims convert time.Time to
+const timestamppbImport = "google.golang.org/protobuf/types/known/timestamppb"
+
+// Shim pairs a Go-located struct with a proto message carrying the same fields,
+// so ToProto/FromProto conversion functions can be generated between them.
+type Shim struct {
+	Struct  *GoStruct
+	Message *proto.ProtoMessage // message emitted into the proto output for Struct
+	fields  []*shimField
+}
+
+// shimField describes how one Go field maps onto the protoc-generated field.
+type shimField struct {
+	goName    string // field name on the Go struct
+	protoName string // field name on the protoc-generated Go type
+	repeated  bool
+	kind      shimKind
+	goElem    string // Go element type (slice element when repeated)
+	protoElem string // protoc-generated Go element type
+}
+
+type shimKind int
+
+const (
+	shimDirect shimKind = iota // identical Go types on both sides
+	shimCast                   // numeric types of different width
+	shimTime                   // time.Time ↔ *timestamppb.Timestamp
+	shimNested                 // another shimmed struct, converted recursively
+)
+
+// protoGoScalars maps proto scalar types to the Go types protoc-gen-go emits.
+var protoGoScalars = map[string]string{
+	"string":   "string",
+	"bool":     "bool",
+	"bytes":    "[]byte",
+	"int32":    "int32",
+	"int64":    "int64",
+	"uint32":   "uint32",
+	"uint64":   "uint64",
+	"sint32":   "int32",
+	"sint64":   "int64",
+	"fixed32":  "uint32",
+	"fixed64":  "uint64",
+	"sfixed32": "int32",
+	"sfixed64": "int64",
+	"float":    "float32",
+	"double":   "float64",
+}
+
+var goNumericTypes = map[string]bool{
+	"int8": true, "int16": true, "int32": true, "int64": true,
+	"uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"float32": true, "float64": true,
+}
+
+// BuildShims selects the non-union structs in ctx whose proto message (built for
+// the same schema but left out of the proto output) has exactly the same fields,
+// and records a Shim for each in ctx.Shims. A struct qualifies only if every field
+// pairs with a proto field of a convertible type; inline objects, array-of-array
+// wrappers, enums and references to unions or other non-qualifying structs
+// disqualify it. It returns the shim messages to append to the proto output, named
+// with ShimSuffix and made unique against tracker.
+func BuildShims(ctx *GoContext, messages []*proto.ProtoMessage, enums []*proto.ProtoEnum, tracker *internal.NameTracker) []*proto.ProtoMessage {
+	bySchema := make(map[string]*proto.ProtoMessage, len(messages))
+	for _, msg := range messages {
+		bySchema[msg.OriginalSchema] = msg
+	}
+	enumNames := make(map[string]bool, len(enums))
+	for _, enum := range enums {
+		enumNames[enum.Name] = true
+	}
+	goNames := make(map[string]bool, len(ctx.Structs))
+	goSchemas := make(map[string]bool, len(ctx.Structs))
+	for _, s := range ctx.Structs {
+		goNames[s.Name] = true
+		goSchemas[s.OriginalSchema] = true
+	}
+
+	// byType finds the message behind a Go field type: a Go struct's own message,
+	// or the protoc-generated type of a proto-located message
+	byType := make(map[string]*proto.ProtoMessage, len(messages))
+	for _, msg := range messages {
+		if !goSchemas[msg.OriginalSchema] {
+			byType[protocGoName(msg.Name)] = msg
+		}
+	}
+	for _, s := range ctx.Structs {
+		if msg := bySchema[s.OriginalSchema]; msg != nil && s.OriginalSchema != "" {
+			byType[s.Name] = msg
+		}
+	}
+
+	candidates := make(map[string]bool)
+	for _, s := range ctx.Structs {
+		msg := bySchema[s.OriginalSchema]
+		if s.OriginalSchema == "" || s.IsUnion || msg == nil || len(msg.Nested) > 0 || len(msg.Oneofs) > 0 || len(msg.Fields) != len(s.Fields) {
+			continue
+		}
+		candidates[s.Name] = true
+	}
+
+	// Dropping a candidate can disqualify the structs that reference it, so
+	// repeat until the set is stable
+	pair := func(s *GoStruct) ([]*shimField, bool) {
+		msg := bySchema[s.OriginalSchema]
+		fields := make([]*shimField, len(s.Fields))
+		for i, gf := range s.Fields {
+			f, ok := pairField(gf, msg.Fields[i], byType, enumNames, goNames, candidates)
+			if !ok {
+				return nil, false
+			}
+			fields[i] = f
+		}
+		return fields, true
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, s := range ctx.Structs {
+			if !candidates[s.Name] {
+				continue
+			}
+			if _, ok := pair(s); !ok {
+				delete(candidates, s.Name)
+				changed = true
+			}
+		}
+	}
+
+	shimNames := make(map[string]string, len(candidates))
+	for _, s := range ctx.Structs {
+		if candidates[s.Name] {
+			name := bySchema[s.OriginalSchema].Name
+			shimNames[name] = tracker.UniqueName(name + ShimSuffix)
+		}
+	}
+
+	var shimMessages []*proto.ProtoMessage
+	for _, s := range ctx.Structs {
+		if !candidates[s.Name] {
+			continue
+		}
+		msg := bySchema[s.OriginalSchema]
+		fields, _ := pair(s)
+
+		shimMsg := &proto.ProtoMessage{
+			Name:           shimNames[msg.Name],
+			Description:    msg.Description,
+			Reserved:       msg.Reserved,
+			ReservedNames:  msg.ReservedNames,
+			OriginalSchema: msg.OriginalSchema,
+		}
+		for i, pf := range msg.Fields {
+			field := *pf
+			if fields[i].kind == shimNested {
+				field.Type = shimNames[pf.Type]
+				fields[i].protoElem = "*" + protocGoName(field.Type)
+			}
+			shimMsg.Fields = append(shimMsg.Fields, &field)
+		}
+
+		ctx.Shims = append(ctx.Shims, &Shim{Struct: s, Message: shimMsg, fields: fields})
+		shimMessages = append(shimMessages, shimMsg)
+	}
+
+	return shimMessages
+}
+
+// pairField reports whether a Go field and a proto field carry the same value and
+// how to convert between them.
+func pairField(gf *GoField, pf *proto.ProtoField, byType map[string]*proto.ProtoMessage,
+	enumNames, goNames, candidates map[string]bool) (*shimField, bool) {
+	f := &shimField{
+		goName:    gf.Name,
+		protoName: protocGoName(pf.Name),
+		repeated:  pf.Repeated,
+		goElem:    gf.Type,
+	}
+
+	// protoc-gen-go emits optional scalars as pointers
+	if pf.Optional {
+		return nil, false
+	}
+
+	if pf.Repeated {
+		if !strings.HasPrefix(gf.Type, "[]") {
+			return nil, false
+		}
+		f.goElem = strings.TrimPrefix(gf.Type, "[]")
+	}
+
+	if goScalar, ok := protoGoScalars[pf.Type]; ok {
+		f.protoElem = goScalar
+		switch {
+		case f.goElem == goScalar:
+			f.kind = shimDirect
+		case goNumericTypes[f.goElem] && goNumericTypes[goScalar]:
+			f.kind = shimCast
+		default:
+			return nil, false
+		}
+		return f, true
+	}
+
+	if pf.Type == "google.protobuf.Timestamp" {
+		if f.goElem != "time.Time" {
+			return nil, false
+		}
+		f.kind = shimTime
+		f.protoElem = "*timestamppb.Timestamp"
+		return f, true
+	}
+
+	if enumNames[pf.Type] || !strings.HasPrefix(f.goElem, "*") {
+		return nil, false
+	}
+
+	target := strings.TrimPrefix(f.goElem, "*")
+	msg := byType[target]
+	if msg == nil || msg.Name != pf.Type {
+		return nil, false
+	}
+
+	if goNames[target] {
+		if !candidates[target] {
+			return nil, false
+		}
+		f.kind = shimNested
+		return f, true
+	}
+
+	// A proto-located message is the protoc-generated type the Go struct already uses
+	if protocGoName(msg.Name) != target {
+		return nil, false
+	}
+	f.kind = shimDirect
+	f.protoElem = f.goElem
+	return f, true
+}
+
+// protocGoName returns the Go identifier protoc-gen-go derives from a proto
+// field or message name: underscores before lowercase letters are dropped and
+// the following letter upper-cased. Examples: pet_type → PetType, petType → PetType
+func protocGoName(s string) string {
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '_' && i == 0:
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(s) && isASCIILower(s[i+1]):
+			// skip; the next letter is upper-cased below
+		case isASCIIDigit(c):
+			b = append(b, c)
+		default:
+			if isASCIILower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			for ; i+1 < len(s) && isASCIILower(s[i+1]); i++ {
+				b = append(b, s[i+1])
+			}
+		}
+	}
+	return string(b)
+}
+
+func isASCIILower(c byte) bool { return 'a' <= c && c <= 'z' }
+func isASCIIDigit(c byte) bool { return '0' <= c && c <= '9' }
+
+// renderShim renders the ToProto method and FromProto function for a shim
+func renderShim(shim *Shim) string {
+	var result strings.Builder
+	name := shim.Struct.Name
+	protoName := protocGoName(shim.Message.Name)
+
+	result.WriteString(fmt.Sprintf("// ToProto converts %s to the protoc-generated %s.\n", name, protoName))
+	result.WriteString(fmt.Sprintf("func (x *%s) ToProto() *%s {\n", name, protoName))
+	result.WriteString("\tif x == nil {\n\t\treturn nil\n\t}\n")
+	result.WriteString(fmt.Sprintf("\tp := &%s{}\n", protoName))
+	for _, f := range shim.fields {
+		result.WriteString(renderShimAssign("p."+f.protoName, "x."+f.goName, f.protoElem, f.repeated, f.toProto))
+	}
+	result.WriteString("\treturn p\n}\n\n")
+
+	result.WriteString(fmt.Sprintf("// %sFromProto converts the protoc-generated %s to %s.\n", name, protoName, name))
+	result.WriteString(fmt.Sprintf("func %sFromProto(p *%s) *%s {\n", name, protoName, name))
+	result.WriteString("\tif p == nil {\n\t\treturn nil\n\t}\n")
+	result.WriteString(fmt.Sprintf("\tx := &%s{}\n", name))
+	for _, f := range shim.fields {
+		result.WriteString(renderShimAssign("x."+f.goName, "p."+f.protoName, f.goElem, f.repeated, f.fromProto))
+	}
+	result.WriteString("\treturn x\n}\n")
+
+	return result.String()
+}
+
+// renderShimAssign assigns src to dst, converting each element with convert.
+// Slices whose elements need no conversion are assigned as a whole.
+func renderShimAssign(dst, src, dstElem string, repeated bool, convert func(string) string) string {
+	if !repeated {
+		return fmt.Sprintf("\t%s = %s\n", dst, convert(src))
+	}
+	if convert("v") == "v" {
+		return fmt.Sprintf("\t%s = %s\n", dst, src)
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("\tif %s != nil {\n", src))
+	result.WriteString(fmt.Sprintf("\t\t%s = make([]%s, len(%s))\n", dst, dstElem, src))
+	result.WriteString(fmt.Sprintf("\t\tfor i, v := range %s {\n", src))
+	result.WriteString(fmt.Sprintf("\t\t\t%s[i] = %s\n", dst, convert("v")))
+	result.WriteString("\t\t}\n\t}\n")
+	return result.String()
+}
+
+// toProto returns the expression converting a Go element v to its proto form
+func (f *shimField) toProto(v string) string {
+	switch f.kind {
+	case shimCast:
+		return fmt.Sprintf("%s(%s)", f.protoElem, v)
+	case shimTime:
+		return fmt.Sprintf("timeToProto(%s)", v)
+	case shimNested:
+		return v + ".ToProto()"
+	}
+	return v
+}
+
+// fromProto returns the expression converting a proto element v to its Go form
+func (f *shimField) fromProto(v string) string {
+	switch f.kind {
+	case shimCast:
+		return fmt.Sprintf("%s(%s)", f.goElem, v)
+	case shimTime:
+		return fmt.Sprintf("timeFromProto(%s)", v)
+	case shimNested:
+		return fmt.Sprintf("%sFromProto(%s)", strings.TrimPrefix(f.goElem, "*"), v)
+	}
+	return v
+}
+
+// usesTime reports whether any shim converts a timestamp
+func usesTime(shims []*Shim) bool {
+	for _, shim := range shims {
+		for _, f := range shim.fields {
+			if f.kind == shimTime {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// shimTimeHelpers keeps the zero time.Time and a nil Timestamp equivalent, which
+// timestamppb.New and AsTime alone do not.
+const shimTimeHelpers = `
+func timeToProto(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func timeFromProto(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+`
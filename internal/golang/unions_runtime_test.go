@@ -759,8 +759,8 @@ func main() {
 		fmt.Fprintf(os.Stderr, "order unmarshal error: %v\n", err)
 		os.Exit(1)
 	}
-	if order.OrderId != "123" {
-		fmt.Fprintf(os.Stderr, "expected orderId=123, got %s\n", order.OrderId)
+	if order.OrderID != "123" {
+		fmt.Fprintf(os.Stderr, "expected orderId=123, got %s\n", order.OrderID)
 		os.Exit(1)
 	}
 
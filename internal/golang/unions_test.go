@@ -47,6 +47,7 @@ components:
 `
 
 	result, err := schema.Convert([]byte(given), schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
 		GoPackagePath: "github.com/example/types/v1",
 		PackageName:   "testpkg",
 		PackagePath:   "github.com/example/proto/v1",
@@ -69,7 +70,7 @@ components:
 	assert.Contains(t, goCode, "type Bird struct")
 
 	// Verify MarshalJSON and UnmarshalJSON are generated for Pet
-	assert.Contains(t, goCode, "func (u *Pet) MarshalJSON()")
+	assert.Contains(t, goCode, "func (u Pet) MarshalJSON()")
 	assert.Contains(t, goCode, "func (u *Pet) UnmarshalJSON(")
 
 	// Verify all types are Go-only
@@ -123,6 +124,7 @@ components:
 `
 
 	result, err := schema.Convert([]byte(given), schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
 		GoPackagePath: "github.com/example/types/v1",
 		PackageName:   "testpkg",
 		PackagePath:   "github.com/example/proto/v1",
@@ -344,6 +346,7 @@ components:
 `
 
 	result, err := schema.Convert([]byte(given), schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
 		GoPackagePath: "github.com/example/types/v1",
 		PackageName:   "testpkg",
 		PackagePath:   "github.com/example/proto/v1",
@@ -458,6 +461,7 @@ components:
 `
 
 	result, err := schema.Convert([]byte(given), schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
 		GoPackagePath: "github.com/example/types/v1",
 		PackageName:   "testpkg",
 		PackagePath:   "github.com/example/proto/v1",
@@ -489,8 +493,8 @@ components:
 	assert.Contains(t, goCode, "type Standard struct")
 
 	// Verify MarshalJSON for both unions
-	assert.Contains(t, goCode, "func (u *Payment) MarshalJSON()")
-	assert.Contains(t, goCode, "func (u *Shipping) MarshalJSON()")
+	assert.Contains(t, goCode, "func (u Payment) MarshalJSON()")
+	assert.Contains(t, goCode, "func (u Shipping) MarshalJSON()")
 
 	// Verify Order references multiple unions
 	require.NotNil(t, result.TypeMap)
@@ -0,0 +1,154 @@
+package internal
+
+import (
+	"strings"
+	"unicode"
+)
+
+// uncountables are nouns whose singular and plural forms are the same
+var uncountables = map[string]bool{
+	"data": true, "metadata": true, "information": true, "equipment": true,
+	"news": true, "series": true, "species": true, "means": true, "media": true,
+	"software": true, "hardware": true, "firmware": true, "feedback": true,
+	"money": true, "advice": true, "evidence": true, "knowledge": true,
+	"traffic": true, "sheep": true, "fish": true, "deer": true, "aircraft": true,
+	"analytics": true, "physics": true, "mathematics": true, "economics": true,
+	"politics": true, "ethics": true, "logistics": true, "graphics": true,
+}
+
+// irregularPlurals maps plurals that no suffix rule singularizes correctly
+var irregularPlurals = map[string]string{
+	"people": "person", "children": "child", "men": "man", "women": "woman",
+	"mice": "mouse", "geese": "goose", "feet": "foot", "teeth": "tooth",
+	"criteria": "criterion", "phenomena": "phenomenon",
+	"indices": "index", "matrices": "matrix", "vertices": "vertex", "appendices": "appendix",
+	"analyses": "analysis", "crises": "crisis", "theses": "thesis", "diagnoses": "diagnosis",
+	"hypotheses": "hypothesis", "parentheses": "parenthesis", "synopses": "synopsis",
+	"axes": "axis", "quizzes": "quiz",
+	"leaves": "leaf", "wolves": "wolf", "knives": "knife", "lives": "life",
+	"halves": "half", "shelves": "shelf", "thieves": "thief", "wives": "wife",
+	"calves": "calf", "loaves": "loaf", "scarves": "scarf", "elves": "elf", "selves": "self",
+	"cacti": "cactus", "fungi": "fungus", "radii": "radius", "nuclei": "nucleus",
+	"alumni": "alumnus", "syllabi": "syllabus", "stimuli": "stimulus",
+	"heroes": "hero", "potatoes": "potato", "tomatoes": "tomato", "echoes": "echo",
+	"vetoes": "veto", "torpedoes": "torpedo", "embargoes": "embargo",
+}
+
+// singularsEndingInS are singular nouns ending in "s" that the -ss, -us and -is
+// rules do not already cover. Their plurals add "es" (aliases, buses).
+var singularsEndingInS = map[string]bool{
+	"alias": true, "atlas": true, "bias": true, "canvas": true, "gas": true,
+	"lens": true, "chaos": true, "kudos": true, "thermos": true, "cosmos": true,
+	"ethos": true, "pathos": true, "yes": true, "this": true,
+	"status": true, "bus": true, "campus": true, "virus": true, "bonus": true,
+	"census": true, "focus": true, "radius": true, "nexus": true, "plus": true,
+	"corpus": true, "apparatus": true, "prospectus": true,
+}
+
+// pluralsEndingInUsOrIs are plurals the -us and -is rules would take as singular
+var pluralsEndingInUsOrIs = map[string]bool{
+	"menus": true, "gurus": true, "emus": true, "tutus": true, "haikus": true,
+	"taxis": true, "skis": true, "kiwis": true, "alibis": true, "bikinis": true,
+	"safaris": true, "semis": true,
+}
+
+// iesPlurals are plurals ending in "ies" whose singular ends in "ie", not "y"
+var iesPlurals = map[string]bool{
+	"movies": true, "cookies": true, "calories": true, "pies": true, "ties": true,
+	"lies": true, "zombies": true, "rookies": true, "selfies": true,
+	"brownies": true, "smoothies": true, "goalies": true, "freebies": true,
+}
+
+// IsPlural reports whether the last word of an identifier is an English plural.
+// Words that merely end in "s" (status, address, analysis, alias) are singular.
+// Examples: contacts → true, addresses → true, shippingAddress → false, data → false
+func IsPlural(s string) bool {
+	_, word := splitLastWord(s)
+	if word == "" {
+		return false
+	}
+	return singularOf(strings.ToLower(word)) != strings.ToLower(word)
+}
+
+// Singularize returns an identifier with its last word made singular, keeping the
+// leading words and the letters' case. Words that are already singular are
+// returned unchanged.
+// Examples: addresses → address, categories → category, lineItems → lineItem,
+// salesPeople → salesPerson, statuses → status
+func Singularize(s string) string {
+	prefix, word := splitLastWord(s)
+	if word == "" {
+		return s
+	}
+
+	lower := strings.ToLower(word)
+	singular := singularOf(lower)
+	if singular == lower {
+		return s
+	}
+
+	// Keep the original letters the singular shares with the plural, so only the
+	// changed ending takes the case of the rest of the word
+	shared := 0
+	for shared < len(singular) && shared < len(lower) && singular[shared] == lower[shared] {
+		shared++
+	}
+	ending := singular[shared:]
+	if word == strings.ToUpper(word) {
+		ending = strings.ToUpper(ending)
+	}
+	if shared == 0 && unicode.IsUpper(rune(word[0])) {
+		ending = strings.ToUpper(ending[:1]) + ending[1:]
+	}
+	return prefix + word[:shared] + ending
+}
+
+// singularOf returns the singular of a lower-case English word
+func singularOf(w string) string {
+	if uncountables[w] || singularsEndingInS[w] {
+		return w
+	}
+	if singular, ok := irregularPlurals[w]; ok {
+		return singular
+	}
+
+	switch {
+	case strings.HasSuffix(w, "ss"):
+		return w
+	case (strings.HasSuffix(w, "us") || strings.HasSuffix(w, "is")) && !pluralsEndingInUsOrIs[w]:
+		return w
+	case strings.HasSuffix(w, "ies") && len(w) > 3:
+		if iesPlurals[w] {
+			return w[:len(w)-1]
+		}
+		return w[:len(w)-3] + "y"
+	case strings.HasSuffix(w, "sses"), strings.HasSuffix(w, "xes"),
+		strings.HasSuffix(w, "ches"), strings.HasSuffix(w, "shes"), strings.HasSuffix(w, "zzes"):
+		return w[:len(w)-2]
+	case strings.HasSuffix(w, "ses") && singularsEndingInS[w[:len(w)-2]]:
+		return w[:len(w)-2]
+	case strings.HasSuffix(w, "s") && len(w) > 1:
+		return w[:len(w)-1]
+	}
+	return w
+}
+
+// splitLastWord splits an identifier before its last word, which starts after the
+// last '_', '-' or '.' separator or at the last lower-to-upper case change.
+// Examples: lineItems → (line, Items), line_items → (line_, items), URLs → ("", URLs)
+func splitLastWord(s string) (string, string) {
+	start := 0
+	for i := 1; i < len(s); i++ {
+		prev, c := s[i-1], s[i]
+		switch {
+		case prev == '_' || prev == '-' || prev == '.':
+			start = i
+		case 'A' <= c && c <= 'Z' && 'a' <= prev && prev <= 'z':
+			start = i
+		}
+	}
+	if start < len(s) && (s[start] == '_' || s[start] == '-' || s[start] == '.') {
+		return s, ""
+	}
+	return s[:start], s[start:]
+}
@@ -0,0 +1,51 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSingularize(t *testing.T) {
+	for _, test := range []struct {
+		given    string
+		expected string
+		plural   bool
+	}{
+		{given: "contacts", expected: "contact", plural: true},
+		{given: "addresses", expected: "address", plural: true},
+		{given: "categories", expected: "category", plural: true},
+		{given: "boxes", expected: "box", plural: true},
+		{given: "statuses", expected: "status", plural: true},
+		{given: "aliases", expected: "alias", plural: true},
+		{given: "causes", expected: "cause", plural: true},
+		{given: "movies", expected: "movie", plural: true},
+		{given: "people", expected: "person", plural: true},
+		{given: "leaves", expected: "leaf", plural: true},
+		{given: "menus", expected: "menu", plural: true},
+		{given: "lineItems", expected: "lineItem", plural: true},
+		{given: "line_items", expected: "line_item", plural: true},
+		{given: "salesPeople", expected: "salesPerson", plural: true},
+		{given: "Categories", expected: "Category", plural: true},
+		{given: "URLs", expected: "URL", plural: true},
+		{given: "ADDRESSES", expected: "ADDRESS", plural: true},
+		{given: "status", expected: "status"},
+		{given: "address", expected: "address"},
+		{given: "shippingAddress", expected: "shippingAddress"},
+		{given: "analysis", expected: "analysis"},
+		{given: "alias", expected: "alias"},
+		{given: "bonus", expected: "bonus"},
+		{given: "data", expected: "data"},
+		{given: "news", expected: "news"},
+		{given: "series", expected: "series"},
+		{given: "contact", expected: "contact"},
+		{given: "", expected: ""},
+		{given: "items_", expected: "items_"},
+	} {
+		t.Run(test.given, func(t *testing.T) {
+			assert.Equal(t, test.expected, internal.Singularize(test.given))
+			assert.Equal(t, test.plural, internal.IsPlural(test.given))
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"go.yaml.in/yaml/v4"
+)
+
+// KnownSchema is a registry entry recognising component schemas that duplicate
+// a shared type, so they are imported rather than generated again
+type KnownSchema struct {
+	ImportMapping
+	// Properties is the signature schemas must match: each property name, compared
+	// ignoring case, underscores and hyphens, mapped to its OpenAPI type, or to ""
+	// to accept any type. Matching schemas have exactly these properties.
+	Properties map[string]string
+}
+
+// DefaultKnownSchemas returns the registry of google.type messages recognised
+// by default
+func DefaultKnownSchemas() []KnownSchema {
+	return []KnownSchema{
+		{
+			ImportMapping: ImportMapping{Proto: "google.type.Money", ProtoImport: "google/type/money.proto"},
+			// units is an int64, which proto3 JSON writes as a string
+			Properties: map[string]string{"currencyCode": "string", "units": "", "nanos": "integer"},
+		},
+		{
+			ImportMapping: ImportMapping{Proto: "google.type.Date", ProtoImport: "google/type/date.proto"},
+			Properties:    map[string]string{"year": "integer", "month": "integer", "day": "integer"},
+		},
+		{
+			ImportMapping: ImportMapping{Proto: "google.type.TimeOfDay", ProtoImport: "google/type/timeofday.proto"},
+			Properties:    map[string]string{"hours": "integer", "minutes": "integer", "seconds": "integer", "nanos": "integer"},
+		},
+		{
+			ImportMapping: ImportMapping{Proto: "google.type.LatLng", ProtoImport: "google/type/latlng.proto"},
+			Properties:    map[string]string{"latitude": "number", "longitude": "number"},
+		},
+		{
+			ImportMapping: ImportMapping{Proto: "google.type.Fraction", ProtoImport: "google/type/fraction.proto"},
+			// numerator and denominator are int64s, which proto3 JSON writes as strings
+			Properties: map[string]string{"numerator": "", "denominator": ""},
+		},
+	}
+}
+
+// ValidateKnownSchemas rejects registry entries without a proto type, import or
+// signature
+func ValidateKnownSchemas(known []KnownSchema) error {
+	for i, k := range known {
+		if k.Proto == "" || k.ProtoImport == "" {
+			return fmt.Errorf("known schema %d must set Proto and ProtoImport", i)
+		}
+		if len(k.Properties) == 0 {
+			return fmt.Errorf("known schema %s must set Properties", k.Proto)
+		}
+	}
+	return nil
+}
+
+// Matches reports whether schema is an object with exactly the properties of
+// k's signature, each of the type it names
+func (k KnownSchema) Matches(schema *base.Schema) bool {
+	if schema == nil || !Contains(schema.Type, "object") || schema.Properties == nil ||
+		schema.Properties.Len() != len(k.Properties) ||
+		len(schema.OneOf) > 0 || len(schema.AnyOf) > 0 || len(schema.AllOf) > 0 {
+		return false
+	}
+
+	signature := make(map[string]string, len(k.Properties))
+	for name, typ := range k.Properties {
+		signature[FoldName(name)] = typ
+	}
+	for name, proxy := range schema.Properties.FromOldest() {
+		typ, ok := signature[FoldName(name)]
+		if !ok {
+			return false
+		}
+		if typ == "" {
+			continue
+		}
+		prop := proxy.Schema()
+		if prop == nil || !Contains(prop.Type, typ) {
+			return false
+		}
+	}
+	return true
+}
+
+// FoldName folds a property name so camelCase, snake_case and kebab-case
+// spellings compare equal
+func FoldName(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+}
+
+// KnownImport returns the import mapping of a component schema: the type named
+// by its x-proto-import extension, with x-proto-type or else the registry entry
+// for that import giving the proto type, or with match set, the first registry
+// entry whose signature it matches. The schema's x-go-type and x-go-import,
+// when set, give the Go type.
+func KnownImport(schema *base.Schema, known []KnownSchema, match bool) (ImportMapping, bool, error) {
+	if schema == nil {
+		return ImportMapping{}, false, nil
+	}
+
+	mapping, found, err := protoImport(schema, known)
+	if err != nil {
+		return ImportMapping{}, false, err
+	}
+	if !found && match {
+		for _, k := range known {
+			if k.Matches(schema) {
+				mapping, found = k.ImportMapping, true
+				break
+			}
+		}
+	}
+	if !found {
+		return ImportMapping{}, false, nil
+	}
+
+	if goType := extensionString(schema, "x-go-type"); goType != "" {
+		mapping.Go = goType
+		mapping.GoImport = extensionString(schema, "x-go-import")
+	}
+	return mapping, true, nil
+}
+
+// protoImport returns the mapping named by schema's x-proto-import extension
+func protoImport(schema *base.Schema, known []KnownSchema) (ImportMapping, bool, error) {
+	if schema.Extensions == nil {
+		return ImportMapping{}, false, nil
+	}
+	node, ok := schema.Extensions.Get("x-proto-import")
+	if !ok || node == nil {
+		return ImportMapping{}, false, nil
+	}
+	file := strings.TrimSpace(node.Value)
+	if node.Kind != yaml.ScalarNode || file == "" {
+		return ImportMapping{}, false, fmt.Errorf("x-proto-import must be a non-empty string")
+	}
+
+	if typeNode, ok := schema.Extensions.Get("x-proto-type"); ok && typeNode != nil {
+		typ := strings.TrimSpace(typeNode.Value)
+		if typeNode.Kind != yaml.ScalarNode || typ == "" {
+			return ImportMapping{}, false, fmt.Errorf("x-proto-type must be a non-empty string")
+		}
+		return ImportMapping{Proto: typ, ProtoImport: file}, true, nil
+	}
+	for _, k := range known {
+		if k.ProtoImport == file {
+			return k.ImportMapping, true, nil
+		}
+	}
+	return ImportMapping{}, false, fmt.Errorf("x-proto-import '%s' is not a known schema import: set x-proto-type", file)
+}
+
+// extensionString returns the trimmed scalar value of schema's extension ext
+func extensionString(schema *base.Schema, ext string) string {
+	if schema.Extensions == nil {
+		return ""
+	}
+	node, ok := schema.Extensions.Get(ext)
+	if !ok || node == nil || node.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return strings.TrimSpace(node.Value)
+}
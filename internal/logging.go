@@ -0,0 +1,25 @@
+package internal
+
+import "log/slog"
+
+// Log event messages emitted at debug level during conversion. Each event carries
+// a "schema" attribute plus event-specific attributes, except LogOperationSkipped,
+// which carries "method" and "path", and LogMockRequest, which carries "method",
+// "path", "operation" and "status".
+const (
+	LogSchemaSkipped    = "schema skipped"
+	LogNameRenamed      = "name renamed"
+	LogHeuristicApplied = "heuristic applied"
+	LogImportAdded      = "import added"
+	LogOperationSkipped = "operation skipped"
+	LogMockRequest      = "mock request"
+)
+
+// LoggerOrDiscard returns l, or a logger that drops every record when l is nil,
+// so conversion code can log unconditionally.
+func LoggerOrDiscard(l *slog.Logger) *slog.Logger {
+	if l == nil {
+		return slog.New(slog.DiscardHandler)
+	}
+	return l
+}
@@ -0,0 +1,420 @@
+package markdown
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/duh-rpc/openapi-schema.go/internal/parser"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"go.yaml.in/yaml/v4"
+)
+
+// maxFieldDepth bounds how deep the fields of inline objects are listed, as
+// YAML aliases can nest an inline schema within itself
+const maxFieldDepth = 10
+
+// Page is the documentation page of a component schema
+type Page struct {
+	Name     string // Component schema name
+	Markdown []byte
+
+	description string
+	body        strings.Builder
+	// references holds the component schemas the page links to, in order of
+	// first use
+	references []string
+}
+
+// Context holds the state of a Markdown generation run
+type Context struct {
+	Pages []*Page
+	Ctx   context.Context // checked between schemas; nil → never cancelled
+	// Examples maps schema names to the JSON example shown on their page; a
+	// schema without one has no example section
+	Examples map[string]json.RawMessage
+	// Link returns the target of a link to the page of a schema; nil → "<name>.md"
+	Link func(name string) string
+
+	// page is the page being written
+	page *Page
+}
+
+// NewContext creates a new Context
+func NewContext() *Context {
+	return &Context{Pages: []*Page{}}
+}
+
+// BuildPages writes a page for each entry, in document order: its description,
+// then its fields, enum values, union variants or type, its example, and the
+// pages of the schemas referring to it.
+func BuildPages(entries []*parser.SchemaEntry, ctx *Context) error {
+	for _, entry := range entries {
+		if err := internal.Cancelled(ctx.Ctx); err != nil {
+			return err
+		}
+		schema := entry.Proxy.Schema()
+		if schema == nil {
+			return internal.SchemaError(entry.Name, "schema is nil")
+		}
+
+		ctx.page = &Page{Name: entry.Name, description: schema.Description}
+		ctx.schemaPage(schema)
+		if example, ok := ctx.Examples[entry.Name]; ok {
+			if err := ctx.examplePage(example); err != nil {
+				return internal.SchemaError(entry.Name, err.Error())
+			}
+		}
+		ctx.Pages = append(ctx.Pages, ctx.page)
+	}
+
+	referencedBy := make(map[string][]string, len(ctx.Pages))
+	for _, page := range ctx.Pages {
+		for _, ref := range page.references {
+			if ref != page.Name {
+				referencedBy[ref] = append(referencedBy[ref], page.Name)
+			}
+		}
+	}
+
+	for _, page := range ctx.Pages {
+		var b strings.Builder
+		b.WriteString("# " + page.Name + "\n")
+		if description := strings.TrimSpace(page.description); description != "" {
+			b.WriteString("\n" + description + "\n")
+		}
+		b.WriteString(page.body.String())
+		if refs := referencedBy[page.Name]; len(refs) > 0 {
+			b.WriteString("\n## Referenced By\n\n")
+			for _, ref := range refs {
+				b.WriteString("- " + ctx.link(ref) + "\n")
+			}
+		}
+		page.Markdown = []byte(b.String())
+	}
+	return nil
+}
+
+// Index returns a page linking to each page, with the first line of its
+// schema's description
+func Index(ctx *Context) []byte {
+	var b strings.Builder
+	b.WriteString("# Schemas\n\n| Schema | Description |\n|--------|-------------|\n")
+	for _, page := range ctx.Pages {
+		summary, _, _ := strings.Cut(strings.TrimSpace(page.description), "\n")
+		b.WriteString("| " + ctx.link(page.Name) + " | " + cell(summary) + " |\n")
+	}
+	return []byte(b.String())
+}
+
+// schemaPage writes the sections describing schema itself
+func (ctx *Context) schemaPage(schema *base.Schema) {
+	b := &ctx.page.body
+	if schema.Deprecated != nil && *schema.Deprecated {
+		b.WriteString("\n**Deprecated.**\n")
+	}
+
+	switch {
+	case len(schema.OneOf) > 0:
+		ctx.variants("One of", schema.OneOf, schema.Discriminator)
+		return
+	case len(schema.AnyOf) > 0:
+		ctx.variants("Any of", schema.AnyOf, schema.Discriminator)
+		return
+	case len(schema.AllOf) > 0:
+		ctx.variants("All of", schema.AllOf, nil)
+		if schema.Properties == nil || schema.Properties.Len() == 0 {
+			return
+		}
+	case len(schema.Enum) > 0:
+		b.WriteString("\n**Type:** " + ctx.typeText(schema) + "\n\n## Values\n\n")
+		for _, value := range schema.Enum {
+			b.WriteString("- " + code(value) + "\n")
+		}
+		return
+	}
+
+	if schema.Properties != nil && schema.Properties.Len() > 0 {
+		b.WriteString("\n## Fields\n\n")
+		b.WriteString("| Name | Type | Required | Constraints | Description |\n")
+		b.WriteString("|------|------|----------|-------------|-------------|\n")
+		ctx.fields("", schema, 0)
+		return
+	}
+
+	b.WriteString("\n**Type:** " + ctx.typeText(schema) + "\n")
+	if constraints := constraints(schema); len(constraints) > 0 {
+		b.WriteString("\n**Constraints:** " + strings.Join(constraints, ", ") + "\n")
+	}
+}
+
+// variants writes the variants of a union or composition, with the discriminator
+// values selecting each
+func (ctx *Context) variants(heading string, variants []*base.SchemaProxy, discriminator *base.Discriminator) {
+	b := &ctx.page.body
+	b.WriteString("\n## Variants\n\n" + heading + ":\n\n")
+	for _, variant := range variants {
+		b.WriteString("- " + ctx.proxyText(variant) + "\n")
+	}
+	if discriminator == nil || discriminator.PropertyName == "" {
+		return
+	}
+
+	b.WriteString("\nDiscriminator: `" + discriminator.PropertyName + "`\n")
+	if discriminator.Mapping == nil || discriminator.Mapping.Len() == 0 {
+		return
+	}
+	b.WriteString("\n| Value | Variant |\n|-------|---------|\n")
+	for value, ref := range discriminator.Mapping.FromOldest() {
+		target := "`" + ref + "`"
+		if name, err := internal.ExtractReferenceName(ref); err == nil {
+			target = ctx.reference(name)
+		}
+		b.WriteString("| `" + cell(value) + "` | " + target + " |\n")
+	}
+}
+
+// fields writes a table row per property of schema, followed by the rows of
+// its inline objects, their names prefixed by the path to them
+// (shipping.street, items[].sku)
+func (ctx *Context) fields(prefix string, schema *base.Schema, depth int) {
+	b := &ctx.page.body
+	for propName, propProxy := range schema.Properties.FromOldest() {
+		required := ""
+		if slices.Contains(schema.Required, propName) {
+			required = "Yes"
+		}
+		propSchema := propProxy.Schema()
+		if propSchema == nil {
+			b.WriteString("| `" + cell(prefix+propName) + "` | unknown | " + required + " | | |\n")
+			continue
+		}
+
+		description := strings.TrimSpace(propSchema.Description)
+		if propSchema.Deprecated != nil && *propSchema.Deprecated {
+			description = strings.TrimSpace("**Deprecated.** " + description)
+		}
+		// The constraints of a referenced schema are on its own page
+		var keywords []string
+		if !propProxy.IsReference() {
+			keywords = constraints(propSchema)
+		}
+		b.WriteString("| `" + cell(prefix+propName) + "` | " + cell(ctx.proxyText(propProxy)) + " | " + required +
+			" | " + cell(strings.Join(keywords, ", ")) + " | " + cell(description) + " |\n")
+
+		if propProxy.IsReference() || depth >= maxFieldDepth {
+			continue
+		}
+		path := prefix + propName
+		if items := propSchema.Items; slices.Contains(propSchema.Type, "array") && items != nil && items.IsA() && items.A != nil && !items.A.IsReference() {
+			propSchema, path = items.A.Schema(), path+"[]"
+		}
+		if propSchema != nil && propSchema.Properties != nil && propSchema.Properties.Len() > 0 {
+			ctx.fields(path+".", propSchema, depth+1)
+		}
+	}
+}
+
+// examplePage writes the example section
+func (ctx *Context) examplePage(example json.RawMessage) error {
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, example, "", "  "); err != nil {
+		return fmt.Errorf("example: %w", err)
+	}
+	ctx.page.body.WriteString("\n## Example\n\n```json\n" + indented.String() + "\n```\n")
+	return nil
+}
+
+// proxyText describes the type of proxy: a link to the page of the schema it
+// references, else the type of its schema
+func (ctx *Context) proxyText(proxy *base.SchemaProxy) string {
+	if proxy.IsReference() {
+		if name, err := internal.ExtractReferenceName(proxy.GetReference()); err == nil {
+			return ctx.reference(name)
+		}
+		return "`" + proxy.GetReference() + "`"
+	}
+	schema := proxy.Schema()
+	if schema == nil {
+		return "unknown"
+	}
+	return ctx.typeText(schema)
+}
+
+// typeText describes the type of schema, such as "string (date-time)",
+// "array of [Item](Item.md)" or "one of [Dog](Dog.md), [Cat](Cat.md)"
+func (ctx *Context) typeText(schema *base.Schema) string {
+	switch {
+	case len(schema.OneOf) > 0:
+		return "one of " + ctx.proxyTexts(schema.OneOf)
+	case len(schema.AnyOf) > 0:
+		return "any of " + ctx.proxyTexts(schema.AnyOf)
+	case len(schema.AllOf) > 0:
+		return "all of " + ctx.proxyTexts(schema.AllOf)
+	}
+
+	var types []string
+	for _, t := range schema.Type {
+		if t == "null" {
+			continue
+		}
+		switch t {
+		case "array":
+			if schema.Items != nil && schema.Items.IsA() && schema.Items.A != nil {
+				t = "array of " + ctx.proxyText(schema.Items.A)
+			}
+		case "object":
+			if additional := schema.AdditionalProperties; additional != nil && additional.IsA() && additional.A != nil {
+				t = "map of " + ctx.proxyText(additional.A)
+			}
+		default:
+			if schema.Format != "" {
+				t += " (" + schema.Format + ")"
+			}
+		}
+		types = append(types, t)
+	}
+	switch {
+	case len(types) > 0:
+		return strings.Join(types, " or ")
+	case schema.Properties != nil && schema.Properties.Len() > 0:
+		return "object"
+	case len(schema.Type) > 0:
+		return "null"
+	}
+	return "any"
+}
+
+// proxyTexts describes the types of proxies as a list
+func (ctx *Context) proxyTexts(proxies []*base.SchemaProxy) string {
+	texts := make([]string, len(proxies))
+	for i, proxy := range proxies {
+		texts[i] = ctx.proxyText(proxy)
+	}
+	return strings.Join(texts, ", ")
+}
+
+// reference returns a link to the page of schema name, recording the reference
+func (ctx *Context) reference(name string) string {
+	if !slices.Contains(ctx.page.references, name) {
+		ctx.page.references = append(ctx.page.references, name)
+	}
+	return ctx.link(name)
+}
+
+// link returns a link to the page of schema name
+func (ctx *Context) link(name string) string {
+	target := name + ".md"
+	if ctx.Link != nil {
+		target = ctx.Link(name)
+	}
+	return "[" + name + "](" + target + ")"
+}
+
+// constraints describes the validation keywords of schema
+func constraints(schema *base.Schema) []string {
+	var result []string
+	add := func(keyword string, value any) {
+		result = append(result, keyword+": "+fmt.Sprint(value))
+	}
+
+	if len(schema.Enum) > 0 {
+		values := make([]string, len(schema.Enum))
+		for i, value := range schema.Enum {
+			values[i] = code(value)
+		}
+		result = append(result, "enum: "+strings.Join(values, ", "))
+	}
+	if schema.Const != nil {
+		result = append(result, "const: "+code(schema.Const))
+	}
+	if schema.Minimum != nil {
+		add("minimum", number(*schema.Minimum))
+	}
+	if bound := schema.ExclusiveMinimum; bound != nil {
+		if bound.IsB() {
+			add("exclusiveMinimum", number(bound.B))
+		} else if bound.A {
+			result = append(result, "exclusiveMinimum")
+		}
+	}
+	if schema.Maximum != nil {
+		add("maximum", number(*schema.Maximum))
+	}
+	if bound := schema.ExclusiveMaximum; bound != nil {
+		if bound.IsB() {
+			add("exclusiveMaximum", number(bound.B))
+		} else if bound.A {
+			result = append(result, "exclusiveMaximum")
+		}
+	}
+	if schema.MultipleOf != nil {
+		add("multipleOf", number(*schema.MultipleOf))
+	}
+	if schema.MinLength != nil {
+		add("minLength", *schema.MinLength)
+	}
+	if schema.MaxLength != nil {
+		add("maxLength", *schema.MaxLength)
+	}
+	if schema.Pattern != "" {
+		result = append(result, "pattern: `"+schema.Pattern+"`")
+	}
+	if schema.MinItems != nil {
+		add("minItems", *schema.MinItems)
+	}
+	if schema.MaxItems != nil {
+		add("maxItems", *schema.MaxItems)
+	}
+	if schema.UniqueItems != nil && *schema.UniqueItems {
+		result = append(result, "uniqueItems")
+	}
+	if schema.MinProperties != nil {
+		add("minProperties", *schema.MinProperties)
+	}
+	if schema.MaxProperties != nil {
+		add("maxProperties", *schema.MaxProperties)
+	}
+	if schema.Default != nil {
+		result = append(result, "default: "+code(schema.Default))
+	}
+	if (schema.Nullable != nil && *schema.Nullable) || (len(schema.Type) > 1 && slices.Contains(schema.Type, "null")) {
+		result = append(result, "nullable")
+	}
+	if schema.ReadOnly != nil && *schema.ReadOnly {
+		result = append(result, "read-only")
+	}
+	if schema.WriteOnly != nil && *schema.WriteOnly {
+		result = append(result, "write-only")
+	}
+	return result
+}
+
+// code returns a YAML value as compact JSON in a code span
+func code(node *yaml.Node) string {
+	var decoded any
+	if err := node.Decode(&decoded); err != nil {
+		return "`" + node.Value + "`"
+	}
+	encoded, err := json.Marshal(decoded)
+	if err != nil {
+		return "`" + node.Value + "`"
+	}
+	return "`" + string(encoded) + "`"
+}
+
+// number formats a number without a trailing .0 or exponent
+func number(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// cell escapes text for a table cell, which cannot hold pipes or line breaks
+func cell(text string) string {
+	text = strings.ReplaceAll(strings.TrimSpace(text), "|", `\|`)
+	return strings.ReplaceAll(text, "\n", "<br>")
+}
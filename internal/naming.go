@@ -79,6 +79,120 @@ func ToPascalCase(s string) string {
 	return result.String()
 }
 
+// goInitialisms are the words Go style writes in a consistent case (ID, not Id)
+var goInitialisms = map[string]bool{
+	"ACL": true, "API": true, "ASCII": true, "CPU": true, "CSS": true, "DNS": true,
+	"EOF": true, "GUID": true, "HTML": true, "HTTP": true, "HTTPS": true, "ID": true,
+	"IP": true, "JSON": true, "JWT": true, "LHS": true, "QPS": true, "RAM": true,
+	"RHS": true, "RPC": true, "SDK": true, "SLA": true, "SMTP": true, "SQL": true,
+	"SSH": true, "SSO": true, "TCP": true, "TLS": true, "TTL": true, "UDP": true,
+	"UI": true, "UID": true, "URI": true, "URL": true, "UTF8": true, "UUID": true,
+	"VM": true, "XML": true, "XMPP": true, "XSRF": true, "XSS": true,
+}
+
+// ToGoName converts a property name to an exported Go identifier, writing
+// initialisms in upper case as Go style requires.
+// Examples: userId → UserID, avatar_url → AvatarURL, userIds → UserIDs,
+// X-Request-ID → XRequestID, HTTPStatus → HTTPStatus, USER → User
+func ToGoName(s string) string {
+	var result strings.Builder
+	for _, word := range splitWords(s) {
+		upper := strings.ToUpper(word)
+		switch {
+		case goInitialisms[upper]:
+			result.WriteString(upper)
+		case len(word) > 2 && word[len(word)-1] == 's' && goInitialisms[upper[:len(upper)-1]]:
+			result.WriteString(upper[:len(upper)-1] + "s")
+		default:
+			runes := []rune(word)
+			rest := string(runes[1:])
+			if upper == word {
+				rest = strings.ToLower(rest)
+			}
+			result.WriteString(string(unicode.ToUpper(runes[0])) + rest)
+		}
+	}
+
+	name := result.String()
+	if name != "" && unicode.IsDigit([]rune(name)[0]) {
+		name = "X" + name
+	}
+	return name
+}
+
+// JSON tag casing modes understood by ApplyJSONCase.
+const (
+	JSONCasePreserve = "preserve"
+	JSONCaseCamel    = "camel"
+	JSONCaseSnake    = "snake"
+)
+
+// ApplyJSONCase rewrites an OpenAPI property name into the requested wire casing.
+// An empty mode or JSONCasePreserve returns the name unchanged.
+// Examples: (userId, snake) → user_id, (HTTPStatus, snake) → http_status,
+// (created_at, camel) → createdAt, (X-Request-ID, camel) → xRequestId
+func ApplyJSONCase(name, mode string) string {
+	switch mode {
+	case JSONCaseCamel:
+		words := splitWords(name)
+		for i, w := range words {
+			w = strings.ToLower(w)
+			if i > 0 {
+				w = strings.ToUpper(w[:1]) + w[1:]
+			}
+			words[i] = w
+		}
+		return strings.Join(words, "")
+	case JSONCaseSnake:
+		words := splitWords(name)
+		for i, w := range words {
+			words[i] = strings.ToLower(w)
+		}
+		return strings.Join(words, "_")
+	}
+	return name
+}
+
+// splitWords breaks a name into words on separators (-, _, ., space) and case
+// boundaries, keeping acronyms together.
+// Examples: HTTPStatus → [HTTP Status], userId2 → [user Id2], created_at → [created at],
+// avatarURLs → [avatar URLs]
+func splitWords(s string) []string {
+	var words []string
+	runes := []rune(s)
+	start := -1
+	for i, r := range runes {
+		if r == '-' || r == '_' || r == '.' || r == ' ' {
+			if start >= 0 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+			continue
+		}
+		if unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			// A lone trailing "s" pluralizes the acronym before it (URLs, IDs)
+			if nextLower && unicode.IsUpper(prev) && runes[i+1] == 's' &&
+				(i+2 == len(runes) || !unicode.IsLower(runes[i+2])) {
+				nextLower = false
+			}
+			if !unicode.IsUpper(prev) || nextLower {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+	}
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
 // ToEnumValueName converts a value to ENUM_PREFIX_VALUE_NAME format.
 // Examples: (Status, active) → STATUS_ACTIVE, (Status, in-progress) → STATUS_IN_PROGRESS,
 // (SortBy, createdAt) → SORT_BY_CREATED_AT.
@@ -189,6 +303,12 @@ func NewNameTracker() *NameTracker {
 	}
 }
 
+// Has reports whether name has been handed out
+func (nt *NameTracker) Has(name string) bool {
+	_, exists := nt.used[name]
+	return exists
+}
+
 // UniqueName returns a unique name, adding numeric suffix if needed (_2, _3, etc.).
 func (nt *NameTracker) UniqueName(name string) string {
 	count, exists := nt.used[name]
@@ -201,3 +321,27 @@ func (nt *NameTracker) UniqueName(name string) string {
 	nt.used[name] = count
 	return fmt.Sprintf("%s_%d", name, count)
 }
+
+// Kinds of renamed names
+const (
+	RenameType  = "type"  // a message or enum
+	RenameField = "field" // a message field
+)
+
+// Reasons a name was changed
+const (
+	RenameSanitized = "sanitized" // characters invalid in proto replaced by underscores
+	RenameCollision = "collision" // the name was already in use
+)
+
+// Rename records a generated name that differs from the name it was derived
+// from: a field whose property name was sanitized, or a type or field whose name
+// collided with one already in use
+type Rename struct {
+	Schema   string // component schema the renamed type or field belongs to
+	Property string // property the type or field was generated for; "" for the schema's own type
+	Kind     string // RenameType or RenameField
+	Reason   string // RenameSanitized or RenameCollision
+	From     string // the type's name before the collision, or the field's property name
+	To       string // the name generated instead
+}
@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+	"unicode"
+)
+
+// networkFormatNotes describes the network string formats in generated field
+// comments, as their Go and proto types are a plain string
+var networkFormatNotes = map[string]string{
+	"ipv4":         "format: ipv4 (dotted-quad IPv4 address, e.g. 192.0.2.1)",
+	"ipv6":         "format: ipv6 (IPv6 address, e.g. 2001:db8::1)",
+	"hostname":     "format: hostname (RFC 1123 host name)",
+	"idn-hostname": "format: idn-hostname (internationalized host name)",
+}
+
+// IsNetworkFormat reports whether format is ipv4, ipv6, hostname or idn-hostname
+func IsNetworkFormat(format string) bool {
+	_, ok := networkFormatNotes[format]
+	return ok
+}
+
+// WithFormatNote appends a line naming a network format to a field comment, so
+// generated code documents what the string holds.
+func WithFormatNote(description, format string) string {
+	note, ok := networkFormatNotes[format]
+	if !ok {
+		return description
+	}
+	description = strings.TrimRight(description, "\n")
+	if strings.TrimSpace(description) == "" {
+		return note
+	}
+	return description + "\n" + note
+}
+
+// CheckNetworkFormat reports why value is not of the network format, or nil;
+// values of other formats are never reported
+func CheckNetworkFormat(format, value string) error {
+	switch format {
+	case "ipv4":
+		addr, err := netip.ParseAddr(value)
+		if err != nil || !addr.Is4() {
+			return fmt.Errorf("not an IPv4 address")
+		}
+	case "ipv6":
+		addr, err := netip.ParseAddr(value)
+		if err != nil || !addr.Is6() || addr.Zone() != "" {
+			return fmt.Errorf("not an IPv6 address")
+		}
+	case "hostname":
+		return checkHostname(value, false)
+	case "idn-hostname":
+		return checkHostname(value, true)
+	}
+	return nil
+}
+
+// checkHostname reports why value is not an RFC 1123 host name: dot-separated
+// labels of 1 to 63 letters, digits and hyphens, neither starting nor ending
+// with a hyphen, 253 characters in all. International names also allow
+// non-ASCII letters, digits and marks in labels.
+func checkHostname(value string, international bool) error {
+	if value == "" || len([]rune(value)) > 253 {
+		return fmt.Errorf("host name must be 1 to 253 characters")
+	}
+	for _, label := range strings.Split(value, ".") {
+		if label == "" || len([]rune(label)) > 63 {
+			return fmt.Errorf("label '%s' must be 1 to 63 characters", label)
+		}
+		if strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return fmt.Errorf("label '%s' must not start or end with a hyphen", label)
+		}
+		for _, r := range label {
+			ascii := r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-')
+			other := international && r > unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r))
+			if !ascii && !other {
+				return fmt.Errorf("label '%s' contains '%c'", label, r)
+			}
+		}
+	}
+	return nil
+}
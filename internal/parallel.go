@@ -0,0 +1,106 @@
+package internal
+
+import (
+	"sync"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// ParallelEach calls fn for every index in [0, count) using up to workers
+// goroutines. Callers write results into index-addressed slots so output order
+// never depends on scheduling. When several calls fail, the error for the lowest
+// index is returned, matching what a sequential loop would have reported first.
+// A panic in fn is raised again on the calling goroutine, as a *ConversionError
+// keeping the worker's stack, once every worker has stopped, so the Recover of
+// the entry point sees it.
+func ParallelEach(workers, count int, fn func(i int) error) error {
+	if workers > count {
+		workers = count
+	}
+
+	if workers <= 1 {
+		for i := 0; i < count; i++ {
+			if err := fn(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	errs := make([]error, count)
+	panics := make([]*ConversionError, count)
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				func() {
+					defer func() {
+						if r := recover(); r != nil {
+							panics[i] = NewConversionError("", r)
+						}
+					}()
+					errs[i] = fn(i)
+				}()
+			}
+		}()
+	}
+
+	for i := 0; i < count; i++ {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	for _, p := range panics {
+		if p != nil {
+			panic(p)
+		}
+	}
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ResolveSchemaTree forces libopenapi to build the high-level model for a schema
+// and everything reachable from it (properties, items, composition branches).
+// libopenapi builds these lazily behind a per-proxy lock, so resolving trees
+// concurrently moves the bulk of the parsing cost off the sequential build path.
+// Referenced component schemas are not followed; they are resolved as their own tree.
+func ResolveSchemaTree(proxy *base.SchemaProxy) {
+	resolveSchemaTree(proxy, make(map[*base.SchemaProxy]bool))
+}
+
+func resolveSchemaTree(proxy *base.SchemaProxy, seen map[*base.SchemaProxy]bool) {
+	if proxy == nil || seen[proxy] {
+		return
+	}
+	seen[proxy] = true
+
+	schema := proxy.Schema()
+	if schema == nil || proxy.IsReference() {
+		return
+	}
+
+	if schema.Properties != nil {
+		for _, prop := range schema.Properties.FromOldest() {
+			resolveSchemaTree(prop, seen)
+		}
+	}
+	if schema.Items != nil && schema.Items.A != nil {
+		resolveSchemaTree(schema.Items.A, seen)
+	}
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.A != nil {
+		resolveSchemaTree(schema.AdditionalProperties.A, seen)
+	}
+	for _, branches := range [][]*base.SchemaProxy{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for _, branch := range branches {
+			resolveSchemaTree(branch, seen)
+		}
+	}
+}
@@ -1,11 +1,15 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/pb33f/libopenapi"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"go.yaml.in/yaml/v4"
 )
 
 // Document wraps the libopenapi v3 document model
@@ -27,6 +31,12 @@ func ParseDocument(openapi []byte) (*Document, error) {
 		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
 	}
 
+	if root := doc.GetSpecInfo().RootNode; root != nil {
+		if err := checkShapes(root); err != nil {
+			return nil, fmt.Errorf("failed to build OpenAPI model: %w", err)
+		}
+	}
+
 	model, errs := doc.BuildV3Model()
 	if errs != nil {
 		return nil, fmt.Errorf("failed to build OpenAPI model: %w", errs)
@@ -39,6 +49,11 @@ func ParseDocument(openapi []byte) (*Document, error) {
 	return &Document{model: model}, nil
 }
 
+// Version returns the OpenAPI version declared by the document (e.g. "3.1.0")
+func (d *Document) Version() string {
+	return d.model.Model.Version
+}
+
 // Schemas returns schemas from components/schemas in insertion order.
 // Returns an empty slice if there are no schemas defined.
 func (d *Document) Schemas() ([]*SchemaEntry, error) {
@@ -60,3 +75,307 @@ func (d *Document) Schemas() ([]*SchemaEntry, error) {
 
 	return entries, nil
 }
+
+// Extension returns the top-level document extension name (e.g. "x-services"),
+// or nil when it is absent
+func (d *Document) Extension(name string) *yaml.Node {
+	if d.model.Model.Extensions == nil {
+		return nil
+	}
+	node, _ := d.model.Model.Extensions.Get(name)
+	return node
+}
+
+// Paths returns the path items in document order, or nil when there are none
+func (d *Document) Paths() *orderedmap.Map[string, *v3.PathItem] {
+	if d.model.Model.Paths == nil {
+		return nil
+	}
+	return d.model.Model.Paths.PathItems
+}
+
+// Model returns the libopenapi model of the whole document
+func (d *Document) Model() *v3.Document {
+	return &d.model.Model
+}
+
+// Components returns the components of the document, or nil when absent
+func (d *Document) Components() *v3.Components {
+	return d.model.Model.Components
+}
+
+// OperationEntry is an operation with its effective parameters
+type OperationEntry struct {
+	Method      string // upper-case HTTP method
+	Path        string
+	OperationID string
+	Summary     string
+	Parameters  []*v3.Parameter
+	// RequestSchema and ResponseSchema name the component schemas referenced by
+	// the request body and the 200 response; empty when absent or inline
+	RequestSchema  string
+	ResponseSchema string
+	RequestBody    *v3.RequestBody // nil without a request body
+	Responses      *v3.Responses   // nil without responses
+}
+
+// Operations returns operations in document order. Path-level parameters are
+// merged with the operation's own, which take precedence on name and location.
+func (d *Document) Operations() []*OperationEntry {
+	if d.model.Model.Paths == nil || d.model.Model.Paths.PathItems == nil {
+		return []*OperationEntry{}
+	}
+
+	var entries []*OperationEntry
+	for path, item := range d.model.Model.Paths.PathItems.FromOldest() {
+		for method, op := range item.GetOperations().FromOldest() {
+			entries = append(entries, &OperationEntry{
+				Method:         strings.ToUpper(method),
+				Path:           path,
+				OperationID:    op.OperationId,
+				Summary:        op.Summary,
+				Parameters:     mergeParameters(item.Parameters, op.Parameters),
+				RequestSchema:  requestSchema(op),
+				ResponseSchema: responseSchema(op),
+				RequestBody:    op.RequestBody,
+				Responses:      op.Responses,
+			})
+		}
+	}
+	return entries
+}
+
+// requestSchema returns the component schema the first media type of op's
+// request body references, or ""
+func requestSchema(op *v3.Operation) string {
+	if op.RequestBody == nil {
+		return ""
+	}
+	return contentSchema(op.RequestBody.Content)
+}
+
+// responseSchema returns the component schema the first media type of op's 200
+// response references, or ""
+func responseSchema(op *v3.Operation) string {
+	if op.Responses == nil || op.Responses.Codes == nil {
+		return ""
+	}
+	resp, ok := op.Responses.Codes.Get("200")
+	if !ok || resp == nil {
+		return ""
+	}
+	return contentSchema(resp.Content)
+}
+
+// contentSchema returns the component schema referenced by the first media
+// type of content, or ""
+func contentSchema(content *orderedmap.Map[string, *v3.MediaType]) string {
+	if content == nil {
+		return ""
+	}
+	for _, media := range content.FromOldest() {
+		if media == nil || media.Schema == nil || !media.Schema.IsReference() {
+			return ""
+		}
+		if name, ok := strings.CutPrefix(media.Schema.GetReference(), "#/components/schemas/"); ok {
+			return name
+		}
+		return ""
+	}
+	return ""
+}
+
+// mergeParameters overlays operation parameters on path parameters, keeping the
+// path parameters' position for overridden entries
+func mergeParameters(pathParams, opParams []*v3.Parameter) []*v3.Parameter {
+	merged := make([]*v3.Parameter, 0, len(pathParams)+len(opParams))
+	index := make(map[string]int)
+	for _, param := range append(append([]*v3.Parameter{}, pathParams...), opParams...) {
+		if param == nil {
+			continue
+		}
+		key := param.In + "\x00" + param.Name
+		if i, ok := index[key]; ok {
+			merged[i] = param
+			continue
+		}
+		index[key] = len(merged)
+		merged = append(merged, param)
+	}
+	return merged
+}
+
+// ExcludeSchemas removes the entries named in names, and those whose schema sets
+// x-proto-skip: true, returning the remaining entries in document order and the
+// names removed. Unknown names are reported together.
+func ExcludeSchemas(entries []*SchemaEntry, names []string) ([]*SchemaEntry, map[string]bool, error) {
+	excluded := make(map[string]bool, len(names))
+	for _, name := range names {
+		excluded[name] = true
+	}
+
+	found := make(map[string]bool, len(names))
+	var errs []error
+	for _, entry := range entries {
+		if excluded[entry.Name] {
+			found[entry.Name] = true
+			continue
+		}
+		schema := entry.Proxy.Schema()
+		if schema == nil || schema.Extensions == nil {
+			continue
+		}
+		node, ok := schema.Extensions.Get("x-proto-skip")
+		if !ok || node == nil {
+			continue
+		}
+		switch node.Value {
+		case "true":
+			excluded[entry.Name] = true
+		case "false":
+		default:
+			errs = append(errs, fmt.Errorf("schema '%s': x-proto-skip must be true or false, got: %s", entry.Name, node.Value))
+		}
+	}
+
+	var missing []string
+	for _, name := range names {
+		if !found[name] {
+			missing = append(missing, fmt.Sprintf("'%s'", name))
+		}
+	}
+	if len(missing) > 0 {
+		errs = append(errs, fmt.Errorf("excluded schema %s not found in components/schemas", strings.Join(missing, ", ")))
+	}
+	if len(errs) > 0 {
+		return nil, nil, errors.Join(errs...)
+	}
+
+	kept := make([]*SchemaEntry, 0, len(entries))
+	for _, entry := range entries {
+		if !excluded[entry.Name] {
+			kept = append(kept, entry)
+		}
+	}
+	return kept, excluded, nil
+}
+
+// ImportSchemas removes the entries named in names, whose types are generated
+// from another spec, returning the remaining entries in document order. Unknown
+// names are reported together.
+func ImportSchemas(entries []*SchemaEntry, names []string) ([]*SchemaEntry, error) {
+	imported := make(map[string]bool, len(names))
+	for _, name := range names {
+		imported[name] = true
+	}
+
+	kept := make([]*SchemaEntry, 0, len(entries))
+	for _, entry := range entries {
+		if imported[entry.Name] {
+			delete(imported, entry.Name)
+			continue
+		}
+		kept = append(kept, entry)
+	}
+
+	if len(imported) > 0 {
+		var missing []string
+		for _, name := range names {
+			if imported[name] {
+				missing = append(missing, fmt.Sprintf("'%s'", name))
+			}
+		}
+		return nil, fmt.Errorf("import-mapped schema %s not found in components/schemas", strings.Join(missing, ", "))
+	}
+	return kept, nil
+}
+
+// SelectSchemas returns the entries named in names together with every schema
+// they reference, directly or transitively, in document order. Unknown names
+// are reported together.
+func SelectSchemas(entries []*SchemaEntry, names []string) ([]*SchemaEntry, error) {
+	byName := make(map[string]*SchemaEntry, len(entries))
+	for _, entry := range entries {
+		byName[entry.Name] = entry
+	}
+
+	var missing []string
+	selected := make(map[string]bool, len(names))
+	queue := make([]string, 0, len(names))
+	for _, name := range names {
+		if byName[name] == nil {
+			missing = append(missing, fmt.Sprintf("'%s'", name))
+			continue
+		}
+		if !selected[name] {
+			selected[name] = true
+			queue = append(queue, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("schema %s not found in components/schemas", strings.Join(missing, ", "))
+	}
+
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		for _, ref := range schemaRefs(byName[name].Proxy, nil) {
+			if byName[ref] != nil && !selected[ref] {
+				selected[ref] = true
+				queue = append(queue, ref)
+			}
+		}
+	}
+
+	result := make([]*SchemaEntry, 0, len(selected))
+	for _, entry := range entries {
+		if selected[entry.Name] {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
+// schemaRefs appends the component schemas proxy references to refs, looking
+// through inline properties, items, compositions and additionalProperties but
+// not into the referenced schemas themselves
+func schemaRefs(proxy *base.SchemaProxy, refs []string) []string {
+	if proxy == nil {
+		return refs
+	}
+	if proxy.IsReference() {
+		if name, ok := strings.CutPrefix(proxy.GetReference(), "#/components/schemas/"); ok {
+			refs = append(refs, name)
+		}
+		return refs
+	}
+
+	schema := proxy.Schema()
+	if schema == nil {
+		return refs
+	}
+	if schema.Properties != nil {
+		for _, prop := range schema.Properties.FromOldest() {
+			refs = schemaRefs(prop, refs)
+		}
+	}
+	if schema.Items != nil && schema.Items.IsA() {
+		refs = schemaRefs(schema.Items.A, refs)
+	}
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.IsA() {
+		refs = schemaRefs(schema.AdditionalProperties.A, refs)
+	}
+	for _, group := range [][]*base.SchemaProxy{schema.AllOf, schema.OneOf, schema.AnyOf, schema.PrefixItems} {
+		for _, sub := range group {
+			refs = schemaRefs(sub, refs)
+		}
+	}
+	if schema.Discriminator != nil && schema.Discriminator.Mapping != nil {
+		for _, ref := range schema.Discriminator.Mapping.FromOldest() {
+			if name, ok := strings.CutPrefix(ref, "#/components/schemas/"); ok {
+				refs = append(refs, name)
+			}
+		}
+	}
+	return schemaRefs(schema.Not, refs)
+}
@@ -0,0 +1,48 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"go.yaml.in/yaml/v4"
+)
+
+// PrefixSchemas prepends prefix to the proto and Go type names of every
+// component schema, by setting x-proto-name and x-go-name to prefix followed by
+// the name they already set or the default for the schema. The schemas keep
+// their names, so references and the TypeMap are unchanged.
+func PrefixSchemas(openapi []byte, prefix string) ([]byte, error) {
+	if prefix == "" {
+		return openapi, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(openapi, &doc); err != nil || len(doc.Content) == 0 {
+		return openapi, nil
+	}
+	schemas := internal.MappingValue(internal.MappingValue(doc.Content[0], "components"), "schemas")
+	if schemas == nil || schemas.Kind != yaml.MappingNode {
+		return openapi, nil
+	}
+
+	for i := 0; i+1 < len(schemas.Content); i += 2 {
+		name, schema := schemas.Content[i].Value, schemas.Content[i+1]
+		// Siblings of a $ref are ignored, so aliases keep their target's names
+		if schema.Kind != yaml.MappingNode || internal.MappingValue(schema, "$ref") != nil {
+			continue
+		}
+		for _, override := range []struct{ ext, name string }{
+			{"x-proto-name", internal.ToPascalCase(name)},
+			{"x-go-name", name},
+		} {
+			if node := internal.MappingValue(schema, override.ext); node != nil {
+				if node.Kind != yaml.ScalarNode {
+					return nil, internal.SchemaError(name, fmt.Sprintf("%s must be a type name", override.ext))
+				}
+				override.name = node.Value
+			}
+			internal.SetMappingValue(schema, override.ext, internal.StringNode(prefix+override.name))
+		}
+	}
+	return internal.EncodeYAML(&doc)
+}
@@ -0,0 +1,74 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// singleSchemaKeywords take one schema. libopenapi waits for one build result
+// per keyword, so a list in their place never completes and hangs the build.
+var singleSchemaKeywords = map[string]bool{
+	"items":                 true,
+	"not":                   true,
+	"contains":              true,
+	"if":                    true,
+	"then":                  true,
+	"else":                  true,
+	"propertyNames":         true,
+	"unevaluatedItems":      true,
+	"unevaluatedProperties": true,
+	"additionalProperties":  true,
+}
+
+// schemaListKeywords take a list of schemas. libopenapi reports a scalar in
+// their place on a channel nobody reads, leaking the goroutine.
+var schemaListKeywords = map[string]bool{
+	"allOf":       true,
+	"anyOf":       true,
+	"oneOf":       true,
+	"prefixItems": true,
+}
+
+// valueKeywords hold instance values rather than schemas, so their contents
+// are not checked
+var valueKeywords = map[string]bool{
+	"example":  true,
+	"examples": true,
+	"default":  true,
+	"enum":     true,
+	"const":    true,
+}
+
+// checkShapes rejects schema keywords whose values have a shape libopenapi
+// cannot build without hanging or leaking, reporting the first one found.
+// Values of example, default, enum and const keywords and of extensions are
+// skipped, as are aliases, which point at nodes checked where they are anchored.
+func checkShapes(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			if err := checkShapes(child); err != nil {
+				return err
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if valueKeywords[key.Value] || strings.HasPrefix(key.Value, "x-") {
+				continue
+			}
+			if singleSchemaKeywords[key.Value] && value.Kind == yaml.SequenceNode {
+				return fmt.Errorf("line %d, column %d: %s must be a schema, not a list", key.Line, key.Column, key.Value)
+			}
+			if schemaListKeywords[key.Value] && value.Kind == yaml.ScalarNode {
+				return fmt.Errorf("line %d, column %d: %s must be a list of schemas", key.Line, key.Column, key.Value)
+			}
+			if err := checkShapes(value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,293 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"go.yaml.in/yaml/v4"
+)
+
+// RewriteUnions rewrites the union forms Convert builds on the discriminated
+// oneOf of a component schema into that form: allOf inheritance from a base
+// schema holding the discriminator (see inheritUnions), then inline oneOf
+// properties (see hoistInlineUnions).
+//
+// openapi is returned unchanged when it uses neither form, or is not a document
+// this can read; ParseDocument reports the latter. Both forms need a
+// discriminator and an allOf or oneOf, so a spec without those keys is returned
+// without being decoded.
+func RewriteUnions(openapi []byte) ([]byte, error) {
+	if !bytes.Contains(openapi, []byte("discriminator")) ||
+		(!bytes.Contains(openapi, []byte("allOf")) && !bytes.Contains(openapi, []byte("oneOf"))) {
+		return openapi, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(openapi, &doc); err != nil || len(doc.Content) == 0 {
+		return openapi, nil
+	}
+	schemas := internal.MappingValue(internal.MappingValue(doc.Content[0], "components"), "schemas")
+	if schemas == nil || schemas.Kind != yaml.MappingNode {
+		return openapi, nil
+	}
+
+	inherited := inheritUnions(schemas)
+	hoisted, err := hoistInlineUnions(schemas)
+	if err != nil {
+		return nil, err
+	}
+	if !inherited && !hoisted {
+		return openapi, nil
+	}
+	return internal.EncodeYAML(&doc)
+}
+
+// inheritUnions rewrites the allOf inheritance pattern, where a base object
+// schema holds the discriminator and each subclass is
+//
+//	allOf: [{$ref: Base}, {properties: ...}]
+//
+// The base becomes a oneOf union of its subclasses, keeping its discriminator,
+// description and extensions; the subclasses are those of the discriminator's
+// mapping or, without one, every schema whose allOf references the base, in
+// document order. Each subclass becomes an object holding the base's
+// properties followed by its own, a property of its own replacing the base's of
+// the same name. Other uses of allOf are left in place. It reports whether any
+// schema changed.
+func inheritUnions(schemas *yaml.Node) bool {
+	byName := make(map[string]*yaml.Node, len(schemas.Content)/2)
+	for i := 0; i+1 < len(schemas.Content); i += 2 {
+		byName[schemas.Content[i].Value] = schemas.Content[i+1]
+	}
+
+	changed := false
+	for i := 0; i+1 < len(schemas.Content); i += 2 {
+		baseName, base := schemas.Content[i].Value, schemas.Content[i+1]
+		discriminator := internal.MappingValue(base, "discriminator")
+		if internal.MappingValue(discriminator, "propertyName") == nil || internal.MappingValue(base, "properties") == nil ||
+			internal.MappingValue(base, "oneOf") != nil || internal.MappingValue(base, "anyOf") != nil ||
+			internal.MappingValue(base, "allOf") != nil {
+			continue
+		}
+
+		baseRef := "#/components/schemas/" + baseName
+		var subclasses []string
+		for j := 0; j+1 < len(schemas.Content); j += 2 {
+			if isSubclass(schemas.Content[j+1], baseRef) {
+				subclasses = append(subclasses, schemas.Content[j].Value)
+			}
+		}
+		if len(subclasses) == 0 {
+			continue
+		}
+
+		variants := subclasses
+		if mapping := internal.MappingValue(discriminator, "mapping"); mapping != nil && mapping.Kind == yaml.MappingNode {
+			variants = nil
+			for k := 1; k < len(mapping.Content); k += 2 {
+				name, err := internal.ExtractReferenceName(mapping.Content[k].Value)
+				if err == nil && !slices.Contains(variants, name) {
+					variants = append(variants, name)
+				}
+			}
+		}
+
+		// Subclasses take a copy of the base's properties before it becomes a union
+		for _, name := range subclasses {
+			byName[name].Content = flattenSubclass(byName[name], base, baseRef).Content
+		}
+
+		oneOf := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, name := range variants {
+			oneOf.Content = append(oneOf.Content, &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{
+				internal.StringNode("$ref"), internal.StringNode("#/components/schemas/" + name),
+			}})
+		}
+		union := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		for k := 0; k+1 < len(base.Content); k += 2 {
+			key := base.Content[k].Value
+			if key == "description" || key == "discriminator" || strings.HasPrefix(key, "x-") {
+				union.Content = append(union.Content, base.Content[k], base.Content[k+1])
+			}
+		}
+		internal.SetMappingValue(union, "oneOf", oneOf)
+		base.Content = union.Content
+		changed = true
+	}
+	return changed
+}
+
+// isSubclass reports whether schema is allOf a reference to baseRef and inline
+// objects
+func isSubclass(schema *yaml.Node, baseRef string) bool {
+	allOf := internal.MappingValue(schema, "allOf")
+	if allOf == nil || allOf.Kind != yaml.SequenceNode {
+		return false
+	}
+	found := false
+	for _, part := range allOf.Content {
+		if part.Kind != yaml.MappingNode {
+			return false
+		}
+		if ref := internal.MappingValue(part, "$ref"); ref != nil {
+			if ref.Value != baseRef || found {
+				return false
+			}
+			found = true
+		}
+	}
+	return found
+}
+
+// flattenSubclass returns the object schema subclass describes: its own keys
+// other than allOf, the properties and required of base and then of its inline
+// allOf parts, and the parts' other keys where subclass lacks them
+func flattenSubclass(subclass, base *yaml.Node, baseRef string) *yaml.Node {
+	flat := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	properties := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	required := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	for k := 0; k+1 < len(subclass.Content); k += 2 {
+		if subclass.Content[k].Value != "allOf" {
+			flat.Content = append(flat.Content, subclass.Content[k], subclass.Content[k+1])
+		}
+	}
+
+	merge := func(part *yaml.Node) {
+		if props := internal.MappingValue(part, "properties"); props != nil && props.Kind == yaml.MappingNode {
+			for k := 0; k+1 < len(props.Content); k += 2 {
+				internal.SetMappingValue(properties, props.Content[k].Value, copyNode(props.Content[k+1]))
+			}
+		}
+		if req := internal.MappingValue(part, "required"); req != nil && req.Kind == yaml.SequenceNode {
+			for _, name := range req.Content {
+				if !slices.ContainsFunc(required.Content, func(n *yaml.Node) bool { return n.Value == name.Value }) {
+					required.Content = append(required.Content, internal.StringNode(name.Value))
+				}
+			}
+		}
+	}
+
+	merge(base)
+	for _, part := range internal.MappingValue(subclass, "allOf").Content {
+		if internal.MappingValue(part, "$ref") != nil {
+			continue
+		}
+		merge(part)
+		for k := 0; k+1 < len(part.Content); k += 2 {
+			key := part.Content[k].Value
+			if key != "properties" && key != "required" && key != "type" && internal.MappingValue(flat, key) == nil {
+				flat.Content = append(flat.Content, part.Content[k], part.Content[k+1])
+			}
+		}
+	}
+
+	internal.SetMappingValue(flat, "type", internal.StringNode("object"))
+	internal.SetMappingValue(flat, "properties", properties)
+	if len(required.Content) > 0 {
+		internal.SetMappingValue(flat, "required", required)
+	}
+	return flat
+}
+
+// copyNode returns a deep copy of node, so schemas sharing a base's properties
+// can be rewritten independently
+func copyNode(node *yaml.Node) *yaml.Node {
+	cp := *node
+	cp.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		cp.Content[i] = copyNode(child)
+	}
+	return &cp
+}
+
+// hoistInlineUnions moves each discriminated oneOf declared inline as a
+// property of a component schema, or as the items of an array property, into a
+// component schema of its own, replacing it with a $ref, so it is generated as a
+// named union like any other. A oneOf without a discriminator is left in place
+// to be rejected as before. The new schema is named after the enclosing schema and
+// the property (Owner.pet → OwnerPet) and follows the schema that declares it.
+// Properties of inline objects are searched too, their names prefixed with the
+// object's (Owner.home.pet → OwnerHomePet). It reports whether any schema was
+// hoisted.
+func hoistInlineUnions(schemas *yaml.Node) (bool, error) {
+	names := make(map[string]bool, len(schemas.Content)/2)
+	for i := 0; i+1 < len(schemas.Content); i += 2 {
+		names[schemas.Content[i].Value] = true
+	}
+
+	hoisted := false
+	content := make([]*yaml.Node, 0, len(schemas.Content))
+	for i := 0; i+1 < len(schemas.Content); i += 2 {
+		name := schemas.Content[i].Value
+		content = append(content, schemas.Content[i], schemas.Content[i+1])
+
+		unions, err := hoistProperties(name, name, schemas.Content[i+1], names)
+		if err != nil {
+			return false, err
+		}
+		content = append(content, unions...)
+		hoisted = hoisted || len(unions) > 0
+	}
+
+	schemas.Content = content
+	return hoisted, nil
+}
+
+// hoistProperties replaces the inline unions among the properties of schema,
+// declared within component schema owner, with references. It returns the
+// key/value nodes of the schemas to add after owner, naming each scope plus the
+// property's Go name.
+func hoistProperties(owner, scope string, schema *yaml.Node, names map[string]bool) ([]*yaml.Node, error) {
+	properties := internal.MappingValue(schema, "properties")
+	if properties == nil || properties.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	var hoisted []*yaml.Node
+	for i := 0; i+1 < len(properties.Content); i += 2 {
+		property := properties.Content[i].Value
+		name := scope + internal.ToGoName(property)
+
+		// The union may be the property itself or its (nested) array items
+		node := properties.Content[i+1]
+		for internal.MappingValue(node, "oneOf") == nil && internal.MappingValue(node, "items") != nil {
+			node = internal.MappingValue(node, "items")
+		}
+		if node.Kind != yaml.MappingNode || internal.MappingValue(node, "$ref") != nil {
+			continue
+		}
+
+		if internal.MappingValue(node, "oneOf") == nil || internal.MappingValue(node, "discriminator") == nil {
+			// An inline object names the unions within it
+			nested, err := hoistProperties(owner, name, node, names)
+			if err != nil {
+				return nil, err
+			}
+			hoisted = append(hoisted, nested...)
+			continue
+		}
+
+		if names[name] {
+			return nil, internal.SchemaError(owner, fmt.Sprintf("property '%s': inline oneOf is named '%s', which is already a component schema", property, name))
+		}
+		names[name] = true
+
+		union := &yaml.Node{}
+		*union = *node
+		*node = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{
+			internal.StringNode("$ref"), internal.StringNode("#/components/schemas/" + name),
+		}}
+		hoisted = append(hoisted, internal.StringNode(name), union)
+
+		// A union's own properties may hold further inline unions
+		nested, err := hoistProperties(owner, name, union, names)
+		if err != nil {
+			return nil, err
+		}
+		hoisted = append(hoisted, nested...)
+	}
+	return hoisted, nil
+}
@@ -396,12 +396,91 @@ components:
             items:
               type: integer
 `
-	_, err := schema.Convert([]byte(given), schema.ConvertOptions{
+	expected := `syntax = "proto3";
+
+package testpkg;
+
+option go_package = "github.com/example/proto/v1";
+
+message Matrix {
+  message DataRow {
+    repeated int32 values = 1 [json_name = "values"];
+  }
+
+  repeated DataRow data = 1 [json_name = "data"];
+}
+
+`
+	result, err := schema.Convert([]byte(given), schema.ConvertOptions{
 		PackageName: "testpkg",
 		PackagePath: "github.com/example/proto/v1",
 	})
-	require.Error(t, err)
-	require.ErrorContains(t, err, "nested arrays not supported")
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(result.Protobuf))
+	assert.Equal(t, []string{"property 'data' is an array of arrays; inner arrays are wrapped in message DataRow"}, result.TypeMap["Matrix"].Notes)
+
+	structs, err := schema.ConvertToStruct([]byte(given), schema.ConvertOptions{
+		GoPackagePath: "github.com/example/types",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, string(structs.Golang), "Data [][]int32 `json:\"data\"`")
+}
+
+func TestNestedArraysThreeLevels(t *testing.T) {
+	given := `
+openapi: 3.0.0
+info:
+  title: Test
+  version: 1.0.0
+components:
+  schemas:
+    Point:
+      type: object
+      properties:
+        x:
+          type: number
+    Cube:
+      type: object
+      properties:
+        grid:
+          type: array
+          items:
+            type: array
+            items:
+              type: array
+              items:
+                $ref: '#/components/schemas/Point'
+`
+	expected := `syntax = "proto3";
+
+package testpkg;
+
+option go_package = "github.com/example/proto/v1";
+
+message Point {
+  double x = 1 [json_name = "x"];
+}
+
+message Cube {
+  message GridList {
+    message GridList_2 {
+      repeated Point values = 1 [json_name = "values"];
+    }
+
+    repeated GridList_2 values = 1 [json_name = "values"];
+  }
+
+  repeated GridList grid = 1 [json_name = "grid"];
+}
+
+`
+	result, err := schema.Convert([]byte(given), schema.ConvertOptions{
+		PackageName:        "testpkg",
+		PackagePath:        "github.com/example/proto/v1",
+		ArrayWrapperSuffix: "List",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(result.Protobuf))
 }
 
 func TestArrayWithoutItems(t *testing.T) {
@@ -1,7 +1,10 @@
 package proto
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -9,18 +12,77 @@ import (
 	"github.com/duh-rpc/openapi-schema.go/internal"
 	"github.com/duh-rpc/openapi-schema.go/internal/parser"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"go.yaml.in/yaml/v4"
 )
 
+// DefaultArrayWrapperSuffix is appended to a property name to name the message that
+// wraps each inner array of an array-of-arrays property
+const DefaultArrayWrapperSuffix = "Row"
+
 // Context holds state during conversion
 type Context struct {
-	Tracker       *internal.NameTracker
-	Messages      []*ProtoMessage
-	Enums         []*ProtoEnum
-	Definitions   []interface{} // Mixed enums and messages in processing order
-	FieldNumbers  *FieldNumbers // nil → positional numbering
-	UsesTimestamp bool
+	Tracker            *internal.NameTracker
+	Messages           []*ProtoMessage
+	Enums              []*ProtoEnum
+	Definitions        []interface{}       // Mixed enums and messages in processing order
+	FieldNumbers       *FieldNumbers       // nil → positional numbering
+	ArrayWrapperSuffix string              // "" → DefaultArrayWrapperSuffix
+	Ctx                context.Context     // checked between schemas; nil → never cancelled
+	Concurrency        int                 // workers used to resolve schemas; <= 1 → sequential
+	Notes              map[string][]string // schema name → notes about how it was mapped
+	Logger             *slog.Logger        // receives debug events about mapping decisions
+	CollectErrors      bool                // record schema/property errors in Errors and keep going
+	Errors             []error             // errors recorded when CollectErrors is set, in schema order
+	ErrorSchemas       []string            // schema each entry of Errors was recorded against
+	FailedSchemas      map[string]bool     // schemas with a recorded error
+	Warnings           []internal.Warning  // information lost in conversion, in build order
+	UsesTimestamp      bool
+	TypeMappings       []internal.TypeMapping   // overrides of the built-in scalar mapping
+	Imports            []string                 // .proto imports required by TypeMappings and well-known types, in first-use order
+	WellKnownTypes     internal.WellKnownTypes  // opt-in mappings onto google.protobuf types
+	Decimals           *internal.DecimalOptions // opt-in mapping of decimal values; nil → built-in scalars
+	// NestedNameFunc names messages and enums generated for inline schemas, given
+	// the enclosing message and the property; "" → the PascalCase property name
+	NestedNameFunc func(parent, property string) string
+	FieldNaming    string // FieldNamingPreserve or FieldNamingSnakeCase; "" → preserve
+	// UseProto3Optional marks scalar and enum fields not listed in required with
+	// the proto3 optional keyword
+	UseProto3Optional bool
+	// Style controls the layout of the generated output, including comments
+	Style Style
+	// FileOptions are written as file-level option statements after go_package
+	FileOptions []Option
+	// Opaque names component schemas excluded from generation; references to
+	// them become bytes fields holding the schema's JSON
+	Opaque map[string]bool
+	// Imported maps component schemas generated from another spec, also listed
+	// in Opaque, to the types references use instead
+	Imported map[string]internal.ImportMapping
+	// Services are emitted after the messages and enums; see BuildServices
+	Services []*ProtoService
+	// NameCollision resolves a type name already in use; "" → NameCollisionSuffix
+	NameCollision string
+	// NonASCIINames handles non-ASCII characters in property names, see
+	// internal.ASCIIName; "" → internal.NonASCIITransliterate
+	NonASCIINames string
+	// Renames lists the type and field names changed to resolve collisions, in
+	// the order they were made
+	Renames []internal.Rename
+
+	schema     string            // top-level schema currently being built, for attributing notes
+	pointer    []string          // JSON pointer segments of the node being built, for fix suggestions
+	components map[string]bool   // component schema names, so suggested names do not collide
+	typeNames  map[string]string // component schema name → its message or enum name
+	typeOwners map[string]string // reserved type name → what it was reserved for, for errors
 }
 
+// Strategies for resolving a generated type name that is already in use
+const (
+	NameCollisionSuffix           = "suffix"           // append _2, _3, ...
+	NameCollisionError            = "error"            // fail the schema
+	NameCollisionPrefixWithSchema = "prefixWithSchema" // prefix inline types with their schema's name, then suffix
+)
+
 // NewContext creates a new conversion context
 func NewContext() *Context {
 	return &Context{
@@ -28,10 +90,285 @@ func NewContext() *Context {
 		Messages:      []*ProtoMessage{},
 		Enums:         []*ProtoEnum{},
 		Definitions:   []interface{}{},
+		Notes:         map[string][]string{},
+		Logger:        internal.LoggerOrDiscard(nil),
+		FailedSchemas: map[string]bool{},
 		UsesTimestamp: false,
 	}
 }
 
+// opaqueRef reports whether proxy references a schema excluded from generation
+func (c *Context) opaqueRef(proxy *base.SchemaProxy) bool {
+	if !proxy.IsReference() {
+		return false
+	}
+	name, err := internal.ExtractReferenceName(proxy.GetReference())
+	return err == nil && c.Opaque[name]
+}
+
+// importedRef returns the mapping of the schema proxy references when it is
+// generated from another spec, adding the import it needs
+func (c *Context) importedRef(proxy *base.SchemaProxy) (string, bool) {
+	if !proxy.IsReference() {
+		return "", false
+	}
+	name, err := internal.ExtractReferenceName(proxy.GetReference())
+	mapping, ok := c.Imported[name]
+	if err != nil || !ok {
+		return "", false
+	}
+	if mapping.ProtoImport != "" {
+		c.addImport(mapping.ProtoImport)
+	}
+	return mapping.Proto, true
+}
+
+// opaqueVariant returns the first of a union's variants excluded from
+// generation, or "" when every variant is generated
+func (c *Context) opaqueVariant(variants []string) string {
+	for _, variant := range variants {
+		if c.Opaque[variant] {
+			return variant
+		}
+	}
+	return ""
+}
+
+// nestedTypeName names the message or enum generated for an inline schema under
+// propertyName: NestedNameFunc's choice when it makes one, otherwise the PascalCase
+// property name. plural reports that the default name was used for a plural
+// property, which callers reject where the schema is an array item or object.
+func (c *Context) nestedTypeName(parent *ProtoMessage, propertyName string) (name string, plural bool, err error) {
+	if c.NestedNameFunc != nil {
+		parentName := ""
+		if parent != nil {
+			parentName = parent.Name
+		}
+		if name := c.NestedNameFunc(parentName, propertyName); name != "" {
+			if !isTypeName(name) {
+				return "", false, fmt.Errorf("property '%s': NestedNameFunc returned invalid name '%s'", propertyName, name)
+			}
+			return name, false, nil
+		}
+	}
+	ascii, err := internal.ASCIIName(propertyName, c.NonASCIINames)
+	if err != nil {
+		return "", false, fmt.Errorf("property '%s': %w", propertyName, err)
+	}
+	return internal.ToPascalCase(ascii), internal.IsPlural(ascii), nil
+}
+
+// isTypeName reports whether name is a valid proto message or enum name
+func isTypeName(name string) bool {
+	for i, r := range name {
+		switch {
+		case 'A' <= r && r <= 'Z', 'a' <= r && r <= 'z':
+		case i > 0 && ('0' <= r && r <= '9' || r == '_'):
+		default:
+			return false
+		}
+	}
+	return name != ""
+}
+
+// addImport records a .proto import needed by the output, once
+func (c *Context) addImport(imp string) {
+	if !slices.Contains(c.Imports, imp) {
+		c.Logger.Debug(internal.LogImportAdded, "schema", c.schema, "import", imp)
+		c.Imports = append(c.Imports, imp)
+	}
+}
+
+// addNote records a mapping note against the top-level schema being built
+func (c *Context) addNote(note string) {
+	if c.schema == "" {
+		return
+	}
+	c.Notes[c.schema] = append(c.Notes[c.schema], note)
+}
+
+// report returns err unchanged unless CollectErrors is set, in which case the error
+// is recorded against schema and nil is returned so the caller carries on
+func (c *Context) report(schema string, err error) error {
+	if err == nil || !c.CollectErrors {
+		return err
+	}
+	c.Errors = append(c.Errors, err)
+	c.ErrorSchemas = append(c.ErrorSchemas, schema)
+	c.FailedSchemas[schema] = true
+	return nil
+}
+
+// warn records a warning against the node currently being built
+func (c *Context) warn(code, message string) {
+	c.Warnings = append(c.Warnings, internal.Warning{
+		Schema:  c.schema,
+		Pointer: c.pointerTo(),
+		Code:    code,
+		Message: message,
+	})
+}
+
+// warnDroppedConstraints warns when schema carries validation keywords proto3 cannot express
+func (c *Context) warnDroppedConstraints(schema *base.Schema) {
+	if dropped := internal.DroppedConstraints(schema); dropped != "" {
+		c.warn(internal.WarnConstraintDropped, dropped+" dropped in proto")
+	}
+}
+
+// typeName returns the message or enum name of the component schema name,
+// reserving it on first use: its x-proto-name, or else its PascalCase name,
+// resolved against names already in use
+func (c *Context) typeName(name string, schema *base.Schema) (string, error) {
+	if typeName, ok := c.typeNames[name]; ok {
+		return typeName, nil
+	}
+
+	typeName, err := internal.NameOverride(schema, "x-proto-name")
+	if err != nil {
+		return "", err
+	}
+	if typeName == "" {
+		typeName = internal.ToPascalCase(name)
+	}
+	typeName, err = c.uniqueTypeName(name, "", typeName)
+	if err != nil {
+		return "", err
+	}
+
+	if c.typeNames == nil {
+		c.typeNames = make(map[string]string)
+	}
+	c.typeNames[name] = typeName
+	return typeName, nil
+}
+
+// uniqueTypeName reserves a message or enum name for schema's own type, or for
+// the inline type of its property, resolving a name already in use under
+// c.NameCollision and recording the rename
+func (c *Context) uniqueTypeName(schema, property, name string) (string, error) {
+	if c.typeOwners == nil {
+		c.typeOwners = make(map[string]string)
+	}
+	owner := fmt.Sprintf("schema '%s'", schema)
+	if property != "" {
+		owner = fmt.Sprintf("property '%s' of schema '%s'", property, schema)
+	}
+
+	if !c.Tracker.Has(name) {
+		c.typeOwners[name] = owner
+		return c.Tracker.UniqueName(name), nil
+	}
+
+	unique := ""
+	switch c.NameCollision {
+	case NameCollisionError:
+		return "", fmt.Errorf("type name '%s' is already used by %s", name, c.typeOwners[name])
+	case NameCollisionPrefixWithSchema:
+		if candidate := internal.ToPascalCase(schema) + name; property != "" && !c.Tracker.Has(candidate) {
+			unique = c.Tracker.UniqueName(candidate)
+		}
+	}
+	if unique == "" {
+		unique = c.Tracker.UniqueName(name)
+	}
+	c.typeOwners[unique] = owner
+
+	c.Logger.Debug(internal.LogNameRenamed, "schema", schema, "kind", "type", "from", name, "to", unique)
+	c.Renames = append(c.Renames, internal.Rename{Schema: schema, Property: property, Kind: internal.RenameType,
+		Reason: internal.RenameCollision, From: name, To: unique})
+	return unique, nil
+}
+
+// Proto field naming strategies
+const (
+	FieldNamingPreserve  = "preserve"   // keep the OpenAPI property name, sanitized
+	FieldNamingSnakeCase = "snake_case" // lower_snake_case, as buf lint's FIELD_LOWER_SNAKE_CASE expects
+)
+
+// fieldName derives the proto field name for property propName of schema: its
+// x-proto-field-name, used as given, or else the name under c.FieldNaming.
+// json_name always carries the original property name, so the JSON wire format
+// is the same under either strategy, and for non-ASCII names made ASCII under
+// c.NonASCIINames.
+func (c *Context) fieldName(schema *base.Schema, propName string) (name string, explicit bool, err error) {
+	name, err = extractFieldName(schema, propName)
+	if err != nil || name != "" {
+		return name, name != "", err
+	}
+
+	ascii, err := internal.ASCIIName(propName, c.NonASCIINames)
+	if err != nil {
+		return "", false, err
+	}
+	if c.FieldNaming == FieldNamingSnakeCase {
+		name, err = internal.SanitizeFieldName(internal.ApplyJSONCase(ascii, internal.JSONCaseSnake))
+		return name, false, err
+	}
+	name, err = internal.SanitizeFieldName(ascii)
+	return name, false, err
+}
+
+// extractFieldName returns the x-proto-field-name of property propName of
+// schema; "" when it has none
+func extractFieldName(schema *base.Schema, propName string) (string, error) {
+	node := internal.PropertyExtension(schema, propName, "x-proto-field-name")
+	if node == nil {
+		return "", nil
+	}
+	if node.Kind != yaml.ScalarNode || !internal.IsTypeIdentifier(node.Value) {
+		return "", fmt.Errorf("x-proto-field-name must be a proto field name (letters, digits and underscores, starting with a letter), got: %s", node.Value)
+	}
+	return node.Value, nil
+}
+
+// optional reports whether a field gets the proto3 optional keyword: a non-repeated
+// scalar or enum whose property is not required. Message fields already track
+// presence, so they are left alone.
+func (c *Context) optional(propSchema *base.Schema, protoType string, repeated, required bool) bool {
+	if !c.UseProto3Optional || repeated || required {
+		return false
+	}
+	if isIntegerEnum(propSchema) {
+		return true
+	}
+	_, known := protoTypeOverrides[protoType]
+	return known && !strings.HasPrefix(protoType, "google.protobuf.")
+}
+
+// uniqueFieldName reserves a proto field name for propName, logging when sanitizing
+// or de-duplicating changes it and recording the rename. A field name already in
+// use is an error under NameCollisionError or when explicit, set by
+// x-proto-field-name, and otherwise suffixed.
+func (c *Context) uniqueFieldName(tracker *internal.NameTracker, schema, propName, sanitized string, explicit bool) (string, error) {
+	if tracker.Has(sanitized) && (c.NameCollision == NameCollisionError || explicit) {
+		return "", fmt.Errorf("field name '%s' is already used by another property", sanitized)
+	}
+	if explicit {
+		return tracker.UniqueName(sanitized), nil
+	}
+
+	unique := tracker.UniqueName(sanitized)
+	if unique != propName {
+		c.Logger.Debug(internal.LogNameRenamed, "schema", schema, "kind", "field", "from", propName, "to", unique)
+	}
+
+	// snake_case naming renames fields by design, so only property names holding
+	// characters proto does not allow count as sanitized
+	reason := ""
+	ascii, _ := internal.ASCIIName(propName, c.NonASCIINames)
+	if unique != sanitized {
+		reason = internal.RenameCollision
+	} else if valid, err := internal.SanitizeFieldName(ascii); err == nil && valid != propName {
+		reason = internal.RenameSanitized
+	}
+	if reason != "" {
+		c.Renames = append(c.Renames, internal.Rename{Schema: c.schema, Property: propName, Kind: internal.RenameField,
+			Reason: reason, From: propName, To: unique})
+	}
+	return unique, nil
+}
+
 // ProtoMessage represents a proto3 message definition
 type ProtoMessage struct {
 	Name           string
@@ -40,6 +377,8 @@ type ProtoMessage struct {
 	Nested         []*ProtoMessage
 	Oneofs         []*ProtoOneof // proto3 oneof groups; members are a subset of Fields
 	Reserved       []int         // proto field numbers retired via removal (rendered as `reserved N, M;`)
+	ReservedNames  []string      // field names retired via x-proto-reserved (rendered as `reserved "a", "b";`)
+	Options        []Option      // message options from x-proto-options
 	OriginalSchema string        // Original schema name before name tracker renaming
 }
 
@@ -60,15 +399,18 @@ type ProtoField struct {
 	JSONName    string
 	Description string
 	Repeated    bool
+	Optional    bool // rendered with the proto3 optional keyword
 	EnumValues  []string
 }
 
 // ProtoEnum represents a proto3 enum definition
 type ProtoEnum struct {
-	Name        string
-	Description string
-	Values      []*ProtoEnumValue
-	Reserved    []int // proto numbers retired via removal (rendered as `reserved N, M;`)
+	Name           string
+	Description    string
+	Values         []*ProtoEnumValue
+	Reserved       []int    // proto numbers retired via removal (rendered as `reserved N, M;`)
+	Options        []Option // enum options from x-proto-options
+	OriginalSchema string   // component schema name; empty for enums nested in a message
 }
 
 // ProtoEnumValue represents an enum value
@@ -81,8 +423,32 @@ type ProtoEnumValue struct {
 func BuildMessages(entries []*parser.SchemaEntry, ctx *Context) (*internal.DependencyGraph, error) {
 	graph := internal.NewDependencyGraph()
 
+	ctx.components = make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		ctx.components[entry.Name] = true
+	}
+
+	// Resolving schemas is the dominant cost on large specs and is safe to run
+	// concurrently; building below stays sequential so naming and order are unchanged.
+	if ctx.Concurrency > 1 {
+		err := internal.ParallelEach(ctx.Concurrency, len(entries), func(i int) error {
+			if err := internal.Cancelled(ctx.Ctx); err != nil {
+				return err
+			}
+			internal.ResolveSchemaTree(entries[i].Proxy)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// First pass: Add all schemas to graph and detect unions
 	for _, entry := range entries {
+		if err := internal.Cancelled(ctx.Ctx); err != nil {
+			return nil, err
+		}
+
 		if err := graph.AddSchema(entry.Name, entry.Proxy); err != nil {
 			return nil, err
 		}
@@ -94,27 +460,62 @@ func BuildMessages(entries []*parser.SchemaEntry, ctx *Context) (*internal.Depen
 
 		// Validate schema first
 		if err := validateTopLevelSchema(schema, entry.Name); err != nil {
-			return nil, err
+			if err := ctx.report(entry.Name, err); err != nil {
+				return nil, err
+			}
+			continue
 		}
 
 		// Detect oneOf and mark as union. Style B is a protobuf oneof built as a
 		// message, not a Go union, so it is left unmarked.
 		if len(schema.OneOf) > 0 && !isStyleBOneOf(schema) {
 			variants := internal.ExtractVariantNames(schema.OneOf)
+			if variant := ctx.opaqueVariant(variants); variant != "" {
+				err := internal.SchemaError(entry.Name, fmt.Sprintf("union variant '%s' is excluded from generation", variant))
+				if err := ctx.report(entry.Name, err); err != nil {
+					return nil, err
+				}
+				continue
+			}
 			graph.MarkUnion(entry.Name, "contains oneOf", variants)
 		}
 	}
 
+	// Names of top-level messages and enums are reserved in document order before
+	// any is built, so references resolve to them and inline types never take them
+	for _, entry := range entries {
+		schema := entry.Proxy.Schema()
+		if schema == nil || ctx.FailedSchemas[entry.Name] || !definesType(schema) {
+			continue
+		}
+		if _, err := ctx.typeName(entry.Name, schema); err != nil {
+			if err := ctx.report(entry.Name, internal.SchemaError(entry.Name, err.Error())); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// Second pass: Build messages and track dependencies
 	for _, entry := range entries {
+		if err := internal.Cancelled(ctx.Ctx); err != nil {
+			return nil, err
+		}
+
 		schema := entry.Proxy.Schema()
 		if schema == nil {
+			ctx.Logger.Debug(internal.LogSchemaSkipped, "schema", entry.Name, "reason", "schema could not be resolved")
+			continue
+		}
+
+		// Already reported as invalid while collecting errors
+		if ctx.FailedSchemas[entry.Name] {
 			continue
 		}
 
 		// Flat/discriminated oneOf schemas are handled as Go code; style-B schemas
 		// fall through and are built as protobuf messages with a oneof group.
 		if len(schema.OneOf) > 0 && !isStyleBOneOf(schema) {
+			ctx.Logger.Debug(internal.LogSchemaSkipped, "schema", entry.Name, "reason", "oneOf union is generated as Go code")
 			continue
 		}
 
@@ -122,29 +523,48 @@ func BuildMessages(entries []*parser.SchemaEntry, ctx *Context) (*internal.Depen
 		if internal.IsEnumSchema(schema) {
 			// Validate enum schema first
 			if err := validateEnumSchema(schema, entry.Name); err != nil {
-				return nil, err
+				if err := ctx.report(entry.Name, err); err != nil {
+					return nil, err
+				}
+				continue
 			}
 
 			// Check if it's a string enum - skip building protobuf enum
 			if isStringEnum(schema) {
+				ctx.Logger.Debug(internal.LogSchemaSkipped, "schema", entry.Name, "reason", "string enum is mapped to string fields")
+				ctx.Warnings = append(ctx.Warnings, internal.Warning{
+					Schema:  entry.Name,
+					Pointer: internal.JSONPointer("components", "schemas", entry.Name),
+					Code:    internal.WarnEnumFlattened,
+					Message: "string enum flattened to string",
+				})
 				continue
 			}
 			// Only build enum for integer enums
 			_, err := buildEnum(entry.Name, entry.Proxy, ctx)
-			if err != nil {
+			if err := ctx.report(entry.Name, err); err != nil {
 				return nil, err
 			}
 			continue
 		}
 
 		_, err := buildMessage(entry.Name, entry.Proxy, ctx, graph)
-		if err != nil {
+		if err := ctx.report(entry.Name, err); err != nil {
 			return nil, err
 		}
 	}
 	return graph, nil
 }
 
+// definesType reports whether a component schema generates a top-level
+// message or enum: integer enums, and objects other than Go unions
+func definesType(schema *base.Schema) bool {
+	if internal.IsEnumSchema(schema) {
+		return isIntegerEnum(schema)
+	}
+	return len(schema.OneOf) == 0 || isStyleBOneOf(schema)
+}
+
 // buildMessage creates a protoMessage from an OpenAPI schema
 func buildMessage(name string, proxy *base.SchemaProxy, ctx *Context, graph *internal.DependencyGraph) (*ProtoMessage, error) {
 	schema := proxy.Schema()
@@ -161,13 +581,26 @@ func buildMessage(name string, proxy *base.SchemaProxy, ctx *Context, graph *int
 	}
 
 	// Validate field numbers before processing
-	if err := validateFieldNumbers(schema, name); err != nil {
+	if err := validateFieldNumbers(schema, name, internal.JSONPointer("components", "schemas", name)); err != nil {
 		return nil, err
 	}
 
+	ctx.schema = name
+	ctx.pointer = []string{"components", "schemas", name}
+	defer func() {
+		ctx.schema = ""
+		ctx.pointer = nil
+	}()
+
+	// x-proto-name renames the message without renaming the schema
+	msgName, err := ctx.typeName(name, schema)
+	if err != nil {
+		return nil, internal.SchemaError(name, err.Error())
+	}
+
 	msg := &ProtoMessage{
-		Name:           ctx.Tracker.UniqueName(internal.ToPascalCase(name)),
-		Description:    schema.Description,
+		Name:           msgName,
+		Description:    internal.SchemaDoc(schema),
 		Fields:         []*ProtoField{},
 		Nested:         []*ProtoMessage{},
 		OriginalSchema: name,
@@ -190,7 +623,10 @@ func buildMessage(name string, proxy *base.SchemaProxy, ctx *Context, graph *int
 		for propName, propProxy := range schema.Properties.FromOldest() {
 			propSchema := propProxy.Schema()
 			if propSchema == nil {
-				return nil, internal.PropertyError(name, propName, "has nil schema")
+				if err := ctx.report(name, internal.PropertyError(name, propName, "has nil schema")); err != nil {
+					return nil, err
+				}
+				continue
 			}
 
 			// Track dependency if property references another schema
@@ -199,52 +635,64 @@ func buildMessage(name string, proxy *base.SchemaProxy, ctx *Context, graph *int
 				parts := strings.Split(ref, "/")
 				if len(parts) > 0 {
 					refName := parts[len(parts)-1]
-					if refName != "" {
+					if refName != "" && !ctx.Opaque[refName] {
 						graph.AddDependency(name, refName)
 					}
 				}
 			}
 
-			// Track dependencies in array items
-			if len(propSchema.Type) > 0 && internal.Contains(propSchema.Type, "array") {
-				if propSchema.Items != nil && propSchema.Items.A != nil {
-					itemProxy := propSchema.Items.A
-					if itemProxy.IsReference() {
-						ref := itemProxy.GetReference()
-						parts := strings.Split(ref, "/")
-						if len(parts) > 0 {
-							refName := parts[len(parts)-1]
-							if refName != "" {
-								graph.AddDependency(name, refName)
-							}
-						}
+			// Track dependencies in array items, looking through arrays of arrays
+			if itemProxy := innermostArrayItems(propSchema); itemProxy != nil && itemProxy.IsReference() {
+				ref := itemProxy.GetReference()
+				parts := strings.Split(ref, "/")
+				if len(parts) > 0 {
+					refName := parts[len(parts)-1]
+					if refName != "" && !ctx.Opaque[refName] {
+						graph.AddDependency(name, refName)
 					}
 				}
 			}
 
-			sanitizedName, err := internal.SanitizeFieldName(propName)
+			sanitizedName, explicit, err := ctx.fieldName(schema, propName)
 			if err != nil {
-				return nil, internal.PropertyError(name, propName, err.Error())
+				if err := ctx.report(name, internal.PropertyError(name, propName, err.Error())); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			protoFieldName, err := ctx.uniqueFieldName(fieldTracker, name, propName, sanitizedName, explicit)
+			if err != nil {
+				if err := ctx.report(name, internal.PropertyError(name, propName, err.Error())); err != nil {
+					return nil, err
+				}
+				continue
 			}
-			protoFieldName := fieldTracker.UniqueName(sanitizedName)
+			ctx.pointer = append(ctx.pointer, "properties", propName)
 			protoType, repeated, enumValues, err := ProtoType(propSchema, propName, propProxy, ctx, msg)
+			ctx.pointer = ctx.pointer[:len(ctx.pointer)-2]
 			if err != nil {
 				// Don't wrap with PropertyError if the error already contains the property name
 				if strings.Contains(err.Error(), fmt.Sprintf("property '%s'", propName)) {
-					return nil, fmt.Errorf("schema '%s': %w", name, err)
+					err = fmt.Errorf("schema '%s': %w", name, err)
+				} else {
+					err = fmt.Errorf("schema '%s': property '%s' %w", name, propName, err)
+				}
+				if err := ctx.report(name, err); err != nil {
+					return nil, err
 				}
-				return nil, internal.PropertyError(name, propName, err.Error())
+				continue
 			}
 
 			// For inline objects and integer enums, description goes to the nested type, not the field
 			// For string enums, keep description on field (not hoisted)
-			fieldDescription := propSchema.Description
+			fieldDescription := internal.SchemaDoc(propSchema)
 			if len(propSchema.Type) > 0 && internal.Contains(propSchema.Type, "object") {
 				fieldDescription = ""
 			}
 			if isIntegerEnum(propSchema) {
 				fieldDescription = ""
 			}
+			fieldDescription = internal.WithAccessNote(fieldDescription, propSchema)
 
 			// Field number priority: supplied FieldNumbers (by JSON name) override
 			// everything; otherwise the x-proto-number extension; otherwise positional.
@@ -275,6 +723,7 @@ func buildMessage(name string, proxy *base.SchemaProxy, ctx *Context, graph *int
 				Repeated:    repeated,
 				JSONName:    propName,
 				EnumValues:  enumValues,
+				Optional:    ctx.optional(propSchema, protoType, repeated, slices.Contains(schema.Required, propName)),
 			}
 
 			msg.Fields = append(msg.Fields, field)
@@ -298,6 +747,13 @@ func buildMessage(name string, proxy *base.SchemaProxy, ctx *Context, graph *int
 		sortFieldsByNumber(msg.Fields)
 	}
 
+	if err := applyReserved(msg, schema); err != nil {
+		return nil, internal.SchemaError(name, err.Error())
+	}
+	if msg.Options, err = extractOptions(schema); err != nil {
+		return nil, internal.SchemaError(name, err.Error())
+	}
+
 	// Style B: group the variant properties into a protobuf oneof. The fields were
 	// already numbered above by the normal property loop; grouping references them by
 	// identity and never alters numbers.
@@ -312,6 +768,23 @@ func buildMessage(name string, proxy *base.SchemaProxy, ctx *Context, graph *int
 	return msg, nil
 }
 
+// innermostArrayItems returns the items proxy of an array schema, descending through
+// inline arrays of arrays, or nil when the schema is not an array with items
+func innermostArrayItems(schema *base.Schema) *base.SchemaProxy {
+	var items *base.SchemaProxy
+	for schema != nil && len(schema.Type) > 0 && internal.Contains(schema.Type, "array") {
+		if schema.Items == nil || schema.Items.A == nil {
+			return items
+		}
+		items = schema.Items.A
+		if items.IsReference() {
+			return items
+		}
+		schema = items.Schema()
+	}
+	return items
+}
+
 // attachOneof records that the style-B variant properties belong to one oneof group.
 // Members are referenced by identity from msg.Fields (so numbering and reserved
 // handling are untouched) and emitted in field-number order for deterministic output.
@@ -337,6 +810,8 @@ func attachOneof(msg *ProtoMessage, schema *base.Schema, name string) error {
 		if !ok {
 			return internal.SchemaError(name, fmt.Sprintf("oneOf variant '%s' has no corresponding field", propName))
 		}
+		// oneof members already have presence and may not be optional
+		field.Optional = false
 		group.Fields = append(group.Fields, field)
 	}
 	sortFieldsByNumber(group.Fields)
@@ -485,7 +960,7 @@ func extractFieldNumber(proxy *base.SchemaProxy) (int, bool, error) {
 // - Field numbers use reserved range (19000-19999)
 // - Field number is 0 (invalid)
 // - Some but not all fields have x-proto-number (all-or-nothing violation)
-func validateFieldNumbers(schema *base.Schema, schemaName string) error {
+func validateFieldNumbers(schema *base.Schema, schemaName, pointer string) error {
 	if schema == nil || schema.Properties == nil {
 		return nil
 	}
@@ -507,7 +982,8 @@ func validateFieldNumbers(schema *base.Schema, schemaName string) error {
 
 	// Enforce all-or-nothing: if any field has x-proto-number, all must have it
 	if annotatedCount > 0 && annotatedCount < totalProps {
-		return internal.SchemaError(schemaName, fmt.Sprintf("x-proto-number must be specified on all fields or none (found on %d of %d fields)", annotatedCount, totalProps))
+		err := internal.SchemaError(schemaName, fmt.Sprintf("x-proto-number must be specified on all fields or none (found on %d of %d fields)", annotatedCount, totalProps))
+		return internal.WithFix(err, fieldNumberFix(schema, pointer))
 	}
 
 	// Track seen field numbers to detect duplicates
@@ -561,12 +1037,17 @@ func buildEnum(name string, proxy *base.SchemaProxy, ctx *Context) (*ProtoEnum,
 		return nil, internal.SchemaError(name, "schema is nil")
 	}
 
-	enumName := ctx.Tracker.UniqueName(internal.ToPascalCase(name))
+	// x-proto-name renames the enum as it does a message
+	enumName, err := ctx.typeName(name, schema)
+	if err != nil {
+		return nil, internal.SchemaError(name, err.Error())
+	}
 
 	enum := &ProtoEnum{
-		Name:        enumName,
-		Description: schema.Description,
-		Values:      []*ProtoEnumValue{},
+		Name:           enumName,
+		Description:    internal.SchemaDoc(schema),
+		Values:         []*ProtoEnumValue{},
+		OriginalSchema: name,
 	}
 
 	// Numbers come from the supplied mapping (keyed by literal enum value) when
@@ -574,6 +1055,12 @@ func buildEnum(name string, proxy *base.SchemaProxy, ctx *Context) (*ProtoEnum,
 	// 0 with no special case, satisfying proto3's zero-value requirement: callers are
 	// expected to declare an *_UNSPECIFIED sentinel first. The library no longer
 	// synthesizes an UNSPECIFIED value.
+	options, err := extractOptions(schema)
+	if err != nil {
+		return nil, internal.SchemaError(name, err.Error())
+	}
+	enum.Options = options
+
 	enumNums := enumNumbersFor(ctx, name)
 	if enumNums != nil {
 		enum.Reserved = enumNums.Reserved
@@ -614,8 +1101,8 @@ func buildEnum(name string, proxy *base.SchemaProxy, ctx *Context) (*ProtoEnum,
 	return enum, nil
 }
 
-// buildNestedMessage creates nested message from inline object property
-func buildNestedMessage(propertyName string, proxy *base.SchemaProxy, ctx *Context, parentMsg *ProtoMessage) (*ProtoMessage, error) {
+// buildNestedMessage creates nested message msgName from inline object property
+func buildNestedMessage(propertyName, msgName string, proxy *base.SchemaProxy, ctx *Context, parentMsg *ProtoMessage) (*ProtoMessage, error) {
 	schema := proxy.Schema()
 	if schema == nil {
 		if err := proxy.GetBuildError(); err != nil {
@@ -624,27 +1111,19 @@ func buildNestedMessage(propertyName string, proxy *base.SchemaProxy, ctx *Conte
 		return nil, fmt.Errorf("nested object schema is nil")
 	}
 
-	// Validate property name is not plural
-	// Simple check: error if ends with 's' or 'es' (no intelligent singularization)
-	if strings.HasSuffix(propertyName, "es") {
-		return nil, fmt.Errorf("cannot derive message name from property '%s'; use singular form or $ref", propertyName)
-	}
-	if strings.HasSuffix(propertyName, "s") {
-		return nil, fmt.Errorf("cannot derive message name from property '%s'; use singular form or $ref", propertyName)
+	msgName, err := ctx.uniqueTypeName(ctx.schema, propertyName, msgName)
+	if err != nil {
+		return nil, fmt.Errorf("property '%s': %w", propertyName, err)
 	}
 
-	// Derive nested message name via PascalCase
-	msgName := internal.ToPascalCase(propertyName)
-	msgName = ctx.Tracker.UniqueName(msgName)
-
 	// Validate field numbers before processing
-	if err := validateFieldNumbers(schema, propertyName); err != nil {
+	if err := validateFieldNumbers(schema, propertyName, ctx.pointerTo()); err != nil {
 		return nil, err
 	}
 
 	msg := &ProtoMessage{
 		Name:           msgName,
-		Description:    schema.Description,
+		Description:    internal.SchemaDoc(schema),
 		Fields:         []*ProtoField{},
 		Nested:         []*ProtoMessage{},
 		OriginalSchema: propertyName, // For nested messages, use property name
@@ -661,12 +1140,17 @@ func buildNestedMessage(propertyName string, proxy *base.SchemaProxy, ctx *Conte
 				return nil, fmt.Errorf("property '%s': has nil schema", propName)
 			}
 
-			sanitizedName, err := internal.SanitizeFieldName(propName)
+			sanitizedName, explicit, err := ctx.fieldName(schema, propName)
 			if err != nil {
 				return nil, fmt.Errorf("property '%s': %w", propName, err)
 			}
-			protoFieldName := fieldTracker.UniqueName(sanitizedName)
+			protoFieldName, err := ctx.uniqueFieldName(fieldTracker, propertyName, propName, sanitizedName, explicit)
+			if err != nil {
+				return nil, fmt.Errorf("property '%s': %w", propName, err)
+			}
+			ctx.pointer = append(ctx.pointer, "properties", propName)
 			protoType, repeated, enumValues, err := ProtoType(propSchema, propName, propProxy, ctx, msg)
+			ctx.pointer = ctx.pointer[:len(ctx.pointer)-2]
 			if err != nil {
 				// Don't wrap if the error already contains the property name
 				if strings.Contains(err.Error(), fmt.Sprintf("property '%s'", propName)) {
@@ -677,13 +1161,14 @@ func buildNestedMessage(propertyName string, proxy *base.SchemaProxy, ctx *Conte
 
 			// For inline objects and integer enums, description goes to the nested type, not the field
 			// For string enums, keep description on field (not hoisted)
-			fieldDescription := propSchema.Description
+			fieldDescription := internal.SchemaDoc(propSchema)
 			if len(propSchema.Type) > 0 && internal.Contains(propSchema.Type, "object") {
 				fieldDescription = ""
 			}
 			if isIntegerEnum(propSchema) {
 				fieldDescription = ""
 			}
+			fieldDescription = internal.WithAccessNote(fieldDescription, propSchema)
 
 			// Extract field number from x-proto-number extension if present
 			customFieldNum, hasCustomNum, _ := extractFieldNumber(propProxy)
@@ -700,6 +1185,7 @@ func buildNestedMessage(propertyName string, proxy *base.SchemaProxy, ctx *Conte
 				Repeated:    repeated,
 				JSONName:    propName,
 				EnumValues:  enumValues,
+				Optional:    ctx.optional(propSchema, protoType, repeated, slices.Contains(schema.Required, propName)),
 			}
 
 			msg.Fields = append(msg.Fields, field)
@@ -711,6 +1197,15 @@ func buildNestedMessage(propertyName string, proxy *base.SchemaProxy, ctx *Conte
 		}
 	}
 
+	if err := applyReserved(msg, schema); err != nil {
+		return nil, fmt.Errorf("property '%s': %w", propertyName, err)
+	}
+	options, err := extractOptions(schema)
+	if err != nil {
+		return nil, fmt.Errorf("property '%s': %w", propertyName, err)
+	}
+	msg.Options = options
+
 	// Add to parent's nested messages
 	if parentMsg != nil {
 		parentMsg.Nested = append(parentMsg.Nested, msg)
@@ -753,7 +1248,8 @@ func validateTopLevelSchema(schema *base.Schema, schemaName string) error {
 			if !anyBranchIsRef(schema.OneOf) {
 				return internal.SchemaError(schemaName, "oneOf without a discriminator must be style B: each branch must name exactly one required property declared in properties")
 			}
-			return fmt.Errorf("schema '%s': oneOf requires discriminator", schemaName)
+			err := fmt.Errorf("schema '%s': oneOf requires discriminator", schemaName)
+			return internal.WithFix(err, discriminatorFix(schema, internal.JSONPointer("components", "schemas", schemaName)))
 		}
 
 		// Require all variants to be $ref (no inline schemas)
@@ -0,0 +1,32 @@
+package proto
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bufbuild/protocompile"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// BuildDescriptor compiles generated proto source, named fileName, into a file
+// descriptor, keeping source positions and comments. Imports of the
+// google/protobuf well-known types resolve to copies bundled with the compiler;
+// any other import is an error, since its source is not available.
+func BuildDescriptor(ctx context.Context, fileName string, src []byte) (protoreflect.FileDescriptor, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{
+			Accessor: protocompile.SourceAccessorFromMap(map[string]string{fileName: string(src)}),
+		}),
+		SourceInfoMode: protocompile.SourceInfoStandard,
+	}
+
+	files, err := compiler.Compile(ctx, fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build descriptor: %w", err)
+	}
+	return files[0], nil
+}
@@ -0,0 +1,176 @@
+package proto
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// reservedRangeStart and reservedRangeEnd bound the field numbers protobuf reserves
+// for its own implementation
+const (
+	reservedRangeStart = 19000
+	reservedRangeEnd   = 19999
+)
+
+// pointerTo returns the JSON pointer of the node currently being built, extended
+// by segments
+func (c *Context) pointerTo(segments ...string) string {
+	return internal.JSONPointer(append(append([]string{}, c.pointer...), segments...)...)
+}
+
+// extractComponentFix suggests moving the inline schema at pointer into its own
+// component and referencing it, so its name no longer derives from a plural
+// property. Returns nil when the inline schema cannot be read back.
+func extractComponentFix(ctx *Context, propertyName string, proxy *base.SchemaProxy, pointer string) *internal.FixSuggestion {
+	node := proxy.GetValueNode()
+	if node == nil {
+		return nil
+	}
+
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return nil
+	}
+
+	stem := internal.ToPascalCase(internal.Singularize(propertyName))
+	name := stem
+	for i := 2; ctx.components[name]; i++ {
+		name = stem + strconv.Itoa(i)
+	}
+
+	return &internal.FixSuggestion{
+		Description: fmt.Sprintf("move the inline schema of '%s' to components/schemas/%s and reference it", propertyName, name),
+		Patch: []internal.PatchOperation{
+			{Op: "add", Path: internal.JSONPointer("components", "schemas", name), Value: value},
+			{Op: "replace", Path: pointer, Value: map[string]interface{}{"$ref": "#/components/schemas/" + name}},
+		},
+	}
+}
+
+// fieldNumberFix suggests numbering every unannotated property of the schema at
+// pointer, keeping existing x-proto-number values so the wire format of annotated
+// fields does not change. Returns nil when an existing value is invalid.
+func fieldNumberFix(schema *base.Schema, pointer string) *internal.FixSuggestion {
+	used := make(map[int]bool)
+	var missing []string
+	for propName, propProxy := range schema.Properties.FromOldest() {
+		num, found, err := extractFieldNumber(propProxy)
+		if err != nil {
+			return nil
+		}
+		if found {
+			used[num] = true
+			continue
+		}
+		missing = append(missing, propName)
+	}
+
+	fix := &internal.FixSuggestion{
+		Description: "add x-proto-number to the properties that lack one",
+	}
+	next := 1
+	for _, propName := range missing {
+		for used[next] || (next >= reservedRangeStart && next <= reservedRangeEnd) {
+			next++
+		}
+		used[next] = true
+		fix.Patch = append(fix.Patch, internal.PatchOperation{
+			Op:    "add",
+			Path:  pointer + internal.JSONPointer("properties", propName, "x-proto-number"),
+			Value: next,
+		})
+	}
+	return fix
+}
+
+// discriminatorFix suggests a discriminator for the oneOf at pointer. A string
+// property shared by every variant is preferred; otherwise a new "type" (or
+// "kind") property is added to each variant. Returns nil unless every variant is
+// a resolvable $ref.
+func discriminatorFix(schema *base.Schema, pointer string) *internal.FixSuggestion {
+	type variant struct {
+		pointer string
+		schema  *base.Schema
+	}
+
+	variants := make([]variant, 0, len(schema.OneOf))
+	for _, proxy := range schema.OneOf {
+		if !proxy.IsReference() || proxy.Schema() == nil {
+			return nil
+		}
+		variants = append(variants, variant{pointer: trimFragment(proxy.GetReference()), schema: proxy.Schema()})
+	}
+	if len(variants) == 0 {
+		return nil
+	}
+
+	hasProperty := func(s *base.Schema, name string) bool {
+		if s.Properties == nil {
+			return false
+		}
+		_, ok := s.Properties.Get(name)
+		return ok
+	}
+
+	// Prefer a string property every variant already declares
+	if variants[0].schema.Properties != nil {
+		for propName, propProxy := range variants[0].schema.Properties.FromOldest() {
+			prop := propProxy.Schema()
+			if prop == nil || !internal.Contains(prop.Type, "string") {
+				continue
+			}
+
+			shared := true
+			for _, v := range variants[1:] {
+				shared = shared && hasProperty(v.schema, propName)
+			}
+			if shared {
+				return &internal.FixSuggestion{
+					Description: fmt.Sprintf("discriminate variants on their shared property '%s'", propName),
+					Patch: []internal.PatchOperation{
+						{Op: "add", Path: pointer + "/discriminator", Value: map[string]interface{}{"propertyName": propName}},
+					},
+				}
+			}
+		}
+	}
+
+	for _, candidate := range []string{"type", "kind"} {
+		taken := false
+		for _, v := range variants {
+			taken = taken || hasProperty(v.schema, candidate)
+		}
+		if taken {
+			continue
+		}
+
+		fix := &internal.FixSuggestion{
+			Description: fmt.Sprintf("add a '%s' property to each variant and discriminate on it", candidate),
+			Patch: []internal.PatchOperation{
+				{Op: "add", Path: pointer + "/discriminator", Value: map[string]interface{}{"propertyName": candidate}},
+			},
+		}
+		property := map[string]interface{}{"type": "string"}
+		for _, v := range variants {
+			op := internal.PatchOperation{Op: "add", Path: v.pointer + internal.JSONPointer("properties", candidate), Value: property}
+			if v.schema.Properties == nil || v.schema.Properties.Len() == 0 {
+				op = internal.PatchOperation{Op: "add", Path: v.pointer + "/properties", Value: map[string]interface{}{candidate: property}}
+			}
+			fix.Patch = append(fix.Patch, op)
+		}
+		return fix
+	}
+	return nil
+}
+
+// trimFragment turns a local reference such as #/components/schemas/Dog into the
+// JSON pointer /components/schemas/Dog
+func trimFragment(ref string) string {
+	if len(ref) > 0 && ref[0] == '#' {
+		return ref[1:]
+	}
+	return ref
+}
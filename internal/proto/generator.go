@@ -9,14 +9,17 @@ import (
 	"text/template"
 )
 
-const protoTemplate = `syntax = "proto3";
+const protoTemplate = `{{if .Editions}}edition = "2023";{{else}}syntax = "proto3";{{end}}
 
 package {{.PackageName}};
-{{if .UsesTimestamp}}
-import "google/protobuf/timestamp.proto";
-{{end}}
+{{if or .UsesTimestamp .Imports}}
+{{if .UsesTimestamp}}import "google/protobuf/timestamp.proto";
+{{end}}{{range .Imports}}import "{{.}}";
+{{end}}{{end}}
 option go_package = "{{.GoPackage}}";
-{{range .Definitions}}{{renderDefinition .}}{{end}}
+{{if .Editions}}option features.field_presence = IMPLICIT;
+{{end}}{{range .FileOptions}}option {{.Name}} = {{.Value}};
+{{end}}{{range .Definitions}}{{renderDefinition .}}{{end}}{{range .Services}}{{renderService .}}{{end}}
 `
 
 type templateData struct {
@@ -24,15 +27,19 @@ type templateData struct {
 	Messages      []*ProtoMessage
 	Enums         []*ProtoEnum
 	Definitions   []interface{}
+	Services      []*ProtoService
 	UsesTimestamp bool
+	Imports       []string
 	GoPackage     string
+	Editions      bool
+	FileOptions   []Option
 }
 
 // Generate creates proto3 output from messages and enums in order
 func Generate(packageName string, packagePath string, ctx *Context) ([]byte, error) {
 	funcMap := template.FuncMap{
-		"formatComment":    formatCommentForTemplate,
-		"renderDefinition": renderDefinition,
+		"renderDefinition": func(def interface{}) string { return renderDefinition(def, ctx.Style) },
+		"renderService":    func(service *ProtoService) string { return renderService(service, ctx.Style) },
 	}
 
 	tmpl, err := template.New("proto").Funcs(funcMap).Parse(protoTemplate)
@@ -45,8 +52,12 @@ func Generate(packageName string, packagePath string, ctx *Context) ([]byte, err
 		Messages:      ctx.Messages,
 		Enums:         ctx.Enums,
 		Definitions:   ctx.Definitions,
+		Services:      ctx.Services,
 		UsesTimestamp: ctx.UsesTimestamp,
+		Imports:       ctx.Imports,
 		GoPackage:     packagePath,
+		Editions:      ctx.Style.Editions,
+		FileOptions:   ctx.FileOptions,
 	}
 
 	var buf bytes.Buffer
@@ -58,31 +69,62 @@ func Generate(packageName string, packagePath string, ctx *Context) ([]byte, err
 }
 
 // renderDefinition renders either an enum or message definition
-func renderDefinition(def interface{}) string {
+func renderDefinition(def interface{}, style Style) string {
 	switch d := def.(type) {
 	case *ProtoEnum:
-		return renderEnum(d)
+		return renderEnum(d, style)
 	case *ProtoMessage:
-		return renderMessage(d)
+		return renderMessage(d, style)
 	default:
 		return ""
 	}
 }
 
+// renderService renders a service definition
+func renderService(service *ProtoService, style Style) string {
+	indent := style.indent()
+	var result strings.Builder
+	result.WriteString("\n")
+
+	if service.Description != "" {
+		result.WriteString(style.Comments.Format(service.Description, ""))
+	}
+
+	result.WriteString(fmt.Sprintf("service %s {\n", service.Name))
+	for _, method := range service.Methods {
+		if method.Description != "" {
+			result.WriteString(style.Comments.Format(method.Description, indent))
+		}
+		request, response := method.Request, method.Response
+		if method.ClientStreaming {
+			request = "stream " + request
+		}
+		if method.ServerStreaming {
+			response = "stream " + response
+		}
+		result.WriteString(fmt.Sprintf("%srpc %s(%s) returns (%s);\n", indent, method.Name, request, response))
+	}
+	result.WriteString("}\n")
+
+	return result.String()
+}
+
 // renderEnum renders an enum definition
-func renderEnum(enum *ProtoEnum) string {
+func renderEnum(enum *ProtoEnum, style Style) string {
+	indent := style.indent()
 	var result strings.Builder
 	result.WriteString("\n")
 
 	if enum.Description != "" {
-		result.WriteString(formatCommentForTemplate(enum.Description))
+		result.WriteString(style.Comments.Format(enum.Description, ""))
 	}
 
 	result.WriteString(fmt.Sprintf("enum %s {\n", enum.Name))
+	result.WriteString(formatOptions(enum.Options, indent))
 	for _, value := range enum.Values {
-		result.WriteString(fmt.Sprintf("  %s = %d;\n", value.Name, value.Number))
+		result.WriteString(fmt.Sprintf("%s%s = %d;\n", indent, value.Name, value.Number))
 	}
-	if reserved := formatReserved(enum.Reserved, "  "); reserved != "" {
+	if reserved := formatReserved(enum.Reserved, indent); reserved != "" {
 		result.WriteString(reserved)
 	}
 	result.WriteString("}\n")
@@ -108,26 +150,47 @@ func formatReserved(numbers []int, indent string) string {
 	return fmt.Sprintf("%sreserved %s;\n", indent, strings.Join(parts, ", "))
 }
 
+// formatReservedNames renders a `reserved "a", "b";` statement (names in the
+// order given) for retired field names, or "" when there are none. Editions
+// write the names as identifiers: `reserved a, b;`.
+func formatReservedNames(names []string, indent string, editions bool) string {
+	if len(names) == 0 {
+		return ""
+	}
+
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = name
+		if !editions {
+			quoted[i] = strconv.Quote(name)
+		}
+	}
+
+	return fmt.Sprintf("%sreserved %s;\n", indent, strings.Join(quoted, ", "))
+}
+
 // renderMessage renders a message definition
-func renderMessage(msg *ProtoMessage) string {
-	return renderMessageWithIndent(msg, "")
+func renderMessage(msg *ProtoMessage, style Style) string {
+	return renderMessageWithIndent(msg, "", style)
 }
 
 // renderMessageWithIndent renders a message definition with custom indentation
-func renderMessageWithIndent(msg *ProtoMessage, indent string) string {
+func renderMessageWithIndent(msg *ProtoMessage, indent string, style Style) string {
+	inner := indent + style.indent()
 	var result strings.Builder
 	result.WriteString("\n")
 
 	if msg.Description != "" {
-		result.WriteString(formatComment(msg.Description, indent))
+		result.WriteString(style.Comments.Format(msg.Description, indent))
 	}
 
 	result.WriteString(indent)
 	result.WriteString(fmt.Sprintf("message %s {\n", msg.Name))
+	result.WriteString(formatOptions(msg.Options, inner))
 
 	// Render nested messages first (with proper indentation)
 	for _, nested := range msg.Nested {
-		nestedContent := renderMessageWithIndent(nested, indent+"  ")
+		nestedContent := renderMessageWithIndent(nested, inner, style)
 		// Remove the leading newline from nested message since we're inside parent
 		result.WriteString(strings.TrimPrefix(nestedContent, "\n"))
 		result.WriteString("\n")
@@ -144,37 +207,32 @@ func renderMessageWithIndent(msg *ProtoMessage, indent string) string {
 	rendered := make(map[*ProtoOneof]bool)
 
 	// Render fields
+	count := 0
 	for _, field := range msg.Fields {
 		if group := memberOf[field]; group != nil {
 			if rendered[group] {
 				continue
 			}
 			rendered[group] = true
-			result.WriteString(renderOneof(group, indent+"  "))
+			if count > 0 && style.blankLineBefore(group.Fields...) {
+				result.WriteString("\n")
+			}
+			count++
+			result.WriteString(renderOneof(group, inner, style))
 			continue
 		}
 
-		if field.Description != "" {
-			result.WriteString(formatComment(field.Description, indent+"  "))
-		}
-
-		if len(field.EnumValues) > 0 {
-			result.WriteString(formatEnumComment(field.EnumValues, indent+"  "))
-		}
-
-		result.WriteString(indent)
-		result.WriteString("  ")
-		if field.Repeated {
-			result.WriteString("repeated ")
-		}
-		result.WriteString(fmt.Sprintf("%s %s = %d", field.Type, field.Name, field.Number))
-		if field.JSONName != "" {
-			result.WriteString(fmt.Sprintf(" [json_name = \"%s\"]", field.JSONName))
+		if count > 0 && style.blankLineBefore(field) {
+			result.WriteString("\n")
 		}
-		result.WriteString(";\n")
+		count++
+		result.WriteString(renderField(field, inner, style))
 	}
 
-	if reserved := formatReserved(msg.Reserved, indent+"  "); reserved != "" {
+	if reserved := formatReserved(msg.Reserved, inner); reserved != "" {
+		result.WriteString(reserved)
+	}
+	if reserved := formatReservedNames(msg.ReservedNames, inner, style.Editions); reserved != "" {
 		result.WriteString(reserved)
 	}
 
@@ -187,26 +245,19 @@ func renderMessageWithIndent(msg *ProtoMessage, indent string) string {
 // renderOneof renders a proto3 oneof group. The indent is the indentation of the
 // `oneof` keyword itself; members are indented one level deeper. proto3 forbids
 // `repeated` members, so members render without a repeated prefix.
-func renderOneof(group *ProtoOneof, indent string) string {
+func renderOneof(group *ProtoOneof, indent string, style Style) string {
+	inner := indent + style.indent()
 	var result strings.Builder
 	result.WriteString(indent)
 	result.WriteString(fmt.Sprintf("oneof %s {\n", group.Name))
 
-	for _, field := range group.Fields {
-		if field.Description != "" {
-			result.WriteString(formatComment(field.Description, indent+"  "))
-		}
-		if len(field.EnumValues) > 0 {
-			result.WriteString(formatEnumComment(field.EnumValues, indent+"  "))
-		}
-
-		result.WriteString(indent)
-		result.WriteString("  ")
-		result.WriteString(fmt.Sprintf("%s %s = %d", field.Type, field.Name, field.Number))
-		if field.JSONName != "" {
-			result.WriteString(fmt.Sprintf(" [json_name = \"%s\"]", field.JSONName))
+	for i, field := range group.Fields {
+		if i > 0 && style.blankLineBefore(field) {
+			result.WriteString("\n")
 		}
-		result.WriteString(";\n")
+		member := *field
+		member.Repeated, member.Optional = false, false
+		result.WriteString(renderField(&member, inner, style))
 	}
 
 	result.WriteString(indent)
@@ -214,32 +265,38 @@ func renderOneof(group *ProtoOneof, indent string) string {
 	return result.String()
 }
 
-// formatCommentForTemplate formats a description as a proto3 comment for use in templates
-func formatCommentForTemplate(description string) string {
-	return formatComment(description, "")
-}
-
-// formatComment formats a description as a proto3 comment with indentation
-func formatComment(description, indent string) string {
-	if strings.TrimSpace(description) == "" {
-		return ""
-	}
-
-	lines := strings.Split(description, "\n")
+// renderField renders a field with its comments at indent, leaving out a
+// json_name annotation that matches the default when the style asks to
+func renderField(field *ProtoField, indent string, style Style) string {
 	var result strings.Builder
+	if field.Description != "" {
+		result.WriteString(style.Comments.Format(field.Description, indent))
+	}
+	if len(field.EnumValues) > 0 {
+		result.WriteString(formatEnumComment(field.EnumValues, indent))
+	}
 
-	for _, line := range lines {
-		trimmed := strings.TrimRight(line, " \t")
-		result.WriteString(indent)
-		if trimmed == "" {
-			result.WriteString("//\n")
-		} else {
-			result.WriteString("// ")
-			result.WriteString(trimmed)
-			result.WriteString("\n")
-		}
+	result.WriteString(indent)
+	if field.Repeated {
+		result.WriteString("repeated ")
+	}
+	if field.Optional && !style.Editions {
+		result.WriteString("optional ")
 	}
+	result.WriteString(fmt.Sprintf("%s %s = %d", field.Type, field.Name, field.Number))
 
+	var options []string
+	if field.JSONName != "" && !(style.OmitDefaultJSONName && field.JSONName == DefaultJSONName(field.Name)) {
+		options = append(options, fmt.Sprintf("json_name = \"%s\"", field.JSONName))
+	}
+	if field.Optional && style.Editions {
+		// Editions have no optional keyword; presence overrides the file's IMPLICIT default
+		options = append(options, "features.field_presence = EXPLICIT")
+	}
+	if len(options) > 0 {
+		result.WriteString(fmt.Sprintf(" [%s]", strings.Join(options, ", ")))
+	}
+	result.WriteString(";\n")
 	return result.String()
 }
 
@@ -72,6 +72,7 @@ components:
 `)
 
 	result, err := schema.Convert(openapi, schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
 		GoPackagePath: "test/ecommerce",
 		PackageName:   "ecommerce",
 		PackagePath:   "github.com/example/proto/v1",
@@ -86,7 +87,7 @@ components:
 	assert.Contains(t, goCode, "BankTransfer *BankTransfer")
 	assert.Contains(t, goCode, "type Order struct")
 	assert.Contains(t, goCode, "PaymentMethod *PaymentMethod")
-	assert.Contains(t, goCode, "OrderId string")
+	assert.Contains(t, goCode, "OrderID string")
 	assert.Contains(t, goCode, "TotalAmount float64")
 
 	assert.NotNil(t, result.TypeMap)
@@ -432,6 +433,7 @@ components:
 `)
 
 	result, err := schema.Convert(openapi, schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
 		GoPackagePath: "test/notifications",
 		PackageName:   "notifications",
 		PackagePath:   "github.com/example/proto/v1",
@@ -612,6 +614,7 @@ components:
 `)
 
 	result, err := schema.Convert(openapi, schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
 		GoPackagePath: "test/config",
 		PackageName:   "config",
 		PackagePath:   "github.com/example/proto/v1",
@@ -13,6 +13,19 @@ import (
 // For inline enums and objects, hoists them appropriately in the context.
 // parentMsg is used for nested messages (can be nil for top-level).
 func ProtoType(schema *base.Schema, propertyName string, propProxy *base.SchemaProxy, ctx *Context, parentMsg *ProtoMessage) (string, bool, []string, error) {
+	// References to schemas generated from another spec use that type, and
+	// references to excluded schemas carry the schema's JSON as bytes
+	if typeName, ok := ctx.importedRef(propProxy); ok {
+		return typeName, false, nil, nil
+	}
+	if ctx.opaqueRef(propProxy) {
+		return "bytes", false, nil, nil
+	}
+
+	if wellKnown, ok := wellKnownType(ctx, schema); ok {
+		return wellKnown, false, nil, nil
+	}
+
 	// Validate schema for unsupported features
 	if err := validateSchema(schema, propertyName); err != nil {
 		return "", false, nil, err
@@ -43,9 +56,20 @@ func ProtoType(schema *base.Schema, propertyName string, propProxy *base.SchemaP
 		if err != nil {
 			return "", false, nil, fmt.Errorf("property '%s': %w", propertyName, err)
 		}
+		typeName, err = ctx.typeName(typeName, resolvedSchema)
+		if err != nil {
+			return "", false, nil, fmt.Errorf("property '%s': %w", propertyName, err)
+		}
 		return typeName, false, nil, nil
 	}
 
+	ctx.warnDroppedConstraints(schema)
+
+	protoType, hasProtoType := extractProtoType(schema)
+	if hasProtoType && !isScalarSchema(schema) {
+		return "", false, nil, fmt.Errorf("property '%s': x-proto-type is only supported on scalar properties and array items", propertyName)
+	}
+
 	// Check if it's an array first
 	if len(schema.Type) > 0 && internal.Contains(schema.Type, "array") {
 		itemType, enumValues, err := ResolveArrayItemType(schema, propertyName, propProxy, ctx, parentMsg)
@@ -57,8 +81,17 @@ func ProtoType(schema *base.Schema, propertyName string, propProxy *base.SchemaP
 
 	// Check if it's an inline object
 	if len(schema.Type) > 0 && internal.Contains(schema.Type, "object") {
+		msgName, plural, err := ctx.nestedTypeName(parentMsg, propertyName)
+		if err != nil {
+			return "", false, nil, err
+		}
+		if plural {
+			err := fmt.Errorf("cannot derive message name from property '%s'; use singular form or $ref", propertyName)
+			return "", false, nil, internal.WithFix(err, extractComponentFix(ctx, propertyName, propProxy, ctx.pointerTo()))
+		}
+
 		// Build nested message
-		nestedMsg, err := buildNestedMessage(propertyName, propProxy, ctx, parentMsg)
+		nestedMsg, err := buildNestedMessage(propertyName, msgName, propProxy, ctx, parentMsg)
 		if err != nil {
 			return "", false, nil, err
 		}
@@ -69,12 +102,16 @@ func ProtoType(schema *base.Schema, propertyName string, propProxy *base.SchemaP
 	if internal.IsEnumSchema(schema) {
 		// Check if it's a string enum
 		if isStringEnum(schema) {
+			ctx.warn(internal.WarnEnumFlattened, "string enum flattened to string")
 			enumValues := extractEnumValues(schema)
 			return "string", false, enumValues, nil
 		}
 		// Integer enum - hoist to top-level
-		enumName := internal.ToPascalCase(propertyName)
-		_, err := buildEnum(enumName, propProxy, ctx)
+		enumName, _, err := ctx.nestedTypeName(parentMsg, propertyName)
+		if err != nil {
+			return "", false, nil, err
+		}
+		_, err = buildEnum(enumName, propProxy, ctx)
 		if err != nil {
 			return "", false, nil, err
 		}
@@ -104,33 +141,103 @@ func ProtoType(schema *base.Schema, propertyName string, propProxy *base.SchemaP
 	}
 	format := schema.Format
 
+	if hasProtoType {
+		scalarType, err := overrideProtoType(ctx, propertyName, typ, protoType)
+		return scalarType, false, nil, err
+	}
+
+	if decimal, ok := decimalType(ctx, schema, typ); ok {
+		return decimal, false, nil, nil
+	}
+
 	scalarType, err := MapScalarType(ctx, typ, format)
 	return scalarType, false, nil, err
 }
 
+// decimalType maps decimal schemas to string, or google.type.Money, when
+// ctx.Decimals is set, so their values survive without float rounding. A
+// TypeMapping for the type and format wins.
+func decimalType(ctx *Context, schema *base.Schema, typ string) (string, bool) {
+	if ctx.Decimals == nil || !internal.IsDecimal(schema) {
+		return "", false
+	}
+	if m := internal.FindTypeMapping(ctx.TypeMappings, typ, schema.Format); m != nil && m.Proto != "" {
+		return "", false
+	}
+	if ctx.Decimals.Money {
+		ctx.addImport("google/type/money.proto")
+		return "google.type.Money", true
+	}
+	return "string", true
+}
+
+// wellKnownType maps free-form objects to google.protobuf.Struct and anyOf without
+// a discriminator to google.protobuf.Any, when enabled in ctx.WellKnownTypes. Any
+// carries its type URL on the wire, so its JSON form gains an "@type" key.
+func wellKnownType(ctx *Context, schema *base.Schema) (string, bool) {
+	switch {
+	case ctx.WellKnownTypes.Struct && internal.IsFreeFormObject(schema):
+		ctx.addImport("google/protobuf/struct.proto")
+		return "google.protobuf.Struct", true
+	case ctx.WellKnownTypes.Any && internal.IsUndiscriminatedAnyOf(schema):
+		ctx.addImport("google/protobuf/any.proto")
+		return "google.protobuf.Any", true
+	}
+	return "", false
+}
+
+// isScalarSchema reports whether schema is a plain scalar: not an array, object or enum
+func isScalarSchema(schema *base.Schema) bool {
+	return !internal.Contains(schema.Type, "array") && !internal.Contains(schema.Type, "object") &&
+		!internal.IsEnumSchema(schema)
+}
+
 // MapScalarType maps OpenAPI type+format to proto3 scalar type.
 func MapScalarType(ctx *Context, typ, format string) (string, error) {
+	if m := internal.FindTypeMapping(ctx.TypeMappings, typ, format); m != nil && m.Proto != "" {
+		if m.ProtoImport != "" {
+			ctx.addImport(m.ProtoImport)
+		}
+		return m.Proto, nil
+	}
+
 	switch typ {
 	case "integer":
 		if format == "int64" {
 			return "int64", nil
 		}
+		if format != "" && format != "int32" {
+			ctx.warn(internal.WarnFormatUnknown, fmt.Sprintf("format '%s' unknown → int32", format))
+		}
 		return "int32", nil
 
 	case "number":
 		if format == "float" {
 			return "float", nil
 		}
+		if format != "" && format != "double" {
+			ctx.warn(internal.WarnFormatUnknown, fmt.Sprintf("format '%s' unknown → double", format))
+		}
 		return "double", nil
 
 	case "string":
+		if format == "duration" && ctx.WellKnownTypes.Duration {
+			ctx.addImport("google/protobuf/duration.proto")
+			return "google.protobuf.Duration", nil
+		}
 		if format == "date" || format == "date-time" {
+			if !ctx.UsesTimestamp {
+				ctx.Logger.Debug(internal.LogImportAdded, "schema", ctx.schema, "import", "google/protobuf/timestamp.proto")
+			}
 			ctx.UsesTimestamp = true
 			return "google.protobuf.Timestamp", nil
 		}
 		if format == "byte" || format == "binary" {
 			return "bytes", nil
 		}
+		if !internal.IsKnownStringFormat(format) {
+			ctx.warn(internal.WarnFormatUnknown, fmt.Sprintf("format '%s' unknown → string", format))
+		}
 		return "string", nil
 
 	case "boolean":
@@ -143,7 +250,8 @@ func MapScalarType(ctx *Context, typ, format string) (string, error) {
 
 // ResolveArrayItemType determines the proto3 type for array items.
 // Returns type name, enum values (for string enums), and error.
-// For inline objects/enums: validates property name is not plural.
+// For inline objects/enums: validates property name is not plural unless
+// ctx.NestedNameFunc names the type.
 func ResolveArrayItemType(schema *base.Schema, propertyName string, propProxy *base.SchemaProxy, ctx *Context, parentMsg *ProtoMessage) (string, []string, error) {
 	// Check if Items is defined
 	if schema.Items == nil || schema.Items.A == nil {
@@ -151,6 +259,9 @@ func ResolveArrayItemType(schema *base.Schema, propertyName string, propProxy *b
 	}
 
 	itemsProxy := schema.Items.A
+	ctx.pointer = append(ctx.pointer, "items")
+	defer func() { ctx.pointer = ctx.pointer[:len(ctx.pointer)-1] }()
+
 	itemsSchema := itemsProxy.Schema()
 	if itemsSchema == nil {
 		if err := itemsProxy.GetBuildError(); err != nil {
@@ -159,9 +270,25 @@ func ResolveArrayItemType(schema *base.Schema, propertyName string, propProxy *b
 		return "", nil, fmt.Errorf("array items schema is nil")
 	}
 
-	// Check for nested arrays
+	if typeName, ok := ctx.importedRef(itemsProxy); ok {
+		return typeName, nil, nil
+	}
+	if ctx.opaqueRef(itemsProxy) {
+		return "bytes", nil, nil
+	}
+
+	if wellKnown, ok := wellKnownType(ctx, itemsSchema); ok {
+		return wellKnown, nil, nil
+	}
+
+	// proto3 has no `repeated repeated`, so an array of arrays wraps each inner
+	// array in a message holding a single repeated field
 	if len(itemsSchema.Type) > 0 && internal.Contains(itemsSchema.Type, "array") {
-		return "", nil, fmt.Errorf("nested arrays not supported")
+		wrapper, err := buildArrayWrapper(propertyName, itemsSchema, itemsProxy, ctx, parentMsg)
+		if err != nil {
+			return "", nil, err
+		}
+		return wrapper.Name, nil, nil
 	}
 
 	// Check if it's a reference
@@ -176,30 +303,41 @@ func ResolveArrayItemType(schema *base.Schema, propertyName string, propProxy *b
 			// Extract the last segment of the reference path
 			parts := strings.Split(ref, "/")
 			if len(parts) > 0 {
-				return parts[len(parts)-1], nil, nil
+				typeName, err := ctx.typeName(parts[len(parts)-1], resolvedSchema)
+				if err != nil {
+					return "", nil, fmt.Errorf("property '%s': %w", propertyName, err)
+				}
+				return typeName, nil, nil
 			}
 		}
 		return "", nil, fmt.Errorf("invalid reference format")
 	}
 
+	protoType, hasProtoType := extractProtoType(itemsSchema)
+	if hasProtoType && !isScalarSchema(itemsSchema) {
+		return "", nil, fmt.Errorf("property '%s': x-proto-type is only supported on scalar properties and array items", propertyName)
+	}
+
 	// Check if it's an inline enum
 	if internal.IsEnumSchema(itemsSchema) {
 		// Check if it's a string enum
 		if isStringEnum(itemsSchema) {
+			ctx.warn(internal.WarnEnumFlattened, "string enum flattened to string")
 			enumValues := extractEnumValues(itemsSchema)
 			return "string", enumValues, nil
 		}
 		// Integer enum - validate property name is not plural
-		if strings.HasSuffix(propertyName, "es") {
-			return "", nil, fmt.Errorf("cannot derive enum name from plural array property '%s'; use singular form or $ref", propertyName)
+		enumName, plural, err := ctx.nestedTypeName(parentMsg, propertyName)
+		if err != nil {
+			return "", nil, err
 		}
-		if strings.HasSuffix(propertyName, "s") {
-			return "", nil, fmt.Errorf("cannot derive enum name from plural array property '%s'; use singular form or $ref", propertyName)
+		if plural {
+			err := fmt.Errorf("cannot derive enum name from plural array property '%s'; use singular form or $ref", propertyName)
+			return "", nil, internal.WithFix(err, extractComponentFix(ctx, propertyName, itemsProxy, ctx.pointerTo()))
 		}
 
 		// Hoist inline integer enum to top-level
-		enumName := internal.ToPascalCase(propertyName)
-		_, err := buildEnum(enumName, itemsProxy, ctx)
+		_, err = buildEnum(enumName, itemsProxy, ctx)
 		if err != nil {
 			return "", nil, err
 		}
@@ -209,15 +347,17 @@ func ResolveArrayItemType(schema *base.Schema, propertyName string, propProxy *b
 	// Check if it's an inline object
 	if len(itemsSchema.Type) > 0 && internal.Contains(itemsSchema.Type, "object") {
 		// Validate property name is not plural
-		if strings.HasSuffix(propertyName, "es") {
-			return "", nil, fmt.Errorf("cannot derive message name from plural array property '%s'; use singular form or $ref", propertyName)
+		msgName, plural, err := ctx.nestedTypeName(parentMsg, propertyName)
+		if err != nil {
+			return "", nil, err
 		}
-		if strings.HasSuffix(propertyName, "s") {
-			return "", nil, fmt.Errorf("cannot derive message name from plural array property '%s'; use singular form or $ref", propertyName)
+		if plural {
+			err := fmt.Errorf("cannot derive message name from plural array property '%s'; use singular form or $ref", propertyName)
+			return "", nil, internal.WithFix(err, extractComponentFix(ctx, propertyName, itemsProxy, ctx.pointerTo()))
 		}
 
 		// Build nested message for inline object in array
-		nestedMsg, err := buildNestedMessage(propertyName, itemsProxy, ctx, parentMsg)
+		nestedMsg, err := buildNestedMessage(propertyName, msgName, itemsProxy, ctx, parentMsg)
 		if err != nil {
 			return "", nil, err
 		}
@@ -229,12 +369,69 @@ func ResolveArrayItemType(schema *base.Schema, propertyName string, propProxy *b
 		return "", nil, fmt.Errorf("array items must have a type")
 	}
 
+	ctx.warnDroppedConstraints(itemsSchema)
 	itemType := itemsSchema.Type[0]
 	format := itemsSchema.Format
+	if hasProtoType {
+		scalarType, err := overrideProtoType(ctx, propertyName, itemType, protoType)
+		return scalarType, nil, err
+	}
+	if decimal, ok := decimalType(ctx, itemsSchema, itemType); ok {
+		return decimal, nil, nil
+	}
 	scalarType, err := MapScalarType(ctx, itemType, format)
 	return scalarType, nil, err
 }
 
+// buildArrayWrapper creates the nested message that carries one inner array of an
+// array-of-arrays property. The message is named after the property plus
+// ctx.ArrayWrapperSuffix (e.g. data → DataRow) and holds a single repeated `values`
+// field; deeper nesting produces a wrapper per level. Note the proto JSON form of a
+// wrapped value is {"values": [...]} rather than a bare nested array.
+func buildArrayWrapper(propertyName string, schema *base.Schema, proxy *base.SchemaProxy, ctx *Context, parentMsg *ProtoMessage) (*ProtoMessage, error) {
+	suffix := ctx.ArrayWrapperSuffix
+	if suffix == "" {
+		suffix = DefaultArrayWrapperSuffix
+	}
+
+	ascii, err := internal.ASCIIName(propertyName, ctx.NonASCIINames)
+	if err != nil {
+		return nil, fmt.Errorf("property '%s': %w", propertyName, err)
+	}
+	msgName, err := ctx.uniqueTypeName(ctx.schema, propertyName, internal.ToPascalCase(ascii)+suffix)
+	if err != nil {
+		return nil, fmt.Errorf("property '%s': %w", propertyName, err)
+	}
+
+	msg := &ProtoMessage{
+		Name:           msgName,
+		Fields:         []*ProtoField{},
+		Nested:         []*ProtoMessage{},
+		OriginalSchema: propertyName,
+	}
+
+	itemType, enumValues, err := ResolveArrayItemType(schema, propertyName, proxy, ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	msg.Fields = append(msg.Fields, &ProtoField{
+		Name:       "values",
+		Type:       itemType,
+		Number:     1,
+		JSONName:   "values",
+		Repeated:   true,
+		EnumValues: enumValues,
+	})
+
+	if parentMsg != nil {
+		parentMsg.Nested = append(parentMsg.Nested, msg)
+	}
+	ctx.addNote(fmt.Sprintf("property '%s' is an array of arrays; inner arrays are wrapped in message %s", propertyName, msg.Name))
+
+	return msg, nil
+}
+
 // validateSchema checks for unsupported OpenAPI features
 func validateSchema(schema *base.Schema, propertyName string) error {
 	if schema == nil {
@@ -1,6 +1,7 @@
 package proto_test
 
 import (
+	"strings"
 	"testing"
 
 	schema "github.com/duh-rpc/openapi-schema.go"
@@ -329,6 +330,38 @@ components:
 `,
 			expectedErr: "cannot derive message name from property 'addresses'; use singular form or $ref",
 		},
+		{
+			name: "singular words ending in 's'",
+			given: `
+openapi: 3.0.0
+info:
+  title: Test
+  version: 1.0.0
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        address:
+          type: object
+          properties:
+            street:
+              type: string
+        status:
+          type: object
+          properties:
+            code:
+              type: string
+        analysis:
+          type: array
+          items:
+            type: object
+            properties:
+              score:
+                type: number
+`,
+			expectedErr: "",
+		},
 	} {
 		t.Run(test.name, func(t *testing.T) {
 			result, err := schema.Convert([]byte(test.given), schema.ConvertOptions{
@@ -399,3 +432,69 @@ message User {
 	require.NotNil(t, result)
 	assert.Equal(t, expected, string(result.Protobuf))
 }
+
+func TestNestedNameFunc(t *testing.T) {
+	given := `
+openapi: 3.0.0
+info:
+  title: Test
+  version: 1.0.0
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        lines:
+          type: array
+          items:
+            type: object
+            properties:
+              sku:
+                type: string
+        settings:
+          type: object
+          properties:
+            gift:
+              type: boolean
+`
+
+	var calls []string
+	opts := schema.ConvertOptions{
+		GoFormat:      schema.GoFormatNone,
+		PackageName:   "testpkg",
+		PackagePath:   "github.com/example/proto/v1",
+		GoPackagePath: "github.com/example/types/v1",
+		NestedNameFunc: func(parent, property string) string {
+			calls = append(calls, parent+"."+property)
+			if property == "lines" {
+				return "LineItem"
+			}
+			return ""
+		},
+	}
+
+	// settings is left to the default name, which rejects the plural
+	_, err := schema.Convert([]byte(given), opts)
+	require.ErrorContains(t, err, "cannot derive message name from property 'settings'")
+
+	opts.NestedNameFunc = func(parent, property string) string {
+		calls = append(calls, parent+"."+property)
+		return strings.ToUpper(property[:1]) + strings.TrimSuffix(property[1:], "s")
+	}
+	calls = nil
+	result, err := schema.Convert([]byte(given), opts)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Order.lines", "Order.settings"}, calls)
+	assert.Contains(t, string(result.Protobuf), "repeated Line lines = 1")
+	assert.Contains(t, string(result.Protobuf), "Setting settings = 2")
+
+	calls = nil
+	structs, err := schema.ConvertToStruct([]byte(given), opts)
+	require.NoError(t, err)
+	assert.Contains(t, string(structs.Golang), "Lines []*OrderLine `json:\"lines\"`")
+	assert.Contains(t, string(structs.Golang), "type OrderSetting struct")
+
+	opts.NestedNameFunc = func(parent, property string) string { return "line-item" }
+	_, err = schema.Convert([]byte(given), opts)
+	require.ErrorContains(t, err, "property 'lines': NestedNameFunc returned invalid name 'line-item'")
+}
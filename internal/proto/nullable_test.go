@@ -442,6 +442,7 @@ components:
 	} {
 		t.Run(test.name, func(t *testing.T) {
 			result, err := schema.Convert([]byte(test.given), schema.ConvertOptions{
+				GoFormat:      schema.GoFormatNone,
 				PackageName:   "testpkg",
 				PackagePath:   "github.com/example/proto/v1",
 				GoPackagePath: "github.com/example/types/v1",
@@ -0,0 +1,73 @@
+package proto
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"go.yaml.in/yaml/v4"
+)
+
+// Option is a proto option statement, `option Name = Value;`, with Value
+// already written as a proto constant (a quoted string, number, bool or
+// enum identifier)
+type Option struct {
+	Name  string
+	Value string
+}
+
+// optionName matches the option names an option statement accepts: a built-in
+// name such as java_package, or a parenthesised custom option with optional
+// field path, such as (my.ext).field
+var optionName = regexp.MustCompile(`^(\([A-Za-z_][A-Za-z0-9_.]*\)|[A-Za-z_][A-Za-z0-9_]*)(\.[A-Za-z_][A-Za-z0-9_]*)*$`)
+
+// ValidOptionName reports whether name can be written in an option statement
+func ValidOptionName(name string) bool {
+	return optionName.MatchString(name)
+}
+
+// extractOptions parses the schema-level x-proto-options extension: a mapping
+// of option names to scalar values, written in document order. Strings are
+// quoted; numbers and booleans are written as they are.
+func extractOptions(schema *base.Schema) ([]Option, error) {
+	if schema == nil || schema.Extensions == nil {
+		return nil, nil
+	}
+
+	node, found := schema.Extensions.Get("x-proto-options")
+	if !found || node == nil {
+		return nil, nil
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("x-proto-options must be a mapping of option names to values")
+	}
+
+	var options []Option
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		name, value := node.Content[i].Value, node.Content[i+1]
+		if !ValidOptionName(name) {
+			return nil, fmt.Errorf("x-proto-options name must be an option name such as deprecated or (my.option), got: %s", name)
+		}
+		if value.Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("x-proto-options value of '%s' must be a string, number or boolean", name)
+		}
+		switch value.Tag {
+		case "!!int", "!!float", "!!bool":
+			options = append(options, Option{Name: name, Value: value.Value})
+		default:
+			options = append(options, Option{Name: name, Value: strconv.Quote(value.Value)})
+		}
+	}
+	return options, nil
+}
+
+// formatOptions renders an `option name = value;` line per option
+func formatOptions(options []Option, indent string) string {
+	var result strings.Builder
+	for _, option := range options {
+		result.WriteString(fmt.Sprintf("%soption %s = %s;\n", indent, option.Name, option.Value))
+	}
+	return result.String()
+}
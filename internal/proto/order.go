@@ -0,0 +1,133 @@
+package proto
+
+import (
+	"container/heap"
+	"slices"
+	"strings"
+)
+
+// Definition orderings for SortDefinitions
+const (
+	OrderInsertion    = "insertion"    // schema order in the document
+	OrderAlphabetical = "alphabetical" // by generated name
+	OrderTopological  = "topological"  // every type after the types it references
+)
+
+// SortDefinitions returns the top-level messages and enums in the given order;
+// "" keeps insertion order. The topological order is stable: among types whose
+// dependencies are already placed, the earliest in insertion order comes first.
+// When only types in reference cycles remain, the earliest of them is placed
+// next, breaking its cycle.
+func SortDefinitions(definitions []interface{}, order string) []interface{} {
+	sorted := slices.Clone(definitions)
+	switch order {
+	case OrderAlphabetical:
+		slices.SortStableFunc(sorted, func(a, b interface{}) int {
+			return strings.Compare(definitionName(a), definitionName(b))
+		})
+	case OrderTopological:
+		sorted = sortTopological(sorted)
+	}
+	return sorted
+}
+
+// sortTopological places each definition after the definitions its fields,
+// including those of nested messages, reference
+func sortTopological(definitions []interface{}) []interface{} {
+	index := make(map[string]int, len(definitions))
+	for i, def := range definitions {
+		index[definitionName(def)] = i
+	}
+
+	deps := make([][]int, len(definitions))
+	for i, def := range definitions {
+		if msg, ok := def.(*ProtoMessage); ok {
+			for _, typ := range referencedTypes(msg) {
+				if j, ok := index[typ]; ok && j != i && !slices.Contains(deps[i], j) {
+					deps[i] = append(deps[i], j)
+				}
+			}
+		}
+	}
+
+	// Kahn's algorithm, taking the earliest ready definition each time
+	pending := make([]int, len(definitions)) // unplaced dependencies of each definition
+	dependents := make([][]int, len(definitions))
+	ready := &indexHeap{}
+	for i := range definitions {
+		pending[i] = len(deps[i])
+		for _, j := range deps[i] {
+			dependents[j] = append(dependents[j], i)
+		}
+		if pending[i] == 0 {
+			heap.Push(ready, i)
+		}
+	}
+
+	placed := make([]bool, len(definitions))
+	result := make([]interface{}, 0, len(definitions))
+	cursor := 0 // every definition before cursor is placed
+	for len(result) < len(definitions) {
+		var next int
+		if ready.Len() > 0 {
+			next = heap.Pop(ready).(int)
+		} else {
+			// Every remaining definition waits on another: break the cycle at
+			// the earliest one
+			for placed[cursor] {
+				cursor++
+			}
+			next = cursor
+		}
+		if placed[next] {
+			continue
+		}
+
+		placed[next] = true
+		result = append(result, definitions[next])
+		for _, d := range dependents[next] {
+			pending[d]--
+			if pending[d] == 0 && !placed[d] {
+				heap.Push(ready, d)
+			}
+		}
+	}
+	return result
+}
+
+// indexHeap is a min-heap of definition indexes
+type indexHeap []int
+
+func (h indexHeap) Len() int           { return len(h) }
+func (h indexHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h indexHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *indexHeap) Push(x any)        { *h = append(*h, x.(int)) }
+func (h *indexHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// referencedTypes returns the field types of msg and its nested messages
+func referencedTypes(msg *ProtoMessage) []string {
+	var types []string
+	for _, field := range msg.Fields {
+		types = append(types, field.Type)
+	}
+	for _, nested := range msg.Nested {
+		types = append(types, referencedTypes(nested)...)
+	}
+	return types
+}
+
+// definitionName returns the name of a message or enum definition
+func definitionName(def interface{}) string {
+	switch d := def.(type) {
+	case *ProtoMessage:
+		return d.Name
+	case *ProtoEnum:
+		return d.Name
+	}
+	return ""
+}
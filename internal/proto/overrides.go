@@ -0,0 +1,93 @@
+package proto
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// protoTypeOverride describes a proto type x-proto-type may name: the OpenAPI
+// types it can faithfully carry and the .proto import it requires.
+type protoTypeOverride struct {
+	openapi []string
+	imp     string
+}
+
+const wrappersImport = "google/protobuf/wrappers.proto"
+
+// protoTypeOverrides lists the types x-proto-type accepts. 64-bit integers also
+// accept `type: string`, the form the proto3 JSON mapping gives them.
+var protoTypeOverrides = map[string]protoTypeOverride{
+	"string":   {openapi: []string{"string"}},
+	"bytes":    {openapi: []string{"string"}},
+	"bool":     {openapi: []string{"boolean"}},
+	"float":    {openapi: []string{"number"}},
+	"double":   {openapi: []string{"number"}},
+	"int32":    {openapi: []string{"integer"}},
+	"uint32":   {openapi: []string{"integer"}},
+	"sint32":   {openapi: []string{"integer"}},
+	"fixed32":  {openapi: []string{"integer"}},
+	"sfixed32": {openapi: []string{"integer"}},
+	"int64":    {openapi: []string{"integer", "string"}},
+	"uint64":   {openapi: []string{"integer", "string"}},
+	"sint64":   {openapi: []string{"integer", "string"}},
+	"fixed64":  {openapi: []string{"integer", "string"}},
+	"sfixed64": {openapi: []string{"integer", "string"}},
+
+	"google.protobuf.Timestamp":   {openapi: []string{"string"}, imp: timestampImport},
+	"google.protobuf.Duration":    {openapi: []string{"string"}, imp: "google/protobuf/duration.proto"},
+	"google.protobuf.StringValue": {openapi: []string{"string"}, imp: wrappersImport},
+	"google.protobuf.BytesValue":  {openapi: []string{"string"}, imp: wrappersImport},
+	"google.protobuf.BoolValue":   {openapi: []string{"boolean"}, imp: wrappersImport},
+	"google.protobuf.FloatValue":  {openapi: []string{"number"}, imp: wrappersImport},
+	"google.protobuf.DoubleValue": {openapi: []string{"number"}, imp: wrappersImport},
+	"google.protobuf.Int32Value":  {openapi: []string{"integer"}, imp: wrappersImport},
+	"google.protobuf.UInt32Value": {openapi: []string{"integer"}, imp: wrappersImport},
+	"google.protobuf.Int64Value":  {openapi: []string{"integer", "string"}, imp: wrappersImport},
+	"google.protobuf.UInt64Value": {openapi: []string{"integer", "string"}, imp: wrappersImport},
+}
+
+// timestampImport is rendered through Context.UsesTimestamp rather than Imports
+const timestampImport = "google/protobuf/timestamp.proto"
+
+// extractProtoType returns the x-proto-type extension of schema, if present
+func extractProtoType(schema *base.Schema) (string, bool) {
+	if schema == nil || schema.Extensions == nil {
+		return "", false
+	}
+
+	node, found := schema.Extensions.Get("x-proto-type")
+	if !found || node == nil {
+		return "", false
+	}
+	return strings.TrimSpace(node.Value), true
+}
+
+// overrideProtoType maps a scalar of OpenAPI type typ to the proto type named by
+// x-proto-type, recording the import it needs. Types that cannot carry typ's
+// values are rejected.
+func overrideProtoType(ctx *Context, propertyName, typ, protoType string) (string, error) {
+	override, ok := protoTypeOverrides[protoType]
+	if !ok {
+		return "", fmt.Errorf("property '%s': x-proto-type '%s' is not a proto scalar or supported well-known type", propertyName, protoType)
+	}
+	if !slices.Contains(override.openapi, typ) {
+		return "", fmt.Errorf("property '%s': x-proto-type '%s' cannot represent OpenAPI type '%s'", propertyName, protoType, typ)
+	}
+
+	switch override.imp {
+	case "":
+	case timestampImport:
+		if !ctx.UsesTimestamp {
+			ctx.Logger.Debug(internal.LogImportAdded, "schema", ctx.schema, "import", timestampImport)
+		}
+		ctx.UsesTimestamp = true
+	default:
+		ctx.addImport(override.imp)
+	}
+
+	return protoType, nil
+}
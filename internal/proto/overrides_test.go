@@ -0,0 +1,140 @@
+package proto_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertProtoTypeOverride(t *testing.T) {
+	given := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Account:
+      type: object
+      properties:
+        id:
+          type: integer
+          format: int64
+          x-proto-type: sfixed64
+        balance:
+          type: string
+          format: int64
+          x-proto-type: int64
+        nickname:
+          type: string
+          x-proto-type: google.protobuf.StringValue
+        ttl:
+          type: string
+          x-proto-type: google.protobuf.Duration
+        avatar:
+          type: string
+          x-proto-type: bytes
+        counter:
+          type: array
+          items:
+            type: integer
+            x-proto-type: fixed32
+`
+	expected := `syntax = "proto3";
+
+package testpkg;
+
+import "google/protobuf/wrappers.proto";
+import "google/protobuf/duration.proto";
+
+option go_package = "github.com/example/proto/v1";
+
+message Account {
+  sfixed64 id = 1 [json_name = "id"];
+  int64 balance = 2 [json_name = "balance"];
+  google.protobuf.StringValue nickname = 3 [json_name = "nickname"];
+  google.protobuf.Duration ttl = 4 [json_name = "ttl"];
+  bytes avatar = 5 [json_name = "avatar"];
+  repeated fixed32 counter = 6 [json_name = "counter"];
+}
+
+`
+
+	result, err := schema.Convert([]byte(given), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(result.Protobuf))
+}
+
+func TestConvertProtoTypeOverrideErrors(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		property string
+		wantErr  string
+	}{
+		{
+			name: "unknown type",
+			property: `          type: string
+          x-proto-type: google.type.Money
+`,
+			wantErr: "schema 'Account': property 'value': x-proto-type 'google.type.Money' is not a proto scalar or supported well-known type",
+		},
+		{
+			name: "incompatible type",
+			property: `          type: number
+          x-proto-type: sfixed64
+`,
+			wantErr: "schema 'Account': property 'value': x-proto-type 'sfixed64' cannot represent OpenAPI type 'number'",
+		},
+		{
+			name: "32-bit integer from string",
+			property: `          type: string
+          x-proto-type: int32
+`,
+			wantErr: "x-proto-type 'int32' cannot represent OpenAPI type 'string'",
+		},
+		{
+			name: "object",
+			property: `          type: object
+          x-proto-type: bytes
+          properties:
+            name:
+              type: string
+`,
+			wantErr: "schema 'Account': property 'value': x-proto-type is only supported on scalar properties and array items",
+		},
+		{
+			name: "enum",
+			property: `          type: string
+          enum: [a, b]
+          x-proto-type: bytes
+`,
+			wantErr: "x-proto-type is only supported on scalar properties and array items",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			given := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Account:
+      type: object
+      properties:
+        value:
+` + test.property
+
+			_, err := schema.Convert([]byte(given), schema.ConvertOptions{
+				PackageName: "testpkg",
+				PackagePath: "github.com/example/proto/v1",
+			})
+			require.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
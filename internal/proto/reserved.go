@@ -0,0 +1,81 @@
+package proto
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"go.yaml.in/yaml/v4"
+)
+
+// protoIdentifier matches the field names a `reserved "name";` statement accepts
+var protoIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// extractReserved parses the schema-level x-proto-reserved extension: a list of
+// retired field numbers and field names, such as [4, 9, "old_name"].
+func extractReserved(schema *base.Schema) ([]int, []string, error) {
+	if schema == nil || schema.Extensions == nil {
+		return nil, nil, nil
+	}
+
+	node, found := schema.Extensions.Get("x-proto-reserved")
+	if !found || node == nil {
+		return nil, nil, nil
+	}
+	if node.Kind != yaml.SequenceNode {
+		return nil, nil, fmt.Errorf("x-proto-reserved must be a list of field numbers and names")
+	}
+
+	var numbers []int
+	var names []string
+	for _, item := range node.Content {
+		if item.Kind != yaml.ScalarNode {
+			return nil, nil, fmt.Errorf("x-proto-reserved entries must be field numbers or names")
+		}
+		if item.Tag == "!!int" {
+			num, err := strconv.Atoi(item.Value)
+			if err != nil || num < 1 || num > 536870911 {
+				return nil, nil, fmt.Errorf("x-proto-reserved number must be between 1 and 536870911, got: %s", item.Value)
+			}
+			if !slices.Contains(numbers, num) {
+				numbers = append(numbers, num)
+			}
+			continue
+		}
+		if !protoIdentifier.MatchString(item.Value) {
+			return nil, nil, fmt.Errorf("x-proto-reserved name must be a proto field name, got: %s", item.Value)
+		}
+		if !slices.Contains(names, item.Value) {
+			names = append(names, item.Value)
+		}
+	}
+	return numbers, names, nil
+}
+
+// applyReserved adds the numbers and names x-proto-reserved retires to msg. A
+// retired number or name must not still be used by one of msg's fields.
+func applyReserved(msg *ProtoMessage, schema *base.Schema) error {
+	numbers, names, err := extractReserved(schema)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range msg.Fields {
+		if slices.Contains(numbers, field.Number) {
+			return fmt.Errorf("x-proto-reserved number %d conflicts with active field '%s'", field.Number, field.Name)
+		}
+		if slices.Contains(names, field.Name) {
+			return fmt.Errorf("x-proto-reserved name '%s' conflicts with an active field", field.Name)
+		}
+	}
+
+	for _, num := range numbers {
+		if !slices.Contains(msg.Reserved, num) {
+			msg.Reserved = append(slices.Clip(msg.Reserved), num)
+		}
+	}
+	msg.ReservedNames = append(msg.ReservedNames, names...)
+	return nil
+}
@@ -0,0 +1,133 @@
+package proto_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProtoReservedExtension(t *testing.T) {
+	given := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    User:
+      type: object
+      x-proto-reserved: [4, 9, "old_name", 4, "legacy"]
+      properties:
+        id:
+          type: string
+        profile:
+          type: object
+          x-proto-reserved: [2]
+          properties:
+            bio:
+              type: string
+`
+	result, err := schema.Convert([]byte(given), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, string(result.Protobuf), `message User {
+  message Profile {
+    string bio = 1 [json_name = "bio"];
+    reserved 2;
+  }
+
+  string id = 1 [json_name = "id"];
+  Profile profile = 2 [json_name = "profile"];
+  reserved 4, 9;
+  reserved "old_name", "legacy";
+}`)
+}
+
+func TestProtoReservedExtensionWithFieldNumbers(t *testing.T) {
+	given := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    User:
+      type: object
+      x-proto-reserved: [3, 7]
+      properties:
+        id:
+          type: string
+`
+	result, err := schema.Convert([]byte(given), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+		FieldNumbers: &schema.FieldNumbers{Messages: map[string]schema.MessageNumbers{
+			"User": {Fields: map[string]int{"id": 1}, Reserved: []int{2, 3}},
+		}},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, string(result.Protobuf), "  reserved 2, 3, 7;\n")
+}
+
+func TestProtoReservedExtensionErrors(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		reserved string
+		wantErr  string
+	}{
+		{
+			name:     "not a list",
+			reserved: "4",
+			wantErr:  "schema 'User': x-proto-reserved must be a list of field numbers and names",
+		},
+		{
+			name:     "number out of range",
+			reserved: "[0]",
+			wantErr:  "x-proto-reserved number must be between 1 and 536870911, got: 0",
+		},
+		{
+			name:     "invalid name",
+			reserved: `["old-name"]`,
+			wantErr:  "x-proto-reserved name must be a proto field name, got: old-name",
+		},
+		{
+			name:     "number in use",
+			reserved: "[2]",
+			wantErr:  "x-proto-reserved number 2 conflicts with active field 'name'",
+		},
+		{
+			name:     "name in use",
+			reserved: `["id"]`,
+			wantErr:  "x-proto-reserved name 'id' conflicts with an active field",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			given := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    User:
+      type: object
+      x-proto-reserved: ` + test.reserved + `
+      properties:
+        id:
+          type: string
+        name:
+          type: string
+`
+			_, err := schema.Convert([]byte(given), schema.ConvertOptions{
+				PackageName: "testpkg",
+				PackagePath: "github.com/example/proto/v1",
+			})
+			require.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
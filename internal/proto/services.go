@@ -0,0 +1,162 @@
+package proto
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// emptyType is the request or response of a method that carries no data
+const emptyType = "google.protobuf.Empty"
+
+// ProtoService is a gRPC service declared in the x-services document extension
+type ProtoService struct {
+	Name        string
+	Description string
+	Methods     []*ProtoMethod
+}
+
+// ProtoMethod is an rpc of a ProtoService. Request and Response are message
+// names as emitted in the proto output.
+type ProtoMethod struct {
+	Name            string
+	Description     string
+	Request         string
+	Response        string
+	ClientStreaming bool
+	ServerStreaming bool
+}
+
+// methodSpec is a method as written under x-services
+type methodSpec struct {
+	Description     string `yaml:"description"`
+	Request         string `yaml:"request"`
+	Response        string `yaml:"response"`
+	ClientStreaming bool   `yaml:"clientStreaming"`
+	ServerStreaming bool   `yaml:"serverStreaming"`
+}
+
+// BuildServices reads the x-services extension into ctx.Services, in document
+// order. Each method names its request and response by component schema (or
+// google.protobuf.Empty), which must be emitted as a message in ctx; goTypes
+// names the schemas generated as Go instead, for a clearer error.
+//
+//	x-services:
+//	  UserService:
+//	    description: Manages users
+//	    methods:
+//	      GetUser:
+//	        request: GetUserRequest
+//	        response: User
+func BuildServices(node *yaml.Node, ctx *Context, goTypes map[string]bool) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("x-services must be a map of service names to services")
+	}
+
+	messages := make(map[string]string, len(ctx.Messages))
+	for _, msg := range ctx.Messages {
+		if _, seen := messages[msg.OriginalSchema]; !seen {
+			messages[msg.OriginalSchema] = msg.Name
+		}
+	}
+
+	// resolve returns the message a method's request or response names
+	resolve := func(role, value string) (string, error) {
+		if value == emptyType {
+			ctx.addImport("google/protobuf/empty.proto")
+			return emptyType, nil
+		}
+		name := strings.TrimPrefix(value, "#/components/schemas/")
+		switch {
+		case name == "":
+			return "", fmt.Errorf("%s is required", role)
+		case goTypes[name]:
+			return "", fmt.Errorf("%s '%s' is generated as Go, not as a proto message", role, name)
+		case messages[name] == "":
+			return "", fmt.Errorf("%s '%s' is not an object schema in components/schemas", role, name)
+		}
+		return messages[name], nil
+	}
+
+	// Services share the package namespace with messages and enums
+	taken := make(map[string]bool, len(ctx.Definitions))
+	for _, def := range ctx.Definitions {
+		switch d := def.(type) {
+		case *ProtoMessage:
+			taken[d.Name] = true
+		case *ProtoEnum:
+			taken[d.Name] = true
+		}
+	}
+
+	var errs []error
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		name, body := node.Content[i].Value, node.Content[i+1]
+		if !isTypeName(name) {
+			errs = append(errs, fmt.Errorf("x-services: invalid service name '%s'", name))
+			continue
+		}
+		if taken[name] {
+			errs = append(errs, fmt.Errorf("x-services: service '%s' conflicts with a message or enum of the same name", name))
+			continue
+		}
+
+		var spec struct {
+			Description string    `yaml:"description"`
+			Methods     yaml.Node `yaml:"methods"`
+		}
+		if err := body.Decode(&spec); err != nil {
+			errs = append(errs, fmt.Errorf("service '%s': %w", name, err))
+			continue
+		}
+		if spec.Methods.Kind != yaml.MappingNode || len(spec.Methods.Content) == 0 {
+			errs = append(errs, fmt.Errorf("service '%s': methods must be a non-empty map of method names to methods", name))
+			continue
+		}
+
+		service := &ProtoService{Name: name, Description: spec.Description}
+		for j := 0; j+1 < len(spec.Methods.Content); j += 2 {
+			methodName := spec.Methods.Content[j].Value
+			method, err := buildMethod(methodName, spec.Methods.Content[j+1], resolve)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("service '%s' method '%s': %w", name, methodName, err))
+				continue
+			}
+			service.Methods = append(service.Methods, method)
+		}
+		ctx.Services = append(ctx.Services, service)
+	}
+	return errors.Join(errs...)
+}
+
+// buildMethod decodes one method of a service, resolving its request and response
+func buildMethod(name string, node *yaml.Node, resolve func(role, value string) (string, error)) (*ProtoMethod, error) {
+	if !isTypeName(name) {
+		return nil, fmt.Errorf("invalid method name")
+	}
+
+	var spec methodSpec
+	if err := node.Decode(&spec); err != nil {
+		return nil, err
+	}
+
+	request, err := resolve("request", spec.Request)
+	if err != nil {
+		return nil, err
+	}
+	response, err := resolve("response", spec.Response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProtoMethod{
+		Name:            name,
+		Description:     spec.Description,
+		Request:         request,
+		Response:        response,
+		ClientStreaming: spec.ClientStreaming,
+		ServerStreaming: spec.ServerStreaming,
+	}, nil
+}
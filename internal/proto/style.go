@@ -0,0 +1,77 @@
+package proto
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+)
+
+// Blank-line policies for Style.BlankLines
+const (
+	BlankLinesDefinitions = "definitions" // between top-level definitions and after nested messages
+	BlankLinesCommented   = "commented"   // also before each field with a comment
+	BlankLinesFields      = "fields"      // also between every field
+)
+
+// Style controls the layout of generated proto output
+type Style struct {
+	// Comments controls how descriptions are written as comments
+	Comments internal.CommentStyle
+	// Indent is the number of spaces per nesting level; 0 → 2
+	Indent int
+	// OmitDefaultJSONName leaves out json_name annotations equal to the name
+	// protoc derives from the field name (see DefaultJSONName)
+	OmitDefaultJSONName bool
+	// BlankLines is BlankLinesDefinitions (or empty), BlankLinesCommented or
+	// BlankLinesFields
+	BlankLines string
+	// Editions writes edition 2023 syntax instead of proto3, keeping proto3's
+	// implicit field presence as the file default; optional fields get explicit
+	// presence as a field feature
+	Editions bool
+}
+
+// indent returns one level of indentation
+func (s Style) indent() string {
+	if s.Indent <= 0 {
+		return "  "
+	}
+	return strings.Repeat(" ", s.Indent)
+}
+
+// blankLineBefore reports whether a blank line separates the field, or oneof
+// of fields, from the field before it
+func (s Style) blankLineBefore(fields ...*ProtoField) bool {
+	switch s.BlankLines {
+	case BlankLinesFields:
+		return true
+	case BlankLinesCommented:
+		for _, field := range fields {
+			if field.Description != "" || len(field.EnumValues) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// DefaultJSONName returns the JSON name protoc gives a field without a
+// json_name annotation: underscores are dropped and the letter after each is
+// upper-cased (status_code → statusCode).
+func DefaultJSONName(name string) string {
+	var result strings.Builder
+	upper := false
+	for _, r := range name {
+		switch {
+		case r == '_':
+			upper = true
+		case upper:
+			result.WriteRune(unicode.ToUpper(r))
+			upper = false
+		default:
+			result.WriteRune(r)
+		}
+	}
+	return result.String()
+}
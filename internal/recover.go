@@ -0,0 +1,50 @@
+package internal
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// ConversionError reports a panic recovered inside a public entry point, so a
+// malformed spec or a libopenapi edge case fails the call instead of crashing
+// the program embedding the package.
+type ConversionError struct {
+	Op    string      // Entry point that panicked, e.g. "Convert"
+	Value interface{} // Value passed to panic
+	Stack []byte      // Stack of the goroutine that panicked
+}
+
+func (e *ConversionError) Error() string {
+	return fmt.Sprintf("%s: recovered from panic: %v", e.Op, e.Value)
+}
+
+// Unwrap returns the value passed to panic when it is an error, such as a
+// runtime.Error
+func (e *ConversionError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Recover turns a panic in the function deferring it into a *ConversionError
+// for op stored in *err. A *ConversionError re-raised from a worker goroutine
+// keeps the worker's stack.
+func Recover(op string, err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	*err = NewConversionError(op, r)
+}
+
+// NewConversionError wraps value, recovered from a panic, as a *ConversionError
+// for op. It must be called from the deferred function that recovered value,
+// for the stack to be that of the panic.
+func NewConversionError(op string, value interface{}) *ConversionError {
+	if ce, ok := value.(*ConversionError); ok {
+		if ce.Op == "" {
+			ce.Op = op
+		}
+		return ce
+	}
+	return &ConversionError{Op: op, Value: value, Stack: debug.Stack()}
+}
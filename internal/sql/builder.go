@@ -0,0 +1,330 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/duh-rpc/openapi-schema.go/internal/parser"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"go.yaml.in/yaml/v4"
+)
+
+// DialectPostgres is the only dialect supported so far
+const DialectPostgres = "postgres"
+
+// Table is a CREATE TABLE statement
+type Table struct {
+	Name        string
+	Description string
+	Columns     []*Column
+	// PrimaryKey lists the primary key columns, in property order
+	PrimaryKey []string
+}
+
+// Column is a column of a table
+type Column struct {
+	Name        string
+	Type        string
+	NotNull     bool
+	Description string
+	// Values holds the SQL literals of an enum, checked by a CHECK constraint
+	Values []string
+}
+
+// Context holds the state of an SQL generation run
+type Context struct {
+	Tables  []*Table
+	Ctx     context.Context // checked between schemas; nil → never cancelled
+	Dialect string
+
+	// tables maps generated table names to the schemas they are generated for
+	tables map[string]string
+}
+
+// NewContext creates a new Context
+func NewContext() *Context {
+	return &Context{Tables: []*Table{}, Dialect: DialectPostgres}
+}
+
+// identifier matches the names x-db-table and x-db-column may set
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// columnType matches the column types x-db-type may set, such as uuid,
+// varchar(64), numeric(12, 2) or text[]
+var columnType = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_ ,()\[\]]*$`)
+
+// BuildTables adds a table for each entry that is an object with properties, in
+// document order. Other schemas, such as enums, unions and arrays, have no
+// table; neither do schemas marked x-db-skip: true. Tables and columns are the
+// snake_case schema and property names, unless renamed with x-db-table and
+// x-db-column.
+func BuildTables(entries []*parser.SchemaEntry, ctx *Context) error {
+	ctx.tables = make(map[string]string, len(entries))
+	for _, entry := range entries {
+		if err := internal.Cancelled(ctx.Ctx); err != nil {
+			return err
+		}
+		if err := ctx.table(entry); err != nil {
+			return internal.SchemaError(entry.Name, err.Error())
+		}
+	}
+	return nil
+}
+
+// table adds the table of entry, if it has one
+func (ctx *Context) table(entry *parser.SchemaEntry) error {
+	schema := entry.Proxy.Schema()
+	if schema == nil {
+		return fmt.Errorf("schema is nil")
+	}
+
+	skip, err := boolExtension(schema, "x-db-skip")
+	if err != nil || skip {
+		return err
+	}
+	name, err := identifierExtension(schema, "x-db-table")
+	if err != nil {
+		return err
+	}
+	if !isObjectWithProperties(schema) {
+		if name != "" {
+			return fmt.Errorf("x-db-table requires an object schema with properties")
+		}
+		return nil
+	}
+	if name == "" {
+		name = internal.ApplyJSONCase(entry.Name, internal.JSONCaseSnake)
+	}
+	if other, ok := ctx.tables[name]; ok {
+		return fmt.Errorf("table '%s' is already generated for schema '%s'; rename one with x-db-table", name, other)
+	}
+	ctx.tables[name] = entry.Name
+
+	table := &Table{Name: name, Description: schema.Description}
+	columns := make(map[string]string, schema.Properties.Len())
+	for propName, propProxy := range schema.Properties.FromOldest() {
+		column, primaryKey, err := ctx.column(propName, propProxy, slices.Contains(schema.Required, propName))
+		if err != nil {
+			return fmt.Errorf("property '%s': %w", propName, err)
+		}
+		if column == nil {
+			continue
+		}
+		if other, ok := columns[column.Name]; ok {
+			return fmt.Errorf("property '%s': column '%s' is already generated for property '%s'; rename one with x-db-column", propName, column.Name, other)
+		}
+		columns[column.Name] = propName
+		table.Columns = append(table.Columns, column)
+		if primaryKey {
+			table.PrimaryKey = append(table.PrimaryKey, column.Name)
+		}
+	}
+
+	ctx.Tables = append(ctx.Tables, table)
+	return nil
+}
+
+// column returns the column of a property and whether it is part of the
+// primary key; nil when the property is marked x-db-skip: true
+func (ctx *Context) column(propName string, proxy *base.SchemaProxy, required bool) (*Column, bool, error) {
+	schema := proxy.Schema()
+	if schema == nil {
+		return nil, false, fmt.Errorf("schema is nil")
+	}
+
+	skip, err := boolExtension(schema, "x-db-skip")
+	if err != nil || skip {
+		return nil, false, err
+	}
+	primaryKey, err := boolExtension(schema, "x-db-primary-key")
+	if err != nil {
+		return nil, false, err
+	}
+	name, err := identifierExtension(schema, "x-db-column")
+	if err != nil {
+		return nil, false, err
+	}
+	if name == "" {
+		name = internal.ApplyJSONCase(propName, internal.JSONCaseSnake)
+	}
+
+	column := &Column{Name: name, Description: schema.Description}
+	nullable := isNullable(schema)
+	if column.Values, nullable, err = values(schema.Enum, nullable); err != nil {
+		return nil, false, err
+	}
+	if primaryKey && nullable {
+		return nil, false, fmt.Errorf("x-db-primary-key cannot be set on a nullable property")
+	}
+	column.NotNull = required && !nullable && !primaryKey
+
+	typ, found, err := typeExtension(schema)
+	if err != nil {
+		return nil, false, err
+	}
+	if !found {
+		typ = postgresType(schema)
+	}
+	column.Type = typ
+	return column, primaryKey, nil
+}
+
+// postgresType maps a schema to its Postgres column type. Scalars map to the
+// matching type; objects, arrays, unions and schemas of several types are
+// stored as jsonb.
+func postgresType(schema *base.Schema) string {
+	if len(schema.OneOf) > 0 || len(schema.AnyOf) > 0 || len(schema.AllOf) > 0 {
+		return "jsonb"
+	}
+
+	var types []string
+	for _, t := range schema.Type {
+		if t != "null" {
+			types = append(types, t)
+		}
+	}
+	if len(types) != 1 {
+		return "jsonb"
+	}
+
+	switch types[0] {
+	case "string":
+		if internal.IsDecimal(schema) {
+			return "numeric"
+		}
+		switch schema.Format {
+		case "date-time":
+			return "timestamptz"
+		case "date":
+			return "date"
+		case "time":
+			return "time"
+		case "uuid":
+			return "uuid"
+		case "byte", "binary":
+			return "bytea"
+		}
+		if schema.MaxLength != nil && *schema.MaxLength > 0 {
+			return "varchar(" + strconv.FormatInt(*schema.MaxLength, 10) + ")"
+		}
+		return "text"
+	case "integer":
+		if schema.Format == "int64" {
+			return "bigint"
+		}
+		return "integer"
+	case "number":
+		if internal.IsDecimal(schema) {
+			return "numeric"
+		}
+		if schema.Format == "float" {
+			return "real"
+		}
+		return "double precision"
+	case "boolean":
+		return "boolean"
+	}
+	return "jsonb"
+}
+
+// values returns the SQL literals of enum values and whether the column is
+// nullable, which a null value makes it
+func values(enum []*yaml.Node, nullable bool) ([]string, bool, error) {
+	var literals []string
+	for _, node := range enum {
+		var decoded any
+		if err := node.Decode(&decoded); err != nil {
+			return nil, false, fmt.Errorf("enum value '%s': %w", node.Value, err)
+		}
+		switch v := decoded.(type) {
+		case nil:
+			nullable = true
+		case string:
+			literals = append(literals, "'"+strings.ReplaceAll(v, "'", "''")+"'")
+		case bool:
+			literals = append(literals, strings.ToUpper(strconv.FormatBool(v)))
+		case int:
+			literals = append(literals, strconv.Itoa(v))
+		case float64:
+			literals = append(literals, strconv.FormatFloat(v, 'f', -1, 64))
+		default:
+			return nil, false, fmt.Errorf("enum value '%s' is not a scalar", node.Value)
+		}
+	}
+	return literals, nullable, nil
+}
+
+// isObjectWithProperties reports whether schema is a plain object with
+// properties, the schemas that get a table
+func isObjectWithProperties(schema *base.Schema) bool {
+	if len(schema.Enum) > 0 || len(schema.OneOf) > 0 || len(schema.AnyOf) > 0 || len(schema.AllOf) > 0 {
+		return false
+	}
+	return schema.Properties != nil && schema.Properties.Len() > 0 &&
+		(len(schema.Type) == 0 || (len(schema.Type) == 1 && schema.Type[0] == "object"))
+}
+
+// isNullable reports whether schema admits null, through OpenAPI 3.0's
+// nullable or a 3.1 "null" type
+func isNullable(schema *base.Schema) bool {
+	if schema.Nullable != nil && *schema.Nullable {
+		return true
+	}
+	return len(schema.Type) > 1 && slices.Contains(schema.Type, "null")
+}
+
+// boolExtension returns the value of a true or false extension; false when
+// absent
+func boolExtension(schema *base.Schema, ext string) (bool, error) {
+	node := extension(schema, ext)
+	if node == nil {
+		return false, nil
+	}
+	switch node.Value {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	return false, fmt.Errorf("%s must be true or false, got: %s", ext, node.Value)
+}
+
+// identifierExtension returns the SQL identifier an extension sets; empty when
+// absent
+func identifierExtension(schema *base.Schema, ext string) (string, error) {
+	node := extension(schema, ext)
+	if node == nil {
+		return "", nil
+	}
+	if node.Kind != yaml.ScalarNode || !identifier.MatchString(node.Value) {
+		return "", fmt.Errorf("%s must be an SQL identifier (letters, digits and underscores, not starting with a digit), got: %s", ext, node.Value)
+	}
+	return node.Value, nil
+}
+
+// typeExtension returns the column type x-db-type sets, written as given
+func typeExtension(schema *base.Schema) (string, bool, error) {
+	node := extension(schema, "x-db-type")
+	if node == nil {
+		return "", false, nil
+	}
+	typ := strings.TrimSpace(node.Value)
+	if node.Kind != yaml.ScalarNode || !columnType.MatchString(typ) {
+		return "", false, fmt.Errorf("x-db-type must be a column type such as uuid or varchar(64), got: %s", node.Value)
+	}
+	return typ, true, nil
+}
+
+// extension returns the node of an extension of schema; nil when absent
+func extension(schema *base.Schema, ext string) *yaml.Node {
+	if schema.Extensions == nil {
+		return nil
+	}
+	node, _ := schema.Extensions.Get(ext)
+	return node
+}
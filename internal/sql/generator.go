@@ -0,0 +1,92 @@
+package sql
+
+import (
+	"regexp"
+	"strings"
+)
+
+// reserved holds the Postgres reserved key words, which must be quoted to be
+// used as table or column names, such as a table named user
+var reserved = map[string]bool{}
+
+func init() {
+	for _, word := range strings.Fields(`all analyse analyze and any array as asc
+		asymmetric authorization binary both case cast check collate collation
+		column concurrently constraint create cross current_catalog current_date
+		current_role current_schema current_time current_timestamp current_user
+		default deferrable desc distinct do else end except false fetch for
+		foreign freeze from full grant group having ilike in initially inner
+		intersect into is isnull join lateral leading left like limit localtime
+		localtimestamp natural not notnull null offset on only or order outer
+		overlaps placing primary references returning right select session_user
+		similar some symmetric system_user table tablesample then to trailing
+		true union unique user using variadic verbose when where window with`) {
+		reserved[word] = true
+	}
+}
+
+// plain matches identifiers Postgres reads as written, without quoting
+var plain = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// Generate renders the tables of ctx as CREATE TABLE statements
+func Generate(ctx *Context) []byte {
+	var b strings.Builder
+	for i, table := range ctx.Tables {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		writeComment(&b, "", table.Description)
+		if len(table.Columns) == 0 {
+			b.WriteString("CREATE TABLE " + quote(table.Name) + " ();\n")
+			continue
+		}
+
+		b.WriteString("CREATE TABLE " + quote(table.Name) + " (\n")
+		lines := make([]string, 0, len(table.Columns)+1)
+		for _, column := range table.Columns {
+			var line strings.Builder
+			writeComment(&line, "  ", column.Description)
+			line.WriteString("  " + quote(column.Name) + " " + column.Type)
+			if column.NotNull {
+				line.WriteString(" NOT NULL")
+			}
+			if len(column.Values) > 0 {
+				line.WriteString(" CHECK (" + quote(column.Name) + " IN (" + strings.Join(column.Values, ", ") + "))")
+			}
+			lines = append(lines, line.String())
+		}
+		if len(table.PrimaryKey) > 0 {
+			keys := make([]string, len(table.PrimaryKey))
+			for i, key := range table.PrimaryKey {
+				keys[i] = quote(key)
+			}
+			lines = append(lines, "  PRIMARY KEY ("+strings.Join(keys, ", ")+")")
+		}
+		b.WriteString(strings.Join(lines, ",\n") + "\n);\n")
+	}
+	return []byte(b.String())
+}
+
+// writeComment writes description as -- comment lines
+func writeComment(b *strings.Builder, indent, description string) {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return
+	}
+	for _, line := range strings.Split(description, "\n") {
+		if line = strings.TrimRight(line, " \t"); line == "" {
+			b.WriteString(indent + "--\n")
+			continue
+		}
+		b.WriteString(indent + "-- " + line + "\n")
+	}
+}
+
+// quote double-quotes name when Postgres would otherwise fold its case or read
+// it as a key word
+func quote(name string) string {
+	if plain.MatchString(name) && !reserved[name] {
+		return name
+	}
+	return `"` + name + `"`
+}
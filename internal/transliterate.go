@@ -0,0 +1,93 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Non-ASCII property name handling for ASCIIName
+const (
+	NonASCIITransliterate = "transliterate" // Latin letters lose their accents, other characters are escaped
+	NonASCIIEscape        = "escape"        // every non-ASCII character is escaped as u<hex>
+	NonASCIIError         = "error"         // non-ASCII characters are an error
+)
+
+// transliterations maps accented and ligature Latin letters to ASCII
+var transliterations = map[rune]string{}
+
+func init() {
+	for _, group := range []struct{ ascii, letters string }{
+		{"a", "àáâãäåāăą"}, {"A", "ÀÁÂÃÄÅĀĂĄ"},
+		{"c", "çćĉċč"}, {"C", "ÇĆĈĊČ"},
+		{"d", "ďđð"}, {"D", "ĎĐÐ"},
+		{"e", "èéêëēĕėęě"}, {"E", "ÈÉÊËĒĔĖĘĚ"},
+		{"g", "ĝğġģ"}, {"G", "ĜĞĠĢ"},
+		{"h", "ĥħ"}, {"H", "ĤĦ"},
+		{"i", "ìíîïĩīĭįı"}, {"I", "ÌÍÎÏĨĪĬĮİ"},
+		{"j", "ĵ"}, {"J", "Ĵ"},
+		{"k", "ķ"}, {"K", "Ķ"},
+		{"l", "ĺļľŀł"}, {"L", "ĹĻĽĿŁ"},
+		{"n", "ñńņňŉ"}, {"N", "ÑŃŅŇ"},
+		{"o", "òóôõöøōŏő"}, {"O", "ÒÓÔÕÖØŌŎŐ"},
+		{"r", "ŕŗř"}, {"R", "ŔŖŘ"},
+		{"s", "śŝşš"}, {"S", "ŚŜŞŠ"},
+		{"t", "ţťŧ"}, {"T", "ŢŤŦ"},
+		{"u", "ùúûüũūŭůűų"}, {"U", "ÙÚÛÜŨŪŬŮŰŲ"},
+		{"w", "ŵ"}, {"W", "Ŵ"},
+		{"y", "ýÿŷ"}, {"Y", "ÝŸŶ"},
+		{"z", "źżž"}, {"Z", "ŹŻŽ"},
+		{"ae", "æ"}, {"AE", "Æ"},
+		{"oe", "œ"}, {"OE", "Œ"},
+		{"ss", "ß"},
+		{"th", "þ"}, {"TH", "Þ"},
+	} {
+		for _, r := range group.letters {
+			transliterations[r] = group.ascii
+		}
+	}
+}
+
+// ASCIIName returns name with its non-ASCII characters handled under mode
+// (NonASCIITransliterate when ""): accented Latin letters transliterated
+// (prénom → prenom, straße → strasse), and other characters escaped as "u" and
+// their lowercase hex code point, set apart by underscores (名前 → u540d_u524d).
+// NonASCIIEscape escapes every non-ASCII character, and NonASCIIError rejects
+// them. ASCII names are returned unchanged.
+func ASCIIName(name, mode string) (string, error) {
+	i := strings.IndexFunc(name, func(r rune) bool { return r >= utf8.RuneSelf })
+	if i < 0 {
+		return name, nil
+	}
+	if mode == NonASCIIError {
+		r, _ := utf8.DecodeRuneInString(name[i:])
+		return "", fmt.Errorf("name contains non-ASCII character '%c' (%U): use NonASCIINames transliterate or escape", r, r)
+	}
+
+	var result strings.Builder
+	escaped := false
+	for _, r := range name {
+		if r < utf8.RuneSelf {
+			if escaped && r != '_' {
+				result.WriteByte('_')
+			}
+			result.WriteRune(r)
+			escaped = false
+			continue
+		}
+		if ascii, ok := transliterations[r]; ok && mode != NonASCIIEscape {
+			if escaped {
+				result.WriteByte('_')
+			}
+			result.WriteString(ascii)
+			escaped = false
+			continue
+		}
+		if result.Len() > 0 && !strings.HasSuffix(result.String(), "_") {
+			result.WriteByte('_')
+		}
+		fmt.Fprintf(&result, "u%04x", r)
+		escaped = true
+	}
+	return result.String(), nil
+}
@@ -0,0 +1,75 @@
+package internal
+
+import (
+	"fmt"
+	"slices"
+)
+
+// TypeMapping overrides the built-in scalar mapping for one OpenAPI type and
+// format. An empty Proto or Go leaves that output on the built-in mapping.
+type TypeMapping struct {
+	Type        string // OpenAPI type, e.g. "string"
+	Format      string // OpenAPI format, e.g. "decimal"; "" matches schemas without a format
+	Proto       string // proto3 type, e.g. "google.type.Decimal"
+	ProtoImport string // .proto file declaring Proto, e.g. "google/type/decimal.proto"
+	Go          string // Go type, e.g. "uuid.UUID"
+	GoImport    string // Go package declaring Go, e.g. "github.com/google/uuid"
+}
+
+// FindTypeMapping returns the mapping for typ and format, or nil
+func FindTypeMapping(mappings []TypeMapping, typ, format string) *TypeMapping {
+	for i := range mappings {
+		if mappings[i].Type == typ && mappings[i].Format == format {
+			return &mappings[i]
+		}
+	}
+	return nil
+}
+
+// ValidateTypeMappings rejects mappings without a type or target and duplicates
+func ValidateTypeMappings(mappings []TypeMapping) error {
+	seen := make(map[[2]string]bool, len(mappings))
+	for _, m := range mappings {
+		if m.Type == "" {
+			return fmt.Errorf("type mapping for format '%s' must set Type", m.Format)
+		}
+		if m.Proto == "" && m.Go == "" {
+			return fmt.Errorf("type mapping for %s/%s must set Proto or Go", m.Type, m.Format)
+		}
+		key := [2]string{m.Type, m.Format}
+		if seen[key] {
+			return fmt.Errorf("duplicate type mapping for %s/%s", m.Type, m.Format)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// ImportMapping points a component schema at a type generated from another
+// spec, so references use that type instead of a regenerated copy
+type ImportMapping struct {
+	Proto       string // fully qualified proto type, e.g. "acme.common.v1.Money"
+	ProtoImport string // .proto file declaring Proto, e.g. "acme/common/v1/money.proto"
+	Go          string // Go type, e.g. "commonv1.Money"; "" → Go references are an error
+	GoImport    string // Go package declaring Go, e.g. "github.com/acme/common/v1"
+}
+
+// ValidateImportMappings rejects mappings without a proto type, and schemas
+// that are also excluded
+func ValidateImportMappings(mappings map[string]ImportMapping, excluded []string) error {
+	names := make([]string, 0, len(mappings))
+	for name := range mappings {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		if mappings[name].Proto == "" {
+			return fmt.Errorf("import mapping for schema '%s' must set Proto", name)
+		}
+		if slices.Contains(excluded, name) {
+			return fmt.Errorf("schema '%s' cannot be both excluded and import-mapped", name)
+		}
+	}
+	return nil
+}
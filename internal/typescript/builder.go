@@ -0,0 +1,478 @@
+package typescript
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/duh-rpc/openapi-schema.go/internal/parser"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"go.yaml.in/yaml/v4"
+)
+
+// Declaration is an exported interface or type alias
+type Declaration struct {
+	Name        string
+	Description string
+	Deprecated  bool
+	// Properties of an interface; nil for a type alias
+	Properties []*Property
+	// Type is the aliased type expression; empty for an interface
+	Type string
+}
+
+// Property is a member of an interface
+type Property struct {
+	Name        string // JSON property name
+	Type        string
+	Optional    bool // absent from the schema's required list
+	Readonly    bool
+	Description string
+	Deprecated  bool
+}
+
+// Context holds the state of a TypeScript generation run
+type Context struct {
+	Declarations []*Declaration
+	Ctx          context.Context // checked between schemas; nil → never cancelled
+	// NestedNameFunc names the interfaces of inline objects, as for Go structs
+	NestedNameFunc func(parent, property string) string
+
+	// names maps component schemas to their declared type names
+	names map[string]string
+	// scope is the name of the declaration being built, which prefixes the
+	// interfaces of its inline objects; scopeName is the name NestedNameFunc
+	// sees as the parent
+	scope     string
+	scopeName string
+}
+
+// NewContext creates a new Context
+func NewContext() *Context {
+	return &Context{Declarations: []*Declaration{}}
+}
+
+// identifier matches property names usable without quotes
+var identifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// BuildDeclarations declares a type for each entry, in document order. Types are
+// named as their Go structs are: the schema name, or its x-go-name; inline
+// object properties become interfaces named after the enclosing type and the
+// property (Order.shipping → OrderShipping).
+func BuildDeclarations(entries []*parser.SchemaEntry, ctx *Context) error {
+	ctx.names = make(map[string]string, len(entries))
+	for _, entry := range entries {
+		name, err := internal.NameOverride(entry.Proxy.Schema(), "x-go-name")
+		if err != nil {
+			return internal.SchemaError(entry.Name, err.Error())
+		}
+		if name == "" {
+			name = entry.Name
+		}
+		if !identifier.MatchString(name) {
+			return internal.SchemaError(entry.Name, fmt.Sprintf("'%s' is not a valid TypeScript type name; rename it with x-go-name", name))
+		}
+		ctx.names[entry.Name] = name
+	}
+
+	for _, entry := range entries {
+		if err := internal.Cancelled(ctx.Ctx); err != nil {
+			return err
+		}
+		ctx.scope, ctx.scopeName = ctx.names[entry.Name], entry.Name
+		if err := ctx.declare(ctx.names[entry.Name], entry.Proxy); err != nil {
+			return internal.SchemaError(entry.Name, err.Error())
+		}
+	}
+	return nil
+}
+
+// declare adds the declaration of proxy named name: an interface for an object
+// with properties, a type alias for anything else
+func (ctx *Context) declare(name string, proxy *base.SchemaProxy) error {
+	schema := proxy.Schema()
+	if schema == nil {
+		return fmt.Errorf("schema is nil")
+	}
+
+	decl := &Declaration{
+		Name:        name,
+		Description: schema.Description,
+		Deprecated:  schema.Deprecated != nil && *schema.Deprecated,
+	}
+	// Reserve the slot first so a declaration precedes those of its inline objects
+	ctx.Declarations = append(ctx.Declarations, decl)
+
+	if proxy.IsReference() || !isObjectWithProperties(schema) || isNullable(schema) {
+		typ, err := ctx.typeExpr(proxy, "")
+		if err != nil {
+			return err
+		}
+		decl.Type = typ
+		return nil
+	}
+
+	properties, err := ctx.properties(schema)
+	if err != nil {
+		return err
+	}
+	decl.Properties = properties
+	return nil
+}
+
+// properties returns the members of an object schema
+func (ctx *Context) properties(schema *base.Schema) ([]*Property, error) {
+	properties := make([]*Property, 0, schema.Properties.Len())
+	for propName, propProxy := range schema.Properties.FromOldest() {
+		propSchema := propProxy.Schema()
+		if propSchema == nil {
+			return nil, fmt.Errorf("property '%s' has nil schema", propName)
+		}
+
+		typ, err := ctx.typeExpr(propProxy, propName)
+		if err != nil {
+			return nil, fmt.Errorf("property '%s': %w", propName, err)
+		}
+
+		properties = append(properties, &Property{
+			Name:        propName,
+			Type:        typ,
+			Optional:    !slices.Contains(schema.Required, propName),
+			Readonly:    internal.IsReadOnly(propSchema),
+			Description: internal.WithAccessNote(propSchema.Description, propSchema),
+			Deprecated:  propSchema.Deprecated != nil && *propSchema.Deprecated,
+		})
+	}
+	return properties, nil
+}
+
+// typeExpr returns the TypeScript type of proxy. property names the property
+// it is declared by, for naming inline objects, and is empty elsewhere.
+func (ctx *Context) typeExpr(proxy *base.SchemaProxy, property string) (string, error) {
+	if proxy.IsReference() {
+		refName, err := internal.ExtractReferenceName(proxy.GetReference())
+		if err != nil {
+			return "", err
+		}
+		name, ok := ctx.names[refName]
+		if !ok {
+			return "", fmt.Errorf("reference to unknown schema '%s'", refName)
+		}
+		return name, nil
+	}
+
+	schema := proxy.Schema()
+	if schema == nil {
+		return "", fmt.Errorf("schema is nil")
+	}
+
+	typ, err := ctx.baseType(schema, property)
+	if err != nil {
+		return "", err
+	}
+	if isNullable(schema) && typ != "unknown" && typ != "null" {
+		return union([]string{typ, "null"}), nil
+	}
+	return typ, nil
+}
+
+// baseType returns the type of schema, ignoring nullability
+func (ctx *Context) baseType(schema *base.Schema, property string) (string, error) {
+	switch {
+	case len(schema.Enum) > 0:
+		return literals(schema.Enum)
+	case schema.Const != nil:
+		return literals([]*yaml.Node{schema.Const})
+	case len(schema.OneOf) > 0:
+		return ctx.unionType(schema, schema.OneOf, property)
+	case len(schema.AnyOf) > 0:
+		return ctx.unionType(schema, schema.AnyOf, property)
+	case len(schema.AllOf) > 0:
+		parts, err := ctx.typeExprs(schema.AllOf, property)
+		if err != nil {
+			return "", err
+		}
+		return intersection(parts), nil
+	}
+
+	var types []string
+	for _, t := range schema.Type {
+		if t == "null" {
+			continue
+		}
+		typ, err := ctx.scalarOrCollection(schema, t, property)
+		if err != nil {
+			return "", err
+		}
+		types = append(types, typ)
+	}
+	switch len(types) {
+	case 0:
+		if schema.Properties != nil && schema.Properties.Len() > 0 {
+			return ctx.scalarOrCollection(schema, "object", property)
+		}
+		if len(schema.Type) > 0 {
+			return "null", nil
+		}
+		return "unknown", nil
+	case 1:
+		return types[0], nil
+	}
+	return union(types), nil
+}
+
+// scalarOrCollection maps one OpenAPI type
+func (ctx *Context) scalarOrCollection(schema *base.Schema, typ, property string) (string, error) {
+	switch typ {
+	case "string":
+		return "string", nil
+	case "integer", "number":
+		return "number", nil
+	case "boolean":
+		return "boolean", nil
+	case "array":
+		if schema.Items == nil || !schema.Items.IsA() || schema.Items.A == nil {
+			return "unknown[]", nil
+		}
+		item, err := ctx.typeExpr(schema.Items.A, property)
+		if err != nil {
+			return "", err
+		}
+		return group(item) + "[]", nil
+	case "object":
+		if schema.Properties != nil && schema.Properties.Len() > 0 {
+			return ctx.inlineObject(schema, property)
+		}
+		if additional := schema.AdditionalProperties; additional != nil && additional.IsA() && additional.A != nil {
+			value, err := ctx.typeExpr(additional.A, property)
+			if err != nil {
+				return "", err
+			}
+			return "Record<string, " + value + ">", nil
+		}
+		return "Record<string, unknown>", nil
+	}
+	return "", fmt.Errorf("unsupported type: %s", typ)
+}
+
+// inlineObject declares the interface of an inline object. One declared by a
+// property is named as Go names its struct; others, such as union members,
+// are written as an object literal type.
+func (ctx *Context) inlineObject(schema *base.Schema, property string) (string, error) {
+	if property == "" {
+		properties, err := ctx.properties(schema)
+		if err != nil {
+			return "", err
+		}
+		members := make([]string, len(properties))
+		for i, prop := range properties {
+			members[i] = member(prop)
+		}
+		return "{ " + strings.Join(members, "; ") + " }", nil
+	}
+
+	name := internal.ToGoName(property)
+	if ctx.NestedNameFunc != nil {
+		if custom := ctx.NestedNameFunc(ctx.scopeName, property); custom != "" {
+			name = custom
+		}
+	}
+	typeName := ctx.scope + name
+
+	scope, scopeName := ctx.scope, ctx.scopeName
+	ctx.scope, ctx.scopeName = typeName, name
+	defer func() { ctx.scope, ctx.scopeName = scope, scopeName }()
+
+	decl := &Declaration{Name: typeName, Description: schema.Description}
+	ctx.Declarations = append(ctx.Declarations, decl)
+	properties, err := ctx.properties(schema)
+	if err != nil {
+		return "", err
+	}
+	decl.Properties = properties
+	return typeName, nil
+}
+
+// unionType returns the union of variants. With a discriminator and referenced
+// variants, each variant's discriminator property is narrowed to the values
+// selecting it, so the union is tagged:
+//
+//	(Dog & { petType: "dog" }) | (Cat & { petType: "cat" })
+func (ctx *Context) unionType(schema *base.Schema, variants []*base.SchemaProxy, property string) (string, error) {
+	types, err := ctx.typeExprs(variants, property)
+	if err != nil {
+		return "", err
+	}
+	if schema.Discriminator == nil || schema.Discriminator.PropertyName == "" {
+		return union(types), nil
+	}
+
+	// Discriminator values per variant: the mapping keys, or the schema name
+	values := make(map[string][]string)
+	if schema.Discriminator.Mapping != nil {
+		for value, ref := range schema.Discriminator.Mapping.FromOldest() {
+			name, err := internal.ExtractReferenceName(ref)
+			if err != nil {
+				return "", fmt.Errorf("discriminator mapping '%s': %w", value, err)
+			}
+			values[name] = append(values[name], value)
+		}
+	}
+
+	key := propertyKey(schema.Discriminator.PropertyName)
+	for i, variant := range variants {
+		if !variant.IsReference() {
+			continue
+		}
+		name, err := internal.ExtractReferenceName(variant.GetReference())
+		if err != nil {
+			return "", err
+		}
+		tags := values[name]
+		if schema.Discriminator.Mapping == nil || schema.Discriminator.Mapping.Len() == 0 {
+			tags = []string{name}
+		}
+		if len(tags) == 0 {
+			return "", fmt.Errorf("variant '%s' not covered by discriminator mapping", name)
+		}
+		quoted := make([]string, len(tags))
+		for j, tag := range tags {
+			quoted[j] = quote(tag)
+		}
+		types[i] = "(" + types[i] + " & { " + key + ": " + strings.Join(quoted, " | ") + " })"
+	}
+	return strings.Join(types, " | "), nil
+}
+
+// typeExprs returns the types of proxies
+func (ctx *Context) typeExprs(proxies []*base.SchemaProxy, property string) ([]string, error) {
+	types := make([]string, 0, len(proxies))
+	for _, proxy := range proxies {
+		typ, err := ctx.typeExpr(proxy, property)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, typ)
+	}
+	return types, nil
+}
+
+// isObjectWithProperties reports whether schema is declared as an interface
+func isObjectWithProperties(schema *base.Schema) bool {
+	if len(schema.Enum) > 0 || len(schema.OneOf) > 0 || len(schema.AnyOf) > 0 || len(schema.AllOf) > 0 {
+		return false
+	}
+	return schema.Properties != nil && schema.Properties.Len() > 0 &&
+		(len(schema.Type) == 0 || (len(schema.Type) == 1 && schema.Type[0] == "object"))
+}
+
+// isNullable reports whether schema admits null, through OpenAPI 3.0's
+// nullable or a 3.1 "null" type
+func isNullable(schema *base.Schema) bool {
+	if schema.Nullable != nil && *schema.Nullable {
+		return true
+	}
+	return len(schema.Type) > 1 && slices.Contains(schema.Type, "null")
+}
+
+// literals returns the union of enum or const values
+func literals(values []*yaml.Node) (string, error) {
+	types := make([]string, 0, len(values))
+	for _, value := range values {
+		var decoded any
+		if err := value.Decode(&decoded); err != nil {
+			return "", fmt.Errorf("enum value '%s': %w", value.Value, err)
+		}
+		encoded, err := literal(decoded)
+		if err != nil {
+			return "", fmt.Errorf("enum value '%s': %w", value.Value, err)
+		}
+		types = append(types, encoded)
+	}
+	return union(types), nil
+}
+
+// union joins types with |, dropping duplicates
+func union(types []string) string {
+	seen := make(map[string]bool, len(types))
+	unique := types[:0:0]
+	for _, typ := range types {
+		if !seen[typ] {
+			seen[typ] = true
+			unique = append(unique, typ)
+		}
+	}
+	return strings.Join(unique, " | ")
+}
+
+// intersection joins types with &, grouping unions
+func intersection(types []string) string {
+	grouped := make([]string, len(types))
+	for i, typ := range types {
+		grouped[i] = group(typ)
+	}
+	return strings.Join(grouped, " & ")
+}
+
+// group parenthesizes a union or intersection so it binds as one type
+func group(typ string) string {
+	depth := 0
+	for _, r := range typ {
+		switch r {
+		case '(', '{', '<', '[':
+			depth++
+		case ')', '}', '>', ']':
+			depth--
+		case '|', '&':
+			if depth == 0 {
+				return "(" + typ + ")"
+			}
+		}
+	}
+	return typ
+}
+
+// propertyKey returns name as an object key, quoted when it is not an identifier
+func propertyKey(name string) string {
+	if identifier.MatchString(name) {
+		return name
+	}
+	return quote(name)
+}
+
+// quote returns s as a TypeScript string literal
+func quote(s string) string {
+	encoded, _ := literal(s)
+	return encoded
+}
+
+// literal returns value as a TypeScript literal, which JSON's syntax is a subset
+// of; unlike json.Marshal, <, > and & are left unescaped
+func literal(value any) (string, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(value); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// member renders prop as an object literal member
+func member(prop *Property) string {
+	var b strings.Builder
+	if prop.Readonly {
+		b.WriteString("readonly ")
+	}
+	b.WriteString(propertyKey(prop.Name))
+	if prop.Optional {
+		b.WriteString("?")
+	}
+	b.WriteString(": " + prop.Type)
+	return b.String()
+}
@@ -0,0 +1,57 @@
+package typescript
+
+import (
+	"strings"
+)
+
+// Generate renders the declarations of ctx as a TypeScript declaration file
+func Generate(ctx *Context) []byte {
+	var b strings.Builder
+	for i, decl := range ctx.Declarations {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		writeDoc(&b, "", decl.Description, decl.Deprecated)
+		if decl.Properties == nil {
+			b.WriteString("export type " + decl.Name + " = " + decl.Type + ";\n")
+			continue
+		}
+
+		b.WriteString("export interface " + decl.Name + " {\n")
+		for _, prop := range decl.Properties {
+			writeDoc(&b, "  ", prop.Description, prop.Deprecated)
+			b.WriteString("  " + member(prop) + ";\n")
+		}
+		b.WriteString("}\n")
+	}
+	return []byte(b.String())
+}
+
+// writeDoc writes a JSDoc comment holding description and a @deprecated tag,
+// on one line when it fits one
+func writeDoc(b *strings.Builder, indent, description string, deprecated bool) {
+	var lines []string
+	if description = strings.TrimSpace(description); description != "" {
+		lines = strings.Split(strings.ReplaceAll(description, "*/", "*\\/"), "\n")
+	}
+	if deprecated {
+		lines = append(lines, "@deprecated")
+	}
+
+	switch len(lines) {
+	case 0:
+		return
+	case 1:
+		b.WriteString(indent + "/** " + strings.TrimSpace(lines[0]) + " */\n")
+		return
+	}
+	b.WriteString(indent + "/**\n")
+	for _, line := range lines {
+		if line = strings.TrimRight(line, " \t"); line == "" {
+			b.WriteString(indent + " *\n")
+			continue
+		}
+		b.WriteString(indent + " * " + line + "\n")
+	}
+	b.WriteString(indent + " */\n")
+}
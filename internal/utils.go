@@ -2,9 +2,11 @@ package internal
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"go.yaml.in/yaml/v4"
 )
 
 // Contains checks if a slice contains a string (case-insensitive)
@@ -42,3 +44,65 @@ func ExtractReferenceName(ref string) (string, error) {
 func IsEnumSchema(schema *base.Schema) bool {
 	return len(schema.Enum) > 0
 }
+
+// typeIdentifier matches the names x-proto-name and x-go-name may set; both
+// proto message names and Go identifiers share this form
+var typeIdentifier = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// IsTypeIdentifier reports whether name is a valid proto message and Go type name
+func IsTypeIdentifier(name string) bool {
+	return typeIdentifier.MatchString(name)
+}
+
+// NameOverride returns the type name set by the extension ext (x-proto-name or
+// x-go-name) of a component schema; empty when absent.
+func NameOverride(schema *base.Schema, ext string) (string, error) {
+	if schema == nil || schema.Extensions == nil {
+		return "", nil
+	}
+
+	node, found := schema.Extensions.Get(ext)
+	if !found || node == nil {
+		return "", nil
+	}
+	if node.Kind != yaml.ScalarNode || !typeIdentifier.MatchString(node.Value) {
+		return "", fmt.Errorf("%s must be a type name (letters, digits and underscores, starting with a letter), got: %s", ext, node.Value)
+	}
+	return node.Value, nil
+}
+
+// PropertyExtension returns the extension ext of property propName of schema
+// as written; nil when absent. It is read from the property's own node, since a
+// $ref property resolves to the schema it references, losing its siblings.
+func PropertyExtension(schema *base.Schema, propName, ext string) *yaml.Node {
+	low := schema.GoLow()
+	if low == nil || low.Properties.ValueNode == nil {
+		return nil
+	}
+
+	props := low.Properties.ValueNode
+	for i := 0; i+1 < len(props.Content); i += 2 {
+		if props.Content[i].Value != propName {
+			continue
+		}
+		prop := props.Content[i+1]
+		if prop.Kind == yaml.AliasNode && prop.Alias != nil {
+			prop = prop.Alias
+		}
+		for j := 0; j+1 < len(prop.Content); j += 2 {
+			if prop.Content[j].Value == ext {
+				return prop.Content[j+1]
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+// IsJSONMediaType reports whether mediaType is application/json or a +json type
+// such as application/problem+json, ignoring parameters such as charset
+func IsJSONMediaType(mediaType string) bool {
+	base, _, _ := strings.Cut(mediaType, ";")
+	base = strings.ToLower(strings.TrimSpace(base))
+	return base == "application/json" || strings.HasSuffix(base, "+json")
+}
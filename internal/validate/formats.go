@@ -0,0 +1,75 @@
+package validate
+
+import (
+	"encoding/base64"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// formatViolations reports the strings within value that are not of their
+// schema's format, for byte (base64) and the network formats ipv4, ipv6,
+// hostname and idn-hostname, which JSON Schema leaves unchecked unless format
+// assertions are on. It follows properties, additionalProperties, items and
+// allOf; the alternatives of oneOf and anyOf are not followed, as the branch a
+// value matches is not known.
+func formatViolations(schema *base.Schema, value any, field string) []Violation {
+	if schema == nil {
+		return nil
+	}
+
+	var violations []Violation
+	for _, proxy := range schema.AllOf {
+		violations = append(violations, formatViolations(proxy.Schema(), value, field)...)
+	}
+
+	switch v := value.(type) {
+	case string:
+		if err := checkFormat(schema.Format, v); err != nil {
+			violations = append(violations, Violation{
+				Field:   field,
+				Class:   "format",
+				Message: fmt.Sprintf("'%s' is not valid '%s': %v", v, schema.Format, err),
+			})
+		}
+	case map[string]any:
+		for _, name := range slices.Sorted(maps.Keys(v)) {
+			item := v[name]
+			var proxy *base.SchemaProxy
+			if schema.Properties != nil {
+				proxy = schema.Properties.GetOrZero(name)
+			}
+			if proxy == nil && schema.AdditionalProperties != nil && schema.AdditionalProperties.IsA() {
+				proxy = schema.AdditionalProperties.A
+			}
+			if proxy != nil {
+				violations = append(violations, formatViolations(proxy.Schema(), item, field+"/"+escapePointer(name))...)
+			}
+		}
+	case []any:
+		if schema.Items == nil || !schema.Items.IsA() {
+			break
+		}
+		items := schema.Items.A.Schema()
+		for i, item := range v {
+			violations = append(violations, formatViolations(items, item, field+"/"+strconv.Itoa(i))...)
+		}
+	}
+	return violations
+}
+
+// checkFormat reports why value is not of format, for the formats
+// formatViolations checks
+func checkFormat(format, value string) error {
+	if format == "byte" {
+		if _, err := base64.StdEncoding.DecodeString(value); err != nil {
+			return fmt.Errorf("not base64")
+		}
+		return nil
+	}
+	return internal.CheckNetworkFormat(format, value)
+}
@@ -0,0 +1,150 @@
+package validate
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/duh-rpc/openapi-schema.go/internal/parser"
+	"github.com/pb33f/libopenapi-validator/schema_validation"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+	yaml "go.yaml.in/yaml/v4"
+)
+
+// exampleWalker validates the examples under paths, keyed by the JSON pointer of
+// the media type or parameter holding them
+type exampleWalker struct {
+	validator   schema_validation.SchemaValidator
+	isOpenAPI30 bool
+	// components maps the value nodes of components/examples to their JSON
+	// pointers, so issues in a referenced example point at the component
+	components map[*yaml.Node]string
+	results    map[string]*SchemaValidation
+}
+
+// validateOperationExamples validates the example and examples of each
+// parameter and request body and response media type under paths. Entries of
+// components/examples are validated against the schema of each media type or
+// parameter that references them.
+func validateOperationExamples(ctx context.Context, doc *parser.Document, validator schema_validation.SchemaValidator, isOpenAPI30 bool) (map[string]*SchemaValidation, error) {
+	w := &exampleWalker{
+		validator:   validator,
+		isOpenAPI30: isOpenAPI30,
+		components:  make(map[*yaml.Node]string),
+		results:     make(map[string]*SchemaValidation),
+	}
+
+	if components := doc.Components(); components != nil && components.Examples != nil {
+		for name, example := range components.Examples.FromOldest() {
+			if example != nil && example.Value != nil {
+				w.components[example.Value] = "/components/examples/" + escapePointer(name) + "/value"
+			}
+		}
+	}
+
+	paths := doc.Paths()
+	if paths == nil {
+		return w.results, nil
+	}
+	for path, item := range paths.FromOldest() {
+		if err := internal.Cancelled(ctx); err != nil {
+			return nil, err
+		}
+		if item == nil {
+			continue
+		}
+
+		pointer := "/paths/" + escapePointer(path)
+		w.parameters(item.Parameters, pointer+"/parameters")
+		for method, op := range item.GetOperations().FromOldest() {
+			opPointer := pointer + "/" + method
+			w.parameters(op.Parameters, opPointer+"/parameters")
+			if op.RequestBody != nil {
+				w.content(op.RequestBody.Content, opPointer+"/requestBody/content")
+			}
+			if op.Responses == nil {
+				continue
+			}
+			if op.Responses.Codes != nil {
+				for code, resp := range op.Responses.Codes.FromOldest() {
+					if resp != nil {
+						w.content(resp.Content, opPointer+"/responses/"+escapePointer(code)+"/content")
+					}
+				}
+			}
+			if op.Responses.Default != nil {
+				w.content(op.Responses.Default.Content, opPointer+"/responses/default/content")
+			}
+		}
+	}
+	return w.results, nil
+}
+
+// parameters validates the examples of each parameter, and of its content
+func (w *exampleWalker) parameters(params []*v3.Parameter, pointer string) {
+	for i, param := range params {
+		if param == nil {
+			continue
+		}
+		paramPointer := pointer + "/" + strconv.Itoa(i)
+		w.validate(paramPointer, param.Schema, param.Example, param.Examples)
+		w.content(param.Content, paramPointer+"/content")
+	}
+}
+
+// content validates the examples of each media type of content
+func (w *exampleWalker) content(content *orderedmap.Map[string, *v3.MediaType], pointer string) {
+	if content == nil {
+		return
+	}
+	for mediaType, media := range content.FromOldest() {
+		if media != nil {
+			w.validate(pointer+"/"+escapePointer(mediaType), media.Schema, media.Example, media.Examples)
+		}
+	}
+}
+
+// validate records the result of validating example and examples, held by the
+// object at pointer, against proxy. Objects without examples or a schema are
+// skipped.
+func (w *exampleWalker) validate(pointer string, proxy *base.SchemaProxy, example *yaml.Node, examples *orderedmap.Map[string, *base.Example]) {
+	if proxy == nil || (example == nil && (examples == nil || examples.Len() == 0)) {
+		return
+	}
+	schema := proxy.Schema()
+	if schema == nil {
+		return
+	}
+
+	result := &SchemaValidation{
+		SchemaPath:  pointer + "/schema",
+		HasExamples: true,
+		Valid:       true,
+		Issues:      []Issue{},
+	}
+	if example != nil {
+		result.Issues = append(result.Issues, validateExample(schema, example, "example", pointer+"/example", w.validator, w.isOpenAPI30)...)
+	}
+	if examples != nil {
+		for name, named := range examples.FromOldest() {
+			if named == nil || named.Value == nil {
+				continue
+			}
+			path, ok := w.components[named.Value]
+			if !ok {
+				path = pointer + "/examples/" + escapePointer(name) + "/value"
+			}
+			result.Issues = append(result.Issues, validateExample(schema, named.Value, "examples."+name, path, w.validator, w.isOpenAPI30)...)
+		}
+	}
+	result.Valid = !hasErrors(result.Issues)
+	w.results[pointer] = result
+}
+
+// escapePointer escapes a JSON pointer reference token per RFC 6901
+func escapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
@@ -1,12 +1,15 @@
 package validate
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 
+	"github.com/duh-rpc/openapi-schema.go/internal"
 	"github.com/duh-rpc/openapi-schema.go/internal/parser"
-	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi-validator/config"
 	"github.com/pb33f/libopenapi-validator/errors"
 	"github.com/pb33f/libopenapi-validator/schema_validation"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
@@ -16,6 +19,9 @@ import (
 // ExampleValidationResult wraps validation results for export to public API
 type ExampleValidationResult struct {
 	Schemas map[string]*SchemaValidation
+	// Examples holds the results for media type and parameter examples, keyed by
+	// the JSON pointer of the object holding them
+	Examples map[string]*SchemaValidation
 }
 
 // SchemaValidation contains validation details for a single schema
@@ -30,6 +36,8 @@ type SchemaValidation struct {
 type Issue struct {
 	Severity     Severity
 	ExampleField string
+	Path         string // JSON pointer to the failing value, e.g. /components/schemas/User/example/age
+	Class        string // Kind of issue, e.g. ClassTypeMismatch or a keyword such as "minimum"
 	Message      string
 	Line         int
 }
@@ -42,23 +50,61 @@ const (
 	SeverityWarning Severity = "warning"
 )
 
-// ValidateExamples validates examples in OpenAPI spec against schemas
-func ValidateExamples(openapi []byte, schemaNames []string) (*ExampleValidationResult, error) {
-	// Parse raw document for version detection
-	document, err := libopenapi.NewDocument(openapi)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
-	}
+// Issue classes that are not named after the JSON Schema keyword that failed
+const (
+	ClassTypeMismatch       = "type-mismatch"      // the type keyword
+	ClassRequired           = "required"           // a required property is missing
+	ClassAdditionalProperty = "additionalProperty" // the additionalProperties keyword
+	ClassInvalidJSON        = "invalid-json"       // the value is not JSON
+	ClassDecode             = "decode"             // the example could not be decoded
+	ClassOpenAPIVersion     = "openapi-version"    // the OpenAPI 3.0 limitations warning
+	ClassSchema             = "schema"             // any other failure
+)
 
-	version := document.GetVersion()
-	isOpenAPI30 := strings.HasPrefix(version, "3.0")
+// Classes lists every issue class: those above and the JSON Schema keywords
+// issues are named after
+var Classes = []string{
+	ClassTypeMismatch, ClassRequired, ClassAdditionalProperty, ClassInvalidJSON, ClassDecode,
+	ClassOpenAPIVersion, ClassSchema,
+	"enum", "const", "format", "pattern", "minimum", "maximum", "exclusiveMinimum",
+	"exclusiveMaximum", "multipleOf", "minLength", "maxLength", "minItems", "maxItems",
+	"uniqueItems", "contains", "minContains", "maxContains", "minProperties", "maxProperties",
+	"propertyNames", "dependentRequired", "dependentSchemas", "oneOf", "anyOf", "allOf", "not",
+	"if", "then", "else", "prefixItems", "items", "unevaluatedProperties", "unevaluatedItems",
+	"false",
+}
+
+// keywordClass returns the class of a failure at keywordLocation, the JSON
+// pointer of the failing keyword within the schema
+func keywordClass(keywordLocation string) string {
+	keyword := keywordLocation[strings.LastIndex(keywordLocation, "/")+1:]
+	switch keyword {
+	case "type":
+		return ClassTypeMismatch
+	case "additionalProperties":
+		return ClassAdditionalProperty
+	}
+	for _, class := range Classes {
+		if class == keyword {
+			return class
+		}
+	}
+	return ClassSchema
+}
 
-	// Parse schemas via existing parser
+// ValidateExamples validates examples in OpenAPI spec against schemas. When
+// schemaNames is empty, every schema is validated, and so are the examples of
+// media types and parameters under paths. formats holds the validators of custom
+// formats, as NewSchemaValidator takes them.
+func ValidateExamples(ctx context.Context, openapi []byte, schemaNames []string, formats map[string]func(string) error) (*ExampleValidationResult, error) {
+	// Parse once; the version is read from the built model rather than a second parse
 	parsedDoc, err := parser.ParseDocument(openapi)
 	if err != nil {
 		return nil, err
 	}
 
+	isOpenAPI30 := strings.HasPrefix(parsedDoc.Version(), "3.0")
+
 	schemas, err := parsedDoc.Schemas()
 	if err != nil {
 		return nil, err
@@ -82,13 +128,21 @@ func ValidateExamples(openapi []byte, schemaNames []string) (*ExampleValidationR
 	}
 
 	// Create validator
-	validator := schema_validation.NewSchemaValidator()
+	validator := NewSchemaValidator(formats)
 
 	// Validate examples for each schema
 	results := make(map[string]*SchemaValidation)
 	for _, schemaEntry := range targetSchemas {
+		if err := internal.Cancelled(ctx); err != nil {
+			return nil, err
+		}
+
 		schema := schemaEntry.Proxy.Schema()
 		schemaName := schemaEntry.Name
+		if schema == nil {
+			return nil, internal.SchemaError(schemaName, fmt.Sprintf("failed to resolve schema: %v", schemaEntry.Proxy.GetBuildError()))
+		}
+		pointer := "/components/schemas/" + escapePointer(schemaName)
 
 		result := &SchemaValidation{
 			SchemaPath:  schemaName,
@@ -102,6 +156,7 @@ func ValidateExamples(openapi []byte, schemaNames []string) (*ExampleValidationR
 			result.Issues = append(result.Issues, Issue{
 				Severity:     SeverityWarning,
 				ExampleField: "",
+				Class:        ClassOpenAPIVersion,
 				Message:      "OpenAPI 3.0 detected: validation may have limitations due to JSON Schema divergence. OpenAPI 3.1+ recommended for full JSON Schema compliance.",
 				Line:         0,
 			})
@@ -110,7 +165,7 @@ func ValidateExamples(openapi []byte, schemaNames []string) (*ExampleValidationR
 		// Validate singular 'example' field
 		if schema.Example != nil {
 			result.HasExamples = true
-			issues := validateExample(schema, schema.Example, "example", validator, isOpenAPI30)
+			issues := validateExample(schema, schema.Example, "example", pointer+"/example", validator, isOpenAPI30)
 			result.Issues = append(result.Issues, issues...)
 			if hasErrors(issues) {
 				result.Valid = false
@@ -122,7 +177,7 @@ func ValidateExamples(openapi []byte, schemaNames []string) (*ExampleValidationR
 			result.HasExamples = true
 			for i, exampleNode := range schema.Examples {
 				exampleField := fmt.Sprintf("examples[%d]", i)
-				issues := validateExample(schema, exampleNode, exampleField, validator, isOpenAPI30)
+				issues := validateExample(schema, exampleNode, exampleField, fmt.Sprintf("%s/examples/%d", pointer, i), validator, isOpenAPI30)
 				result.Issues = append(result.Issues, issues...)
 				if hasErrors(issues) {
 					result.Valid = false
@@ -133,13 +188,22 @@ func ValidateExamples(openapi []byte, schemaNames []string) (*ExampleValidationR
 		results[schemaName] = result
 	}
 
+	examples := make(map[string]*SchemaValidation)
+	if len(schemaNames) == 0 {
+		examples, err = validateOperationExamples(ctx, parsedDoc, validator, isOpenAPI30)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &ExampleValidationResult{
-		Schemas: results,
+		Schemas:  results,
+		Examples: examples,
 	}, nil
 }
 
 // validateExample validates a single example against a schema
-func validateExample(schema *base.Schema, exampleNode *yaml.Node, exampleField string, validator schema_validation.SchemaValidator, isOpenAPI30 bool) []Issue {
+func validateExample(schema *base.Schema, exampleNode *yaml.Node, exampleField, path string, validator schema_validation.SchemaValidator, isOpenAPI30 bool) []Issue {
 	var issues []Issue
 
 	// Convert yaml.Node to interface{}
@@ -149,6 +213,8 @@ func validateExample(schema *base.Schema, exampleNode *yaml.Node, exampleField s
 		issues = append(issues, Issue{
 			Severity:     SeverityError,
 			ExampleField: exampleField,
+			Path:         path,
+			Class:        ClassDecode,
 			Message:      fmt.Sprintf("failed to decode example: %v", err),
 			Line:         exampleNode.Line,
 		})
@@ -161,30 +227,23 @@ func validateExample(schema *base.Schema, exampleNode *yaml.Node, exampleField s
 		issues = append(issues, Issue{
 			Severity:     SeverityError,
 			ExampleField: exampleField,
+			Path:         path,
+			Class:        ClassDecode,
 			Message:      fmt.Sprintf("failed to marshal example to JSON: %v", err),
 			Line:         exampleNode.Line,
 		})
 		return issues
 	}
 
-	// Validate using version-aware method
-	var valid bool
-	var validationErrors []*errors.ValidationError
-	if isOpenAPI30 {
-		valid, validationErrors = validator.ValidateSchemaStringWithVersion(schema, string(exampleJSON), 3.0)
-	} else {
-		valid, validationErrors = validator.ValidateSchemaString(schema, string(exampleJSON))
-	}
-
-	if !valid {
-		for _, validationError := range validationErrors {
-			issues = append(issues, Issue{
-				Severity:     SeverityError,
-				ExampleField: exampleField,
-				Message:      validationError.Message,
-				Line:         exampleNode.Line,
-			})
-		}
+	for _, violation := range ValidateJSON(validator, schema, exampleJSON, isOpenAPI30) {
+		issues = append(issues, Issue{
+			Severity:     SeverityError,
+			ExampleField: exampleField,
+			Path:         path + violation.Field,
+			Class:        violation.Class,
+			Message:      violation.Message,
+			Line:         exampleNode.Line,
+		})
 	}
 
 	return issues
@@ -199,3 +258,77 @@ func hasErrors(issues []Issue) bool {
 	}
 	return false
 }
+
+// NewSchemaValidator returns a schema validator that also checks the custom
+// formats in formats, by name. Custom formats are only checked with format
+// assertions on, so with any given, the built-in formats are asserted too.
+func NewSchemaValidator(formats map[string]func(string) error) schema_validation.SchemaValidator {
+	if len(formats) == 0 {
+		return schema_validation.NewSchemaValidator()
+	}
+
+	opts := []config.Option{config.WithFormatAssertions()}
+	for name, validate := range formats {
+		opts = append(opts, config.WithCustomFormat(name, func(v any) error {
+			// format applies to strings only
+			if s, ok := v.(string); ok {
+				return validate(s)
+			}
+			return nil
+		}))
+	}
+	return schema_validation.NewSchemaValidator(opts...)
+}
+
+// Violation is one way a JSON document fails its schema
+type Violation struct {
+	Field   string // JSON pointer to the failing value, e.g. "/address/city"; "" for the document
+	Class   string // Kind of violation; see Classes
+	Message string
+}
+
+// ValidateJSON validates the JSON document data against schema, returning nil
+// when it is valid. OpenAPI 3.0 schemas are validated with 3.0 semantics, and
+// strings of format byte, ipv4, ipv6, hostname and idn-hostname are checked
+// even without format assertions.
+func ValidateJSON(validator schema_validation.SchemaValidator, schema *base.Schema, data []byte, isOpenAPI30 bool) []Violation {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return []Violation{{Class: ClassInvalidJSON, Message: "invalid JSON"}}
+	}
+
+	var valid bool
+	var validationErrors []*errors.ValidationError
+	if isOpenAPI30 {
+		valid, validationErrors = validator.ValidateSchemaStringWithVersion(schema, string(data), 3.0)
+	} else {
+		valid, validationErrors = validator.ValidateSchemaString(schema, string(data))
+	}
+
+	formats := formatViolations(schema, value, "")
+	if valid && len(formats) == 0 {
+		return nil
+	}
+
+	violations := []Violation{}
+	for _, validationError := range validationErrors {
+		if len(validationError.SchemaValidationErrors) == 0 {
+			violations = append(violations, Violation{Class: ClassSchema, Message: validationError.Message})
+			continue
+		}
+		for _, failure := range validationError.SchemaValidationErrors {
+			field := ""
+			if len(failure.InstancePath) > 0 {
+				field = "/" + strings.Join(failure.InstancePath, "/")
+			}
+			violations = append(violations, Violation{Field: field, Class: keywordClass(failure.DeepLocation), Message: failure.Reason})
+		}
+	}
+	// With format assertions on, the validator may have reported the value already
+	for _, format := range formats {
+		if !slices.ContainsFunc(violations, func(v Violation) bool { return v.Field == format.Field && v.Class == format.Class }) {
+			violations = append(violations, format)
+		}
+	}
+	return violations
+}
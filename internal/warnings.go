@@ -0,0 +1,64 @@
+package internal
+
+import (
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// Warning codes classify the information a conversion could not carry over.
+const (
+	WarnConstraintDropped = "constraint-dropped"
+	WarnEnumFlattened     = "enum-flattened"
+	WarnFormatUnknown     = "format-unknown"
+)
+
+// Warning reports information lost while converting a schema that did not stop
+// the conversion.
+type Warning struct {
+	Schema  string `json:"schema"`  // top-level component schema the node belongs to
+	Pointer string `json:"pointer"` // JSON pointer to the node in the OpenAPI document
+	Code    string `json:"code"`    // one of the Warn* codes
+	Message string `json:"message"`
+}
+
+// knownStringFormats are string formats whose values survive as a plain string
+var knownStringFormats = map[string]bool{
+	"": true, "email": true, "idn-email": true, "hostname": true, "idn-hostname": true,
+	"ipv4": true, "ipv6": true, "uri": true, "uri-reference": true, "iri": true,
+	"iri-reference": true, "uri-template": true, "uuid": true, "json-pointer": true,
+	"relative-json-pointer": true, "regex": true, "password": true, "time": true,
+	"duration": true,
+}
+
+// IsKnownStringFormat reports whether format is a standard string format that
+// needs no special mapping.
+func IsKnownStringFormat(format string) bool {
+	return knownStringFormats[format]
+}
+
+// DroppedConstraints lists the validation keywords set on schema, in the order
+// they appear in the JSON Schema validation vocabulary. Neither proto3 nor the
+// generated Go structs enforce them.
+func DroppedConstraints(schema *base.Schema) string {
+	var keywords []string
+	add := func(set bool, keyword string) {
+		if set {
+			keywords = append(keywords, keyword)
+		}
+	}
+	add(schema.MultipleOf != nil, "multipleOf")
+	add(schema.Maximum != nil, "maximum")
+	add(schema.ExclusiveMaximum != nil, "exclusiveMaximum")
+	add(schema.Minimum != nil, "minimum")
+	add(schema.ExclusiveMinimum != nil, "exclusiveMinimum")
+	add(schema.MaxLength != nil, "maxLength")
+	add(schema.MinLength != nil, "minLength")
+	add(schema.Pattern != "", "pattern")
+	add(schema.MaxItems != nil, "maxItems")
+	add(schema.MinItems != nil, "minItems")
+	add(schema.UniqueItems != nil && *schema.UniqueItems, "uniqueItems")
+	add(schema.MaxProperties != nil, "maxProperties")
+	add(schema.MinProperties != nil, "minProperties")
+	return strings.Join(keywords, ", ")
+}
@@ -0,0 +1,38 @@
+package internal
+
+import "github.com/pb33f/libopenapi/datamodel/high/base"
+
+// WellKnownTypes opts into mapping OpenAPI constructs that have no direct proto3
+// equivalent onto google.protobuf well-known types.
+type WellKnownTypes struct {
+	Duration bool // string format "duration" → google.protobuf.Duration
+	Struct   bool // free-form objects → google.protobuf.Struct
+	Any      bool // anyOf without a discriminator → google.protobuf.Any
+}
+
+// IsFreeFormObject reports whether schema is an object with no declared
+// properties whose additionalProperties, if present, allows any value.
+func IsFreeFormObject(schema *base.Schema) bool {
+	if !Contains(schema.Type, "object") || (schema.Properties != nil && schema.Properties.Len() > 0) {
+		return false
+	}
+
+	additional := schema.AdditionalProperties
+	switch {
+	case additional == nil:
+		return true
+	case additional.IsB():
+		return additional.B
+	case additional.A != nil:
+		// additionalProperties: {} places no constraint on values
+		s := additional.A.Schema()
+		return s != nil && !additional.A.IsReference() && len(s.Type) == 0 &&
+			(s.Properties == nil || s.Properties.Len() == 0)
+	}
+	return false
+}
+
+// IsUndiscriminatedAnyOf reports whether schema is an anyOf without a discriminator
+func IsUndiscriminatedAnyOf(schema *base.Schema) bool {
+	return len(schema.AnyOf) > 0 && (schema.Discriminator == nil || schema.Discriminator.PropertyName == "")
+}
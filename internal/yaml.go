@@ -0,0 +1,143 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// ClearStyle resets the style of every node so JSON-sourced content renders as
+// plain block YAML; the encoder re-applies quoting wherever a scalar requires it.
+func ClearStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		ClearStyle(child)
+	}
+}
+
+// ClearFlowStyle converts flow collections (as produced by JSON input) to block style
+// while leaving scalar quoting untouched.
+func ClearFlowStyle(node *yaml.Node) {
+	node.Style &^= yaml.FlowStyle
+	for _, child := range node.Content {
+		ClearFlowStyle(child)
+	}
+}
+
+// MappingValue returns the value node for key in a mapping node, or nil
+func MappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// SetMappingValue replaces the value for key in a mapping node, appending the key
+// when it is not already present
+func SetMappingValue(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append(node.Content, StringNode(key), value)
+}
+
+// StringNode returns a plain string scalar node
+func StringNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+// EncodeYAML renders node with two-space indentation
+func EncodeYAML(node *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(node); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// EncodeJSON renders node as two-space indented JSON, keeping mapping key order.
+// Scalars tagged !!int, !!float, !!bool and !!null become JSON literals; every
+// other scalar becomes a string.
+func EncodeJSON(node *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, node); err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, buf.Bytes(), "", "  "); err != nil {
+		return nil, fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	out.WriteByte('\n')
+	return out.Bytes(), nil
+}
+
+// writeJSON appends the compact JSON form of node to buf
+func writeJSON(buf *bytes.Buffer, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			buf.WriteString("null")
+			return nil
+		}
+		return writeJSON(buf, node.Content[0])
+	case yaml.AliasNode:
+		return writeJSON(buf, node.Alias)
+	case yaml.MappingNode:
+		buf.WriteByte('{')
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, _ := json.Marshal(node.Content[i].Value)
+			buf.Write(key)
+			buf.WriteByte(':')
+			if err := writeJSON(buf, node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+		for i, item := range node.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSON(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case yaml.ScalarNode:
+		var value any = node.Value
+		switch node.ShortTag() {
+		case "!!int", "!!float", "!!bool", "!!null":
+			if err := node.Decode(&value); err != nil {
+				return fmt.Errorf("failed to encode JSON: %w", err)
+			}
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		buf.Write(encoded)
+	default:
+		return fmt.Errorf("failed to encode JSON: unexpected YAML node kind %d", node.Kind)
+	}
+	return nil
+}
@@ -0,0 +1,296 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/duh-rpc/openapi-schema.go/internal/parser"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"go.yaml.in/yaml/v4"
+)
+
+// inventoryDepth bounds how deep the properties of inline objects are listed,
+// as YAML aliases can nest an inline schema within itself
+const inventoryDepth = 10
+
+// InventoryConstraints lists the validation keywords an inventory records, in
+// the order of the CSV columns.
+var InventoryConstraints = []string{
+	"enum", "const", "default",
+	"minimum", "exclusiveMinimum", "maximum", "exclusiveMaximum", "multipleOf",
+	"minLength", "maxLength", "pattern",
+	"minItems", "maxItems", "uniqueItems",
+	"minProperties", "maxProperties",
+	"readOnly", "writeOnly", "deprecated",
+}
+
+// InventoryOptions configures ExportInventory.
+type InventoryOptions struct {
+	// Source fills InventoryRow.Source, naming the spec the rows come from so
+	// the inventories of many specs can be concatenated.
+	Source string
+}
+
+// Inventory lists the schemas and properties of a spec, for auditing model
+// coverage and constraint usage. Marshal it with encoding/json, or write it as
+// CSV with CSV.
+type Inventory struct {
+	Rows []InventoryRow `json:"rows"`
+}
+
+// InventoryRow is a component schema, when Property is empty, or one of its
+// properties.
+type InventoryRow struct {
+	Source string `json:"source,omitempty"`
+	Schema string `json:"schema"`
+	// Property is the path to the property within Schema: its name, or for the
+	// properties of inline objects the names joined with dots, with [] for array
+	// items (shipping.street, items[].sku). Empty for the schema itself.
+	Property string `json:"property,omitempty"`
+	// Type is the JSON type, or types joined with |, leaving out null; oneOf,
+	// anyOf or allOf for compositions. Referenced schemas give their own type.
+	Type   string `json:"type,omitempty"`
+	Format string `json:"format,omitempty"`
+	// Items is the type of array items, or the schema they reference
+	Items string `json:"items,omitempty"`
+	// Reference is the component schema a property references
+	Reference string `json:"reference,omitempty"`
+	Required  bool   `json:"required,omitempty"`
+	Nullable  bool   `json:"nullable,omitempty"`
+	// Location is where Convert generates the schema, as in TypeMap
+	Location TypeLocation `json:"location"`
+	// Reason explains a golang Location, on schema rows only
+	Reason string `json:"reason,omitempty"`
+	// Constraints maps the InventoryConstraints keywords the schema sets to
+	// their values; enum, const and default values are written as JSON
+	Constraints map[string]string `json:"constraints,omitempty"`
+}
+
+// ExportInventory lists every component schema and property of openapi with
+// its type, format, constraints and where Convert generates it, so platform
+// teams can audit model coverage and constraint usage across many specs. As
+// with AnalyzeDependencies, schemas Convert would reject still appear.
+func ExportInventory(openapi []byte, opts InventoryOptions) (_ *Inventory, err error) {
+	defer internal.Recover("ExportInventory", &err)
+
+	if len(openapi) == 0 {
+		return nil, fmt.Errorf("openapi input cannot be empty")
+	}
+
+	doc, err := parser.ParseDocument(openapi)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas, err := doc.Schemas()
+	if err != nil {
+		return nil, err
+	}
+
+	dependencies, err := analyzeDependencies(schemas)
+	if err != nil {
+		return nil, err
+	}
+
+	inventory := &Inventory{Rows: []InventoryRow{}}
+	for i, entry := range schemas {
+		node := dependencies.Nodes[i]
+		row := InventoryRow{Source: opts.Source, Schema: entry.Name, Location: node.Location, Reason: node.Reason}
+		schema := entry.Proxy.Schema()
+		if schema == nil {
+			inventory.Rows = append(inventory.Rows, row)
+			continue
+		}
+		describeInventoryRow(&row, schema)
+		inventory.Rows = append(inventory.Rows, row)
+		inventory.properties(InventoryRow{Source: opts.Source, Schema: entry.Name, Location: node.Location}, "", schema, 0)
+	}
+	return inventory, nil
+}
+
+// properties adds a row per property of schema, followed by the rows of its
+// inline objects, prefixing names with the path to them
+func (inv *Inventory) properties(parent InventoryRow, prefix string, schema *base.Schema, depth int) {
+	if schema.Properties == nil {
+		return
+	}
+	for propName, propProxy := range schema.Properties.FromOldest() {
+		row := parent
+		row.Property = prefix + propName
+		row.Required = slices.Contains(schema.Required, propName)
+		if propProxy.IsReference() {
+			row.Reference, _ = internal.ExtractReferenceName(propProxy.GetReference())
+		}
+		propSchema := propProxy.Schema()
+		if propSchema == nil {
+			inv.Rows = append(inv.Rows, row)
+			continue
+		}
+		describeInventoryRow(&row, propSchema)
+		if propProxy.IsReference() {
+			// The constraints of a referenced schema are counted on its own row
+			row.Constraints = nil
+		}
+		inv.Rows = append(inv.Rows, row)
+
+		if propProxy.IsReference() || depth >= inventoryDepth {
+			continue
+		}
+		path := row.Property
+		if items := propSchema.Items; slices.Contains(propSchema.Type, "array") && items != nil && items.IsA() && items.A != nil && !items.A.IsReference() {
+			propSchema, path = items.A.Schema(), path+"[]"
+		}
+		if propSchema != nil {
+			inv.properties(parent, path+".", propSchema, depth+1)
+		}
+	}
+}
+
+// describeInventoryRow fills the type, format, nullability and constraints of
+// row from schema
+func describeInventoryRow(row *InventoryRow, schema *base.Schema) {
+	row.Format = schema.Format
+	row.Nullable = (schema.Nullable != nil && *schema.Nullable) || slices.Contains(schema.Type, "null")
+
+	switch {
+	case len(schema.OneOf) > 0:
+		row.Type = "oneOf"
+	case len(schema.AnyOf) > 0:
+		row.Type = "anyOf"
+	case len(schema.AllOf) > 0:
+		row.Type = "allOf"
+	default:
+		var types []string
+		for _, t := range schema.Type {
+			if t != "null" {
+				types = append(types, t)
+			}
+		}
+		row.Type = strings.Join(types, "|")
+		if row.Type == "" && schema.Properties != nil && schema.Properties.Len() > 0 {
+			row.Type = "object"
+		}
+	}
+
+	if items := schema.Items; items != nil && items.IsA() && items.A != nil {
+		if items.A.IsReference() {
+			row.Items, _ = internal.ExtractReferenceName(items.A.GetReference())
+		} else if itemSchema := items.A.Schema(); itemSchema != nil {
+			var item InventoryRow
+			describeInventoryRow(&item, itemSchema)
+			row.Items = item.Type
+		}
+	}
+
+	row.Constraints = inventoryConstraints(schema)
+}
+
+// inventoryConstraints returns the InventoryConstraints keywords schema sets;
+// nil when it sets none
+func inventoryConstraints(schema *base.Schema) map[string]string {
+	constraints := map[string]string{}
+	number := func(keyword string, value *float64) {
+		if value != nil {
+			constraints[keyword] = strconv.FormatFloat(*value, 'f', -1, 64)
+		}
+	}
+	integer := func(keyword string, value *int64) {
+		if value != nil {
+			constraints[keyword] = strconv.FormatInt(*value, 10)
+		}
+	}
+	flag := func(keyword string, value *bool) {
+		if value != nil && *value {
+			constraints[keyword] = "true"
+		}
+	}
+	exclusive := func(keyword string, value *base.DynamicValue[bool, float64]) {
+		switch {
+		case value == nil:
+		case value.IsB():
+			number(keyword, &value.B)
+		case value.A:
+			constraints[keyword] = "true"
+		}
+	}
+
+	if len(schema.Enum) > 0 {
+		constraints["enum"] = inventoryJSON(&yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: schema.Enum})
+	}
+	if schema.Const != nil {
+		constraints["const"] = inventoryJSON(schema.Const)
+	}
+	if schema.Default != nil {
+		constraints["default"] = inventoryJSON(schema.Default)
+	}
+	number("minimum", schema.Minimum)
+	exclusive("exclusiveMinimum", schema.ExclusiveMinimum)
+	number("maximum", schema.Maximum)
+	exclusive("exclusiveMaximum", schema.ExclusiveMaximum)
+	number("multipleOf", schema.MultipleOf)
+	integer("minLength", schema.MinLength)
+	integer("maxLength", schema.MaxLength)
+	if schema.Pattern != "" {
+		constraints["pattern"] = schema.Pattern
+	}
+	integer("minItems", schema.MinItems)
+	integer("maxItems", schema.MaxItems)
+	flag("uniqueItems", schema.UniqueItems)
+	integer("minProperties", schema.MinProperties)
+	integer("maxProperties", schema.MaxProperties)
+	flag("readOnly", schema.ReadOnly)
+	flag("writeOnly", schema.WriteOnly)
+	flag("deprecated", schema.Deprecated)
+
+	if len(constraints) == 0 {
+		return nil
+	}
+	return constraints
+}
+
+// inventoryJSON returns a YAML value as compact JSON, or as written when it has
+// no JSON form
+func inventoryJSON(node *yaml.Node) string {
+	var decoded interface{}
+	if err := node.Decode(&decoded); err != nil {
+		return node.Value
+	}
+	encoded, err := json.Marshal(decoded)
+	if err != nil {
+		return node.Value
+	}
+	return string(encoded)
+}
+
+// CSV writes the inventory as CSV with a header row: source, schema, property,
+// type, format, items, reference, required, nullable, location and reason,
+// then one column per InventoryConstraints keyword.
+func (inv *Inventory) CSV() ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	header := []string{"source", "schema", "property", "type", "format", "items", "reference", "required", "nullable", "location", "reason"}
+	if err := w.Write(append(header, InventoryConstraints...)); err != nil {
+		return nil, err
+	}
+	for _, row := range inv.Rows {
+		record := []string{
+			row.Source, row.Schema, row.Property, row.Type, row.Format, row.Items, row.Reference,
+			strconv.FormatBool(row.Required), strconv.FormatBool(row.Nullable), string(row.Location), row.Reason,
+		}
+		for _, keyword := range InventoryConstraints {
+			record = append(record, row.Constraints[keyword])
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
@@ -0,0 +1,125 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const inventorySpec = `openapi: 3.1.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Order:
+      type: object
+      required: [id, status]
+      properties:
+        id:
+          type: string
+          format: uuid
+          readOnly: true
+        status:
+          $ref: '#/components/schemas/Status'
+        quantity:
+          type: integer
+          minimum: 1
+          exclusiveMaximum: 100
+          default: 1
+        note:
+          type: [string, "null"]
+          maxLength: 200
+          pattern: '^[a-z, ]*$'
+        items:
+          type: array
+          minItems: 1
+          items:
+            type: object
+            properties:
+              sku:
+                type: string
+        tags:
+          type: array
+          items:
+            $ref: '#/components/schemas/Tag'
+    Status:
+      type: string
+      enum: [pending, shipped]
+    Tag:
+      type: string
+    Payment:
+      oneOf:
+        - $ref: '#/components/schemas/Order'
+        - $ref: '#/components/schemas/Refund'
+      discriminator:
+        propertyName: kind
+    Refund:
+      type: object
+      properties:
+        amount:
+          type: number
+`
+
+func TestExportInventory(t *testing.T) {
+	inventory, err := schema.ExportInventory([]byte(inventorySpec), schema.InventoryOptions{Source: "orders.yaml"})
+	require.NoError(t, err)
+
+	order := func(property string, row schema.InventoryRow) schema.InventoryRow {
+		row.Source, row.Schema, row.Property, row.Location = "orders.yaml", "Order", property, schema.TypeLocationGolang
+		return row
+	}
+	assert.Equal(t, []schema.InventoryRow{
+		order("", schema.InventoryRow{Type: "object", Reason: "variant of union type Payment"}),
+		order("id", schema.InventoryRow{Type: "string", Format: "uuid", Required: true, Constraints: map[string]string{"readOnly": "true"}}),
+		order("status", schema.InventoryRow{Type: "string", Reference: "Status", Required: true}),
+		order("quantity", schema.InventoryRow{Type: "integer", Constraints: map[string]string{"minimum": "1", "exclusiveMaximum": "100", "default": "1"}}),
+		order("note", schema.InventoryRow{Type: "string", Nullable: true, Constraints: map[string]string{"maxLength": "200", "pattern": "^[a-z, ]*$"}}),
+		order("items", schema.InventoryRow{Type: "array", Items: "object", Constraints: map[string]string{"minItems": "1"}}),
+		order("items[].sku", schema.InventoryRow{Type: "string"}),
+		order("tags", schema.InventoryRow{Type: "array", Items: "Tag"}),
+		{Source: "orders.yaml", Schema: "Status", Type: "string", Location: schema.TypeLocationProto, Constraints: map[string]string{"enum": `["pending","shipped"]`}},
+		{Source: "orders.yaml", Schema: "Tag", Type: "string", Location: schema.TypeLocationProto},
+		{Source: "orders.yaml", Schema: "Payment", Type: "oneOf", Location: schema.TypeLocationGolang, Reason: "contains oneOf"},
+		{Source: "orders.yaml", Schema: "Refund", Type: "object", Location: schema.TypeLocationGolang, Reason: "variant of union type Payment"},
+		{Source: "orders.yaml", Schema: "Refund", Property: "amount", Type: "number", Location: schema.TypeLocationGolang},
+	}, inventory.Rows)
+}
+
+func TestExportInventoryCSV(t *testing.T) {
+	inventory, err := schema.ExportInventory([]byte(inventorySpec), schema.InventoryOptions{})
+	require.NoError(t, err)
+
+	data, err := inventory.CSV()
+	require.NoError(t, err)
+	assert.Equal(t, `source,schema,property,type,format,items,reference,required,nullable,location,reason,enum,const,default,minimum,exclusiveMinimum,maximum,exclusiveMaximum,multipleOf,minLength,maxLength,pattern,minItems,maxItems,uniqueItems,minProperties,maxProperties,readOnly,writeOnly,deprecated
+,Order,,object,,,,false,false,golang,variant of union type Payment,,,,,,,,,,,,,,,,,,,
+,Order,id,string,uuid,,,true,false,golang,,,,,,,,,,,,,,,,,,true,,
+,Order,status,string,,,Status,true,false,golang,,,,,,,,,,,,,,,,,,,,
+,Order,quantity,integer,,,,false,false,golang,,,,1,1,,,100,,,,,,,,,,,,
+,Order,note,string,,,,false,true,golang,,,,,,,,,,,200,"^[a-z, ]*$",,,,,,,,
+,Order,items,array,,object,,false,false,golang,,,,,,,,,,,,,1,,,,,,,
+,Order,items[].sku,string,,,,false,false,golang,,,,,,,,,,,,,,,,,,,,
+,Order,tags,array,,Tag,,false,false,golang,,,,,,,,,,,,,,,,,,,,
+,Status,,string,,,,false,false,proto,,"[""pending"",""shipped""]",,,,,,,,,,,,,,,,,,
+,Tag,,string,,,,false,false,proto,,,,,,,,,,,,,,,,,,,,
+,Payment,,oneOf,,,,false,false,golang,contains oneOf,,,,,,,,,,,,,,,,,,,
+,Refund,,object,,,,false,false,golang,variant of union type Payment,,,,,,,,,,,,,,,,,,,
+,Refund,amount,number,,,,false,false,golang,,,,,,,,,,,,,,,,,,,,
+`, string(data))
+
+	encoded, err := json.Marshal(inventory.Rows[1])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"schema":"Order","property":"id","type":"string","format":"uuid","required":true,"location":"golang","constraints":{"readOnly":"true"}}`, string(encoded))
+}
+
+func TestExportInventoryErrors(t *testing.T) {
+	_, err := schema.ExportInventory(nil, schema.InventoryOptions{})
+	require.ErrorContains(t, err, "openapi input cannot be empty")
+
+	_, err = schema.ExportInventory([]byte("openapi: 2.0.0\n"), schema.InventoryOptions{})
+	require.Error(t, err)
+}
@@ -0,0 +1,189 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"go.yaml.in/yaml/v4"
+)
+
+// jsonSchemaDialect is the JSON Schema draft ConvertJSONSchema accepts
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// jsonSchemaDocument hosts a JSON Schema's definitions as component schemas.
+// OpenAPI 3.1 schemas are JSON Schema 2020-12, so no keyword needs translating.
+const jsonSchemaDocument = `openapi: 3.1.0
+info:
+  title: json schema
+  version: 0.0.0
+paths: {}
+components:
+  schemas: {}
+`
+
+// jsonSchemaDocumentKeys are root keywords about the document, left out of the
+// root schema
+var jsonSchemaDocumentKeys = map[string]bool{
+	"$schema": true, "$id": true, "$defs": true, "definitions": true,
+}
+
+// jsonSchemaAnnotations are root keywords kept on the root schema that do not on
+// their own make it describe a value
+var jsonSchemaAnnotations = map[string]bool{
+	"title": true, "description": true, "$comment": true,
+}
+
+// ConvertJSONSchema converts a standalone JSON Schema (draft 2020-12, YAML or
+// JSON) as Convert converts an OpenAPI document. Each entry of $defs (or the
+// older definitions) becomes a message or struct of the same name. The root
+// schema becomes one too when it describes a value, not just definitions; it is
+// named by its title, with spaces, hyphens and underscores read as word breaks
+// ("User profile" → UserProfile).
+//
+// References must stay within the document: #/$defs/Name, #/definitions/Name,
+// or # for the root schema. A $schema other than draft 2020-12 is rejected.
+func ConvertJSONSchema(jsonSchema []byte, opts ConvertOptions) (*ConvertResult, error) {
+	return ConvertJSONSchemaContext(context.Background(), jsonSchema, opts)
+}
+
+// ConvertJSONSchemaContext is like ConvertJSONSchema but stops early with an
+// error wrapping ctx.Err() when ctx is cancelled or its deadline expires.
+func ConvertJSONSchemaContext(ctx context.Context, jsonSchema []byte, opts ConvertOptions) (_ *ConvertResult, err error) {
+	defer internal.Recover("ConvertJSONSchema", &err)
+
+	if len(jsonSchema) == 0 {
+		return nil, fmt.Errorf("json schema input cannot be empty")
+	}
+
+	openapi, err := jsonSchemaToOpenAPI(jsonSchema)
+	if err != nil {
+		return nil, err
+	}
+	return ConvertContext(ctx, openapi, opts)
+}
+
+// jsonSchemaToOpenAPI hoists a JSON Schema's definitions, and its root schema
+// when it has one, into the components of an OpenAPI 3.1 document, rewriting
+// references to match
+func jsonSchemaToOpenAPI(jsonSchema []byte) ([]byte, error) {
+	var parsed yaml.Node
+	if err := yaml.Unmarshal(jsonSchema, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON Schema: %w", err)
+	}
+	if len(parsed.Content) == 0 || parsed.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("JSON Schema must be a YAML or JSON object")
+	}
+	root := parsed.Content[0]
+
+	if dialect := internal.MappingValue(root, "$schema"); dialect != nil &&
+		strings.TrimSuffix(dialect.Value, "#") != jsonSchemaDialect {
+		return nil, fmt.Errorf("unsupported $schema '%s': must be %s", dialect.Value, jsonSchemaDialect)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(jsonSchemaDocument), &doc); err != nil {
+		return nil, err
+	}
+	schemas := internal.MappingValue(internal.MappingValue(doc.Content[0], "components"), "schemas")
+
+	for _, key := range []string{"$defs", "definitions"} {
+		defs := internal.MappingValue(root, key)
+		if defs == nil {
+			continue
+		}
+		if defs.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("%s must be a map of names to schemas", key)
+		}
+		for i := 0; i+1 < len(defs.Content); i += 2 {
+			name := defs.Content[i].Value
+			if internal.MappingValue(schemas, name) != nil {
+				return nil, fmt.Errorf("definition '%s' is declared in both $defs and definitions", name)
+			}
+			internal.SetMappingValue(schemas, name, defs.Content[i+1])
+		}
+	}
+
+	// The root is a schema in its own right when it has keywords beyond metadata
+	rootSchema := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	hasRoot := false
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key := root.Content[i].Value
+		if jsonSchemaDocumentKeys[key] {
+			continue
+		}
+		if !jsonSchemaAnnotations[key] {
+			hasRoot = true
+		}
+		rootSchema.Content = append(rootSchema.Content, root.Content[i], root.Content[i+1])
+	}
+
+	var rootName string
+	if hasRoot {
+		title := internal.MappingValue(root, "title")
+		if title == nil || titleName(title.Value) == "" {
+			return nil, fmt.Errorf("root schema requires a title to name it")
+		}
+		rootName = titleName(title.Value)
+		if internal.MappingValue(schemas, rootName) != nil {
+			return nil, fmt.Errorf("root schema '%s' conflicts with a definition of the same name", rootName)
+		}
+		internal.SetMappingValue(schemas, rootName, rootSchema)
+	}
+
+	if err := rewriteJSONSchemaRefs(schemas, rootName); err != nil {
+		return nil, err
+	}
+	internal.ClearFlowStyle(&doc)
+
+	return internal.EncodeYAML(&doc)
+}
+
+// titleName joins the words of a title into a PascalCase schema name, splitting
+// on spaces, hyphens and underscores: "User profile" → UserProfile
+func titleName(title string) string {
+	var name strings.Builder
+	for _, word := range strings.FieldsFunc(title, func(r rune) bool {
+		return r == ' ' || r == '-' || r == '_'
+	}) {
+		name.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return name.String()
+}
+
+// rewriteJSONSchemaRefs points every $ref under node at components/schemas.
+// rootName is the component holding the root schema, "" when there is none.
+func rewriteJSONSchemaRefs(node *yaml.Node, rootName string) error {
+	if node.Kind == yaml.MappingNode {
+		if ref := internal.MappingValue(node, "$ref"); ref != nil {
+			target, err := jsonSchemaRefTarget(ref.Value, rootName)
+			if err != nil {
+				return err
+			}
+			ref.Value = "#/components/schemas/" + target
+		}
+	}
+	for _, child := range node.Content {
+		if err := rewriteJSONSchemaRefs(child, rootName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jsonSchemaRefTarget returns the component a JSON Schema reference names
+func jsonSchemaRefTarget(ref, rootName string) (string, error) {
+	if ref == "#" {
+		if rootName == "" {
+			return "", fmt.Errorf("$ref '#' refers to the root schema, which only holds definitions")
+		}
+		return rootName, nil
+	}
+	for _, prefix := range []string{"#/$defs/", "#/definitions/"} {
+		if name, ok := strings.CutPrefix(ref, prefix); ok && name != "" && !strings.Contains(name, "/") {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported $ref '%s': only #, #/$defs/Name and #/definitions/Name are supported", ref)
+}
@@ -0,0 +1,321 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/duh-rpc/openapi-schema.go/internal/parser"
+	"go.yaml.in/yaml/v4"
+)
+
+// JSONSchemaOptions configures ConvertToJSONSchema.
+type JSONSchemaOptions struct {
+	// SchemaNames limits output to the named component schemas. Empty → every
+	// schema. Naming a schema that does not exist is an error.
+	SchemaNames []string
+	// BaseURI, when set, gives each file an $id of BaseURI + name + ".json",
+	// e.g. "https://example.com/schemas/" → https://example.com/schemas/User.json.
+	BaseURI string
+}
+
+// JSONSchemaResult holds the files generated by ConvertToJSONSchema.
+type JSONSchemaResult struct {
+	Schemas []*JSONSchemaFile // In document order
+}
+
+// JSONSchemaFile is one component schema as a standalone JSON Schema document.
+type JSONSchemaFile struct {
+	Name   string // Component schema name
+	Schema []byte // JSON Schema (draft 2020-12) as indented JSON
+}
+
+// subschemaShape is how a keyword holds subschemas
+type subschemaShape int
+
+const (
+	subschemaSingle subschemaShape = iota // a schema
+	subschemaMap                          // a map of names to schemas
+	subschemaList                         // a list of schemas
+)
+
+// schemaKeywords are the keywords holding subschemas
+var schemaKeywords = map[string]subschemaShape{
+	"items": subschemaSingle, "additionalProperties": subschemaSingle, "not": subschemaSingle,
+	"contains": subschemaSingle, "propertyNames": subschemaSingle, "if": subschemaSingle,
+	"then": subschemaSingle, "else": subschemaSingle, "unevaluatedItems": subschemaSingle,
+	"unevaluatedProperties": subschemaSingle,
+	"properties":            subschemaMap, "patternProperties": subschemaMap,
+	"dependentSchemas": subschemaMap, "$defs": subschemaMap,
+	"allOf": subschemaList, "anyOf": subschemaList, "oneOf": subschemaList,
+	"prefixItems": subschemaList,
+}
+
+// openAPIOnlyKeywords are schema keywords JSON Schema validators do not know
+var openAPIOnlyKeywords = map[string]bool{
+	"discriminator": true, "xml": true, "externalDocs": true,
+}
+
+// ConvertToJSONSchema exports each component schema as a standalone JSON Schema
+// (draft 2020-12) document, for validators such as ajv in non-Go clients. The
+// schemas a component references, directly or transitively, are copied into
+// its $defs, so every file validates on its own.
+//
+// OpenAPI-only constructs are translated: nullable: true adds "null" to the
+// type, boolean exclusiveMinimum/exclusiveMaximum take the bound's value,
+// example becomes examples, and discriminator, xml, externalDocs and x-
+// extensions are dropped.
+func ConvertToJSONSchema(openapi []byte, opts JSONSchemaOptions) (*JSONSchemaResult, error) {
+	return ConvertToJSONSchemaContext(context.Background(), openapi, opts)
+}
+
+// ConvertToJSONSchemaContext is like ConvertToJSONSchema but stops early with
+// an error wrapping ctx.Err() when ctx is cancelled or its deadline expires.
+// Cancellation is checked before parsing and between schemas.
+func ConvertToJSONSchemaContext(ctx context.Context, openapi []byte, opts JSONSchemaOptions) (_ *JSONSchemaResult, err error) {
+	defer internal.Recover("ConvertToJSONSchema", &err)
+
+	if len(openapi) == 0 {
+		return nil, fmt.Errorf("openapi input cannot be empty")
+	}
+
+	if err := internal.Cancelled(ctx); err != nil {
+		return nil, err
+	}
+
+	// Parsing the model validates the document; the schemas are copied from its YAML
+	doc, err := parser.ParseDocument(openapi)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := doc.Schemas()
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.SchemaNames) > 0 {
+		if _, err := parser.SelectSchemas(entries, opts.SchemaNames); err != nil {
+			return nil, err
+		}
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(openapi, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+	components := internal.MappingValue(internal.MappingValue(root.Content[0], "components"), "schemas")
+
+	nodes := make(map[string]*yaml.Node, len(entries))
+	for i := 0; components != nil && i+1 < len(components.Content); i += 2 {
+		nodes[components.Content[i].Value] = components.Content[i+1]
+	}
+
+	names := opts.SchemaNames
+	if len(names) == 0 {
+		for _, entry := range entries {
+			names = append(names, entry.Name)
+		}
+	}
+
+	result := &JSONSchemaResult{Schemas: make([]*JSONSchemaFile, 0, len(names))}
+	for _, entry := range entries {
+		if !slices.Contains(names, entry.Name) {
+			continue
+		}
+		if err := internal.Cancelled(ctx); err != nil {
+			return nil, err
+		}
+
+		content, err := exportJSONSchema(entry.Name, nodes, entries, opts.BaseURI)
+		if err != nil {
+			return nil, fmt.Errorf("schema '%s': %w", entry.Name, err)
+		}
+		result.Schemas = append(result.Schemas, &JSONSchemaFile{Name: entry.Name, Schema: content})
+	}
+	return result, nil
+}
+
+// exportJSONSchema renders component name as a JSON Schema document, with the
+// components it references in $defs in document order
+func exportJSONSchema(name string, nodes map[string]*yaml.Node, entries []*parser.SchemaEntry, baseURI string) ([]byte, error) {
+	// Collect the components reachable from name
+	reachable := map[string]bool{name: true}
+	queue := []string{name}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, ref := range componentRefs(nodes[current], nil) {
+			if nodes[ref] == nil {
+				return nil, fmt.Errorf("reference to unknown schema '%s'", ref)
+			}
+			if !reachable[ref] {
+				reachable[ref] = true
+				queue = append(queue, ref)
+			}
+		}
+	}
+
+	out := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	internal.SetMappingValue(out, "$schema", internal.StringNode(jsonSchemaDialect))
+	if baseURI != "" {
+		internal.SetMappingValue(out, "$id", internal.StringNode(baseURI+name+".json"))
+	}
+
+	body := toJSONSchema(nodes[name], name)
+	if body.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("schema must be an object")
+	}
+	out.Content = append(out.Content, body.Content...)
+
+	defs := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for _, entry := range entries {
+		if entry.Name != name && reachable[entry.Name] {
+			internal.SetMappingValue(defs, entry.Name, toJSONSchema(nodes[entry.Name], name))
+		}
+	}
+	if len(defs.Content) > 0 {
+		internal.SetMappingValue(out, "$defs", defs)
+	}
+
+	return internal.EncodeJSON(out)
+}
+
+// toJSONSchema returns a translated copy of the OpenAPI schema node. References
+// to root become "#" and other component references point into $defs.
+func toJSONSchema(node *yaml.Node, root string) *yaml.Node {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind != yaml.MappingNode {
+		return copyNode(node)
+	}
+
+	out := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	var nullable bool
+	var example *yaml.Node
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i].Value, node.Content[i+1]
+		switch {
+		case strings.HasPrefix(key, "x-") || openAPIOnlyKeywords[key]:
+			continue
+		case key == "nullable":
+			nullable = value.Value == "true"
+			continue
+		case key == "example":
+			example = value
+			continue
+		case key == "$ref":
+			target := value.Value
+			if name, ok := strings.CutPrefix(target, "#/components/schemas/"); ok {
+				target = "#/$defs/" + name
+				if name == root {
+					target = "#"
+				}
+			}
+			value = internal.StringNode(target)
+		default:
+			value = translateSubschemas(key, value, root)
+		}
+		out.Content = append(out.Content, internal.StringNode(key), value)
+	}
+
+	if example != nil && internal.MappingValue(out, "examples") == nil {
+		internal.SetMappingValue(out, "examples", &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq",
+			Content: []*yaml.Node{copyNode(example)}})
+	}
+	for _, bound := range [][2]string{{"exclusiveMinimum", "minimum"}, {"exclusiveMaximum", "maximum"}} {
+		exclusiveBound(out, bound[0], bound[1])
+	}
+	if nullable {
+		return nullableSchema(out)
+	}
+	return out
+}
+
+// translateSubschemas translates the subschemas held by keyword's value, and
+// copies values of other keywords unchanged
+func translateSubschemas(keyword string, value *yaml.Node, root string) *yaml.Node {
+	shape, ok := schemaKeywords[keyword]
+	if !ok {
+		return copyNode(value)
+	}
+	switch {
+	case shape == subschemaMap && value.Kind == yaml.MappingNode:
+		out := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		for i := 0; i+1 < len(value.Content); i += 2 {
+			out.Content = append(out.Content, copyNode(value.Content[i]), toJSONSchema(value.Content[i+1], root))
+		}
+		return out
+	case shape == subschemaList && value.Kind == yaml.SequenceNode:
+		out := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, item := range value.Content {
+			out.Content = append(out.Content, toJSONSchema(item, root))
+		}
+		return out
+	}
+	return toJSONSchema(value, root)
+}
+
+// exclusiveBound rewrites OpenAPI 3.0's boolean exclusive bound into the
+// numeric form, taking the value of the inclusive bound it modified
+func exclusiveBound(schema *yaml.Node, exclusive, inclusive string) {
+	flag := internal.MappingValue(schema, exclusive)
+	if flag == nil || flag.ShortTag() != "!!bool" {
+		return
+	}
+	limit := internal.MappingValue(schema, inclusive)
+
+	kept := schema.Content[:0]
+	for i := 0; i+1 < len(schema.Content); i += 2 {
+		key := schema.Content[i].Value
+		if key == exclusive || (key == inclusive && flag.Value == "true") {
+			continue
+		}
+		kept = append(kept, schema.Content[i], schema.Content[i+1])
+	}
+	schema.Content = kept
+	if flag.Value == "true" && limit != nil {
+		internal.SetMappingValue(schema, exclusive, limit)
+	}
+}
+
+// nullableSchema admits null in schema: "null" joins its type and enum, or,
+// without a type, the schema becomes one alternative of an anyOf
+func nullableSchema(schema *yaml.Node) *yaml.Node {
+	null := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+	if enum := internal.MappingValue(schema, "enum"); enum != nil && enum.Kind == yaml.SequenceNode {
+		enum.Content = append(enum.Content, null)
+	}
+
+	typ := internal.MappingValue(schema, "type")
+	switch {
+	case typ == nil:
+		alternative := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		internal.SetMappingValue(alternative, "type", internal.StringNode("null"))
+		wrapped := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		internal.SetMappingValue(wrapped, "anyOf", &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq",
+			Content: []*yaml.Node{schema, alternative}})
+		return wrapped
+	case typ.Kind == yaml.SequenceNode:
+		typ.Content = append(typ.Content, internal.StringNode("null"))
+	default:
+		internal.SetMappingValue(schema, "type", &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq",
+			Content: []*yaml.Node{internal.StringNode(typ.Value), internal.StringNode("null")}})
+	}
+	return schema
+}
+
+// copyNode returns a deep copy of node, resolving aliases
+func copyNode(node *yaml.Node) *yaml.Node {
+	if node.Kind == yaml.AliasNode {
+		return copyNode(node.Alias)
+	}
+	out := *node
+	out.Anchor = ""
+	out.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		out.Content[i] = copyNode(child)
+	}
+	return &out
+}
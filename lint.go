@@ -0,0 +1,187 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/duh-rpc/openapi-schema.go/internal/golang"
+	"github.com/duh-rpc/openapi-schema.go/internal/parser"
+	"github.com/duh-rpc/openapi-schema.go/internal/proto"
+)
+
+// LintOptions configures Lint. The fields mirror the ConvertOptions that change
+// which schemas are valid.
+type LintOptions struct {
+	// FieldNumbers is validated against the schemas exactly as Convert would use it.
+	FieldNumbers *FieldNumbers
+	// ArrayWrapperSuffix names array-of-arrays wrappers; see ConvertOptions.
+	ArrayWrapperSuffix string
+	// Concurrency is the number of workers used to resolve schemas; see ConvertOptions.
+	Concurrency int
+	// TypeMappings are validated, and formats mapped for proto are not reported as unknown.
+	TypeMappings []TypeMapping
+	// Formats adds the TypeMappings of its formats, as in ConvertOptions.
+	Formats *FormatRegistry
+	// WellKnownTypes accepts the constructs it maps, such as anyOf properties.
+	WellKnownTypes WellKnownTypes
+	// Decimals accepts decimal formats, as Convert maps them; see ConvertOptions.
+	Decimals *DecimalOptions
+	// NestedNameFunc names inline types, so plural property names it names are not reported.
+	NestedNameFunc func(parent, property string) string
+}
+
+// LintSeverity is the severity of a LintIssue.
+type LintSeverity string
+
+const (
+	// LintError marks a problem that makes Convert fail.
+	LintError LintSeverity = "error"
+	// LintWarning marks information Convert would drop; see Warning.
+	LintWarning LintSeverity = "warning"
+)
+
+// LintIssue is a single problem found by Lint.
+type LintIssue struct {
+	Severity LintSeverity `json:"severity"`
+	Schema   string       `json:"schema"`
+	// Pointer locates the problem in the OpenAPI document. Errors point at the
+	// component schema they were found in.
+	Pointer string `json:"pointer"`
+	// Code is a Warning code for warnings and empty for errors.
+	Code    string         `json:"code,omitempty"`
+	Message string         `json:"message"`
+	Fix     *FixSuggestion `json:"fix,omitempty"`
+}
+
+// LintReport lists every issue Lint found: errors first, then warnings, each in
+// schema order.
+type LintReport struct {
+	Issues []LintIssue `json:"issues"`
+}
+
+// HasErrors reports whether Convert would fail on the linted document.
+func (r *LintReport) HasErrors() bool {
+	for _, issue := range r.Issues {
+		if issue.Severity == LintError {
+			return true
+		}
+	}
+	return false
+}
+
+// Lint runs every validation Convert performs (naming, oneOf rules,
+// x-proto-number, x-sunset, unsupported keywords) without generating output, and reports
+// all problems rather than stopping at the first. It is meant as a pre-commit or
+// CI gate: fail when HasErrors is true.
+//
+// An error is returned only when the document itself cannot be read (empty
+// input, invalid YAML/JSON, not OpenAPI 3.x).
+func Lint(openapi []byte, opts LintOptions) (*LintReport, error) {
+	return LintContext(context.Background(), openapi, opts)
+}
+
+// LintContext is like Lint but stops early with an error wrapping ctx.Err()
+// when ctx is cancelled or its deadline expires.
+func LintContext(ctx context.Context, openapi []byte, opts LintOptions) (_ *LintReport, err error) {
+	defer internal.Recover("Lint", &err)
+
+	if len(openapi) == 0 {
+		return nil, fmt.Errorf("openapi input cannot be empty")
+	}
+
+	opts.TypeMappings = opts.Formats.typeMappings(opts.TypeMappings)
+	if err := internal.ValidateTypeMappings(opts.TypeMappings); err != nil {
+		return nil, err
+	}
+
+	if err := internal.ValidateDecimalOptions(opts.Decimals); err != nil {
+		return nil, err
+	}
+
+	if err := internal.Cancelled(ctx); err != nil {
+		return nil, err
+	}
+
+	doc, err := parser.ParseDocument(openapi)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas, err := doc.Schemas()
+	if err != nil {
+		return nil, err
+	}
+
+	protoCtx := proto.NewContext()
+	protoCtx.Ctx = ctx
+	protoCtx.FieldNumbers = opts.FieldNumbers
+	protoCtx.ArrayWrapperSuffix = opts.ArrayWrapperSuffix
+	protoCtx.Concurrency = opts.Concurrency
+	protoCtx.CollectErrors = true
+	protoCtx.TypeMappings = opts.TypeMappings
+	protoCtx.WellKnownTypes = opts.WellKnownTypes
+	protoCtx.Decimals = opts.Decimals
+	protoCtx.NestedNameFunc = opts.NestedNameFunc
+	graph, err := proto.BuildMessages(schemas, protoCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	goTypes, _, _ := graph.ComputeTransitiveClosure()
+	for name := range protoCtx.FailedSchemas {
+		delete(goTypes, name)
+	}
+
+	report := &LintReport{Issues: []LintIssue{}}
+	for i, err := range protoCtx.Errors {
+		report.addError(protoCtx.ErrorSchemas[i], err)
+	}
+
+	if len(goTypes) > 0 {
+		goCtx := golang.NewGoContext("lint")
+		goCtx.Ctx = ctx
+		goCtx.Concurrency = opts.Concurrency
+		goCtx.CollectErrors = true
+		goCtx.TypeMappings = opts.TypeMappings
+		goCtx.WellKnownTypes = opts.WellKnownTypes
+		goCtx.Decimals = opts.Decimals
+		goCtx.NestedNameFunc = opts.NestedNameFunc
+		if err := golang.BuildGoStructs(schemas, goTypes, graph, goCtx); err != nil {
+			return nil, err
+		}
+		for i, err := range goCtx.Errors {
+			report.addError(goCtx.ErrorSchemas[i], err)
+		}
+	}
+
+	for _, entry := range schemas {
+		if _, err := collectDeprecations([]*parser.SchemaEntry{entry}, nil); err != nil {
+			report.addError(entry.Name, err)
+		}
+	}
+
+	for _, w := range protoWarnings(protoCtx.Warnings, goTypes, nil) {
+		report.Issues = append(report.Issues, LintIssue{
+			Severity: LintWarning,
+			Schema:   w.Schema,
+			Pointer:  w.Pointer,
+			Code:     w.Code,
+			Message:  w.Message,
+		})
+	}
+
+	return report, nil
+}
+
+// addError records a conversion error found in schema
+func (r *LintReport) addError(schema string, err error) {
+	fix, _ := SuggestedFix(err)
+	r.Issues = append(r.Issues, LintIssue{
+		Severity: LintError,
+		Schema:   schema,
+		Pointer:  internal.JSONPointer("components", "schemas", schema),
+		Message:  err.Error(),
+		Fix:      fix,
+	})
+}
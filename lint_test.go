@@ -0,0 +1,109 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLint(t *testing.T) {
+	given := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Composite:
+      allOf:
+        - $ref: '#/components/schemas/Valid'
+    Valid:
+      type: object
+      properties:
+        code:
+          type: string
+          pattern: '^[A-Z]+$'
+    Order:
+      type: object
+      properties:
+        addresses:
+          type: array
+          items:
+            type: object
+            properties:
+              street:
+                type: string
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+      discriminator:
+        propertyName: petType
+    Dog:
+      type: object
+      properties:
+        petType:
+          type: string
+    Cat:
+      type: object
+      properties:
+        name:
+          type: string
+`
+	report, err := schema.Lint([]byte(given), schema.LintOptions{})
+	require.NoError(t, err)
+	require.True(t, report.HasErrors())
+	require.Len(t, report.Issues, 4)
+
+	assert.Equal(t, schema.LintIssue{
+		Severity: schema.LintError,
+		Schema:   "Composite",
+		Pointer:  "/components/schemas/Composite",
+		Message:  "schema 'Composite': uses 'allOf' which is not supported",
+	}, report.Issues[0])
+
+	assert.Equal(t, "Order", report.Issues[1].Schema)
+	require.NotNil(t, report.Issues[1].Fix)
+	assert.Contains(t, report.Issues[1].Fix.Description, "components/schemas/Address")
+
+	// Union rules are checked on the Go side
+	assert.Equal(t, schema.LintError, report.Issues[2].Severity)
+	assert.Equal(t, "Pet", report.Issues[2].Schema)
+	assert.Contains(t, report.Issues[2].Message, "discriminator property 'petType' missing in variant 'Cat'")
+
+	assert.Equal(t, schema.LintIssue{
+		Severity: schema.LintWarning,
+		Schema:   "Valid",
+		Pointer:  "/components/schemas/Valid/properties/code",
+		Code:     schema.WarningConstraintDropped,
+		Message:  "pattern dropped in proto",
+	}, report.Issues[3])
+}
+
+func TestLintClean(t *testing.T) {
+	given := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        id:
+          type: string
+`
+	report, err := schema.Lint([]byte(given), schema.LintOptions{})
+	require.NoError(t, err)
+	assert.False(t, report.HasErrors())
+	assert.Empty(t, report.Issues)
+}
+
+func TestLintInvalidDocument(t *testing.T) {
+	_, err := schema.Lint(nil, schema.LintOptions{})
+	require.ErrorContains(t, err, "openapi input cannot be empty")
+
+	_, err = schema.Lint([]byte("not: [valid"), schema.LintOptions{})
+	require.Error(t, err)
+}
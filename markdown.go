@@ -0,0 +1,121 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/duh-rpc/openapi-schema.go/internal/markdown"
+	"github.com/duh-rpc/openapi-schema.go/internal/parser"
+)
+
+// MarkdownOptions configures ConvertToMarkdown.
+type MarkdownOptions struct {
+	// SchemaNames limits output to the named component schemas and the schemas
+	// they reference, directly or transitively. Empty → every schema. Naming a
+	// schema that does not exist is an error.
+	SchemaNames []string
+	// Examples configures the example JSON shown on each page, generated by
+	// ConvertToExamples for every schema; SchemaNames and IncludeAll are
+	// ignored. Nil → default options with Seed 1, so pages are stable from run
+	// to run.
+	Examples *ExampleOptions
+	// OmitExamples leaves out the example sections.
+	OmitExamples bool
+	// LinkFunc returns the link target of the page of a schema, for portals
+	// that lay pages out differently. Nil → "<name>.md".
+	LinkFunc func(schemaName string) string
+}
+
+// MarkdownResult holds the output of ConvertToMarkdown.
+type MarkdownResult struct {
+	// Pages maps component schema names to their documentation page.
+	Pages map[string][]byte
+	// Names lists the keys of Pages in document order.
+	Names []string
+	// Index is a page linking to every page, with the first line of each
+	// schema's description.
+	Index []byte
+}
+
+// ConvertToMarkdown generates a Markdown documentation page per component
+// schema, so API portals can be generated from one call. Each page holds the
+// schema's description and:
+//
+//   - for objects, a table of fields with their type, whether they are
+//     required, their constraints and description; fields of inline objects
+//     follow, named by their path (shipping.street, items[].sku)
+//   - for enums, the values
+//   - for oneOf, anyOf and allOf, the variants and any discriminator mapping
+//   - for other schemas, the type and constraints
+//
+// then an example from ConvertToExamples and the schemas referring to it.
+// References to other schemas link to their pages.
+func ConvertToMarkdown(openapi []byte, opts MarkdownOptions) (*MarkdownResult, error) {
+	return ConvertToMarkdownContext(context.Background(), openapi, opts)
+}
+
+// ConvertToMarkdownContext is like ConvertToMarkdown but stops early with an
+// error wrapping ctx.Err() when ctx is cancelled or its deadline expires.
+// Cancellation is checked before parsing and between schemas.
+func ConvertToMarkdownContext(ctx context.Context, openapi []byte, opts MarkdownOptions) (_ *MarkdownResult, err error) {
+	defer internal.Recover("ConvertToMarkdown", &err)
+
+	if len(openapi) == 0 {
+		return nil, fmt.Errorf("openapi input cannot be empty")
+	}
+
+	if err := internal.Cancelled(ctx); err != nil {
+		return nil, err
+	}
+
+	doc, err := parser.ParseDocument(openapi)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas, err := doc.Schemas()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(opts.SchemaNames) > 0 {
+		schemas, err = parser.SelectSchemas(schemas, opts.SchemaNames)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	mdCtx := markdown.NewContext()
+	mdCtx.Ctx = ctx
+	mdCtx.Link = opts.LinkFunc
+
+	if !opts.OmitExamples {
+		exampleOpts := ExampleOptions{Seed: 1}
+		if opts.Examples != nil {
+			exampleOpts = *opts.Examples
+		}
+		exampleOpts.IncludeAll, exampleOpts.SchemaNames = true, nil
+
+		examples, err := ConvertToExamplesContext(ctx, openapi, exampleOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate examples: %w", err)
+		}
+		mdCtx.Examples = examples.Examples
+	}
+
+	if err := markdown.BuildPages(schemas, mdCtx); err != nil {
+		return nil, err
+	}
+
+	result := &MarkdownResult{
+		Pages: make(map[string][]byte, len(mdCtx.Pages)),
+		Names: make([]string, 0, len(mdCtx.Pages)),
+		Index: markdown.Index(mdCtx),
+	}
+	for _, page := range mdCtx.Pages {
+		result.Pages[page.Name] = page.Markdown
+		result.Names = append(result.Names, page.Name)
+	}
+	return result, nil
+}
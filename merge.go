@@ -0,0 +1,254 @@
+package schema
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"go.yaml.in/yaml/v4"
+)
+
+// NamedSpec is one OpenAPI document converted by ConvertMany. Name identifies
+// the spec in errors and is the path other specs' references name it by, such
+// as "common/models.yaml".
+type NamedSpec struct {
+	Name    string
+	OpenAPI []byte
+	// TypePrefix renames the spec's component schemas in the merged document
+	// (Error → BillingError), so specs may define schemas of the same name. The
+	// TypeMap is keyed by the renamed schemas, with TypeInfo.OriginalName holding
+	// the name in the spec.
+	TypePrefix string
+}
+
+// ConvertMany merges the components and paths of several OpenAPI documents into
+// one and converts it with opts, for organisations with a spec per service
+// sharing models. A reference into another spec, such as
+// "../common/models.yaml#/components/schemas/Money", resolves against the
+// referring spec's Name and becomes a local reference into the merged document.
+//
+// A component or path may be defined by more than one spec only when every
+// definition is identical; differing definitions are reported together as
+// conflicts. The openapi version, info and other top-level fields come from the
+// first spec. opts.TypePrefix applies to every spec, after each spec's own
+// TypePrefix.
+func ConvertMany(specs []NamedSpec, opts ConvertOptions) (*ConvertResult, error) {
+	return ConvertManyContext(context.Background(), specs, opts)
+}
+
+// ConvertManyContext is like ConvertMany but stops early with an error wrapping
+// ctx.Err() when ctx is cancelled or its deadline expires.
+func ConvertManyContext(ctx context.Context, specs []NamedSpec, opts ConvertOptions) (_ *ConvertResult, err error) {
+	defer internal.Recover("ConvertMany", &err)
+
+	openapi, renamed, err := mergeSpecs(specs)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := ConvertContext(ctx, openapi, opts)
+	if result != nil {
+		for name, info := range result.TypeMap {
+			info.OriginalName = renamed[name]
+		}
+	}
+	return result, err
+}
+
+// mergeSpecs returns the single document ConvertMany converts, and the
+// original name of each schema a spec's TypePrefix renamed, by its new name
+func mergeSpecs(specs []NamedSpec) ([]byte, map[string]string, error) {
+	if len(specs) == 0 {
+		return nil, nil, fmt.Errorf("ConvertMany requires at least one spec")
+	}
+
+	// prefixes holds the TypePrefix of every spec given, by cleaned name
+	prefixes := make(map[string]string, len(specs))
+	docs := make([]*yaml.Node, len(specs))
+	for i, spec := range specs {
+		if spec.Name == "" {
+			return nil, nil, fmt.Errorf("spec %d must have a Name", i)
+		}
+		name := path.Clean(spec.Name)
+		if _, ok := prefixes[name]; ok {
+			return nil, nil, fmt.Errorf("spec '%s' is given more than once", spec.Name)
+		}
+		if spec.TypePrefix != "" && !internal.IsTypeIdentifier(spec.TypePrefix) {
+			return nil, nil, fmt.Errorf("spec '%s': invalid TypePrefix %q: must be letters, digits and underscores, starting with a letter", spec.Name, spec.TypePrefix)
+		}
+		prefixes[name] = spec.TypePrefix
+
+		var doc yaml.Node
+		if err := yaml.Unmarshal(spec.OpenAPI, &doc); err != nil {
+			return nil, nil, fmt.Errorf("spec '%s': failed to parse: %w", spec.Name, err)
+		}
+		if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+			return nil, nil, fmt.Errorf("spec '%s': must be a YAML or JSON object", spec.Name)
+		}
+		docs[i] = doc.Content[0]
+	}
+
+	var errs []error
+	for i, doc := range docs {
+		errs = append(errs, localizeRefs(doc, specs[i].Name, prefixes)...)
+	}
+	if len(errs) > 0 {
+		return nil, nil, errors.Join(errs...)
+	}
+
+	renamed := make(map[string]string)
+	for i, doc := range docs {
+		prefix := specs[i].TypePrefix
+		schemas := internal.MappingValue(internal.MappingValue(doc, "components"), "schemas")
+		if prefix == "" || schemas == nil || schemas.Kind != yaml.MappingNode {
+			continue
+		}
+		for j := 0; j+1 < len(schemas.Content); j += 2 {
+			key := schemas.Content[j]
+			renamed[prefix+key.Value] = key.Value
+			key.Value = prefix + key.Value
+		}
+	}
+
+	merged := docs[0]
+	components := internal.MappingValue(merged, "components")
+	if components == nil {
+		components = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		internal.SetMappingValue(merged, "components", components)
+	}
+	paths := internal.MappingValue(merged, "paths")
+	if paths == nil {
+		paths = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		internal.SetMappingValue(merged, "paths", paths)
+	}
+
+	definedBy := make(map[string]string)
+	record := func(section string, target *yaml.Node, spec string) {
+		for i := 0; i+1 < len(target.Content); i += 2 {
+			definedBy[section+"\x00"+target.Content[i].Value] = spec
+		}
+	}
+	record("path", paths, specs[0].Name)
+	for i := 0; i+1 < len(components.Content); i += 2 {
+		record("components/"+components.Content[i].Value, components.Content[i+1], specs[0].Name)
+	}
+
+	for i, doc := range docs[1:] {
+		spec := specs[i+1].Name
+		errs = append(errs, mergeMapping(paths, internal.MappingValue(doc, "paths"), "path", spec, definedBy)...)
+
+		from := internal.MappingValue(doc, "components")
+		if from == nil || from.Kind != yaml.MappingNode {
+			continue
+		}
+		for j := 0; j+1 < len(from.Content); j += 2 {
+			section := from.Content[j].Value
+			target := internal.MappingValue(components, section)
+			if target == nil {
+				target = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+				internal.SetMappingValue(components, section, target)
+			}
+			errs = append(errs, mergeMapping(target, from.Content[j+1], "components/"+section, spec, definedBy)...)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, nil, errors.Join(errs...)
+	}
+
+	internal.ClearFlowStyle(merged)
+	openapi, err := internal.EncodeYAML(merged)
+	return openapi, renamed, err
+}
+
+// mergeMapping adds the entries of from, defined by spec, to target. An entry
+// already in target must be identical; definedBy records which spec defined
+// each entry of section, for reporting conflicts.
+func mergeMapping(target, from *yaml.Node, section, spec string, definedBy map[string]string) []error {
+	if from == nil || from.Kind != yaml.MappingNode || target.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	var errs []error
+	for i := 0; i+1 < len(from.Content); i += 2 {
+		name, value := from.Content[i].Value, from.Content[i+1]
+		existing := internal.MappingValue(target, name)
+		if existing == nil {
+			target.Content = append(target.Content, from.Content[i], value)
+			definedBy[section+"\x00"+name] = spec
+			continue
+		}
+		if !sameNode(existing, value) {
+			errs = append(errs, fmt.Errorf("%s '%s' is defined differently by '%s' and '%s'",
+				section, name, definedBy[section+"\x00"+name], spec))
+		}
+	}
+	return errs
+}
+
+// sameNode reports whether a and b hold the same content, whatever their style
+func sameNode(a, b *yaml.Node) bool {
+	ja, errA := internal.EncodeJSON(a)
+	jb, errB := internal.EncodeJSON(b)
+	return errA == nil && errB == nil && bytes.Equal(ja, jb)
+}
+
+// localizeRefs rewrites the references in node, part of spec, that point into
+// another spec given to ConvertMany to local references, and the references to
+// component schemas, including discriminator mappings, to the names the
+// TypePrefix of the spec declaring them gives. References to files that are not
+// among prefixes are reported.
+func localizeRefs(node *yaml.Node, spec string, prefixes map[string]string) []error {
+	var errs []error
+	if node.Kind == yaml.MappingNode {
+		if ref := internal.MappingValue(node, "$ref"); ref != nil && ref.Kind == yaml.ScalarNode {
+			if err := localizeRef(ref, spec, prefixes); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		mapping := internal.MappingValue(internal.MappingValue(node, "discriminator"), "mapping")
+		if mapping != nil && mapping.Kind == yaml.MappingNode {
+			for i := 1; i < len(mapping.Content); i += 2 {
+				value := mapping.Content[i]
+				if !strings.Contains(value.Value, "#") {
+					// Conversion reports values that are not references
+					continue
+				}
+				if err := localizeRef(value, spec, prefixes); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+	}
+	for _, child := range node.Content {
+		errs = append(errs, localizeRefs(child, spec, prefixes)...)
+	}
+	return errs
+}
+
+// localizeRef rewrites the reference held by ref, in spec, as localizeRefs does
+func localizeRef(ref *yaml.Node, spec string, prefixes map[string]string) error {
+	const schemasPrefix = "/components/schemas/"
+
+	file, pointer, found := strings.Cut(ref.Value, "#")
+	target := path.Clean(spec)
+	switch {
+	case file == "":
+	case !found || strings.Contains(file, "://"):
+		return fmt.Errorf("spec '%s': $ref '%s' must point into a spec given to ConvertMany", spec, ref.Value)
+	default:
+		target = path.Join(path.Dir(spec), file)
+		if _, ok := prefixes[target]; !ok {
+			return fmt.Errorf("spec '%s': $ref '%s' names spec '%s', which was not given to ConvertMany", spec, ref.Value, target)
+		}
+	}
+
+	if strings.HasPrefix(pointer, schemasPrefix) {
+		pointer = schemasPrefix + prefixes[target] + strings.TrimPrefix(pointer, schemasPrefix)
+	}
+	ref.Value = "#" + pointer
+	return nil
+}
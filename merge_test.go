@@ -0,0 +1,222 @@
+package schema_test
+
+import (
+	"strings"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const mergeHeader = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+`
+
+func TestConvertMany(t *testing.T) {
+	specs := []schema.NamedSpec{
+		{Name: "services/orders/openapi.yaml", OpenAPI: []byte(mergeHeader + `components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        total:
+          $ref: '../../common/models.yaml#/components/schemas/Money'
+        error:
+          $ref: '#/components/schemas/Error'
+    Error:
+      type: object
+      properties:
+        message:
+          type: string
+`)},
+		{Name: "services/users/openapi.yaml", OpenAPI: []byte(mergeHeader + `components:
+  schemas:
+    User:
+      type: object
+      properties:
+        balance:
+          $ref: '../../common/models.yaml#/components/schemas/Money'
+    Error:
+      type: object
+      properties:
+        message:
+          type: string
+`)},
+		{Name: "common/models.yaml", OpenAPI: []byte(`{"openapi": "3.0.0", "info": {"title": "Common", "version": "1.0.0"},
+  "components": {"schemas": {"Money": {"type": "object", "properties": {"amount": {"type": "string"}}}}}}`)},
+	}
+
+	result, err := schema.ConvertMany(specs, schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto",
+	})
+	require.NoError(t, err)
+
+	proto := string(result.Protobuf)
+	assert.Contains(t, proto, "message Order {\n  Money total = 1 [json_name = \"total\"];\n  Error error = 2 [json_name = \"error\"];\n}\n")
+	assert.Contains(t, proto, "message User {\n  Money balance = 1 [json_name = \"balance\"];\n}\n")
+	assert.Contains(t, proto, "message Money {\n")
+	// Error is defined identically by both services, so it is generated once
+	assert.Equal(t, 1, strings.Count(proto, "message Error {"))
+	assert.Equal(t, []string{"Money"}, result.TypeMap["User"].Dependencies)
+}
+
+func TestConvertManyTypePrefix(t *testing.T) {
+	specs := []schema.NamedSpec{
+		{Name: "billing.yaml", TypePrefix: "Billing", OpenAPI: []byte(mergeHeader + `components:
+  schemas:
+    Invoice:
+      type: object
+      properties:
+        error:
+          $ref: '#/components/schemas/Error'
+        customer:
+          $ref: 'users.yaml#/components/schemas/User'
+    Error:
+      type: object
+      properties:
+        code:
+          type: integer
+`)},
+		{Name: "users.yaml", TypePrefix: "Users", OpenAPI: []byte(mergeHeader + `components:
+  schemas:
+    User:
+      type: object
+      properties:
+        error:
+          $ref: '#/components/schemas/Error'
+    Error:
+      type: object
+      properties:
+        message:
+          type: string
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+      discriminator:
+        propertyName: petType
+        mapping:
+          dog: '#/components/schemas/Dog'
+          cat: '#/components/schemas/Cat'
+    Dog:
+      type: object
+      properties:
+        petType:
+          type: string
+    Cat:
+      type: object
+      properties:
+        petType:
+          type: string
+`)},
+	}
+
+	result, err := schema.ConvertMany(specs, schema.ConvertOptions{
+		PackageName:   "testpkg",
+		PackagePath:   "github.com/example/proto",
+		GoPackagePath: "github.com/example/types",
+	})
+	require.NoError(t, err)
+
+	proto := string(result.Protobuf)
+	assert.Contains(t, proto, "message BillingInvoice {\n  BillingError error = 1 [json_name = \"error\"];\n  UsersUser customer = 2 [json_name = \"customer\"];\n}\n")
+	assert.Contains(t, proto, "message BillingError {\n  int32 code = 1 [json_name = \"code\"];\n}\n")
+	assert.Contains(t, proto, "message UsersError {\n  string message = 1 [json_name = \"message\"];\n}\n")
+
+	golang := string(result.Golang)
+	assert.Contains(t, golang, "\tcase \"dog\":\n\t\tu.UsersDog = &UsersDog{}\n")
+	assert.Contains(t, golang, "\tcase \"cat\":\n\t\tu.UsersCat = &UsersCat{}\n")
+
+	assert.Equal(t, "Error", result.TypeMap["BillingError"].OriginalName)
+	assert.Equal(t, "User", result.TypeMap["UsersUser"].OriginalName)
+}
+
+func TestConvertManyErrors(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		specs    []schema.NamedSpec
+		expected string
+	}{
+		{
+			name:     "no specs",
+			expected: "ConvertMany requires at least one spec",
+		},
+		{
+			name:     "missing name",
+			specs:    []schema.NamedSpec{{OpenAPI: []byte(mergeHeader)}},
+			expected: "spec 0 must have a Name",
+		},
+		{
+			name: "duplicate name",
+			specs: []schema.NamedSpec{
+				{Name: "a.yaml", OpenAPI: []byte(mergeHeader)},
+				{Name: "./a.yaml", OpenAPI: []byte(mergeHeader)},
+			},
+			expected: "spec './a.yaml' is given more than once",
+		},
+		{
+			name:     "invalid prefix",
+			specs:    []schema.NamedSpec{{Name: "a.yaml", TypePrefix: "1st", OpenAPI: []byte(mergeHeader)}},
+			expected: `spec 'a.yaml': invalid TypePrefix "1st": must be letters, digits and underscores, starting with a letter`,
+		},
+		{
+			name: "conflicting schemas",
+			specs: []schema.NamedSpec{
+				{Name: "a.yaml", OpenAPI: []byte(mergeHeader + `components:
+  schemas:
+    Error:
+      type: object
+      properties:
+        message:
+          type: string
+`)},
+				{Name: "b.yaml", OpenAPI: []byte(mergeHeader + `components:
+  schemas:
+    Error:
+      type: object
+      properties:
+        code:
+          type: integer
+`)},
+			},
+			expected: "components/schemas 'Error' is defined differently by 'a.yaml' and 'b.yaml'",
+		},
+		{
+			name: "unknown spec",
+			specs: []schema.NamedSpec{
+				{Name: "services/a.yaml", OpenAPI: []byte(mergeHeader + `components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        total:
+          $ref: 'common.yaml#/components/schemas/Money'
+`)},
+			},
+			expected: "spec 'services/a.yaml': $ref 'common.yaml#/components/schemas/Money' names spec 'services/common.yaml', which was not given to ConvertMany",
+		},
+		{
+			name: "remote reference",
+			specs: []schema.NamedSpec{
+				{Name: "a.yaml", OpenAPI: []byte(mergeHeader + `components:
+  schemas:
+    Order:
+      $ref: 'https://example.com/order.yaml#/Order'
+`)},
+			},
+			expected: "spec 'a.yaml': $ref 'https://example.com/order.yaml#/Order' must point into a spec given to ConvertMany",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := schema.ConvertMany(test.specs, schema.ConvertOptions{
+				PackageName: "testpkg",
+				PackagePath: "github.com/example/proto",
+			})
+			require.ErrorContains(t, err, test.expected)
+		})
+	}
+}
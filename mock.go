@@ -0,0 +1,164 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/duh-rpc/openapi-schema.go/internal/parser"
+)
+
+// MockServerOptions configures the handler NewMockServer returns
+type MockServerOptions struct {
+	// BasePath is stripped from request paths before matching, e.g. "/v1"
+	BasePath       string
+	MaxDepth       int                    // Maximum nesting depth for circular references (default 5)
+	Seed           int64                  // Random seed for deterministic responses (0 = use time-based seed)
+	FieldOverrides map[string]interface{} // Field name, path or pattern to value overrides, as in ExampleOptions
+	// OverridePrecedence decides whether FieldOverrides beat the spec's example
+	// and default values, as in ExampleOptions
+	OverridePrecedence OverridePrecedence
+	Mode               ExampleMode       // Typical, boundary or invalid values, as in ExampleOptions
+	Providers          []ExampleProvider // Realistic string values, as in ExampleOptions
+	Locale             Locale            // Adds realistic fake data for the locale, as in ExampleOptions
+	Formats            *FormatRegistry   // Example strings for custom formats, as in ExampleOptions
+	Logger             *slog.Logger      // Receives a debug event per request served; nil → discarded
+}
+
+// mockRoute is an operation the mock server answers
+type mockRoute struct {
+	op       *parser.OperationEntry
+	example  *OperationExample
+	statuses []string // declared response statuses, in document order, default last
+}
+
+// mockServer serves generated examples for the operations of a spec
+type mockServer struct {
+	router *router
+	routes map[*parser.OperationEntry]*mockRoute
+	logger *slog.Logger
+}
+
+// NewMockServer returns an http.Handler serving the operations of the spec with
+// responses built by ConvertToOperationExamples, so contract mocks can be stood
+// up directly from a spec. Responses are generated once, so they are the same
+// for every request.
+//
+// A request is matched to an operation by method and path template, literal
+// paths winning over templated ones. The response is the first declared 2XX
+// status, or else default; a client picks another declared status with a
+// "Prefer: code=404" header. The status code, Content-Type and body come from
+// the chosen response, and responses without content send no body. Unmatched
+// paths get 404, unmatched methods 405 with an Allow header, and undeclared
+// Prefer codes 400, each with a JSON {"error": "..."} body.
+func NewMockServer(openapi []byte, opts MockServerOptions) (_ http.Handler, err error) {
+	defer internal.Recover("NewMockServer", &err)
+
+	entries, examples, err := operationExamples(context.Background(), openapi, OperationExampleOptions{
+		MaxDepth:           opts.MaxDepth,
+		Seed:               opts.Seed,
+		FieldOverrides:     opts.FieldOverrides,
+		OverridePrecedence: opts.OverridePrecedence,
+		Mode:               opts.Mode,
+		Providers:          opts.Providers,
+		Locale:             opts.Locale,
+		Formats:            opts.Formats,
+		Logger:             opts.Logger,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	server := &mockServer{
+		router: newRouter(entries, opts.BasePath),
+		routes: make(map[*parser.OperationEntry]*mockRoute, len(entries)),
+		logger: internal.LoggerOrDiscard(opts.Logger),
+	}
+	for i, op := range entries {
+		server.routes[op] = &mockRoute{op: op, example: examples[i], statuses: responseStatuses(op)}
+	}
+	return server, nil
+}
+
+// ServeHTTP answers r with the example response of the operation it matches
+func (s *mockServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	defer func() {
+		if p := recover(); p != nil {
+			mockError(w, http.StatusInternalServerError, internal.NewConversionError("MockServer", p).Error())
+		}
+	}()
+
+	op, allowed := s.router.match(r.Method, r.URL.Path)
+	switch {
+	case op != nil:
+	case len(allowed) > 0:
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		mockError(w, http.StatusMethodNotAllowed, fmt.Sprintf("method %s is not allowed for %s", r.Method, r.URL.Path))
+		return
+	default:
+		mockError(w, http.StatusNotFound, fmt.Sprintf("no operation matches %s %s", r.Method, r.URL.Path))
+		return
+	}
+
+	route := s.routes[op]
+	status, err := route.status(r.Header.Get("Prefer"))
+	if err != nil {
+		mockError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	s.logger.Debug(internal.LogMockRequest, "method", r.Method, "path", r.URL.Path,
+		"operation", route.op.OperationID, "status", status)
+
+	code := statusCode(status)
+	body := route.example.Responses[status]
+	if body == nil {
+		w.WriteHeader(code)
+		return
+	}
+	w.Header().Set("Content-Type", body.MediaType)
+	w.WriteHeader(code)
+	_, _ = w.Write(body.Value)
+}
+
+// status returns the declared response status to send: the code a "Prefer:
+// code=NNN" header asks for, or else the first 2XX status, or else default
+func (m *mockRoute) status(prefer string) (string, error) {
+	for _, pref := range strings.Split(prefer, ",") {
+		code, ok := strings.CutPrefix(strings.TrimSpace(pref), "code=")
+		if !ok {
+			continue
+		}
+		for _, status := range m.statuses {
+			if status == code {
+				return status, nil
+			}
+		}
+		for _, status := range m.statuses {
+			if len(code) == 3 && strings.EqualFold(status, code[:1]+"XX") {
+				return status, nil
+			}
+		}
+		return "", fmt.Errorf("operation %s %s declares no response %s", m.op.Method, m.op.Path, code)
+	}
+
+	for _, status := range m.statuses {
+		if strings.HasPrefix(status, "2") {
+			return status, nil
+		}
+	}
+	if internal.Contains(m.statuses, "default") {
+		return "default", nil
+	}
+	return "", fmt.Errorf("operation %s %s declares no success or default response", m.op.Method, m.op.Path)
+}
+
+// mockError writes a JSON error response
+func mockError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
@@ -0,0 +1,174 @@
+package schema_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const mockSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /pets/{petId}:
+    get:
+      operationId: getPet
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+        '404':
+          description: Not found
+          content:
+            application/problem+json:
+              example:
+                title: Not Found
+        5XX:
+          description: Server error
+    delete:
+      operationId: deletePet
+      responses:
+        '204':
+          description: Deleted
+  /pets/mine:
+    get:
+      operationId: getMyPet
+      responses:
+        default:
+          description: Mine
+          content:
+            application/json:
+              example:
+                name: Mine
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+          example: Rex
+`
+
+func TestNewMockServer(t *testing.T) {
+	handler, err := schema.NewMockServer([]byte(mockSpec), schema.MockServerOptions{BasePath: "/v1", Seed: 1})
+	require.NoError(t, err)
+
+	for _, test := range []struct {
+		name        string
+		method      string
+		path        string
+		prefer      string
+		status      int
+		contentType string
+		body        string
+		allow       string
+	}{
+		{
+			name:        "first success response",
+			method:      http.MethodGet,
+			path:        "/v1/pets/42",
+			status:      http.StatusOK,
+			contentType: "application/json",
+			body:        `{"name": "Rex"}`,
+		},
+		{
+			name:        "preferred status",
+			method:      http.MethodGet,
+			path:        "/v1/pets/42",
+			prefer:      "code=404",
+			status:      http.StatusNotFound,
+			contentType: "application/problem+json",
+			body:        `{"title": "Not Found"}`,
+		},
+		{
+			name:   "preferred status range without content",
+			method: http.MethodGet,
+			path:   "/v1/pets/42",
+			prefer: "code=503",
+			status: http.StatusInternalServerError,
+		},
+		{
+			name:        "undeclared preferred status",
+			method:      http.MethodGet,
+			path:        "/v1/pets/42",
+			prefer:      "code=418",
+			status:      http.StatusBadRequest,
+			contentType: "application/json",
+			body:        `{"error": "operation GET /pets/{petId} declares no response 418"}`,
+		},
+		{
+			name:   "no content",
+			method: http.MethodDelete,
+			path:   "/v1/pets/42",
+			status: http.StatusNoContent,
+		},
+		{
+			name:        "literal path wins over template",
+			method:      http.MethodGet,
+			path:        "/v1/pets/mine",
+			status:      http.StatusOK,
+			contentType: "application/json",
+			body:        `{"name": "Mine"}`,
+		},
+		{
+			name:        "method not allowed",
+			method:      http.MethodPost,
+			path:        "/v1/pets/42",
+			status:      http.StatusMethodNotAllowed,
+			contentType: "application/json",
+			body:        `{"error": "method POST is not allowed for /v1/pets/42"}`,
+			allow:       "GET, DELETE",
+		},
+		{
+			name:        "unknown path",
+			method:      http.MethodGet,
+			path:        "/v1/owners",
+			status:      http.StatusNotFound,
+			contentType: "application/json",
+			body:        `{"error": "no operation matches GET /v1/owners"}`,
+		},
+		{
+			name:        "outside base path",
+			method:      http.MethodGet,
+			path:        "/pets/42",
+			status:      http.StatusNotFound,
+			contentType: "application/json",
+			body:        `{"error": "no operation matches GET /pets/42"}`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(test.method, test.path, nil)
+			if test.prefer != "" {
+				req.Header.Set("Prefer", test.prefer)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, test.status, rec.Code)
+			assert.Equal(t, test.contentType, rec.Header().Get("Content-Type"))
+			assert.Equal(t, test.allow, rec.Header().Get("Allow"))
+			if test.body == "" {
+				assert.Empty(t, rec.Body.String())
+				return
+			}
+			assert.JSONEq(t, test.body, rec.Body.String())
+		})
+	}
+}
+
+func TestNewMockServerInvalidOptions(t *testing.T) {
+	_, err := schema.NewMockServer([]byte(mockSpec), schema.MockServerOptions{Mode: "negative"})
+	require.ErrorContains(t, err, `unknown Mode "negative"`)
+
+	_, err = schema.NewMockServer(nil, schema.MockServerOptions{})
+	require.ErrorContains(t, err, "openapi input cannot be empty")
+}
@@ -0,0 +1,181 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/duh-rpc/openapi-schema.go/internal/example"
+	"github.com/duh-rpc/openapi-schema.go/internal/parser"
+)
+
+// OperationExampleOptions configures operation example generation
+type OperationExampleOptions struct {
+	OperationIDs   []string               // Specific operations to generate (empty = all operations)
+	MaxDepth       int                    // Maximum nesting depth for circular references (default 5)
+	Seed           int64                  // Random seed for deterministic generation (0 = use time-based seed)
+	FieldOverrides map[string]interface{} // Field name, path or pattern to value overrides, as in ExampleOptions
+	// OverridePrecedence decides whether FieldOverrides beat the spec's example
+	// and default values, as in ExampleOptions
+	OverridePrecedence OverridePrecedence
+	// Mode selects typical, boundary or invalid values, as in ExampleOptions
+	Mode      ExampleMode
+	Providers []ExampleProvider // Realistic string values, as in ExampleOptions
+	Locale    Locale            // Adds realistic fake data for the locale, as in ExampleOptions
+	Formats   *FormatRegistry   // Example strings for custom formats, as in ExampleOptions
+	Logger    *slog.Logger      // Receives debug events; nil → discarded
+}
+
+// OperationExampleResult contains the examples of each operation
+type OperationExampleResult struct {
+	Operations []*OperationExample // In document order
+}
+
+// OperationExample holds example parameters, request body and response bodies
+// for one operation
+type OperationExample struct {
+	OperationID string
+	Method      string // Upper-case HTTP method
+	Path        string // Path template, e.g. /pets/{petId}
+	// Parameters holds the path, query, header and cookie parameter values, and
+	// the URL, headers and cookie they produce, as GenerateParameterExamples does
+	Parameters *OperationParameters
+	Request    *BodyExample // nil without a request body
+	// Responses maps status codes ("200", "4XX", "default") to example bodies.
+	// Responses without content are omitted.
+	Responses map[string]*BodyExample
+}
+
+// BodyExample is an example request or response body
+type BodyExample struct {
+	MediaType string // The media type the example is for, e.g. application/json
+	Value     json.RawMessage
+}
+
+// ConvertToOperationExamples walks the paths of the spec and generates, for
+// each operation, example parameters, an example request body and an example
+// body for each response status code. Bodies use the first JSON media type of
+// their content, or else the first media type. A media type's example wins, then
+// its first named example, then a value generated from its schema.
+func ConvertToOperationExamples(openapi []byte, opts OperationExampleOptions) (*OperationExampleResult, error) {
+	return ConvertToOperationExamplesContext(context.Background(), openapi, opts)
+}
+
+// ConvertToOperationExamplesContext is like ConvertToOperationExamples but
+// stops early with an error wrapping ctx.Err() when ctx is cancelled or its
+// deadline expires.
+func ConvertToOperationExamplesContext(ctx context.Context, openapi []byte, opts OperationExampleOptions) (_ *OperationExampleResult, err error) {
+	defer internal.Recover("ConvertToOperationExamples", &err)
+
+	_, operations, err := operationExamples(ctx, openapi, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &OperationExampleResult{Operations: operations}, nil
+}
+
+// operationExamples generates the examples of the operations opts selects,
+// returning them with the operations they are for
+func operationExamples(ctx context.Context, openapi []byte, opts OperationExampleOptions) ([]*parser.OperationEntry, []*OperationExample, error) {
+	if len(openapi) == 0 {
+		return nil, nil, fmt.Errorf("openapi input cannot be empty")
+	}
+
+	if opts.MaxDepth <= 0 {
+		opts.MaxDepth = 5
+	}
+
+	if err := example.ValidateOverrides(opts.FieldOverrides); err != nil {
+		return nil, nil, err
+	}
+
+	if err := opts.OverridePrecedence.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	if err := opts.Mode.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	if err := example.ValidateProviders(opts.Providers); err != nil {
+		return nil, nil, err
+	}
+	opts.Providers = opts.Formats.providers(opts.Providers)
+
+	if err := opts.Locale.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	if opts.Seed == 0 {
+		opts.Seed = time.Now().UnixNano()
+	}
+
+	if err := internal.Cancelled(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	doc, err := parser.ParseDocument(openapi)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	schemas, err := doc.Schemas()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	wanted := make(map[string]bool, len(opts.OperationIDs))
+	for _, id := range opts.OperationIDs {
+		wanted[id] = true
+	}
+
+	entries := []*parser.OperationEntry{}
+	operations := []*OperationExample{}
+	for _, op := range doc.Operations() {
+		if len(wanted) > 0 && !wanted[op.OperationID] {
+			continue
+		}
+
+		value, err := example.GenerateOperation(schemas, op, example.Options{
+			FieldOverrides:     opts.FieldOverrides,
+			OverridePrecedence: string(opts.OverridePrecedence),
+			Mode:               string(opts.Mode),
+			MaxDepth:           opts.MaxDepth,
+			Seed:               opts.Seed,
+			Ctx:                ctx,
+			Logger:             opts.Logger,
+			Providers:          opts.Providers,
+			Locale:             string(opts.Locale),
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("operation %s %s: %w", op.Method, op.Path, err)
+		}
+
+		operation := &OperationExample{
+			OperationID: op.OperationID,
+			Method:      op.Method,
+			Path:        op.Path,
+			Parameters:  buildOperationParameters(op, value.Parameters),
+			Request:     bodyExample(value.Request),
+			Responses:   make(map[string]*BodyExample, len(value.Responses)),
+		}
+		for _, resp := range value.Responses {
+			operation.Responses[resp.Status] = bodyExample(resp.Body)
+		}
+		entries = append(entries, op)
+		operations = append(operations, operation)
+	}
+
+	return entries, operations, nil
+}
+
+// bodyExample converts a generated body, keeping nil as nil
+func bodyExample(body *example.BodyValue) *BodyExample {
+	if body == nil {
+		return nil
+	}
+	return &BodyExample{MediaType: body.MediaType, Value: body.Value}
+}
@@ -0,0 +1,161 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/duh-rpc/openapi-schema.go/internal/example"
+	"github.com/duh-rpc/openapi-schema.go/internal/parser"
+)
+
+// ParameterExampleOptions configures parameter example generation
+type ParameterExampleOptions struct {
+	OperationIDs   []string               // Specific operations to generate (empty = all operations)
+	MaxDepth       int                    // Maximum nesting depth for object and array values (default 5)
+	Seed           int64                  // Random seed for deterministic generation (0 = use time-based seed)
+	FieldOverrides map[string]interface{} // Parameter name to value overrides, as in ExampleOptions
+}
+
+// ParameterExampleResult contains example parameter values for each operation
+type ParameterExampleResult struct {
+	Operations []*OperationParameters // In document order
+}
+
+// OperationParameters holds the example parameters of one operation and the
+// request pieces they produce
+type OperationParameters struct {
+	OperationID string
+	Method      string // Upper-case HTTP method
+	Path        string // Path template, e.g. /pets/{petId}
+	// URL is Path with path parameters substituted and query parameters appended,
+	// e.g. /pets/42?limit=10
+	URL        string
+	Headers    map[string]string // Header parameter name to serialized value
+	Cookie     string            // Cookie header value ("a=1; b=2"), empty without cookie parameters
+	Parameters []*ParameterExample
+}
+
+// ParameterExample is the example value of a single parameter
+type ParameterExample struct {
+	Name    string
+	In      string // path, query, header or cookie
+	Style   string // Effective style, defaulted per location when the spec omits it
+	Explode bool   // Effective explode, defaulted per style when the spec omits it
+	Value   json.RawMessage
+	// Serialized is Value rendered per Style and Explode and percent-encoded for
+	// its location, e.g. "id=3&id=4" for an exploded form array
+	Serialized string
+}
+
+// GenerateParameterExamples generates example values for the path, query,
+// header and cookie parameters of each operation. Values honor the parameter's
+// example, then its schema's example, default and constraints, and are
+// serialized according to the parameter's style and explode settings.
+func GenerateParameterExamples(openapi []byte, opts ParameterExampleOptions) (*ParameterExampleResult, error) {
+	return GenerateParameterExamplesContext(context.Background(), openapi, opts)
+}
+
+// GenerateParameterExamplesContext is like GenerateParameterExamples but stops
+// early with an error wrapping ctx.Err() when ctx is cancelled or its deadline
+// expires.
+func GenerateParameterExamplesContext(ctx context.Context, openapi []byte, opts ParameterExampleOptions) (_ *ParameterExampleResult, err error) {
+	defer internal.Recover("GenerateParameterExamples", &err)
+
+	if len(openapi) == 0 {
+		return nil, fmt.Errorf("openapi input cannot be empty")
+	}
+
+	if opts.MaxDepth <= 0 {
+		opts.MaxDepth = 5
+	}
+
+	if opts.Seed == 0 {
+		opts.Seed = time.Now().UnixNano()
+	}
+
+	if err := internal.Cancelled(ctx); err != nil {
+		return nil, err
+	}
+
+	doc, err := parser.ParseDocument(openapi)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas, err := doc.Schemas()
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool, len(opts.OperationIDs))
+	for _, id := range opts.OperationIDs {
+		wanted[id] = true
+	}
+
+	result := &ParameterExampleResult{Operations: []*OperationParameters{}}
+	for _, op := range doc.Operations() {
+		if len(wanted) > 0 && !wanted[op.OperationID] {
+			continue
+		}
+
+		values, err := example.GenerateParameters(schemas, op.Parameters, example.Options{
+			FieldOverrides: opts.FieldOverrides,
+			MaxDepth:       opts.MaxDepth,
+			Seed:           opts.Seed,
+			Ctx:            ctx,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("operation %s %s: %w", op.Method, op.Path, err)
+		}
+		result.Operations = append(result.Operations, buildOperationParameters(op, values))
+	}
+
+	return result, nil
+}
+
+// buildOperationParameters assembles the URL, headers and cookie for an operation
+// from its serialized parameters
+func buildOperationParameters(op *parser.OperationEntry, values []*example.ParameterValue) *OperationParameters {
+	result := &OperationParameters{
+		OperationID: op.OperationID,
+		Method:      op.Method,
+		Path:        op.Path,
+		Headers:     map[string]string{},
+		Parameters:  make([]*ParameterExample, 0, len(values)),
+	}
+
+	path := op.Path
+	var query, cookies []string
+	for _, value := range values {
+		result.Parameters = append(result.Parameters, &ParameterExample{
+			Name:       value.Name,
+			In:         value.In,
+			Style:      value.Style,
+			Explode:    value.Explode,
+			Value:      value.Value,
+			Serialized: value.Serialized,
+		})
+
+		switch value.In {
+		case "path":
+			path = strings.ReplaceAll(path, "{"+value.Name+"}", value.Serialized)
+		case "query":
+			query = append(query, value.Serialized)
+		case "header":
+			result.Headers[value.Name] = value.Serialized
+		case "cookie":
+			cookies = append(cookies, value.Serialized)
+		}
+	}
+
+	result.URL = path
+	if len(query) > 0 {
+		result.URL += "?" + strings.Join(query, "&")
+	}
+	result.Cookie = strings.Join(cookies, "; ")
+	return result
+}
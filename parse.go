@@ -0,0 +1,116 @@
+package schema
+
+import (
+	"fmt"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/duh-rpc/openapi-schema.go/internal/parser"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// Document is a parsed spec classified the way Convert sees it, for tooling
+// that builds its own generators on the same parsing layer rather than forking
+// the internal packages.
+type Document struct {
+	// Version is the OpenAPI version the spec declares, e.g. "3.1.0".
+	Version string
+	// Model is the libopenapi model of the whole spec.
+	Model *v3.Document
+	// Schemas holds one entry per component schema, in document order.
+	Schemas []*SchemaEntry
+	// Unions holds the component schemas Convert generates as Go unions, in
+	// document order.
+	Unions []*Union
+	// Dependencies is the graph AnalyzeDependencies returns.
+	Dependencies *DependencyAnalysis
+}
+
+// SchemaEntry is a component schema and where Convert generates it.
+type SchemaEntry struct {
+	Name  string
+	Proxy *base.SchemaProxy
+	// Schema is nil when libopenapi cannot build the schema;
+	// Proxy.GetBuildError says why.
+	Schema   *base.Schema
+	Location TypeLocation
+	// Reason explains a golang Location, as in TypeInfo.
+	Reason string
+}
+
+// Union is a component schema whose oneOf variants Convert generates as a Go
+// union.
+type Union struct {
+	Name     string
+	Variants []string // Variant schema names, in oneOf order
+	// Discriminator is the property naming the variant, empty without a
+	// discriminator.
+	Discriminator string
+}
+
+// Parse parses openapi and classifies its component schemas as Convert does:
+// where each is generated, which are unions, and how they depend on each other.
+// As with AnalyzeDependencies, schemas Convert would reject still appear.
+func Parse(openapi []byte) (_ *Document, err error) {
+	defer internal.Recover("Parse", &err)
+
+	if len(openapi) == 0 {
+		return nil, fmt.Errorf("openapi input cannot be empty")
+	}
+
+	doc, err := parser.ParseDocument(openapi)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas, err := doc.Schemas()
+	if err != nil {
+		return nil, err
+	}
+
+	dependencies, err := analyzeDependencies(schemas)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Document{
+		Version:      doc.Version(),
+		Model:        doc.Model(),
+		Schemas:      make([]*SchemaEntry, 0, len(schemas)),
+		Unions:       []*Union{},
+		Dependencies: dependencies,
+	}
+	for i, entry := range schemas {
+		node := dependencies.Nodes[i]
+		schema := entry.Proxy.Schema()
+		result.Schemas = append(result.Schemas, &SchemaEntry{
+			Name:     entry.Name,
+			Proxy:    entry.Proxy,
+			Schema:   schema,
+			Location: node.Location,
+			Reason:   node.Reason,
+		})
+
+		if len(node.Variants) == 0 {
+			continue
+		}
+		union := &Union{Name: entry.Name, Variants: node.Variants}
+		if schema != nil && schema.Discriminator != nil {
+			union.Discriminator = schema.Discriminator.PropertyName
+		}
+		result.Unions = append(result.Unions, union)
+	}
+
+	return result, nil
+}
+
+// Schema returns the entry of the component schema named name, or nil when
+// there is none.
+func (d *Document) Schema(name string) *SchemaEntry {
+	for _, entry := range d.Schemas {
+		if entry.Name == name {
+			return entry
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	doc, err := schema.Parse([]byte(dependenciesSpec))
+	require.NoError(t, err)
+
+	assert.Equal(t, "3.0.0", doc.Version)
+	assert.Equal(t, "Test API", doc.Model.Info.Title)
+
+	var names []string
+	for _, entry := range doc.Schemas {
+		names = append(names, entry.Name)
+	}
+	assert.Equal(t, []string{"Owner", "Address", "Pet", "Dog", "Cat"}, names)
+
+	address := doc.Schema("Address")
+	require.NotNil(t, address)
+	assert.Equal(t, schema.TypeLocationProto, address.Location)
+	assert.Empty(t, address.Reason)
+	require.NotNil(t, address.Schema)
+	assert.Equal(t, []string{"object"}, address.Schema.Type)
+	_, ok := address.Schema.Properties.Get("street")
+	assert.True(t, ok)
+
+	owner := doc.Schema("Owner")
+	require.NotNil(t, owner)
+	assert.Equal(t, schema.TypeLocationGolang, owner.Location)
+	assert.Equal(t, "references union type Pet", owner.Reason)
+
+	assert.Equal(t, []*schema.Union{
+		{Name: "Pet", Variants: []string{"Dog", "Cat"}, Discriminator: "petType"},
+	}, doc.Unions)
+
+	analysis, err := schema.AnalyzeDependencies([]byte(dependenciesSpec))
+	require.NoError(t, err)
+	assert.Equal(t, analysis, doc.Dependencies)
+
+	assert.Nil(t, doc.Schema("Missing"))
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		given   string
+		wantErr string
+	}{
+		{
+			name:    "empty",
+			wantErr: "openapi input cannot be empty",
+		},
+		{
+			name:    "not openapi 3",
+			given:   "swagger: '2.0'\ninfo:\n  title: Test API\n  version: 1.0.0\n",
+			wantErr: "failed to build OpenAPI model",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := schema.Parse([]byte(test.given))
+			require.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
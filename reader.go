@@ -0,0 +1,88 @@
+package schema
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+)
+
+// ConvertReader is like Convert but reads the OpenAPI specification from r.
+//
+// The document is not streamed: r is read to the end before conversion starts,
+// since the parser needs the whole document, so peak memory is that of Convert
+// on the same bytes. When r reports its size (*os.File, *bytes.Reader,
+// *strings.Reader, *bytes.Buffer) it is read into a buffer of that size.
+func ConvertReader(r io.Reader, opts ConvertOptions) (*ConvertResult, error) {
+	openapi, err := readSpec(r)
+	if err != nil {
+		return nil, err
+	}
+	return Convert(openapi, opts)
+}
+
+// ConvertToStructReader is like ConvertToStruct but reads the OpenAPI specification from r.
+func ConvertToStructReader(r io.Reader, opts ConvertOptions) (*StructResult, error) {
+	openapi, err := readSpec(r)
+	if err != nil {
+		return nil, err
+	}
+	return ConvertToStruct(openapi, opts)
+}
+
+// ConvertToExamplesReader is like ConvertToExamples but reads the OpenAPI specification from r.
+func ConvertToExamplesReader(r io.Reader, opts ExampleOptions) (*ExampleResult, error) {
+	openapi, err := readSpec(r)
+	if err != nil {
+		return nil, err
+	}
+	return ConvertToExamples(openapi, opts)
+}
+
+// ValidateExamplesReader is like ValidateExamples but reads the OpenAPI specification from r.
+func ValidateExamplesReader(r io.Reader, opts ValidateOptions) (*ValidationResult, error) {
+	openapi, err := readSpec(r)
+	if err != nil {
+		return nil, err
+	}
+	return ValidateExamples(openapi, opts)
+}
+
+// LintReader is like Lint but reads the OpenAPI specification from r.
+func LintReader(r io.Reader, opts LintOptions) (*LintReport, error) {
+	openapi, err := readSpec(r)
+	if err != nil {
+		return nil, err
+	}
+	return Lint(openapi, opts)
+}
+
+// readSpec reads the whole specification, sizing the buffer up front when the
+// reader can report how many bytes remain.
+func readSpec(r io.Reader) ([]byte, error) {
+	if r == nil {
+		return nil, fmt.Errorf("openapi reader cannot be nil")
+	}
+
+	var size int64
+	switch v := r.(type) {
+	case interface{ Len() int }:
+		size = int64(v.Len())
+	case interface{ Stat() (fs.FileInfo, error) }:
+		if info, err := v.Stat(); err == nil && info.Mode().IsRegular() {
+			size = info.Size()
+		}
+	}
+
+	var buf bytes.Buffer
+	if size > 0 {
+		// ReadFrom insists on MinRead bytes of headroom before each read, so reserve
+		// it up front to observe EOF without reallocating.
+		buf.Grow(int(size) + bytes.MinRead)
+	}
+
+	if _, err := buf.ReadFrom(r); err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI document: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,23 @@
+package schema
+
+import "github.com/duh-rpc/openapi-schema.go/internal"
+
+// ConversionError is the error an entry point returns in place of a panic, so a
+// malformed spec or a libopenapi edge case cannot crash a long-running program
+// embedding the package. Op names the entry point, Value is what was passed to
+// panic, and Stack is the stack of the goroutine that panicked. errors.As finds
+// it, and errors.Is and errors.As see through it to Value when that is an error.
+type ConversionError = internal.ConversionError
+
+// ClassInternal is the Violation class of a panic recovered while validating a
+// body, reported in place of the violations that could not be determined
+const ClassInternal = "internal"
+
+// recoverViolations turns a panic while validating a body into a ClassInternal
+// violation in *violations. It must be deferred directly, as recover only
+// stops a panic there.
+func recoverViolations(in, op string, violations *[]Violation) {
+	if r := recover(); r != nil {
+		*violations = []Violation{{In: in, Class: ClassInternal, Message: internal.NewConversionError(op, r).Error()}}
+	}
+}
@@ -0,0 +1,102 @@
+package schema_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const recoverSpec = `openapi: 3.1.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/User'
+      responses:
+        '200':
+          description: OK
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        name:
+          type: string
+        email:
+          type: string
+          format: company-email
+    Team:
+      type: object
+      properties:
+        title:
+          type: string
+`
+
+func TestConvertToExamplesRecoversPanic(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		concurrency int
+	}{
+		{name: "sequential"},
+		{name: "parallel", concurrency: 2},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := schema.ConvertToExamples([]byte(recoverSpec), schema.ExampleOptions{
+				IncludeAll:  true,
+				Seed:        1,
+				Concurrency: test.concurrency,
+				ValueFunc: func(schemaName, fieldPath string, info schema.ExampleFieldInfo) (interface{}, bool) {
+					if fieldPath == "title" {
+						var values map[string]string
+						values["title"] = "boom"
+					}
+					return nil, false
+				},
+			})
+			require.ErrorContains(t, err, "ConvertToExamples: recovered from panic: assignment to entry in nil map")
+			assert.Nil(t, result)
+
+			var conversionErr *schema.ConversionError
+			require.True(t, errors.As(err, &conversionErr))
+			assert.Equal(t, "ConvertToExamples", conversionErr.Op)
+			assert.Contains(t, string(conversionErr.Stack), "recover_test.go")
+
+			var runtimeErr runtime.Error
+			assert.True(t, errors.As(err, &runtimeErr))
+		})
+	}
+}
+
+func TestValidatorRecoversPanic(t *testing.T) {
+	formats, err := schema.NewFormatRegistry(schema.Format{
+		Name: "company-email",
+		Validate: func(value string) error {
+			panic("validator exploded")
+		},
+	})
+	require.NoError(t, err)
+
+	validator, err := schema.NewValidator([]byte(recoverSpec), schema.ValidatorOptions{Formats: formats})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/users", strings.NewReader(`{"email":"a@example.com"}`))
+	req.Header.Set("Content-Type", "application/json")
+	violations := validator.ValidateRequest(req, []byte(`{"email":"a@example.com"}`))
+	assert.Equal(t, []schema.Violation{{
+		In:      "request",
+		Class:   schema.ClassInternal,
+		Message: "ValidateRequest: recovered from panic: validator exploded",
+	}}, violations)
+}
@@ -0,0 +1,139 @@
+package schema
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/duh-rpc/openapi-schema.go/internal/parser"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// route matches requests to an operation by its path template
+type route struct {
+	pattern *regexp.Regexp // matches the operation's path template
+	params  int            // path parameters in the template, fewer are more specific
+	op      *parser.OperationEntry
+}
+
+// router finds the operation of the spec a request is for
+type router struct {
+	routes   []*route // literal paths before templated ones
+	basePath string
+}
+
+// newRouter returns a router over operations, stripping basePath from request
+// paths before matching
+func newRouter(operations []*parser.OperationEntry, basePath string) *router {
+	r := &router{basePath: strings.TrimSuffix(basePath, "/")}
+	for _, op := range operations {
+		pattern, params := pathPattern(op.Path)
+		r.routes = append(r.routes, &route{pattern: pattern, params: params, op: op})
+	}
+	sort.SliceStable(r.routes, func(i, j int) bool {
+		return r.routes[i].params < r.routes[j].params
+	})
+	return r
+}
+
+// match returns the operation for method and path, preferring literal paths to
+// templated ones. When none matches it returns nil and the methods of the
+// operations matching path, if any.
+func (r *router) match(method, path string) (*parser.OperationEntry, []string) {
+	if r.basePath != "" {
+		trimmed, ok := strings.CutPrefix(path, r.basePath)
+		if !ok {
+			return nil, nil
+		}
+		path = trimmed
+	}
+
+	var allowed []string
+	for _, candidate := range r.routes {
+		if !candidate.pattern.MatchString(path) {
+			continue
+		}
+		if candidate.op.Method == method {
+			return candidate.op, nil
+		}
+		if !internal.Contains(allowed, candidate.op.Method) {
+			allowed = append(allowed, candidate.op.Method)
+		}
+	}
+	return nil, allowed
+}
+
+// pathPattern compiles a path template to a regular expression matching it,
+// each {param} matching one non-empty path segment, and counts its parameters
+func pathPattern(template string) (*regexp.Regexp, int) {
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	params := 0
+	for rest := template; rest != ""; {
+		open := strings.IndexByte(rest, '{')
+		end := strings.IndexByte(rest, '}')
+		if open < 0 || end < open {
+			pattern.WriteString(regexp.QuoteMeta(rest))
+			break
+		}
+		pattern.WriteString(regexp.QuoteMeta(rest[:open]))
+		pattern.WriteString("[^/]+")
+		params++
+		rest = rest[end+1:]
+	}
+	pattern.WriteString("$")
+	return regexp.MustCompile(pattern.String()), params
+}
+
+// responseStatuses returns the response statuses op declares, default last
+func responseStatuses(op *parser.OperationEntry) []string {
+	var statuses []string
+	if op.Responses == nil {
+		return statuses
+	}
+	if op.Responses.Codes != nil {
+		for status := range op.Responses.Codes.KeysFromOldest() {
+			statuses = append(statuses, status)
+		}
+	}
+	if op.Responses.Default != nil {
+		statuses = append(statuses, "default")
+	}
+	return statuses
+}
+
+// declaredResponse returns the response op declares for an HTTP status code:
+// the exact code, else its range such as 4XX, else default, else nil
+func declaredResponse(op *parser.OperationEntry, code int) *v3.Response {
+	if op.Responses == nil {
+		return nil
+	}
+	if op.Responses.Codes != nil {
+		exact := strconv.Itoa(code)
+		for status, resp := range op.Responses.Codes.FromOldest() {
+			if status == exact {
+				return resp
+			}
+		}
+		for status, resp := range op.Responses.Codes.FromOldest() {
+			if len(exact) == 3 && strings.EqualFold(status, exact[:1]+"XX") {
+				return resp
+			}
+		}
+	}
+	return op.Responses.Default
+}
+
+// statusCode returns the HTTP status code for a declared status: ranges such as
+// 4XX send their first code, and default sends 200
+func statusCode(status string) int {
+	if code, err := strconv.Atoi(status); err == nil {
+		return code
+	}
+	if len(status) == 3 && strings.EqualFold(status[1:], "XX") && status[0] >= '1' && status[0] <= '5' {
+		return int(status[0]-'0') * 100
+	}
+	return 200
+}
@@ -0,0 +1,155 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/duh-rpc/openapi-schema.go/internal/parser"
+	"github.com/duh-rpc/openapi-schema.go/internal/sql"
+)
+
+// SQLDialect selects the SQL dialect ConvertToSQL writes
+type SQLDialect string
+
+const (
+	// SQLDialectPostgres writes PostgreSQL DDL. The default.
+	SQLDialectPostgres SQLDialect = sql.DialectPostgres
+)
+
+// validate reports an error for dialects other than the declared constants.
+func (d SQLDialect) validate() error {
+	switch d {
+	case "", SQLDialectPostgres:
+		return nil
+	}
+	return fmt.Errorf("unknown Dialect %q: must be postgres", string(d))
+}
+
+// SQLOptions configures ConvertToSQL and NewSQLGenerator.
+type SQLOptions struct {
+	// Dialect selects the SQL dialect. Empty → SQLDialectPostgres.
+	Dialect SQLDialect
+	// SchemaNames limits output to the named component schemas and the schemas
+	// they reference, directly or transitively. Empty → every schema. Naming a
+	// schema that does not exist is an error.
+	SchemaNames []string
+}
+
+// SQLResult holds the output of ConvertToSQL.
+type SQLResult struct {
+	// SQL holds one CREATE TABLE statement per table, in document order.
+	SQL []byte
+}
+
+// ConvertToSQL generates CREATE TABLE statements for the component schemas
+// that are objects with properties, for teams deriving persistence from their
+// API models. Other schemas, such as enums, unions and arrays, get no table.
+//
+// Tables and columns are the snake_case schema and property names. Required
+// properties are NOT NULL unless nullable, and enums are checked with a CHECK
+// constraint. Scalars map to the matching column type by type and format, e.g.
+// date-time to timestamptz and int64 to bigint; nested objects, arrays and
+// unions are stored as jsonb. Extensions refine the mapping:
+//
+//   - x-db-table and x-db-column rename a table or column
+//   - x-db-type sets the column type of a property, or of every property
+//     referencing a schema, e.g. uuid or numeric(12, 2)
+//   - x-db-primary-key: true makes a property part of the primary key
+//   - x-db-skip: true leaves out a schema's table or a property's column
+func ConvertToSQL(openapi []byte, opts SQLOptions) (*SQLResult, error) {
+	return ConvertToSQLContext(context.Background(), openapi, opts)
+}
+
+// ConvertToSQLContext is like ConvertToSQL but stops early with an error
+// wrapping ctx.Err() when ctx is cancelled or its deadline expires.
+// Cancellation is checked before parsing and between schemas.
+func ConvertToSQLContext(ctx context.Context, openapi []byte, opts SQLOptions) (_ *SQLResult, err error) {
+	defer internal.Recover("ConvertToSQL", &err)
+
+	if len(openapi) == 0 {
+		return nil, fmt.Errorf("openapi input cannot be empty")
+	}
+	if err := opts.Dialect.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := internal.Cancelled(ctx); err != nil {
+		return nil, err
+	}
+
+	doc, err := parser.ParseDocument(openapi)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas, err := doc.Schemas()
+	if err != nil {
+		return nil, err
+	}
+
+	ddl, err := buildSQL(ctx, schemas, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &SQLResult{SQL: ddl}, nil
+}
+
+// buildSQL renders the tables of schemas
+func buildSQL(ctx context.Context, schemas []*parser.SchemaEntry, opts SQLOptions) ([]byte, error) {
+	if len(opts.SchemaNames) > 0 {
+		var err error
+		if schemas, err = parser.SelectSchemas(schemas, opts.SchemaNames); err != nil {
+			return nil, err
+		}
+	}
+
+	sqlCtx := sql.NewContext()
+	sqlCtx.Ctx = ctx
+	if opts.Dialect != "" {
+		sqlCtx.Dialect = string(opts.Dialect)
+	}
+	if err := sql.BuildTables(schemas, sqlCtx); err != nil {
+		return nil, err
+	}
+	return sql.Generate(sqlCtx), nil
+}
+
+// NewSQLGenerator returns a Generator named "sql" writing the output of
+// ConvertToSQL to schema.sql, for running with other generators through
+// Generate. The generator option "dialect" overrides opts.Dialect.
+func NewSQLGenerator(opts SQLOptions) Generator {
+	return sqlGenerator{opts: opts}
+}
+
+// sqlGenerator is the Generator returned by NewSQLGenerator
+type sqlGenerator struct {
+	opts SQLOptions
+}
+
+// Name returns "sql", the name Generate selects the generator by.
+func (g sqlGenerator) Name() string { return "sql" }
+
+// Generate writes the tables of model's schemas to schema.sql.
+func (g sqlGenerator) Generate(model *Model) ([]OutputFile, error) {
+	opts := g.opts
+	for key, value := range model.Options {
+		if key != "dialect" {
+			return nil, fmt.Errorf("unknown option %q: must be dialect", key)
+		}
+		opts.Dialect = SQLDialect(value)
+	}
+	if err := opts.Dialect.validate(); err != nil {
+		return nil, err
+	}
+
+	schemas := make([]*parser.SchemaEntry, len(model.Schemas))
+	for i, entry := range model.Schemas {
+		schemas[i] = &parser.SchemaEntry{Name: entry.Name, Proxy: entry.Proxy}
+	}
+	ddl, err := buildSQL(model.Context(), schemas, opts)
+	if err != nil {
+		return nil, err
+	}
+	return []OutputFile{{Path: "schema.sql", Content: ddl}}, nil
+}
@@ -0,0 +1,149 @@
+//go:build ignore
+
+// gen writes the generated specs of the benchmark corpus. Run it from this
+// directory with `go run gen.go` after changing a shape below; small.yaml is
+// maintained by hand.
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+const header = `openapi: 3.0.0
+info:
+  title: %s
+  version: 1.0.0
+components:
+  schemas:
+`
+
+func main() {
+	files := map[string]string{
+		"medium.yaml": mixed("Medium corpus", 100),
+		"huge.yaml":   mixed("Huge corpus", 1000),
+		"deep.yaml":   deep("Deep corpus", 32, 200),
+		"wide.yaml":   wide("Wide corpus", 1000),
+	}
+	for name, spec := range files {
+		if err := os.WriteFile(name, []byte(spec), 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// mixed produces count schemas cycling through the shapes real specs are made
+// of: objects referencing earlier objects, integer and string enums, arrays,
+// timestamps, and a discriminated union every 25 schemas so the transitive
+// closure has Go types to propagate.
+func mixed(title string, count int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, header, title)
+	for i := 0; i < count; i++ {
+		switch {
+		case i%25 == 24:
+			fmt.Fprintf(&b, `    Union%d:
+      oneOf:
+        - $ref: '#/components/schemas/Object%d'
+        - $ref: '#/components/schemas/Object%d'
+      discriminator:
+        propertyName: kind
+`, i, previousObject(i), previousObject(previousObject(i)))
+		case i%10 == 5:
+			fmt.Fprintf(&b, `    Status%d:
+      type: string
+      enum: [active, inactive, pending]
+`, i)
+		case i%10 == 7:
+			fmt.Fprintf(&b, `    Priority%d:
+      type: integer
+      enum: [1, 2, 3]
+`, i)
+		default:
+			fmt.Fprintf(&b, `    Object%d:
+      type: object
+      description: Object number %d
+      properties:
+        kind:
+          type: string
+        id:
+          type: string
+          format: uuid
+        count:
+          type: integer
+          format: int64
+        createdAt:
+          type: string
+          format: date-time
+        tags:
+          type: array
+          items:
+            type: string
+`, i, i)
+			if prev := previousObject(i); prev >= 0 {
+				fmt.Fprintf(&b, `        parent:
+          $ref: '#/components/schemas/Object%d'
+        children:
+          type: array
+          items:
+            $ref: '#/components/schemas/Object%d'
+`, prev, prev)
+			}
+			if i >= 25 && i%25 == 3 {
+				fmt.Fprintf(&b, `        variant:
+          $ref: '#/components/schemas/Union%d'
+`, i-4)
+			}
+		}
+	}
+	return b.String()
+}
+
+// previousObject returns the index of the closest earlier ObjectN, or -1
+func previousObject(i int) int {
+	for j := i - 1; j >= 0; j-- {
+		if j%25 != 24 && j%10 != 5 && j%10 != 7 {
+			return j
+		}
+	}
+	return -1
+}
+
+// deep produces one schema with levels of inline nested objects and a chain of
+// chain schemas each referencing the next.
+func deep(title string, levels, chain int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, header, title)
+	b.WriteString("    Root:\n      type: object\n      properties:\n")
+	indent := "        "
+	for i := 0; i < levels; i++ {
+		fmt.Fprintf(&b, "%slevel%d:\n%s  type: object\n%s  properties:\n", indent, i, indent, indent)
+		fmt.Fprintf(&b, "%s    name:\n%s      type: string\n", indent, indent)
+		indent += "    "
+	}
+	fmt.Fprintf(&b, "%sleaf:\n%s  type: string\n", indent, indent)
+
+	for i := 0; i < chain; i++ {
+		fmt.Fprintf(&b, "    Chain%d:\n      type: object\n      properties:\n        value:\n          type: integer\n", i)
+		if i+1 < chain {
+			fmt.Fprintf(&b, "        next:\n          $ref: '#/components/schemas/Chain%d'\n", i+1)
+		}
+	}
+	return b.String()
+}
+
+// wide produces objects with many properties each
+func wide(title string, props int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, header, title)
+	types := []string{"type: string", "type: integer", "type: number", "type: boolean", "type: string\n          format: date-time"}
+	for _, name := range []string{"WideA", "WideB", "WideC"} {
+		fmt.Fprintf(&b, "    %s:\n      type: object\n      properties:\n", name)
+		for i := 0; i < props; i++ {
+			fmt.Fprintf(&b, "        field%d:\n          %s\n", i, types[i%len(types)])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,126 @@
+// Package testutil compares generated proto, Go and example output against
+// golden files in consumer test suites. Setting UPDATE_GOLDEN rewrites the
+// golden files from the current output instead of comparing:
+//
+//	UPDATE_GOLDEN=1 go test ./...
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// UpdateEnv is the environment variable that makes the helpers rewrite golden
+// files rather than compare against them.
+const UpdateEnv = "UPDATE_GOLDEN"
+
+// Update reports whether golden files are being rewritten: UpdateEnv is set to
+// anything but a false value such as 0 or false.
+func Update() bool {
+	value := os.Getenv(UpdateEnv)
+	if value == "" {
+		return false
+	}
+	update, err := strconv.ParseBool(value)
+	return err != nil || update
+}
+
+// Golden compares got with the golden file at path, reporting a diff on
+// mismatch. Under Update it writes got to path, creating directories as needed.
+func Golden(t testing.TB, path string, got []byte) {
+	t.Helper()
+	if Update() {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, got, 0o644))
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		require.Failf(t, "missing golden file", "%s does not exist; run with %s=1 to create it", path, UpdateEnv)
+	}
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got), "golden file %s differs; run with %s=1 to update it", path, UpdateEnv)
+}
+
+// GoldenConvert compares the proto and Go output of a conversion with the
+// golden files base+".proto" and base+".go". Output that is empty, such as the
+// proto of a spec whose types all use unions, expects no golden file.
+func GoldenConvert(t testing.TB, base string, result *schema.ConvertResult) {
+	t.Helper()
+	require.NotNil(t, result)
+	goldenOptional(t, base+".proto", result.Protobuf)
+	goldenOptional(t, base+".go", result.Golang)
+}
+
+// GoldenStruct compares the Go output of ConvertToStruct with the golden file
+// at path.
+func GoldenStruct(t testing.TB, path string, result *schema.StructResult) {
+	t.Helper()
+	require.NotNil(t, result)
+	Golden(t, path, result.Golang)
+}
+
+// GoldenExamples compares each example with the golden file dir/<schema>.json,
+// indented so diffs are readable. dir holds only these golden files: a golden
+// file without an example fails the comparison, and Update removes it.
+func GoldenExamples(t testing.TB, dir string, result *schema.ExampleResult) {
+	t.Helper()
+	require.NotNil(t, result)
+
+	names := make([]string, 0, len(result.Examples))
+	for name := range result.Examples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		var indented bytes.Buffer
+		require.NoError(t, json.Indent(&indented, result.Examples[name], "", "  "))
+		indented.WriteByte('\n')
+		Golden(t, filepath.Join(dir, name+".json"), indented.Bytes())
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	require.NoError(t, err)
+	for _, file := range files {
+		if _, ok := result.Examples[strings.TrimSuffix(filepath.Base(file), ".json")]; ok {
+			continue
+		}
+		if Update() {
+			require.NoError(t, os.Remove(file))
+			continue
+		}
+		assert.Failf(t, "stale golden file", "%s has no example; run with %s=1 to remove it", file, UpdateEnv)
+	}
+}
+
+// goldenOptional compares got with the golden file at path like Golden, except
+// that empty output expects no golden file, and Update removes one.
+func goldenOptional(t testing.TB, path string, got []byte) {
+	t.Helper()
+	if len(got) > 0 {
+		Golden(t, path, got)
+		return
+	}
+
+	if Update() {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			require.NoError(t, err)
+		}
+		return
+	}
+	assert.NoFileExists(t, path, "output is empty; run with %s=1 to remove the golden file", UpdateEnv)
+}
@@ -0,0 +1,168 @@
+package testutil_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/duh-rpc/openapi-schema.go/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const goldenSpec = `openapi: 3.1.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        name:
+          type: string
+          example: Ada
+`
+
+// recorder is a testing.TB whose failures are recorded rather than failing the
+// test running it
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) FailNow() {
+	runtime.Goexit()
+}
+
+// record runs fn against a recorder, returning the failures it reported
+func record(t *testing.T, fn func(testing.TB)) []string {
+	r := &recorder{TB: t}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(r)
+	}()
+	<-done
+	return r.errors
+}
+
+func TestUpdate(t *testing.T) {
+	for _, test := range []struct {
+		value string
+		want  bool
+	}{
+		{value: "", want: false},
+		{value: "0", want: false},
+		{value: "false", want: false},
+		{value: "1", want: true},
+		{value: "true", want: true},
+		{value: "yes", want: true},
+	} {
+		t.Run(test.value, func(t *testing.T) {
+			t.Setenv(testutil.UpdateEnv, test.value)
+			assert.Equal(t, test.want, testutil.Update())
+		})
+	}
+}
+
+func TestGolden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "out.txt")
+
+	t.Setenv(testutil.UpdateEnv, "")
+	errs := record(t, func(tb testing.TB) { testutil.Golden(tb, path, []byte("one\n")) })
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0], "does not exist; run with UPDATE_GOLDEN=1 to create it")
+
+	t.Setenv(testutil.UpdateEnv, "1")
+	testutil.Golden(t, path, []byte("one\n"))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "one\n", string(data))
+
+	t.Setenv(testutil.UpdateEnv, "")
+	testutil.Golden(t, path, []byte("one\n"))
+
+	errs = record(t, func(tb testing.TB) { testutil.Golden(tb, path, []byte("two\n")) })
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0], "-one")
+	assert.Contains(t, errs[0], "+two")
+	assert.Contains(t, errs[0], "differs; run with UPDATE_GOLDEN=1 to update it")
+}
+
+func TestGoldenConvert(t *testing.T) {
+	base := filepath.Join(t.TempDir(), "user")
+	result, err := schema.Convert([]byte(goldenSpec), schema.ConvertOptions{
+		PackageName: "testpkg",
+		PackagePath: "github.com/example/proto/v1",
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, result.Protobuf)
+	require.Empty(t, result.Golang)
+
+	// A stale Go golden file is removed, as the conversion has no Go output
+	require.NoError(t, os.WriteFile(base+".go", []byte("package stale\n"), 0o644))
+	t.Setenv(testutil.UpdateEnv, "1")
+	testutil.GoldenConvert(t, base, result)
+	assert.FileExists(t, base+".proto")
+	assert.NoFileExists(t, base+".go")
+
+	t.Setenv(testutil.UpdateEnv, "")
+	testutil.GoldenConvert(t, base, result)
+
+	require.NoError(t, os.WriteFile(base+".go", []byte("package stale\n"), 0o644))
+	errs := record(t, func(tb testing.TB) { testutil.GoldenConvert(tb, base, result) })
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0], "output is empty; run with UPDATE_GOLDEN=1 to remove the golden file")
+}
+
+func TestGoldenStruct(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "user.go")
+	result, err := schema.ConvertToStruct([]byte(goldenSpec), schema.ConvertOptions{
+		GoPackagePath: "github.com/example/types/v1",
+	})
+	require.NoError(t, err)
+
+	t.Setenv(testutil.UpdateEnv, "1")
+	testutil.GoldenStruct(t, path, result)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(result.Golang), string(data))
+
+	t.Setenv(testutil.UpdateEnv, "")
+	testutil.GoldenStruct(t, path, result)
+}
+
+func TestGoldenExamples(t *testing.T) {
+	dir := t.TempDir()
+	result, err := schema.ConvertToExamples([]byte(goldenSpec), schema.ExampleOptions{
+		IncludeAll: true,
+		Seed:       1,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Removed.json"), []byte("{}\n"), 0o644))
+	t.Setenv(testutil.UpdateEnv, "1")
+	testutil.GoldenExamples(t, dir, result)
+	data, err := os.ReadFile(filepath.Join(dir, "User.json"))
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"name\": \"Ada\"\n}\n", string(data))
+	assert.NoFileExists(t, filepath.Join(dir, "Removed.json"))
+
+	t.Setenv(testutil.UpdateEnv, "")
+	testutil.GoldenExamples(t, dir, result)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Removed.json"), []byte("{}\n"), 0o644))
+	errs := record(t, func(tb testing.TB) { testutil.GoldenExamples(tb, dir, result) })
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0], "Removed.json has no example; run with UPDATE_GOLDEN=1 to remove it")
+}
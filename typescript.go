@@ -0,0 +1,89 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/duh-rpc/openapi-schema.go/internal/parser"
+	"github.com/duh-rpc/openapi-schema.go/internal/typescript"
+)
+
+// TypeScriptOptions configures ConvertToTypeScript.
+type TypeScriptOptions struct {
+	// SchemaNames limits output to the named component schemas and the schemas
+	// they reference, directly or transitively. Empty → every schema. Naming a
+	// schema that does not exist is an error.
+	SchemaNames []string
+	// NestedNameFunc names the interfaces generated for inline objects, as
+	// ConvertOptions.NestedNameFunc names Go structs, so both outputs agree.
+	NestedNameFunc func(parent, property string) string
+}
+
+// TypeScriptResult holds the output of ConvertToTypeScript.
+type TypeScriptResult struct {
+	// TypeScript is a declaration file (.d.ts) exporting one type per schema, in
+	// document order.
+	TypeScript []byte
+}
+
+// ConvertToTypeScript generates TypeScript declarations (.d.ts) for the
+// component schemas, so frontend code shares the models of the Go and proto
+// output. Types are named as the Go structs are: by schema name or x-go-name,
+// with inline objects named after the enclosing type and property.
+//
+// Objects with properties become interfaces, with properties missing from
+// required marked optional (?) and readOnly properties readonly. Other schemas
+// become type aliases: enums as unions of literals, oneOf and anyOf as unions,
+// allOf as intersections and nullable schemas as unions with null. A oneOf with
+// a discriminator becomes a tagged union, each variant narrowing the
+// discriminator property to its values:
+//
+//	export type Pet = (Dog & { petType: "dog" }) | (Cat & { petType: "cat" });
+//
+// Integers are number whatever their format, and string formats such as
+// date-time are string, matching their JSON form.
+func ConvertToTypeScript(openapi []byte, opts TypeScriptOptions) (*TypeScriptResult, error) {
+	return ConvertToTypeScriptContext(context.Background(), openapi, opts)
+}
+
+// ConvertToTypeScriptContext is like ConvertToTypeScript but stops early with
+// an error wrapping ctx.Err() when ctx is cancelled or its deadline expires.
+// Cancellation is checked before parsing and between schemas.
+func ConvertToTypeScriptContext(ctx context.Context, openapi []byte, opts TypeScriptOptions) (_ *TypeScriptResult, err error) {
+	defer internal.Recover("ConvertToTypeScript", &err)
+
+	if len(openapi) == 0 {
+		return nil, fmt.Errorf("openapi input cannot be empty")
+	}
+
+	if err := internal.Cancelled(ctx); err != nil {
+		return nil, err
+	}
+
+	doc, err := parser.ParseDocument(openapi)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas, err := doc.Schemas()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(opts.SchemaNames) > 0 {
+		schemas, err = parser.SelectSchemas(schemas, opts.SchemaNames)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tsCtx := typescript.NewContext()
+	tsCtx.Ctx = ctx
+	tsCtx.NestedNameFunc = opts.NestedNameFunc
+	if err := typescript.BuildDeclarations(schemas, tsCtx); err != nil {
+		return nil, err
+	}
+
+	return &TypeScriptResult{TypeScript: typescript.Generate(tsCtx)}, nil
+}
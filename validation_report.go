@@ -0,0 +1,257 @@
+package schema
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SARIF rule IDs of ValidationResult.ToSARIF results
+const (
+	sarifRuleInvalid = "invalid-example"
+	sarifRuleWarning = "example-warning"
+)
+
+// ToJSON renders the result as indented JSON, with schemas and media types in
+// sorted order.
+func (r *ValidationResult) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// ToSARIF renders the issues as a SARIF 2.1.0 log, for code-scanning UIs.
+// specURI is the spec's path relative to the repository root (e.g.
+// "api/openapi.yaml"), where results are located by line; each result also
+// names the JSON pointer of its example as a logical location.
+func (r *ValidationResult) ToSARIF(specURI string) ([]byte, error) {
+	results := []sarifResult{}
+	for _, entry := range r.entries() {
+		for _, issue := range entry.result.Issues {
+			result := sarifResult{
+				RuleID:  sarifRuleInvalid,
+				Level:   "error",
+				Message: sarifMessage{Text: issue.Message},
+			}
+			if issue.Severity == IssueSeverityWarning {
+				result.RuleID, result.Level = sarifRuleWarning, "warning"
+			}
+
+			location := sarifLocation{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifact{URI: specURI}}}
+			if issue.Line > 0 {
+				location.PhysicalLocation.Region = &sarifRegion{StartLine: issue.Line}
+			}
+			if issue.Path != "" {
+				location.LogicalLocations = []sarifLogicalLocation{{FullyQualifiedName: issue.Path}}
+			}
+			result.Locations = []sarifLocation{location}
+			results = append(results, result)
+		}
+	}
+
+	return json.MarshalIndent(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "openapi-schema",
+				InformationURI: "https://" + modulePath,
+				Rules: []sarifRule{
+					{ID: sarifRuleInvalid, ShortDescription: sarifMessage{Text: "Example does not match its schema"}},
+					{ID: sarifRuleWarning, ShortDescription: sarifMessage{Text: "Example validation warning"}},
+				},
+			}},
+			Results: results,
+		}},
+	}, "", "  ")
+}
+
+// ToJUnitXML renders the result as a JUnit XML report, for CI test dashboards:
+// a "schemas" suite with a test case per component schema, and an "examples"
+// suite with one per media type or parameter holding examples. Invalid results
+// fail their test case with every issue; warnings of valid ones are reported in
+// its system-out.
+func (r *ValidationResult) ToJUnitXML() ([]byte, error) {
+	report := junitTestSuites{Name: "openapi examples"}
+	for _, suite := range []struct {
+		name    string
+		results map[string]*SchemaValidationResult
+	}{
+		{name: "schemas", results: r.Schemas},
+		{name: "examples", results: r.Examples},
+	} {
+		if len(suite.results) == 0 {
+			continue
+		}
+
+		junitSuite := junitTestSuite{Name: suite.name}
+		for _, key := range sortedKeys(suite.results) {
+			result := suite.results[key]
+			testCase := junitTestCase{Name: key, ClassName: suite.name}
+
+			var failures, warnings []string
+			for _, issue := range result.Issues {
+				if issue.Severity == IssueSeverityWarning && result.Valid {
+					warnings = append(warnings, issueText(issue))
+					continue
+				}
+				failures = append(failures, issueText(issue))
+			}
+			if !result.Valid {
+				testCase.Failure = &junitFailure{
+					Message: fmt.Sprintf("%d invalid example issue(s)", len(failures)),
+					Type:    "invalid-example",
+					Text:    strings.Join(failures, "\n"),
+				}
+				junitSuite.Failures++
+			}
+			if len(warnings) > 0 {
+				testCase.SystemOut = strings.Join(warnings, "\n")
+			}
+			junitSuite.Tests++
+			junitSuite.TestCases = append(junitSuite.TestCases, testCase)
+		}
+
+		report.Tests += junitSuite.Tests
+		report.Failures += junitSuite.Failures
+		report.Suites = append(report.Suites, junitSuite)
+	}
+
+	out, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(out, '\n')...), nil
+}
+
+// validationEntry is a schema or media type result with its key
+type validationEntry struct {
+	key    string
+	result *SchemaValidationResult
+}
+
+// entries returns the schema results then the example results, each in sorted
+// key order
+func (r *ValidationResult) entries() []validationEntry {
+	var entries []validationEntry
+	for _, results := range []map[string]*SchemaValidationResult{r.Schemas, r.Examples} {
+		for _, key := range sortedKeys(results) {
+			entries = append(entries, validationEntry{key: key, result: results[key]})
+		}
+	}
+	return entries
+}
+
+// sortedKeys returns the keys of results in sorted order
+func sortedKeys(results map[string]*SchemaValidationResult) []string {
+	keys := make([]string, 0, len(results))
+	for key := range results {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// issueText describes an issue on one line, led by its example and line
+func issueText(issue ValidationIssue) string {
+	var where []string
+	if issue.Path != "" {
+		where = append(where, issue.Path)
+	} else if issue.ExampleField != "" {
+		where = append(where, issue.ExampleField)
+	}
+	if issue.Line > 0 {
+		where = append(where, fmt.Sprintf("line %d", issue.Line))
+	}
+	if len(where) == 0 {
+		return issue.Message
+	}
+	return strings.Join(where, " ") + ": " + issue.Message
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifact `json:"artifactLocation"`
+	Region           *sarifRegion  `json:"region,omitempty"`
+}
+
+type sarifArtifact struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
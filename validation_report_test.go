@@ -0,0 +1,149 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const reportSpec = `openapi: 3.1.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+      example:
+        name: 42
+    User:
+      type: object
+      properties:
+        name:
+          type: string
+      example:
+        name: Ada
+`
+
+func validateReportSpec(t *testing.T) *schema.ValidationResult {
+	result, err := schema.ValidateExamples([]byte(reportSpec), schema.ValidateOptions{IncludeAll: true})
+	require.NoError(t, err)
+	return result
+}
+
+func TestValidationResultToJSON(t *testing.T) {
+	out, err := validateReportSpec(t).ToJSON()
+	require.NoError(t, err)
+
+	var decoded struct {
+		Schemas map[string]struct {
+			SchemaPath string `json:"schemaPath"`
+			Valid      bool   `json:"valid"`
+			Issues     []struct {
+				Severity string `json:"severity"`
+				Path     string `json:"path"`
+				Line     int    `json:"line"`
+			} `json:"issues"`
+		} `json:"schemas"`
+	}
+	require.NoError(t, json.Unmarshal(out, &decoded))
+	require.Len(t, decoded.Schemas, 2)
+	assert.True(t, decoded.Schemas["User"].Valid)
+	assert.False(t, decoded.Schemas["Pet"].Valid)
+	require.NotEmpty(t, decoded.Schemas["Pet"].Issues)
+	assert.Equal(t, "error", decoded.Schemas["Pet"].Issues[0].Severity)
+	assert.Equal(t, "/components/schemas/Pet/example/name", decoded.Schemas["Pet"].Issues[0].Path)
+	assert.Equal(t, 13, decoded.Schemas["Pet"].Issues[0].Line)
+	assert.NotContains(t, string(out), `"examples"`)
+}
+
+func TestValidationResultToSARIF(t *testing.T) {
+	out, err := validateReportSpec(t).ToSARIF("api/openapi.yaml")
+	require.NoError(t, err)
+
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name  string `json:"name"`
+					Rules []struct {
+						ID string `json:"id"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				Level     string `json:"level"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+						Region struct {
+							StartLine int `json:"startLine"`
+						} `json:"region"`
+					} `json:"physicalLocation"`
+					LogicalLocations []struct {
+						FullyQualifiedName string `json:"fullyQualifiedName"`
+					} `json:"logicalLocations"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	require.NoError(t, json.Unmarshal(out, &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+	assert.Equal(t, "openapi-schema", log.Runs[0].Tool.Driver.Name)
+	assert.Len(t, log.Runs[0].Tool.Driver.Rules, 2)
+
+	require.NotEmpty(t, log.Runs[0].Results)
+	result := log.Runs[0].Results[0]
+	assert.Equal(t, "invalid-example", result.RuleID)
+	assert.Equal(t, "error", result.Level)
+	require.Len(t, result.Locations, 1)
+	assert.Equal(t, "api/openapi.yaml", result.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, 13, result.Locations[0].PhysicalLocation.Region.StartLine)
+	require.Len(t, result.Locations[0].LogicalLocations, 1)
+	assert.Equal(t, "/components/schemas/Pet/example/name", result.Locations[0].LogicalLocations[0].FullyQualifiedName)
+}
+
+func TestValidationResultToJUnitXML(t *testing.T) {
+	out, err := validateReportSpec(t).ToJUnitXML()
+	require.NoError(t, err)
+	assert.Contains(t, string(out), `<?xml version="1.0" encoding="UTF-8"?>`)
+
+	var report struct {
+		Tests    int `xml:"tests,attr"`
+		Failures int `xml:"failures,attr"`
+		Suites   []struct {
+			Name      string `xml:"name,attr"`
+			TestCases []struct {
+				Name    string `xml:"name,attr"`
+				Failure *struct {
+					Message string `xml:"message,attr"`
+					Text    string `xml:",chardata"`
+				} `xml:"failure"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	require.NoError(t, xml.Unmarshal(out, &report))
+	assert.Equal(t, 2, report.Tests)
+	assert.Equal(t, 1, report.Failures)
+	require.Len(t, report.Suites, 1)
+	assert.Equal(t, "schemas", report.Suites[0].Name)
+
+	require.Len(t, report.Suites[0].TestCases, 2)
+	pet := report.Suites[0].TestCases[0]
+	assert.Equal(t, "Pet", pet.Name)
+	require.NotNil(t, pet.Failure)
+	assert.Contains(t, pet.Failure.Text, "/components/schemas/Pet/example/name line 13: got number, want string")
+	assert.Nil(t, report.Suites[0].TestCases[1].Failure)
+}
@@ -0,0 +1,317 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/duh-rpc/openapi-schema.go/internal"
+	"github.com/duh-rpc/openapi-schema.go/internal/parser"
+	"github.com/duh-rpc/openapi-schema.go/internal/validate"
+	"github.com/pb33f/libopenapi-validator/schema_validation"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// DefaultMaxBodyBytes is the default of ValidatorOptions.MaxBodyBytes
+const DefaultMaxBodyBytes = 10 << 20
+
+// ValidatorOptions configures the Validator NewValidator returns
+type ValidatorOptions struct {
+	// BasePath is stripped from request paths before matching, e.g. "/v1"
+	BasePath string
+	// SkipResponses validates request bodies only, passing responses through
+	// unbuffered
+	SkipResponses bool
+	// Formats checks strings of its formats with their Validate functions, as in
+	// ValidateOptions
+	Formats *FormatRegistry
+	// MaxBodyBytes is the largest request body Middleware reads; larger ones
+	// get 413. Zero → DefaultMaxBodyBytes.
+	MaxBodyBytes int64
+}
+
+// Validator checks request and response bodies against the schemas the
+// operations of a spec declare for them
+type Validator struct {
+	router        *router
+	validator     schema_validation.SchemaValidator
+	isOpenAPI30   bool
+	skipResponses bool
+	maxBodyBytes  int64
+}
+
+// Violation is one way a request or response fails the spec
+type Violation struct {
+	In      string `json:"in"`              // "request" or "response"
+	Field   string `json:"field,omitempty"` // JSON pointer to the failing value, e.g. "/address/city"
+	Class   string `json:"class,omitempty"` // Kind of violation, as ValidationIssue.Class
+	Message string `json:"message"`
+}
+
+// ViolationResponse is the JSON body the Middleware sends when validation fails
+type ViolationResponse struct {
+	Error      string      `json:"error"`
+	Violations []Violation `json:"violations"`
+}
+
+// NewValidator returns a Validator for the operations of the spec, validating
+// bodies with the same schema validator ValidateExamples uses.
+func NewValidator(openapi []byte, opts ValidatorOptions) (_ *Validator, err error) {
+	defer internal.Recover("NewValidator", &err)
+
+	if len(openapi) == 0 {
+		return nil, fmt.Errorf("openapi input cannot be empty")
+	}
+	if opts.MaxBodyBytes < 0 {
+		return nil, fmt.Errorf("MaxBodyBytes cannot be negative")
+	}
+	maxBodyBytes := opts.MaxBodyBytes
+	if maxBodyBytes == 0 {
+		maxBodyBytes = DefaultMaxBodyBytes
+	}
+
+	doc, err := parser.ParseDocument(openapi)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Validator{
+		router:        newRouter(doc.Operations(), opts.BasePath),
+		validator:     validate.NewSchemaValidator(opts.Formats.validators()),
+		isOpenAPI30:   strings.HasPrefix(doc.Version(), "3.0"),
+		skipResponses: opts.SkipResponses,
+		maxBodyBytes:  maxBodyBytes,
+	}, nil
+}
+
+// ValidateRequest validates body, the body of r, against the request body of
+// the operation r is for. Requests matching no operation are not validated.
+// Only JSON media types are validated against their schema; others need only be
+// declared.
+func (v *Validator) ValidateRequest(r *http.Request, body []byte) (violations []Violation) {
+	defer recoverViolations("request", "ValidateRequest", &violations)
+
+	op, _ := v.router.match(r.Method, r.URL.Path)
+	return v.validateRequest(op, r.Header.Get("Content-Type"), body, len(body) > 0)
+}
+
+// ValidateResponse validates a response with status code, header and body
+// against the response the operation r is for declares for code: the exact
+// code, else its range such as 4XX, else default.
+func (v *Validator) ValidateResponse(r *http.Request, code int, header http.Header, body []byte) (violations []Violation) {
+	defer recoverViolations("response", "ValidateResponse", &violations)
+
+	op, _ := v.router.match(r.Method, r.URL.Path)
+	if op == nil || op.Responses == nil {
+		return nil
+	}
+
+	resp := declaredResponse(op, code)
+	if resp == nil {
+		return []Violation{{In: "response", Message: fmt.Sprintf("status %d is not declared", code)}}
+	}
+	if len(body) == 0 || resp.Content == nil || resp.Content.Len() == 0 {
+		return nil
+	}
+	return v.validateBody("response", resp.Content, header.Get("Content-Type"), body)
+}
+
+// Middleware returns middleware validating each request body before calling the
+// next handler, and each response the handler writes before sending it.
+// Invalid requests get 400, request bodies over MaxBodyBytes 413 and invalid
+// responses 500, with a JSON ViolationResponse body. Only request bodies
+// validated against a JSON schema are read, up to MaxBodyBytes; others, such as
+// uploads and streams, reach the handler unread, and requests matching no
+// operation pass through untouched. Responses are buffered to be validated, so
+// handlers cannot flush or hijack the connection unless SkipResponses is set.
+func (v *Validator) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			op, _ := v.router.match(r.Method, r.URL.Path)
+			if op == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			var body []byte
+			sent := r.Body != nil && r.Body != http.NoBody && r.ContentLength != 0
+			if sent && validatesJSON(op, r.Header.Get("Content-Type")) {
+				var err error
+				body, err = io.ReadAll(http.MaxBytesReader(w, r.Body, v.maxBodyBytes))
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					writeViolations(w, http.StatusRequestEntityTooLarge, "request body is too large",
+						[]Violation{{In: "request", Message: fmt.Sprintf("body exceeds %d bytes", tooLarge.Limit)}})
+					return
+				}
+				if err != nil {
+					writeViolations(w, http.StatusBadRequest, "request does not match the spec",
+						[]Violation{{In: "request", Message: fmt.Sprintf("failed to read body: %v", err)}})
+					return
+				}
+				r.Body = io.NopCloser(bytes.NewReader(body))
+				sent = len(body) > 0
+			}
+
+			if violations := v.middlewareRequest(op, r, body, sent); len(violations) > 0 {
+				writeViolations(w, http.StatusBadRequest, "request does not match the spec", violations)
+				return
+			}
+
+			if v.skipResponses {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			rec := &responseBuffer{header: http.Header{}}
+			next.ServeHTTP(rec, r)
+			if rec.code == 0 {
+				rec.code = http.StatusOK
+			}
+
+			if violations := v.ValidateResponse(r, rec.code, rec.header, rec.body.Bytes()); len(violations) > 0 {
+				writeViolations(w, http.StatusInternalServerError, "response does not match the spec", violations)
+				return
+			}
+
+			for key, values := range rec.header {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(rec.code)
+			_, _ = w.Write(rec.body.Bytes())
+		})
+	}
+}
+
+// middlewareRequest validates the request Middleware passes on, recovering
+// from panics as ValidateRequest does
+func (v *Validator) middlewareRequest(op *parser.OperationEntry, r *http.Request, body []byte, sent bool) (violations []Violation) {
+	defer recoverViolations("request", "Middleware", &violations)
+
+	return v.validateRequest(op, r.Header.Get("Content-Type"), body, sent)
+}
+
+// validateRequest validates a request for op sent with contentType. sent tells
+// whether it has a body, which is only read when validated against a JSON
+// schema, so body may be nil when sent is true.
+func (v *Validator) validateRequest(op *parser.OperationEntry, contentType string, body []byte, sent bool) []Violation {
+	if op == nil || op.RequestBody == nil {
+		return nil
+	}
+
+	if !sent {
+		if op.RequestBody.Required != nil && *op.RequestBody.Required {
+			return []Violation{{In: "request", Message: "request body is required"}}
+		}
+		return nil
+	}
+	return v.validateBody("request", op.RequestBody.Content, contentType, body)
+}
+
+// validatesJSON reports whether a request body for op sent with contentType is
+// validated against a JSON schema, and so has to be read
+func validatesJSON(op *parser.OperationEntry, contentType string) bool {
+	if op.RequestBody == nil {
+		return false
+	}
+	mediaType, media := declaredMedia(op.RequestBody.Content, contentType)
+	return media != nil && media.Schema != nil && internal.IsJSONMediaType(mediaType)
+}
+
+// validateBody validates body, sent with contentType, against the media type
+// of content it is declared as
+func (v *Validator) validateBody(in string, content *orderedmap.Map[string, *v3.MediaType], contentType string, body []byte) []Violation {
+	mediaType, media := declaredMedia(content, contentType)
+	if media == nil {
+		return []Violation{{In: in, Message: fmt.Sprintf("content type %q is not declared", contentType)}}
+	}
+	if !internal.IsJSONMediaType(mediaType) || media.Schema == nil {
+		return nil
+	}
+
+	schema := media.Schema.Schema()
+	if schema == nil {
+		return nil
+	}
+
+	var violations []Violation
+	for _, violation := range validate.ValidateJSON(v.validator, schema, body, v.isOpenAPI30) {
+		violations = append(violations, Violation{In: in, Field: violation.Field, Class: violation.Class, Message: violation.Message})
+	}
+	return violations
+}
+
+// declaredMedia returns the media type of content a body sent with contentType
+// is declared as: an exact match, else a type/* or */* wildcard. A body without
+// a content type is taken to be the first JSON media type.
+func declaredMedia(content *orderedmap.Map[string, *v3.MediaType], contentType string) (string, *v3.MediaType) {
+	if content == nil {
+		return "", nil
+	}
+
+	if contentType == "" {
+		for mediaType, media := range content.FromOldest() {
+			if internal.IsJSONMediaType(mediaType) {
+				return mediaType, media
+			}
+		}
+		return "", nil
+	}
+
+	sent, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", nil
+	}
+
+	kind, _, _ := strings.Cut(sent, "/")
+	for _, candidate := range []string{sent, kind + "/*", "*/*"} {
+		for mediaType, media := range content.FromOldest() {
+			declared, _, err := mime.ParseMediaType(mediaType)
+			if err == nil && declared == candidate {
+				if candidate != sent {
+					return sent, media
+				}
+				return mediaType, media
+			}
+		}
+	}
+	return "", nil
+}
+
+// writeViolations writes a JSON ViolationResponse
+func writeViolations(w http.ResponseWriter, code int, message string, violations []Violation) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(ViolationResponse{Error: message, Violations: violations})
+}
+
+// responseBuffer records the response a handler writes so it can be validated
+// before it is sent
+type responseBuffer struct {
+	header http.Header
+	code   int
+	body   bytes.Buffer
+}
+
+func (b *responseBuffer) Header() http.Header {
+	return b.header
+}
+
+func (b *responseBuffer) WriteHeader(code int) {
+	if b.code == 0 {
+		b.code = code
+	}
+}
+
+func (b *responseBuffer) Write(p []byte) (int, error) {
+	if b.code == 0 {
+		b.code = http.StatusOK
+	}
+	return b.body.Write(p)
+}
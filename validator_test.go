@@ -0,0 +1,329 @@
+package schema_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validatorSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /pets:
+    post:
+      operationId: createPet
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+          text/plain:
+            schema:
+              type: string
+      responses:
+        '201':
+          description: Created
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+        4XX:
+          description: Client error
+          content:
+            application/problem+json:
+              schema:
+                type: object
+                required: [title]
+                properties:
+                  title:
+                    type: string
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        age:
+          type: integer
+          minimum: 0
+`
+
+func TestValidatorMiddleware(t *testing.T) {
+	validator, err := schema.NewValidator([]byte(validatorSpec), schema.ValidatorOptions{BasePath: "/v1"})
+	require.NoError(t, err)
+
+	for _, test := range []struct {
+		name         string
+		path         string
+		contentType  string
+		body         string
+		respStatus   int
+		respType     string
+		respBody     string
+		expectStatus int
+		expectBody   string
+	}{
+		{
+			name:         "valid request and response",
+			path:         "/v1/pets",
+			contentType:  "application/json",
+			body:         `{"name": "Rex", "age": 3}`,
+			respStatus:   http.StatusCreated,
+			respType:     "application/json",
+			respBody:     `{"name": "Rex"}`,
+			expectStatus: http.StatusCreated,
+			expectBody:   `{"name": "Rex"}`,
+		},
+		{
+			name:         "invalid request body",
+			path:         "/v1/pets",
+			contentType:  "application/json; charset=utf-8",
+			body:         `{"name": "Rex", "age": -1}`,
+			expectStatus: http.StatusBadRequest,
+			expectBody: `{"error": "request does not match the spec", "violations": [
+				{"in": "request", "field": "/age", "class": "minimum", "message": "minimum: got -1, want 0"}]}`,
+		},
+		{
+			name:         "missing required body",
+			path:         "/v1/pets",
+			contentType:  "application/json",
+			expectStatus: http.StatusBadRequest,
+			expectBody: `{"error": "request does not match the spec", "violations": [
+				{"in": "request", "message": "request body is required"}]}`,
+		},
+		{
+			name:         "malformed JSON",
+			path:         "/v1/pets",
+			contentType:  "application/json",
+			body:         `{"name": `,
+			expectStatus: http.StatusBadRequest,
+			expectBody: `{"error": "request does not match the spec", "violations": [
+				{"in": "request", "class": "invalid-json", "message": "invalid JSON"}]}`,
+		},
+		{
+			name:         "undeclared content type",
+			path:         "/v1/pets",
+			contentType:  "application/xml",
+			body:         `<pet/>`,
+			expectStatus: http.StatusBadRequest,
+			expectBody: `{"error": "request does not match the spec", "violations": [
+				{"in": "request", "message": "content type \"application/xml\" is not declared"}]}`,
+		},
+		{
+			name:         "non-JSON media type is not validated",
+			path:         "/v1/pets",
+			contentType:  "text/plain",
+			body:         `Rex`,
+			respStatus:   http.StatusCreated,
+			respType:     "application/json",
+			respBody:     `{"name": "Rex"}`,
+			expectStatus: http.StatusCreated,
+			expectBody:   `{"name": "Rex"}`,
+		},
+		{
+			name:         "invalid response body",
+			path:         "/v1/pets",
+			contentType:  "application/json",
+			body:         `{"name": "Rex"}`,
+			respStatus:   http.StatusCreated,
+			respType:     "application/json",
+			respBody:     `{"age": 3}`,
+			expectStatus: http.StatusInternalServerError,
+			expectBody: `{"error": "response does not match the spec", "violations": [
+				{"in": "response", "class": "required", "message": "missing property 'name'"}]}`,
+		},
+		{
+			name:         "response status range",
+			path:         "/v1/pets",
+			contentType:  "application/json",
+			body:         `{"name": "Rex"}`,
+			respStatus:   http.StatusConflict,
+			respType:     "application/problem+json",
+			respBody:     `{"title": "Conflict"}`,
+			expectStatus: http.StatusConflict,
+			expectBody:   `{"title": "Conflict"}`,
+		},
+		{
+			name:         "undeclared response status",
+			path:         "/v1/pets",
+			contentType:  "application/json",
+			body:         `{"name": "Rex"}`,
+			respStatus:   http.StatusOK,
+			respType:     "application/json",
+			respBody:     `{"name": "Rex"}`,
+			expectStatus: http.StatusInternalServerError,
+			expectBody: `{"error": "response does not match the spec", "violations": [
+				{"in": "response", "message": "status 200 is not declared"}]}`,
+		},
+		{
+			name:         "unknown path is not validated",
+			path:         "/v1/owners",
+			contentType:  "application/json",
+			body:         `{}`,
+			respStatus:   http.StatusOK,
+			respType:     "application/json",
+			respBody:     `[]`,
+			expectStatus: http.StatusOK,
+			expectBody:   `[]`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", test.respType)
+				w.WriteHeader(test.respStatus)
+				_, _ = w.Write([]byte(test.respBody))
+			})
+
+			req := httptest.NewRequest(http.MethodPost, test.path, strings.NewReader(test.body))
+			req.Header.Set("Content-Type", test.contentType)
+			rec := httptest.NewRecorder()
+			validator.Middleware()(next).ServeHTTP(rec, req)
+
+			assert.Equal(t, test.expectStatus, rec.Code)
+			assert.JSONEq(t, test.expectBody, rec.Body.String())
+		})
+	}
+}
+
+func TestValidatorSkipResponses(t *testing.T) {
+	validator, err := schema.NewValidator([]byte(validatorSpec), schema.ValidatorOptions{SkipResponses: true})
+	require.NoError(t, err)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	req := httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader(`{"name": "Rex"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	validator.Middleware()(next).ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusTeapot, rec.Code)
+
+	_, err = schema.NewValidator(nil, schema.ValidatorOptions{})
+	require.ErrorContains(t, err, "openapi input cannot be empty")
+}
+
+func TestValidatorMaxBodyBytes(t *testing.T) {
+	validator, err := schema.NewValidator([]byte(validatorSpec), schema.ValidatorOptions{MaxBodyBytes: 16})
+	require.NoError(t, err)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"name": "Rex"}`))
+	})
+
+	for _, test := range []struct {
+		name         string
+		body         string
+		expectStatus int
+		expectBody   string
+	}{
+		{
+			name:         "within the limit",
+			body:         `{"name": "Rex"}`,
+			expectStatus: http.StatusCreated,
+			expectBody:   `{"name": "Rex"}`,
+		},
+		{
+			name:         "over the limit",
+			body:         `{"name": "Rexford"}`,
+			expectStatus: http.StatusRequestEntityTooLarge,
+			expectBody: `{"error": "request body is too large", "violations": [
+				{"in": "request", "message": "body exceeds 16 bytes"}]}`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader(test.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			validator.Middleware()(next).ServeHTTP(rec, req)
+
+			assert.Equal(t, test.expectStatus, rec.Code)
+			assert.JSONEq(t, test.expectBody, rec.Body.String())
+		})
+	}
+
+	_, err = schema.NewValidator([]byte(validatorSpec), schema.ValidatorOptions{MaxBodyBytes: -1})
+	require.ErrorContains(t, err, "MaxBodyBytes cannot be negative")
+}
+
+func TestValidatorMiddlewarePassThrough(t *testing.T) {
+	validator, err := schema.NewValidator([]byte(validatorSpec), schema.ValidatorOptions{MaxBodyBytes: 16})
+	require.NoError(t, err)
+
+	large := strings.Repeat("x", 64)
+	for _, test := range []struct {
+		name         string
+		path         string
+		contentType  string
+		body         string
+		expectStatus int
+		expectBody   string
+	}{
+		{
+			name:         "unknown path",
+			path:         "/upload",
+			contentType:  "application/json",
+			body:         large,
+			expectStatus: http.StatusOK,
+			expectBody:   large,
+		},
+		{
+			name:         "body not validated against a JSON schema",
+			path:         "/pets",
+			contentType:  "text/plain",
+			body:         large,
+			expectStatus: http.StatusCreated,
+			expectBody:   `{"name": "Rex"}`,
+		},
+		{
+			name:         "undeclared content type",
+			path:         "/pets",
+			contentType:  "application/octet-stream",
+			body:         large,
+			expectStatus: http.StatusBadRequest,
+			expectBody:   `{"error":"request does not match the spec","violations":[{"in":"request","message":"content type \"application/octet-stream\" is not declared"}]}`,
+		},
+		{
+			name:         "required body missing",
+			path:         "/pets",
+			contentType:  "text/plain",
+			expectStatus: http.StatusBadRequest,
+			expectBody:   `{"error":"request does not match the spec","violations":[{"in":"request","message":"request body is required"}]}`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				if r.URL.Path == "/pets" {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusCreated)
+					_, _ = w.Write([]byte(`{"name": "Rex"}`))
+					assert.Equal(t, test.body, string(body))
+					return
+				}
+				_, _ = w.Write(body)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, test.path, strings.NewReader(test.body))
+			req.Header.Set("Content-Type", test.contentType)
+			rec := httptest.NewRecorder()
+			validator.Middleware()(next).ServeHTTP(rec, req)
+
+			assert.Equal(t, test.expectStatus, rec.Code)
+			assert.Equal(t, test.expectBody, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package schema
+
+import (
+	"github.com/duh-rpc/openapi-schema.go/internal"
+)
+
+// Warning reports information lost in a conversion that did not fail it, such as
+// a pattern proto3 cannot express. Pointer locates the node in the OpenAPI
+// document; Code is one of the WarningCode constants.
+type Warning = internal.Warning
+
+// Warning codes reported in Warning.Code.
+const (
+	// WarningConstraintDropped: validation keywords (pattern, minLength, maximum,
+	// ...) have no proto3 equivalent and were dropped.
+	WarningConstraintDropped = internal.WarnConstraintDropped
+	// WarningEnumFlattened: a string enum became a plain string field; the allowed
+	// values survive only as a comment.
+	WarningEnumFlattened = internal.WarnEnumFlattened
+	// WarningFormatUnknown: a format the converter does not recognize was mapped to
+	// the base type of the schema.
+	WarningFormatUnknown = internal.WarnFormatUnknown
+)
+
+// protoWarnings keeps the warnings for schemas that appear in the proto output,
+// dropping those for schemas generated only as Go.
+func protoWarnings(warnings []internal.Warning, goTypes, shimmed map[string]bool) []Warning {
+	var result []Warning
+	for _, w := range warnings {
+		if goTypes[w.Schema] && !shimmed[w.Schema] {
+			continue
+		}
+		result = append(result, w)
+	}
+	return result
+}
+
+// protoRenames keeps the renames for schemas that appear in the proto output,
+// as protoWarnings does.
+func protoRenames(renames []internal.Rename, goTypes, shimmed map[string]bool) []Rename {
+	var result []Rename
+	for _, r := range renames {
+		if goTypes[r.Schema] && !shimmed[r.Schema] {
+			continue
+		}
+		result = append(result, r)
+	}
+	return result
+}
@@ -0,0 +1,186 @@
+package schema
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"time"
+)
+
+// Defaults of WatchOptions
+const (
+	DefaultWatchInterval = 500 * time.Millisecond
+	DefaultWatchDebounce = 200 * time.Millisecond
+)
+
+// WatchOptions configures Watch.
+type WatchOptions struct {
+	// Convert configures each conversion. Previous must be nil: Watch passes
+	// each result as Previous of the next conversion.
+	Convert ConvertOptions
+	// Interval is how often the files are read for changes. Zero →
+	// DefaultWatchInterval.
+	Interval time.Duration
+	// Debounce is how long the files must stay unchanged before converting, so
+	// an editor saving several files, or writing one in steps, converts once.
+	// Zero → DefaultWatchDebounce.
+	Debounce time.Duration
+	// OnError receives the errors reading or converting the files; Watch keeps
+	// watching, and the next change converts again. Nil → errors are dropped.
+	OnError func(err error)
+}
+
+// Watch converts the specs at paths in fsys, then converts them again each time
+// their content changes, passing each result to onChange, so editor and daemon
+// integrations need not each implement file watching. A single path is
+// converted with Convert and several are merged with ConvertMany, named by
+// their path.
+//
+// Files are polled every Interval, as fs.FS has no change notification, and
+// converted once they have been unchanged for Debounce. Each conversion passes
+// the last result as ConvertOptions.Previous, so onChange is not called when a
+// change leaves every schema hash as it was, such as an edit to comments;
+// Changes reports what changed since the last call. onChange is called from
+// the goroutine running Watch, which polls again once it returns.
+//
+// Watch blocks until ctx is done, then returns ctx.Err().
+func Watch(ctx context.Context, fsys fs.FS, paths []string, opts WatchOptions, onChange func(*ConvertResult)) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("paths cannot be empty")
+	}
+	if onChange == nil {
+		return fmt.Errorf("onChange cannot be nil")
+	}
+	if opts.Convert.Previous != nil {
+		return fmt.Errorf("Previous is not supported by Watch: each result is passed to the next conversion")
+	}
+	if opts.Interval < 0 {
+		return fmt.Errorf("Interval cannot be negative")
+	}
+	if opts.Debounce < 0 {
+		return fmt.Errorf("Debounce cannot be negative")
+	}
+
+	interval := opts.Interval
+	if interval == 0 {
+		interval = DefaultWatchInterval
+	}
+	debounce := opts.Debounce
+	if debounce == 0 {
+		debounce = DefaultWatchDebounce
+	}
+
+	w := &watcher{fsys: fsys, paths: paths, opts: opts, onChange: onChange}
+	w.snapshot = w.read()
+	w.convert(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var changedAt time.Time
+	pending := false
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case now := <-ticker.C:
+			if snapshot := w.read(); !slices.EqualFunc(snapshot, w.snapshot, watchedFile.same) {
+				w.snapshot, changedAt, pending = snapshot, now, true
+			}
+			if pending && now.Sub(changedAt) >= debounce {
+				pending = false
+				w.convert(ctx)
+			}
+		}
+	}
+}
+
+// watcher holds the state of one Watch
+type watcher struct {
+	fsys     fs.FS
+	paths    []string
+	opts     WatchOptions
+	onChange func(*ConvertResult)
+	// snapshot is the content of paths last read
+	snapshot []watchedFile
+	// previous is the last result passed to onChange
+	previous *ConvertResult
+}
+
+// watchedFile is the content of a watched path, or the error reading it
+type watchedFile struct {
+	content []byte
+	sum     [sha256.Size]byte
+	err     error
+}
+
+// same reports whether f and other were read with the same outcome, so a file
+// that stays unreadable is not reported again
+func (f watchedFile) same(other watchedFile) bool {
+	if f.err != nil || other.err != nil {
+		return f.err != nil && other.err != nil && f.err.Error() == other.err.Error()
+	}
+	return f.sum == other.sum
+}
+
+// read returns the current content of the watched paths
+func (w *watcher) read() []watchedFile {
+	files := make([]watchedFile, len(w.paths))
+	for i, path := range w.paths {
+		content, err := fs.ReadFile(w.fsys, path)
+		files[i] = watchedFile{content: content, sum: sha256.Sum256(content), err: err}
+	}
+	return files
+}
+
+// convert converts the snapshot, passing a result that changed anything to
+// onChange and errors to OnError
+func (w *watcher) convert(ctx context.Context) {
+	var errs []error
+	for _, file := range w.snapshot {
+		if file.err != nil {
+			errs = append(errs, file.err)
+		}
+	}
+	if len(errs) > 0 {
+		w.report(ctx, errors.Join(errs...))
+		return
+	}
+
+	opts := w.opts.Convert
+	opts.Previous = w.previous
+	opts.BuildManifest = true
+
+	var result *ConvertResult
+	var err error
+	if len(w.paths) == 1 {
+		result, err = ConvertContext(ctx, w.snapshot[0].content, opts)
+	} else {
+		specs := make([]NamedSpec, len(w.paths))
+		for i, path := range w.paths {
+			specs[i] = NamedSpec{Name: path, OpenAPI: w.snapshot[i].content}
+		}
+		result, err = ConvertManyContext(ctx, specs, opts)
+	}
+	if err != nil {
+		w.report(ctx, err)
+		return
+	}
+
+	if result.Changes != nil && result.Changes.Reused {
+		return
+	}
+	w.previous = result
+	w.onChange(result)
+}
+
+// report passes err to OnError, unless it stems from ctx being done
+func (w *watcher) report(ctx context.Context, err error) {
+	if ctx.Err() != nil || w.opts.OnError == nil {
+		return
+	}
+	w.opts.OnError(err)
+}
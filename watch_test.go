@@ -0,0 +1,212 @@
+package schema_test
+
+import (
+	"context"
+	"io/fs"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	schema "github.com/duh-rpc/openapi-schema.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// syncFS is an fstest.MapFS that files may be written to while Watch reads it
+type syncFS struct {
+	mu    sync.Mutex
+	files fstest.MapFS
+}
+
+func (f *syncFS) Open(name string) (fs.File, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.files.Open(name)
+}
+
+func (f *syncFS) write(name, content string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.files[name] = &fstest.MapFile{Data: []byte(content)}
+}
+
+func (f *syncFS) remove(name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.files, name)
+}
+
+// startWatch runs Watch until the test ends, returning its results and errors
+func startWatch(t *testing.T, fsys fs.FS, paths []string) (<-chan *schema.ConvertResult, <-chan error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	results := make(chan *schema.ConvertResult, 10)
+	errs := make(chan error, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- schema.Watch(ctx, fsys, paths, schema.WatchOptions{
+			Convert:  cacheOptions(),
+			Interval: 5 * time.Millisecond,
+			Debounce: 20 * time.Millisecond,
+			OnError:  func(err error) { errs <- err },
+		}, func(result *schema.ConvertResult) { results <- result })
+	}()
+	t.Cleanup(func() {
+		cancel()
+		assert.ErrorIs(t, <-done, context.Canceled)
+	})
+	return results, errs
+}
+
+func receive[T any](t *testing.T, ch <-chan T) T {
+	t.Helper()
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for Watch")
+		panic("unreachable")
+	}
+}
+
+func TestWatch(t *testing.T) {
+	fsys := &syncFS{files: fstest.MapFS{"api.yaml": {Data: []byte(cacheSpec)}}}
+	results, errs := startWatch(t, fsys, []string{"api.yaml"})
+
+	first := receive(t, results)
+	assert.Nil(t, first.Changes)
+	assert.Contains(t, string(first.Protobuf), "message Product {")
+
+	// Comments leave every schema hash unchanged, so nothing is reported
+	fsys.write("api.yaml", "# edited\n"+cacheSpec)
+	time.Sleep(100 * time.Millisecond)
+	assert.Empty(t, results)
+
+	fsys.write("api.yaml", strings.Replace(cacheSpec, "        sku:\n", "        title:\n          type: string\n        sku:\n", 1))
+	changed := receive(t, results)
+	require.NotNil(t, changed.Changes)
+	assert.Equal(t, []string{"Product"}, changed.Changes.Schemas)
+	assert.Contains(t, string(changed.Protobuf), `string title = 1 [json_name = "title"];`)
+
+	fsys.write("api.yaml", "openapi: [")
+	assert.Error(t, receive(t, errs))
+
+	fsys.remove("api.yaml")
+	require.ErrorIs(t, receive(t, errs), fs.ErrNotExist)
+
+	fsys.write("api.yaml", cacheSpec)
+	restored := receive(t, results)
+	assert.Equal(t, first.Manifest, restored.Manifest)
+	assert.Equal(t, []string{"Product"}, restored.Changes.Schemas)
+
+	select {
+	case result := <-results:
+		assert.Fail(t, "unexpected result", "%v", result.Changes)
+	case err := <-errs:
+		assert.Fail(t, "unexpected error", "%v", err)
+	default:
+	}
+}
+
+func TestWatchMany(t *testing.T) {
+	fsys := &syncFS{files: fstest.MapFS{
+		"orders.yaml": {Data: []byte(`openapi: 3.0.0
+info:
+  title: Orders
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        customer:
+          $ref: 'customers.yaml#/components/schemas/Customer'
+`)},
+		"customers.yaml": {Data: []byte(`openapi: 3.0.0
+info:
+  title: Customers
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Customer:
+      type: object
+      properties:
+        name:
+          type: string
+`)},
+	}}
+	results, _ := startWatch(t, fsys, []string{"orders.yaml", "customers.yaml"})
+
+	first := receive(t, results)
+	assert.Contains(t, string(first.Protobuf), "message Order {")
+	assert.Contains(t, string(first.Protobuf), "message Customer {")
+
+	fsys.write("customers.yaml", `openapi: 3.0.0
+info:
+  title: Customers
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Customer:
+      type: object
+      properties:
+        email:
+          type: string
+`)
+	changed := receive(t, results)
+	assert.Equal(t, []string{"Order", "Customer"}, changed.Changes.Schemas)
+}
+
+func TestWatchErrors(t *testing.T) {
+	fsys := fstest.MapFS{"api.yaml": {Data: []byte(cacheSpec)}}
+	onChange := func(*schema.ConvertResult) {}
+
+	for _, test := range []struct {
+		name     string
+		paths    []string
+		opts     schema.WatchOptions
+		onChange func(*schema.ConvertResult)
+		err      string
+	}{
+		{
+			name:     "no paths",
+			onChange: onChange,
+			err:      "paths cannot be empty",
+		},
+		{
+			name:  "no onChange",
+			paths: []string{"api.yaml"},
+			err:   "onChange cannot be nil",
+		},
+		{
+			name:     "previous",
+			paths:    []string{"api.yaml"},
+			opts:     schema.WatchOptions{Convert: schema.ConvertOptions{Previous: &schema.ConvertResult{}}},
+			onChange: onChange,
+			err:      "Previous is not supported by Watch",
+		},
+		{
+			name:     "negative interval",
+			paths:    []string{"api.yaml"},
+			opts:     schema.WatchOptions{Interval: -time.Second},
+			onChange: onChange,
+			err:      "Interval cannot be negative",
+		},
+		{
+			name:     "negative debounce",
+			paths:    []string{"api.yaml"},
+			opts:     schema.WatchOptions{Debounce: -time.Second},
+			onChange: onChange,
+			err:      "Debounce cannot be negative",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			err := schema.Watch(context.Background(), fsys, test.paths, test.opts, test.onChange)
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}